@@ -128,4 +128,5 @@ const (
 	EventLabelAdded        = types.EventLabelAdded
 	EventLabelRemoved      = types.EventLabelRemoved
 	EventCompacted         = types.EventCompacted
+	EventWorkflowOverride  = types.EventWorkflowOverride
 )
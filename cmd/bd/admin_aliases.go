@@ -72,6 +72,9 @@ func init() {
 	compactAliasCmd.Flags().StringVar(&compactActor, "actor", "agent", "Actor name for audit trail")
 	compactAliasCmd.Flags().IntVar(&compactLimit, "limit", 0, "Limit number of candidates (0 = no limit)")
 	compactAliasCmd.Flags().BoolVar(&compactDolt, "dolt", false, "Dolt mode: run Dolt garbage collection on .beads/dolt")
+	compactAliasCmd.Flags().StringVar(&compactSummarizer, "summarizer", "", "Summarizer for --auto: anthropic, openai or extractive")
+	compactAliasCmd.Flags().StringVar(&compactModel, "model", "", "Model for the summarizer")
+	compactAliasCmd.Flags().StringVar(&compactEndpoint, "endpoint", "", "Base URL of an OpenAI-compatible API")
 
 	// Reset alias flags - these read from cmd.Flags() in the Run function
 	resetAliasCmd.Flags().Bool("force", false, "Actually perform the reset (required)")
@@ -0,0 +1,430 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/attachments"
+	"github.com/steveyegge/beads/internal/beads"
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+var attachCmd = &cobra.Command{
+	Use:     "attach <issue-id> <file>",
+	GroupID: "issues",
+	Short:   "Attach a file to an issue",
+	Long: `Attach a file (log, screenshot, patch, ...) to an issue.
+
+The content is stored once under .beads/attachments, named by its SHA-256
+hash, and is committed to git with the rest of .beads. The issue records the
+file name, size, media type and hash; these are exported to JSONL and merged
+across clones like comments.
+
+Use "-" as the file to read from stdin (requires --name). Attaching a file
+with the same name as an existing attachment replaces it.
+
+Files larger than attachments.max-size (default 10MB) are rejected. Set
+attachments.lfs to true to store attachments with git LFS.
+
+Examples:
+  bd attach bd-123 crash.log
+  bd attach bd-123 screenshot.png --name login-bug.png
+  go test ./... 2>&1 | bd attach bd-123 - --name test-output.txt`,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		CheckReadonly("attach")
+		name, _ := cmd.Flags().GetString("name")
+		mediaType, _ := cmd.Flags().GetString("type")
+		ctx := rootCtx
+		issueID := resolveAttachmentIssueID(ctx, args[0])
+
+		src := args[1]
+		var r io.Reader
+		if src == "-" {
+			if name == "" {
+				FatalErrorRespectJSON("--name is required when attaching from stdin")
+			}
+			r = os.Stdin
+		} else {
+			info, err := os.Stat(src)
+			if err != nil {
+				FatalErrorRespectJSON("%v", err)
+			}
+			if info.IsDir() {
+				FatalErrorRespectJSON("%s is a directory", src)
+			}
+			// #nosec G304 - user-specified file to attach
+			f, err := os.Open(src)
+			if err != nil {
+				FatalErrorRespectJSON("%v", err)
+			}
+			defer func() { _ = f.Close() }()
+			r = f
+			if name == "" {
+				name = filepath.Base(src)
+			}
+		}
+
+		blobs := attachmentBlobStore()
+		if config.GetBool("attachments.lfs") {
+			if err := blobs.EnableLFS(); err != nil {
+				FatalErrorRespectJSON("enabling git LFS for attachments: %v", err)
+			}
+		}
+		maxSize := int64(config.GetSizeInBytes("attachments.max-size")) // #nosec G115 - configured size fits in int64
+		hash, size, err := blobs.Put(r, maxSize)
+		if errors.Is(err, attachments.ErrTooLarge) {
+			FatalErrorRespectJSON("%s is larger than attachments.max-size (%s)", src, formatBytes(maxSize))
+		}
+		if err != nil {
+			FatalErrorRespectJSON("storing attachment: %v", err)
+		}
+		if mediaType == "" {
+			mediaType = detectMediaType(name, blobs, hash)
+		}
+
+		existing, err := store.GetAttachments(ctx, issueID)
+		if err != nil {
+			FatalErrorRespectJSON("getting attachments: %v", err)
+		}
+		now := time.Now().UTC()
+		var replaced *types.Attachment
+		for _, a := range types.LiveAttachments(existing) {
+			if a.Name != name {
+				continue
+			}
+			if a.SHA256 == hash {
+				if jsonOutput {
+					outputJSON(a)
+					return
+				}
+				fmt.Printf("%s %s is already attached to %s\n", ui.RenderPass("✓"), name, ui.RenderID(issueID))
+				return
+			}
+			replaced = a
+		}
+
+		id, err := newRandomID("att-")
+		if err != nil {
+			FatalErrorRespectJSON("saving attachment: %v", err)
+		}
+		attachment := &types.Attachment{
+			ID:        id,
+			IssueID:   issueID,
+			Name:      name,
+			SHA256:    hash,
+			Size:      size,
+			MediaType: mediaType,
+			CreatedBy: getActorWithGit(),
+			CreatedAt: now,
+		}
+		if err := store.SaveAttachment(ctx, attachment); err != nil {
+			FatalErrorRespectJSON("saving attachment: %v", err)
+		}
+		if replaced != nil {
+			replaced.DetachedAt = &now
+			if err := store.SaveAttachment(ctx, replaced); err != nil {
+				FatalErrorRespectJSON("detaching previous %s: %v", name, err)
+			}
+		}
+		markDirtyAndScheduleFlush()
+
+		if jsonOutput {
+			outputJSON(attachment)
+			return
+		}
+		verb := "Attached"
+		if replaced != nil {
+			verb = "Replaced"
+		}
+		fmt.Printf("%s %s %s (%s) to %s\n", ui.RenderPass("✓"), verb, name, formatBytes(size), ui.RenderID(issueID))
+	},
+}
+
+var attachmentsCmd = &cobra.Command{
+	Use:     "attachments <issue-id> [name-or-id]",
+	GroupID: "issues",
+	Short:   "List or extract the attachments of an issue",
+	Long: `List the attachments of an issue, or write the content of one attachment
+to stdout (or to a file with --output).
+
+An attachment is named by its file name or its ID (a unique ID prefix is
+enough).
+
+Examples:
+  bd attachments bd-123                       # List attachments
+  bd attachments bd-123 crash.log             # Print content to stdout
+  bd attachments bd-123 shot.png -o /tmp/shot.png`,
+	Args: cobra.RangeArgs(1, 2),
+	Run: func(cmd *cobra.Command, args []string) {
+		all, _ := cmd.Flags().GetBool("all")
+		output, _ := cmd.Flags().GetString("output")
+		ctx := rootCtx
+		issueID := resolveAttachmentIssueID(ctx, args[0])
+
+		list, err := store.GetAttachments(ctx, issueID)
+		if err != nil {
+			FatalErrorRespectJSON("getting attachments: %v", err)
+		}
+		if !all {
+			list = types.LiveAttachments(list)
+		}
+
+		if len(args) == 1 {
+			if list == nil {
+				list = []*types.Attachment{}
+			}
+			if jsonOutput {
+				outputJSON(list)
+				return
+			}
+			if len(list) == 0 {
+				fmt.Printf("No attachments on %s\n", issueID)
+				return
+			}
+			fmt.Printf("\nAttachments on %s:\n\n", ui.RenderID(issueID))
+			for _, a := range list {
+				fmt.Println(formatAttachmentLine(a))
+			}
+			fmt.Println()
+			return
+		}
+
+		a, err := findAttachment(list, args[1])
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		blob, err := attachmentBlobStore().Open(a.SHA256)
+		if errors.Is(err, attachments.ErrNotFound) {
+			FatalErrorRespectJSON("content of %s is not in this clone (try git pull, or git lfs pull if attachments.lfs is enabled)", a.Name)
+		}
+		if err != nil {
+			FatalErrorRespectJSON("opening attachment: %v", err)
+		}
+		defer func() { _ = blob.Close() }()
+
+		var w io.Writer = os.Stdout
+		if output != "" {
+			// #nosec G304 - user-specified output file
+			f, err := os.Create(output)
+			if err != nil {
+				FatalErrorRespectJSON("%v", err)
+			}
+			defer func() { _ = f.Close() }()
+			w = f
+		}
+		if _, err := io.Copy(w, blob); err != nil {
+			FatalErrorRespectJSON("writing attachment: %v", err)
+		}
+		if output != "" && !jsonOutput {
+			fmt.Fprintf(os.Stderr, "%s Wrote %s to %s\n", ui.RenderPass("✓"), a.Name, output)
+		}
+	},
+}
+
+var detachCmd = &cobra.Command{
+	Use:     "detach <issue-id> <name-or-id>",
+	GroupID: "issues",
+	Short:   "Remove an attachment from an issue",
+	Long: `Remove an attachment from an issue.
+
+The attachment is marked detached rather than deleted, so the removal syncs
+to other clones. Its content is deleted by bd cleanup once no issue
+references it.`,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		CheckReadonly("detach")
+		ctx := rootCtx
+		issueID := resolveAttachmentIssueID(ctx, args[0])
+
+		list, err := store.GetAttachments(ctx, issueID)
+		if err != nil {
+			FatalErrorRespectJSON("getting attachments: %v", err)
+		}
+		a, err := findAttachment(types.LiveAttachments(list), args[1])
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+
+		now := time.Now().UTC()
+		a.DetachedAt = &now
+		if err := store.SaveAttachment(ctx, a); err != nil {
+			FatalErrorRespectJSON("detaching %s: %v", a.Name, err)
+		}
+		markDirtyAndScheduleFlush()
+
+		if jsonOutput {
+			outputJSON(a)
+			return
+		}
+		fmt.Printf("%s Detached %s from %s\n", ui.RenderPass("✓"), a.Name, ui.RenderID(issueID))
+	},
+}
+
+// resolveAttachmentIssueID switches to direct mode and resolves a partial
+// issue ID. Attachment blobs are written next to the database, so these
+// commands don't go through the daemon.
+func resolveAttachmentIssueID(ctx context.Context, id string) string {
+	if err := ensureDirectMode("attachments require direct database access"); err != nil {
+		FatalErrorRespectJSON("%v", err)
+	}
+	fullID, err := utils.ResolvePartialID(ctx, store, id)
+	if err != nil {
+		FatalErrorRespectJSON("resolving %s: %v", id, err)
+	}
+	return fullID
+}
+
+// attachmentBlobStore returns the blob store of the current .beads directory.
+func attachmentBlobStore() *attachments.Store {
+	beadsDir := attachmentBeadsDir()
+	if beadsDir == "" {
+		FatalErrorRespectJSON("could not find .beads directory")
+	}
+	return attachments.New(beadsDir)
+}
+
+// attachmentBeadsDir returns the .beads directory holding the database,
+// which is where attachment blobs are kept.
+func attachmentBeadsDir() string {
+	if dbPath != "" {
+		return filepath.Dir(dbPath)
+	}
+	return beads.FindBeadsDir()
+}
+
+// findAttachment looks up an attachment by exact ID, file name, or unique ID
+// prefix, in that order.
+func findAttachment(list []*types.Attachment, ref string) (*types.Attachment, error) {
+	for _, a := range list {
+		if a.ID == ref {
+			return a, nil
+		}
+	}
+	var byName, byPrefix []*types.Attachment
+	for _, a := range list {
+		if a.Name == ref {
+			byName = append(byName, a)
+		}
+		if strings.HasPrefix(a.ID, ref) {
+			byPrefix = append(byPrefix, a)
+		}
+	}
+	for _, matches := range [][]*types.Attachment{byName, byPrefix} {
+		switch len(matches) {
+		case 0:
+			continue
+		case 1:
+			return matches[0], nil
+		default:
+			ids := make([]string, len(matches))
+			for i, a := range matches {
+				ids[i] = a.ID
+			}
+			return nil, fmt.Errorf("%q matches several attachments (%s); use the ID", ref, strings.Join(ids, ", "))
+		}
+	}
+	return nil, fmt.Errorf("no attachment %q", ref)
+}
+
+// detectMediaType guesses the media type of an attachment from its name,
+// falling back to sniffing the first bytes of its content.
+func detectMediaType(name string, blobs *attachments.Store, hash string) string {
+	if t := mime.TypeByExtension(filepath.Ext(name)); t != "" {
+		return t
+	}
+	f, err := blobs.Open(hash)
+	if err != nil {
+		return ""
+	}
+	defer func() { _ = f.Close() }()
+	buf := make([]byte, 512)
+	n, _ := io.ReadFull(f, buf)
+	return http.DetectContentType(buf[:n])
+}
+
+// formatAttachmentLine renders one attachment for bd attachments and bd show.
+func formatAttachmentLine(a *types.Attachment) string {
+	line := fmt.Sprintf("  %s  %s", a.Name, ui.RenderMuted(formatBytes(a.Size)))
+	if a.MediaType != "" {
+		line += "  " + ui.RenderMuted(a.MediaType)
+	}
+	line += "  " + ui.RenderMuted(a.ID)
+	if a.IsDetached() {
+		line += "  " + ui.RenderWarn("detached "+a.DetachedAt.Local().Format("2006-01-02"))
+	}
+	return line
+}
+
+// printAttachmentsSection prints the ATTACHMENTS section of bd show.
+func printAttachmentsSection(list []*types.Attachment) {
+	list = types.LiveAttachments(list)
+	if len(list) == 0 {
+		return
+	}
+	fmt.Printf("\n%s\n", ui.RenderBold("ATTACHMENTS"))
+	for _, a := range list {
+		fmt.Println(formatAttachmentLine(a))
+	}
+}
+
+// attachmentGCGrace protects blobs written by a bd attach that hasn't saved
+// its metadata yet, or pulled by git before the JSONL referencing them was
+// imported.
+const attachmentGCGrace = time.Hour
+
+// gcAttachmentBlobs deletes attachment blobs no live attachment refers to.
+// Used by bd cleanup; with dryRun it only reports them.
+func gcAttachmentBlobs(ctx context.Context, dryRun bool) {
+	referenced, err := store.GetAttachmentHashes(ctx)
+	if err != nil {
+		if !jsonOutput {
+			fmt.Fprintf(os.Stderr, "Warning: failed to get attachment references: %v\n", err)
+		}
+		return
+	}
+	beadsDir := attachmentBeadsDir()
+	if beadsDir == "" {
+		return
+	}
+	removed, err := attachments.New(beadsDir).GC(referenced, attachmentGCGrace, dryRun)
+	if err != nil && !jsonOutput {
+		fmt.Fprintf(os.Stderr, "Warning: failed to remove unreferenced attachments: %v\n", err)
+	}
+	if len(removed) == 0 || jsonOutput {
+		return
+	}
+	var total int64
+	for _, b := range removed {
+		total += b.Size
+	}
+	if dryRun {
+		fmt.Printf("\nUnreferenced attachments that would be removed: %d (%s)\n", len(removed), formatBytes(total))
+		return
+	}
+	fmt.Printf("\n%s Removed %d unreferenced attachment(s) (%s)\n", ui.RenderPass("✓"), len(removed), formatBytes(total))
+}
+
+func init() {
+	attachCmd.Flags().String("name", "", "Attachment name (default: the file's base name)")
+	attachCmd.Flags().String("type", "", "Media type (default: detected from name or content)")
+	attachmentsCmd.Flags().StringP("output", "o", "", "Write the attachment to a file instead of stdout")
+	attachmentsCmd.Flags().Bool("all", false, "Include detached attachments")
+
+	for _, c := range []*cobra.Command{attachCmd, attachmentsCmd, detachCmd} {
+		c.ValidArgsFunction = issueIDCompletion
+		rootCmd.AddCommand(c)
+	}
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/attachments"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestFindAttachment(t *testing.T) {
+	list := []*types.Attachment{
+		{ID: "att-1a2b3c", Name: "build.log"},
+		{ID: "att-1a9f00", Name: "shot.png"},
+		{ID: "att-ffee00", Name: "shot.png"},
+	}
+
+	tests := []struct {
+		ref     string
+		wantID  string
+		wantErr string
+	}{
+		{ref: "att-1a2b3c", wantID: "att-1a2b3c"},
+		{ref: "build.log", wantID: "att-1a2b3c"},
+		{ref: "att-ff", wantID: "att-ffee00"},
+		{ref: "shot.png", wantErr: "matches several"},
+		{ref: "att-1a", wantErr: "matches several"},
+		{ref: "missing.txt", wantErr: "no attachment"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.ref, func(t *testing.T) {
+			got, err := findAttachment(list, tt.ref)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("findAttachment(%q) error = %v, want %q", tt.ref, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("findAttachment(%q) failed: %v", tt.ref, err)
+			}
+			if got.ID != tt.wantID {
+				t.Errorf("findAttachment(%q) = %s, want %s", tt.ref, got.ID, tt.wantID)
+			}
+		})
+	}
+}
+
+func TestDetectMediaType(t *testing.T) {
+	blobs := attachments.New(t.TempDir())
+	hash, _, err := blobs.Put(strings.NewReader("\x89PNG\r\n\x1a\n0000"), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := detectMediaType("notes.txt", blobs, hash); !strings.HasPrefix(got, "text/plain") {
+		t.Errorf("by extension = %q, want text/plain", got)
+	}
+	if got := detectMediaType("screenshot", blobs, hash); got != "image/png" {
+		t.Errorf("by content = %q, want image/png", got)
+	}
+}
@@ -698,6 +698,20 @@ func fetchAndMergeIssues(ctx context.Context, s storage.Storage, dirtyIDs []stri
 		}
 		issue.Comments = comments
 
+		// Get work log for this issue
+		workLog, err := s.GetWorkLog(ctx, issueID)
+		if err != nil {
+			return fmt.Errorf("failed to get work log for %s: %w", issueID, err)
+		}
+		issue.WorkLog = workLog
+
+		// Get attachments for this issue
+		attachments, err := s.GetAttachments(ctx, issueID)
+		if err != nil {
+			return fmt.Errorf("failed to get attachments for %s: %w", issueID, err)
+		}
+		issue.Attachments = attachments
+
 		// Update map
 		issueMap[issueID] = issue
 	}
@@ -33,6 +33,7 @@ var cleanupCmd = &cobra.Command{
 This command:
 1. Converts closed issues to tombstones (soft delete)
 2. Prunes expired tombstones (older than 30 days) from issues.jsonl
+3. Removes attachment content (.beads/attachments) no issue refers to
 
 It does NOT remove temporary files - use 'bd clean' for that.
 
@@ -178,6 +179,9 @@ SEE ALSO:
 				}
 				fmt.Println(msg)
 			}
+			if force || dryRun {
+				gcAttachmentBlobs(ctx, dryRun)
+			}
 			return
 		}
 
@@ -256,6 +260,11 @@ SEE ALSO:
 			}
 		}
 
+		// Remove attachment blobs left behind by detached or deleted attachments
+		if force || dryRun {
+			gcAttachmentBlobs(ctx, dryRun)
+		}
+
 		// bd-bqcc: Show hint about doctor --fix consolidation
 		if !jsonOutput {
 			showCleanupDeprecationHint()
@@ -1,16 +1,21 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/beads/internal/hooks"
+	"github.com/steveyegge/beads/internal/recurrence"
 	"github.com/steveyegge/beads/internal/rpc"
+	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/types"
 	"github.com/steveyegge/beads/internal/ui"
 	"github.com/steveyegge/beads/internal/utils"
+	"github.com/steveyegge/beads/internal/validation"
 )
 
 var closeCmd = &cobra.Command{
@@ -57,6 +62,10 @@ create, update, show, or close operation).`,
 		}
 
 		ctx := rootCtx
+		if force {
+			// Also lets the close past the workflow rules; storage audits it
+			ctx = validation.WithWorkflowForce(ctx)
+		}
 
 		// --continue only works with a single issue
 		if continueFlag && len(args) > 1 {
@@ -286,12 +295,18 @@ create, update, show, or close operation).`,
 				hookRunner.Run(hooks.EventClose, closedIssue)
 			}
 
+			next := spawnNextOccurrence(ctx, store, closedIssue, actor)
+			if closedIssue != nil && closedIssue.IssueType == "gate" {
+				notifyGateWaiters(ctx, store, id, actor)
+			}
+
 			if jsonOutput {
 				if closedIssue != nil {
 					closedIssues = append(closedIssues, closedIssue)
 				}
 			} else {
 				fmt.Printf("%s Closed %s: %s\n", ui.RenderPass("✓"), id, reason)
+				printNextOccurrence(next)
 			}
 		}
 
@@ -409,7 +424,7 @@ func init() {
 	_ = closeCmd.Flags().MarkHidden("resolution") // Hidden alias for agent/CLI ergonomics
 	closeCmd.Flags().StringP("message", "m", "", "Alias for --reason (git commit convention)")
 	_ = closeCmd.Flags().MarkHidden("message") // Hidden alias for agent/CLI ergonomics
-	closeCmd.Flags().BoolP("force", "f", false, "Force close pinned issues")
+	closeCmd.Flags().BoolP("force", "f", false, "Force close pinned or blocked issues, or past the workflow rules")
 	closeCmd.Flags().Bool("continue", false, "Auto-advance to next step in molecule")
 	closeCmd.Flags().Bool("no-auto", false, "With --continue, show next step but don't claim it")
 	closeCmd.Flags().Bool("suggest-next", false, "Show newly unblocked issues after closing")
@@ -417,3 +432,26 @@ func init() {
 	closeCmd.ValidArgsFunction = issueIDCompletion
 	rootCmd.AddCommand(closeCmd)
 }
+
+// spawnNextOccurrence creates the next occurrence of a closed recurring issue
+// in direct mode. The daemon does this itself on close. A failure is only a
+// warning: the rule stays in place and the daemon's periodic scan retries it.
+func spawnNextOccurrence(ctx context.Context, s storage.Storage, closed *types.Issue, actor string) *types.Issue {
+	if closed == nil || closed.Recurrence == "" {
+		return nil
+	}
+	next, err := recurrence.Spawn(ctx, s, closed.ID, actor, time.Now())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to spawn next occurrence of %s: %v\n", closed.ID, err)
+		return nil
+	}
+	return next
+}
+
+// printNextOccurrence reports a spawned occurrence after a close.
+func printNextOccurrence(next *types.Issue) {
+	if next == nil || next.DeferUntil == nil {
+		return
+	}
+	fmt.Printf("  ↻ Next occurrence: %s (deferred until %s)\n", next.ID, next.DeferUntil.Format("2006-01-02 15:04"))
+}
@@ -7,11 +7,13 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/beads/internal/beads"
 	"github.com/steveyegge/beads/internal/compact"
+	"github.com/steveyegge/beads/internal/rpc"
 	"github.com/steveyegge/beads/internal/storage/sqlite"
 )
 
@@ -34,6 +36,9 @@ var (
 	compactLimit           int
 	compactOlderThan       int
 	compactDolt            bool
+	compactSummarizer      string
+	compactModel           string
+	compactEndpoint        string
 )
 
 var compactCmd = &cobra.Command{
@@ -48,12 +53,25 @@ Modes:
   - Prune: Remove expired tombstones from issues.jsonl (no API key needed)
   - Analyze: Export candidates for agent review (no API key needed)
   - Apply: Accept agent-provided summary (no API key needed)
-  - Auto: AI-powered compaction (requires ANTHROPIC_API_KEY, legacy)
+  - Auto: Compaction with a built-in summarizer (see Summarizers below)
   - Dolt: Run Dolt garbage collection (for Dolt-backend repositories)
 
 Tiers:
   - Tier 1: Semantic compression (30 days closed, 70% reduction)
-  - Tier 2: Ultra compression (90 days closed, 95% reduction)
+  - Tier 2: Archival rollup (90 days closed, 95% reduction). A closed epic and
+    its closed descendants become one summary on the epic; the originals and
+    their events move to the snapshot archive. Issues already at tier 1 with
+    long event histories are archived on their own.
+
+Both tiers keep a snapshot of the original content: 'bd restore <id> --apply'
+brings it back.
+
+Summarizers (--summarizer, or config key compact_summarizer):
+  - anthropic:  Claude Haiku (default, requires ANTHROPIC_API_KEY)
+  - openai:     Any OpenAI-compatible endpoint, e.g. a local model server
+                (--endpoint / compact_endpoint, --model / compact_model,
+                optional OPENAI_API_KEY)
+  - extractive: Leading sentences and child titles; offline and deterministic
 
 Tombstone Cleanup:
   Tombstones are soft-delete markers that prevent resurrection of deleted issues.
@@ -91,10 +109,13 @@ Examples:
   bd compact --apply --id bd-42 --summary summary.txt
   bd compact --apply --id bd-42 --summary - < summary.txt
 
-  # Legacy AI-powered workflow
+  # Built-in summarizer workflow
   bd compact --auto --dry-run              # Preview candidates
   bd compact --auto --all                  # Compact all eligible issues
   bd compact --auto --id bd-42             # Compact specific issue
+  bd compact --auto --all --tier 2 --summarizer extractive
+  bd compact --auto --id bd-7 --tier 2 --summarizer openai \
+    --endpoint http://localhost:11434/v1 --model llama3.1
 
   # Statistics
   bd compact --stats                       # Show statistics
@@ -219,32 +240,25 @@ Examples:
 				os.Exit(1)
 			}
 
+			config, err := compactSummarizerConfig(ctx)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+
 			// Use RPC if daemon available, otherwise direct mode
 			if daemonClient != nil {
-				runCompactRPC(ctx)
+				runCompactRPC(ctx, config)
 				return
 			}
 
-			// Fallback to direct mode
-			apiKey := os.Getenv("ANTHROPIC_API_KEY")
-			if apiKey == "" && !compactDryRun {
-				fmt.Fprintf(os.Stderr, "Error: --auto mode requires ANTHROPIC_API_KEY environment variable\n")
-				os.Exit(1)
-			}
-
 			sqliteStore, ok := store.(*sqlite.SQLiteStorage)
 			if !ok {
 				fmt.Fprintf(os.Stderr, "Error: compact requires SQLite storage\n")
 				os.Exit(1)
 			}
 
-			config := &compact.Config{
-				APIKey:      apiKey,
-				Concurrency: compactWorkers,
-				DryRun:      compactDryRun,
-			}
-
-			compactor, err := compact.New(sqliteStore, apiKey, config)
+			compactor, err := compact.New(sqliteStore, config.APIKey, config)
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "Error: failed to create compactor: %v\n", err)
 				os.Exit(1)
@@ -284,6 +298,14 @@ func runCompactSingle(ctx context.Context, compactor *compact.Compactor, store *
 	originalSize := len(issue.Description) + len(issue.Design) + len(issue.Notes) + len(issue.AcceptanceCriteria)
 
 	if compactDryRun {
+		var rolledUp []string
+		if compactTier == 2 {
+			// In dry-run mode the compactor only reports what it would roll up
+			if result, _ := compactor.CompactTier2(ctx, issueID); result != nil && result.OriginalSize > 0 {
+				originalSize = result.OriginalSize
+				rolledUp = result.Children
+			}
+		}
 		if jsonOutput {
 			output := map[string]interface{}{
 				"dry_run":             true,
@@ -292,23 +314,34 @@ func runCompactSingle(ctx context.Context, compactor *compact.Compactor, store *
 				"original_size":       originalSize,
 				"estimated_reduction": "70-80%",
 			}
+			if compactTier == 2 {
+				output["rolled_up"] = rolledUp
+			}
 			outputJSON(output)
 			return
 		}
 
 		fmt.Printf("DRY RUN - Tier %d compaction\n\n", compactTier)
 		fmt.Printf("Issue: %s\n", issueID)
+		if len(rolledUp) > 0 {
+			fmt.Printf("Rolls up: %d children\n", len(rolledUp))
+		}
 		fmt.Printf("Original size: %d bytes\n", originalSize)
 		fmt.Printf("Estimated reduction: 70-80%%\n")
 		return
 	}
 
 	var compactErr error
+	var rolledUp []string
 	if compactTier == 1 {
 		compactErr = compactor.CompactTier1(ctx, issueID)
 	} else {
-		fmt.Fprintf(os.Stderr, "Error: Tier 2 compaction not yet implemented\n")
-		os.Exit(1)
+		var result *compact.Result
+		result, compactErr = compactor.CompactTier2(ctx, issueID)
+		if result != nil && result.OriginalSize > 0 {
+			originalSize = result.OriginalSize
+			rolledUp = result.Children
+		}
 	}
 
 	if compactErr != nil {
@@ -337,11 +370,17 @@ func runCompactSingle(ctx context.Context, compactor *compact.Compactor, store *
 			"reduction_pct":  float64(savingBytes) / float64(originalSize) * 100,
 			"elapsed_ms":     elapsed.Milliseconds(),
 		}
+		if compactTier == 2 {
+			output["rolled_up"] = rolledUp
+		}
 		outputJSON(output)
 		return
 	}
 
 	fmt.Printf("✓ Compacted %s (Tier %d)\n", issueID, compactTier)
+	if len(rolledUp) > 0 {
+		fmt.Printf("  Rolled up %d children: %s\n", len(rolledUp), strings.Join(rolledUp, ", "))
+	}
 	fmt.Printf("  %d → %d bytes (saved %d, %.1f%%)\n",
 		originalSize, compactedSize, savingBytes,
 		float64(savingBytes)/float64(originalSize)*100)
@@ -429,7 +468,13 @@ func runCompactAll(ctx context.Context, compactor *compact.Compactor, store *sql
 		fmt.Printf("Compacting %d issues (Tier %d)...\n\n", len(candidates), compactTier)
 	}
 
-	results, err := compactor.CompactTier1Batch(ctx, candidates)
+	var results []*compact.Result
+	var err error
+	if compactTier == 1 {
+		results, err = compactor.CompactTier1Batch(ctx, candidates)
+	} else {
+		results, err = compactor.CompactTier2Batch(ctx, candidates)
+	}
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: batch compaction failed: %v\n", err)
 		os.Exit(1)
@@ -675,6 +720,11 @@ func runCompactApply(ctx context.Context, store *sqlite.SQLiteStorage) {
 	}
 	summary := string(summaryBytes)
 
+	if compactTier == 2 {
+		runCompactApplyTier2(ctx, store, summary, start)
+		return
+	}
+
 	// Get issue
 	issue, err := store.GetIssue(ctx, compactID)
 	if err != nil {
@@ -713,6 +763,11 @@ func runCompactApply(ctx context.Context, store *sqlite.SQLiteStorage) {
 		actor = "agent"
 	}
 
+	if err := store.SnapshotIssue(ctx, compactID, compactTier, compactedSize); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: failed to snapshot issue: %v\n", err)
+		os.Exit(1)
+	}
+
 	updates := map[string]interface{}{
 		"description":         summary,
 		"design":              "",
@@ -788,6 +843,62 @@ func runCompactApply(ctx context.Context, store *sqlite.SQLiteStorage) {
 	markDirtyAndScheduleFlush()
 }
 
+// runCompactApplyTier2 rolls --id and its closed descendants up under an
+// agent-provided summary.
+func runCompactApplyTier2(ctx context.Context, store *sqlite.SQLiteStorage, summary string, start time.Time) {
+	actor := compactActor
+	if actor == "" {
+		actor = "agent"
+	}
+
+	// The summary is supplied, so no summarizer backend is contacted
+	compactor, err := compact.New(store, "", &compact.Config{Summarizer: compact.SummarizerExtractive})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: failed to create compactor: %v\n", err)
+		os.Exit(1)
+	}
+	result, err := compactor.ApplyTier2(ctx, compactID, summary, actor, compactForce)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		if !compactForce {
+			fmt.Fprintf(os.Stderr, "Hint: use --force to bypass eligibility and size checks\n")
+		}
+		os.Exit(1)
+	}
+
+	elapsed := time.Since(start)
+	savingBytes := result.OriginalSize - result.CompactedSize
+	reductionPct := 0.0
+	if result.OriginalSize > 0 {
+		reductionPct = float64(savingBytes) / float64(result.OriginalSize) * 100
+	}
+
+	if jsonOutput {
+		outputJSON(map[string]interface{}{
+			"success":        true,
+			"issue_id":       compactID,
+			"tier":           2,
+			"original_size":  result.OriginalSize,
+			"compacted_size": result.CompactedSize,
+			"saved_bytes":    savingBytes,
+			"reduction_pct":  reductionPct,
+			"rolled_up":      result.Children,
+			"elapsed_ms":     elapsed.Milliseconds(),
+		})
+		markDirtyAndScheduleFlush()
+		return
+	}
+
+	fmt.Printf("✓ Compacted %s (Tier 2)\n", compactID)
+	if len(result.Children) > 0 {
+		fmt.Printf("  Rolled up %d children: %s\n", len(result.Children), strings.Join(result.Children, ", "))
+	}
+	fmt.Printf("  %d → %d bytes (saved %d, %.1f%%)\n", result.OriginalSize, result.CompactedSize, savingBytes, reductionPct)
+	fmt.Printf("  Time: %v\n", elapsed)
+
+	markDirtyAndScheduleFlush()
+}
+
 // runCompactDolt runs Dolt garbage collection on the .beads/dolt directory
 func runCompactDolt() {
 	start := time.Now()
@@ -887,6 +998,57 @@ func runCompactDolt() {
 	fmt.Printf("  Time: %v\n", elapsed)
 }
 
+// compactSummarizerConfig resolves the summarizer for --auto from the
+// --summarizer, --model and --endpoint flags, falling back to the
+// compact_summarizer, compact_model and compact_endpoint config keys, and
+// picks up the matching API key from the environment.
+func compactSummarizerConfig(ctx context.Context) (*compact.Config, error) {
+	config := &compact.Config{
+		Concurrency: compactWorkers,
+		DryRun:      compactDryRun,
+		Summarizer:  compactSummarizer,
+		Model:       compactModel,
+		Endpoint:    compactEndpoint,
+	}
+
+	var source interface {
+		GetConfig(ctx context.Context, key string) (string, error)
+	} = store
+	if daemonClient != nil {
+		source = daemonConfigSource{}
+	}
+	if source != nil {
+		if err := config.LoadSummarizerConfig(ctx, source); err != nil {
+			return nil, err
+		}
+	}
+
+	switch config.Summarizer {
+	case "", compact.SummarizerAnthropic:
+		config.APIKey = os.Getenv("ANTHROPIC_API_KEY")
+		if config.APIKey == "" && !compactDryRun {
+			return nil, fmt.Errorf("the anthropic summarizer requires ANTHROPIC_API_KEY (or use --summarizer openai or extractive)")
+		}
+	case compact.SummarizerOpenAI:
+		config.APIKey = os.Getenv("OPENAI_API_KEY") // optional for local servers
+	case compact.SummarizerExtractive:
+	default:
+		return nil, fmt.Errorf("unknown summarizer %q (valid: %s)", config.Summarizer, strings.Join(compact.Summarizers, ", "))
+	}
+	return config, nil
+}
+
+// daemonConfigSource reads config keys through the daemon.
+type daemonConfigSource struct{}
+
+func (daemonConfigSource) GetConfig(_ context.Context, key string) (string, error) {
+	resp, err := daemonClient.GetConfig(&rpc.GetConfigArgs{Key: key})
+	if err != nil {
+		return "", err
+	}
+	return resp.Value, nil
+}
+
 // getDirSize calculates the total size of a directory recursively
 func getDirSize(path string) (int64, error) {
 	var size int64
@@ -930,7 +1092,7 @@ func init() {
 	// New mode flags
 	compactCmd.Flags().BoolVar(&compactAnalyze, "analyze", false, "Analyze mode: export candidates for agent review")
 	compactCmd.Flags().BoolVar(&compactApply, "apply", false, "Apply mode: accept agent-provided summary")
-	compactCmd.Flags().BoolVar(&compactAuto, "auto", false, "Auto mode: AI-powered compaction (legacy)")
+	compactCmd.Flags().BoolVar(&compactAuto, "auto", false, "Auto mode: compact with a built-in summarizer")
 	compactCmd.Flags().BoolVar(&compactPrune, "prune", false, "Prune mode: remove expired tombstones from issues.jsonl (by age)")
 	compactCmd.Flags().IntVar(&compactOlderThan, "older-than", -1, "Prune tombstones older than N days (0=all, default: 30)")
 	compactCmd.Flags().BoolVar(&compactPurgeTombstones, "purge-tombstones", false, "Purge mode: remove tombstones with no open deps (by dependency analysis)")
@@ -938,6 +1100,9 @@ func init() {
 	compactCmd.Flags().StringVar(&compactActor, "actor", "agent", "Actor name for audit trail")
 	compactCmd.Flags().IntVar(&compactLimit, "limit", 0, "Limit number of candidates (0 = no limit)")
 	compactCmd.Flags().BoolVar(&compactDolt, "dolt", false, "Dolt mode: run Dolt garbage collection on .beads/dolt")
+	compactCmd.Flags().StringVar(&compactSummarizer, "summarizer", "", "Summarizer for --auto: anthropic, openai or extractive (default: compact_summarizer config or anthropic)")
+	compactCmd.Flags().StringVar(&compactModel, "model", "", "Model for the summarizer (default: compact_model config or backend default)")
+	compactCmd.Flags().StringVar(&compactEndpoint, "endpoint", "", "Base URL of an OpenAI-compatible API (default: compact_endpoint config or OpenAI)")
 
 	// Note: compactCmd is added to adminCmd in admin.go
 }
@@ -5,6 +5,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/compact"
 )
 
 func progressBar(current, total int) string {
@@ -25,7 +28,7 @@ func progressBar(current, total int) string {
 }
 
 //nolint:unparam // ctx may be used in future for cancellation
-func runCompactRPC(_ context.Context) {
+func runCompactRPC(_ context.Context, config *compact.Config) {
 	if compactID != "" && compactAll {
 		fmt.Fprintf(os.Stderr, "Error: cannot use --id and --all together\n")
 		os.Exit(1)
@@ -41,20 +44,17 @@ func runCompactRPC(_ context.Context) {
 		os.Exit(1)
 	}
 
-	apiKey := os.Getenv("ANTHROPIC_API_KEY")
-	if apiKey == "" && !compactDryRun {
-		fmt.Fprintf(os.Stderr, "Error: ANTHROPIC_API_KEY environment variable not set\n")
-		os.Exit(1)
-	}
-
 	args := map[string]interface{}{
 		"tier":       compactTier,
 		"dry_run":    compactDryRun,
 		"force":      compactForce,
 		"all":        compactAll,
-		"api_key":    apiKey,
+		"api_key":    config.APIKey,
 		"workers":    compactWorkers,
 		"batch_size": compactBatch,
+		"summarizer": config.Summarizer,
+		"model":      config.Model,
+		"endpoint":   config.Endpoint,
 	}
 	if compactID != "" {
 		args["issue_id"] = compactID
@@ -77,20 +77,22 @@ func runCompactRPC(_ context.Context) {
 	}
 
 	var result struct {
-		Success       bool   `json:"success"`
-		IssueID       string `json:"issue_id,omitempty"`
-		OriginalSize  int    `json:"original_size,omitempty"`
-		CompactedSize int    `json:"compacted_size,omitempty"`
-		Reduction     string `json:"reduction,omitempty"`
-		Duration      string `json:"duration,omitempty"`
-		DryRun        bool   `json:"dry_run,omitempty"`
+		Success       bool     `json:"success"`
+		IssueID       string   `json:"issue_id,omitempty"`
+		OriginalSize  int      `json:"original_size,omitempty"`
+		CompactedSize int      `json:"compacted_size,omitempty"`
+		Reduction     string   `json:"reduction,omitempty"`
+		Duration      string   `json:"duration,omitempty"`
+		DryRun        bool     `json:"dry_run,omitempty"`
+		RolledUp      []string `json:"rolled_up,omitempty"`
 		Results       []struct {
-			IssueID       string `json:"issue_id"`
-			Success       bool   `json:"success"`
-			Error         string `json:"error,omitempty"`
-			OriginalSize  int    `json:"original_size,omitempty"`
-			CompactedSize int    `json:"compacted_size,omitempty"`
-			Reduction     string `json:"reduction,omitempty"`
+			IssueID       string   `json:"issue_id"`
+			Success       bool     `json:"success"`
+			Error         string   `json:"error,omitempty"`
+			OriginalSize  int      `json:"original_size,omitempty"`
+			CompactedSize int      `json:"compacted_size,omitempty"`
+			Reduction     string   `json:"reduction,omitempty"`
+			RolledUp      []string `json:"rolled_up,omitempty"`
 		} `json:"results,omitempty"`
 	}
 
@@ -105,8 +107,14 @@ func runCompactRPC(_ context.Context) {
 			fmt.Printf("Issue: %s\n", compactID)
 			fmt.Printf("Original size: %d bytes\n", result.OriginalSize)
 			fmt.Printf("Estimated reduction: %s\n", result.Reduction)
+			if len(result.RolledUp) > 0 {
+				fmt.Printf("Would roll up: %s\n", strings.Join(result.RolledUp, ", "))
+			}
 		} else {
 			fmt.Printf("Successfully compacted %s\n", result.IssueID)
+			if len(result.RolledUp) > 0 {
+				fmt.Printf("Rolled up %d children: %s\n", len(result.RolledUp), strings.Join(result.RolledUp, ", "))
+			}
 			fmt.Printf("Original size: %d bytes\n", result.OriginalSize)
 			fmt.Printf("Compacted size: %d bytes\n", result.CompactedSize)
 			fmt.Printf("Reduction: %s\n", result.Reduction)
@@ -126,6 +134,9 @@ func runCompactRPC(_ context.Context) {
 			for _, r := range result.Results {
 				if r.Success {
 					fmt.Printf("  ✓ %s: %d → %d bytes (%s)\n", r.IssueID, r.OriginalSize, r.CompactedSize, r.Reduction)
+					if len(r.RolledUp) > 0 {
+						fmt.Printf("    rolled up: %s\n", strings.Join(r.RolledUp, ", "))
+					}
 				} else {
 					fmt.Printf("  ✗ %s: %s\n", r.IssueID, r.Error)
 				}
@@ -64,6 +64,9 @@ Examples:
 		priorityMin, _ := cmd.Flags().GetInt("priority-min")
 		priorityMax, _ := cmd.Flags().GetInt("priority-max")
 
+		// Filter expression
+		where := parseWhereFlag(cmd)
+
 		// Group by flags
 		byStatus, _ := cmd.Flags().GetBool("by-status")
 		byPriority, _ := cmd.Flags().GetBool("by-priority")
@@ -167,6 +170,11 @@ Examples:
 				countArgs.PriorityMax = &priorityMax
 			}
 
+			// Filter expression
+			if where != nil {
+				countArgs.Where = where.String()
+			}
+
 			resp, err := daemonClient.Count(countArgs)
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
@@ -323,6 +331,11 @@ Examples:
 			filter.PriorityMax = &priorityMax
 		}
 
+		// Filter expression
+		if where != nil {
+			filter.Where = where.String()
+		}
+
 		issues, err := store.SearchIssues(ctx, "", filter)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
@@ -456,6 +469,9 @@ func init() {
 	countCmd.Flags().Int("priority-min", 0, "Filter by minimum priority (inclusive)")
 	countCmd.Flags().Int("priority-max", 0, "Filter by maximum priority (inclusive)")
 
+	// Filter expression
+	countCmd.Flags().String("where", "", whereFlagUsage)
+
 	// Grouping flags
 	countCmd.Flags().Bool("by-status", false, "Group count by status")
 	countCmd.Flags().Bool("by-priority", false, "Group count by priority")
@@ -15,6 +15,7 @@ import (
 	"github.com/steveyegge/beads/internal/hooks"
 	"github.com/steveyegge/beads/internal/routing"
 	"github.com/steveyegge/beads/internal/rpc"
+	"github.com/steveyegge/beads/internal/search"
 	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/storage/factory"
 	"github.com/steveyegge/beads/internal/storage/sqlite"
@@ -181,6 +182,9 @@ var createCmd = &cobra.Command{
 			deferUntil = &t
 		}
 
+		recur := parseRecurFlag(cmd)
+		customFields := parseFieldFlags(cmd, types.IssueType(issueType).Normalize())
+
 		// Handle --dry-run flag (before --rig to ensure it works with cross-rig creation)
 		dryRun, _ := cmd.Flags().GetBool("dry-run")
 		if dryRun {
@@ -208,6 +212,8 @@ var createCmd = &cobra.Command{
 				Rig:                agentRig,
 				DueAt:              dueAt,
 				DeferUntil:         deferUntil,
+				Recurrence:         recur,
+				CustomFields:       customFields,
 				// Event fields
 				EventKind: eventCategory,
 				Actor:     eventActor,
@@ -483,6 +489,11 @@ var createCmd = &cobra.Command{
 			externalRefPtr = &externalRef
 		}
 
+		// Warn if the new issue closely matches an open one (unless silent mode)
+		if !wisp && !silent && !debug.IsQuiet() && config.GetBool("create.warn-similar") {
+			warnSimilarOpenIssues(rootCtx, title, description)
+		}
+
 		// If daemon is running, use RPC
 		if daemonClient != nil {
 			createArgs := &rpc.CreateArgs{
@@ -514,6 +525,8 @@ var createCmd = &cobra.Command{
 				EventPayload:       eventPayload,
 				DueAt:              formatTimeForRPC(dueAt),
 				DeferUntil:         formatTimeForRPC(deferUntil),
+				Recurrence:         recur,
+				CustomFields:       customFields,
 			}
 
 			resp, err := daemonClient.Create(createArgs)
@@ -574,6 +587,8 @@ var createCmd = &cobra.Command{
 			Payload:            eventPayload,
 			DueAt:              dueAt,
 			DeferUntil:         deferUntil,
+			Recurrence:         recur,
+			CustomFields:       customFields,
 		}
 
 		ctx := rootCtx
@@ -767,6 +782,104 @@ var createCmd = &cobra.Command{
 	},
 }
 
+// maxSimilarWarnings caps how many near-duplicates bd create lists
+const maxSimilarWarnings = 3
+
+// similarCandidateLimit caps how many open issues bd create loads and
+// scores against a new one. A ranked full-text search picks them, so the
+// check reads the same few issues however many the database holds.
+const similarCandidateLimit = 50
+
+// findSimilarOpenIssues returns open issues whose title and description
+// closely match the given ones, most similar first.
+func findSimilarOpenIssues(ctx context.Context, title, description string) ([]*search.Similarity, error) {
+	q := search.CandidateQuery(title, description, 12)
+	if q == "" {
+		return nil, nil
+	}
+	excludeClosed := []types.Status{types.StatusClosed}
+	var results []*types.SearchResult
+	if daemonClient != nil {
+		resp, err := daemonClient.List(&rpc.ListArgs{
+			Query:         q,
+			Ranked:        true,
+			Limit:         similarCandidateLimit,
+			ExcludeStatus: []string{string(types.StatusClosed)},
+		})
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(resp.Data, &results); err != nil {
+			return nil, fmt.Errorf("parsing response: %w", err)
+		}
+	} else {
+		var err error
+		results, err = store.SearchIssuesRanked(ctx, q, types.IssueFilter{ExcludeStatus: excludeClosed, Limit: similarCandidateLimit})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(results) == 0 {
+		return nil, nil
+	}
+
+	// The candidate goes first so it can be compared against everything else
+	candidate := &types.Issue{Title: title, Description: description, Status: types.StatusOpen}
+	issues := []*types.Issue{candidate}
+	for _, r := range results {
+		issues = append(issues, r.Issue)
+	}
+
+	// Weight terms as if over every open issue, as bd duplicates --similar
+	// does, so the threshold means the same thing here. The candidates are
+	// the open issues sharing the new issue's words, so their frequencies
+	// stand in for the full set; only the open count is fetched.
+	open, err := countOpenIssues(ctx)
+	if err != nil {
+		return nil, err
+	}
+	df := search.NewSampleFrequencies(issues, open+1)
+	return search.NewCorpusWithFrequencies(issues, df).Similar(0, similarityThreshold(), maxSimilarWarnings), nil
+}
+
+// countOpenIssues returns the number of issues that aren't closed.
+func countOpenIssues(ctx context.Context) (int, error) {
+	var stats *types.Statistics
+	if daemonClient != nil {
+		resp, err := daemonClient.Stats()
+		if err != nil {
+			return 0, err
+		}
+		if err := json.Unmarshal(resp.Data, &stats); err != nil {
+			return 0, fmt.Errorf("parsing response: %w", err)
+		}
+	} else {
+		var err error
+		stats, err = store.GetStatistics(ctx)
+		if err != nil {
+			return 0, err
+		}
+	}
+	return stats.TotalIssues - stats.ClosedIssues, nil
+}
+
+// warnSimilarOpenIssues prints a warning listing open issues that look like
+// near-duplicates of the issue being created. The check is advisory, so
+// lookup failures are silently ignored rather than blocking the create.
+func warnSimilarOpenIssues(ctx context.Context, title, description string) {
+	matches, err := findSimilarOpenIssues(ctx, title, description)
+	if err != nil || len(matches) == 0 {
+		return
+	}
+	fmt.Fprintf(os.Stderr, "%s New issue closely matches %d open issue(s):\n", ui.RenderWarn("⚠"), len(matches))
+	for _, m := range matches {
+		fmt.Fprintf(os.Stderr, "  %s: %s\n", m.B.ID, m.B.Title)
+		fmt.Fprintf(os.Stderr, "    %s\n", ui.RenderMuted(m.Explain()))
+	}
+	fmt.Fprintf(os.Stderr, "  Consider updating the existing issue instead (see: bd duplicates --similar)\n")
+}
+
 // flushRoutedRepo ensures the target repo's JSONL is updated after routing an issue.
 // This is critical for multi-repo hydration to work correctly (bd-fix-routing).
 func flushRoutedRepo(targetStore storage.Storage, repoPath string) {
@@ -922,6 +1035,13 @@ func init() {
 	//   --defer=tomorrow    Hidden until tomorrow
 	createCmd.Flags().String("due", "", "Due date/time. Formats: +6h, +1d, +2w, tomorrow, next monday, 2025-01-15")
 	createCmd.Flags().String("defer", "", "Defer until date (issue hidden from bd ready until then). Same formats as --due")
+	// Recurrence: closing the issue spawns the next occurrence
+	//   --recur="every monday 09:00"
+	//   --recur="every 2 weeks"
+	//   --recur="0 9 * * 1-5"      Cron: weekdays at 9:00
+	createCmd.Flags().String("recur", "", `Recur on a schedule: closing spawns the next occurrence. Examples: "every monday 09:00", "every 2 weeks", "0 9 * * 1-5"`)
+	// Custom fields defined under custom_fields in config.yaml (see bd fields)
+	createCmd.Flags().StringArray("field", nil, "Set a custom field: name=value (repeatable, see 'bd fields')")
 	// Note: --json flag is defined as a persistent flag in main.go, not here
 	rootCmd.AddCommand(createCmd)
 }
@@ -1002,6 +1122,9 @@ func createInRig(cmd *cobra.Command, rigName, explicitID, title, description, is
 		deferUntil = &t
 	}
 
+	recur := parseRecurFlag(cmd)
+	customFields := parseFieldFlags(cmd, types.IssueType(issueType).Normalize())
+
 	// Create issue with explicit ID if provided, otherwise CreateIssue will generate one
 	issue := &types.Issue{
 		ID:                 explicitID, // Set explicit ID if provided (empty string if not)
@@ -1030,6 +1153,9 @@ func createInRig(cmd *cobra.Command, rigName, explicitID, title, description, is
 		// Time scheduling fields (bd-xwvo fix)
 		DueAt:      dueAt,
 		DeferUntil: deferUntil,
+		Recurrence: recur,
+		// Custom fields
+		CustomFields: customFields,
 		// Cross-rig routing: use route prefix instead of database config
 		PrefixOverride: prefixOverride,
 	}
@@ -1151,3 +1277,16 @@ func ensureBeadsDirForPath(ctx context.Context, targetPath string, sourceStore s
 
 	return nil
 }
+
+// parseRecurFlag returns the validated --recur rule, or "" if not set.
+func parseRecurFlag(cmd *cobra.Command) string {
+	recur, _ := cmd.Flags().GetString("recur")
+	recur = strings.TrimSpace(recur)
+	if recur == "" {
+		return ""
+	}
+	if _, err := timeparsing.ParseRecurrence(recur); err != nil {
+		FatalError("invalid --recur: %v", err)
+	}
+	return recur
+}
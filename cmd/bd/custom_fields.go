@@ -0,0 +1,193 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+	"github.com/steveyegge/beads/internal/validation"
+)
+
+var fieldsCmd = &cobra.Command{
+	Use:     "fields",
+	GroupID: "setup",
+	Short:   "Show the custom fields defined in config.yaml",
+	Long: `Show the custom fields defined in config.yaml.
+
+Custom fields hold structured data that would otherwise end up in labels or
+notes. They are defined in .beads/config.yaml:
+
+  custom_fields:
+    points:
+      type: int
+      required: [feature, bug]      # Issue types that must set it ("*" = all)
+    severity:
+      type: enum
+      values: [low, medium, high, critical]
+    customer:
+      type: string
+      description: Customer who reported the issue
+    review_date:
+      type: date                    # YYYY-MM-DD; +2w, next friday also accepted
+    reviewer:
+      type: user
+    spec:
+      type: url
+
+Set values with --field on create and update (an empty value clears a field):
+
+  bd create "Login fails" -t bug --field points=3 --field severity=high
+  bd update bd-42 --field severity=critical --field customer=
+
+Filter and sort on them in list and search:
+
+  bd list --field points>=3 --field severity!=low --sort points
+  bd list --field reviewer          # Has a reviewer
+  bd list --field reviewer=         # Has no reviewer
+
+Values are exported to JSONL and can be mapped to Jira and Linear fields
+(jira.field_map.<field>, linear.field_map.<field>). The daemon reads the
+schema at startup; restart it after changes.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		schema, err := validation.LoadFieldSchema()
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+
+		if jsonOutput {
+			defs := []*validation.FieldDef{}
+			for _, name := range schema.Names() {
+				defs = append(defs, schema.Field(name))
+			}
+			outputJSON(defs)
+			return
+		}
+
+		if schema == nil {
+			fmt.Println("No custom fields configured (see 'bd fields --help')")
+			return
+		}
+		for _, name := range schema.Names() {
+			def := schema.Field(name)
+			line := fmt.Sprintf("%s %s", ui.RenderBold(name), ui.RenderMuted(string(def.Type)))
+			if len(def.Values) > 0 {
+				line += " " + strings.Join(def.Values, "|")
+			}
+			if len(def.Required) > 0 {
+				line += ui.RenderWarn(" required: " + strings.Join(def.Required, ", "))
+			}
+			fmt.Println(line)
+			if def.Description != "" {
+				fmt.Printf("  %s\n", def.Description)
+			}
+		}
+	},
+}
+
+// parseFieldFlags returns the validated --field values for a new issue of
+// the given type, or nil if none were given.
+func parseFieldFlags(cmd *cobra.Command, issueType types.IssueType) map[string]string {
+	values, err := validation.ParseFieldAssignments(fieldFlagValues(cmd))
+	if err != nil {
+		FatalError("invalid --field: %v", err)
+	}
+	fields, err := validation.CustomFieldsForCreate(issueType, values)
+	if err != nil {
+		FatalError("%v", err)
+	}
+	return fields
+}
+
+// parseFieldChanges returns the --field changes for bd update, keyed by
+// field name. They are validated per issue by validation.CustomFieldUpdates.
+func parseFieldChanges(cmd *cobra.Command) map[string]string {
+	changes, err := validation.ParseFieldAssignments(fieldFlagValues(cmd))
+	if err != nil {
+		FatalErrorRespectJSON("invalid --field: %v", err)
+	}
+	return changes
+}
+
+// parseFieldFilterFlags returns the --field filters of bd list and bd search.
+func parseFieldFilterFlags(cmd *cobra.Command) []types.CustomFieldFilter {
+	exprs := fieldFlagValues(cmd)
+	if len(exprs) == 0 {
+		return nil
+	}
+	schema, err := validation.LoadFieldSchema()
+	if err != nil {
+		FatalErrorRespectJSON("%v", err)
+	}
+	filters := make([]types.CustomFieldFilter, 0, len(exprs))
+	for _, expr := range exprs {
+		f, err := schema.ParseFilter(expr)
+		if err != nil {
+			FatalErrorRespectJSON("invalid --field: %v", err)
+		}
+		filters = append(filters, f)
+	}
+	return filters
+}
+
+func fieldFlagValues(cmd *cobra.Command) []string {
+	values, _ := cmd.Flags().GetStringArray("field")
+	return values
+}
+
+// customFieldSorter returns a comparison for sorting by a custom field, or
+// nil if sortBy isn't a defined field. Issues without a value sort last.
+func customFieldSorter(sortBy string) func(a, b *types.Issue) int {
+	schema, err := validation.LoadFieldSchema()
+	if err != nil {
+		return nil
+	}
+	def := schema.Field(sortBy)
+	if def == nil {
+		return nil
+	}
+	return func(a, b *types.Issue) int {
+		x, okA := a.CustomFields[def.Name]
+		y, okB := b.CustomFields[def.Name]
+		switch {
+		case !okA && !okB:
+			return 0
+		case !okA:
+			return 1
+		case !okB:
+			return -1
+		}
+		return types.CompareCustomFieldValues(x, y, def.Numeric())
+	}
+}
+
+// customFieldTypes returns the type of each configured custom field, keyed by
+// name, for tracker field mappings.
+func customFieldTypes() map[string]string {
+	result := map[string]string{}
+	schema, err := validation.LoadFieldSchema()
+	if err != nil {
+		return result
+	}
+	for _, name := range schema.Names() {
+		result[name] = string(schema.Field(name).Type)
+	}
+	return result
+}
+
+// printCustomFieldsSection prints the FIELDS section of bd show.
+func printCustomFieldsSection(issue *types.Issue) {
+	names := issue.CustomFieldNames()
+	if len(names) == 0 {
+		return
+	}
+	fmt.Printf("\n%s\n", ui.RenderBold("FIELDS"))
+	for _, name := range names {
+		fmt.Printf("  %s: %s\n", name, issue.CustomFields[name])
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(fieldsCmd)
+}
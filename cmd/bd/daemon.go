@@ -580,6 +580,21 @@ func runDaemonLoop(interval time.Duration, autoCommit, autoPush, autoPull, local
 		return
 	}
 
+	// Deliver mutation events to any webhooks configured in config.yaml
+	startWebhookDispatcher(serverCtx, server, beadsDir, workspacePath, log)
+
+	// Serve the RPC operations over HTTP if daemon.http.enabled is set
+	startHTTPGateway(serverCtx, server, beadsDir, log)
+
+	// Record sync outcomes and serve Prometheus metrics if daemon.metrics.enabled is set
+	startMetricsExporter(serverCtx, server, log)
+
+	// Spawn the next occurrence of recurring issues closed outside this daemon
+	startRecurrenceScheduler(serverCtx, server, log)
+
+	// Return issues whose claim lease lapsed to open
+	startLeaseReaper(serverCtx, server, beadsDir, log)
+
 	// Choose event loop based on BEADS_DAEMON_MODE (need to determine early for SetConfig)
 	daemonMode := os.Getenv("BEADS_DAEMON_MODE")
 	if daemonMode == "" {
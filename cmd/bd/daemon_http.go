@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/rpc"
+)
+
+// httpTokenPath returns the generated HTTP gateway token file for a .beads dir.
+func httpTokenPath(beadsDir string) string {
+	return filepath.Join(beadsDir, "http-token")
+}
+
+// loadOrCreateHTTPToken reads the gateway token from path, generating a
+// random one (readable only by the owner) if the file doesn't exist yet.
+func loadOrCreateHTTPToken(path string) (string, error) {
+	// #nosec G304 - path is derived from the .beads directory
+	if data, err := os.ReadFile(path); err == nil {
+		if token := strings.TrimSpace(string(data)); token != "" {
+			return token, nil
+		}
+	} else if !os.IsNotExist(err) {
+		return "", fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate token: %w", err)
+	}
+	token := hex.EncodeToString(buf)
+	if err := os.WriteFile(path, []byte(token+"\n"), 0600); err != nil {
+		return "", fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return token, nil
+}
+
+// startHTTPGateway serves the daemon's RPC operations over HTTP when
+// daemon.http.enabled is set. The bearer token comes from the environment
+// variable named by daemon.http.token-env, or from .beads/http-token.
+func startHTTPGateway(ctx context.Context, server *rpc.Server, beadsDir string, log daemonLogger) {
+	cfg := config.GetHTTPGatewayConfig()
+	if !cfg.Enabled {
+		return
+	}
+
+	token := cfg.Token()
+	tokenSource := "$" + cfg.TokenEnv
+	if token == "" {
+		var err error
+		token, err = loadOrCreateHTTPToken(httpTokenPath(beadsDir))
+		if err != nil {
+			log.Error("HTTP gateway disabled", "error", err)
+			return
+		}
+		tokenSource = httpTokenPath(beadsDir)
+	}
+
+	ln, err := net.Listen("tcp", cfg.Addr)
+	if err != nil {
+		log.Error("HTTP gateway disabled", "addr", cfg.Addr, "error", err)
+		return
+	}
+	if !cfg.IsLoopback() {
+		log.Warn("HTTP gateway is reachable from other hosts; traffic is not encrypted", "addr", ln.Addr().String())
+	}
+
+	gateway := rpc.NewHTTPGateway(server, token)
+	go func() {
+		if err := gateway.Serve(ctx, ln); err != nil {
+			log.Error("HTTP gateway stopped", "error", err)
+		}
+	}()
+	log.Info("HTTP gateway enabled", "addr", ln.Addr().String(), "token", tokenSource)
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestLoadOrCreateHTTPToken(t *testing.T) {
+	path := httpTokenPath(t.TempDir())
+
+	token, err := loadOrCreateHTTPToken(path)
+	if err != nil {
+		t.Fatalf("loadOrCreateHTTPToken() error = %v", err)
+	}
+	if len(token) != 64 {
+		t.Errorf("token length = %d, want 64 hex chars", len(token))
+	}
+
+	if runtime.GOOS != "windows" {
+		info, err := os.Stat(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if perm := info.Mode().Perm(); perm != 0600 {
+			t.Errorf("token file mode = %o, want 600", perm)
+		}
+	}
+
+	again, err := loadOrCreateHTTPToken(path)
+	if err != nil {
+		t.Fatalf("second loadOrCreateHTTPToken() error = %v", err)
+	}
+	if again != token {
+		t.Error("token should be reused across daemon restarts")
+	}
+
+	if err := os.WriteFile(filepath.Clean(path), []byte("  custom\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := loadOrCreateHTTPToken(path); got != "custom" {
+		t.Errorf("token = %q, want hand-edited value", got)
+	}
+}
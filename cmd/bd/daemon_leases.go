@@ -0,0 +1,48 @@
+package main
+
+import (
+	"context"
+	"path/filepath"
+	"time"
+
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/hooks"
+	"github.com/steveyegge/beads/internal/rpc"
+)
+
+// startLeaseReaper periodically returns issues whose claim lease lapsed
+// (bd update --claim --lease without a bd heartbeat in time) to open, and
+// runs the on_lease_expired hook for each. Runs every daemon.leases.interval;
+// a zero or negative interval disables it.
+func startLeaseReaper(ctx context.Context, server *rpc.Server, beadsDir string, log daemonLogger) {
+	interval := config.GetDuration("daemon.leases.interval")
+	if interval <= 0 {
+		return
+	}
+	runner := hooks.NewRunner(filepath.Join(beadsDir, "hooks"))
+
+	reap := func() {
+		reclaimed, err := server.ReclaimExpiredLeases(ctx)
+		for _, issue := range reclaimed {
+			log.Info("reclaimed expired lease", "issue", issue.ID)
+			runner.Run(hooks.EventLeaseExpired, issue)
+		}
+		if err != nil {
+			log.Warn("lease reaper failed", "error", err)
+		}
+	}
+
+	go func() {
+		reap()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				reap()
+			}
+		}
+	}()
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/rpc"
+)
+
+// daemonMetrics is the running daemon's metrics collector, or nil outside
+// the daemon. Sync functions record their outcomes into it.
+var daemonMetrics *rpc.Metrics
+
+// recordDaemonSync records the outcome of a sync stage when running as the
+// daemon. It is a no-op otherwise.
+func recordDaemonSync(stage string, err error) {
+	if daemonMetrics != nil {
+		daemonMetrics.RecordSync(stage, err)
+	}
+}
+
+// startMetricsExporter hooks sync outcomes into the server's metrics and,
+// when daemon.metrics.enabled is set, serves them in Prometheus format on
+// daemon.metrics.addr. The gateway's /metrics (see startHTTPGateway) serves
+// the same data behind the bearer token.
+func startMetricsExporter(ctx context.Context, server *rpc.Server, log daemonLogger) {
+	daemonMetrics = server.Metrics()
+
+	cfg := config.GetMetricsConfig()
+	if !cfg.Enabled {
+		return
+	}
+
+	ln, err := net.Listen("tcp", cfg.Addr)
+	if err != nil {
+		log.Error("metrics listener disabled", "addr", cfg.Addr, "error", err)
+		return
+	}
+	if !cfg.IsLoopback() {
+		log.Warn("metrics listener is reachable from other hosts and has no authentication", "addr", ln.Addr().String())
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("GET /metrics", server.MetricsHandler())
+	srv := &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() {
+		<-ctx.Done()
+		_ = srv.Close()
+	}()
+	go func() {
+		if err := srv.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Error("metrics listener stopped", "error", err)
+		}
+	}()
+	log.Info("metrics enabled", "addr", ln.Addr().String(), "path", "/metrics")
+}
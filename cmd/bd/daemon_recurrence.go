@@ -0,0 +1,44 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/rpc"
+)
+
+// startRecurrenceScheduler periodically spawns the next occurrence of closed
+// recurring issues the close handler didn't see: issues closed in direct
+// mode, imported from other clones, or whose spawn failed. Runs every
+// daemon.recurrence.interval; a zero or negative interval disables it.
+func startRecurrenceScheduler(ctx context.Context, server *rpc.Server, log daemonLogger) {
+	interval := config.GetDuration("daemon.recurrence.interval")
+	if interval <= 0 {
+		return
+	}
+
+	scan := func() {
+		spawned, err := server.SpawnRecurring(ctx)
+		for _, issue := range spawned {
+			log.Info("spawned next occurrence", "issue", issue.ID, "recurrence", issue.Recurrence)
+		}
+		if err != nil {
+			log.Warn("recurring issue scan failed", "error", err)
+		}
+	}
+
+	go func() {
+		scan()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				scan()
+			}
+		}
+	}()
+}
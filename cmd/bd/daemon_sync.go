@@ -14,6 +14,7 @@ import (
 
 	"github.com/steveyegge/beads/internal/beads"
 	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/rpc"
 	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/storage/sqlite"
 	"github.com/steveyegge/beads/internal/types"
@@ -91,6 +92,24 @@ func exportToJSONLWithStore(ctx context.Context, store storage.Storage, jsonlPat
 		issue.Comments = comments
 	}
 
+	// Populate work log for all issues
+	for _, issue := range issues {
+		workLog, err := store.GetWorkLog(ctx, issue.ID)
+		if err != nil {
+			return fmt.Errorf("failed to get work log for %s: %w", issue.ID, err)
+		}
+		issue.WorkLog = workLog
+	}
+
+	// Populate attachments for all issues
+	for _, issue := range issues {
+		attachments, err := store.GetAttachments(ctx, issue.ID)
+		if err != nil {
+			return fmt.Errorf("failed to get attachments for %s: %w", issue.ID, err)
+		}
+		issue.Attachments = attachments
+	}
+
 	// Create temp file for atomic write
 	dir := filepath.Dir(jsonlPath)
 	base := filepath.Base(jsonlPath)
@@ -456,7 +475,9 @@ func performExport(ctx context.Context, store storage.Storage, autoCommit, autoP
 		}
 
 		// Export to JSONL
-		if err := exportToJSONLWithStore(exportCtx, store, jsonlPath); err != nil {
+		err = exportToJSONLWithStore(exportCtx, store, jsonlPath)
+		recordDaemonSync(rpc.SyncStageExport, err)
+		if err != nil {
 			log.log("Export failed: %v", err)
 			return
 		}
@@ -497,6 +518,7 @@ func performExport(ctx context.Context, store storage.Storage, autoCommit, autoP
 			// mean the local state is authoritative and should not be merged with worktree.
 			// This is critical for delete mutations to be properly reflected in the sync branch.
 			committed, err := syncBranchCommitAndPushWithOptions(exportCtx, store, autoPush, true, log)
+			recordDaemonSync(rpc.SyncStageCommit, err)
 			if err != nil {
 				log.log("Sync branch commit failed: %v", err)
 				return
@@ -515,7 +537,9 @@ func performExport(ctx context.Context, store storage.Storage, autoCommit, autoP
 
 				if hasChanges {
 					message := fmt.Sprintf("bd daemon export: %s", time.Now().Format("2006-01-02 15:04:05"))
-					if err := gitCommit(exportCtx, jsonlPath, message); err != nil {
+					err := gitCommit(exportCtx, jsonlPath, message)
+					recordDaemonSync(rpc.SyncStageCommit, err)
+					if err != nil {
 						log.log("Commit failed: %v", err)
 						return
 					}
@@ -528,7 +552,9 @@ func performExport(ctx context.Context, store storage.Storage, autoCommit, autoP
 					// Auto-push if enabled (GH#872: use sync.remote config)
 					if autoPush {
 						configuredRemote, _ := store.GetConfig(exportCtx, "sync.remote")
-						if err := gitPush(exportCtx, configuredRemote); err != nil {
+						err := gitPush(exportCtx, configuredRemote)
+						recordDaemonSync(rpc.SyncStagePush, err)
+						if err != nil {
 							log.log("Push failed: %v", err)
 							return
 						}
@@ -637,6 +663,7 @@ func performAutoImport(ctx context.Context, store storage.Storage, skipGit bool,
 
 			// Try sync branch first
 			pulled, err := syncBranchPull(importCtx, store, log)
+			recordDaemonSync(rpc.SyncStagePull, err)
 			if err != nil {
 				backoff := RecordSyncFailure(beadsDir, err.Error())
 				log.log("Sync branch pull failed: %v (backoff: %v)", err, backoff)
@@ -646,7 +673,9 @@ func performAutoImport(ctx context.Context, store storage.Storage, skipGit bool,
 			// If sync branch not configured, use regular pull (GH#872: use sync.remote config)
 			if !pulled {
 				configuredRemote, _ := store.GetConfig(importCtx, "sync.remote")
-				if err := gitPull(importCtx, configuredRemote); err != nil {
+				err := gitPull(importCtx, configuredRemote)
+				recordDaemonSync(rpc.SyncStagePull, err)
+				if err != nil {
 					backoff := RecordSyncFailure(beadsDir, err.Error())
 					log.log("Pull failed: %v (backoff: %v)", err, backoff)
 					return
@@ -663,7 +692,9 @@ func performAutoImport(ctx context.Context, store storage.Storage, skipGit bool,
 		}
 
 		// Import from JSONL
-		if err := importToJSONLWithStore(importCtx, store, jsonlPath); err != nil {
+		err = importToJSONLWithStore(importCtx, store, jsonlPath)
+		recordDaemonSync(rpc.SyncStageImport, err)
+		if err != nil {
 			log.log("Import failed: %v", err)
 			return
 		}
@@ -759,7 +790,9 @@ func performSync(ctx context.Context, store storage.Storage, autoCommit, autoPus
 			log.log("Found %d orphaned dependencies: %v", len(orphaned), orphaned)
 		}
 
-		if err := exportToJSONLWithStore(syncCtx, store, jsonlPath); err != nil {
+		err = exportToJSONLWithStore(syncCtx, store, jsonlPath)
+		recordDaemonSync(rpc.SyncStageExport, err)
+		if err != nil {
 			log.log("Export failed: %v", err)
 			return
 		}
@@ -825,6 +858,7 @@ func performSync(ctx context.Context, store storage.Storage, autoCommit, autoPus
 		if autoCommit {
 			// Try sync branch commit first
 			committed, err := syncBranchCommitAndPush(syncCtx, store, autoPush, log)
+			recordDaemonSync(rpc.SyncStageCommit, err)
 			if err != nil {
 				log.log("Sync branch commit failed: %v", err)
 				return
@@ -840,7 +874,9 @@ func performSync(ctx context.Context, store storage.Storage, autoCommit, autoPus
 
 				if hasChanges {
 					message := fmt.Sprintf("bd daemon sync: %s", time.Now().Format("2006-01-02 15:04:05"))
-					if err := gitCommit(syncCtx, jsonlPath, message); err != nil {
+					err := gitCommit(syncCtx, jsonlPath, message)
+					recordDaemonSync(rpc.SyncStageCommit, err)
+					if err != nil {
 						log.log("Commit failed: %v", err)
 						return
 					}
@@ -854,6 +890,7 @@ func performSync(ctx context.Context, store storage.Storage, autoCommit, autoPus
 
 		// Pull (try sync branch first)
 		pulled, err := syncBranchPull(syncCtx, store, log)
+		recordDaemonSync(rpc.SyncStagePull, err)
 		if err != nil {
 			log.log("Sync branch pull failed: %v", err)
 			return
@@ -862,7 +899,9 @@ func performSync(ctx context.Context, store storage.Storage, autoCommit, autoPus
 		// If sync branch not configured, use regular pull (GH#872: use sync.remote config)
 		if !pulled {
 			configuredRemote, _ := store.GetConfig(syncCtx, "sync.remote")
-			if err := gitPull(syncCtx, configuredRemote); err != nil {
+			err := gitPull(syncCtx, configuredRemote)
+			recordDaemonSync(rpc.SyncStagePull, err)
+			if err != nil {
 				log.log("Pull failed: %v", err)
 				return
 			}
@@ -895,7 +934,9 @@ func performSync(ctx context.Context, store storage.Storage, autoCommit, autoPus
 			}
 		}
 
-		if err := importToJSONLWithStore(syncCtx, store, jsonlPath); err != nil {
+		err = importToJSONLWithStore(syncCtx, store, jsonlPath)
+		recordDaemonSync(rpc.SyncStageImport, err)
+		if err != nil {
 			log.log("Import failed: %v", err)
 			return
 		}
@@ -955,7 +996,9 @@ func performSync(ctx context.Context, store storage.Storage, autoCommit, autoPus
 		// GH#872: use sync.remote config
 		if autoPush && autoCommit {
 			configuredRemote, _ := store.GetConfig(syncCtx, "sync.remote")
-			if err := gitPush(syncCtx, configuredRemote); err != nil {
+			err := gitPush(syncCtx, configuredRemote)
+			recordDaemonSync(rpc.SyncStagePush, err)
+			if err != nil {
 				log.log("Push failed: %v", err)
 				return
 			}
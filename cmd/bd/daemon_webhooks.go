@@ -0,0 +1,64 @@
+package main
+
+import (
+	"context"
+	"path/filepath"
+
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/rpc"
+	"github.com/steveyegge/beads/internal/webhooks"
+)
+
+// webhooksDir returns the webhook delivery store directory for a .beads dir.
+func webhooksDir(beadsDir string) string {
+	return filepath.Join(beadsDir, "webhooks")
+}
+
+// webhookEventFromMutation converts a daemon mutation event to a webhook payload.
+func webhookEventFromMutation(m rpc.MutationEvent, workspacePath string) webhooks.Event {
+	return webhooks.Event{
+		Type:      m.Type,
+		IssueID:   m.IssueID,
+		Title:     m.Title,
+		Assignee:  m.Assignee,
+		Actor:     m.Actor,
+		Timestamp: m.Timestamp,
+		OldStatus: m.OldStatus,
+		NewStatus: m.NewStatus,
+		ParentID:  m.ParentID,
+		StepCount: m.StepCount,
+		Workspace: workspacePath,
+	}
+}
+
+// startWebhookDispatcher subscribes the configured webhook endpoints to the
+// server's mutation stream and starts the delivery loop. Does nothing when
+// no endpoints are configured. Deliveries left in the queue by a previous
+// daemon run are retried on startup.
+func startWebhookDispatcher(ctx context.Context, server *rpc.Server, beadsDir, workspacePath string, log daemonLogger) {
+	cfg := config.GetWebhookConfig()
+	if len(cfg.Endpoints) == 0 {
+		return
+	}
+
+	store, err := webhooks.NewStore(webhooksDir(beadsDir))
+	if err != nil {
+		log.Error("webhooks disabled", "error", err)
+		return
+	}
+
+	webhooks.UserAgent = "beads-webhooks/" + Version
+	dispatcher := webhooks.NewDispatcher(store, cfg)
+	dispatcher.Logger = log.logger
+
+	// Enqueue is one small atomic file write per endpoint; doing it inline
+	// means no event is lost between the mutation and the persistent queue.
+	server.OnMutationSync(func(m rpc.MutationEvent) {
+		if _, err := dispatcher.Enqueue(webhookEventFromMutation(m, workspacePath)); err != nil {
+			log.Warn("failed to queue webhook", "event", m.Type, "issue", m.IssueID, "error", err)
+		}
+	})
+
+	go dispatcher.Run(ctx, webhooks.DefaultPollInterval)
+	log.Info("webhooks enabled", "endpoints", len(cfg.Endpoints))
+}
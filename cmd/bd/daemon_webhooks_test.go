@@ -0,0 +1,35 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/rpc"
+)
+
+func TestWebhookEventFromMutation(t *testing.T) {
+	ts := time.Date(2026, 3, 4, 5, 6, 7, 0, time.UTC)
+	event := webhookEventFromMutation(rpc.MutationEvent{
+		Type:      rpc.MutationStatus,
+		IssueID:   "bd-42",
+		Title:     "Fix login timeout",
+		Assignee:  "alice",
+		Actor:     "bob",
+		Timestamp: ts,
+		OldStatus: "open",
+		NewStatus: "in_progress",
+	}, "/work/repo")
+
+	if event.Type != rpc.MutationStatus || event.IssueID != "bd-42" || event.Title != "Fix login timeout" {
+		t.Errorf("identity fields not copied: %+v", event)
+	}
+	if event.Assignee != "alice" || event.Actor != "bob" || !event.Timestamp.Equal(ts) {
+		t.Errorf("context fields not copied: %+v", event)
+	}
+	if event.OldStatus != "open" || event.NewStatus != "in_progress" {
+		t.Errorf("status fields not copied: %+v", event)
+	}
+	if event.Workspace != "/work/repo" {
+		t.Errorf("Workspace = %q, want /work/repo", event.Workspace)
+	}
+}
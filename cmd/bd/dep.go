@@ -4,6 +4,7 @@ package main
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
@@ -866,6 +867,7 @@ func getStatusEmoji(status types.Status) string {
 
 // treeRenderer holds state for rendering a tree with proper connectors
 type treeRenderer struct {
+	w io.Writer
 	// Track which nodes we've already displayed (for "shown above" handling)
 	seen map[string]bool
 	// Track connector state at each depth level (true = has more siblings)
@@ -878,11 +880,17 @@ type treeRenderer struct {
 
 // renderTree renders the tree with proper box-drawing connectors
 func renderTree(tree []*types.TreeNode, maxDepth int, direction string) {
+	writeTree(os.Stdout, tree, maxDepth, direction)
+}
+
+// writeTree renders the tree like renderTree, writing to w
+func writeTree(w io.Writer, tree []*types.TreeNode, maxDepth int, direction string) {
 	if len(tree) == 0 {
 		return
 	}
 
 	r := &treeRenderer{
+		w:                w,
 		seen:             make(map[string]bool),
 		activeConnectors: make([]bool, maxDepth+1),
 		maxDepth:         maxDepth,
@@ -938,7 +946,7 @@ func (r *treeRenderer) renderNode(node *types.TreeNode, children map[string][]*t
 
 	// Check if we've seen this node before (diamond dependency)
 	if r.seen[node.ID] {
-		fmt.Printf("%s%s (shown above)\n", prefix.String(), ui.RenderMuted(node.ID))
+		fmt.Fprintf(r.w, "%s%s (shown above)\n", prefix.String(), ui.RenderMuted(node.ID))
 		return
 	}
 	r.seen[node.ID] = true
@@ -951,7 +959,7 @@ func (r *treeRenderer) renderNode(node *types.TreeNode, children map[string][]*t
 		line += ui.RenderWarn(" …")
 	}
 
-	fmt.Printf("%s%s\n", prefix.String(), line)
+	fmt.Fprintf(r.w, "%s%s\n", prefix.String(), line)
 
 	// Render children
 	nodeChildren := children[node.ID]
@@ -11,10 +11,11 @@ import (
 var diffCmd = &cobra.Command{
 	Use:     "diff <from-ref> <to-ref>",
 	GroupID: "views",
-	Short:   "Show changes between two commits or branches (requires Dolt backend)",
+	Short:   "Show changes between two commits or branches",
 	Long: `Show the differences in issues between two commits or branches.
 
-This command requires the Dolt storage backend. The refs can be:
+With the Dolt backend the refs name Dolt commits; with SQLite they name git
+commits, and the issues are compared as exported to JSONL. The refs can be:
 - Commit hashes (e.g., abc123def)
 - Branch names (e.g., main, feature-branch)
 - Special refs like HEAD, HEAD~1
@@ -29,14 +30,10 @@ Examples:
 		fromRef := args[0]
 		toRef := args[1]
 
-		// Check if storage supports versioning
-		vs, ok := storage.AsVersioned(store)
-		if !ok {
-			FatalErrorRespectJSON("diff requires Dolt backend (current backend does not support versioning)")
-		}
+		hs := requireHistoryStorage("diff")
 
 		// Get diff between refs
-		entries, err := vs.Diff(ctx, fromRef, toRef)
+		entries, err := hs.Diff(ctx, fromRef, toRef)
 		if err != nil {
 			FatalErrorRespectJSON("failed to get diff: %v", err)
 		}
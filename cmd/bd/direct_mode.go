@@ -7,13 +7,14 @@ import (
 
 	"github.com/steveyegge/beads/internal/beads"
 	"github.com/steveyegge/beads/internal/debug"
+	"github.com/steveyegge/beads/internal/rpc"
 	"github.com/steveyegge/beads/internal/storage/sqlite"
 )
 
 // ensureDirectMode makes sure the CLI is operating in direct-storage mode.
 // If the daemon is active, it is cleanly disconnected and the shared store is opened.
 func ensureDirectMode(reason string) error {
-	if getDaemonClient() != nil {
+	if connectedDaemonClient() != nil {
 		if err := fallbackToDirectMode(reason); err != nil {
 			return err
 		}
@@ -22,6 +23,16 @@ func ensureDirectMode(reason string) error {
 	return ensureStoreActive()
 }
 
+// connectedDaemonClient returns the daemon client, if any. The command
+// context is only synced once PersistentPreRun finishes, which it doesn't
+// when the daemon connects, so the global is checked too.
+func connectedDaemonClient() *rpc.Client {
+	if client := getDaemonClient(); client != nil {
+		return client
+	}
+	return daemonClient
+}
+
 // fallbackToDirectMode disables the daemon client and ensures a local store is ready.
 func fallbackToDirectMode(reason string) error {
 	disableDaemonForFallback(reason)
@@ -30,7 +41,7 @@ func fallbackToDirectMode(reason string) error {
 
 // disableDaemonForFallback closes the daemon client and updates status metadata.
 func disableDaemonForFallback(reason string) {
-	if client := getDaemonClient(); client != nil {
+	if client := connectedDaemonClient(); client != nil {
 		_ = client.Close()
 		setDaemonClient(nil)
 	}
@@ -274,3 +274,33 @@ func TestImportFromJSONLInlineAfterDaemonDisconnect(t *testing.T) {
 		t.Errorf("expected title 'Test Issue', got %q", imported.Title)
 	}
 }
+
+// TestDisableDaemonBeforeContextSync covers falling back while the command
+// context is not synced yet: PersistentPreRun returns early after connecting
+// to the daemon, so only the global holds the client.
+func TestDisableDaemonBeforeContextSync(t *testing.T) {
+	origCmdCtx := cmdCtx
+	origUseGlobals := testModeUseGlobals
+	origDaemonClient := daemonClient
+	origDaemonStatus := daemonStatus
+	defer func() {
+		cmdCtx = origCmdCtx
+		testModeUseGlobals = origUseGlobals
+		daemonClient = origDaemonClient
+		daemonStatus = origDaemonStatus
+	}()
+
+	cmdCtx = &CommandContext{}
+	testModeUseGlobals = false
+	daemonClient = &rpc.Client{}
+	daemonStatus = DaemonStatus{}
+
+	disableDaemonForFallback("test fallback")
+
+	if daemonClient != nil {
+		t.Error("expected the global daemon client to be cleared")
+	}
+	if ds := getDaemonStatus(); ds.Mode != "direct" || ds.Connected {
+		t.Errorf("daemon status = %+v, want direct mode", ds)
+	}
+}
@@ -373,7 +373,7 @@ func checkMetadataConfigValues(repoPath string) []string {
 	// Validate jsonl_export filename
 	if cfg.JSONLExport != "" {
 		switch cfg.JSONLExport {
-		case "deletions.jsonl", "interactions.jsonl", "molecules.jsonl":
+		case "deletions.jsonl", "interactions.jsonl", "molecules.jsonl", "views.jsonl":
 			issues = append(issues, fmt.Sprintf("metadata.json jsonl_export: %q is a system file and should not be configured as a JSONL export (expected issues.jsonl)", cfg.JSONLExport))
 		}
 		if strings.Contains(cfg.JSONLExport, string(os.PathSeparator)) || strings.Contains(cfg.JSONLExport, "/") {
@@ -27,6 +27,12 @@ bd.sock
 sync-state.json
 last-touched
 
+# Webhook delivery queue and dead letters (local-only, per-machine)
+webhooks/
+
+# HTTP gateway bearer token (secret, per-machine)
+http-token
+
 # Local version tracking (prevents upgrade notification spam after git ops)
 .local_version
 
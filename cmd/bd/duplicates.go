@@ -5,6 +5,8 @@ import (
 	"regexp"
 	"strings"
 	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/search"
 	"github.com/steveyegge/beads/internal/types"
 	"github.com/steveyegge/beads/internal/ui"
 )
@@ -18,13 +20,33 @@ The merge target is chosen by:
 1. Reference count (most referenced issue wins)
 2. Lexicographically smallest ID if reference counts are equal
 Only groups issues with matching status (open with open, closed with closed).
+
+With --similar, near-duplicates are found as well: issues are compared by
+TF-IDF cosine similarity over title and description words and character
+trigrams, and issues scoring at least --threshold (default from
+duplicates.similarity-threshold, 0.35) are clustered together. Each
+duplicate is shown with its score and the terms it shares with the target.
+Clusters can chain (A~B~C), so only members at least --threshold similar
+to the merge target itself are suggested for merging or auto-merged.
+
 Example:
   bd duplicates                    # Show all duplicate groups
   bd duplicates --auto-merge       # Automatically merge all duplicates
-  bd duplicates --dry-run          # Show what would be merged`,
+  bd duplicates --dry-run          # Show what would be merged
+  bd duplicates --similar          # Include near-duplicates
+  bd duplicates --similar --threshold 0.5  # Only strong near-duplicates`,
 	Run: func(cmd *cobra.Command, _ []string) {
 		autoMerge, _ := cmd.Flags().GetBool("auto-merge")
 		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		similar, _ := cmd.Flags().GetBool("similar")
+		threshold := similarityThreshold()
+		if cmd.Flags().Changed("threshold") {
+			threshold, _ = cmd.Flags().GetFloat64("threshold")
+		}
+		if similar && (threshold <= 0 || threshold > 1) {
+			fmt.Fprintf(os.Stderr, "Error: --threshold must be between 0 and 1 (got %g)\n", threshold)
+			os.Exit(1)
+		}
 		// Block writes in readonly mode (merging modifies data)
 		if autoMerge && !dryRun {
 			CheckReadonly("duplicates --auto-merge")
@@ -61,7 +83,16 @@ Example:
 		}
 	}
 	// Find duplicates (only among open issues)
-	duplicateGroups := findDuplicateGroups(openIssues)
+	var duplicateGroups [][]*types.Issue
+	var corpus *search.Corpus
+	if similar {
+		// Pairs never pairs issues with different statuses, so clusters
+		// stay within one status like the exact groups below
+		corpus = search.NewCorpus(openIssues)
+		duplicateGroups = search.Cluster(corpus.Pairs(threshold))
+	} else {
+		duplicateGroups = findDuplicateGroups(openIssues)
+	}
 		if len(duplicateGroups) == 0 {
 			if !jsonOutput {
 				fmt.Println("No duplicates found!")
@@ -82,16 +113,13 @@ Example:
 		var mergeResults []map[string]interface{}
 		for _, group := range duplicateGroups {
 			target := chooseMergeTarget(group, refCounts, structuralScores)
-			sources := make([]string, 0, len(group)-1)
-			for _, issue := range group {
-				if issue.ID != target.ID {
-					sources = append(sources, issue.ID)
-				}
+			sources := mergeSources(group, target, corpus, threshold)
+			if len(sources) == 0 {
+				continue
 			}
 			// Generate actionable command suggestion
-			cmd := fmt.Sprintf("# Duplicate: %s (same content as %s)\n# Suggested action: bd close %s && bd dep add %s %s --type related",
-				strings.Join(sources, " "),
-				target.ID,
+			cmd := fmt.Sprintf("# %s\n# Suggested action: bd close %s && bd dep add %s %s --type related",
+				duplicateNote(sources, target.ID, corpus != nil),
 				strings.Join(sources, " "),
 				strings.Join(sources, " "),
 				target.ID)
@@ -112,7 +140,7 @@ Example:
 		if jsonOutput {
 			output := map[string]interface{}{
 				"duplicate_groups": len(duplicateGroups),
-				"groups":           formatDuplicateGroupsJSON(duplicateGroups, refCounts, structuralScores),
+				"groups":           formatDuplicateGroupsJSON(duplicateGroups, refCounts, structuralScores, corpus, threshold),
 			}
 			if autoMerge || dryRun {
 				output["merge_commands"] = mergeCommands
@@ -138,14 +166,22 @@ Example:
 					}
 					fmt.Printf("%s%s (%s, P%d, weight=%d, %d refs)\n",
 						marker, issue.ID, issue.Status, issue.Priority, weight, refs)
-				}
-				sources := make([]string, 0, len(group)-1)
-				for _, issue := range group {
-					if issue.ID != target.ID {
-						sources = append(sources, issue.ID)
+					if corpus != nil && issue.ID != target.ID {
+						if sim := corpus.Compare(issue.ID, target.ID); sim != nil {
+							explanation := sim.Explain()
+							if sim.Score < threshold {
+								explanation += "; linked through another issue, not merged"
+							}
+							fmt.Printf("    %s %s\n", ui.RenderMuted("~"), ui.RenderMuted(explanation))
+						}
 					}
 				}
-				fmt.Printf("  %s Duplicate: %s (same content as %s)\n", ui.RenderAccent("Note:"), strings.Join(sources, " "), target.ID)
+				sources := mergeSources(group, target, corpus, threshold)
+				if len(sources) == 0 {
+					fmt.Printf("  %s no issue is similar enough to %s to merge\n\n", ui.RenderAccent("Note:"), target.ID)
+					continue
+				}
+				fmt.Printf("  %s %s\n", ui.RenderAccent("Note:"), duplicateNote(sources, target.ID, corpus != nil))
 				fmt.Printf("  %s bd close %s && bd dep add %s %s --type related\n\n",
 					ui.RenderAccent("Suggested:"), strings.Join(sources, " "), strings.Join(sources, " "), target.ID)
 			}
@@ -164,6 +200,8 @@ Example:
 func init() {
 	duplicatesCmd.Flags().Bool("auto-merge", false, "Automatically merge all duplicates")
 	duplicatesCmd.Flags().Bool("dry-run", false, "Show what would be merged without making changes")
+	duplicatesCmd.Flags().Bool("similar", false, "Also find near-duplicates by text similarity")
+	duplicatesCmd.Flags().Float64("threshold", search.DefaultSimilarityThreshold, "Minimum similarity score (0-1) for --similar (default from duplicates.similarity-threshold)")
 	rootCmd.AddCommand(duplicatesCmd)
 }
 // contentKey represents the fields we use to identify duplicate issues
@@ -196,6 +234,45 @@ func findDuplicateGroups(issues []*types.Issue) [][]*types.Issue {
 	}
 	return duplicates
 }
+// similarityThreshold returns the configured near-duplicate threshold,
+// falling back to the default when unset or out of range.
+func similarityThreshold() float64 {
+	threshold := config.GetFloat64("duplicates.similarity-threshold")
+	if threshold <= 0 || threshold > 1 {
+		return search.DefaultSimilarityThreshold
+	}
+	return threshold
+}
+
+// duplicateNote describes why sources are considered duplicates of the target
+func duplicateNote(sources []string, targetID string, similar bool) string {
+	relation := "same content as"
+	if similar {
+		relation = "similar to"
+	}
+	return fmt.Sprintf("Duplicate: %s (%s %s)", strings.Join(sources, " "), relation, targetID)
+}
+
+// mergeSources returns the group members to merge into target. Similarity
+// clusters are single-linkage, so A~B~C can group A with an unrelated C;
+// with a corpus, only members at least threshold similar to the target
+// itself are merged.
+func mergeSources(group []*types.Issue, target *types.Issue, corpus *search.Corpus, threshold float64) []string {
+	sources := make([]string, 0, len(group)-1)
+	for _, issue := range group {
+		if issue.ID == target.ID {
+			continue
+		}
+		if corpus != nil {
+			if sim := corpus.Compare(issue.ID, target.ID); sim == nil || sim.Score < threshold {
+				continue
+			}
+		}
+		sources = append(sources, issue.ID)
+	}
+	return sources
+}
+
 // issueScore captures all factors used to choose which duplicate to keep
 type issueScore struct {
 	dependentCount int // Issues that depend on this one (children, blocked-by) - highest priority
@@ -312,8 +389,10 @@ func chooseMergeTarget(group []*types.Issue, refCounts map[string]int, structura
 	}
 	return target
 }
-// formatDuplicateGroupsJSON formats duplicate groups for JSON output
-func formatDuplicateGroupsJSON(groups [][]*types.Issue, refCounts map[string]int, structuralScores map[string]*issueScore) []map[string]interface{} {
+// formatDuplicateGroupsJSON formats duplicate groups for JSON output.
+// When corpus is non-nil (--similar), each non-target issue includes its
+// similarity to the target.
+func formatDuplicateGroupsJSON(groups [][]*types.Issue, refCounts map[string]int, structuralScores map[string]*issueScore, corpus *search.Corpus, threshold float64) []map[string]interface{} {
 	var result []map[string]interface{}
 	for _, group := range groups {
 		target := chooseMergeTarget(group, refCounts, structuralScores)
@@ -336,21 +415,24 @@ func formatDuplicateGroupsJSON(groups [][]*types.Issue, refCounts map[string]int
 				"weight":          dependents + dependencies,
 				"is_merge_target": issue.ID == target.ID,
 			}
-		}
-		sources := make([]string, 0, len(group)-1)
-		for _, issue := range group {
-			if issue.ID != target.ID {
-				sources = append(sources, issue.ID)
+			if corpus != nil && issue.ID != target.ID {
+				if sim := corpus.Compare(issue.ID, target.ID); sim != nil {
+					issues[i]["similarity"] = sim
+				}
 			}
 		}
-		result = append(result, map[string]interface{}{
+		sources := mergeSources(group, target, corpus, threshold)
+		entry := map[string]interface{}{
 			"title":             group[0].Title,
 			"issues":            issues,
 			"suggested_target":  target.ID,
 			"suggested_sources": sources,
-			"suggested_action":  fmt.Sprintf("bd close %s && bd dep add %s %s --type related", strings.Join(sources, " "), strings.Join(sources, " "), target.ID),
-			"note":              fmt.Sprintf("Duplicate: %s (same content as %s)", strings.Join(sources, " "), target.ID),
-		})
+		}
+		if len(sources) > 0 {
+			entry["suggested_action"] = fmt.Sprintf("bd close %s && bd dep add %s %s --type related", strings.Join(sources, " "), strings.Join(sources, " "), target.ID)
+			entry["note"] = duplicateNote(sources, target.ID, corpus != nil)
+		}
+		result = append(result, entry)
 	}
 	return result
 }
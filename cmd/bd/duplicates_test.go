@@ -2,8 +2,10 @@ package main
 
 import (
 	"context"
+	"math"
 	"testing"
 
+	"github.com/steveyegge/beads/internal/search"
 	"github.com/steveyegge/beads/internal/types"
 )
 
@@ -331,6 +333,140 @@ func TestDuplicatesIntegration(t *testing.T) {
 	}
 }
 
+func TestSimilarDuplicateGroups(t *testing.T) {
+	issues := []*types.Issue{
+		{ID: "bd-1", Title: "Fix login timeout", Status: types.StatusOpen},
+		{ID: "bd-2", Title: "login times out after 30s", Status: types.StatusOpen},
+		{ID: "bd-3", Title: "Add dark mode to settings page", Status: types.StatusOpen},
+		{ID: "bd-4", Title: "Update README installation steps", Status: types.StatusOpen},
+		{ID: "bd-5", Title: "Crash when exporting empty database", Status: types.StatusOpen},
+	}
+
+	// Exact matching misses the reworded duplicate
+	if groups := findDuplicateGroups(issues); len(groups) != 0 {
+		t.Fatalf("findDuplicateGroups() returned %d groups, want 0", len(groups))
+	}
+
+	corpus := search.NewCorpus(issues)
+	groups := search.Cluster(corpus.Pairs(search.DefaultSimilarityThreshold))
+	if len(groups) != 1 || len(groups[0]) != 2 {
+		t.Fatalf("expected one near-duplicate pair, got %v", groups)
+	}
+
+	target := chooseMergeTarget(groups[0], map[string]int{"bd-2": 1}, map[string]*issueScore{})
+	if target.ID != "bd-2" {
+		t.Errorf("chooseMergeTarget() = %s, want bd-2", target.ID)
+	}
+
+	formatted := formatDuplicateGroupsJSON(groups, map[string]int{"bd-2": 1}, map[string]*issueScore{}, corpus, search.DefaultSimilarityThreshold)
+	if len(formatted) != 1 {
+		t.Fatalf("formatDuplicateGroupsJSON() returned %d groups, want 1", len(formatted))
+	}
+	if note := formatted[0]["note"]; note != "Duplicate: bd-1 (similar to bd-2)" {
+		t.Errorf("note = %q", note)
+	}
+	for _, issue := range formatted[0]["issues"].([]map[string]interface{}) {
+		_, hasSimilarity := issue["similarity"]
+		if isTarget := issue["is_merge_target"].(bool); isTarget == hasSimilarity {
+			t.Errorf("issue %v: similarity should be reported for non-targets only", issue["id"])
+		}
+	}
+}
+
+func TestSimilarDuplicateMergeSourcesChain(t *testing.T) {
+	issues := []*types.Issue{
+		{ID: "bd-1", Title: "Login timeout on slow networks", Status: types.StatusOpen},
+		{ID: "bd-2", Title: "Login timeout when exporting database", Status: types.StatusOpen},
+		{ID: "bd-3", Title: "Crash when exporting database", Status: types.StatusOpen},
+		{ID: "bd-4", Title: "Update README installation steps", Status: types.StatusOpen},
+	}
+
+	// bd-1~bd-2 and bd-2~bd-3 chain all three into one cluster, though
+	// bd-1 and bd-3 share nothing
+	corpus := search.NewCorpus(issues)
+	groups := search.Cluster(corpus.Pairs(search.DefaultSimilarityThreshold))
+	if len(groups) != 1 || len(groups[0]) != 3 {
+		t.Fatalf("expected one chained cluster of 3, got %v", groups)
+	}
+
+	target := chooseMergeTarget(groups[0], map[string]int{"bd-1": 1}, map[string]*issueScore{})
+	if target.ID != "bd-1" {
+		t.Fatalf("chooseMergeTarget() = %s, want bd-1", target.ID)
+	}
+	sources := mergeSources(groups[0], target, corpus, search.DefaultSimilarityThreshold)
+	if len(sources) != 1 || sources[0] != "bd-2" {
+		t.Errorf("mergeSources() = %v, want [bd-2]", sources)
+	}
+
+	// Exact groups merge every member
+	if sources := mergeSources(groups[0], target, nil, search.DefaultSimilarityThreshold); len(sources) != 2 {
+		t.Errorf("mergeSources() without a corpus = %v, want both other members", sources)
+	}
+}
+
+func TestSimilarDuplicateGroupsSameStatus(t *testing.T) {
+	issues := []*types.Issue{
+		{ID: "bd-1", Title: "Fix login timeout", Status: types.StatusOpen},
+		{ID: "bd-2", Title: "Fix login timeout", Status: types.StatusInProgress},
+		{ID: "bd-3", Title: "login times out after 30s", Status: types.StatusInProgress},
+		{ID: "bd-4", Title: "Add dark mode to settings page", Status: types.StatusOpen},
+		{ID: "bd-5", Title: "Update README installation steps", Status: types.StatusOpen},
+		{ID: "bd-6", Title: "Crash when exporting empty database", Status: types.StatusOpen},
+	}
+	groups := search.Cluster(search.NewCorpus(issues).Pairs(search.DefaultSimilarityThreshold))
+	if len(groups) != 1 || groups[0][0].ID != "bd-2" || groups[0][1].ID != "bd-3" {
+		t.Fatalf("expected only the in_progress pair to cluster, got %v", groups)
+	}
+}
+
+func TestFindSimilarOpenIssues(t *testing.T) {
+	tmpDir := t.TempDir()
+	testStore := newTestStore(t, tmpDir+"/.beads/beads.db")
+	ctx := context.Background()
+
+	oldStore := store
+	store = testStore
+	defer func() { store = oldStore }()
+
+	for _, issue := range []*types.Issue{
+		{Title: "Fix login timeout", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeBug},
+		{Title: "Add dark mode to settings page", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeFeature},
+		{Title: "Update README installation steps", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask},
+	} {
+		if err := testStore.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+
+	matches, err := findSimilarOpenIssues(ctx, "login times out after 30s", "")
+	if err != nil {
+		t.Fatalf("findSimilarOpenIssues failed: %v", err)
+	}
+	if len(matches) != 1 || matches[0].B.Title != "Fix login timeout" {
+		t.Fatalf("expected login timeout issue to match, got %d matches", len(matches))
+	}
+
+	// The score matches what bd duplicates --similar computes over every
+	// open issue, not just the full-text candidates
+	open, err := testStore.SearchIssues(ctx, "", types.IssueFilter{})
+	if err != nil {
+		t.Fatalf("SearchIssues failed: %v", err)
+	}
+	candidate := &types.Issue{Title: "login times out after 30s", Status: types.StatusOpen}
+	want := search.NewCorpus(append([]*types.Issue{candidate}, open...)).Similar(0, 0, 1)[0].Score
+	if math.Abs(matches[0].Score-want) > 1e-9 {
+		t.Errorf("score = %f, want %f as computed over all open issues", matches[0].Score, want)
+	}
+
+	matches, err = findSimilarOpenIssues(ctx, "Support webhooks", "")
+	if err != nil {
+		t.Fatalf("findSimilarOpenIssues failed: %v", err)
+	}
+	if len(matches) != 0 {
+		t.Errorf("expected no matches for unrelated title, got %d", len(matches))
+	}
+}
+
 func TestPerformMerge(t *testing.T) {
 	tmpDir := t.TempDir()
 	testStore := newTestStore(t, tmpDir+"/.beads/beads.db")
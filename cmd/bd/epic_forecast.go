@@ -0,0 +1,722 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+var epicForecastCmd = &cobra.Command{
+	Use:   "forecast <epic-id>",
+	Short: "Forecast when an epic will finish",
+	Long: `Forecast an epic's finish from its children's estimates and blocking deps.
+
+The epic's open tasks and the blocking dependencies between them form a DAG.
+Tasks are the epic's children and, for a nested sub-epic, its children in
+turn; a blocker on a sub-epic holds up each of its tasks. From the DAG the
+forecast computes:
+
+  - the critical path: the longest chain of dependent work, i.e. the earliest
+    the epic can finish with unlimited workers
+  - slack per task: how long a task can slip without delaying that finish
+  - a schedule for a fixed team, either --workers N interchangeable workers or
+    one worker per distinct assignee (--assignees), where assigned tasks stay
+    with their assignee
+
+A task takes its estimate (bd update --estimate) minus the time already
+logged on it (bd time). Tasks without an estimate are flagged and counted as
+--default-estimate minutes. Closed tasks are done and do not delay
+anything. Blockers outside the epic are reported and assumed resolved.
+
+Examples:
+  bd epic forecast bd-42                  # One worker
+  bd epic forecast bd-42 --workers 3      # Three parallel workers
+  bd epic forecast bd-42 --assignees      # One lane per assignee
+  bd epic forecast bd-42 --default-estimate 120 --json`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		workers, _ := cmd.Flags().GetInt("workers")
+		byAssignee, _ := cmd.Flags().GetBool("assignees")
+		defaultEstimate, _ := cmd.Flags().GetInt("default-estimate")
+		hoursPerDay, _ := cmd.Flags().GetFloat64("hours-per-day")
+
+		if byAssignee && cmd.Flags().Changed("workers") {
+			FatalErrorRespectJSON("--workers and --assignees cannot be combined")
+		}
+		if workers < 1 {
+			FatalErrorRespectJSON("--workers must be at least 1")
+		}
+		if defaultEstimate < 0 {
+			FatalErrorRespectJSON("--default-estimate cannot be negative")
+		}
+		if hoursPerDay <= 0 || hoursPerDay > 24 {
+			FatalErrorRespectJSON("--hours-per-day must be between 0 and 24")
+		}
+
+		if err := ensureDirectMode("epic forecast requires direct database access"); err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		ctx := rootCtx
+		if err := ensureDatabaseFresh(ctx); err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+
+		epicID, err := utils.ResolvePartialID(ctx, store, args[0])
+		if err != nil {
+			FatalErrorRespectJSON("epic '%s' not found: %v", args[0], err)
+		}
+		epic, err := store.GetIssue(ctx, epicID)
+		if err != nil {
+			FatalErrorRespectJSON("failed to get epic: %v", err)
+		}
+		if epic == nil {
+			FatalErrorRespectJSON("epic '%s' not found", epicID)
+		}
+		if epic.IssueType != types.TypeEpic && epic.IssueType != "molecule" {
+			FatalErrorRespectJSON("'%s' is not an epic or molecule (type: %s)", epicID, epic.IssueType)
+		}
+
+		input, err := loadForecastInput(ctx, epic)
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		forecast, err := buildEpicForecast(input, forecastOptions{
+			Workers:         workers,
+			ByAssignee:      byAssignee,
+			DefaultEstimate: defaultEstimate,
+		})
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		forecast.setFinishDate(time.Now(), hoursPerDay)
+
+		if jsonOutput {
+			outputJSON(forecast)
+			return
+		}
+		printEpicForecast(forecast)
+	},
+}
+
+// forecastInput is what the forecast needs from storage.
+type forecastInput struct {
+	Epic     *types.Issue
+	Children []*types.Issue
+	// Deps holds each child's dependency records.
+	Deps map[string][]*types.Dependency
+	// Outside maps blockers outside the epic to their status.
+	Outside map[string]types.Status
+	// Logged is the time logged per child, excluding running timers.
+	Logged map[string]int
+}
+
+// loadForecastInput reads the epic's tasks with a few bulk queries. The
+// tasks are the epic's descendants through parent-child links that have no
+// children of their own, so a nested sub-epic contributes its tasks, and a
+// blocking dep on or of a sub-epic applies to each of its tasks.
+func loadForecastInput(ctx context.Context, epic *types.Issue) (*forecastInput, error) {
+	allDeps, err := store.GetAllDependencyRecords(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get dependencies: %w", err)
+	}
+	tree := newEpicTree(epic.ID, allDeps)
+	input := &forecastInput{
+		Epic:    epic,
+		Deps:    tree.taskDeps(allDeps),
+		Outside: make(map[string]types.Status),
+		Logged:  make(map[string]int),
+	}
+	if len(tree.tasks) == 0 {
+		return input, nil
+	}
+
+	input.Children, err = store.SearchIssues(ctx, "", types.IssueFilter{IDs: tree.tasks})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get epic tasks: %w", err)
+	}
+
+	var outside []string
+	for _, deps := range input.Deps {
+		for _, dep := range deps {
+			if !dep.Type.AffectsReadyWork() || tree.contains(dep.DependsOnID) {
+				continue
+			}
+			if _, seen := input.Outside[dep.DependsOnID]; !seen {
+				input.Outside[dep.DependsOnID] = types.Status("unknown")
+				outside = append(outside, dep.DependsOnID)
+			}
+		}
+	}
+	if len(outside) > 0 {
+		blockers, err := store.SearchIssues(ctx, "", types.IssueFilter{IDs: outside})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get blockers outside the epic: %w", err)
+		}
+		for _, blocker := range blockers {
+			input.Outside[blocker.ID] = blocker.Status
+		}
+	}
+
+	workLog, err := store.GetWorkLogForIssues(ctx, tree.tasks)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get work log: %w", err)
+	}
+	for id, entries := range workLog {
+		for _, entry := range entries {
+			if !entry.IsRunning() {
+				input.Logged[id] += entry.Minutes
+			}
+		}
+	}
+	return input, nil
+}
+
+// epicTree is an epic's hierarchy of descendants through parent-child links.
+type epicTree struct {
+	root     string
+	children map[string][]string // parent -> children, each issue under one parent
+	parent   map[string]string   // descendant -> its parent in the tree
+	tasks    []string            // descendants without children, sorted
+	leaves   map[string][]string // memoized tasks under each issue
+}
+
+// newEpicTree walks parent-child links down from root. An issue reachable
+// along several paths is placed under the first parent found, so the tree
+// stays acyclic even if the links aren't.
+func newEpicTree(root string, allDeps map[string][]*types.Dependency) *epicTree {
+	kids := make(map[string][]string)
+	for id, deps := range allDeps {
+		for _, dep := range deps {
+			if dep.Type == types.DepParentChild {
+				kids[dep.DependsOnID] = append(kids[dep.DependsOnID], id)
+			}
+		}
+	}
+	t := &epicTree{
+		root:     root,
+		children: make(map[string][]string),
+		parent:   make(map[string]string),
+		leaves:   make(map[string][]string),
+	}
+	queue := []string{root}
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		sort.Strings(kids[id])
+		for _, kid := range kids[id] {
+			if kid == root || t.parent[kid] != "" {
+				continue
+			}
+			t.parent[kid] = id
+			t.children[id] = append(t.children[id], kid)
+			queue = append(queue, kid)
+		}
+	}
+	for id := range t.parent {
+		if len(t.children[id]) == 0 {
+			t.tasks = append(t.tasks, id)
+		}
+	}
+	sort.Strings(t.tasks)
+	return t
+}
+
+// contains reports whether id is the root or one of its descendants.
+func (t *epicTree) contains(id string) bool {
+	return id == t.root || t.parent[id] != ""
+}
+
+// tasksUnder returns the tasks at or below id.
+func (t *epicTree) tasksUnder(id string) []string {
+	if tasks, ok := t.leaves[id]; ok {
+		return tasks
+	}
+	tasks := []string{id}
+	if kids := t.children[id]; len(kids) > 0 {
+		tasks = nil
+		for _, kid := range kids {
+			tasks = append(tasks, t.tasksUnder(kid)...)
+		}
+	}
+	t.leaves[id] = tasks
+	return tasks
+}
+
+// taskDeps returns the dependencies of each task: its own plus those of the
+// sub-epics above it, with deps on a sub-epic expanded to deps on each of
+// its tasks. Parent-child links within the tree are structure, not
+// blockers, and are left out.
+func (t *epicTree) taskDeps(allDeps map[string][]*types.Dependency) map[string][]*types.Dependency {
+	result := make(map[string][]*types.Dependency, len(t.tasks))
+	for _, task := range t.tasks {
+		var deps []*types.Dependency
+		for id := task; id != t.root; id = t.parent[id] {
+			for _, dep := range allDeps[id] {
+				if dep.Type == types.DepParentChild && t.contains(dep.DependsOnID) {
+					continue
+				}
+				if dep.DependsOnID == t.root || !t.contains(dep.DependsOnID) {
+					inherited := *dep
+					inherited.IssueID = task
+					deps = append(deps, &inherited)
+					continue
+				}
+				for _, blocker := range t.tasksUnder(dep.DependsOnID) {
+					if blocker == task {
+						continue
+					}
+					expanded := *dep
+					expanded.IssueID = task
+					expanded.DependsOnID = blocker
+					deps = append(deps, &expanded)
+				}
+			}
+		}
+		result[task] = deps
+	}
+	return result
+}
+
+// forecastOptions configures the team the schedule assumes.
+type forecastOptions struct {
+	Workers         int  // interchangeable workers (ignored with ByAssignee)
+	ByAssignee      bool // one worker per distinct assignee
+	DefaultEstimate int  // minutes assumed for unestimated tasks
+}
+
+// forecastTask is one open child of the epic. Times are minutes from now.
+type forecastTask struct {
+	ID               string   `json:"id"`
+	Title            string   `json:"title"`
+	Status           string   `json:"status"`
+	Priority         int      `json:"priority"`
+	Assignee         string   `json:"assignee,omitempty"`
+	DependsOn        []string `json:"depends_on,omitempty"`
+	EstimateMinutes  *int     `json:"estimate_minutes"`
+	LoggedMinutes    int      `json:"logged_minutes,omitempty"`
+	RemainingMinutes int      `json:"remaining_minutes"`
+	NoEstimate       bool     `json:"no_estimate,omitempty"`
+	// Unlimited-worker (critical path) times
+	EarliestStart  int  `json:"earliest_start"`
+	EarliestFinish int  `json:"earliest_finish"`
+	LatestStart    int  `json:"latest_start"`
+	LatestFinish   int  `json:"latest_finish"`
+	Slack          int  `json:"slack"`
+	Critical       bool `json:"critical"`
+	// Schedule for the given workers
+	Worker string `json:"worker,omitempty"`
+	Start  int    `json:"start"`
+	Finish int    `json:"finish"`
+
+	successors []*forecastTask
+	preds      []*forecastTask
+}
+
+// epicForecast is the result of bd epic forecast.
+type epicForecast struct {
+	EpicID                 string          `json:"epic_id"`
+	EpicTitle              string          `json:"epic_title"`
+	OpenTasks              int             `json:"open_tasks"`
+	ClosedTasks            int             `json:"closed_tasks"`
+	Mode                   string          `json:"mode"` // "workers" or "assignees"
+	Workers                []string        `json:"workers"`
+	DefaultEstimateMinutes int             `json:"default_estimate_minutes"`
+	CriticalPath           []string        `json:"critical_path"`
+	CriticalPathMinutes    int             `json:"critical_path_minutes"` // earliest finish with unlimited workers
+	ForecastMinutes        int             `json:"forecast_minutes"`      // finish with the given workers
+	HoursPerDay            float64         `json:"hours_per_day"`
+	WorkingDays            float64         `json:"working_days"`
+	FinishDate             string          `json:"finish_date"`
+	Unestimated            []string        `json:"unestimated"`
+	Warnings               []string        `json:"warnings"`
+	Tasks                  []*forecastTask `json:"tasks"`
+}
+
+// buildEpicForecast computes the critical path and a schedule for the open
+// children of an epic.
+func buildEpicForecast(input *forecastInput, opts forecastOptions) (*epicForecast, error) {
+	f := &epicForecast{
+		EpicID:                 input.Epic.ID,
+		EpicTitle:              input.Epic.Title,
+		Mode:                   "workers",
+		DefaultEstimateMinutes: opts.DefaultEstimate,
+		CriticalPath:           []string{},
+		Unestimated:            []string{},
+		Warnings:               []string{},
+		Tasks:                  []*forecastTask{},
+	}
+
+	byID := make(map[string]*forecastTask)
+	for _, child := range input.Children {
+		if child.Status == types.StatusClosed {
+			f.ClosedTasks++
+			continue
+		}
+		task := &forecastTask{
+			ID:              child.ID,
+			Title:           child.Title,
+			Status:          string(child.Status),
+			Priority:        child.Priority,
+			Assignee:        child.Assignee,
+			EstimateMinutes: child.EstimatedMinutes,
+			LoggedMinutes:   input.Logged[child.ID],
+		}
+		estimate := opts.DefaultEstimate
+		if child.EstimatedMinutes != nil {
+			estimate = *child.EstimatedMinutes
+		} else {
+			task.NoEstimate = true
+			f.Unestimated = append(f.Unestimated, child.ID)
+		}
+		task.RemainingMinutes = max(estimate-task.LoggedMinutes, 0)
+		byID[child.ID] = task
+		f.Tasks = append(f.Tasks, task)
+	}
+	f.OpenTasks = len(f.Tasks)
+	sort.Strings(f.Unestimated)
+	sort.Slice(f.Tasks, func(i, j int) bool { return forecastLess(f.Tasks[i], f.Tasks[j]) })
+
+	// Blocking edges between open children. Closed blockers are done.
+	for _, task := range f.Tasks {
+		for _, dep := range input.Deps[task.ID] {
+			if !dep.Type.AffectsReadyWork() || dep.DependsOnID == input.Epic.ID {
+				continue
+			}
+			if blocker, ok := byID[dep.DependsOnID]; ok {
+				if !slices.Contains(task.DependsOn, blocker.ID) {
+					task.DependsOn = append(task.DependsOn, blocker.ID)
+					task.preds = append(task.preds, blocker)
+					blocker.successors = append(blocker.successors, task)
+				}
+				continue
+			}
+			if status, ok := input.Outside[dep.DependsOnID]; ok && status != types.StatusClosed {
+				f.Warnings = append(f.Warnings, fmt.Sprintf(
+					"%s is blocked by %s outside the epic (%s); assumed resolved", task.ID, dep.DependsOnID, status))
+			}
+		}
+	}
+
+	order, err := forecastTopoOrder(f.Tasks)
+	if err != nil {
+		return nil, err
+	}
+	computeCriticalPath(f, order)
+	workers := forecastWorkers(f, opts)
+	scheduleForecast(f, workers)
+	return f, nil
+}
+
+// forecastTopoOrder returns the tasks with every task after its blockers,
+// or an error naming the tasks caught in a cycle.
+func forecastTopoOrder(tasks []*forecastTask) ([]*forecastTask, error) {
+	indegree := make(map[*forecastTask]int, len(tasks))
+	var queue []*forecastTask
+	for _, task := range tasks {
+		indegree[task] = len(task.preds)
+		if len(task.preds) == 0 {
+			queue = append(queue, task)
+		}
+	}
+	order := make([]*forecastTask, 0, len(tasks))
+	for len(queue) > 0 {
+		task := queue[0]
+		queue = queue[1:]
+		order = append(order, task)
+		for _, next := range task.successors {
+			indegree[next]--
+			if indegree[next] == 0 {
+				queue = append(queue, next)
+			}
+		}
+	}
+	if len(order) < len(tasks) {
+		var cyclic []string
+		for _, task := range tasks {
+			if indegree[task] > 0 {
+				cyclic = append(cyclic, task.ID)
+			}
+		}
+		return nil, fmt.Errorf("dependency cycle among %s; fix it with bd dep remove (see bd dep cycles)", strings.Join(cyclic, ", "))
+	}
+	return order, nil
+}
+
+// computeCriticalPath runs the critical path method: a forward pass for
+// earliest times, a backward pass for latest times, and slack between them.
+func computeCriticalPath(f *epicForecast, order []*forecastTask) {
+	end := 0
+	for _, task := range order {
+		task.EarliestStart = 0
+		for _, pred := range task.preds {
+			task.EarliestStart = max(task.EarliestStart, pred.EarliestFinish)
+		}
+		task.EarliestFinish = task.EarliestStart + task.RemainingMinutes
+		end = max(end, task.EarliestFinish)
+	}
+	for i := len(order) - 1; i >= 0; i-- {
+		task := order[i]
+		task.LatestFinish = end
+		for _, next := range task.successors {
+			task.LatestFinish = min(task.LatestFinish, next.LatestStart)
+		}
+		task.LatestStart = task.LatestFinish - task.RemainingMinutes
+		task.Slack = task.LatestStart - task.EarliestStart
+		task.Critical = task.Slack == 0
+	}
+	f.CriticalPathMinutes = end
+
+	// Walk back from a critical task that ends last, through critical
+	// blockers that finish exactly when it starts
+	var last *forecastTask
+	for _, task := range order {
+		if task.Critical && task.EarliestFinish == end && task.RemainingMinutes > 0 &&
+			(last == nil || forecastLess(task, last)) {
+			last = task
+		}
+	}
+	var path []string
+	for task := last; task != nil; {
+		path = append(path, task.ID)
+		var prev *forecastTask
+		for _, pred := range task.preds {
+			if pred.Critical && pred.EarliestFinish == task.EarliestStart && pred.RemainingMinutes > 0 &&
+				(prev == nil || forecastLess(pred, prev)) {
+				prev = pred
+			}
+		}
+		task = prev
+	}
+	slices.Reverse(path)
+	if path != nil {
+		f.CriticalPath = path
+	}
+}
+
+// forecastWorkers returns the worker names for the schedule.
+func forecastWorkers(f *epicForecast, opts forecastOptions) []string {
+	if opts.ByAssignee {
+		f.Mode = "assignees"
+		seen := map[string]bool{}
+		var names []string
+		for _, task := range f.Tasks {
+			if task.Assignee != "" && !seen[task.Assignee] {
+				seen[task.Assignee] = true
+				names = append(names, task.Assignee)
+			}
+		}
+		sort.Strings(names)
+		if len(names) > 0 {
+			f.Workers = names
+			return names
+		}
+		f.Warnings = append(f.Warnings, "no open task has an assignee; scheduling with one worker")
+		opts.Workers = 1
+	}
+	names := make([]string, opts.Workers)
+	for i := range names {
+		names[i] = fmt.Sprintf("worker-%d", i+1)
+	}
+	f.Workers = names
+	return names
+}
+
+// scheduleForecast assigns tasks to workers with list scheduling: the
+// unblocked task with the least slack goes first, to the worker who can
+// start it soonest. In assignee mode assigned tasks stay with their
+// assignee and unassigned ones go to whoever is free.
+func scheduleForecast(f *epicForecast, workers []string) {
+	freeAt := make(map[string]int, len(workers))
+	byAssignee := f.Mode == "assignees"
+	done := make(map[*forecastTask]bool, len(f.Tasks))
+
+	for len(done) < len(f.Tasks) {
+		var next *forecastTask
+		for _, task := range f.Tasks {
+			if done[task] || slices.ContainsFunc(task.preds, func(p *forecastTask) bool { return !done[p] }) {
+				continue
+			}
+			if next == nil || task.LatestStart < next.LatestStart ||
+				(task.LatestStart == next.LatestStart && forecastLess(task, next)) {
+				next = task
+			}
+		}
+
+		release := 0
+		for _, pred := range next.preds {
+			release = max(release, pred.Finish)
+		}
+		next.Start = release
+		if next.RemainingMinutes > 0 {
+			candidates := workers
+			if byAssignee && next.Assignee != "" {
+				candidates = []string{next.Assignee}
+			}
+			best := ""
+			for _, w := range candidates {
+				if best == "" || max(release, freeAt[w]) < max(release, freeAt[best]) {
+					best = w
+				}
+			}
+			next.Worker = best
+			next.Start = max(release, freeAt[best])
+		}
+		next.Finish = next.Start + next.RemainingMinutes
+		if next.Worker != "" {
+			freeAt[next.Worker] = next.Finish
+		}
+		f.ForecastMinutes = max(f.ForecastMinutes, next.Finish)
+		done[next] = true
+	}
+
+	sortForecastTasks(f.Tasks, func(t *forecastTask) int { return t.Start })
+}
+
+// setFinishDate converts the forecast into working days and a finish date,
+// counting hoursPerDay of work per weekday from now.
+func (f *epicForecast) setFinishDate(now time.Time, hoursPerDay float64) {
+	f.HoursPerDay = hoursPerDay
+	f.WorkingDays = math.Round(float64(f.ForecastMinutes)/(hoursPerDay*60)*10) / 10
+	days := int(math.Ceil(float64(f.ForecastMinutes) / (hoursPerDay * 60)))
+	f.FinishDate = addWorkingDays(now, days).Format("2006-01-02")
+}
+
+// addWorkingDays returns the date days working days after t. The day t
+// falls on counts as the first working day if it is a weekday.
+func addWorkingDays(t time.Time, days int) time.Time {
+	isWeekend := func(d time.Time) bool { return d.Weekday() == time.Saturday || d.Weekday() == time.Sunday }
+	for isWeekend(t) {
+		t = t.AddDate(0, 0, 1)
+	}
+	for days > 1 {
+		t = t.AddDate(0, 0, 1)
+		if !isWeekend(t) {
+			days--
+		}
+	}
+	return t
+}
+
+// forecastLess orders tasks by priority, then ID.
+func forecastLess(a, b *forecastTask) bool {
+	if a.Priority != b.Priority {
+		return a.Priority < b.Priority
+	}
+	return a.ID < b.ID
+}
+
+// sortForecastTasks sorts tasks by key, then priority and ID.
+func sortForecastTasks(tasks []*forecastTask, key func(*forecastTask) int) {
+	sort.SliceStable(tasks, func(i, j int) bool {
+		if ki, kj := key(tasks[i]), key(tasks[j]); ki != kj {
+			return ki < kj
+		}
+		return forecastLess(tasks[i], tasks[j])
+	})
+}
+
+// ganttWidth is the number of columns the Gantt bars span.
+const ganttWidth = 48
+
+func printEpicForecast(f *epicForecast) {
+	fmt.Printf("\n%s %s %s\n", ui.RenderAccent("📅"), ui.RenderAccent(f.EpicID), ui.RenderBold(f.EpicTitle))
+	fmt.Printf("Open tasks: %d (%d closed)\n", f.OpenTasks, f.ClosedTasks)
+	if f.OpenTasks == 0 {
+		fmt.Println(ui.RenderPass("Nothing left to do"))
+		fmt.Println()
+		return
+	}
+	team := fmt.Sprintf("%d worker(s)", len(f.Workers))
+	if f.Mode == "assignees" {
+		team = fmt.Sprintf("%d assignee(s): %s", len(f.Workers), strings.Join(f.Workers, ", "))
+	}
+	fmt.Printf("Team: %s\n", team)
+	if len(f.CriticalPath) > 0 {
+		fmt.Printf("Critical path: %s (%s)\n", formatMinutes(f.CriticalPathMinutes), strings.Join(f.CriticalPath, " → "))
+	}
+	fmt.Printf("Forecast: %s of work, %.1f working day(s) at %gh/day, finishing %s\n",
+		formatMinutes(f.ForecastMinutes), f.WorkingDays, f.HoursPerDay, ui.RenderBold(f.FinishDate))
+	if len(f.Unestimated) > 0 {
+		fmt.Println(ui.RenderWarn(fmt.Sprintf("⚠ %d task(s) without an estimate, counted as %s: %s",
+			len(f.Unestimated), formatMinutes(f.DefaultEstimateMinutes), strings.Join(f.Unestimated, ", "))))
+	}
+	for _, w := range f.Warnings {
+		fmt.Println(ui.RenderWarn("⚠ " + w))
+	}
+
+	fmt.Printf("\n%s\n", ui.RenderMuted("█ critical  ▒ other  · slack  ? no estimate"))
+	fmt.Print(renderGantt(f))
+	fmt.Println()
+}
+
+// renderGantt draws the schedule as one bar per task, scaled so the whole
+// forecast spans ganttWidth columns.
+func renderGantt(f *epicForecast) string {
+	idWidth, workerWidth := len("TASK"), len("WORKER")
+	for _, task := range f.Tasks {
+		idWidth = max(idWidth, len(task.ID))
+		workerWidth = max(workerWidth, len(task.Worker))
+	}
+	total := max(f.ForecastMinutes, 1)
+	col := func(minutes int) int {
+		return min(int(math.Round(float64(minutes)*ganttWidth/float64(total))), ganttWidth)
+	}
+
+	var b strings.Builder
+	axis := "0" + strings.Repeat(" ", ganttWidth-len("0")-len(formatMinutes(f.ForecastMinutes))) + formatMinutes(f.ForecastMinutes)
+	fmt.Fprintf(&b, "%-*s  %-*s  %s\n", idWidth, "TASK", workerWidth, "WORKER", axis)
+	for _, task := range f.Tasks {
+		start, end := col(task.Start), col(task.Finish)
+		if end == start && task.RemainingMinutes > 0 && end < ganttWidth {
+			end++ // keep short tasks visible
+		}
+		slackEnd := min(col(task.Finish+task.Slack), ganttWidth)
+		cells := []rune(strings.Repeat(" ", ganttWidth))
+		fill := '▒'
+		if task.Critical {
+			fill = '█'
+		}
+		for i := start; i < end; i++ {
+			cells[i] = fill
+		}
+		for i := end; i < slackEnd; i++ {
+			cells[i] = '·'
+		}
+		bar := string(cells)
+		if task.Critical {
+			bar = ui.RenderFail(bar)
+		}
+
+		info := formatMinutes(task.RemainingMinutes)
+		if task.NoEstimate {
+			info += ui.RenderWarn("?")
+		}
+		if task.Slack > 0 {
+			info += ui.RenderMuted(fmt.Sprintf(" +%s slack", formatMinutes(task.Slack)))
+		}
+		worker := task.Worker
+		if worker == "" {
+			worker = "-"
+		}
+		fmt.Fprintf(&b, "%-*s  %-*s  %s  %s  %s\n", idWidth, task.ID, workerWidth, worker, bar, info, task.Title)
+	}
+	return b.String()
+}
+
+func init() {
+	epicForecastCmd.Flags().Int("workers", 1, "Number of parallel workers")
+	epicForecastCmd.Flags().Bool("assignees", false, "Schedule one worker per distinct assignee")
+	epicForecastCmd.Flags().Int("default-estimate", 0, "Minutes assumed for tasks without an estimate")
+	epicForecastCmd.Flags().Float64("hours-per-day", 8, "Working hours per day for the finish date")
+	epicForecastCmd.ValidArgsFunction = issueIDCompletion
+	epicCmd.AddCommand(epicForecastCmd)
+}
@@ -0,0 +1,262 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// forecastFixture builds an epic with children A (1h), B (2h, after A),
+// C (30m, after A), D (no estimate, after B and C) and a closed child X.
+func forecastFixture() *forecastInput {
+	est := func(m int) *int { return &m }
+	blocks := func(from, to string) *types.Dependency {
+		return &types.Dependency{IssueID: from, DependsOnID: to, Type: types.DepBlocks}
+	}
+	parent := func(id string) *types.Dependency {
+		return &types.Dependency{IssueID: id, DependsOnID: "ep", Type: types.DepParentChild}
+	}
+	return &forecastInput{
+		Epic: &types.Issue{ID: "ep", Title: "Epic", IssueType: types.TypeEpic},
+		Children: []*types.Issue{
+			{ID: "a", Title: "A", Status: types.StatusOpen, Priority: 2, EstimatedMinutes: est(60), Assignee: "alice"},
+			{ID: "b", Title: "B", Status: types.StatusInProgress, Priority: 2, EstimatedMinutes: est(120), Assignee: "alice"},
+			{ID: "c", Title: "C", Status: types.StatusOpen, Priority: 1, EstimatedMinutes: est(30), Assignee: "bob"},
+			{ID: "d", Title: "D", Status: types.StatusOpen, Priority: 2},
+			{ID: "x", Title: "X", Status: types.StatusClosed, Priority: 0, EstimatedMinutes: est(500)},
+		},
+		Deps: map[string][]*types.Dependency{
+			"a": {parent("a")},
+			"b": {parent("b"), blocks("b", "a")},
+			"c": {parent("c"), blocks("c", "a"), blocks("c", "x")},
+			"d": {parent("d"), blocks("d", "b"), blocks("d", "c"), blocks("d", "other-1"), {IssueID: "d", DependsOnID: "c", Type: types.DepRelated}},
+		},
+		Outside: map[string]types.Status{"other-1": types.StatusOpen},
+		Logged:  map[string]int{},
+	}
+}
+
+func forecastTaskByID(t *testing.T, f *epicForecast, id string) *forecastTask {
+	t.Helper()
+	for _, task := range f.Tasks {
+		if task.ID == id {
+			return task
+		}
+	}
+	t.Fatalf("task %s not in forecast", id)
+	return nil
+}
+
+func TestBuildEpicForecast_CriticalPath(t *testing.T) {
+	f, err := buildEpicForecast(forecastFixture(), forecastOptions{Workers: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f.OpenTasks != 4 || f.ClosedTasks != 1 {
+		t.Errorf("open/closed = %d/%d, want 4/1", f.OpenTasks, f.ClosedTasks)
+	}
+	if f.CriticalPathMinutes != 180 {
+		t.Errorf("critical path = %dm, want 180m", f.CriticalPathMinutes)
+	}
+	if got := strings.Join(f.CriticalPath, ","); got != "a,b" {
+		t.Errorf("critical path = %s, want a,b", got)
+	}
+	if c := forecastTaskByID(t, f, "c"); c.Slack != 90 || c.Critical {
+		t.Errorf("c slack = %d critical = %v, want 90 and false", c.Slack, c.Critical)
+	}
+	if d := forecastTaskByID(t, f, "d"); !d.NoEstimate || d.EarliestStart != 180 || len(d.DependsOn) != 2 {
+		t.Errorf("d = %+v", d)
+	}
+	if got := strings.Join(f.Unestimated, ","); got != "d" {
+		t.Errorf("unestimated = %s, want d", got)
+	}
+	if len(f.Warnings) != 1 || !strings.Contains(f.Warnings[0], "other-1") {
+		t.Errorf("warnings = %v, want one about other-1", f.Warnings)
+	}
+
+	// One worker runs everything back to back: least slack first
+	if f.ForecastMinutes != 210 {
+		t.Errorf("forecast with 1 worker = %dm, want 210m", f.ForecastMinutes)
+	}
+	var order []string
+	for _, task := range f.Tasks {
+		order = append(order, task.ID)
+	}
+	if got := strings.Join(order, ","); got != "a,b,c,d" {
+		t.Errorf("schedule order = %s, want a,b,c,d", got)
+	}
+}
+
+func TestBuildEpicForecast_Workers(t *testing.T) {
+	f, err := buildEpicForecast(forecastFixture(), forecastOptions{Workers: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f.ForecastMinutes != 180 {
+		t.Errorf("forecast with 2 workers = %dm, want 180m", f.ForecastMinutes)
+	}
+	if b, c := forecastTaskByID(t, f, "b"), forecastTaskByID(t, f, "c"); b.Worker == c.Worker || b.Start != 60 || c.Start != 60 {
+		t.Errorf("b and c should run in parallel: b=%s@%d c=%s@%d", b.Worker, b.Start, c.Worker, c.Start)
+	}
+
+	input := forecastFixture()
+	input.Logged["b"] = 30
+	f, err = buildEpicForecast(input, forecastOptions{Workers: 2, DefaultEstimate: 60})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// b has 90m left; d counts 60m
+	if f.ForecastMinutes != 60+90+60 {
+		t.Errorf("forecast = %dm, want 210m", f.ForecastMinutes)
+	}
+}
+
+func TestBuildEpicForecast_Assignees(t *testing.T) {
+	input := forecastFixture()
+	input.Children[2].Assignee = "alice" // c now competes with b
+	f, err := buildEpicForecast(input, forecastOptions{ByAssignee: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f.Mode != "assignees" || strings.Join(f.Workers, ",") != "alice" {
+		t.Fatalf("mode = %s workers = %v", f.Mode, f.Workers)
+	}
+	for _, task := range f.Tasks {
+		if task.RemainingMinutes > 0 && task.Worker != "alice" {
+			t.Errorf("%s scheduled on %s, want alice", task.ID, task.Worker)
+		}
+	}
+	if f.ForecastMinutes != 210 {
+		t.Errorf("forecast = %dm, want 210m", f.ForecastMinutes)
+	}
+
+	input = forecastFixture()
+	for _, child := range input.Children {
+		child.Assignee = ""
+	}
+	f, err = buildEpicForecast(input, forecastOptions{ByAssignee: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(f.Workers) != 1 || len(f.Warnings) != 2 {
+		t.Errorf("without assignees: workers = %v warnings = %v", f.Workers, f.Warnings)
+	}
+}
+
+func TestBuildEpicForecast_Cycle(t *testing.T) {
+	input := forecastFixture()
+	input.Deps["a"] = append(input.Deps["a"], &types.Dependency{IssueID: "a", DependsOnID: "d", Type: types.DepBlocks})
+	_, err := buildEpicForecast(input, forecastOptions{Workers: 1})
+	if err == nil || !strings.Contains(err.Error(), "cycle") {
+		t.Fatalf("expected cycle error, got %v", err)
+	}
+}
+
+func TestEpicTreeNestedSubEpics(t *testing.T) {
+	link := func(from, to string, typ types.DependencyType) *types.Dependency {
+		return &types.Dependency{IssueID: from, DependsOnID: to, Type: typ}
+	}
+	// ep has task a and sub-epic sub (tasks s1, s2); sub is blocked by a,
+	// and z blocks on the whole sub-epic
+	allDeps := map[string][]*types.Dependency{
+		"a":   {link("a", "ep", types.DepParentChild)},
+		"sub": {link("sub", "ep", types.DepParentChild), link("sub", "a", types.DepBlocks)},
+		"s1":  {link("s1", "sub", types.DepParentChild)},
+		"s2":  {link("s2", "sub", types.DepParentChild), link("s2", "s1", types.DepBlocks), link("s2", "other-1", types.DepBlocks)},
+		"z":   {link("z", "ep", types.DepParentChild), link("z", "sub", types.DepBlocks)},
+		"far": {link("far", "elsewhere", types.DepParentChild)},
+	}
+	tree := newEpicTree("ep", allDeps)
+	if got := strings.Join(tree.tasks, ","); got != "a,s1,s2,z" {
+		t.Fatalf("tasks = %s, want a,s1,s2,z", got)
+	}
+
+	blockers := func(id string) string {
+		var ids []string
+		for _, dep := range tree.taskDeps(allDeps)[id] {
+			ids = append(ids, dep.DependsOnID)
+		}
+		return strings.Join(ids, ",")
+	}
+	for id, want := range map[string]string{
+		"a":  "",
+		"s1": "a",            // inherited from sub
+		"s2": "s1,other-1,a", // own deps, then sub's
+		"z":  "s1,s2",        // expanded to sub's tasks
+	} {
+		if got := blockers(id); got != want {
+			t.Errorf("blockers of %s = %q, want %q", id, got, want)
+		}
+	}
+
+	// The grandchildren's work is on the critical path
+	est := func(m int) *int { return &m }
+	input := &forecastInput{
+		Epic: &types.Issue{ID: "ep", Title: "Epic", IssueType: types.TypeEpic},
+		Children: []*types.Issue{
+			{ID: "a", Status: types.StatusOpen, EstimatedMinutes: est(60)},
+			{ID: "s1", Status: types.StatusOpen, EstimatedMinutes: est(60)},
+			{ID: "s2", Status: types.StatusOpen, EstimatedMinutes: est(60)},
+			{ID: "z", Status: types.StatusOpen, EstimatedMinutes: est(60)},
+		},
+		Deps:    tree.taskDeps(allDeps),
+		Outside: map[string]types.Status{"other-1": types.StatusClosed},
+		Logged:  map[string]int{},
+	}
+	f, err := buildEpicForecast(input, forecastOptions{Workers: 1})
+	if err != nil {
+		t.Fatalf("buildEpicForecast failed: %v", err)
+	}
+	if got := strings.Join(f.CriticalPath, ","); got != "a,s1,s2,z" || f.CriticalPathMinutes != 240 {
+		t.Errorf("critical path = %s (%dm), want a,s1,s2,z (240m)", got, f.CriticalPathMinutes)
+	}
+}
+
+func TestAddWorkingDays(t *testing.T) {
+	friday := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	tests := []struct {
+		start time.Time
+		days  int
+		want  string
+	}{
+		{friday, 0, "2026-10-16"},
+		{friday, 1, "2026-10-16"},
+		{friday, 2, "2026-10-19"},
+		{friday.AddDate(0, 0, 1), 1, "2026-10-19"}, // Saturday starts Monday
+		{friday, 6, "2026-10-23"},
+	}
+	for _, tt := range tests {
+		if got := addWorkingDays(tt.start, tt.days).Format("2006-01-02"); got != tt.want {
+			t.Errorf("addWorkingDays(%s, %d) = %s, want %s", tt.start.Format("Mon 01-02"), tt.days, got, tt.want)
+		}
+	}
+}
+
+func TestRenderGantt(t *testing.T) {
+	f, err := buildEpicForecast(forecastFixture(), forecastOptions{Workers: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.setFinishDate(time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC), 8)
+	if f.WorkingDays != 0.4 || f.FinishDate != "2026-10-16" {
+		t.Errorf("working days = %v finish = %s", f.WorkingDays, f.FinishDate)
+	}
+	out := renderGantt(f)
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) != 5 {
+		t.Fatalf("want header and 4 rows, got:\n%s", out)
+	}
+	if !strings.Contains(lines[0], "3h") {
+		t.Errorf("axis should end at 3h: %q", lines[0])
+	}
+	for _, line := range lines[1:] {
+		if strings.HasPrefix(line, "d ") && !strings.Contains(line, "?") {
+			t.Errorf("unestimated task not flagged: %q", line)
+		}
+		if strings.HasPrefix(line, "c ") && (!strings.Contains(line, "·") || !strings.Contains(line, "slack")) {
+			t.Errorf("slack not shown for c: %q", line)
+		}
+	}
+}
@@ -416,10 +416,22 @@ Examples:
 			fmt.Fprintf(os.Stderr, "Error getting comments: %v\n", err)
 			os.Exit(1)
 		}
+		workLogMap, err := store.GetWorkLogForIssues(ctx, ids)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error getting work log: %v\n", err)
+			os.Exit(1)
+		}
+		attachmentsMap, err := store.GetAttachmentsForIssues(ctx, ids)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error getting attachments: %v\n", err)
+			os.Exit(1)
+		}
 
 		for _, issue := range issues {
 			issue.Labels = labelsMap[issue.ID]
 			issue.Comments = commentsMap[issue.ID]
+			issue.WorkLog = workLogMap[issue.ID]
+			issue.Attachments = attachmentsMap[issue.ID]
 		}
 
 		// Open output
@@ -16,8 +16,10 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/beads/internal/beads"
 	"github.com/steveyegge/beads/internal/configfile"
+	"github.com/steveyegge/beads/internal/mail"
 	"github.com/steveyegge/beads/internal/routing"
 	"github.com/steveyegge/beads/internal/rpc"
+	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/types"
 	"github.com/steveyegge/beads/internal/ui"
 )
@@ -203,8 +205,9 @@ var gateAddWaiterCmd = &cobra.Command{
 	Short: "Add a waiter to a gate",
 	Long: `Register an agent as a waiter on a gate bead.
 
-When the gate closes, the waiter will receive a wake notification via 'gt gate wake'.
-The waiter is typically the polecat's address (e.g., "gastown/polecats/Toast").
+When the gate closes, each waiter is sent a message (see 'bd mail inbox'), and
+orchestrators can wake it via 'gt gate wake'. The waiter is typically the
+polecat's address (e.g., "gastown/polecats/Toast").
 
 This is used by 'gt done --phase-complete' to register for gate wake notifications.`,
 	Args: cobra.ExactArgs(2),
@@ -435,6 +438,7 @@ Use --reason to provide context for why the gate was resolved.`,
 				fmt.Fprintf(os.Stderr, "Error closing gate: %v\n", err)
 				os.Exit(1)
 			}
+			notifyGateWaiters(ctx, store, gateID, actor)
 			markDirtyAndScheduleFlush()
 		}
 
@@ -944,10 +948,23 @@ func closeGate(_ interface{}, gateID, reason string) error {
 	if err := store.CloseIssue(rootCtx, gateID, reason, actor, ""); err != nil {
 		return err
 	}
+	notifyGateWaiters(rootCtx, store, gateID, actor)
 	markDirtyAndScheduleFlush()
 	return nil
 }
 
+// notifyGateWaiters mails the waiters of a gate closed in direct mode. The
+// daemon does this itself on close. A failure is only a warning.
+func notifyGateWaiters(ctx context.Context, s storage.Storage, gateID, actor string) {
+	gate, err := s.GetIssue(ctx, gateID)
+	if err != nil || gate == nil || gate.IssueType != "gate" {
+		return
+	}
+	if _, err := mail.NotifyWaiters(ctx, s, gate, actor); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to notify waiters of %s: %v\n", gateID, err)
+	}
+}
+
 // escalateGate sends an escalation for a failed/expired gate
 func escalateGate(gate *types.Issue, reason string) {
 	topic := fmt.Sprintf("Gate escalation: %s", gate.ID)
@@ -0,0 +1,301 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/github"
+	"github.com/steveyegge/beads/internal/storage/sqlite"
+	"github.com/steveyegge/beads/internal/tracker"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// githubCmd is the root command for GitHub Issues integration.
+var githubCmd = &cobra.Command{
+	Use:     "github",
+	GroupID: "advanced",
+	Short:   "GitHub Issues integration commands",
+	Long: `Synchronize issues between beads and GitHub Issues.
+
+Configuration:
+  bd config set github.repo "owner/name"
+  bd config set github.token "YOUR_TOKEN"
+  bd config set github.api_endpoint "https://ghe.example.com/api/v3"  # Optional: GitHub Enterprise
+
+Environment variables (alternative to config):
+  GITHUB_TOKEN      - Personal access token (needs Issues read/write)
+  GITHUB_REPOSITORY - Repository as "owner/name"
+
+Linked issues use external_ref values of the form "gh-<number>".
+
+Data Mapping (optional, sensible defaults provided):
+  Labels are copied in both directions. Labels also set priority and type:
+    bd config set github.priority_map.urgent 0     # Label "urgent" -> Critical
+    bd config set github.label_type_map.regression bug
+
+  Defaults: p0-p4 and "priority: critical|high|medium|low" set priority;
+  bug, enhancement, feature, epic, chore and task set the issue type.
+  Without such labels, the local priority and type are kept.
+
+  Milestones are carried as a "milestone:<title>" label (missing milestones
+  are created on push):
+    bd config set github.milestone_prefix "release:"
+
+  State: open <-> open (local in_progress/blocked are kept while open),
+         closed <-> closed.
+  Assignee: the first GitHub assignee <-> the beads assignee (a GitHub login).
+
+Examples:
+  bd github sync --pull         # Import issues from GitHub
+  bd github sync --push         # Export issues to GitHub
+  bd github sync                # Bidirectional sync (pull then push)
+  bd github sync --dry-run      # Preview sync without changes
+  bd github status              # Show sync status`,
+}
+
+// githubSyncCmd handles synchronization with GitHub.
+var githubSyncCmd = &cobra.Command{
+	Use:   "sync",
+	Short: "Synchronize issues with GitHub",
+	Long: `Synchronize issues between beads and GitHub Issues.
+
+Modes:
+  --pull         Import issues from GitHub into beads
+  --push         Export issues from beads to GitHub
+  (no flags)     Bidirectional sync: pull then push, with conflict resolution
+
+Type Filtering (--push only):
+  --type task,feature    Only sync issues of these types
+  --exclude-type chore   Exclude issues of these types
+
+Conflict Resolution:
+  Issues changed on both sides since the last sync are conflicts.
+  By default, newer timestamp wins. Override with:
+  --prefer-local            Always prefer local beads version
+  --prefer-github           Always prefer GitHub version
+  --conflict-policy=manual  Leave conflicts unchanged and report them
+
+Pull requests are never imported.
+
+Examples:
+  bd github sync --pull                         # Import from GitHub
+  bd github sync --pull --state=open            # Import open issues only
+  bd github sync --push --create-only           # Push new issues only
+  bd github sync --dry-run                      # Preview without changes
+  bd github sync --prefer-local                 # Bidirectional, local wins`,
+	Run: runGitHubSync,
+}
+
+// githubStatusCmd shows the current sync status.
+var githubStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show GitHub sync status",
+	Long: `Show the current GitHub sync status, including:
+  - Last sync timestamp
+  - Configuration status
+  - Number of issues with GitHub links
+  - Issues pending push (no external_ref)`,
+	Run: runGitHubStatus,
+}
+
+func init() {
+	addTrackerSyncFlags(githubSyncCmd, "github", "GitHub")
+
+	githubCmd.AddCommand(githubSyncCmd)
+	githubCmd.AddCommand(githubStatusCmd)
+	rootCmd.AddCommand(githubCmd)
+}
+
+func runGitHubSync(cmd *cobra.Command, args []string) {
+	runTrackerSync(cmd, "github", validateGitHubConfig, newGitHubEngine)
+}
+
+// newGitHubEngine creates a sync engine for the configured repository.
+func newGitHubEngine(ctx context.Context) (*tracker.Engine, error) {
+	client, err := getGitHubClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GitHub client: %w", err)
+	}
+	return newTrackerEngine(ctx, github.NewAdapter(client, loadGitHubMappingConfig(ctx))), nil
+}
+
+func runGitHubStatus(cmd *cobra.Command, args []string) {
+	ctx := rootCtx
+
+	if err := ensureStoreActive(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	token, _ := getGitHubConfig(ctx, "github.token")
+	repo, _ := getGitHubConfig(ctx, "github.repo")
+	lastSync, _ := store.GetConfig(ctx, "github.last_sync")
+
+	configured := token != "" && repo != ""
+
+	allIssues, err := store.SearchIssues(ctx, "", types.IssueFilter{})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	withGitHubRef := 0
+	pendingPush := 0
+	for _, issue := range allIssues {
+		if issue.ExternalRef != nil && github.IsGitHubExternalRef(*issue.ExternalRef) {
+			withGitHubRef++
+		} else if issue.ExternalRef == nil {
+			pendingPush++
+		}
+	}
+
+	if jsonOutput {
+		outputJSON(map[string]interface{}{
+			"configured":      configured,
+			"has_token":       token != "",
+			"repo":            repo,
+			"last_sync":       lastSync,
+			"total_issues":    len(allIssues),
+			"with_github_ref": withGitHubRef,
+			"pending_push":    pendingPush,
+		})
+		return
+	}
+
+	fmt.Println("GitHub Sync Status")
+	fmt.Println("==================")
+	fmt.Println()
+
+	if !configured {
+		fmt.Println("Status: Not configured")
+		fmt.Println()
+		fmt.Println("To configure GitHub integration:")
+		fmt.Println("  bd config set github.repo \"owner/name\"")
+		fmt.Println("  bd config set github.token \"YOUR_TOKEN\"")
+		fmt.Println()
+		fmt.Println("Or use environment variables:")
+		fmt.Println("  export GITHUB_REPOSITORY=\"owner/name\"")
+		fmt.Println("  export GITHUB_TOKEN=\"YOUR_TOKEN\"")
+		return
+	}
+
+	fmt.Printf("Repository:   %s\n", repo)
+	fmt.Printf("Token:        %s\n", maskAPIKey(token))
+	if lastSync != "" {
+		fmt.Printf("Last Sync:    %s\n", lastSync)
+	} else {
+		fmt.Println("Last Sync:    Never")
+	}
+	fmt.Println()
+	fmt.Printf("Total Issues: %d\n", len(allIssues))
+	fmt.Printf("With GitHub:  %d\n", withGitHubRef)
+	fmt.Printf("Local Only:   %d\n", pendingPush)
+
+	if pendingPush > 0 {
+		fmt.Println()
+		fmt.Printf("Run 'bd github sync --push' to push %d local issue(s) to GitHub\n", pendingPush)
+	}
+}
+
+// validateGitHubConfig checks that required GitHub configuration is present.
+func validateGitHubConfig() error {
+	if err := ensureStoreActive(); err != nil {
+		return fmt.Errorf("database not available: %w", err)
+	}
+
+	ctx := rootCtx
+
+	token, _ := getGitHubConfig(ctx, "github.token")
+	if token == "" {
+		return fmt.Errorf("GitHub token not configured\nRun: bd config set github.token \"YOUR_TOKEN\"\nOr: export GITHUB_TOKEN=YOUR_TOKEN")
+	}
+
+	repo, _ := getGitHubConfig(ctx, "github.repo")
+	if repo == "" {
+		return fmt.Errorf("github.repo not configured\nRun: bd config set github.repo \"owner/name\"\nOr: export GITHUB_REPOSITORY=owner/name")
+	}
+
+	if _, _, err := github.ParseRepo(repo); err != nil {
+		return fmt.Errorf("github.repo: %w", err)
+	}
+
+	return nil
+}
+
+// getGitHubConfig reads a GitHub configuration value, handling both daemon mode
+// (where store is nil) and direct mode. Returns the value and its source.
+// Priority: project config > environment variable.
+func getGitHubConfig(ctx context.Context, key string) (value string, source string) {
+	if store != nil {
+		value, _ = store.GetConfig(ctx, key)
+		if value != "" {
+			return value, "project config (bd config)"
+		}
+	} else if dbPath != "" {
+		tempStore, err := sqlite.NewWithTimeout(ctx, dbPath, 5*time.Second)
+		if err == nil {
+			defer func() { _ = tempStore.Close() }()
+			value, _ = tempStore.GetConfig(ctx, key)
+			if value != "" {
+				return value, "project config (bd config)"
+			}
+		}
+	}
+
+	envKey := githubConfigToEnvVar(key)
+	if envKey != "" {
+		value = os.Getenv(envKey)
+		if value != "" {
+			return value, fmt.Sprintf("environment variable (%s)", envKey)
+		}
+	}
+
+	return "", ""
+}
+
+// githubConfigToEnvVar maps GitHub config keys to their environment variable names.
+func githubConfigToEnvVar(key string) string {
+	switch key {
+	case "github.token":
+		return "GITHUB_TOKEN"
+	case "github.repo":
+		return "GITHUB_REPOSITORY"
+	default:
+		return ""
+	}
+}
+
+// getGitHubClient creates a configured GitHub client from beads config.
+func getGitHubClient(ctx context.Context) (*github.Client, error) {
+	token, _ := getGitHubConfig(ctx, "github.token")
+	if token == "" {
+		return nil, fmt.Errorf("GitHub token not configured")
+	}
+
+	repo, _ := getGitHubConfig(ctx, "github.repo")
+	owner, name, err := github.ParseRepo(repo)
+	if err != nil {
+		return nil, err
+	}
+
+	client := github.NewClient(token, owner, name)
+
+	if store != nil {
+		if endpoint, _ := store.GetConfig(ctx, "github.api_endpoint"); endpoint != "" {
+			client = client.WithEndpoint(endpoint)
+		}
+	}
+
+	return client, nil
+}
+
+// loadGitHubMappingConfig loads mapping configuration from beads config.
+func loadGitHubMappingConfig(ctx context.Context) *github.MappingConfig {
+	if store == nil {
+		return github.DefaultMappingConfig()
+	}
+	return github.LoadMappingConfig(&storeConfigLoader{ctx: ctx})
+}
@@ -0,0 +1,466 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/github"
+	"github.com/steveyegge/beads/internal/tracker"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// fakeGitHub is a minimal in-memory stand-in for the GitHub Issues REST API.
+type fakeGitHub struct {
+	mu         sync.Mutex
+	issues     map[int]*github.Issue
+	milestones []github.Milestone
+	nextNumber int
+	patched    []int
+}
+
+func newFakeGitHub(t *testing.T) (*fakeGitHub, *httptest.Server) {
+	t.Helper()
+	fake := &fakeGitHub{issues: make(map[int]*github.Issue), nextNumber: 1}
+	server := httptest.NewServer(http.HandlerFunc(fake.serve))
+	t.Cleanup(server.Close)
+	return fake, server
+}
+
+func (f *fakeGitHub) add(issue github.Issue) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.issues[issue.Number] = &issue
+	if issue.Number >= f.nextNumber {
+		f.nextNumber = issue.Number + 1
+	}
+}
+
+func (f *fakeGitHub) get(number int) github.Issue {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return *f.issues[number]
+}
+
+func (f *fakeGitHub) serve(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	path := strings.TrimPrefix(r.URL.Path, "/repos/octo/repo")
+	w.Header().Set("Content-Type", "application/json")
+
+	switch {
+	case path == "/issues" && r.Method == http.MethodGet:
+		since, _ := time.Parse(time.RFC3339, r.URL.Query().Get("since"))
+		list := []*github.Issue{}
+		for _, issue := range f.issues {
+			if !since.IsZero() && issue.UpdatedAt.Before(since) {
+				continue
+			}
+			list = append(list, issue)
+		}
+		sort.Slice(list, func(i, j int) bool { return list[i].Number < list[j].Number })
+		_ = json.NewEncoder(w).Encode(list)
+
+	case path == "/issues" && r.Method == http.MethodPost:
+		var req github.IssueRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		issue := &github.Issue{Number: f.nextNumber, State: "open", CreatedAt: time.Now().UTC()}
+		f.nextNumber++
+		f.apply(issue, &req)
+		f.issues[issue.Number] = issue
+		_ = json.NewEncoder(w).Encode(issue)
+
+	case strings.HasPrefix(path, "/issues/"):
+		number, _ := strconv.Atoi(strings.TrimPrefix(path, "/issues/"))
+		issue, ok := f.issues[number]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"message":"Not Found"}`)
+			return
+		}
+		if r.Method == http.MethodPatch {
+			var req github.IssueRequest
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			f.apply(issue, &req)
+			f.patched = append(f.patched, number)
+		}
+		_ = json.NewEncoder(w).Encode(issue)
+
+	case path == "/milestones" && r.Method == http.MethodGet:
+		_ = json.NewEncoder(w).Encode(f.milestones)
+
+	case path == "/milestones" && r.Method == http.MethodPost:
+		var req map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		m := github.Milestone{Number: len(f.milestones) + 1, Title: req["title"], State: "open"}
+		f.milestones = append(f.milestones, m)
+		_ = json.NewEncoder(w).Encode(m)
+
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprintf(w, `{"message":"unexpected %s %s"}`, r.Method, r.URL.Path)
+	}
+}
+
+// apply copies an IssueRequest onto a stored issue, as GitHub would.
+func (f *fakeGitHub) apply(issue *github.Issue, req *github.IssueRequest) {
+	issue.Title = req.Title
+	issue.Body = req.Body
+	if req.State != "" {
+		issue.State = req.State
+	}
+	issue.Labels = nil
+	for _, l := range req.Labels {
+		issue.Labels = append(issue.Labels, github.Label{Name: l})
+	}
+	issue.Assignees = nil
+	for _, a := range req.Assignees {
+		issue.Assignees = append(issue.Assignees, github.User{Login: a})
+	}
+	issue.Milestone = nil
+	if req.Milestone != nil {
+		for _, m := range f.milestones {
+			if m.Number == *req.Milestone {
+				m := m
+				issue.Milestone = &m
+			}
+		}
+	}
+	issue.UpdatedAt = time.Now().UTC()
+}
+
+// setupGitHubSyncTest points the package-level store at a fresh database
+// configured to talk to the given fake server.
+func setupGitHubSyncTest(t *testing.T, serverURL string) context.Context {
+	t.Helper()
+	testStore, cleanup := setupTestDB(t)
+	t.Cleanup(cleanup)
+
+	ctx := context.Background()
+	for key, value := range map[string]string{
+		"github.token":        "test-token",
+		"github.repo":         "octo/repo",
+		"github.api_endpoint": serverURL,
+	} {
+		if err := testStore.SetConfig(ctx, key, value); err != nil {
+			t.Fatalf("SetConfig %s failed: %v", key, err)
+		}
+	}
+
+	origStore := store
+	origActor := actor
+	store = testStore
+	actor = "test-actor"
+	t.Cleanup(func() {
+		store = origStore
+		actor = origActor
+	})
+	return ctx
+}
+
+// newGitHubTestEngine returns a quiet sync engine for the configured repo.
+func newGitHubTestEngine(t *testing.T, ctx context.Context) *tracker.Engine {
+	t.Helper()
+	engine, err := newGitHubEngine(ctx)
+	if err != nil {
+		t.Fatalf("newGitHubEngine failed: %v", err)
+	}
+	engine.Out = io.Discard
+	engine.Err = io.Discard
+	return engine
+}
+
+// findByExternalRef returns the local issue linked to a GitHub issue number.
+func findByExternalRef(t *testing.T, ctx context.Context, number int) *types.Issue {
+	t.Helper()
+	issues, err := store.SearchIssues(ctx, "", types.IssueFilter{})
+	if err != nil {
+		t.Fatalf("SearchIssues failed: %v", err)
+	}
+	for _, issue := range issues {
+		if issue.ExternalRef != nil && *issue.ExternalRef == github.FormatExternalRef(number) {
+			if issue.Labels, err = store.GetLabels(ctx, issue.ID); err != nil {
+				t.Fatalf("GetLabels failed: %v", err)
+			}
+			return issue
+		}
+	}
+	t.Fatalf("no local issue linked to gh-%d", number)
+	return nil
+}
+
+func TestGitHubPull(t *testing.T) {
+	fake, server := newFakeGitHub(t)
+	ctx := setupGitHubSyncTest(t, server.URL)
+
+	created := time.Now().Add(-48 * time.Hour).UTC().Truncate(time.Second)
+	closedAt := created.Add(time.Hour)
+	fake.add(github.Issue{
+		Number:    1,
+		Title:     "Login times out",
+		Body:      "After 30s",
+		State:     "open",
+		Labels:    []github.Label{{Name: "bug"}, {Name: "p1"}, {Name: "area/auth"}},
+		Assignees: []github.User{{Login: "octocat"}},
+		Milestone: &github.Milestone{Number: 1, Title: "v1.0"},
+		CreatedAt: created,
+		UpdatedAt: created,
+	})
+	fake.add(github.Issue{Number: 2, Title: "Old request", State: "closed", CreatedAt: created, UpdatedAt: closedAt, ClosedAt: &closedAt})
+	fake.add(github.Issue{Number: 3, Title: "A pull request", State: "open", PullRequest: &struct{}{}, CreatedAt: created, UpdatedAt: created})
+
+	engine := newGitHubTestEngine(t, ctx)
+	stats, err := engine.Pull(ctx, tracker.PullOptions{State: "all"})
+	if err != nil {
+		t.Fatalf("Pull failed: %v", err)
+	}
+	if stats.Created != 2 {
+		t.Fatalf("Created = %d, want 2 (pull request skipped)", stats.Created)
+	}
+
+	first := findByExternalRef(t, ctx, 1)
+	if first.Title != "Login times out" || first.Priority != 1 || first.IssueType != types.TypeBug {
+		t.Errorf("unexpected mapping: %+v", first)
+	}
+	if first.Assignee != "octocat" || first.Status != types.StatusOpen {
+		t.Errorf("assignee/status = %q/%s", first.Assignee, first.Status)
+	}
+	sort.Strings(first.Labels)
+	if strings.Join(first.Labels, ",") != "area/auth,bug,milestone:v1.0,p1" {
+		t.Errorf("Labels = %v", first.Labels)
+	}
+
+	second := findByExternalRef(t, ctx, 2)
+	if second.Status != types.StatusClosed {
+		t.Errorf("gh-2 status = %s, want closed", second.Status)
+	}
+
+	// Work starts locally, then the GitHub issue is relabeled.
+	if err := store.UpdateIssue(ctx, first.ID, map[string]interface{}{"status": string(types.StatusInProgress)}, actor); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	time.Sleep(1100 * time.Millisecond) // GitHub timestamps have second precision
+	issue := fake.get(1)
+	issue.Labels = []github.Label{{Name: "bug"}, {Name: "p0"}}
+	issue.UpdatedAt = time.Now().UTC()
+	fake.add(issue)
+
+	if _, err := engine.Pull(ctx, tracker.PullOptions{State: "all"}); err != nil {
+		t.Fatalf("second Pull failed: %v", err)
+	}
+
+	first = findByExternalRef(t, ctx, 1)
+	if first.Status != types.StatusInProgress {
+		t.Errorf("Status = %s, want local in_progress kept while GitHub issue is open", first.Status)
+	}
+	if first.Priority != 0 {
+		t.Errorf("Priority = %d, want 0 from p0 label", first.Priority)
+	}
+	sort.Strings(first.Labels)
+	if strings.Join(first.Labels, ",") != "bug,milestone:v1.0,p0" {
+		t.Errorf("Labels = %v, want removed GitHub labels dropped", first.Labels)
+	}
+}
+
+func TestGitHubPullSkipsIDs(t *testing.T) {
+	fake, server := newFakeGitHub(t)
+	ctx := setupGitHubSyncTest(t, server.URL)
+
+	now := time.Now().UTC()
+	fake.add(github.Issue{Number: 1, Title: "Keep", State: "open", CreatedAt: now, UpdatedAt: now})
+	fake.add(github.Issue{Number: 2, Title: "Skip", State: "open", CreatedAt: now, UpdatedAt: now})
+
+	stats, err := newGitHubTestEngine(t, ctx).Pull(ctx, tracker.PullOptions{State: "all", SkipIDs: map[string]bool{"#2": true}})
+	if err != nil {
+		t.Fatalf("Pull failed: %v", err)
+	}
+	if stats.Created != 1 || stats.Skipped != 1 {
+		t.Errorf("stats = %+v, want 1 created and 1 skipped", stats)
+	}
+}
+
+func TestGitHubPush(t *testing.T) {
+	fake, server := newFakeGitHub(t)
+	ctx := setupGitHubSyncTest(t, server.URL)
+
+	issue := &types.Issue{
+		Title:       "Add SSO",
+		Description: "Support SAML",
+		Notes:       "Check Okta",
+		Priority:    1,
+		IssueType:   types.TypeFeature,
+		Status:      types.StatusOpen,
+		Assignee:    "octocat",
+	}
+	if err := store.CreateIssue(ctx, issue, actor); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	for _, l := range []string{"enhancement", "milestone:v2.0"} {
+		if err := store.AddLabel(ctx, issue.ID, l, actor); err != nil {
+			t.Fatalf("AddLabel failed: %v", err)
+		}
+	}
+
+	engine := newGitHubTestEngine(t, ctx)
+	stats, err := engine.Push(ctx, tracker.PushOptions{UpdateRefs: true})
+	if err != nil {
+		t.Fatalf("Push failed: %v", err)
+	}
+	if stats.Created != 1 || stats.Errors != 0 {
+		t.Fatalf("stats = %+v, want 1 created", stats)
+	}
+
+	remote := fake.get(1)
+	if remote.Body != "Support SAML\n\n## Notes\nCheck Okta" {
+		t.Errorf("Body = %q", remote.Body)
+	}
+	if len(remote.Labels) != 1 || remote.Labels[0].Name != "enhancement" {
+		t.Errorf("Labels = %v, want only enhancement", remote.Labels)
+	}
+	if remote.Milestone == nil || remote.Milestone.Title != "v2.0" {
+		t.Errorf("Milestone = %v, want created v2.0", remote.Milestone)
+	}
+	if len(remote.Assignees) != 1 || remote.Assignees[0].Login != "octocat" {
+		t.Errorf("Assignees = %v", remote.Assignees)
+	}
+
+	local, err := store.GetIssue(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if local.ExternalRef == nil || *local.ExternalRef != "gh-1" {
+		t.Fatalf("ExternalRef = %v, want gh-1", local.ExternalRef)
+	}
+
+	// Nothing changed: the linked issue is skipped.
+	stats, err = engine.Push(ctx, tracker.PushOptions{UpdateRefs: true})
+	if err != nil {
+		t.Fatalf("second Push failed: %v", err)
+	}
+	if stats.Updated != 0 || len(fake.patched) != 0 {
+		t.Errorf("expected no updates, got stats=%+v patched=%v", stats, fake.patched)
+	}
+
+	// Closing locally pushes the new state.
+	time.Sleep(1100 * time.Millisecond)
+	if err := store.CloseIssue(ctx, issue.ID, "done", actor, ""); err != nil {
+		t.Fatalf("CloseIssue failed: %v", err)
+	}
+	stats, err = engine.Push(ctx, tracker.PushOptions{UpdateRefs: true})
+	if err != nil {
+		t.Fatalf("third Push failed: %v", err)
+	}
+	if stats.Updated != 1 {
+		t.Fatalf("Updated = %d, want 1", stats.Updated)
+	}
+	if remote := fake.get(1); remote.State != "closed" {
+		t.Errorf("remote state = %s, want closed", remote.State)
+	}
+}
+
+func TestGitHubConflictsNewerWins(t *testing.T) {
+	fake, server := newFakeGitHub(t)
+	ctx := setupGitHubSyncTest(t, server.URL)
+
+	old := time.Now().Add(-time.Hour).UTC().Truncate(time.Second)
+	fake.add(github.Issue{Number: 1, Title: "Original", State: "open", CreatedAt: old, UpdatedAt: old})
+	fake.add(github.Issue{Number: 2, Title: "Second", State: "open", CreatedAt: old, UpdatedAt: old})
+	engine := newGitHubTestEngine(t, ctx)
+	if _, err := engine.Pull(ctx, tracker.PullOptions{State: "all"}); err != nil {
+		t.Fatalf("Pull failed: %v", err)
+	}
+	lastSync := time.Now().Add(-time.Minute).UTC()
+	if err := store.SetConfig(ctx, "github.last_sync", lastSync.Format(time.RFC3339)); err != nil {
+		t.Fatalf("SetConfig failed: %v", err)
+	}
+
+	// gh-1: edited locally, then on GitHub (GitHub newer).
+	// gh-2: edited on GitHub, then locally (local newer).
+	first := findByExternalRef(t, ctx, 1)
+	second := findByExternalRef(t, ctx, 2)
+	if err := store.UpdateIssue(ctx, first.ID, map[string]interface{}{"title": "Local edit"}, actor); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	remote := fake.get(1)
+	remote.Title = "GitHub edit"
+	remote.Labels = []github.Label{{Name: "p0"}}
+	remote.UpdatedAt = time.Now().Add(time.Minute).UTC()
+	fake.add(remote)
+
+	remote = fake.get(2)
+	remote.Title = "GitHub edit 2"
+	remote.UpdatedAt = time.Now().UTC()
+	fake.add(remote)
+	time.Sleep(10 * time.Millisecond)
+	if err := store.UpdateIssue(ctx, second.ID, map[string]interface{}{"title": "Local edit 2"}, actor); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+
+	conflicts, err := engine.DetectConflicts(ctx)
+	if err != nil {
+		t.Fatalf("DetectConflicts failed: %v", err)
+	}
+	if len(conflicts) != 2 {
+		t.Fatalf("got %d conflicts, want 2: %+v", len(conflicts), conflicts)
+	}
+
+	githubWins, localWins := tracker.SplitConflictsByTimestamp(conflicts)
+	if len(githubWins) != 1 || githubWins[0].RemoteID != "#1" {
+		t.Fatalf("githubWins = %+v, want #1", githubWins)
+	}
+	if len(localWins) != 1 || localWins[0].RemoteID != "#2" {
+		t.Fatalf("localWins = %+v, want #2", localWins)
+	}
+
+	if err := engine.ApplyRemote(ctx, githubWins); err != nil {
+		t.Fatalf("ApplyRemote failed: %v", err)
+	}
+
+	first = findByExternalRef(t, ctx, 1)
+	if first.Title != "GitHub edit" || first.Priority != 0 {
+		t.Errorf("gh-1 should take GitHub version, got title=%q priority=%d", first.Title, first.Priority)
+	}
+	if len(first.Labels) != 1 || first.Labels[0] != "p0" {
+		t.Errorf("gh-1 labels = %v, want [p0]", first.Labels)
+	}
+	second = findByExternalRef(t, ctx, 2)
+	if second.Title != "Local edit 2" {
+		t.Errorf("gh-2 should keep local version, got %q", second.Title)
+	}
+
+	// Pushing with the local-wins IDs forced overwrites GitHub.
+	force := map[string]bool{second.ID: true}
+	skip := map[string]bool{first.ID: true}
+	if _, err := engine.Push(ctx, tracker.PushOptions{UpdateRefs: true, ForceIDs: force, SkipIDs: skip}); err != nil {
+		t.Fatalf("Push failed: %v", err)
+	}
+	if got := fake.get(2).Title; got != "Local edit 2" {
+		t.Errorf("gh-2 remote title = %q, want local edit pushed", got)
+	}
+	if got := fake.get(1).Title; got != "GitHub edit" {
+		t.Errorf("gh-1 remote title = %q, want unchanged", got)
+	}
+}
+
+func TestGitHubConfigToEnvVar(t *testing.T) {
+	tests := map[string]string{
+		"github.token":        "GITHUB_TOKEN",
+		"github.repo":         "GITHUB_REPOSITORY",
+		"github.api_endpoint": "",
+	}
+	for key, want := range tests {
+		if got := githubConfigToEnvVar(key); got != want {
+			t.Errorf("githubConfigToEnvVar(%q) = %q, want %q", key, got, want)
+		}
+	}
+}
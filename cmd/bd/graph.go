@@ -37,6 +37,7 @@ var (
 	graphCompact bool
 	graphBox     bool
 	graphAll     bool
+	graphFormat  string
 )
 
 var graphCmd = &cobra.Command{
@@ -54,6 +55,16 @@ Display formats:
   --box (default)  ASCII boxes showing layers, more detailed
   --compact        Tree format, one line per issue, more scannable
 
+Export formats (--format), for design docs and PR descriptions:
+  dot      Graphviz source (render with: dot -Tpng)
+  mermaid  Mermaid flowchart (paste into a `+"```"+`mermaid block)
+  svg      Self-contained SVG, rendered without Graphviz
+  json     Nodes, edges and clusters
+
+Exports draw each dependency type differently (blocks, parent-child,
+conditional-blocks, waits-for, related), group epics with their children,
+fill nodes by status and outline them by priority.
+
 The graph shows execution order:
 - Layer 0 / leftmost = no dependencies (can start immediately)
 - Higher layers depend on lower layers
@@ -73,6 +84,10 @@ Status icons: ○ open  ◐ in_progress  ● blocked  ✓ closed  ❄ deferred`,
 			fmt.Fprintf(os.Stderr, "Error: issue ID required (or use --all for all open issues)\n")
 			os.Exit(1)
 		}
+		if graphFormat != "" && !containsStr(graphFormats, graphFormat) {
+			fmt.Fprintf(os.Stderr, "Error: invalid --format %q (valid: %s)\n", graphFormat, strings.Join(graphFormats, ", "))
+			os.Exit(1)
+		}
 
 		// If daemon is running but doesn't support this command, use direct storage
 		if daemonClient != nil && store == nil {
@@ -98,6 +113,11 @@ Status icons: ○ open  ◐ in_progress  ● blocked  ✓ closed  ❄ deferred`,
 				os.Exit(1)
 			}
 
+			if graphFormat != "" {
+				exportGraph(mergeSubgraphs(subgraphs))
+				return
+			}
+
 			if len(subgraphs) == 0 {
 				fmt.Println("No open issues found")
 				return
@@ -152,6 +172,11 @@ Status icons: ○ open  ◐ in_progress  ● blocked  ✓ closed  ❄ deferred`,
 			os.Exit(1)
 		}
 
+		if graphFormat != "" {
+			exportGraph(subgraph)
+			return
+		}
+
 		// Compute layout
 		layout := computeLayout(subgraph)
 
@@ -177,10 +202,20 @@ func init() {
 	graphCmd.Flags().BoolVar(&graphAll, "all", false, "Show graph for all open issues")
 	graphCmd.Flags().BoolVar(&graphCompact, "compact", false, "Tree format, one line per issue, more scannable")
 	graphCmd.Flags().BoolVar(&graphBox, "box", true, "ASCII boxes showing layers (default)")
+	graphCmd.Flags().StringVar(&graphFormat, "format", "", "Export format: dot, mermaid, svg, json")
 	graphCmd.ValidArgsFunction = issueIDCompletion
 	rootCmd.AddCommand(graphCmd)
 }
 
+// exportGraph writes the subgraph to stdout in the --format export format.
+func exportGraph(subgraph *TemplateSubgraph) {
+	g := buildGraphExport(subgraph, computeLayout(subgraph))
+	if err := writeGraphExport(os.Stdout, g, graphFormat); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
 // loadGraphSubgraph loads an issue and its subgraph for visualization
 // Unlike template loading, this includes ALL dependency types (not just parent-child)
 func loadGraphSubgraph(ctx context.Context, s storage.Storage, issueID string) (*TemplateSubgraph, error) {
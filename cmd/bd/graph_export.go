@@ -0,0 +1,565 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// graphFormats are the values accepted by bd graph --format.
+var graphFormats = []string{"dot", "mermaid", "svg", "json"}
+
+// GraphExport is the format-independent graph written by bd graph --format.
+// Edges point from the issue that must come first (blocker, parent) to the
+// issue that depends on it, matching the left-to-right layer order.
+type GraphExport struct {
+	Root     string                `json:"root,omitempty"`
+	Nodes    []*GraphExportNode    `json:"nodes"`
+	Edges    []*GraphExportEdge    `json:"edges"`
+	Clusters []*GraphExportCluster `json:"clusters,omitempty"`
+}
+
+// GraphExportNode is one issue in the exported graph.
+type GraphExportNode struct {
+	ID       string `json:"id"`
+	Title    string `json:"title"`
+	Status   string `json:"status"`
+	Priority int    `json:"priority"`
+	Type     string `json:"type"`
+	Layer    int    `json:"layer"`
+	Cluster  string `json:"cluster,omitempty"` // Epic whose cluster holds the node
+}
+
+// GraphExportEdge is one dependency in the exported graph.
+type GraphExportEdge struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+	Type string `json:"type"`
+}
+
+// GraphExportCluster groups an epic with its children. Clusters nest when
+// an epic is the child of another epic.
+type GraphExportCluster struct {
+	ID     string `json:"id"` // The epic's issue ID
+	Title  string `json:"title"`
+	Parent string `json:"parent,omitempty"`
+}
+
+// graphEdgeStyle is how an edge type is drawn in every format.
+type graphEdgeStyle struct {
+	Color   string
+	Dash    string // SVG stroke-dasharray, empty for solid
+	DOT     string // Graphviz style
+	Arrow   bool
+	Mermaid string // Mermaid link operator
+	Label   string
+}
+
+var graphEdgeStyles = map[types.DependencyType]graphEdgeStyle{
+	types.DepBlocks:            {Color: "#374151", DOT: "solid", Arrow: true, Mermaid: "-->"},
+	types.DepParentChild:       {Color: "#9ca3af", Dash: "6 4", DOT: "dashed", Arrow: true, Mermaid: "-.->"},
+	types.DepConditionalBlocks: {Color: "#d97706", Dash: "10 3 2 3", DOT: "bold", Arrow: true, Mermaid: "==>", Label: "if fails"},
+	types.DepWaitsFor:          {Color: "#2563eb", Dash: "2 3", DOT: "dotted", Arrow: true, Mermaid: "--o", Label: "waits for"},
+	types.DepRelated:           {Color: "#a855f7", Dash: "1 4", DOT: "dotted", Mermaid: "-.-"},
+}
+
+// otherEdgeStyle draws dependency types without a style of their own.
+var otherEdgeStyle = graphEdgeStyle{Color: "#d1d5db", Dash: "4 2 1 2", DOT: "dashed", Arrow: true, Mermaid: "-.->"}
+
+func edgeStyle(depType string) graphEdgeStyle {
+	if s, ok := graphEdgeStyles[types.DependencyType(depType)]; ok {
+		return s
+	}
+	s := otherEdgeStyle
+	s.Label = depType
+	return s
+}
+
+// graphStatusColors returns the fill and text colors for a status.
+func graphStatusColors(status string) (fill, text string) {
+	switch types.Status(status) {
+	case types.StatusInProgress:
+		return "#fef3c7", "#78350f"
+	case types.StatusBlocked:
+		return "#fee2e2", "#7f1d1d"
+	case types.StatusClosed:
+		return "#f3f4f6", "#9ca3af"
+	case types.StatusDeferred:
+		return "#e0e7ff", "#3730a3"
+	default:
+		return "#ffffff", "#111827"
+	}
+}
+
+// graphPriorityBorder returns the border color and width for a priority.
+func graphPriorityBorder(priority int) (color string, width float64) {
+	switch priority {
+	case 0:
+		return "#dc2626", 3
+	case 1:
+		return "#ea580c", 2
+	case 2:
+		return "#4b5563", 1
+	default:
+		return "#9ca3af", 1
+	}
+}
+
+// buildGraphExport flattens a subgraph and its layout into a GraphExport.
+func buildGraphExport(subgraph *TemplateSubgraph, layout *GraphLayout) *GraphExport {
+	g := &GraphExport{Nodes: []*GraphExportNode{}, Edges: []*GraphExportEdge{}}
+	if subgraph.Root != nil {
+		g.Root = subgraph.Root.ID
+	}
+
+	// An epic with children in the graph heads a cluster
+	parentOf := make(map[string]string)
+	heads := make(map[string]bool)
+	for _, dep := range subgraph.Dependencies {
+		parent := subgraph.IssueMap[dep.DependsOnID]
+		if dep.Type != types.DepParentChild || parent == nil || parent.IssueType != types.TypeEpic {
+			continue
+		}
+		if _, seen := parentOf[dep.IssueID]; !seen {
+			parentOf[dep.IssueID] = dep.DependsOnID
+		}
+		heads[dep.DependsOnID] = true
+	}
+
+	for _, issue := range subgraph.Issues {
+		node := &GraphExportNode{
+			ID:       issue.ID,
+			Title:    issue.Title,
+			Status:   string(issue.Status),
+			Priority: issue.Priority,
+			Type:     string(issue.IssueType),
+		}
+		if n := layout.Nodes[issue.ID]; n != nil {
+			node.Layer = n.Layer
+		}
+		if heads[issue.ID] {
+			node.Cluster = issue.ID
+			g.Clusters = append(g.Clusters, &GraphExportCluster{
+				ID:     issue.ID,
+				Title:  issue.Title,
+				Parent: clusterParent(issue.ID, parentOf),
+			})
+		} else {
+			node.Cluster = parentOf[issue.ID]
+		}
+		g.Nodes = append(g.Nodes, node)
+	}
+
+	for _, dep := range subgraph.Dependencies {
+		g.Edges = append(g.Edges, &GraphExportEdge{From: dep.DependsOnID, To: dep.IssueID, Type: string(dep.Type)})
+	}
+
+	sort.Slice(g.Nodes, func(i, j int) bool {
+		if g.Nodes[i].Layer != g.Nodes[j].Layer {
+			return g.Nodes[i].Layer < g.Nodes[j].Layer
+		}
+		return g.Nodes[i].ID < g.Nodes[j].ID
+	})
+	sort.Slice(g.Edges, func(i, j int) bool {
+		a, b := g.Edges[i], g.Edges[j]
+		if a.From != b.From {
+			return a.From < b.From
+		}
+		if a.To != b.To {
+			return a.To < b.To
+		}
+		return a.Type < b.Type
+	})
+	sort.Slice(g.Clusters, func(i, j int) bool { return g.Clusters[i].ID < g.Clusters[j].ID })
+	return g
+}
+
+// clusterParent returns the epic whose cluster encloses epicID's cluster,
+// or "" if it is top level or its parent chain has a cycle.
+func clusterParent(epicID string, parentOf map[string]string) string {
+	seen := map[string]bool{epicID: true}
+	for id := parentOf[epicID]; id != ""; id = parentOf[id] {
+		if seen[id] {
+			return ""
+		}
+		seen[id] = true
+	}
+	return parentOf[epicID]
+}
+
+// mergeSubgraphs combines the components shown by bd graph --all into one
+// subgraph, rooted at the first component's root.
+func mergeSubgraphs(subgraphs []*TemplateSubgraph) *TemplateSubgraph {
+	merged := &TemplateSubgraph{IssueMap: make(map[string]*types.Issue)}
+	for _, sg := range subgraphs {
+		if merged.Root == nil {
+			merged.Root = sg.Root
+		}
+		merged.Issues = append(merged.Issues, sg.Issues...)
+		merged.Dependencies = append(merged.Dependencies, sg.Dependencies...)
+		for id, issue := range sg.IssueMap {
+			merged.IssueMap[id] = issue
+		}
+	}
+	if merged.Root == nil {
+		merged.Root = &types.Issue{}
+	}
+	return merged
+}
+
+// writeGraphExport writes g in the given --format.
+func writeGraphExport(w io.Writer, g *GraphExport, format string) error {
+	switch format {
+	case "dot":
+		return writeGraphDOT(w, g)
+	case "mermaid":
+		return writeGraphMermaid(w, g)
+	case "svg":
+		return writeGraphSVG(w, g)
+	case "json":
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		enc.SetEscapeHTML(false)
+		return enc.Encode(g)
+	}
+	return fmt.Errorf("unknown graph format %q (valid: %s)", format, strings.Join(graphFormats, ", "))
+}
+
+// clusterMembers indexes nodes and child clusters by the cluster that holds them.
+func (g *GraphExport) clusterMembers() (nodes map[string][]*GraphExportNode, children map[string][]*GraphExportCluster) {
+	nodes = make(map[string][]*GraphExportNode)
+	children = make(map[string][]*GraphExportCluster)
+	for _, n := range g.Nodes {
+		nodes[n.Cluster] = append(nodes[n.Cluster], n)
+	}
+	for _, c := range g.Clusters {
+		children[c.Parent] = append(children[c.Parent], c)
+	}
+	return nodes, children
+}
+
+// ===== Graphviz DOT =====
+
+func dotQuote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	s = strings.ReplaceAll(s, "\n", `\n`)
+	return `"` + s + `"`
+}
+
+func writeGraphDOT(w io.Writer, g *GraphExport) error {
+	var b strings.Builder
+	b.WriteString("digraph beads {\n")
+	b.WriteString("  rankdir=LR;\n")
+	b.WriteString("  node [shape=box, style=\"rounded,filled\", fontname=\"Helvetica\", fontsize=11];\n")
+	b.WriteString("  edge [fontname=\"Helvetica\", fontsize=9];\n")
+
+	nodes, children := g.clusterMembers()
+	var writeCluster func(c *GraphExportCluster, indent string)
+	writeNodes := func(list []*GraphExportNode, indent string) {
+		for _, n := range list {
+			fill, text := graphStatusColors(n.Status)
+			border, width := graphPriorityBorder(n.Priority)
+			label := fmt.Sprintf("%s\n%s\nP%d · %s", n.ID, truncateTitle(n.Title, 40), n.Priority, n.Status)
+			fmt.Fprintf(&b, "%s%s [label=%s, fillcolor=%s, fontcolor=%s, color=%s, penwidth=%g];\n",
+				indent, dotQuote(n.ID), dotQuote(label), dotQuote(fill), dotQuote(text), dotQuote(border), width)
+		}
+	}
+	writeCluster = func(c *GraphExportCluster, indent string) {
+		fmt.Fprintf(&b, "%ssubgraph %s {\n", indent, dotQuote("cluster_"+c.ID))
+		fmt.Fprintf(&b, "%s  label=%s;\n", indent, dotQuote(c.ID+": "+truncateTitle(c.Title, 50)))
+		fmt.Fprintf(&b, "%s  style=\"rounded,dashed\";\n", indent)
+		fmt.Fprintf(&b, "%s  color=\"#9ca3af\";\n", indent)
+		writeNodes(nodes[c.ID], indent+"  ")
+		for _, child := range children[c.ID] {
+			writeCluster(child, indent+"  ")
+		}
+		fmt.Fprintf(&b, "%s}\n", indent)
+	}
+	for _, c := range children[""] {
+		writeCluster(c, "  ")
+	}
+	writeNodes(nodes[""], "  ")
+
+	for _, e := range g.Edges {
+		s := edgeStyle(e.Type)
+		attrs := []string{"style=" + s.DOT, "color=" + dotQuote(s.Color)}
+		if !s.Arrow {
+			attrs = append(attrs, "dir=none")
+		}
+		if s.Label != "" {
+			attrs = append(attrs, "label="+dotQuote(s.Label), "fontcolor="+dotQuote(s.Color))
+		}
+		fmt.Fprintf(&b, "  %s -> %s [%s];\n", dotQuote(e.From), dotQuote(e.To), strings.Join(attrs, ", "))
+	}
+	b.WriteString("}\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// ===== Mermaid =====
+
+// mermaidText escapes text for a quoted Mermaid label.
+func mermaidText(s string) string {
+	return strings.NewReplacer(`"`, "#quot;", "<", "#lt;", ">", "#gt;", "\n", " ").Replace(s)
+}
+
+func writeGraphMermaid(w io.Writer, g *GraphExport) error {
+	// Issue IDs contain '-', which Mermaid reads as part of a link, so nodes
+	// get positional IDs
+	ids := make(map[string]string, len(g.Nodes))
+	for i, n := range g.Nodes {
+		ids[n.ID] = fmt.Sprintf("n%d", i)
+	}
+
+	var b strings.Builder
+	b.WriteString("flowchart LR\n")
+
+	nodes, children := g.clusterMembers()
+	writeNodes := func(list []*GraphExportNode, indent string) {
+		for _, n := range list {
+			fmt.Fprintf(&b, "%s%s[\"%s<br/>%s<br/>P%d · %s\"]\n", indent, ids[n.ID],
+				mermaidText(n.ID), mermaidText(truncateTitle(n.Title, 40)), n.Priority, n.Status)
+		}
+	}
+	var writeCluster func(c *GraphExportCluster, indent string)
+	writeCluster = func(c *GraphExportCluster, indent string) {
+		fmt.Fprintf(&b, "%ssubgraph c_%s [\"%s\"]\n", indent, ids[c.ID], mermaidText(c.ID+": "+truncateTitle(c.Title, 50)))
+		writeNodes(nodes[c.ID], indent+"  ")
+		for _, child := range children[c.ID] {
+			writeCluster(child, indent+"  ")
+		}
+		fmt.Fprintf(&b, "%send\n", indent)
+	}
+	for _, c := range children[""] {
+		writeCluster(c, "  ")
+	}
+	writeNodes(nodes[""], "  ")
+
+	for _, e := range g.Edges {
+		s := edgeStyle(e.Type)
+		if s.Label != "" {
+			fmt.Fprintf(&b, "  %s %s|%s| %s\n", ids[e.From], s.Mermaid, mermaidText(s.Label), ids[e.To])
+		} else {
+			fmt.Fprintf(&b, "  %s %s %s\n", ids[e.From], s.Mermaid, ids[e.To])
+		}
+	}
+	for i, e := range g.Edges {
+		fmt.Fprintf(&b, "  linkStyle %d stroke:%s\n", i, edgeStyle(e.Type).Color)
+	}
+
+	for _, n := range g.Nodes {
+		fill, text := graphStatusColors(n.Status)
+		border, width := graphPriorityBorder(n.Priority)
+		fmt.Fprintf(&b, "  style %s fill:%s,color:%s,stroke:%s,stroke-width:%gpx\n", ids[n.ID], fill, text, border, width)
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// ===== SVG =====
+
+// SVG layout: one column per layer, and one horizontal band per cluster so
+// cluster boxes never overlap. Nested clusters are banded inside their parent.
+const (
+	svgMargin   = 20
+	svgNodeW    = 200
+	svgNodeH    = 54
+	svgColGap   = 70
+	svgRowGap   = 14
+	svgInset    = 12 // Padding between nested cluster boxes
+	svgHeaderH  = 22 // Cluster title height
+	svgLegendH  = 30
+	svgFontSize = 11
+)
+
+var svgLegendTypes = []types.DependencyType{
+	types.DepBlocks, types.DepParentChild, types.DepConditionalBlocks, types.DepWaitsFor, types.DepRelated,
+}
+
+func svgLegendEntryWidth(depType types.DependencyType) int {
+	return 30 + 6*len(depType) + 16 // Sample line, label at ~6px per character, gap
+}
+
+func svgLegendWidth() int {
+	w := 0
+	for _, depType := range svgLegendTypes {
+		w += svgLegendEntryWidth(depType)
+	}
+	return w
+}
+
+type svgBox struct {
+	X, Y, W, H int
+}
+
+type svgLayout struct {
+	nodes    map[string]svgBox
+	clusters []svgCluster
+	width    int
+	height   int
+}
+
+type svgCluster struct {
+	*GraphExportCluster
+	Box svgBox
+}
+
+func layoutGraphSVG(g *GraphExport) *svgLayout {
+	nodes, children := g.clusterMembers()
+	maxLayer, maxDepth := 0, 0
+	for _, n := range g.Nodes {
+		if n.Layer > maxLayer {
+			maxLayer = n.Layer
+		}
+	}
+	var depthOf func(id string, depth int)
+	depthOf = func(id string, depth int) {
+		if depth > maxDepth {
+			maxDepth = depth
+		}
+		for _, c := range children[id] {
+			depthOf(c.ID, depth+1)
+		}
+	}
+	depthOf("", 0)
+
+	l := &svgLayout{nodes: make(map[string]svgBox)}
+	left := svgMargin + maxDepth*svgInset
+	columnsW := (maxLayer+1)*svgNodeW + maxLayer*svgColGap
+	l.width = 2*left + columnsW
+	if legendW := 2*svgMargin + svgLegendWidth(); legendW > l.width {
+		l.width = legendW
+	}
+
+	// placeNodes stacks nodes in their layer columns starting at y and
+	// returns the y below them.
+	placeNodes := func(list []*GraphExportNode, y int) int {
+		rows := make(map[int]int)
+		bottom := y
+		for _, n := range list {
+			box := svgBox{
+				X: left + n.Layer*(svgNodeW+svgColGap),
+				Y: y + rows[n.Layer]*(svgNodeH+svgRowGap),
+				W: svgNodeW,
+				H: svgNodeH,
+			}
+			rows[n.Layer]++
+			l.nodes[n.ID] = box
+			if box.Y+box.H > bottom {
+				bottom = box.Y + box.H
+			}
+		}
+		return bottom
+	}
+
+	var placeCluster func(c *GraphExportCluster, depth, y int) int
+	placeCluster = func(c *GraphExportCluster, depth, y int) int {
+		inner := placeNodes(nodes[c.ID], y+svgHeaderH)
+		for _, child := range children[c.ID] {
+			inner = placeCluster(child, depth+1, inner+svgRowGap)
+		}
+		x := svgMargin + (depth-1)*svgInset
+		l.clusters = append(l.clusters, svgCluster{c, svgBox{X: x, Y: y, W: l.width - 2*x, H: inner + svgInset - y}})
+		return inner + svgInset
+	}
+
+	y := svgMargin
+	for _, c := range children[""] {
+		y = placeCluster(c, 1, y) + svgRowGap
+	}
+	y = placeNodes(nodes[""], y)
+	l.height = y + svgMargin + svgLegendH
+
+	// Outer clusters first so inner ones are drawn on top
+	sort.SliceStable(l.clusters, func(i, j int) bool { return l.clusters[i].Box.X < l.clusters[j].Box.X })
+	return l
+}
+
+func writeGraphSVG(w io.Writer, g *GraphExport) error {
+	l := layoutGraphSVG(g)
+	esc := html.EscapeString
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="Helvetica, Arial, sans-serif" font-size="%d">`+"\n",
+		l.width, l.height, l.width, l.height, svgFontSize)
+
+	// One arrowhead marker per edge color
+	b.WriteString("<defs>\n")
+	markers := map[string]string{}
+	for _, e := range g.Edges {
+		color := edgeStyle(e.Type).Color
+		if _, ok := markers[color]; ok {
+			continue
+		}
+		id := fmt.Sprintf("arrow%d", len(markers))
+		markers[color] = id
+		fmt.Fprintf(&b, `<marker id="%s" viewBox="0 0 10 10" refX="10" refY="5" markerWidth="7" markerHeight="7" orient="auto-start-reverse"><path d="M0,0 L10,5 L0,10 z" fill="%s"/></marker>`+"\n", id, color)
+	}
+	b.WriteString("</defs>\n")
+	fmt.Fprintf(&b, `<rect width="100%%" height="100%%" fill="#ffffff"/>`+"\n")
+
+	for _, c := range l.clusters {
+		fmt.Fprintf(&b, `<g class="cluster" id="cluster-%s"><rect x="%d" y="%d" width="%d" height="%d" rx="8" fill="#f9fafb" fill-opacity="0.6" stroke="#9ca3af" stroke-dasharray="5 3"/>`,
+			esc(c.ID), c.Box.X, c.Box.Y, c.Box.W, c.Box.H)
+		fmt.Fprintf(&b, `<text x="%d" y="%d" fill="#4b5563" font-weight="bold">%s</text></g>`+"\n",
+			c.Box.X+8, c.Box.Y+15, esc(c.ID+": "+truncateTitle(c.Title, 60)))
+	}
+
+	for _, e := range g.Edges {
+		from, okFrom := l.nodes[e.From]
+		to, okTo := l.nodes[e.To]
+		if !okFrom || !okTo {
+			continue
+		}
+		s := edgeStyle(e.Type)
+		x1, y1 := from.X+from.W, from.Y+from.H/2
+		x2, y2 := to.X, to.Y+to.H/2
+		dx := (x2 - x1) / 2
+		if dx < 40 {
+			dx = 40
+		}
+		fmt.Fprintf(&b, `<path class="edge edge-%s" d="M%d,%d C%d,%d %d,%d %d,%d" fill="none" stroke="%s" stroke-width="1.5"`,
+			esc(e.Type), x1, y1, x1+dx, y1, x2-dx, y2, x2, y2, s.Color)
+		if s.Dash != "" {
+			fmt.Fprintf(&b, ` stroke-dasharray="%s"`, s.Dash)
+		}
+		if s.Arrow {
+			fmt.Fprintf(&b, ` marker-end="url(#%s)"`, markers[s.Color])
+		}
+		fmt.Fprintf(&b, "><title>%s %s %s</title></path>\n", esc(e.From), esc(e.Type), esc(e.To))
+	}
+
+	for _, n := range g.Nodes {
+		box := l.nodes[n.ID]
+		fill, text := graphStatusColors(n.Status)
+		border, width := graphPriorityBorder(n.Priority)
+		fmt.Fprintf(&b, `<g class="node status-%s" id="node-%s"><title>%s</title>`, esc(n.Status), esc(n.ID), esc(n.ID+": "+n.Title))
+		fmt.Fprintf(&b, `<rect x="%d" y="%d" width="%d" height="%d" rx="6" fill="%s" stroke="%s" stroke-width="%g"/>`,
+			box.X, box.Y, box.W, box.H, fill, border, width)
+		fmt.Fprintf(&b, `<text x="%d" y="%d" fill="%s" font-weight="bold">%s</text>`, box.X+8, box.Y+16, text, esc(n.ID))
+		fmt.Fprintf(&b, `<text x="%d" y="%d" fill="%s">%s</text>`, box.X+8, box.Y+31, text, esc(truncateTitle(n.Title, 30)))
+		fmt.Fprintf(&b, `<text x="%d" y="%d" fill="%s" font-size="9">P%d · %s</text></g>`+"\n", box.X+8, box.Y+45, text, n.Priority, esc(n.Status))
+	}
+
+	// Legend: one sample line per edge type
+	x, y := svgMargin, l.height-svgMargin
+	for _, depType := range svgLegendTypes {
+		s := edgeStyle(string(depType))
+		fmt.Fprintf(&b, `<line x1="%d" y1="%d" x2="%d" y2="%d" stroke="%s" stroke-width="1.5"`, x, y-4, x+24, y-4, s.Color)
+		if s.Dash != "" {
+			fmt.Fprintf(&b, ` stroke-dasharray="%s"`, s.Dash)
+		}
+		fmt.Fprintf(&b, `/><text x="%d" y="%d" fill="#4b5563" font-size="10">%s</text>`+"\n", x+30, y, depType)
+		x += svgLegendEntryWidth(depType)
+	}
+
+	b.WriteString("</svg>\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
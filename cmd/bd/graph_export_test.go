@@ -0,0 +1,214 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// exportTestSubgraph builds an epic containing a sub-epic, with one edge of
+// each styled dependency type.
+func exportTestSubgraph() *TemplateSubgraph {
+	issues := []*types.Issue{
+		{ID: "bd-1", Title: "Auth epic", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeEpic},
+		{ID: "bd-2", Title: "Sessions", Status: types.StatusInProgress, Priority: 2, IssueType: types.TypeEpic},
+		{ID: "bd-3", Title: `Schema "v2" <draft>`, Status: types.StatusClosed, Priority: 0, IssueType: types.TypeTask},
+		{ID: "bd-4", Title: "Login form", Status: types.StatusBlocked, Priority: 2, IssueType: types.TypeTask},
+		{ID: "bd-5", Title: "Rollback", Status: types.StatusOpen, Priority: 3, IssueType: types.TypeTask},
+		{ID: "bd-6", Title: "Docs", Status: types.StatusOpen, Priority: 4, IssueType: types.TypeTask},
+	}
+	deps := []*types.Dependency{
+		{IssueID: "bd-2", DependsOnID: "bd-1", Type: types.DepParentChild},
+		{IssueID: "bd-3", DependsOnID: "bd-1", Type: types.DepParentChild},
+		{IssueID: "bd-4", DependsOnID: "bd-2", Type: types.DepParentChild},
+		{IssueID: "bd-4", DependsOnID: "bd-3", Type: types.DepBlocks},
+		{IssueID: "bd-5", DependsOnID: "bd-4", Type: types.DepConditionalBlocks},
+		{IssueID: "bd-2", DependsOnID: "bd-3", Type: types.DepWaitsFor},
+		{IssueID: "bd-6", DependsOnID: "bd-4", Type: types.DepRelated},
+		{IssueID: "bd-6", DependsOnID: "bd-5", Type: types.DepDiscoveredFrom},
+	}
+	sg := &TemplateSubgraph{Root: issues[0], Issues: issues, Dependencies: deps, IssueMap: map[string]*types.Issue{}}
+	for _, issue := range issues {
+		sg.IssueMap[issue.ID] = issue
+	}
+	return sg
+}
+
+func TestBuildGraphExport(t *testing.T) {
+	sg := exportTestSubgraph()
+	g := buildGraphExport(sg, computeLayout(sg))
+
+	if g.Root != "bd-1" || len(g.Nodes) != 6 || len(g.Edges) != 8 {
+		t.Fatalf("export has root %q, %d nodes, %d edges; want bd-1, 6, 8", g.Root, len(g.Nodes), len(g.Edges))
+	}
+
+	clusters := map[string]string{}
+	for _, n := range g.Nodes {
+		clusters[n.ID] = n.Cluster
+	}
+	want := map[string]string{"bd-1": "bd-1", "bd-2": "bd-2", "bd-3": "bd-1", "bd-4": "bd-2", "bd-5": "", "bd-6": ""}
+	for id, cluster := range want {
+		if clusters[id] != cluster {
+			t.Errorf("node %s in cluster %q, want %q", id, clusters[id], cluster)
+		}
+	}
+	if len(g.Clusters) != 2 || g.Clusters[0].Parent != "" || g.Clusters[1].ID != "bd-2" || g.Clusters[1].Parent != "bd-1" {
+		t.Errorf("clusters = %+v %+v, want bd-1 enclosing bd-2", g.Clusters[0], g.Clusters[1])
+	}
+
+	// Edges point from the prerequisite to the dependent issue
+	found := false
+	for _, e := range g.Edges {
+		if e.Type == string(types.DepBlocks) {
+			found = e.From == "bd-3" && e.To == "bd-4"
+		}
+	}
+	if !found {
+		t.Error("expected blocks edge bd-3 -> bd-4")
+	}
+}
+
+func TestClusterParentCycle(t *testing.T) {
+	parentOf := map[string]string{"bd-1": "bd-2", "bd-2": "bd-1", "bd-3": "bd-1"}
+	if got := clusterParent("bd-1", parentOf); got != "" {
+		t.Errorf("clusterParent in a cycle = %q, want top level", got)
+	}
+	parentOf["bd-4"] = "bd-5"
+	if got := clusterParent("bd-4", parentOf); got != "bd-5" {
+		t.Errorf("clusterParent(bd-4) = %q, want bd-5", got)
+	}
+}
+
+func TestWriteGraphExport(t *testing.T) {
+	sg := exportTestSubgraph()
+	g := buildGraphExport(sg, computeLayout(sg))
+	render := func(format string) string {
+		t.Helper()
+		var buf bytes.Buffer
+		if err := writeGraphExport(&buf, g, format); err != nil {
+			t.Fatalf("writeGraphExport(%s) failed: %v", format, err)
+		}
+		return buf.String()
+	}
+
+	t.Run("dot", func(t *testing.T) {
+		out := render("dot")
+		for _, want := range []string{
+			`subgraph "cluster_bd-1" {`,
+			`    subgraph "cluster_bd-2" {`, // Nested in bd-1
+			`"bd-3" [label="bd-3\nSchema \"v2\" <draft>\nP0 · closed"`,
+			`"bd-3" -> "bd-4" [style=solid`,
+			`"bd-4" -> "bd-5" [style=bold, color="#d97706", label="if fails"`,
+			`"bd-3" -> "bd-2" [style=dotted, color="#2563eb"`,
+			`"bd-4" -> "bd-6" [style=dotted, color="#a855f7", dir=none]`,
+			`label="discovered-from"`,
+		} {
+			if !strings.Contains(out, want) {
+				t.Errorf("DOT output missing %q:\n%s", want, out)
+			}
+		}
+		if strings.Count(out, "{") != strings.Count(out, "}") {
+			t.Errorf("DOT output has unbalanced braces:\n%s", out)
+		}
+	})
+
+	t.Run("mermaid", func(t *testing.T) {
+		out := render("mermaid")
+		if !strings.HasPrefix(out, "flowchart LR\n") {
+			t.Errorf("Mermaid output should start with flowchart LR:\n%s", out)
+		}
+		for _, want := range []string{
+			`Schema #quot;v2#quot; #lt;draft#gt;`,
+			" ==>|if fails| ",
+			" --o|waits for| ",
+			" -.- ",
+			"linkStyle 7 stroke:",
+			"fill:#fee2e2", // Blocked
+		} {
+			if !strings.Contains(out, want) {
+				t.Errorf("Mermaid output missing %q:\n%s", want, out)
+			}
+		}
+		if strings.Count(out, "subgraph ") != strings.Count(out, "end\n") {
+			t.Errorf("Mermaid subgraphs are not closed:\n%s", out)
+		}
+		if strings.Contains(out, "bd-3 -->") {
+			t.Errorf("Mermaid edges should use positional node IDs:\n%s", out)
+		}
+	})
+
+	t.Run("svg", func(t *testing.T) {
+		out := render("svg")
+		dec := xml.NewDecoder(strings.NewReader(out))
+		for {
+			if _, err := dec.Token(); err == io.EOF {
+				break
+			} else if err != nil {
+				t.Fatalf("SVG is not well-formed XML: %v\n%s", err, out)
+			}
+		}
+		if got := strings.Count(out, `<g class="node `); got != 6 {
+			t.Errorf("SVG has %d nodes, want 6", got)
+		}
+		if got := strings.Count(out, `<g class="cluster"`); got != 2 {
+			t.Errorf("SVG has %d clusters, want 2", got)
+		}
+		for _, want := range []string{`class="edge edge-waits-for"`, `stroke-dasharray="2 3"`, "Schema &#34;v2&#34; &lt;draft&gt;"} {
+			if !strings.Contains(out, want) {
+				t.Errorf("SVG output missing %q", want)
+			}
+		}
+	})
+
+	t.Run("json", func(t *testing.T) {
+		var decoded GraphExport
+		if err := json.Unmarshal([]byte(render("json")), &decoded); err != nil {
+			t.Fatalf("invalid JSON: %v", err)
+		}
+		if len(decoded.Nodes) != 6 || len(decoded.Edges) != 8 || len(decoded.Clusters) != 2 {
+			t.Errorf("decoded %d nodes, %d edges, %d clusters", len(decoded.Nodes), len(decoded.Edges), len(decoded.Clusters))
+		}
+	})
+
+	if err := writeGraphExport(io.Discard, g, "png"); err == nil {
+		t.Error("expected unknown format to fail")
+	}
+}
+
+func TestSVGClustersDoNotOverlapNodes(t *testing.T) {
+	sg := exportTestSubgraph()
+	g := buildGraphExport(sg, computeLayout(sg))
+	l := layoutGraphSVG(g)
+
+	inside := func(n, c svgBox) bool {
+		return n.X >= c.X && n.Y >= c.Y && n.X+n.W <= c.X+c.W && n.Y+n.H <= c.Y+c.H
+	}
+	for _, node := range g.Nodes {
+		box := l.nodes[node.ID]
+		for _, c := range l.clusters {
+			// A node is inside exactly the clusters that enclose it
+			want := false
+			for id := node.Cluster; id != ""; {
+				if id == c.ID {
+					want = true
+					break
+				}
+				parent := ""
+				for _, other := range g.Clusters {
+					if other.ID == id {
+						parent = other.Parent
+					}
+				}
+				id = parent
+			}
+			if got := inside(box, c.Box); got != want {
+				t.Errorf("node %s inside cluster %s = %v, want %v", node.ID, c.ID, got, want)
+			}
+		}
+	}
+}
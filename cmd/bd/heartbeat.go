@@ -0,0 +1,123 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/rpc"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+// defaultHeartbeatLease is how far bd heartbeat pushes a lease without --lease.
+const defaultHeartbeatLease = 30 * time.Minute
+
+var heartbeatCmd = &cobra.Command{
+	Use:     "heartbeat [id...]",
+	GroupID: "issues",
+	Short:   "Renew the lease on issues you claimed",
+	Long: `Renew the lease on issues claimed with 'bd update --claim --lease'.
+
+A leased claim lapses when its deadline passes: bd ready lists the issue again,
+anyone can claim it, and the daemon returns it to open (recording a
+lease_expired event and running the on_lease_expired hook). Agents working on
+a long task should send a heartbeat well before the lease runs out:
+
+  bd update bd-42 --claim --lease 30m
+  bd heartbeat bd-42                # Lease now ends 30 minutes from now
+  bd heartbeat bd-42 --lease 2h
+
+Only the current assignee can renew, and only while the issue is in progress.
+A heartbeat on a claim made without --lease turns it into a leased claim.`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		CheckReadonly("heartbeat")
+		lease, _ := cmd.Flags().GetDuration("lease")
+		if lease <= 0 {
+			FatalErrorRespectJSON("--lease must be a positive duration (e.g. 30m)")
+		}
+
+		var renewed []*types.Issue
+		failed := false
+		for _, id := range args {
+			var issue *types.Issue
+			var err error
+			if daemonClient != nil {
+				issue, err = heartbeatViaDaemon(id, lease)
+			} else {
+				issue, err = heartbeatDirect(id, lease)
+			}
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error renewing lease on %s: %v\n", id, err)
+				failed = true
+				continue
+			}
+			renewed = append(renewed, issue)
+			if !jsonOutput {
+				fmt.Printf("%s Renewed lease on %s until %s\n", ui.RenderPass("✓"), issue.ID,
+					issue.LeaseExpiresAt.Local().Format("15:04:05"))
+			}
+		}
+
+		if jsonOutput && len(renewed) > 0 {
+			outputJSON(renewed)
+		}
+		if failed {
+			os.Exit(1)
+		}
+	},
+}
+
+func heartbeatViaDaemon(id string, lease time.Duration) (*types.Issue, error) {
+	resp, err := daemonClient.ResolveID(&rpc.ResolveIDArgs{ID: id})
+	if err != nil {
+		return nil, err
+	}
+	var resolvedID string
+	if err := json.Unmarshal(resp.Data, &resolvedID); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal resolved ID: %w", err)
+	}
+	resp, err = daemonClient.Heartbeat(&rpc.HeartbeatArgs{ID: resolvedID, Lease: lease.String()})
+	if err != nil {
+		return nil, err
+	}
+	var issue types.Issue
+	if err := json.Unmarshal(resp.Data, &issue); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal issue: %w", err)
+	}
+	return &issue, nil
+}
+
+func heartbeatDirect(id string, lease time.Duration) (*types.Issue, error) {
+	if store == nil {
+		return nil, fmt.Errorf("database not initialized")
+	}
+	fullID, err := utils.ResolvePartialID(rootCtx, store, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := store.RenewLease(rootCtx, fullID, actor, *leaseDeadline(lease)); err != nil {
+		return nil, err
+	}
+	return store.GetIssue(rootCtx, fullID)
+}
+
+// leaseDeadline returns the deadline of a lease taken now, or nil for a
+// claim without a lease.
+func leaseDeadline(lease time.Duration) *time.Time {
+	if lease <= 0 {
+		return nil
+	}
+	deadline := time.Now().Add(lease)
+	return &deadline
+}
+
+func init() {
+	heartbeatCmd.Flags().Duration("lease", defaultHeartbeatLease, "New lease length, counted from now")
+	heartbeatCmd.ValidArgsFunction = issueIDCompletion
+	rootCmd.AddCommand(heartbeatCmd)
+}
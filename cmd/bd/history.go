@@ -15,12 +15,13 @@ var (
 var historyCmd = &cobra.Command{
 	Use:     "history <id>",
 	GroupID: "views",
-	Short:   "Show version history for an issue (requires Dolt backend)",
+	Short:   "Show version history for an issue",
 	Long: `Show the complete version history of an issue, including all commits
 where the issue was modified.
 
-This command requires the Dolt storage backend. If you're using SQLite,
-you'll see an error message suggesting to use Dolt for versioning features.
+With the Dolt backend the history comes from Dolt's commit graph. With
+SQLite it comes from the git history of the JSONL export, so it only
+includes changes that were committed to git (after bd sync or the hooks).
 
 Examples:
   bd history bd-123           # Show all history for issue bd-123
@@ -30,14 +31,10 @@ Examples:
 		ctx := rootCtx
 		issueID := args[0]
 
-		// Check if storage supports versioning
-		vs, ok := storage.AsVersioned(store)
-		if !ok {
-			FatalErrorRespectJSON("history requires Dolt backend (current backend does not support versioning)")
-		}
+		hs := requireHistoryStorage("history")
 
 		// Get issue history
-		history, err := vs.History(ctx, issueID)
+		history, err := hs.History(ctx, issueID)
 		if err != nil {
 			FatalErrorRespectJSON("failed to get history: %v", err)
 		}
@@ -77,6 +74,8 @@ Examples:
 					entry.Issue.Title,
 					entry.Issue.Priority,
 					entry.Issue.Status)
+			} else {
+				fmt.Printf("  %s\n", ui.RenderMuted("(deleted)"))
 			}
 
 			// Separator between entries
@@ -88,6 +87,20 @@ Examples:
 	},
 }
 
+// requireHistoryStorage returns the store as a HistoryStorage, exiting if
+// the backend can't answer history queries. The daemon doesn't serve them,
+// so the command switches to direct mode first.
+func requireHistoryStorage(command string) storage.HistoryStorage {
+	if err := ensureDirectMode(command + " reads version history directly"); err != nil {
+		FatalErrorRespectJSON("%s: %v", command, err)
+	}
+	hs, ok := storage.AsHistory(store)
+	if !ok {
+		FatalErrorRespectJSON("%s is not supported by the current storage backend", command)
+	}
+	return hs
+}
+
 func init() {
 	historyCmd.Flags().IntVar(&historyLimit, "limit", 0, "Limit number of history entries (0 = all)")
 	historyCmd.ValidArgsFunction = issueIDCompletion
@@ -28,6 +28,7 @@ var jsonlFilePaths = []string{
 	".beads/issues.jsonl",
 	".beads/deletions.jsonl",
 	".beads/interactions.jsonl",
+	".beads/views.jsonl",
 	".beads/beads.jsonl", // Legacy filename, kept for backwards compatibility
 }
 
@@ -463,6 +463,24 @@ func computeDBHash(ctx context.Context, store storage.Storage) (string, error) {
 		issue.Comments = comments
 	}
 
+	// Populate work log
+	for _, issue := range issues {
+		workLog, err := store.GetWorkLog(ctx, issue.ID)
+		if err != nil {
+			return "", fmt.Errorf("failed to get work log for %s: %w", issue.ID, err)
+		}
+		issue.WorkLog = workLog
+	}
+
+	// Populate attachments
+	for _, issue := range issues {
+		attachments, err := store.GetAttachments(ctx, issue.ID)
+		if err != nil {
+			return "", fmt.Errorf("failed to get attachments for %s: %w", issue.ID, err)
+		}
+		issue.Attachments = attachments
+	}
+
 	// Serialize to JSON and hash
 	var buf bytes.Buffer
 	encoder := json.NewEncoder(&buf)
@@ -1,294 +1,483 @@
 package main
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
 	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/jira"
+	"github.com/steveyegge/beads/internal/tracker"
+	"github.com/steveyegge/beads/internal/types"
 )
 
-func TestIsJiraExternalRef(t *testing.T) {
-	tests := []struct {
-		name        string
-		externalRef string
-		jiraURL     string
-		want        bool
-	}{
-		{
-			name:        "valid Jira Cloud URL",
-			externalRef: "https://company.atlassian.net/browse/PROJ-123",
-			jiraURL:     "https://company.atlassian.net",
-			want:        true,
-		},
-		{
-			name:        "valid Jira Cloud URL with trailing slash in config",
-			externalRef: "https://company.atlassian.net/browse/PROJ-123",
-			jiraURL:     "https://company.atlassian.net/",
-			want:        true,
-		},
-		{
-			name:        "valid Jira Server URL",
-			externalRef: "https://jira.company.com/browse/PROJ-456",
-			jiraURL:     "https://jira.company.com",
-			want:        true,
-		},
-		{
-			name:        "mismatched Jira host",
-			externalRef: "https://other.atlassian.net/browse/PROJ-123",
-			jiraURL:     "https://company.atlassian.net",
-			want:        false,
-		},
-		{
-			name:        "GitHub issue URL",
-			externalRef: "https://github.com/org/repo/issues/123",
-			jiraURL:     "https://company.atlassian.net",
-			want:        false,
-		},
-		{
-			name:        "empty external_ref",
-			externalRef: "",
-			jiraURL:     "https://company.atlassian.net",
-			want:        false,
-		},
-		{
-			name:        "no jiraURL configured - valid pattern",
-			externalRef: "https://any.atlassian.net/browse/PROJ-123",
-			jiraURL:     "",
-			want:        true,
-		},
-		{
-			name:        "no jiraURL configured - invalid pattern",
-			externalRef: "https://github.com/org/repo/issues/123",
-			jiraURL:     "",
-			want:        false,
-		},
-		{
-			name:        "browse in path but not Jira format",
-			externalRef: "https://example.com/browse/docs/page",
-			jiraURL:     "",
-			want:        true, // Contains /browse/, so matches pattern
-		},
-		{
-			name:        "browse in path with jiraURL check",
-			externalRef: "https://example.com/browse/docs/page",
-			jiraURL:     "https://company.atlassian.net",
-			want:        false, // Host doesn't match
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			got := isJiraExternalRef(tt.externalRef, tt.jiraURL)
-			if got != tt.want {
-				t.Errorf("isJiraExternalRef(%q, %q) = %v, want %v",
-					tt.externalRef, tt.jiraURL, got, tt.want)
-			}
+const jiraTimeFormat = "2006-01-02T15:04:05.000-0700"
+
+// fakeJira is a minimal in-memory stand-in for the Jira REST API (v2).
+type fakeJira struct {
+	mu          sync.Mutex
+	issues      map[string]*jira.Issue
+	nextID      int
+	jqls        []string
+	updated     []string
+	transitions []string
+}
+
+// fakeJiraStatuses are the workflow statuses every fake issue can move to.
+var fakeJiraStatuses = []string{"To Do", "In Progress", "Blocked", "Done"}
+
+func newFakeJira(t *testing.T) (*fakeJira, *httptest.Server) {
+	t.Helper()
+	fake := &fakeJira{issues: make(map[string]*jira.Issue), nextID: 1}
+	server := httptest.NewServer(http.HandlerFunc(fake.serve))
+	t.Cleanup(server.Close)
+	return fake, server
+}
+
+func jiraTime(t time.Time) string {
+	return t.UTC().Format(jiraTimeFormat)
+}
+
+func (f *fakeJira) add(issue jira.Issue) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.issues[issue.Key] = &issue
+	f.nextID++
+}
+
+func (f *fakeJira) get(key string) jira.Issue {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return *f.issues[key]
+}
+
+func (f *fakeJira) serve(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	path := strings.TrimPrefix(r.URL.Path, "/rest/api/2")
+	w.Header().Set("Content-Type", "application/json")
+
+	switch {
+	case path == "/search" && r.Method == http.MethodGet:
+		f.jqls = append(f.jqls, r.URL.Query().Get("jql"))
+		list := []*jira.Issue{}
+		for _, issue := range f.issues {
+			list = append(list, issue)
+		}
+		sort.Slice(list, func(i, j int) bool { return list[i].Key < list[j].Key })
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"startAt": 0, "maxResults": len(list), "total": len(list), "issues": list,
 		})
+
+	case path == "/issue" && r.Method == http.MethodPost:
+		key := fmt.Sprintf("PROJ-%d", f.nextID)
+		f.nextID++
+		issue := &jira.Issue{Key: key, Fields: jira.IssueFields{
+			Status:  &jira.Status{Name: "To Do"},
+			Created: jiraTime(time.Now()),
+		}}
+		f.apply(issue, r)
+		f.issues[key] = issue
+		_ = json.NewEncoder(w).Encode(map[string]string{"id": "1", "key": key})
+
+	case strings.HasPrefix(path, "/issue/") && strings.HasSuffix(path, "/transitions"):
+		key := strings.TrimSuffix(strings.TrimPrefix(path, "/issue/"), "/transitions")
+		issue := f.issues[key]
+		if r.Method == http.MethodGet {
+			var transitions []jira.Transition
+			for i, name := range fakeJiraStatuses {
+				transitions = append(transitions, jira.Transition{ID: fmt.Sprint(i), Name: name, To: jira.Status{Name: name}})
+			}
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"transitions": transitions})
+			return
+		}
+		var body struct {
+			Transition struct {
+				ID string `json:"id"`
+			} `json:"transition"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		var idx int
+		_, _ = fmt.Sscanf(body.Transition.ID, "%d", &idx)
+		issue.Fields.Status = &jira.Status{Name: fakeJiraStatuses[idx]}
+		issue.Fields.Updated = jiraTime(time.Now())
+		f.transitions = append(f.transitions, key+"->"+fakeJiraStatuses[idx])
+		w.WriteHeader(http.StatusNoContent)
+
+	case strings.HasPrefix(path, "/issue/"):
+		key := strings.TrimPrefix(path, "/issue/")
+		issue, ok := f.issues[key]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"errorMessages":["Issue does not exist"]}`)
+			return
+		}
+		if r.Method == http.MethodPut {
+			f.apply(issue, r)
+			f.updated = append(f.updated, key)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(issue)
+
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprintf(w, `{"errorMessages":["unexpected %s %s"]}`, r.Method, r.URL.Path)
 	}
 }
 
-func TestJiraSyncStats(t *testing.T) {
-	// Test that stats struct initializes correctly
-	stats := JiraSyncStats{}
+// apply copies a create/update payload onto a stored issue, as Jira would.
+func (f *fakeJira) apply(issue *jira.Issue, r *http.Request) {
+	var body struct {
+		Fields struct {
+			Summary     string          `json:"summary"`
+			Description json.RawMessage `json:"description"`
+			IssueType   *jira.IssueType `json:"issuetype"`
+			Priority    *jira.Priority  `json:"priority"`
+			Labels      []string        `json:"labels"`
+		} `json:"fields"`
+	}
+	_ = json.NewDecoder(r.Body).Decode(&body)
+	issue.Fields.Summary = body.Fields.Summary
+	issue.Fields.Description = body.Fields.Description
+	issue.Fields.IssueType = body.Fields.IssueType
+	issue.Fields.Priority = body.Fields.Priority
+	issue.Fields.Labels = body.Fields.Labels
+	issue.Fields.Updated = jiraTime(time.Now())
+}
+
+// setupJiraSyncTest points the package-level store at a fresh database
+// configured to talk to the given fake server.
+func setupJiraSyncTest(t *testing.T, serverURL string) context.Context {
+	t.Helper()
+	testStore, cleanup := setupTestDB(t)
+	t.Cleanup(cleanup)
 
-	if stats.Pulled != 0 {
-		t.Errorf("expected Pulled to be 0, got %d", stats.Pulled)
+	ctx := context.Background()
+	for key, value := range map[string]string{
+		"jira.url":       serverURL,
+		"jira.project":   "PROJ",
+		"jira.api_token": "test-token",
+	} {
+		if err := testStore.SetConfig(ctx, key, value); err != nil {
+			t.Fatalf("SetConfig %s failed: %v", key, err)
+		}
 	}
-	if stats.Pushed != 0 {
-		t.Errorf("expected Pushed to be 0, got %d", stats.Pushed)
+
+	origStore := store
+	origActor := actor
+	store = testStore
+	actor = "test-actor"
+	t.Cleanup(func() {
+		store = origStore
+		actor = origActor
+	})
+	return ctx
+}
+
+// newJiraTestEngine returns a quiet sync engine for the configured project.
+func newJiraTestEngine(t *testing.T, ctx context.Context) *tracker.Engine {
+	t.Helper()
+	engine, err := newJiraEngine(ctx)
+	if err != nil {
+		t.Fatalf("newJiraEngine failed: %v", err)
 	}
-	if stats.Created != 0 {
-		t.Errorf("expected Created to be 0, got %d", stats.Created)
+	engine.Out = io.Discard
+	engine.Err = io.Discard
+	return engine
+}
+
+// findByJiraKey returns the local issue linked to a Jira issue key.
+func findByJiraKey(t *testing.T, ctx context.Context, serverURL, key string) *types.Issue {
+	t.Helper()
+	issues, err := store.SearchIssues(ctx, "", types.IssueFilter{})
+	if err != nil {
+		t.Fatalf("SearchIssues failed: %v", err)
 	}
-	if stats.Updated != 0 {
-		t.Errorf("expected Updated to be 0, got %d", stats.Updated)
+	for _, issue := range issues {
+		if issue.ExternalRef != nil && *issue.ExternalRef == jira.FormatExternalRef(serverURL, key) {
+			if issue.Labels, err = store.GetLabels(ctx, issue.ID); err != nil {
+				t.Fatalf("GetLabels failed: %v", err)
+			}
+			return issue
+		}
 	}
-	if stats.Skipped != 0 {
-		t.Errorf("expected Skipped to be 0, got %d", stats.Skipped)
+	t.Fatalf("no local issue linked to %s", key)
+	return nil
+}
+
+func TestJiraPull(t *testing.T) {
+	fake, server := newFakeJira(t)
+	ctx := setupJiraSyncTest(t, server.URL)
+
+	created := time.Now().Add(-48 * time.Hour)
+	fake.add(jira.Issue{Key: "PROJ-1", Fields: jira.IssueFields{
+		Summary:     "Login times out",
+		Description: json.RawMessage(`"After 30s"`),
+		Status:      &jira.Status{Name: "In Progress"},
+		Priority:    &jira.Priority{Name: "High"},
+		IssueType:   &jira.IssueType{Name: "Bug"},
+		Assignee:    &jira.User{DisplayName: "Ada Lovelace"},
+		Labels:      []string{"auth", "backend"},
+		Created:     jiraTime(created),
+		Updated:     jiraTime(created),
+	}})
+	fake.add(jira.Issue{Key: "PROJ-2", Fields: jira.IssueFields{
+		Summary:        "Old request",
+		Status:         &jira.Status{Name: "Done"},
+		Created:        jiraTime(created),
+		Updated:        jiraTime(created.Add(time.Hour)),
+		ResolutionDate: jiraTime(created.Add(time.Hour)),
+	}})
+
+	engine := newJiraTestEngine(t, ctx)
+	stats, err := engine.Pull(ctx, tracker.PullOptions{State: "all"})
+	if err != nil {
+		t.Fatalf("Pull failed: %v", err)
 	}
-	if stats.Errors != 0 {
-		t.Errorf("expected Errors to be 0, got %d", stats.Errors)
+	if stats.Created != 2 || stats.Incremental {
+		t.Fatalf("stats = %+v, want 2 created in a full sync", stats)
 	}
-	if stats.Conflicts != 0 {
-		t.Errorf("expected Conflicts to be 0, got %d", stats.Conflicts)
+
+	first := findByJiraKey(t, ctx, server.URL, "PROJ-1")
+	if first.Title != "Login times out" || first.Description != "After 30s" {
+		t.Errorf("unexpected content: %+v", first)
+	}
+	if first.Status != types.StatusInProgress || first.Priority != 1 || first.IssueType != types.TypeBug {
+		t.Errorf("status/priority/type = %s/%d/%s", first.Status, first.Priority, first.IssueType)
+	}
+	if first.Assignee != "Ada Lovelace" {
+		t.Errorf("Assignee = %q", first.Assignee)
+	}
+	sort.Strings(first.Labels)
+	if strings.Join(first.Labels, ",") != "auth,backend" {
+		t.Errorf("Labels = %v", first.Labels)
+	}
+	if second := findByJiraKey(t, ctx, server.URL, "PROJ-2"); second.Status != types.StatusClosed {
+		t.Errorf("PROJ-2 status = %s, want closed", second.Status)
 	}
-}
 
-func TestJiraSyncResult(t *testing.T) {
-	// Test result struct initialization
-	result := JiraSyncResult{
-		Success: true,
-		Stats: JiraSyncStats{
-			Created: 5,
-			Updated: 3,
-		},
-		LastSync: "2025-01-15T10:30:00Z",
+	// After a sync, pulls are incremental and pick up Jira edits.
+	if err := store.SetConfig(ctx, "jira.last_sync", time.Now().Add(-time.Minute).Format(time.RFC3339)); err != nil {
+		t.Fatalf("SetConfig failed: %v", err)
 	}
+	issue := fake.get("PROJ-1")
+	issue.Fields.Priority = &jira.Priority{Name: "Highest"}
+	issue.Fields.Labels = []string{"auth"}
+	issue.Fields.Updated = jiraTime(time.Now().Add(time.Second))
+	fake.add(issue)
 
-	if !result.Success {
-		t.Error("expected Success to be true")
+	stats, err = engine.Pull(ctx, tracker.PullOptions{State: "open"})
+	if err != nil {
+		t.Fatalf("second Pull failed: %v", err)
 	}
-	if result.Stats.Created != 5 {
-		t.Errorf("expected Created to be 5, got %d", result.Stats.Created)
+	if !stats.Incremental {
+		t.Error("expected incremental pull")
 	}
-	if result.Stats.Updated != 3 {
-		t.Errorf("expected Updated to be 3, got %d", result.Stats.Updated)
+	lastJQL := fake.jqls[len(fake.jqls)-1]
+	if !strings.Contains(lastJQL, "statusCategory != Done") || !strings.Contains(lastJQL, `updated >= "-`) {
+		t.Errorf("incremental JQL = %q", lastJQL)
 	}
-	if result.LastSync != "2025-01-15T10:30:00Z" {
-		t.Errorf("unexpected LastSync value: %s", result.LastSync)
+
+	first = findByJiraKey(t, ctx, server.URL, "PROJ-1")
+	if first.Priority != 0 {
+		t.Errorf("Priority = %d, want 0 from Highest", first.Priority)
 	}
-	if result.Error != "" {
-		t.Errorf("expected Error to be empty, got %s", result.Error)
+	if strings.Join(first.Labels, ",") != "auth" {
+		t.Errorf("Labels = %v, want removed Jira labels dropped", first.Labels)
 	}
-	if len(result.Warnings) != 0 {
-		t.Errorf("expected Warnings to be empty, got %v", result.Warnings)
+}
+
+func TestJiraPullSkipsKeys(t *testing.T) {
+	fake, server := newFakeJira(t)
+	ctx := setupJiraSyncTest(t, server.URL)
+
+	now := jiraTime(time.Now())
+	fake.add(jira.Issue{Key: "PROJ-1", Fields: jira.IssueFields{Summary: "Keep", Created: now, Updated: now}})
+	fake.add(jira.Issue{Key: "PROJ-2", Fields: jira.IssueFields{Summary: "Skip", Created: now, Updated: now}})
+
+	stats, err := newJiraTestEngine(t, ctx).Pull(ctx, tracker.PullOptions{State: "all", SkipIDs: map[string]bool{"PROJ-2": true}})
+	if err != nil {
+		t.Fatalf("Pull failed: %v", err)
+	}
+	if stats.Created != 1 || stats.Skipped != 1 {
+		t.Errorf("stats = %+v, want 1 created and 1 skipped", stats)
 	}
 }
 
-func TestPullStats(t *testing.T) {
-	stats := PullStats{
-		Created: 10,
-		Updated: 5,
-		Skipped: 2,
+func TestJiraPush(t *testing.T) {
+	fake, server := newFakeJira(t)
+	ctx := setupJiraSyncTest(t, server.URL)
+
+	issue := &types.Issue{
+		Title:       "Add SSO",
+		Description: "Support SAML",
+		Notes:       "Check Okta",
+		Priority:    1,
+		IssueType:   types.TypeFeature,
+		Status:      types.StatusInProgress,
+	}
+	if err := store.CreateIssue(ctx, issue, actor); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	if err := store.AddLabel(ctx, issue.ID, "auth", actor); err != nil {
+		t.Fatalf("AddLabel failed: %v", err)
 	}
 
-	if stats.Created != 10 {
-		t.Errorf("expected Created to be 10, got %d", stats.Created)
+	engine := newJiraTestEngine(t, ctx)
+	stats, err := engine.Push(ctx, tracker.PushOptions{UpdateRefs: true})
+	if err != nil {
+		t.Fatalf("Push failed: %v", err)
 	}
-	if stats.Updated != 5 {
-		t.Errorf("expected Updated to be 5, got %d", stats.Updated)
+	if stats.Created != 1 || stats.Errors != 0 {
+		t.Fatalf("stats = %+v, want 1 created", stats)
 	}
-	if stats.Skipped != 2 {
-		t.Errorf("expected Skipped to be 2, got %d", stats.Skipped)
+
+	remote := fake.get("PROJ-1")
+	if got := remote.Fields.DescriptionText(); got != "Support SAML\n\n## Notes\nCheck Okta" {
+		t.Errorf("Description = %q", got)
+	}
+	if remote.Fields.IssueType.Name != "Story" || remote.Fields.Priority.Name != "High" {
+		t.Errorf("type/priority = %s/%s", remote.Fields.IssueType.Name, remote.Fields.Priority.Name)
+	}
+	if strings.Join(remote.Fields.Labels, ",") != "auth" {
+		t.Errorf("Labels = %v", remote.Fields.Labels)
+	}
+	if remote.Fields.Status.Name != "In Progress" {
+		t.Errorf("Status = %s, want transitioned to In Progress", remote.Fields.Status.Name)
 	}
-}
 
-func TestPushStats(t *testing.T) {
-	stats := PushStats{
-		Created: 8,
-		Updated: 4,
-		Skipped: 1,
-		Errors:  2,
+	local, err := store.GetIssue(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if local.ExternalRef == nil || *local.ExternalRef != server.URL+"/browse/PROJ-1" {
+		t.Fatalf("ExternalRef = %v", local.ExternalRef)
 	}
 
-	if stats.Created != 8 {
-		t.Errorf("expected Created to be 8, got %d", stats.Created)
+	// Nothing changed: the linked issue is skipped.
+	stats, err = engine.Push(ctx, tracker.PushOptions{UpdateRefs: true})
+	if err != nil {
+		t.Fatalf("second Push failed: %v", err)
+	}
+	if stats.Updated != 0 || len(fake.updated) != 0 {
+		t.Errorf("expected no updates, got stats=%+v updated=%v", stats, fake.updated)
 	}
-	if stats.Updated != 4 {
-		t.Errorf("expected Updated to be 4, got %d", stats.Updated)
+
+	// Closing locally transitions the Jira issue.
+	time.Sleep(10 * time.Millisecond)
+	if err := store.CloseIssue(ctx, issue.ID, "done", actor, ""); err != nil {
+		t.Fatalf("CloseIssue failed: %v", err)
 	}
-	if stats.Skipped != 1 {
-		t.Errorf("expected Skipped to be 1, got %d", stats.Skipped)
+	stats, err = engine.Push(ctx, tracker.PushOptions{UpdateRefs: true})
+	if err != nil {
+		t.Fatalf("third Push failed: %v", err)
 	}
-	if stats.Errors != 2 {
-		t.Errorf("expected Errors to be 2, got %d", stats.Errors)
+	if stats.Updated != 1 {
+		t.Fatalf("Updated = %d, want 1", stats.Updated)
+	}
+	if remote := fake.get("PROJ-1"); remote.Fields.Status.Name != "Done" {
+		t.Errorf("remote status = %s, want Done", remote.Fields.Status.Name)
 	}
 }
 
-func TestExtractJiraKey(t *testing.T) {
-	tests := []struct {
-		name        string
-		externalRef string
-		want        string
-	}{
-		{
-			name:        "standard Jira Cloud URL",
-			externalRef: "https://company.atlassian.net/browse/PROJ-123",
-			want:        "PROJ-123",
-		},
-		{
-			name:        "Jira Server URL",
-			externalRef: "https://jira.company.com/browse/ISSUE-456",
-			want:        "ISSUE-456",
-		},
-		{
-			name:        "URL with trailing path",
-			externalRef: "https://company.atlassian.net/browse/ABC-789/some/path",
-			want:        "ABC-789/some/path",
-		},
-		{
-			name:        "no browse pattern",
-			externalRef: "https://github.com/org/repo/issues/123",
-			want:        "",
-		},
-		{
-			name:        "empty string",
-			externalRef: "",
-			want:        "",
-		},
-		{
-			name:        "only browse",
-			externalRef: "https://example.com/browse/",
-			want:        "",
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			got := extractJiraKey(tt.externalRef)
-			if got != tt.want {
-				t.Errorf("extractJiraKey(%q) = %q, want %q", tt.externalRef, got, tt.want)
-			}
-		})
+func TestJiraConflictsNewerWins(t *testing.T) {
+	fake, server := newFakeJira(t)
+	ctx := setupJiraSyncTest(t, server.URL)
+
+	old := jiraTime(time.Now().Add(-time.Hour))
+	fake.add(jira.Issue{Key: "PROJ-1", Fields: jira.IssueFields{Summary: "Original", Created: old, Updated: old}})
+	fake.add(jira.Issue{Key: "PROJ-2", Fields: jira.IssueFields{Summary: "Second", Created: old, Updated: old}})
+	engine := newJiraTestEngine(t, ctx)
+	if _, err := engine.Pull(ctx, tracker.PullOptions{State: "all"}); err != nil {
+		t.Fatalf("Pull failed: %v", err)
+	}
+	if err := store.SetConfig(ctx, "jira.last_sync", time.Now().Add(-time.Minute).Format(time.RFC3339)); err != nil {
+		t.Fatalf("SetConfig failed: %v", err)
+	}
+
+	// PROJ-1: edited locally, then in Jira (Jira newer).
+	// PROJ-2: edited in Jira, then locally (local newer).
+	first := findByJiraKey(t, ctx, server.URL, "PROJ-1")
+	second := findByJiraKey(t, ctx, server.URL, "PROJ-2")
+	if err := store.UpdateIssue(ctx, first.ID, map[string]interface{}{"title": "Local edit"}, actor); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	remote := fake.get("PROJ-1")
+	remote.Fields.Summary = "Jira edit"
+	remote.Fields.Labels = []string{"hot"}
+	remote.Fields.Updated = jiraTime(time.Now().Add(time.Minute))
+	fake.add(remote)
+
+	remote = fake.get("PROJ-2")
+	remote.Fields.Summary = "Jira edit 2"
+	remote.Fields.Updated = jiraTime(time.Now())
+	fake.add(remote)
+	time.Sleep(10 * time.Millisecond)
+	if err := store.UpdateIssue(ctx, second.ID, map[string]interface{}{"title": "Local edit 2"}, actor); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+
+	conflicts, err := engine.DetectConflicts(ctx)
+	if err != nil {
+		t.Fatalf("DetectConflicts failed: %v", err)
+	}
+	if len(conflicts) != 2 {
+		t.Fatalf("got %d conflicts, want 2: %+v", len(conflicts), conflicts)
+	}
+
+	jiraWins, localWins := tracker.SplitConflictsByTimestamp(conflicts)
+	if len(jiraWins) != 1 || jiraWins[0].RemoteID != "PROJ-1" {
+		t.Fatalf("jiraWins = %+v, want PROJ-1", jiraWins)
+	}
+	if len(localWins) != 1 || localWins[0].RemoteID != "PROJ-2" {
+		t.Fatalf("localWins = %+v, want PROJ-2", localWins)
+	}
+
+	if err := engine.ApplyRemote(ctx, jiraWins); err != nil {
+		t.Fatalf("ApplyRemote failed: %v", err)
+	}
+
+	first = findByJiraKey(t, ctx, server.URL, "PROJ-1")
+	if first.Title != "Jira edit" || strings.Join(first.Labels, ",") != "hot" {
+		t.Errorf("PROJ-1 should take Jira version, got title=%q labels=%v", first.Title, first.Labels)
+	}
+	second = findByJiraKey(t, ctx, server.URL, "PROJ-2")
+	if second.Title != "Local edit 2" {
+		t.Errorf("PROJ-2 should keep local version, got %q", second.Title)
+	}
+
+	// Pushing with the local-wins IDs forced overwrites Jira.
+	force := map[string]bool{second.ID: true}
+	skip := map[string]bool{first.ID: true}
+	if _, err := engine.Push(ctx, tracker.PushOptions{UpdateRefs: true, ForceIDs: force, SkipIDs: skip}); err != nil {
+		t.Fatalf("Push failed: %v", err)
+	}
+	if got := fake.get("PROJ-2").Fields.Summary; got != "Local edit 2" {
+		t.Errorf("PROJ-2 summary = %q, want local edit pushed", got)
+	}
+	if got := fake.get("PROJ-1").Fields.Summary; got != "Jira edit" {
+		t.Errorf("PROJ-1 summary = %q, want untouched", got)
 	}
 }
 
-func TestParseJiraTimestamp(t *testing.T) {
-	tests := []struct {
-		name      string
-		timestamp string
-		wantErr   bool
-		wantYear  int
-	}{
-		{
-			name:      "standard Jira Cloud format with milliseconds",
-			timestamp: "2024-01-15T10:30:00.000+0000",
-			wantErr:   false,
-			wantYear:  2024,
-		},
-		{
-			name:      "Jira format with Z suffix",
-			timestamp: "2024-01-15T10:30:00.000Z",
-			wantErr:   false,
-			wantYear:  2024,
-		},
-		{
-			name:      "without milliseconds",
-			timestamp: "2024-01-15T10:30:00+0000",
-			wantErr:   false,
-			wantYear:  2024,
-		},
-		{
-			name:      "RFC3339 format",
-			timestamp: "2024-01-15T10:30:00Z",
-			wantErr:   false,
-			wantYear:  2024,
-		},
-		{
-			name:      "empty string",
-			timestamp: "",
-			wantErr:   true,
-		},
-		{
-			name:      "invalid format",
-			timestamp: "not-a-timestamp",
-			wantErr:   true,
-		},
-		{
-			name:      "with negative timezone offset",
-			timestamp: "2024-06-15T10:30:00.000-0500",
-			wantErr:   false,
-			wantYear:  2024,
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			got, err := parseJiraTimestamp(tt.timestamp)
-			if (err != nil) != tt.wantErr {
-				t.Errorf("parseJiraTimestamp(%q) error = %v, wantErr %v", tt.timestamp, err, tt.wantErr)
-				return
-			}
-			if !tt.wantErr && got.Year() != tt.wantYear {
-				t.Errorf("parseJiraTimestamp(%q) year = %d, want %d", tt.timestamp, got.Year(), tt.wantYear)
-			}
-		})
+func TestJiraConfigToEnvVar(t *testing.T) {
+	tests := map[string]string{
+		"jira.api_token": "JIRA_API_TOKEN",
+		"jira.username":  "JIRA_USERNAME",
+		"jira.url":       "",
+	}
+	for key, want := range tests {
+		if got := jiraConfigToEnvVar(key); got != want {
+			t.Errorf("jiraConfigToEnvVar(%q) = %q, want %q", key, got, want)
+		}
 	}
 }
@@ -13,6 +13,7 @@ import (
 	"github.com/steveyegge/beads/internal/debug"
 	"github.com/steveyegge/beads/internal/linear"
 	"github.com/steveyegge/beads/internal/storage/sqlite"
+	"github.com/steveyegge/beads/internal/tracker"
 	"github.com/steveyegge/beads/internal/types"
 )
 
@@ -87,9 +88,11 @@ Type Filtering (--push only):
   --exclude-type wisp    Exclude issues of these types
 
 Conflict Resolution:
+  Issues changed on both sides since the last sync are conflicts.
   By default, newer timestamp wins. Override with:
-  --prefer-local    Always prefer local beads version
-  --prefer-linear   Always prefer Linear version
+  --prefer-local            Always prefer local beads version
+  --prefer-linear           Always prefer Linear version
+  --conflict-policy=manual  Leave conflicts unchanged and report them
 
 Examples:
   bd linear sync --pull                         # Import from Linear
@@ -128,16 +131,7 @@ Example:
 }
 
 func init() {
-	linearSyncCmd.Flags().Bool("pull", false, "Pull issues from Linear")
-	linearSyncCmd.Flags().Bool("push", false, "Push issues to Linear")
-	linearSyncCmd.Flags().Bool("dry-run", false, "Preview sync without making changes")
-	linearSyncCmd.Flags().Bool("prefer-local", false, "Prefer local version on conflicts")
-	linearSyncCmd.Flags().Bool("prefer-linear", false, "Prefer Linear version on conflicts")
-	linearSyncCmd.Flags().Bool("create-only", false, "Only create new issues, don't update existing")
-	linearSyncCmd.Flags().Bool("update-refs", true, "Update external_ref after creating Linear issues")
-	linearSyncCmd.Flags().String("state", "all", "Issue state to sync: open, closed, all")
-	linearSyncCmd.Flags().StringSlice("type", nil, "Only sync issues of these types (can be repeated)")
-	linearSyncCmd.Flags().StringSlice("exclude-type", nil, "Exclude issues of these types (can be repeated)")
+	addTrackerSyncFlags(linearSyncCmd, "linear", "Linear")
 
 	linearCmd.AddCommand(linearSyncCmd)
 	linearCmd.AddCommand(linearStatusCmd)
@@ -146,228 +140,33 @@ func init() {
 }
 
 func runLinearSync(cmd *cobra.Command, args []string) {
-	pull, _ := cmd.Flags().GetBool("pull")
-	push, _ := cmd.Flags().GetBool("push")
-	dryRun, _ := cmd.Flags().GetBool("dry-run")
-	preferLocal, _ := cmd.Flags().GetBool("prefer-local")
-	preferLinear, _ := cmd.Flags().GetBool("prefer-linear")
-	createOnly, _ := cmd.Flags().GetBool("create-only")
-	updateRefs, _ := cmd.Flags().GetBool("update-refs")
-	state, _ := cmd.Flags().GetString("state")
-	typeFilters, _ := cmd.Flags().GetStringSlice("type")
-	excludeTypes, _ := cmd.Flags().GetStringSlice("exclude-type")
-
-	if !dryRun {
-		CheckReadonly("linear sync")
-	}
-
-	if preferLocal && preferLinear {
-		fmt.Fprintf(os.Stderr, "Error: cannot use both --prefer-local and --prefer-linear\n")
-		os.Exit(1)
-	}
-
-	if err := ensureStoreActive(); err != nil {
-		fmt.Fprintf(os.Stderr, "Error: database not available: %v\n", err)
-		os.Exit(1)
-	}
-
-	if err := validateLinearConfig(); err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(1)
-	}
-
-	if !pull && !push {
-		pull = true
-		push = true
-	}
-
-	ctx := rootCtx
-	result := &linear.SyncResult{Success: true}
-	var forceUpdateIDs map[string]bool
-	var skipUpdateIDs map[string]bool
-	var prePullConflicts []linear.Conflict
-	var prePullSkipLinearIDs map[string]bool
-
-	if pull {
-		if preferLocal || preferLinear {
-			conflicts, err := detectLinearConflicts(ctx)
-			if err != nil {
-				result.Warnings = append(result.Warnings, fmt.Sprintf("conflict detection failed: %v", err))
-			} else if len(conflicts) > 0 {
-				prePullConflicts = conflicts
-				if preferLocal {
-					prePullSkipLinearIDs = make(map[string]bool, len(conflicts))
-					forceUpdateIDs = make(map[string]bool, len(conflicts))
-					for _, conflict := range conflicts {
-						prePullSkipLinearIDs[conflict.LinearIdentifier] = true
-						forceUpdateIDs[conflict.IssueID] = true
-					}
-				} else if preferLinear {
-					skipUpdateIDs = make(map[string]bool, len(conflicts))
-					for _, conflict := range conflicts {
-						skipUpdateIDs[conflict.IssueID] = true
-					}
-				}
-			}
-		}
-
-		if dryRun {
-			fmt.Println("→ [DRY RUN] Would pull issues from Linear")
-		} else {
-			fmt.Println("→ Pulling issues from Linear...")
-		}
-
-		pullStats, err := doPullFromLinear(ctx, dryRun, state, prePullSkipLinearIDs)
-		if err != nil {
-			result.Success = false
-			result.Error = err.Error()
-			if jsonOutput {
-				outputJSON(result)
-			} else {
-				fmt.Fprintf(os.Stderr, "Error pulling from Linear: %v\n", err)
-			}
-			os.Exit(1)
-		}
-
-		result.Stats.Pulled = pullStats.Created + pullStats.Updated
-		result.Stats.Created += pullStats.Created
-		result.Stats.Updated += pullStats.Updated
-		result.Stats.Skipped += pullStats.Skipped
-
-		if !dryRun {
-			fmt.Printf("✓ Pulled %d issues (%d created, %d updated)\n",
-				result.Stats.Pulled, pullStats.Created, pullStats.Updated)
-		}
-	}
-
-	if pull && push {
-		conflicts := prePullConflicts
-		var err error
-		if conflicts == nil {
-			conflicts, err = detectLinearConflicts(ctx)
-		}
-		if err != nil {
-			result.Warnings = append(result.Warnings, fmt.Sprintf("conflict detection failed: %v", err))
-		} else if len(conflicts) > 0 {
-			result.Stats.Conflicts = len(conflicts)
-			if dryRun {
-				if preferLocal {
-					fmt.Printf("→ [DRY RUN] Would resolve %d conflicts (preferring local)\n", len(conflicts))
-					forceUpdateIDs = make(map[string]bool, len(conflicts))
-					for _, conflict := range conflicts {
-						forceUpdateIDs[conflict.IssueID] = true
-					}
-				} else if preferLinear {
-					fmt.Printf("→ [DRY RUN] Would resolve %d conflicts (preferring Linear)\n", len(conflicts))
-					skipUpdateIDs = make(map[string]bool, len(conflicts))
-					for _, conflict := range conflicts {
-						skipUpdateIDs[conflict.IssueID] = true
-					}
-				} else {
-					fmt.Printf("→ [DRY RUN] Would resolve %d conflicts (newer wins)\n", len(conflicts))
-					var linearWins []linear.Conflict
-					var localWins []linear.Conflict
-					for _, conflict := range conflicts {
-						if conflict.LinearUpdated.After(conflict.LocalUpdated) {
-							linearWins = append(linearWins, conflict)
-						} else {
-							localWins = append(localWins, conflict)
-						}
-					}
-					if len(localWins) > 0 {
-						forceUpdateIDs = make(map[string]bool, len(localWins))
-						for _, conflict := range localWins {
-							forceUpdateIDs[conflict.IssueID] = true
-						}
-					}
-					if len(linearWins) > 0 {
-						skipUpdateIDs = make(map[string]bool, len(linearWins))
-						for _, conflict := range linearWins {
-							skipUpdateIDs[conflict.IssueID] = true
-						}
-					}
-				}
-			} else if preferLocal {
-				fmt.Printf("→ Resolving %d conflicts (preferring local)\n", len(conflicts))
-				if forceUpdateIDs == nil {
-					forceUpdateIDs = make(map[string]bool, len(conflicts))
-					for _, conflict := range conflicts {
-						forceUpdateIDs[conflict.IssueID] = true
-					}
-				}
-			} else if preferLinear {
-				fmt.Printf("→ Resolving %d conflicts (preferring Linear)\n", len(conflicts))
-				if skipUpdateIDs == nil {
-					skipUpdateIDs = make(map[string]bool, len(conflicts))
-					for _, conflict := range conflicts {
-						skipUpdateIDs[conflict.IssueID] = true
-					}
-				}
-				if prePullConflicts == nil {
-					if err := reimportLinearConflicts(ctx, conflicts); err != nil {
-						result.Warnings = append(result.Warnings, fmt.Sprintf("conflict resolution failed: %v", err))
-					}
-				}
-			} else {
-				fmt.Printf("→ Resolving %d conflicts (newer wins)\n", len(conflicts))
-				if err := resolveLinearConflictsByTimestamp(ctx, conflicts); err != nil {
-					result.Warnings = append(result.Warnings, fmt.Sprintf("conflict resolution failed: %v", err))
-				}
-			}
-		}
-	}
-
-	if push {
-		if dryRun {
-			fmt.Println("→ [DRY RUN] Would push issues to Linear")
-		} else {
-			fmt.Println("→ Pushing issues to Linear...")
-		}
-
-		pushStats, err := doPushToLinear(ctx, dryRun, createOnly, updateRefs, forceUpdateIDs, skipUpdateIDs, typeFilters, excludeTypes)
-		if err != nil {
-			result.Success = false
-			result.Error = err.Error()
-			if jsonOutput {
-				outputJSON(result)
-			} else {
-				fmt.Fprintf(os.Stderr, "Error pushing to Linear: %v\n", err)
-			}
-			os.Exit(1)
-		}
-
-		result.Stats.Pushed = pushStats.Created + pushStats.Updated
-		result.Stats.Created += pushStats.Created
-		result.Stats.Updated += pushStats.Updated
-		result.Stats.Skipped += pushStats.Skipped
-		result.Stats.Errors += pushStats.Errors
+	runTrackerSync(cmd, "linear", validateLinearConfig, newLinearEngine)
+}
 
-		if !dryRun {
-			fmt.Printf("✓ Pushed %d issues (%d created, %d updated)\n",
-				result.Stats.Pushed, pushStats.Created, pushStats.Updated)
-		}
+// newLinearEngine creates a sync engine for the configured team, honoring
+// linear.id_mode and linear.hash_length for pulled issues.
+func newLinearEngine(ctx context.Context) (*tracker.Engine, error) {
+	client, err := getLinearClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Linear client: %w", err)
 	}
 
-	if !dryRun && result.Success {
-		result.LastSync = time.Now().Format(time.RFC3339)
-		if err := store.SetConfig(ctx, "linear.last_sync", result.LastSync); err != nil {
-			result.Warnings = append(result.Warnings, fmt.Sprintf("failed to update last_sync: %v", err))
+	engine := newTrackerEngine(ctx, linear.NewAdapter(client, loadLinearMappingConfig(ctx)))
+	switch idMode := getLinearIDMode(ctx); idMode {
+	case "hash":
+		idOpts := linear.IDGenerationOptions{
+			BaseLength: getLinearHashLength(ctx),
+			MaxLength:  8,
 		}
-	}
-
-	if jsonOutput {
-		outputJSON(result)
-	} else if dryRun {
-		fmt.Println("\n✓ Dry run complete (no changes made)")
-	} else {
-		fmt.Println("\n✓ Linear sync complete")
-		if len(result.Warnings) > 0 {
-			fmt.Println("\nWarnings:")
-			for _, w := range result.Warnings {
-				fmt.Printf("  - %s\n", w)
-			}
+		engine.GenerateIDs = func(issues []*types.Issue) error {
+			return generateTrackerIDs(ctx, issues, "linear-import", idOpts)
 		}
+	case "db":
+		engine.GenerateIDs = nil
+	default:
+		return nil, fmt.Errorf("unsupported linear.id_mode %q (expected \"hash\" or \"db\")", idMode)
 	}
+	return engine, nil
 }
 
 func runLinearStatus(cmd *cobra.Command, args []string) {
@@ -653,4 +452,3 @@ func getLinearHashLength(ctx context.Context) int {
 	}
 	return value
 }
-
@@ -13,6 +13,7 @@ import (
 	"time"
 
 	"github.com/steveyegge/beads/internal/linear"
+	"github.com/steveyegge/beads/internal/tracker"
 	"github.com/steveyegge/beads/internal/types"
 )
 
@@ -1219,10 +1220,15 @@ func TestDoPushToLinearPreferLocalForcesUpdate(t *testing.T) {
 		actor = origActor
 	})
 
+	engine, err := newLinearEngine(ctx)
+	if err != nil {
+		t.Fatalf("newLinearEngine failed: %v", err)
+	}
+	engine.Out = io.Discard
 	forceUpdateIDs := map[string]bool{issue.ID: true}
-	stats, err := doPushToLinear(ctx, false, false, true, forceUpdateIDs, nil, nil, nil)
+	stats, err := engine.Push(ctx, tracker.PushOptions{UpdateRefs: true, ForceIDs: forceUpdateIDs})
 	if err != nil {
-		t.Fatalf("doPushToLinear failed: %v", err)
+		t.Fatalf("Push failed: %v", err)
 	}
 	if !updatedCalled {
 		t.Fatal("expected UpdateIssue to be called when force-update is enabled")
@@ -419,6 +419,9 @@ func sortIssues(issues []*types.Issue, sortBy string, reverse bool) {
 		return
 	}
 
+	// Anything that isn't a built-in field may be a custom field
+	customField := customFieldSorter(sortBy)
+
 	slices.SortFunc(issues, func(a, b *types.Issue) int {
 		var result int
 
@@ -455,8 +458,12 @@ func sortIssues(issues []*types.Issue, sortBy string, reverse bool) {
 		case "assignee":
 			result = cmp.Compare(a.Assignee, b.Assignee)
 		default:
-			// Unknown sort field, no sorting
-			result = 0
+			if customField != nil {
+				result = customField(a, b)
+			} else {
+				// Unknown sort field, no sorting
+				result = 0
+			}
 		}
 
 		if reverse {
@@ -539,6 +546,11 @@ var listCmd = &cobra.Command{
 	GroupID: "issues",
 	Short:   "List issues",
 	Run: func(cmd *cobra.Command, args []string) {
+		// Saved view: fills in the flags that weren't given explicitly
+		if viewName, _ := cmd.Flags().GetString("view"); viewName != "" {
+			applyView(cmd, loadView(rootCtx, viewName))
+		}
+
 		status, _ := cmd.Flags().GetString("status")
 		assignee, _ := cmd.Flags().GetString("assignee")
 		issueType, _ := cmd.Flags().GetString("type")
@@ -553,6 +565,10 @@ var listCmd = &cobra.Command{
 		longFormat, _ := cmd.Flags().GetBool("long")
 		sortBy, _ := cmd.Flags().GetString("sort")
 		reverse, _ := cmd.Flags().GetBool("reverse")
+		columns, _ := cmd.Flags().GetStringSlice("columns")
+		if err := validateViewColumns(columns); err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
 
 		// Pattern matching flags
 		titleContains, _ := cmd.Flags().GetString("title-contains")
@@ -606,12 +622,19 @@ var listCmd = &cobra.Command{
 
 		// Time-based scheduling filters (GH#820)
 		deferredFlag, _ := cmd.Flags().GetBool("deferred")
+		recurringFlag, _ := cmd.Flags().GetBool("recurring")
 		deferAfter, _ := cmd.Flags().GetString("defer-after")
 		deferBefore, _ := cmd.Flags().GetString("defer-before")
 		dueAfter, _ := cmd.Flags().GetString("due-after")
 		dueBefore, _ := cmd.Flags().GetString("due-before")
 		overdueFlag, _ := cmd.Flags().GetBool("overdue")
 
+		// Custom field filters
+		fieldFilters := parseFieldFilterFlags(cmd)
+
+		// Filter expression
+		where := parseWhereFlag(cmd)
+
 		// Pretty and watch flags (GH#654)
 		prettyFormat, _ := cmd.Flags().GetBool("pretty")
 		treeFormat, _ := cmd.Flags().GetBool("tree")
@@ -665,8 +688,9 @@ var listCmd = &cobra.Command{
 			filter.Status = &s
 		}
 
-		// Default to non-closed issues unless --all or explicit --status (GH#788)
-		if status == "" && !allFlag && !readyFlag {
+		// Default to non-closed issues unless --all or explicit --status (GH#788),
+		// including a status test in --where
+		if status == "" && !allFlag && !readyFlag && (where == nil || !where.References("status")) {
 			filter.ExcludeStatus = []types.Status{types.StatusClosed}
 		}
 		// Use Changed() to properly handle P0 (priority=0)
@@ -832,6 +856,13 @@ var listCmd = &cobra.Command{
 		if deferredFlag {
 			filter.Deferred = true
 		}
+		if recurringFlag {
+			filter.Recurring = true
+		}
+		filter.CustomFields = fieldFilters
+		if where != nil {
+			filter.Where = where.String()
+		}
 		if deferAfter != "" {
 			t, err := parseTimeFlag(deferAfter)
 			if err != nil {
@@ -868,6 +899,14 @@ var listCmd = &cobra.Command{
 			filter.Overdue = true
 		}
 
+		// --format reads dependency records, which needs the database
+		if formatStr != "" && daemonClient != nil {
+			if err := ensureDirectMode("list --format requires direct database access"); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+		}
+
 		// Check database freshness before reading
 		// Skip check when using daemon (daemon auto-imports on staleness)
 		ctx := rootCtx
@@ -973,6 +1012,9 @@ var listCmd = &cobra.Command{
 
 			// Time-based scheduling filters (GH#820)
 			listArgs.Deferred = filter.Deferred
+			listArgs.Recurring = filter.Recurring
+			listArgs.CustomFields = filter.CustomFields
+			listArgs.Where = filter.Where
 			if filter.DeferAfter != nil {
 				listArgs.DeferAfter = filter.DeferAfter.Format(time.RFC3339)
 			}
@@ -1077,7 +1119,9 @@ var listCmd = &cobra.Command{
 
 			// Build output in buffer for pager support (bd-jdz3)
 			var buf strings.Builder
-			if ui.IsAgentMode() {
+			if len(columns) > 0 {
+				formatColumns(&buf, issues, columns, nil)
+			} else if ui.IsAgentMode() {
 				// Agent mode: ultra-compact, no colors, no pager
 				for _, issue := range issues {
 					formatAgentIssue(&buf, issue)
@@ -1224,7 +1268,9 @@ var listCmd = &cobra.Command{
 
 		// Build output in buffer for pager support (bd-jdz3)
 		var buf strings.Builder
-		if ui.IsAgentMode() {
+		if len(columns) > 0 {
+			formatColumns(&buf, issues, columns, labelsMap)
+		} else if ui.IsAgentMode() {
 			// Agent mode: ultra-compact, no colors, no pager
 			for _, issue := range issues {
 				formatAgentIssue(&buf, issue)
@@ -1276,8 +1322,10 @@ func init() {
 	listCmd.Flags().String("format", "", "Output format: 'digraph' (for golang.org/x/tools/cmd/digraph), 'dot' (Graphviz), or Go template")
 	listCmd.Flags().Bool("all", false, "Show all issues including closed (overrides default filter)")
 	listCmd.Flags().Bool("long", false, "Show detailed multi-line output for each issue")
-	listCmd.Flags().String("sort", "", "Sort by field: priority, created, updated, closed, status, id, title, type, assignee")
+	listCmd.Flags().String("sort", "", "Sort by field: priority, created, updated, closed, status, id, title, type, assignee, or a custom field")
 	listCmd.Flags().BoolP("reverse", "r", false, "Reverse sort order")
+	listCmd.Flags().String("view", "", "Apply a saved view (see 'bd views'); explicit flags override it")
+	listCmd.Flags().StringSlice("columns", nil, "Show a table of these columns: "+strings.Join(viewColumns, ",")+", or custom fields")
 
 	// Pattern matching
 	listCmd.Flags().String("title-contains", "", "Filter by title substring (case-insensitive)")
@@ -1320,12 +1368,19 @@ func init() {
 
 	// Time-based scheduling filters (GH#820)
 	listCmd.Flags().Bool("deferred", false, "Show only issues with defer_until set")
+	listCmd.Flags().Bool("recurring", false, "Show only issues with a recurrence rule")
 	listCmd.Flags().String("defer-after", "", "Filter issues deferred after date (supports relative: +6h, tomorrow)")
 	listCmd.Flags().String("defer-before", "", "Filter issues deferred before date (supports relative: +6h, tomorrow)")
 	listCmd.Flags().String("due-after", "", "Filter issues due after date (supports relative: +6h, tomorrow)")
 	listCmd.Flags().String("due-before", "", "Filter issues due before date (supports relative: +6h, tomorrow)")
 	listCmd.Flags().Bool("overdue", false, "Show only issues with due_at in the past (not closed)")
 
+	// Custom field filters
+	listCmd.Flags().StringArray("field", nil, "Filter by custom field: points>=3, severity=high, reviewer (set), reviewer= (unset) (repeatable)")
+
+	// Filter expression
+	listCmd.Flags().String("where", "", whereFlagUsage)
+
 	// Pretty and watch flags (GH#654)
 	listCmd.Flags().Bool("pretty", false, "Display issues in a tree format with status/priority symbols")
 	listCmd.Flags().Bool("tree", false, "Alias for --pretty: hierarchical tree format")
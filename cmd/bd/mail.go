@@ -7,35 +7,46 @@ import (
 	"strings"
 
 	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/mail"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+	"github.com/steveyegge/beads/internal/utils"
 )
 
-// mailCmd delegates to an external mail provider.
-// This enables agents to use 'bd mail' consistently, while the actual
-// mail implementation is provided by the orchestrator.
+// mailCmd is a mailbox for agents, built on message issues. A configured
+// delegate (e.g. gt mail) takes over all of it.
 var mailCmd = &cobra.Command{
 	Use:   "mail [subcommand] [args...]",
-	Short: "Delegate to mail provider (e.g., gt mail)",
-	Long: `Delegates mail operations to an external mail provider.
+	Short: "Send and read messages between agents",
+	Long: `Send and read messages between agents and people working in this repository.
 
-Agents often type 'bd mail' when working with beads, but mail functionality
-is typically provided by the orchestrator. This command bridges that gap
-by delegating to the configured mail provider.
+Messages are issues of type "message": the subject is the title, the body the
+description. They are ephemeral by default (not exported to JSONL); use --keep
+to persist one. Read and archived state is tracked per recipient, and replies
+are threaded (see 'bd show --thread').
 
-Configuration (checked in order):
-  1. BEADS_MAIL_DELEGATE or BD_MAIL_DELEGATE environment variable
-  2. 'mail.delegate' config setting (bd config set mail.delegate "gt mail")
+Your address is --identity, else BEADS_IDENTITY / identity in config.yaml,
+else git user.name.
 
 Examples:
-  # Configure delegation (one-time setup)
-  export BEADS_MAIL_DELEGATE="gt mail"
-  # or
-  bd config set mail.delegate "gt mail"
-
-  # Then use bd mail as if it were gt mail
-  bd mail inbox                    # Lists inbox
-  bd mail send mayor/ -s "Hi"      # Sends mail
-  bd mail read msg-123             # Reads a message`,
-	DisableFlagParsing: true, // Pass all args through to delegate
+  bd mail send bob -s "Review wanted" -m "bd-42 is ready"
+  bd mail send bob,carol -s "Standup moved" --keep
+  bd mail inbox                    # Your messages, newest first
+  bd mail inbox --unread
+  bd mail read bd-a1b              # Show a message and mark it read
+  bd mail reply bd-a1b -m "On it"
+  bd mail archive bd-a1b           # Hide it from your inbox
+
+Closing a gate sends each of its waiters a message.
+
+Delegation:
+  An orchestrator can provide its own mail instead. When a delegate is
+  configured, every 'bd mail' command is passed to it unchanged.
+  Checked in order:
+    1. BEADS_MAIL_DELEGATE or BD_MAIL_DELEGATE environment variable
+    2. 'mail.delegate' config setting (bd config set mail.delegate "gt mail")`,
+	DisableFlagParsing: true, // Pass unknown subcommands through to a delegate
 	Run: func(cmd *cobra.Command, args []string) {
 		// Handle --help and -h ourselves since flag parsing is disabled
 		for _, arg := range args {
@@ -44,45 +55,277 @@ Examples:
 				return
 			}
 		}
+		if delegate := mailDelegate(); delegate != "" {
+			runMailDelegate(delegate, args)
+			return
+		}
+		if len(args) == 0 {
+			_ = cmd.Help()
+			return
+		}
+		fmt.Fprintf(os.Stderr, "Error: unknown mail command %q\n", args[0])
+		fmt.Fprintf(os.Stderr, "Run 'bd mail --help' for usage, or configure a mail delegate (bd config set mail.delegate \"gt mail\")\n")
+		os.Exit(1)
+	},
+}
 
-		// Find the mail delegate command
-		delegate := findMailDelegate()
-		if delegate == "" {
-			fmt.Fprintf(os.Stderr, "Error: no mail delegate configured\n\n")
-			fmt.Fprintf(os.Stderr, "bd mail delegates to an external mail provider.\n")
-			fmt.Fprintf(os.Stderr, "Configure one of:\n")
-			fmt.Fprintf(os.Stderr, "  export BEADS_MAIL_DELEGATE=\"gt mail\"   # Environment variable\n")
-			fmt.Fprintf(os.Stderr, "  bd config set mail.delegate \"gt mail\"  # Per-project config\n")
-			os.Exit(1)
+var mailSendCmd = &cobra.Command{
+	Use:   "send <to>... -s <subject>",
+	Short: "Send a message",
+	Long: `Send a message to one or more addresses (separate arguments or a
+comma-separated list).`,
+	FParseErrWhitelist: cobra.FParseErrWhitelist{UnknownFlags: true}, // Delegates have their own flags
+	Run: mailRun(func(cmd *cobra.Command, args []string) {
+		CheckReadonly("mail send")
+		subject, _ := cmd.Flags().GetString("subject")
+		body, _ := cmd.Flags().GetString("message")
+		keep, _ := cmd.Flags().GetBool("keep")
+		if len(args) == 0 {
+			FatalErrorRespectJSON("send needs at least one recipient")
+		}
+		if strings.TrimSpace(subject) == "" {
+			FatalErrorRespectJSON("send needs a subject (-s)")
 		}
 
-		// Parse the delegate command (e.g., "gt mail" -> ["gt", "mail"])
-		parts := strings.Fields(delegate)
-		if len(parts) == 0 {
-			fmt.Fprintf(os.Stderr, "Error: invalid mail delegate: %q\n", delegate)
-			os.Exit(1)
+		msg, err := mail.Send(rootCtx, store, mail.Message{
+			From:    mailIdentity(cmd),
+			To:      args,
+			Subject: subject,
+			Body:    body,
+			Keep:    keep,
+		}, actor)
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		markDirtyAndScheduleFlush()
+
+		if jsonOutput {
+			outputJSON(msg)
+			return
+		}
+		fmt.Printf("%s Sent %s to %s: %s\n", ui.RenderPass("✓"), msg.ID,
+			strings.Join(mail.Recipients(msg), ", "), msg.Title)
+	}),
+}
+
+var mailInboxCmd = &cobra.Command{
+	Use:                "inbox",
+	Short:              "List your messages",
+	FParseErrWhitelist: cobra.FParseErrWhitelist{UnknownFlags: true},
+	Run: mailRun(func(cmd *cobra.Command, args []string) {
+		unread, _ := cmd.Flags().GetBool("unread")
+		archived, _ := cmd.Flags().GetBool("archived")
+		me := mailIdentity(cmd)
+
+		inbox, err := mail.Inbox(rootCtx, store, me, mail.InboxOptions{Unread: unread, Archived: archived})
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		if jsonOutput {
+			if inbox == nil {
+				inbox = []*types.Issue{}
+			}
+			outputJSON(inbox)
+			return
+		}
+
+		if len(inbox) == 0 {
+			fmt.Printf("No messages for %s\n", me)
+			return
+		}
+		unreadCount := 0
+		for _, msg := range inbox {
+			if !mail.IsRead(msg, me) {
+				unreadCount++
+			}
+		}
+		noun := "messages"
+		if len(inbox) == 1 {
+			noun = "message"
+		}
+		fmt.Printf("\n%s Inbox for %s (%d %s, %d unread)\n\n", ui.RenderAccent("📬"), me, len(inbox), noun, unreadCount)
+		for _, msg := range inbox {
+			marker := " "
+			line := fmt.Sprintf("%s  %-16s %s", msg.ID, msg.Sender, msg.Title)
+			if !mail.IsRead(msg, me) {
+				marker = ui.RenderAccent("●")
+			} else {
+				line = ui.RenderMuted(line)
+			}
+			fmt.Printf("%s %s  %s\n", marker, line, ui.RenderMuted(formatTimeAgo(msg.CreatedAt)))
+		}
+		fmt.Println()
+	}),
+}
+
+var mailReadCmd = &cobra.Command{
+	Use:                "read <id>...",
+	Short:              "Show messages and mark them read",
+	FParseErrWhitelist: cobra.FParseErrWhitelist{UnknownFlags: true},
+	Run: mailRun(func(cmd *cobra.Command, args []string) {
+		if len(args) == 0 {
+			FatalErrorRespectJSON("read needs a message ID")
+		}
+		me := mailIdentity(cmd)
+
+		var messages []*types.Issue
+		for _, id := range args {
+			fullID, err := utils.ResolvePartialID(rootCtx, store, id)
+			if err != nil {
+				FatalErrorRespectJSON("%v", err)
+			}
+			// Read-only mode can still show messages, just not record reading them
+			var msg *types.Issue
+			if readonlyMode {
+				msg, err = mail.Get(rootCtx, store, fullID)
+			} else {
+				msg, err = mail.MarkRead(rootCtx, store, fullID, me, actor)
+			}
+			if err != nil {
+				FatalErrorRespectJSON("%v", err)
+			}
+			messages = append(messages, msg)
+		}
+		if !readonlyMode {
+			markDirtyAndScheduleFlush()
+		}
+
+		if jsonOutput {
+			outputJSON(messages)
+			return
+		}
+		for i, msg := range messages {
+			if i > 0 {
+				fmt.Println(ui.RenderMuted(strings.Repeat("─", 60)))
+			}
+			printMailMessage(msg)
+		}
+	}),
+}
+
+var mailReplyCmd = &cobra.Command{
+	Use:                "reply <id> -m <body>",
+	Short:              "Reply to a message",
+	Long:               `Reply to the sender of a message (or, for your own message, to its recipients).`,
+	FParseErrWhitelist: cobra.FParseErrWhitelist{UnknownFlags: true},
+	Run: mailRun(func(cmd *cobra.Command, args []string) {
+		CheckReadonly("mail reply")
+		if len(args) != 1 {
+			FatalErrorRespectJSON("reply needs exactly one message ID")
+		}
+		subject, _ := cmd.Flags().GetString("subject")
+		body, _ := cmd.Flags().GetString("message")
+		keep, _ := cmd.Flags().GetBool("keep")
+		if strings.TrimSpace(body) == "" {
+			FatalErrorRespectJSON("reply needs a body (-m)")
+		}
+
+		fullID, err := utils.ResolvePartialID(rootCtx, store, args[0])
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
 		}
+		reply, err := mail.Reply(rootCtx, store, fullID, mailIdentity(cmd), subject, body, keep, actor)
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		markDirtyAndScheduleFlush()
 
-		// Build the full command with our args appended
-		cmdName := parts[0]
-		cmdArgs := append(parts[1:], args...)
+		if jsonOutput {
+			outputJSON(reply)
+			return
+		}
+		fmt.Printf("%s Sent %s to %s: %s\n", ui.RenderPass("✓"), reply.ID,
+			strings.Join(mail.Recipients(reply), ", "), reply.Title)
+	}),
+}
 
-		// Execute the delegate command
-		// #nosec G204 - cmdName comes from user configuration (mail_delegate setting)
-		execCmd := exec.Command(cmdName, cmdArgs...)
-		execCmd.Stdin = os.Stdin
-		execCmd.Stdout = os.Stdout
-		execCmd.Stderr = os.Stderr
+var mailArchiveCmd = &cobra.Command{
+	Use:                "archive <id>...",
+	Short:              "Remove messages from your inbox",
+	Long:               `Archive messages for you. A message is closed once all its recipients archived it.`,
+	FParseErrWhitelist: cobra.FParseErrWhitelist{UnknownFlags: true},
+	Run: mailRun(func(cmd *cobra.Command, args []string) {
+		CheckReadonly("mail archive")
+		if len(args) == 0 {
+			FatalErrorRespectJSON("archive needs a message ID")
+		}
+		me := mailIdentity(cmd)
 
-		if err := execCmd.Run(); err != nil {
-			// Try to preserve the exit code
-			if exitErr, ok := err.(*exec.ExitError); ok {
-				os.Exit(exitErr.ExitCode())
+		var archived []string
+		failed := false
+		for _, id := range args {
+			fullID, err := utils.ResolvePartialID(rootCtx, store, id)
+			if err == nil {
+				_, err = mail.Archive(rootCtx, store, fullID, me, actor)
+			}
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error archiving %s: %v\n", id, err)
+				failed = true
+				continue
+			}
+			archived = append(archived, fullID)
+			if !jsonOutput {
+				fmt.Printf("%s Archived %s\n", ui.RenderPass("✓"), fullID)
 			}
-			fmt.Fprintf(os.Stderr, "Error running %s: %v\n", delegate, err)
+		}
+
+		if len(archived) > 0 {
+			markDirtyAndScheduleFlush()
+		}
+		if jsonOutput && len(archived) > 0 {
+			outputJSON(map[string]interface{}{"archived": archived})
+		}
+		if failed {
 			os.Exit(1)
 		}
-	},
+	}),
+}
+
+// mailRun wraps a native mail subcommand. A configured delegate still takes
+// over, and gets the arguments exactly as typed.
+func mailRun(run func(cmd *cobra.Command, args []string)) func(cmd *cobra.Command, args []string) {
+	return func(cmd *cobra.Command, args []string) {
+		if delegate := mailDelegate(); delegate != "" {
+			runMailDelegate(delegate, rawMailArgs())
+			return
+		}
+		if err := ensureDirectMode("mail requires direct database access"); err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		run(cmd, args)
+	}
+}
+
+// mailIdentity returns the address to send and read mail as.
+func mailIdentity(cmd *cobra.Command) string {
+	identity, _ := cmd.Flags().GetString("identity")
+	return config.GetIdentity(identity)
+}
+
+// printMailMessage prints a message with its headers.
+func printMailMessage(msg *types.Issue) {
+	fmt.Printf("%s %s\n", ui.RenderAccent("📨"), msg.ID)
+	fmt.Printf("From:    %s\n", msg.Sender)
+	fmt.Printf("To:      %s\n", strings.Join(mail.Recipients(msg), ", "))
+	fmt.Printf("Subject: %s\n", msg.Title)
+	fmt.Printf("Date:    %s (%s)\n", msg.CreatedAt.Local().Format("2006-01-02 15:04"), formatTimeAgo(msg.CreatedAt))
+	if msg.Description != "" {
+		fmt.Printf("\n%s\n", msg.Description)
+	}
+	fmt.Println()
+}
+
+// mailDelegate returns the configured mail delegate, if any. The
+// mail.delegate setting lives in the database, so this switches to direct
+// mode when no delegate is set in the environment.
+func mailDelegate() string {
+	if delegate := findMailDelegate(); delegate != "" || store != nil {
+		return delegate
+	}
+	if err := ensureDirectMode("mail.delegate is read from the database"); err != nil {
+		return ""
+	}
+	return findMailDelegate()
 }
 
 // findMailDelegate checks for mail delegation configuration
@@ -97,7 +340,6 @@ func findMailDelegate() string {
 	}
 
 	// Check bd config (requires database)
-	// This works even without a database connection since we use direct mode
 	if store != nil {
 		if delegate, err := store.GetConfig(rootCtx, "mail.delegate"); err == nil && delegate != "" {
 			return delegate
@@ -107,6 +349,65 @@ func findMailDelegate() string {
 	return ""
 }
 
+// rawMailArgs returns the command line after "mail", before cobra parsed it.
+func rawMailArgs() []string {
+	for i, arg := range os.Args[1:] {
+		if arg == "mail" {
+			return os.Args[i+2:]
+		}
+	}
+	return nil
+}
+
+// runMailDelegate runs the delegate command with args appended and exits
+// with its exit code on failure.
+func runMailDelegate(delegate string, args []string) {
+	// Parse the delegate command (e.g., "gt mail" -> ["gt", "mail"])
+	parts := strings.Fields(delegate)
+	if len(parts) == 0 {
+		fmt.Fprintf(os.Stderr, "Error: invalid mail delegate: %q\n", delegate)
+		os.Exit(1)
+	}
+
+	// Build the full command with our args appended
+	cmdName := parts[0]
+	cmdArgs := append(parts[1:], args...)
+
+	// Execute the delegate command
+	// #nosec G204 - cmdName comes from user configuration (mail_delegate setting)
+	execCmd := exec.Command(cmdName, cmdArgs...)
+	execCmd.Stdin = os.Stdin
+	execCmd.Stdout = os.Stdout
+	execCmd.Stderr = os.Stderr
+
+	if err := execCmd.Run(); err != nil {
+		// Try to preserve the exit code
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			os.Exit(exitErr.ExitCode())
+		}
+		fmt.Fprintf(os.Stderr, "Error running %s: %v\n", delegate, err)
+		os.Exit(1)
+	}
+}
+
 func init() {
+	mailCmd.PersistentFlags().String("identity", "", "Mail address to act as (default: BEADS_IDENTITY, then git user.name)")
+
+	mailSendCmd.Flags().StringP("subject", "s", "", "Subject (required)")
+	mailSendCmd.Flags().StringP("message", "m", "", "Message body")
+	mailSendCmd.Flags().Bool("keep", false, "Persist the message (default: ephemeral, not exported to JSONL)")
+
+	mailInboxCmd.Flags().BoolP("unread", "u", false, "Only show unread messages")
+	mailInboxCmd.Flags().Bool("archived", false, "Include archived messages")
+
+	mailReplyCmd.Flags().StringP("message", "m", "", "Reply body (required)")
+	mailReplyCmd.Flags().StringP("subject", "s", "", "Subject (default: Re: <original subject>)")
+	mailReplyCmd.Flags().Bool("keep", false, "Persist the reply (default: follows the original message)")
+
+	mailReadCmd.ValidArgsFunction = issueIDCompletion
+	mailReplyCmd.ValidArgsFunction = issueIDCompletion
+	mailArchiveCmd.ValidArgsFunction = issueIDCompletion
+
+	mailCmd.AddCommand(mailSendCmd, mailInboxCmd, mailReadCmd, mailReplyCmd, mailArchiveCmd)
 	rootCmd.AddCommand(mailCmd)
 }
@@ -340,6 +340,7 @@ var rootCmd = &cobra.Command{
 			"bash",
 			"completion",
 			"doctor",
+			"fields",
 			"fish",
 			"help",
 			"hooks",
@@ -354,6 +355,8 @@ var rootCmd = &cobra.Command{
 			"resolve-conflicts",
 			"setup",
 			"version",
+			"webhooks",
+			"workflow",
 			"zsh",
 		}
 		// Check both the command name and parent command name for subcommands
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/beads"
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/mcp"
+	"github.com/steveyegge/beads/internal/rpc"
+)
+
+var mcpCmd = &cobra.Command{
+	Use:     "mcp",
+	GroupID: "advanced",
+	Short:   "Model Context Protocol (MCP) server for AI agents",
+	Long: `Model Context Protocol (MCP) server for AI agents.
+
+'bd mcp serve' speaks MCP over stdio, so agent hosts can use beads without
+the Python beads-mcp package. Configure your host to run it in the project
+directory, for example in .mcp.json:
+
+  {
+    "mcpServers": {
+      "beads": { "command": "bd", "args": ["mcp", "serve"] }
+    }
+  }`,
+}
+
+var mcpServeCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve MCP over stdio",
+	Long: `Serve the Model Context Protocol over stdin/stdout.
+
+Tools: ready, list, show, create, update, close, dep, stats, context.
+Resources: beads://prime (bd prime --mcp) and beads://prime/full
+(bd prime --full).
+
+Tool calls go through the daemon when one is running and use the database
+directly otherwise, with the same arguments and results either way. The
+server serves the workspace it was started in; use --db or the working
+directory to choose it. Diagnostics go to stderr.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		workspace := mcp.Workspace{
+			Database: dbPath,
+			Mode:     "direct",
+			Actor:    actor,
+			Version:  Version,
+		}
+		if beadsDir := beads.FindBeadsDir(); beadsDir != "" {
+			workspace.Root = filepath.Dir(beadsDir)
+		}
+
+		var exec mcp.Executor
+		if daemonClient != nil {
+			exec = &mcpDaemonExecutor{client: daemonClient, socketPath: getSocketPath()}
+			workspace.Mode = cmdDaemon
+		} else {
+			local := rpc.NewLocalClient(store, workspace.Root, dbPath)
+			local.SetActor(actor)
+			local.OnMutation(func(rpc.MutationEvent) {
+				markDirtyAndScheduleFlush()
+			})
+			exec = local
+		}
+
+		server := mcp.NewBeadsServer(mcp.Config{
+			Version:   Version,
+			Executor:  exec,
+			Workspace: workspace,
+			Prime: func(w io.Writer, mcpMode bool) error {
+				return writePrime(w, beads.FindBeadsDir(), mcpMode, config.GetBool("no-git-ops"), false)
+			},
+		})
+
+		// stdout carries the protocol; anything else printed while serving
+		// goes to stderr instead of corrupting it
+		out := os.Stdout
+		os.Stdout = os.Stderr
+		defer func() { os.Stdout = out }()
+
+		if err := server.Serve(rootCtx, os.Stdin, out); err != nil && rootCtx.Err() == nil {
+			FatalError("mcp server: %v", err)
+		}
+	},
+}
+
+// mcpDaemonExecutor forwards tool calls to the daemon, reconnecting once
+// when the connection is lost (e.g. the daemon restarted during a long
+// session).
+type mcpDaemonExecutor struct {
+	client     *rpc.Client
+	socketPath string
+}
+
+func (e *mcpDaemonExecutor) Execute(operation string, args interface{}) (*rpc.Response, error) {
+	resp, err := e.client.Execute(operation, args)
+	if err == nil || resp != nil {
+		// Success, or the daemon answered with an operation error
+		return resp, err
+	}
+	client, connErr := connectDaemon(e.socketPath)
+	if connErr != nil {
+		return nil, fmt.Errorf("%w (daemon unavailable; restart bd mcp serve to fall back to direct mode)", err)
+	}
+	_ = e.client.Close()
+	e.client = client
+	daemonClient = client
+	return e.client.Execute(operation, args)
+}
+
+// connectDaemon opens a new daemon connection set up like the global
+// daemonClient (database path and actor).
+func connectDaemon(socketPath string) (*rpc.Client, error) {
+	client, err := rpc.TryConnect(socketPath)
+	if err != nil {
+		return nil, err
+	}
+	if client == nil {
+		return nil, fmt.Errorf("daemon not running")
+	}
+	if dbPath != "" {
+		if absDBPath, absErr := filepath.Abs(dbPath); absErr == nil {
+			client.SetDatabasePath(absDBPath)
+		}
+	}
+	client.SetActor(actor)
+	return client, nil
+}
+
+func init() {
+	mcpCmd.AddCommand(mcpServeCmd)
+	rootCmd.AddCommand(mcpCmd)
+}
@@ -401,7 +401,7 @@ func importToDolt(ctx context.Context, store *dolt.DoltStore, data *migrationDat
 				event_kind, actor, target, payload,
 				await_type, await_id, timeout_ns, waiters,
 				hook_bead, role_bead, agent_state, last_activity, role_type, rig,
-				due_at, defer_until
+				due_at, defer_until, recurrence, custom_fields, lease_expires_at
 			) VALUES (
 				?, ?, ?, ?, ?, ?, ?,
 				?, ?, ?, ?, ?,
@@ -413,7 +413,7 @@ func importToDolt(ctx context.Context, store *dolt.DoltStore, data *migrationDat
 				?, ?, ?, ?,
 				?, ?, ?, ?,
 				?, ?, ?, ?, ?, ?,
-				?, ?
+				?, ?, ?, ?, ?
 			)
 		`,
 			issue.ID, issue.ContentHash, issue.Title, issue.Description, issue.Design, issue.AcceptanceCriteria, issue.Notes,
@@ -426,7 +426,7 @@ func importToDolt(ctx context.Context, store *dolt.DoltStore, data *migrationDat
 			issue.EventKind, issue.Actor, issue.Target, issue.Payload,
 			issue.AwaitType, issue.AwaitID, issue.Timeout.Nanoseconds(), formatJSONArray(issue.Waiters),
 			issue.HookBead, issue.RoleBead, issue.AgentState, issue.LastActivity, issue.RoleType, issue.Rig,
-			issue.DueAt, issue.DeferUntil,
+			issue.DueAt, issue.DeferUntil, issue.Recurrence, formatJSONMap(issue.CustomFields), issue.LeaseExpiresAt,
 		)
 		if err != nil {
 			if strings.Contains(err.Error(), "Duplicate entry") ||
@@ -803,3 +803,15 @@ func formatJSONArray(arr []string) string {
 	}
 	return string(data)
 }
+
+// formatJSONMap formats a string map as JSON (custom_fields column)
+func formatJSONMap(m map[string]string) string {
+	if len(m) == 0 {
+		return ""
+	}
+	data, err := json.Marshal(m)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
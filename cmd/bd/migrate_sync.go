@@ -226,7 +226,7 @@ func runMigrateSync(ctx context.Context, branchName string, dryRun, force bool)
 
 	// Also sync other beads files
 	beadsDir := filepath.Dir(jsonlPath)
-	for _, filename := range []string{"deletions.jsonl", "metadata.json"} {
+	for _, filename := range []string{"deletions.jsonl", "views.jsonl", "metadata.json"} {
 		srcPath := filepath.Join(beadsDir, filename)
 		if _, err := os.Stat(srcPath); err == nil {
 			relPath, err := filepath.Rel(repoRoot, srcPath)
@@ -13,8 +13,13 @@ import (
 	"github.com/steveyegge/beads"
 	internalbeads "github.com/steveyegge/beads/internal/beads"
 	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/ranking"
 	"github.com/steveyegge/beads/internal/rpc"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/storage/factory"
 	"github.com/steveyegge/beads/internal/syncbranch"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/views"
 )
 
 // isDaemonAutoSyncing checks if daemon is running with auto-commit and auto-push enabled.
@@ -47,6 +52,7 @@ var (
 	primeMCPMode     bool
 	primeStealthMode bool
 	primeExportMode  bool
+	primeView        string
 )
 
 var primeCmd = &cobra.Command{
@@ -69,7 +75,9 @@ Config options:
 
 Workflow customization:
 - Place a .beads/PRIME.md file to override the default output entirely.
-- Use --export to dump the default content for customization.`,
+- Use --export to dump the default content for customization.
+- Use --view (or set prime.view, e.g. BD_PRIME_VIEW per agent role) to append
+  the ready work matching a saved view as the agent's work queue.`,
 	Run: func(cmd *cobra.Command, args []string) {
 		// Find .beads/ directory (supports both database and JSONL-only mode)
 		beadsDir := beads.FindBeadsDir()
@@ -93,41 +101,116 @@ Workflow customization:
 		// This allows users to disable git ops in session close protocol via config
 		stealthMode := primeStealthMode || config.GetBool("no-git-ops")
 
-		// Check for custom PRIME.md override (unless --export flag)
-		// This allows users to fully customize workflow instructions
-		// Check local .beads/ first (even if redirected), then redirected location
-		if !primeExportMode {
-			localPrimePath := filepath.Join(".beads", "PRIME.md")
-			redirectedPrimePath := filepath.Join(beadsDir, "PRIME.md")
-
-			// Try local first (user's clone-specific customization)
-			// #nosec G304 -- path is relative to cwd
-			if content, err := os.ReadFile(localPrimePath); err == nil {
-				fmt.Print(string(content))
-				return
-			}
-			// Fall back to redirected location (shared customization)
-			// #nosec G304 -- path is constructed from beadsDir which we control
-			if content, err := os.ReadFile(redirectedPrimePath); err == nil {
-				fmt.Print(string(content))
-				return
-			}
-		}
-
-		// Output workflow context (adaptive based on MCP and stealth mode)
-		if err := outputPrimeContext(os.Stdout, mcpMode, stealthMode); err != nil {
+		// Output workflow context (adaptive based on MCP and stealth mode),
+		// or the custom PRIME.md override unless --export is set
+		if err := writePrime(os.Stdout, beadsDir, mcpMode, stealthMode, primeExportMode); err != nil {
 			// Suppress all errors - silent exit with success
 			// Never write to stderr (breaks Windows compatibility)
 			os.Exit(0)
 		}
+
+		// Work queue from a saved view (skipped on any error, like the rest of prime)
+		viewName := primeView
+		if viewName == "" {
+			viewName = config.GetString("prime.view")
+		}
+		if viewName != "" && !primeExportMode {
+			_ = writePrimeWorkQueue(rootCtx, os.Stdout, beadsDir, viewName)
+		}
 	},
 }
 
+// writePrimeWorkQueue opens the database read-only and writes the ready work
+// matching the named view.
+func writePrimeWorkQueue(ctx context.Context, w io.Writer, beadsDir, viewName string) error {
+	s, err := factory.NewFromConfigWithOptions(ctx, beadsDir, factory.Options{ReadOnly: true, LockTimeout: lockTimeout})
+	if err != nil {
+		return err
+	}
+	defer func() { _ = s.Close() }()
+	return writeViewQueue(ctx, w, s, beadsDir, viewName)
+}
+
+// primeQueueLimit is the default number of issues in the prime work queue.
+const primeQueueLimit = 10
+
+// writeViewQueue writes the ready work matching a view as a markdown section.
+func writeViewQueue(ctx context.Context, w io.Writer, s storage.Storage, beadsDir, viewName string) error {
+	v, err := views.Get(ctx, s, beadsDir, viewName)
+	if err != nil {
+		return err
+	}
+	limit := primeQueueLimit
+	if v.Limit != nil {
+		limit = *v.Limit
+	}
+	filter := types.WorkFilter{Where: v.Where, Limit: limit}
+	listSort := ""
+	if v.Reverse || !types.SortPolicy(v.Sort).IsValid() {
+		listSort, filter.Limit = v.Sort, 0
+	} else {
+		filter.SortPolicy = types.SortPolicy(v.Sort)
+	}
+	scored, err := ranking.ReadyWork(ctx, s, filter)
+	if err != nil {
+		return err
+	}
+	issues := sortAndLimit(ranking.Issues(scored), listSort, v.Reverse, limit)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "\n# Work Queue (view: %s)\n\n", v.Name)
+	if v.Description != "" {
+		fmt.Fprintf(&b, "%s\n\n", v.Description)
+	}
+	if len(issues) == 0 {
+		fmt.Fprintf(&b, "No ready work matches `%s`.\n", v.Where)
+	} else {
+		fmt.Fprintf(&b, "Ready work matching `%s`:\n\n", v.Where)
+		for _, issue := range issues {
+			fmt.Fprintf(&b, "- [P%d] %s: %s", issue.Priority, issue.ID, issue.Title)
+			if issue.Assignee != "" {
+				fmt.Fprintf(&b, " (%s)", issue.Assignee)
+			}
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(&b, "\nRun `bd ready --view %s` for the current queue.\n", v.Name)
+	}
+	_, err = io.WriteString(w, b.String())
+	return err
+}
+
+// writePrime writes the prime output for beadsDir: a custom PRIME.md
+// override if one exists (unless skipOverride), else the default context.
+func writePrime(w io.Writer, beadsDir string, mcpMode, stealthMode, skipOverride bool) error {
+	// Check for custom PRIME.md override
+	// This allows users to fully customize workflow instructions
+	// Check local .beads/ first (even if redirected), then redirected location
+	if !skipOverride {
+		localPrimePath := filepath.Join(".beads", "PRIME.md")
+		redirectedPrimePath := filepath.Join(beadsDir, "PRIME.md")
+
+		// Try local first (user's clone-specific customization)
+		// #nosec G304 -- path is relative to cwd
+		if content, err := os.ReadFile(localPrimePath); err == nil {
+			_, err = w.Write(content)
+			return err
+		}
+		// Fall back to redirected location (shared customization)
+		// #nosec G304 -- path is constructed from beadsDir which we control
+		if content, err := os.ReadFile(redirectedPrimePath); err == nil {
+			_, err = w.Write(content)
+			return err
+		}
+	}
+	return outputPrimeContext(w, mcpMode, stealthMode)
+}
+
 func init() {
 	primeCmd.Flags().BoolVar(&primeFullMode, "full", false, "Force full CLI output (ignore MCP detection)")
 	primeCmd.Flags().BoolVar(&primeMCPMode, "mcp", false, "Force MCP mode (minimal output)")
 	primeCmd.Flags().BoolVar(&primeStealthMode, "stealth", false, "Stealth mode (no git operations, flush only)")
 	primeCmd.Flags().BoolVar(&primeExportMode, "export", false, "Output default content (ignores PRIME.md override)")
+	primeCmd.Flags().StringVar(&primeView, "view", "", "Append the ready work matching a saved view (default: prime.view config)")
 	rootCmd.AddCommand(primeCmd)
 }
 
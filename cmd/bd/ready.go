@@ -4,9 +4,13 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"slices"
+	"strconv"
+	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/ranking"
 	"github.com/steveyegge/beads/internal/rpc"
 	"github.com/steveyegge/beads/internal/storage/sqlite"
 	"github.com/steveyegge/beads/internal/types"
@@ -26,6 +30,22 @@ Use --mol to filter to a specific molecule's steps:
 Use --gated to find molecules ready for gate-resume dispatch:
   bd ready --gated           # Find molecules where a gate closed
 
+Use --view to get the ready work matching a saved view (see 'bd views'):
+  bd ready --view frontend   # Ready work for the frontend agent
+
+Use a scored --sort to rank by what the work unlocks or how urgent it is:
+  bd ready --sort unblock        # Issues that unblock the most open work
+  bd ready --sort critical-path  # Issues heading the longest dependency chains
+  bd ready --sort due            # Closest and overdue due dates first
+  bd ready --sort wsjf           # Weighted shortest job first (uses estimates)
+  bd ready --sort age            # Longest waiting first
+  bd ready --sort score          # Weighted formula from the ready.score config
+
+  bd config set ready.score "2*unblock + 3*due + priority + age"
+
+Factors are priority, unblock, critical, due, wsjf and age. With --json each
+issue carries a "score" object with every factor's value and contribution.
+
 This is useful for agents executing molecules to see which steps can run next.`,
 	Run: func(cmd *cobra.Command, args []string) {
 		// Handle --gated flag (gate-resume discovery)
@@ -42,6 +62,21 @@ This is useful for agents executing molecules to see which steps can run next.`,
 			return
 		}
 
+		// Saved view. A view sort that isn't a ready sort policy (or is
+		// reversed) is applied to the results like bd list --sort.
+		var listSort string
+		var listReverse bool
+		if viewName, _ := cmd.Flags().GetString("view"); viewName != "" {
+			v := loadView(rootCtx, viewName)
+			if !cmd.Flags().Changed("sort") && (v.Reverse || !types.SortPolicy(v.Sort).IsValid()) {
+				listSort, listReverse = v.Sort, v.Reverse
+				viewCopy := *v
+				viewCopy.Sort, viewCopy.Reverse = "", false
+				v = &viewCopy
+			}
+			applyView(cmd, v)
+		}
+
 		limit, _ := cmd.Flags().GetInt("limit")
 		assignee, _ := cmd.Flags().GetString("assignee")
 		unassigned, _ := cmd.Flags().GetBool("unassigned")
@@ -54,6 +89,11 @@ This is useful for agents executing molecules to see which steps can run next.`,
 		molTypeStr, _ := cmd.Flags().GetString("mol-type")
 		prettyFormat, _ := cmd.Flags().GetBool("pretty")
 		includeDeferred, _ := cmd.Flags().GetBool("include-deferred")
+		where := parseWhereFlag(cmd)
+		columns, _ := cmd.Flags().GetStringSlice("columns")
+		if err := validateViewColumns(columns); err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
 		var molType *types.MolType
 		if molTypeStr != "" {
 			mt := types.MolType(molTypeStr)
@@ -86,6 +126,13 @@ This is useful for agents executing molecules to see which steps can run next.`,
 			LabelsAny:       labelsAny,
 			IncludeDeferred: includeDeferred, // GH#820: respect --include-deferred flag
 		}
+		if where != nil {
+			filter.Where = where.String()
+		}
+		// Sorting on the client needs every candidate, the limit is applied after
+		if listSort != "" {
+			filter.Limit = 0
+		}
 		// Use Changed() to properly handle P0 (priority=0)
 		if cmd.Flags().Changed("priority") {
 			priority, _ := cmd.Flags().GetInt("priority")
@@ -102,22 +149,30 @@ This is useful for agents executing molecules to see which steps can run next.`,
 		}
 		// Validate sort policy
 		if !filter.SortPolicy.IsValid() {
-			fmt.Fprintf(os.Stderr, "Error: invalid sort policy '%s'. Valid values: hybrid, priority, oldest\n", sortPolicy)
+			fmt.Fprintf(os.Stderr, "Error: invalid sort policy '%s'. Valid values: hybrid, priority, oldest, unblock, critical-path, due, wsjf, age, score\n", sortPolicy)
 			os.Exit(1)
 		}
+		// Issue labels aren't returned by the daemon's ready work
+		if slices.Contains(columns, "labels") && daemonClient != nil {
+			if err := ensureDirectMode("ready --columns labels requires direct database access"); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+		}
 		// If daemon is running, use RPC
 		if daemonClient != nil {
 			readyArgs := &rpc.ReadyArgs{
 				Assignee:        assignee,
 				Unassigned:      unassigned,
 				Type:            issueType,
-				Limit:           limit,
+				Limit:           filter.Limit,
 				SortPolicy:      sortPolicy,
 				Labels:          labels,
 				LabelsAny:       labelsAny,
 				ParentID:        parentID,
 				MolType:         molTypeStr,
 				IncludeDeferred: includeDeferred, // GH#820
+				Where:           filter.Where,
 			}
 			if cmd.Flags().Changed("priority") {
 				priority, _ := cmd.Flags().GetInt("priority")
@@ -128,18 +183,20 @@ This is useful for agents executing molecules to see which steps can run next.`,
 				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 				os.Exit(1)
 			}
-			var issues []*types.Issue
-			if err := json.Unmarshal(resp.Data, &issues); err != nil {
+			var scored []*ranking.ScoredIssue
+			if err := json.Unmarshal(resp.Data, &scored); err != nil {
 				fmt.Fprintf(os.Stderr, "Error parsing response: %v\n", err)
 				os.Exit(1)
 			}
+			scored = sortScoredAndLimit(scored, listSort, listReverse, limit)
 			if jsonOutput {
-				if issues == nil {
-					issues = []*types.Issue{}
+				if scored == nil {
+					scored = []*ranking.ScoredIssue{}
 				}
-				outputJSON(issues)
+				outputJSON(scored)
 				return
 			}
+			issues := ranking.Issues(scored)
 
 			// Show upgrade notification if needed
 			maybeShowUpgradeNotification()
@@ -162,6 +219,12 @@ This is useful for agents executing molecules to see which steps can run next.`,
 				}
 				return
 			}
+			if len(columns) > 0 {
+				var buf strings.Builder
+				formatColumns(&buf, issues, columns, nil)
+				fmt.Print(buf.String())
+				return
+			}
 			if prettyFormat {
 				displayPrettyList(issues, false)
 			} else {
@@ -174,6 +237,9 @@ This is useful for agents executing molecules to see which steps can run next.`,
 					if issue.EstimatedMinutes != nil {
 						fmt.Printf("   Estimate: %d min\n", *issue.EstimatedMinutes)
 					}
+					if score := scored[i].Score; score != nil {
+						fmt.Printf("   Score: %s\n", formatScore(score))
+					}
 					if issue.Assignee != "" {
 						fmt.Printf("   Assignee: %s\n", issue.Assignee)
 					}
@@ -194,30 +260,32 @@ This is useful for agents executing molecules to see which steps can run next.`,
 			}
 		}
 
-		issues, err := store.GetReadyWork(ctx, filter)
+		scored, err := ranking.ReadyWork(ctx, store, filter)
 		if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 		}
 	// If no ready work found, check if git has issues and auto-import
-	if len(issues) == 0 {
+	if len(scored) == 0 {
 		if checkAndAutoImport(ctx, store) {
 			// Re-run the query after import
-			issues, err = store.GetReadyWork(ctx, filter)
+			scored, err = ranking.ReadyWork(ctx, store, filter)
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 				os.Exit(1)
 			}
 		}
 	}
+		scored = sortScoredAndLimit(scored, listSort, listReverse, limit)
 		if jsonOutput {
 			// Always output array, even if empty
-			if issues == nil {
-				issues = []*types.Issue{}
+			if scored == nil {
+				scored = []*ranking.ScoredIssue{}
 			}
-			outputJSON(issues)
+			outputJSON(scored)
 			return
 		}
+		issues := ranking.Issues(scored)
 		// Show upgrade notification if needed
 		maybeShowUpgradeNotification()
 
@@ -237,6 +305,20 @@ This is useful for agents executing molecules to see which steps can run next.`,
 			maybeShowTip(store)
 			return
 		}
+		if len(columns) > 0 {
+			var labelsMap map[string][]string
+			if slices.Contains(columns, "labels") {
+				ids := make([]string, len(issues))
+				for i, issue := range issues {
+					ids[i] = issue.ID
+				}
+				labelsMap, _ = store.GetLabelsForIssues(ctx, ids)
+			}
+			var buf strings.Builder
+			formatColumns(&buf, issues, columns, labelsMap)
+			fmt.Print(buf.String())
+			return
+		}
 		if prettyFormat {
 			displayPrettyList(issues, false)
 		} else {
@@ -249,6 +331,9 @@ This is useful for agents executing molecules to see which steps can run next.`,
 				if issue.EstimatedMinutes != nil {
 					fmt.Printf("   Estimate: %d min\n", *issue.EstimatedMinutes)
 				}
+				if score := scored[i].Score; score != nil {
+					fmt.Printf("   Score: %s\n", formatScore(score))
+				}
 				if issue.Assignee != "" {
 					fmt.Printf("   Assignee: %s\n", issue.Assignee)
 				}
@@ -260,6 +345,50 @@ This is useful for agents executing molecules to see which steps can run next.`,
 		maybeShowTip(store)
 	},
 }
+// sortScoredAndLimit is sortAndLimit for ranked ready work. A list sort
+// replaces the ranking, so the scores are dropped.
+func sortScoredAndLimit(scored []*ranking.ScoredIssue, sortBy string, reverse bool, limit int) []*ranking.ScoredIssue {
+	if sortBy == "" {
+		return scored
+	}
+	return ranking.Unscored(sortAndLimit(ranking.Issues(scored), sortBy, reverse, limit))
+}
+
+// formatScore summarizes a score as its total and the terms that
+// contributed, e.g. "22 (unblock 10 ×2, priority 2)".
+func formatScore(s *ranking.Score) string {
+	var parts []string
+	for _, c := range s.Components {
+		if c.Contribution == 0 {
+			continue
+		}
+		part := fmt.Sprintf("%s %s", c.Factor, strconv.FormatFloat(c.Value, 'f', -1, 64))
+		if c.Weight != 1 {
+			part += " ×" + strconv.FormatFloat(c.Weight, 'f', -1, 64)
+		}
+		parts = append(parts, part)
+	}
+	total := strconv.FormatFloat(s.Total, 'f', -1, 64)
+	if len(parts) == 0 {
+		return total
+	}
+	return fmt.Sprintf("%s (%s)", total, strings.Join(parts, ", "))
+}
+
+// sortAndLimit sorts ready work by a bd list sort field and applies the
+// limit. It does nothing if sortBy is empty (the query already sorted and
+// limited).
+func sortAndLimit(issues []*types.Issue, sortBy string, reverse bool, limit int) []*types.Issue {
+	if sortBy == "" {
+		return issues
+	}
+	sortIssues(issues, sortBy, reverse)
+	if limit > 0 && len(issues) > limit {
+		issues = issues[:limit]
+	}
+	return issues
+}
+
 var blockedCmd = &cobra.Command{
 	Use:   "blocked",
 	Short: "Show blocked issues",
@@ -452,7 +581,7 @@ func init() {
 	readyCmd.Flags().IntP("priority", "p", 0, "Filter by priority")
 	readyCmd.Flags().StringP("assignee", "a", "", "Filter by assignee")
 	readyCmd.Flags().BoolP("unassigned", "u", false, "Show only unassigned issues")
-	readyCmd.Flags().StringP("sort", "s", "hybrid", "Sort policy: hybrid (default), priority, oldest")
+	readyCmd.Flags().StringP("sort", "s", "hybrid", "Sort policy: hybrid (default), priority, oldest, or a scored ranking: unblock, critical-path, due, wsjf, age, score (formula from ready.score config)")
 	readyCmd.Flags().StringSliceP("label", "l", []string{}, "Filter by labels (AND: must have ALL). Can combine with --label-any")
 	readyCmd.Flags().StringSlice("label-any", []string{}, "Filter by labels (OR: must have AT LEAST ONE). Can combine with --label")
 	readyCmd.Flags().StringP("type", "t", "", "Filter by issue type (task, bug, feature, epic, merge-request). Aliases: mr→merge-request, feat→feature, mol→molecule")
@@ -462,6 +591,9 @@ func init() {
 	readyCmd.Flags().Bool("pretty", false, "Display issues in a tree format with status/priority symbols")
 	readyCmd.Flags().Bool("include-deferred", false, "Include issues with future defer_until timestamps")
 	readyCmd.Flags().Bool("gated", false, "Find molecules ready for gate-resume dispatch")
+	readyCmd.Flags().String("view", "", "Apply a saved view (see 'bd views'); explicit flags override it")
+	readyCmd.Flags().String("where", "", whereFlagUsage)
+	readyCmd.Flags().StringSlice("columns", nil, "Show a table of these columns: "+strings.Join(viewColumns, ",")+", or custom fields")
 	rootCmd.AddCommand(readyCmd)
 	blockedCmd.Flags().String("parent", "", "Filter to descendants of this bead/epic")
 	rootCmd.AddCommand(blockedCmd)
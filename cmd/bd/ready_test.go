@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/steveyegge/beads/internal/ranking"
 	"github.com/steveyegge/beads/internal/types"
 )
 
@@ -452,3 +453,20 @@ func TestReadyWorkUnassigned(t *testing.T) {
 		}
 	}
 }
+
+func TestFormatScore(t *testing.T) {
+	s := &ranking.Score{
+		Total: 22.5,
+		Components: []ranking.Component{
+			{Factor: ranking.FactorUnblock, Value: 10, Weight: 2, Contribution: 20},
+			{Factor: ranking.FactorDue, Value: 0, Weight: 3, Contribution: 0},
+			{Factor: ranking.FactorPriority, Value: 2.5, Weight: 1, Contribution: 2.5},
+		},
+	}
+	if got, want := formatScore(s), "22.5 (unblock 10 ×2, priority 2.5)"; got != want {
+		t.Errorf("formatScore = %q, want %q", got, want)
+	}
+	if got := formatScore(&ranking.Score{}); got != "0" {
+		t.Errorf("formatScore(empty) = %q, want 0", got)
+	}
+}
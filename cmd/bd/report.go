@@ -0,0 +1,18 @@
+package main
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var reportCmd = &cobra.Command{
+	Use:     "report",
+	GroupID: "views",
+	Short:   "Aggregate reports over issues",
+	Long: `Aggregate reports over issues.
+
+Reports read the local database directly and support --json for tooling.`,
+}
+
+func init() {
+	rootCmd.AddCommand(reportCmd)
+}
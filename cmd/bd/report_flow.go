@@ -0,0 +1,748 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+// Dimensions supported by bd report flow --by
+var flowReportDimensions = []string{"assignee", "type", "priority"}
+
+// Sections of bd report flow output, selectable with --section
+var flowReportSections = []string{"summary", "series", "groups", "issues"}
+
+var reportFlowCmd = &cobra.Command{
+	Use:   "flow",
+	Short: "Cycle time, lead time, throughput, WIP and burndown",
+	Long: `Derive flow metrics from the status transitions recorded in the event log.
+
+For issues closed in the window (--since/--until, default the last 12 weeks):
+  lead time    created → closed
+  cycle time   first start → closed
+
+Work starts the first time an issue enters an active status: in_progress,
+hooked, review or a custom workflow status. A reopened issue counts from its
+first start to its final close; a claim whose lease expired is abandoned work
+and the clock restarts at the next claim.
+
+The series section shows, per week or day (UTC): issues started, issues
+closed (throughput), work in progress at the end of the period, and the
+burnup/burndown of the scope (issues created so far, done, remaining).
+Scope it to an epic's descendants with --epic or to a label with --label.
+
+Issues imported from another clone have no local transitions; their close
+comes from closed_at and they count toward lead time and throughput only.
+
+Output formats:
+  table   summary, series and groups (add issues with --section)
+  csv     one section, chosen with --section (default issues)
+  json    everything (same as --json)
+
+Examples:
+  bd report flow
+  bd report flow --since -4w --interval day --epic bd-42
+  bd report flow --label agents --by assignee
+  bd report flow --format csv --section series > flow.csv`,
+	Run: func(cmd *cobra.Command, args []string) {
+		sinceFlag, _ := cmd.Flags().GetString("since")
+		untilFlag, _ := cmd.Flags().GetString("until")
+		byFlag, _ := cmd.Flags().GetString("by")
+		interval, _ := cmd.Flags().GetString("interval")
+		epicFlag, _ := cmd.Flags().GetString("epic")
+		labelFlag, _ := cmd.Flags().GetString("label")
+		format, _ := cmd.Flags().GetString("format")
+		sectionFlag, _ := cmd.Flags().GetString("section")
+
+		if jsonOutput {
+			format = "json"
+		}
+		if format != "table" && format != "csv" && format != "json" {
+			FatalErrorRespectJSON("invalid --format %q (valid: table, csv, json)", format)
+		}
+		if interval != "week" && interval != "day" {
+			FatalErrorRespectJSON("invalid --interval %q (valid: week, day)", interval)
+		}
+		if epicFlag != "" && labelFlag != "" {
+			FatalErrorRespectJSON("--epic and --label cannot be combined")
+		}
+		dims, err := parseReportDimensions(byFlag, flowReportDimensions)
+		if err != nil {
+			FatalErrorRespectJSON("invalid --by: %v", err)
+		}
+		sections, err := parseFlowSections(sectionFlag, format)
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		since, err := parseTimeFlag(sinceFlag)
+		if err != nil {
+			FatalErrorRespectJSON("invalid --since: %v", err)
+		}
+		until := time.Now()
+		if untilFlag != "" {
+			if until, err = parseTimeFlag(untilFlag); err != nil {
+				FatalErrorRespectJSON("invalid --until: %v", err)
+			}
+		}
+		if !since.Before(until) {
+			FatalErrorRespectJSON("--since must be before --until")
+		}
+
+		if err := ensureDirectMode("flow report requires direct database access"); err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		ctx := rootCtx
+		if err := ensureDatabaseFresh(ctx); err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+
+		notTemplate := false
+		issues, err := store.SearchIssues(ctx, "", types.IssueFilter{IsTemplate: &notTemplate})
+		if err != nil {
+			FatalErrorRespectJSON("listing issues: %v", err)
+		}
+
+		scope := ""
+		switch {
+		case epicFlag != "":
+			epicID, err := utils.ResolvePartialID(ctx, store, epicFlag)
+			if err != nil {
+				FatalErrorRespectJSON("epic '%s' not found: %v", epicFlag, err)
+			}
+			deps, err := store.GetAllDependencyRecords(ctx)
+			if err != nil {
+				FatalErrorRespectJSON("getting dependencies: %v", err)
+			}
+			issues = filterIssues(issues, flowDescendants(epicID, deps))
+			scope = "epic " + epicID
+		case labelFlag != "":
+			withLabel, err := store.GetIssuesByLabel(ctx, labelFlag)
+			if err != nil {
+				FatalErrorRespectJSON("getting issues with label %s: %v", labelFlag, err)
+			}
+			ids := make(map[string]bool, len(withLabel))
+			for _, issue := range withLabel {
+				ids[issue.ID] = true
+			}
+			issues = filterIssues(issues, ids)
+			scope = "label " + labelFlag
+		}
+
+		ids := make([]string, 0, len(issues))
+		for _, issue := range issues {
+			ids = append(ids, issue.ID)
+		}
+		events, err := store.GetEventsForIssues(ctx, ids)
+		if err != nil {
+			FatalErrorRespectJSON("getting events: %v", err)
+		}
+
+		report := buildFlowReport(issues, events, since, until, interval, dims)
+		report.Scope = scope
+
+		switch format {
+		case "json":
+			outputJSON(report)
+		case "csv":
+			if err := writeFlowCSV(os.Stdout, report, sections[0]); err != nil {
+				FatalError("writing CSV: %v", err)
+			}
+		default:
+			printFlowReport(report, sections)
+		}
+	},
+}
+
+// flowTransition is an issue entering a status.
+type flowTransition struct {
+	At     time.Time
+	Status types.Status
+	// Reclaimed marks a return to open because a claim lease lapsed; the
+	// abandoned work before it doesn't count toward cycle time
+	Reclaimed bool
+}
+
+// flowDurationStats summarizes a set of durations, in hours.
+type flowDurationStats struct {
+	Count    int     `json:"count"`
+	AvgHours float64 `json:"avg_hours"`
+	P50Hours float64 `json:"p50_hours"`
+	P85Hours float64 `json:"p85_hours"`
+	MaxHours float64 `json:"max_hours"`
+}
+
+// flowIssue is the flow record of one issue closed in the window.
+type flowIssue struct {
+	ID         string     `json:"id"`
+	Title      string     `json:"title"`
+	Type       string     `json:"type"`
+	Priority   int        `json:"priority"`
+	Assignee   string     `json:"assignee,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	StartedAt  *time.Time `json:"started_at,omitempty"`
+	ClosedAt   time.Time  `json:"closed_at"`
+	CycleHours *float64   `json:"cycle_hours,omitempty"`
+	LeadHours  float64    `json:"lead_hours"`
+	Reopened   int        `json:"reopened,omitempty"`
+	// NoHistory is set when the transitions weren't all recorded locally
+	// (e.g. the issue was imported already closed), so only lead time is known.
+	NoHistory bool `json:"no_history,omitempty"`
+}
+
+// flowPeriod is one week or day of the series.
+type flowPeriod struct {
+	Period    string    `json:"period"`
+	Start     time.Time `json:"start"`
+	Started   int       `json:"started"`
+	Closed    int       `json:"closed"`
+	WIP       int       `json:"wip"`
+	Scope     int       `json:"scope"`
+	Done      int       `json:"done"`
+	Remaining int       `json:"remaining"`
+}
+
+// flowGroupRow aggregates the issues closed in the window for one group.
+type flowGroupRow struct {
+	Group     string            `json:"group"`
+	Completed int               `json:"completed"`
+	Cycle     flowDurationStats `json:"cycle_time"`
+	Lead      flowDurationStats `json:"lead_time"`
+}
+
+// flowReport is the result of bd report flow.
+type flowReport struct {
+	Since             time.Time                 `json:"since"`
+	Until             time.Time                 `json:"until"`
+	Interval          string                    `json:"interval"`
+	Scope             string                    `json:"scope,omitempty"`
+	Dimensions        []string                  `json:"dimensions"`
+	Total             flowGroupRow              `json:"total"`
+	ThroughputPerWeek float64                   `json:"throughput_per_week"`
+	WIP               int                       `json:"wip"`
+	NoHistory         int                       `json:"no_history"`
+	Series            []flowPeriod              `json:"series"`
+	Groups            map[string][]flowGroupRow `json:"groups"`
+	Issues            []flowIssue               `json:"issues"`
+}
+
+// flowActive reports whether an issue in status is being worked on. Custom
+// workflow statuses count as active.
+func flowActive(status types.Status) bool {
+	switch status {
+	case types.StatusOpen, types.StatusClosed, types.StatusBlocked, types.StatusDeferred,
+		types.StatusTombstone, types.StatusPinned, "":
+		return false
+	}
+	return true
+}
+
+// flowEventStatus returns the status an event moved its issue to, or "" if
+// the event didn't change the status.
+func flowEventStatus(e *types.Event) types.Status {
+	switch e.EventType {
+	case types.EventClosed:
+		return types.StatusClosed
+	case types.EventCreated, types.EventStatusChanged, types.EventReopened, types.EventClaimed, types.EventLeaseExpired:
+		if e.NewValue == nil {
+			return ""
+		}
+		var v struct {
+			Status types.Status `json:"status"`
+		}
+		if err := json.Unmarshal([]byte(*e.NewValue), &v); err != nil {
+			return ""
+		}
+		return v.Status
+	}
+	return ""
+}
+
+// flowTimeline rebuilds an issue's status history from its events (oldest
+// first). recorded is false when part of the history predates the local
+// event log, e.g. the issue was imported in progress or closed.
+func flowTimeline(issue *types.Issue, events []*types.Event) (timeline []flowTransition, recorded bool) {
+	timeline = []flowTransition{{At: issue.CreatedAt, Status: types.StatusOpen}}
+	recorded = true
+	move := func(at time.Time, status types.Status) {
+		if last := timeline[len(timeline)-1]; last.Status == status {
+			return
+		}
+		if at.Before(timeline[len(timeline)-1].At) {
+			at = timeline[len(timeline)-1].At
+		}
+		timeline = append(timeline, flowTransition{At: at, Status: status})
+	}
+
+	for _, e := range events {
+		status := flowEventStatus(e)
+		if status == "" {
+			continue
+		}
+		if e.EventType == types.EventCreated && status != types.StatusOpen {
+			// Created straight into another status: imported mid-flight, so
+			// when it got there is unknown
+			recorded = false
+			if status == types.StatusClosed && issue.ClosedAt != nil && issue.Status == types.StatusClosed {
+				move(*issue.ClosedAt, status)
+				continue
+			}
+		}
+		before := len(timeline)
+		move(e.CreatedAt, status)
+		if e.EventType == types.EventLeaseExpired && len(timeline) > before {
+			timeline[len(timeline)-1].Reclaimed = true
+		}
+	}
+
+	if issue.Status == types.StatusClosed && timeline[len(timeline)-1].Status != types.StatusClosed {
+		closedAt := issue.UpdatedAt
+		if issue.ClosedAt != nil {
+			closedAt = *issue.ClosedAt
+		}
+		recorded = false
+		move(closedAt, types.StatusClosed)
+	}
+	return timeline, recorded
+}
+
+// flowStatusAt returns the status of a timeline at t, or "" before creation.
+func flowStatusAt(timeline []flowTransition, t time.Time) types.Status {
+	status := types.Status("")
+	for _, tr := range timeline {
+		if tr.At.After(t) {
+			break
+		}
+		status = tr.Status
+	}
+	return status
+}
+
+// flowStarted returns when a timeline first entered an active status,
+// ignoring work abandoned before a lease reclaim.
+func flowStarted(timeline []flowTransition) (time.Time, bool) {
+	var started time.Time
+	ok := false
+	for _, tr := range timeline {
+		if tr.Reclaimed {
+			ok = false
+		} else if !ok && flowActive(tr.Status) {
+			started, ok = tr.At, true
+		}
+	}
+	return started, ok
+}
+
+// flowClosed returns the final close of a timeline, if it ends closed.
+func flowClosed(timeline []flowTransition) (time.Time, bool) {
+	last := timeline[len(timeline)-1]
+	return last.At, last.Status == types.StatusClosed
+}
+
+// buildFlowReport computes flow metrics over [since, until). Periods are
+// UTC weeks (starting Monday) or days.
+func buildFlowReport(issues []*types.Issue, events map[string][]*types.Event, since, until time.Time, interval string, dims []string) *flowReport {
+	report := &flowReport{
+		Since:      since,
+		Until:      until,
+		Interval:   interval,
+		Dimensions: dims,
+		Groups:     make(map[string][]flowGroupRow),
+		Series:     []flowPeriod{},
+		Issues:     []flowIssue{},
+	}
+
+	type tracked struct {
+		issue    *types.Issue
+		timeline []flowTransition
+	}
+	var all []tracked
+	for _, issue := range issues {
+		if issue.Status == types.StatusTombstone {
+			continue
+		}
+		timeline, recorded := flowTimeline(issue, events[issue.ID])
+		all = append(all, tracked{issue, timeline})
+
+		if flowActive(flowStatusAt(timeline, until)) {
+			report.WIP++
+		}
+		closedAt, closed := flowClosed(timeline)
+		if !closed || closedAt.Before(since) || !closedAt.Before(until) {
+			continue
+		}
+		fi := flowIssue{
+			ID:        issue.ID,
+			Title:     issue.Title,
+			Type:      string(issue.IssueType),
+			Priority:  issue.Priority,
+			Assignee:  issue.Assignee,
+			CreatedAt: issue.CreatedAt,
+			ClosedAt:  closedAt,
+			LeadHours: flowHours(closedAt.Sub(issue.CreatedAt)),
+			NoHistory: !recorded,
+		}
+		if started, ok := flowStarted(timeline); ok && recorded {
+			fi.StartedAt = &started
+			cycle := flowHours(closedAt.Sub(started))
+			fi.CycleHours = &cycle
+		}
+		for i := 1; i < len(timeline); i++ {
+			if timeline[i-1].Status == types.StatusClosed {
+				fi.Reopened++
+			}
+		}
+		if fi.NoHistory {
+			report.NoHistory++
+		}
+		report.Issues = append(report.Issues, fi)
+	}
+	sort.Slice(report.Issues, func(i, j int) bool {
+		if !report.Issues[i].ClosedAt.Equal(report.Issues[j].ClosedAt) {
+			return report.Issues[i].ClosedAt.Before(report.Issues[j].ClosedAt)
+		}
+		return report.Issues[i].ID < report.Issues[j].ID
+	})
+
+	report.Total = flowGroup("total", report.Issues)
+	report.ThroughputPerWeek = math.Round(float64(report.Total.Completed)/(until.Sub(since).Hours()/(24*7))*100) / 100
+
+	for start := flowPeriodStart(since, interval); start.Before(until); start = flowPeriodNext(start, interval) {
+		end := flowPeriodNext(start, interval)
+		at := end
+		if until.Before(at) {
+			at = until
+		}
+		p := flowPeriod{Period: flowPeriodLabel(start, interval), Start: start}
+		for _, t := range all {
+			if started, ok := flowStarted(t.timeline); ok && !started.Before(start) && started.Before(end) && started.Before(until) {
+				p.Started++
+			}
+			if closedAt, ok := flowClosed(t.timeline); ok && !closedAt.Before(start) && closedAt.Before(at) {
+				p.Closed++
+			}
+			switch status := flowStatusAt(t.timeline, at); {
+			case status == "":
+				continue
+			case status == types.StatusClosed:
+				p.Done++
+			case flowActive(status):
+				p.WIP++
+			}
+			p.Scope++
+		}
+		p.Remaining = p.Scope - p.Done
+		report.Series = append(report.Series, p)
+	}
+
+	for _, dim := range dims {
+		byGroup := make(map[string][]flowIssue)
+		for _, fi := range report.Issues {
+			group := ""
+			switch dim {
+			case "assignee":
+				group = fi.Assignee
+				if group == "" {
+					group = "(unassigned)"
+				}
+			case "type":
+				group = fi.Type
+			case "priority":
+				group = fmt.Sprintf("P%d", fi.Priority)
+			}
+			byGroup[group] = append(byGroup[group], fi)
+		}
+		rows := make([]flowGroupRow, 0, len(byGroup))
+		for group, list := range byGroup {
+			rows = append(rows, flowGroup(group, list))
+		}
+		sort.Slice(rows, func(i, j int) bool {
+			if dim != "priority" && rows[i].Completed != rows[j].Completed {
+				return rows[i].Completed > rows[j].Completed
+			}
+			return rows[i].Group < rows[j].Group
+		})
+		report.Groups[dim] = rows
+	}
+	return report
+}
+
+// flowGroup aggregates cycle and lead times of issues.
+func flowGroup(group string, issues []flowIssue) flowGroupRow {
+	var cycle, lead []float64
+	for _, fi := range issues {
+		lead = append(lead, fi.LeadHours)
+		if fi.CycleHours != nil {
+			cycle = append(cycle, *fi.CycleHours)
+		}
+	}
+	return flowGroupRow{
+		Group:     group,
+		Completed: len(issues),
+		Cycle:     flowStats(cycle),
+		Lead:      flowStats(lead),
+	}
+}
+
+// flowStats summarizes durations in hours. Percentiles use nearest rank.
+func flowStats(hours []float64) flowDurationStats {
+	stats := flowDurationStats{Count: len(hours)}
+	if len(hours) == 0 {
+		return stats
+	}
+	sorted := append([]float64(nil), hours...)
+	sort.Float64s(sorted)
+	sum := 0.0
+	for _, h := range sorted {
+		sum += h
+	}
+	rank := func(p float64) float64 {
+		return sorted[int(math.Ceil(p*float64(len(sorted))))-1]
+	}
+	stats.AvgHours = math.Round(sum/float64(len(sorted))*100) / 100
+	stats.P50Hours = rank(0.5)
+	stats.P85Hours = rank(0.85)
+	stats.MaxHours = sorted[len(sorted)-1]
+	return stats
+}
+
+// flowHours converts d to hours, rounded to hundredths.
+func flowHours(d time.Duration) float64 {
+	return math.Round(d.Hours()*100) / 100
+}
+
+// flowPeriodStart returns the start of the week (Monday) or day containing t, in UTC.
+func flowPeriodStart(t time.Time, interval string) time.Time {
+	t = t.UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	if interval == "day" {
+		return day
+	}
+	return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+}
+
+func flowPeriodNext(start time.Time, interval string) time.Time {
+	if interval == "day" {
+		return start.AddDate(0, 0, 1)
+	}
+	return start.AddDate(0, 0, 7)
+}
+
+func flowPeriodLabel(start time.Time, interval string) string {
+	if interval == "day" {
+		return start.Format("2006-01-02")
+	}
+	return isoWeek(start)
+}
+
+// flowDescendants returns the IDs of an epic's descendants via parent-child
+// dependencies, not including the epic.
+func flowDescendants(epicID string, deps map[string][]*types.Dependency) map[string]bool {
+	children := make(map[string][]string)
+	for _, list := range deps {
+		for _, dep := range list {
+			if dep.Type == types.DepParentChild {
+				children[dep.DependsOnID] = append(children[dep.DependsOnID], dep.IssueID)
+			}
+		}
+	}
+	result := make(map[string]bool)
+	queue := []string{epicID}
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		for _, child := range children[id] {
+			if !result[child] && child != epicID {
+				result[child] = true
+				queue = append(queue, child)
+			}
+		}
+	}
+	return result
+}
+
+func filterIssues(issues []*types.Issue, keep map[string]bool) []*types.Issue {
+	var result []*types.Issue
+	for _, issue := range issues {
+		if keep[issue.ID] {
+			result = append(result, issue)
+		}
+	}
+	return result
+}
+
+// parseFlowSections parses --section. CSV output takes exactly one section.
+func parseFlowSections(s, format string) ([]string, error) {
+	if s == "" {
+		switch format {
+		case "csv":
+			return []string{"issues"}, nil
+		default:
+			return []string{"summary", "series", "groups"}, nil
+		}
+	}
+	sections, err := parseReportDimensions(s, flowReportSections)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --section: %w", err)
+	}
+	if format == "csv" && (len(sections) != 1 || sections[0] == "summary") {
+		return nil, fmt.Errorf("--format csv takes one --section: issues, series or groups")
+	}
+	return sections, nil
+}
+
+// formatFlowHours formats hours as hours below a day and days above.
+func formatFlowHours(h float64) string {
+	if h < 24 {
+		return fmt.Sprintf("%.1fh", h)
+	}
+	return fmt.Sprintf("%.1fd", h/24)
+}
+
+func formatFlowStats(s flowDurationStats) string {
+	if s.Count == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("median %s, 85th pct %s, avg %s (%d issue(s))",
+		formatFlowHours(s.P50Hours), formatFlowHours(s.P85Hours), formatFlowHours(s.AvgHours), s.Count)
+}
+
+func formatFlowPercentile(s flowDurationStats, p float64) string {
+	if s.Count == 0 {
+		return "-"
+	}
+	return formatFlowHours(p)
+}
+
+func printFlowReport(report *flowReport, sections []string) {
+	if containsString(sections, "summary") {
+		fmt.Printf("\n%s Flow report %s → %s", ui.RenderAccent("📈"),
+			report.Since.Format("2006-01-02"), report.Until.Format("2006-01-02"))
+		if report.Scope != "" {
+			fmt.Printf(" (%s)", report.Scope)
+		}
+		fmt.Println()
+		fmt.Printf("Completed:  %d issue(s), %.1f/week\n", report.Total.Completed, report.ThroughputPerWeek)
+		fmt.Printf("In progress now: %d\n", report.WIP)
+		fmt.Printf("Cycle time: %s\n", formatFlowStats(report.Total.Cycle))
+		fmt.Printf("Lead time:  %s\n", formatFlowStats(report.Total.Lead))
+		if report.NoHistory > 0 {
+			fmt.Println(ui.RenderWarn(fmt.Sprintf("%d issue(s) have no recorded transitions (imported); counted in lead time only", report.NoHistory)))
+		}
+	}
+
+	if containsString(sections, "series") {
+		fmt.Printf("\nPer %s:\n", report.Interval)
+		fmt.Printf("  %-10s  %7s  %6s  %4s  %5s  %4s  %9s\n", "PERIOD", "STARTED", "CLOSED", "WIP", "SCOPE", "DONE", "REMAINING")
+		for _, p := range report.Series {
+			fmt.Printf("  %-10s  %7d  %6d  %4d  %5d  %4d  %9d\n", p.Period, p.Started, p.Closed, p.WIP, p.Scope, p.Done, p.Remaining)
+		}
+	}
+
+	if containsString(sections, "groups") {
+		for _, dim := range report.Dimensions {
+			rows := report.Groups[dim]
+			if len(rows) == 0 {
+				continue
+			}
+			width := len("GROUP")
+			for _, row := range rows {
+				width = max(width, len(row.Group))
+			}
+			fmt.Printf("\nBy %s:\n", dim)
+			fmt.Printf("  %-*s  %4s  %9s  %9s  %8s  %8s\n", width, "GROUP", "DONE", "CYCLE P50", "CYCLE P85", "LEAD P50", "LEAD P85")
+			for _, row := range rows {
+				fmt.Printf("  %-*s  %4d  %9s  %9s  %8s  %8s\n", width, row.Group, row.Completed,
+					formatFlowPercentile(row.Cycle, row.Cycle.P50Hours), formatFlowPercentile(row.Cycle, row.Cycle.P85Hours),
+					formatFlowPercentile(row.Lead, row.Lead.P50Hours), formatFlowPercentile(row.Lead, row.Lead.P85Hours))
+			}
+		}
+	}
+
+	if containsString(sections, "issues") {
+		fmt.Printf("\nClosed issues:\n")
+		if len(report.Issues) == 0 {
+			fmt.Println("  (none)")
+		}
+		for _, fi := range report.Issues {
+			cycle := "-"
+			if fi.CycleHours != nil {
+				cycle = formatFlowHours(*fi.CycleHours)
+			}
+			fmt.Printf("  %s  %s  cycle %6s  lead %6s  %s\n", ui.RenderID(fi.ID),
+				fi.ClosedAt.Local().Format("2006-01-02"), cycle, formatFlowHours(fi.LeadHours), fi.Title)
+		}
+	}
+	fmt.Println()
+}
+
+// writeFlowCSV writes one section of the report as CSV.
+func writeFlowCSV(out io.Writer, report *flowReport, section string) error {
+	w := csv.NewWriter(out)
+	hours := func(h float64) string { return strconv.FormatFloat(h, 'f', 2, 64) }
+	stamp := func(t time.Time) string { return t.UTC().Format(time.RFC3339) }
+
+	switch section {
+	case "series":
+		_ = w.Write([]string{"period", "start", "started", "closed", "wip", "scope", "done", "remaining"})
+		for _, p := range report.Series {
+			_ = w.Write([]string{p.Period, stamp(p.Start), strconv.Itoa(p.Started), strconv.Itoa(p.Closed),
+				strconv.Itoa(p.WIP), strconv.Itoa(p.Scope), strconv.Itoa(p.Done), strconv.Itoa(p.Remaining)})
+		}
+	case "groups":
+		_ = w.Write([]string{"dimension", "group", "completed",
+			"cycle_count", "cycle_avg_hours", "cycle_p50_hours", "cycle_p85_hours",
+			"lead_count", "lead_avg_hours", "lead_p50_hours", "lead_p85_hours"})
+		write := func(dim string, row flowGroupRow) {
+			_ = w.Write([]string{dim, row.Group, strconv.Itoa(row.Completed),
+				strconv.Itoa(row.Cycle.Count), hours(row.Cycle.AvgHours), hours(row.Cycle.P50Hours), hours(row.Cycle.P85Hours),
+				strconv.Itoa(row.Lead.Count), hours(row.Lead.AvgHours), hours(row.Lead.P50Hours), hours(row.Lead.P85Hours)})
+		}
+		write("total", report.Total)
+		for _, dim := range report.Dimensions {
+			for _, row := range report.Groups[dim] {
+				write(dim, row)
+			}
+		}
+	default:
+		_ = w.Write([]string{"id", "title", "type", "priority", "assignee", "created_at", "started_at", "closed_at",
+			"cycle_hours", "lead_hours", "reopened"})
+		for _, fi := range report.Issues {
+			started, cycle := "", ""
+			if fi.StartedAt != nil {
+				started = stamp(*fi.StartedAt)
+			}
+			if fi.CycleHours != nil {
+				cycle = hours(*fi.CycleHours)
+			}
+			_ = w.Write([]string{fi.ID, fi.Title, fi.Type, strconv.Itoa(fi.Priority), fi.Assignee,
+				stamp(fi.CreatedAt), started, stamp(fi.ClosedAt), cycle, hours(fi.LeadHours), strconv.Itoa(fi.Reopened)})
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
+
+func init() {
+	reportFlowCmd.Flags().String("since", "-12w", "Start of the window (date or relative, e.g. -4w)")
+	reportFlowCmd.Flags().String("until", "", "End of the window (default now)")
+	reportFlowCmd.Flags().String("by", strings.Join(flowReportDimensions, ","), "Dimensions to group by (assignee, type, priority)")
+	reportFlowCmd.Flags().String("interval", "week", "Series period: week or day")
+	reportFlowCmd.Flags().String("epic", "", "Only include descendants of this epic")
+	reportFlowCmd.Flags().String("label", "", "Only include issues with this label")
+	reportFlowCmd.Flags().String("format", "table", "Output format: table, csv or json")
+	reportFlowCmd.Flags().String("section", "", "Sections to show: summary, series, groups, issues (csv takes one)")
+
+	reportCmd.AddCommand(reportFlowCmd)
+}
@@ -0,0 +1,241 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// flowEvent builds an event moving issueID to status at.
+func flowEvent(issueID string, typ types.EventType, status types.Status, at time.Time) *types.Event {
+	e := &types.Event{IssueID: issueID, EventType: typ, CreatedAt: at}
+	if status != "" {
+		v := `{"status":"` + string(status) + `"}`
+		e.NewValue = &v
+	}
+	return e
+}
+
+func TestFlowTimeline(t *testing.T) {
+	day := time.Date(2025, 3, 3, 9, 0, 0, 0, time.UTC) // Monday
+	at := func(d int) time.Time { return day.AddDate(0, 0, d) }
+
+	issue := &types.Issue{ID: "bd-1", Status: types.StatusClosed, CreatedAt: at(0)}
+	events := []*types.Event{
+		flowEvent("bd-1", types.EventCreated, types.StatusOpen, at(0)),
+		{IssueID: "bd-1", EventType: types.EventUpdated, CreatedAt: at(1)},
+		flowEvent("bd-1", types.EventClaimed, types.StatusInProgress, at(1)),
+		flowEvent("bd-1", types.EventClosed, "", at(2)),
+		flowEvent("bd-1", types.EventReopened, types.StatusOpen, at(3)),
+		flowEvent("bd-1", types.EventStatusChanged, types.StatusReview, at(4)),
+		flowEvent("bd-1", types.EventClosed, "", at(5)),
+	}
+	timeline, recorded := flowTimeline(issue, events)
+	if !recorded {
+		t.Error("expected a fully recorded history")
+	}
+	var got []string
+	for _, tr := range timeline {
+		got = append(got, string(tr.Status))
+	}
+	if s := strings.Join(got, ","); s != "open,in_progress,closed,open,review,closed" {
+		t.Fatalf("timeline = %s", s)
+	}
+	if started, _ := flowStarted(timeline); !started.Equal(at(1)) {
+		t.Errorf("started = %v, want day 1", started)
+	}
+	if closed, ok := flowClosed(timeline); !ok || !closed.Equal(at(5)) {
+		t.Errorf("closed = %v %v, want final close on day 5", closed, ok)
+	}
+	if s := flowStatusAt(timeline, at(3).Add(time.Hour)); s != types.StatusOpen {
+		t.Errorf("status after reopen = %s", s)
+	}
+
+	// Imported already closed: close comes from closed_at
+	closedAt := at(2)
+	imported := &types.Issue{ID: "bd-2", Status: types.StatusClosed, CreatedAt: at(0), ClosedAt: &closedAt}
+	timeline, recorded = flowTimeline(imported, []*types.Event{flowEvent("bd-2", types.EventCreated, types.StatusClosed, at(9))})
+	if recorded || len(timeline) != 2 || !timeline[1].At.Equal(closedAt) {
+		t.Errorf("imported closed issue: recorded = %v, timeline = %+v", recorded, timeline)
+	}
+
+	// No events at all (e.g. backend without an event log)
+	timeline, recorded = flowTimeline(imported, nil)
+	if recorded || timeline[len(timeline)-1].Status != types.StatusClosed {
+		t.Errorf("closed issue without events: recorded = %v, timeline = %+v", recorded, timeline)
+	}
+}
+
+func TestFlowTimelineLeaseExpired(t *testing.T) {
+	day := time.Date(2025, 3, 3, 9, 0, 0, 0, time.UTC)
+	at := func(d int) time.Time { return day.AddDate(0, 0, d) }
+
+	// Claimed, abandoned until the reaper reclaimed it, then claimed again
+	issue := &types.Issue{ID: "bd-1", Status: types.StatusClosed, CreatedAt: at(0)}
+	events := []*types.Event{
+		flowEvent("bd-1", types.EventCreated, types.StatusOpen, at(0)),
+		flowEvent("bd-1", types.EventClaimed, types.StatusInProgress, at(1)),
+		flowEvent("bd-1", types.EventLeaseExpired, types.StatusOpen, at(2)),
+		flowEvent("bd-1", types.EventClaimed, types.StatusInProgress, at(3)),
+		flowEvent("bd-1", types.EventClosed, "", at(4)),
+	}
+	timeline, _ := flowTimeline(issue, events)
+	var got []string
+	for _, tr := range timeline {
+		got = append(got, string(tr.Status))
+	}
+	if s := strings.Join(got, ","); s != "open,in_progress,open,in_progress,closed" {
+		t.Fatalf("timeline = %s", s)
+	}
+	if s := flowStatusAt(timeline, at(2).Add(time.Hour)); s != types.StatusOpen {
+		t.Errorf("status after lease expiry = %s", s)
+	}
+	if started, _ := flowStarted(timeline); !started.Equal(at(3)) {
+		t.Errorf("started = %v, want the claim after the reclaim on day 3", started)
+	}
+}
+
+func TestBuildFlowReport(t *testing.T) {
+	monday := time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC) // ISO week 10
+	at := func(d, h int) time.Time { return monday.AddDate(0, 0, d).Add(time.Duration(h) * time.Hour) }
+
+	issues := []*types.Issue{
+		{ID: "bd-1", Title: "One", IssueType: types.TypeTask, Priority: 1, Assignee: "alice", Status: types.StatusClosed, CreatedAt: at(0, 0)},
+		{ID: "bd-2", Title: "Two", IssueType: types.TypeBug, Priority: 2, Assignee: "bob", Status: types.StatusClosed, CreatedAt: at(0, 0)},
+		{ID: "bd-3", Title: "Three", IssueType: types.TypeTask, Priority: 1, Assignee: "alice", Status: types.StatusInProgress, CreatedAt: at(1, 0)},
+		{ID: "bd-4", Title: "Four", IssueType: types.TypeTask, Priority: 3, Status: types.StatusOpen, CreatedAt: at(8, 0)},
+		{ID: "bd-5", Title: "Gone", IssueType: types.TypeTask, Status: types.StatusTombstone, CreatedAt: at(0, 0)},
+	}
+	events := map[string][]*types.Event{
+		"bd-1": {
+			flowEvent("bd-1", types.EventStatusChanged, types.StatusInProgress, at(1, 0)),
+			flowEvent("bd-1", types.EventClosed, "", at(1, 12)),
+		},
+		"bd-2": {
+			flowEvent("bd-2", types.EventStatusChanged, types.StatusInProgress, at(2, 0)),
+			flowEvent("bd-2", types.EventClosed, "", at(9, 0)),
+		},
+		"bd-3": {
+			flowEvent("bd-3", types.EventClaimed, types.StatusInProgress, at(8, 0)),
+		},
+	}
+
+	report := buildFlowReport(issues, events, monday, at(14, 0), "week", flowReportDimensions)
+
+	if report.Total.Completed != 2 || report.WIP != 1 || report.NoHistory != 0 {
+		t.Fatalf("completed = %d, wip = %d, no history = %d", report.Total.Completed, report.WIP, report.NoHistory)
+	}
+	if report.ThroughputPerWeek != 1 {
+		t.Errorf("throughput = %v/week, want 1", report.ThroughputPerWeek)
+	}
+	// bd-1: cycle 12h, lead 36h; bd-2: cycle 7d, lead 9d
+	if c := report.Total.Cycle; c.Count != 2 || c.P50Hours != 12 || c.P85Hours != 168 || c.AvgHours != 90 {
+		t.Errorf("cycle stats = %+v", c)
+	}
+	if l := report.Total.Lead; l.P50Hours != 36 || l.MaxHours != 216 {
+		t.Errorf("lead stats = %+v", l)
+	}
+	if report.Issues[0].ID != "bd-1" || report.Issues[1].ID != "bd-2" {
+		t.Errorf("issues not sorted by close: %s, %s", report.Issues[0].ID, report.Issues[1].ID)
+	}
+
+	if len(report.Series) != 2 {
+		t.Fatalf("series has %d periods, want 2", len(report.Series))
+	}
+	w10, w11 := report.Series[0], report.Series[1]
+	if w10.Period != "2025-W10" || w10.Started != 2 || w10.Closed != 1 || w10.WIP != 1 || w10.Scope != 3 || w10.Done != 1 || w10.Remaining != 2 {
+		t.Errorf("week 10 = %+v", w10)
+	}
+	if w11.Started != 1 || w11.Closed != 1 || w11.WIP != 1 || w11.Scope != 4 || w11.Done != 2 || w11.Remaining != 2 {
+		t.Errorf("week 11 = %+v", w11)
+	}
+
+	if rows := report.Groups["assignee"]; len(rows) != 2 || rows[0].Group != "alice" || rows[1].Group != "bob" {
+		t.Errorf("assignee groups = %+v", rows)
+	}
+	if rows := report.Groups["priority"]; len(rows) != 2 || rows[0].Group != "P1" || rows[1].Group != "P2" {
+		t.Errorf("priority groups = %+v", rows)
+	}
+	if rows := report.Groups["type"]; len(rows) != 2 {
+		t.Errorf("type groups = %+v", rows)
+	}
+
+	daily := buildFlowReport(issues, events, monday, at(14, 0), "day", nil)
+	if len(daily.Series) != 14 || daily.Series[1].Period != "2025-03-04" || daily.Series[1].Closed != 1 {
+		t.Errorf("daily series: %d periods, day 2 = %+v", len(daily.Series), daily.Series[1])
+	}
+}
+
+func TestFlowDescendants(t *testing.T) {
+	parent := func(child, p string) *types.Dependency {
+		return &types.Dependency{IssueID: child, DependsOnID: p, Type: types.DepParentChild}
+	}
+	deps := map[string][]*types.Dependency{
+		"bd-1.1":   {parent("bd-1.1", "bd-1")},
+		"bd-1.1.1": {parent("bd-1.1.1", "bd-1.1")},
+		"bd-1.2":   {parent("bd-1.2", "bd-1"), {IssueID: "bd-1.2", DependsOnID: "bd-9", Type: types.DepBlocks}},
+		"bd-9":     {parent("bd-9", "bd-8")},
+	}
+	got := flowDescendants("bd-1", deps)
+	if len(got) != 3 || !got["bd-1.1.1"] || got["bd-9"] || got["bd-1"] {
+		t.Errorf("descendants = %v", got)
+	}
+}
+
+func TestParseFlowSections(t *testing.T) {
+	if s, _ := parseFlowSections("", "table"); strings.Join(s, ",") != "summary,series,groups" {
+		t.Errorf("table default = %v", s)
+	}
+	if s, _ := parseFlowSections("", "csv"); strings.Join(s, ",") != "issues" {
+		t.Errorf("csv default = %v", s)
+	}
+	if _, err := parseFlowSections("series,groups", "csv"); err == nil {
+		t.Error("csv should take a single section")
+	}
+	if _, err := parseFlowSections("summary", "csv"); err == nil {
+		t.Error("csv has no summary section")
+	}
+	if _, err := parseFlowSections("charts", "table"); err == nil {
+		t.Error("expected error for unknown section")
+	}
+}
+
+func TestWriteFlowCSV(t *testing.T) {
+	started := time.Date(2025, 3, 3, 9, 0, 0, 0, time.UTC)
+	cycle := 3.5
+	report := &flowReport{
+		Dimensions: []string{"type"},
+		Total:      flowGroupRow{Group: "total", Completed: 1},
+		Groups:     map[string][]flowGroupRow{"type": {{Group: "bug", Completed: 1}}},
+		Series:     []flowPeriod{{Period: "2025-W10", Start: started, Closed: 1, Scope: 2, Done: 1, Remaining: 1}},
+		Issues: []flowIssue{{ID: "bd-1", Title: "Fix, then ship", Type: "bug", Priority: 1,
+			CreatedAt: started, StartedAt: &started, ClosedAt: started.Add(4 * time.Hour), CycleHours: &cycle, LeadHours: 4}},
+	}
+
+	for section, want := range map[string][][]string{
+		"issues": {{"bd-1", "Fix, then ship", "bug", "1", "", "2025-03-03T09:00:00Z", "2025-03-03T09:00:00Z", "2025-03-03T13:00:00Z", "3.50", "4.00", "0"}},
+		"series": {{"2025-W10", "2025-03-03T09:00:00Z", "0", "1", "0", "2", "1", "1"}},
+		"groups": {{"total", "total", "1", "0", "0.00", "0.00", "0.00", "0", "0.00", "0.00", "0.00"}, {"type", "bug", "1", "0", "0.00", "0.00", "0.00", "0", "0.00", "0.00", "0.00"}},
+	} {
+		var buf bytes.Buffer
+		if err := writeFlowCSV(&buf, report, section); err != nil {
+			t.Fatal(err)
+		}
+		records, err := csv.NewReader(&buf).ReadAll()
+		if err != nil {
+			t.Fatalf("%s: invalid CSV: %v", section, err)
+		}
+		if len(records) != len(want)+1 {
+			t.Fatalf("%s: %d records, want header and %d rows", section, len(records), len(want))
+		}
+		for i, row := range want {
+			if got := strings.Join(records[i+1], "|"); got != strings.Join(row, "|") {
+				t.Errorf("%s row %d = %s, want %s", section, i, got, strings.Join(row, "|"))
+			}
+		}
+	}
+}
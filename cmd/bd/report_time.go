@@ -0,0 +1,414 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+// Dimensions supported by bd report time --by
+var timeReportDimensions = []string{"assignee", "label", "epic", "week"}
+
+var reportTimeCmd = &cobra.Command{
+	Use:   "time",
+	Short: "Compare actual time spent with estimates",
+	Long: `Aggregate logged time (bd time) against issue estimates.
+
+Time is grouped by assignee, label, parent epic and ISO week (UTC). An issue
+with several labels counts toward each of them. For weekly groups, an issue's
+estimate is split across weeks in proportion to the time logged in each.
+
+ACTUAL/EST only covers issues that have an estimate, so unestimated work
+doesn't skew the ratio. Running timers are not counted.
+
+Examples:
+  bd report time                          # All dimensions, all time
+  bd report time --by assignee,week       # Selected dimensions
+  bd report time --since 2025-03-01 --closed
+  bd report time --json`,
+	Run: func(cmd *cobra.Command, args []string) {
+		byFlag, _ := cmd.Flags().GetString("by")
+		sinceFlag, _ := cmd.Flags().GetString("since")
+		untilFlag, _ := cmd.Flags().GetString("until")
+		closedOnly, _ := cmd.Flags().GetBool("closed")
+
+		dims, err := parseReportDimensions(byFlag, timeReportDimensions)
+		if err != nil {
+			FatalErrorRespectJSON("invalid --by: %v", err)
+		}
+		var since, until time.Time
+		if sinceFlag != "" {
+			if since, err = parseTimeFlag(sinceFlag); err != nil {
+				FatalErrorRespectJSON("invalid --since: %v", err)
+			}
+		}
+		if untilFlag != "" {
+			if until, err = parseTimeFlag(untilFlag); err != nil {
+				FatalErrorRespectJSON("invalid --until: %v", err)
+			}
+		}
+
+		if err := ensureDirectMode("time report requires direct database access"); err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		ctx := rootCtx
+		if err := ensureDatabaseFresh(ctx); err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+
+		issues, err := store.SearchIssues(ctx, "", types.IssueFilter{})
+		if err != nil {
+			FatalErrorRespectJSON("listing issues: %v", err)
+		}
+		ids := make([]string, 0, len(issues))
+		for _, issue := range issues {
+			ids = append(ids, issue.ID)
+		}
+		workLog, err := store.GetWorkLogForIssues(ctx, ids)
+		if err != nil {
+			FatalErrorRespectJSON("getting work log: %v", err)
+		}
+		if containsString(dims, "label") {
+			labels, err := store.GetLabelsForIssues(ctx, ids)
+			if err != nil {
+				FatalErrorRespectJSON("getting labels: %v", err)
+			}
+			for _, issue := range issues {
+				issue.Labels = labels[issue.ID]
+			}
+		}
+		var deps map[string][]*types.Dependency
+		if containsString(dims, "epic") {
+			if deps, err = store.GetAllDependencyRecords(ctx); err != nil {
+				FatalErrorRespectJSON("getting dependencies: %v", err)
+			}
+		}
+
+		if closedOnly {
+			closed := issues[:0]
+			for _, issue := range issues {
+				if issue.Status == types.StatusClosed {
+					closed = append(closed, issue)
+				}
+			}
+			issues = closed
+		}
+
+		report := buildTimeReport(issues, workLog, epicResolver(issues, deps), dims, since, until)
+
+		if jsonOutput {
+			outputJSON(report)
+			return
+		}
+		printTimeReport(report)
+	},
+}
+
+// timeReportRow aggregates logged time and estimates for one group.
+type timeReportRow struct {
+	Group            string `json:"group"`
+	Issues           int    `json:"issues"`
+	Entries          int    `json:"entries"`
+	ActualMinutes    int    `json:"actual_minutes"`
+	EstimatedMinutes int    `json:"estimated_minutes"`
+	// ActualEstimatedMinutes is the actual time on issues that have an
+	// estimate, i.e. the numerator of Ratio.
+	ActualEstimatedMinutes int     `json:"actual_estimated_minutes"`
+	Ratio                  float64 `json:"ratio,omitempty"` // actual / estimated
+}
+
+// timeReport is the result of bd report time.
+type timeReport struct {
+	Since         *time.Time                 `json:"since,omitempty"`
+	Until         *time.Time                 `json:"until,omitempty"`
+	Dimensions    []string                   `json:"dimensions"`
+	Total         timeReportRow              `json:"total"`
+	Groups        map[string][]timeReportRow `json:"groups"`
+	RunningTimers int                        `json:"running_timers"`
+}
+
+// timeAccumulator collects one group's totals before they become a row.
+type timeAccumulator struct {
+	issues     map[string]bool
+	entries    int
+	actual     int
+	estimated  float64
+	actualEstd int
+}
+
+func (a *timeAccumulator) add(issueID string, entries, actual int, estimate float64, hasEstimate bool) {
+	if a.issues == nil {
+		a.issues = make(map[string]bool)
+	}
+	a.issues[issueID] = true
+	a.entries += entries
+	a.actual += actual
+	if hasEstimate {
+		a.estimated += estimate
+		a.actualEstd += actual
+	}
+}
+
+func (a *timeAccumulator) row(group string) timeReportRow {
+	row := timeReportRow{
+		Group:                  group,
+		Issues:                 len(a.issues),
+		Entries:                a.entries,
+		ActualMinutes:          a.actual,
+		EstimatedMinutes:       int(a.estimated + 0.5),
+		ActualEstimatedMinutes: a.actualEstd,
+	}
+	if a.estimated > 0 {
+		row.Ratio = float64(a.actualEstd) / a.estimated
+	}
+	return row
+}
+
+// buildTimeReport aggregates the stopped work-log entries of issues that fall
+// within [since, until) by the given dimensions. Zero times leave the window
+// open. epicOf maps an issue ID to its epic's ID, or "" if it has none.
+func buildTimeReport(issues []*types.Issue, workLog map[string][]*types.WorkLogEntry, epicOf func(string) string, dims []string, since, until time.Time) *timeReport {
+	report := &timeReport{
+		Dimensions: dims,
+		Groups:     make(map[string][]timeReportRow),
+	}
+	if !since.IsZero() {
+		report.Since = &since
+	}
+	if !until.IsZero() {
+		report.Until = &until
+	}
+
+	var total timeAccumulator
+	groups := make(map[string]map[string]*timeAccumulator)
+	addTo := func(dim, group, issueID string, entries, actual int, estimate float64, hasEstimate bool) {
+		if groups[dim] == nil {
+			groups[dim] = make(map[string]*timeAccumulator)
+		}
+		acc := groups[dim][group]
+		if acc == nil {
+			acc = &timeAccumulator{}
+			groups[dim][group] = acc
+		}
+		acc.add(issueID, entries, actual, estimate, hasEstimate)
+	}
+
+	for _, issue := range issues {
+		var counted []*types.WorkLogEntry
+		actual := 0
+		for _, e := range workLog[issue.ID] {
+			if e.IsRunning() {
+				report.RunningTimers++
+				continue
+			}
+			if (!since.IsZero() && e.StartedAt.Before(since)) || (!until.IsZero() && !e.StartedAt.Before(until)) {
+				continue
+			}
+			counted = append(counted, e)
+			actual += e.Minutes
+		}
+		if len(counted) == 0 {
+			continue
+		}
+
+		hasEstimate := issue.EstimatedMinutes != nil && *issue.EstimatedMinutes > 0
+		estimate := 0.0
+		if hasEstimate {
+			estimate = float64(*issue.EstimatedMinutes)
+		}
+		total.add(issue.ID, len(counted), actual, estimate, hasEstimate)
+
+		for _, dim := range dims {
+			switch dim {
+			case "assignee":
+				group := issue.Assignee
+				if group == "" {
+					group = "(unassigned)"
+				}
+				addTo(dim, group, issue.ID, len(counted), actual, estimate, hasEstimate)
+			case "label":
+				if len(issue.Labels) == 0 {
+					addTo(dim, "(no label)", issue.ID, len(counted), actual, estimate, hasEstimate)
+				}
+				for _, label := range issue.Labels {
+					addTo(dim, label, issue.ID, len(counted), actual, estimate, hasEstimate)
+				}
+			case "epic":
+				group := ""
+				if epicOf != nil {
+					group = epicOf(issue.ID)
+				}
+				if group == "" {
+					group = "(no epic)"
+				}
+				addTo(dim, group, issue.ID, len(counted), actual, estimate, hasEstimate)
+			case "week":
+				weekEntries := make(map[string]int)
+				weekActual := make(map[string]int)
+				for _, e := range counted {
+					week := isoWeek(e.StartedAt)
+					weekEntries[week]++
+					weekActual[week] += e.Minutes
+				}
+				for week, minutes := range weekActual {
+					// Split the estimate by the share of time logged that week,
+					// falling back to entry counts if nothing had any minutes.
+					share := float64(weekEntries[week]) / float64(len(counted))
+					if actual > 0 {
+						share = float64(minutes) / float64(actual)
+					}
+					addTo(dim, week, issue.ID, weekEntries[week], minutes, estimate*share, hasEstimate)
+				}
+			}
+		}
+	}
+
+	report.Total = total.row("total")
+	for _, dim := range dims {
+		rows := make([]timeReportRow, 0, len(groups[dim]))
+		for group, acc := range groups[dim] {
+			rows = append(rows, acc.row(group))
+		}
+		sort.Slice(rows, func(i, j int) bool {
+			if dim == "week" {
+				return rows[i].Group < rows[j].Group
+			}
+			if rows[i].ActualMinutes != rows[j].ActualMinutes {
+				return rows[i].ActualMinutes > rows[j].ActualMinutes
+			}
+			return rows[i].Group < rows[j].Group
+		})
+		report.Groups[dim] = rows
+	}
+	return report
+}
+
+// epicResolver returns a function mapping an issue ID to its nearest epic
+// ancestor via parent-child dependencies. An epic maps to itself.
+func epicResolver(issues []*types.Issue, deps map[string][]*types.Dependency) func(string) string {
+	byID := make(map[string]*types.Issue, len(issues))
+	for _, issue := range issues {
+		byID[issue.ID] = issue
+	}
+	parentOf := make(map[string]string)
+	for _, list := range deps {
+		for _, dep := range list {
+			if dep.Type == types.DepParentChild {
+				parentOf[dep.IssueID] = dep.DependsOnID
+			}
+		}
+	}
+
+	return func(id string) string {
+		// Depth guard protects against parent-child cycles
+		for depth := 0; id != "" && depth < 50; depth++ {
+			if issue, ok := byID[id]; ok && issue.IssueType == types.TypeEpic {
+				return id
+			}
+			id = parentOf[id]
+		}
+		return ""
+	}
+}
+
+// parseReportDimensions parses a comma-separated list of report dimensions
+// (or sections), dropping duplicates.
+func parseReportDimensions(s string, valid []string) ([]string, error) {
+	var dims []string
+	for _, part := range strings.Split(s, ",") {
+		dim := strings.ToLower(strings.TrimSpace(part))
+		if dim == "" || containsString(dims, dim) {
+			continue
+		}
+		if !containsString(valid, dim) {
+			return nil, fmt.Errorf("unknown value %q (valid: %s)", dim, strings.Join(valid, ", "))
+		}
+		dims = append(dims, dim)
+	}
+	if len(dims) == 0 {
+		return nil, fmt.Errorf("need at least one of: %s", strings.Join(valid, ", "))
+	}
+	return dims, nil
+}
+
+// isoWeek formats t's ISO week in UTC, e.g. "2025-W03".
+func isoWeek(t time.Time) string {
+	year, week := t.UTC().ISOWeek()
+	return fmt.Sprintf("%d-W%02d", year, week)
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+func printTimeReport(report *timeReport) {
+	if report.Total.Entries == 0 {
+		fmt.Println("No time logged in this period (record time with: bd time log <id> <duration>)")
+		if report.RunningTimers > 0 {
+			fmt.Printf("%d timer(s) running\n", report.RunningTimers)
+		}
+		return
+	}
+
+	fmt.Printf("\n%s %s\n", ui.RenderAccent("⏱"), "Time report")
+	fmt.Printf("Total: %s\n", formatTimeReportRow(report.Total))
+	if report.RunningTimers > 0 {
+		fmt.Printf("%s\n", ui.RenderWarn(fmt.Sprintf("%d timer(s) still running, not counted", report.RunningTimers)))
+	}
+
+	for _, dim := range report.Dimensions {
+		rows := report.Groups[dim]
+		width := len("GROUP")
+		for _, row := range rows {
+			width = max(width, len(row.Group))
+		}
+		fmt.Printf("\nBy %s:\n", dim)
+		fmt.Printf("  %-*s  %6s  %8s  %8s  %10s\n", width, "GROUP", "ISSUES", "ACTUAL", "ESTIMATE", "ACTUAL/EST")
+		for _, row := range rows {
+			fmt.Printf("  %-*s  %6d  %8s  %8s  %10s\n", width, row.Group, row.Issues,
+				formatMinutes(row.ActualMinutes), formatEstimate(row), formatRatio(row))
+		}
+	}
+	fmt.Println()
+}
+
+func formatTimeReportRow(row timeReportRow) string {
+	s := fmt.Sprintf("%s logged on %d issue(s)", formatMinutes(row.ActualMinutes), row.Issues)
+	if row.EstimatedMinutes > 0 {
+		s += fmt.Sprintf(", %s estimated, actual/estimate %s", formatMinutes(row.EstimatedMinutes), formatRatio(row))
+	}
+	return s
+}
+
+func formatEstimate(row timeReportRow) string {
+	if row.EstimatedMinutes == 0 {
+		return "-"
+	}
+	return formatMinutes(row.EstimatedMinutes)
+}
+
+func formatRatio(row timeReportRow) string {
+	if row.Ratio == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.0f%%", 100*row.Ratio)
+}
+
+func init() {
+	reportTimeCmd.Flags().String("by", strings.Join(timeReportDimensions, ","), "Dimensions to group by (assignee, label, epic, week)")
+	reportTimeCmd.Flags().String("since", "", "Only count time started at or after this date")
+	reportTimeCmd.Flags().String("until", "", "Only count time started before this date")
+	reportTimeCmd.Flags().Bool("closed", false, "Only count closed issues (finished work calibrates estimates best)")
+
+	reportCmd.AddCommand(reportTimeCmd)
+}
@@ -0,0 +1,180 @@
+package main
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestFormatMinutes(t *testing.T) {
+	tests := []struct {
+		minutes  int
+		expected string
+	}{
+		{0, "0m"},
+		{45, "45m"},
+		{60, "1h"},
+		{90, "1h30m"},
+		{125, "2h05m"},
+		{-30, "-30m"},
+	}
+	for _, tt := range tests {
+		if got := formatMinutes(tt.minutes); got != tt.expected {
+			t.Errorf("formatMinutes(%d) = %q, want %q", tt.minutes, got, tt.expected)
+		}
+	}
+}
+
+func TestDurationMinutes(t *testing.T) {
+	tests := []struct {
+		d        time.Duration
+		expected int
+	}{
+		{0, 0},
+		{-time.Minute, 0},
+		{10 * time.Second, 1},
+		{89 * time.Second, 1},
+		{90 * time.Second, 2},
+		{2 * time.Hour, 120},
+	}
+	for _, tt := range tests {
+		if got := durationMinutes(tt.d); got != tt.expected {
+			t.Errorf("durationMinutes(%v) = %d, want %d", tt.d, got, tt.expected)
+		}
+	}
+}
+
+func TestParseReportDimensions(t *testing.T) {
+	dims, err := parseReportDimensions("Week, assignee,week", timeReportDimensions)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(dims) != 2 || dims[0] != "week" || dims[1] != "assignee" {
+		t.Errorf("got %v, want [week assignee]", dims)
+	}
+	if _, err := parseReportDimensions("assignee,team", timeReportDimensions); err == nil {
+		t.Error("expected error for unknown dimension")
+	}
+	if _, err := parseReportDimensions(" , ", timeReportDimensions); err == nil {
+		t.Error("expected error for empty dimensions")
+	}
+}
+
+func TestBuildTimeReport(t *testing.T) {
+	est := func(m int) *int { return &m }
+	logged := func(id, issueID string, start time.Time, minutes int) *types.WorkLogEntry {
+		end := start.Add(time.Duration(minutes) * time.Minute)
+		return &types.WorkLogEntry{ID: id, IssueID: issueID, Actor: "alice", Minutes: minutes, StartedAt: start, EndedAt: &end}
+	}
+	// 2025-03-03 is a Monday in ISO week 10
+	week10 := time.Date(2025, 3, 3, 9, 0, 0, 0, time.UTC)
+	week11 := week10.AddDate(0, 0, 7)
+
+	issues := []*types.Issue{
+		{ID: "bd-epic", Title: "Epic", IssueType: types.TypeEpic},
+		{ID: "bd-1", Title: "Task 1", IssueType: types.TypeTask, Assignee: "alice", Labels: []string{"backend", "api"}, EstimatedMinutes: est(120)},
+		{ID: "bd-2", Title: "Task 2", IssueType: types.TypeTask, Assignee: "bob", EstimatedMinutes: est(60)},
+		{ID: "bd-3", Title: "Task 3", IssueType: types.TypeTask},
+	}
+	workLog := map[string][]*types.WorkLogEntry{
+		"bd-1": {
+			logged("wl-1", "bd-1", week10, 60),
+			logged("wl-2", "bd-1", week11, 120),
+		},
+		"bd-2": {
+			logged("wl-3", "bd-2", week10, 30),
+			{ID: "wl-4", IssueID: "bd-2", Actor: "bob", StartedAt: week11}, // running
+		},
+		"bd-3": {
+			logged("wl-5", "bd-3", week11, 45),
+		},
+	}
+	deps := map[string][]*types.Dependency{
+		"bd-1": {{IssueID: "bd-1", DependsOnID: "bd-epic", Type: types.DepParentChild}},
+		"bd-2": {{IssueID: "bd-2", DependsOnID: "bd-1", Type: types.DepParentChild}},
+		"bd-3": {{IssueID: "bd-3", DependsOnID: "bd-epic", Type: types.DepBlocks}},
+	}
+
+	report := buildTimeReport(issues, workLog, epicResolver(issues, deps), timeReportDimensions, time.Time{}, time.Time{})
+
+	if report.RunningTimers != 1 {
+		t.Errorf("RunningTimers = %d, want 1", report.RunningTimers)
+	}
+	total := report.Total
+	if total.Issues != 3 || total.Entries != 4 || total.ActualMinutes != 255 {
+		t.Errorf("total = %+v, want 3 issues, 4 entries, 255 minutes", total)
+	}
+	// Only bd-1 and bd-2 have estimates: 210 actual vs 180 estimated
+	if total.EstimatedMinutes != 180 || total.ActualEstimatedMinutes != 210 {
+		t.Errorf("total estimate = %d/%d, want 210/180", total.ActualEstimatedMinutes, total.EstimatedMinutes)
+	}
+	if math.Abs(total.Ratio-210.0/180.0) > 1e-9 {
+		t.Errorf("total ratio = %v, want %v", total.Ratio, 210.0/180.0)
+	}
+
+	rows := func(dim string) map[string]timeReportRow {
+		byGroup := make(map[string]timeReportRow)
+		for _, row := range report.Groups[dim] {
+			byGroup[row.Group] = row
+		}
+		return byGroup
+	}
+
+	assignees := rows("assignee")
+	if assignees["alice"].ActualMinutes != 180 || assignees["bob"].ActualMinutes != 30 || assignees["(unassigned)"].ActualMinutes != 45 {
+		t.Errorf("unexpected assignee rows: %+v", report.Groups["assignee"])
+	}
+	if report.Groups["assignee"][0].Group != "alice" {
+		t.Errorf("expected rows sorted by actual time, got %+v", report.Groups["assignee"])
+	}
+	if assignees["(unassigned)"].Ratio != 0 {
+		t.Errorf("expected no ratio without estimates, got %v", assignees["(unassigned)"].Ratio)
+	}
+
+	labels := rows("label")
+	if labels["backend"].ActualMinutes != 180 || labels["api"].ActualMinutes != 180 || labels["(no label)"].ActualMinutes != 75 {
+		t.Errorf("unexpected label rows: %+v", report.Groups["label"])
+	}
+
+	// bd-2 reaches the epic through bd-1; bd-3 only blocks it
+	epics := rows("epic")
+	if epics["bd-epic"].Issues != 2 || epics["bd-epic"].ActualMinutes != 210 || epics["(no epic)"].ActualMinutes != 45 {
+		t.Errorf("unexpected epic rows: %+v", report.Groups["epic"])
+	}
+
+	weeks := report.Groups["week"]
+	if len(weeks) != 2 || weeks[0].Group != "2025-W10" || weeks[1].Group != "2025-W11" {
+		t.Fatalf("unexpected week rows: %+v", weeks)
+	}
+	// bd-1's 120m estimate splits 1/3 to W10 and 2/3 to W11; bd-2's 60m all to W10
+	if weeks[0].ActualMinutes != 90 || weeks[0].EstimatedMinutes != 100 {
+		t.Errorf("W10 = %+v, want 90m actual, 100m estimated", weeks[0])
+	}
+	if weeks[1].ActualMinutes != 165 || weeks[1].EstimatedMinutes != 80 {
+		t.Errorf("W11 = %+v, want 165m actual, 80m estimated", weeks[1])
+	}
+}
+
+func TestBuildTimeReport_Window(t *testing.T) {
+	start := time.Date(2025, 3, 3, 9, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+	issues := []*types.Issue{{ID: "bd-1", IssueType: types.TypeTask}}
+	workLog := map[string][]*types.WorkLogEntry{
+		"bd-1": {
+			{ID: "wl-1", IssueID: "bd-1", Minutes: 60, StartedAt: start, EndedAt: &end},
+			{ID: "wl-2", IssueID: "bd-1", Minutes: 30, StartedAt: start.AddDate(0, 0, 1), EndedAt: &end},
+		},
+	}
+
+	report := buildTimeReport(issues, workLog, nil, []string{"assignee"}, start.Add(time.Minute), start.AddDate(0, 0, 1))
+	if report.Total.Entries != 0 {
+		t.Errorf("expected no entries in window, got %+v", report.Total)
+	}
+
+	report = buildTimeReport(issues, workLog, nil, []string{"assignee"}, start, start.AddDate(0, 0, 2))
+	if report.Total.ActualMinutes != 90 {
+		t.Errorf("ActualMinutes = %d, want 90", report.Total.ActualMinutes)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -476,6 +477,62 @@ func mergeIssueConflict(left, right merge.Issue) merge.Issue {
 		result.Dependencies = append(result.Dependencies, dep)
 	}
 
+	// Work log: union by entry ID, a stopped timer beats a running one
+	workLog := make(map[string]merge.WorkLogEntry)
+	for _, entry := range left.WorkLog {
+		workLog[entry.ID] = entry
+	}
+	for _, entry := range right.WorkLog {
+		if existing, exists := workLog[entry.ID]; !exists || (existing.EndedAt == "" && entry.EndedAt != "") {
+			workLog[entry.ID] = entry
+		}
+	}
+	for _, entry := range workLog {
+		result.WorkLog = append(result.WorkLog, entry)
+	}
+	sort.Slice(result.WorkLog, func(i, j int) bool {
+		if result.WorkLog[i].StartedAt != result.WorkLog[j].StartedAt {
+			return result.WorkLog[i].StartedAt < result.WorkLog[j].StartedAt
+		}
+		return result.WorkLog[i].ID < result.WorkLog[j].ID
+	})
+
+	// Attachments: union by ID, a detached attachment beats a live one
+	attachments := make(map[string]merge.Attachment)
+	for _, att := range left.Attachments {
+		attachments[att.ID] = att
+	}
+	for _, att := range right.Attachments {
+		if existing, exists := attachments[att.ID]; !exists || (existing.DetachedAt == "" && att.DetachedAt != "") {
+			attachments[att.ID] = att
+		}
+	}
+	for _, att := range attachments {
+		result.Attachments = append(result.Attachments, att)
+	}
+	sort.Slice(result.Attachments, func(i, j int) bool {
+		if result.Attachments[i].CreatedAt != result.Attachments[j].CreatedAt {
+			return result.Attachments[i].CreatedAt < result.Attachments[j].CreatedAt
+		}
+		return result.Attachments[i].ID < result.Attachments[j].ID
+	})
+
+	// Custom fields: union by name, prefer later updated_at when both set one
+	for _, fields := range []map[string]string{left.CustomFields, right.CustomFields} {
+		for name := range fields {
+			value := left.CustomFields[name]
+			if other := right.CustomFields[name]; value == "" {
+				value = other
+			} else if other != "" {
+				value = pickByUpdatedAt(value, other, left.UpdatedAt, right.UpdatedAt)
+			}
+			if result.CustomFields == nil {
+				result.CustomFields = make(map[string]string)
+			}
+			result.CustomFields[name] = value
+		}
+	}
+
 	// Tombstone fields
 	if result.Status == "tombstone" {
 		if isTimeAfterStr(left.DeletedAt, right.DeletedAt) {
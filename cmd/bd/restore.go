@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/storage/sqlite"
 	"github.com/steveyegge/beads/internal/types"
 	"github.com/steveyegge/beads/internal/ui"
 )
@@ -16,22 +17,65 @@ import (
 var restoreCmd = &cobra.Command{
 	Use:     "restore <issue-id>",
 	GroupID: "sync",
-	Short:   "Restore full history of a compacted issue from git",
-	Long: `Restore full history of a compacted issue from git version control.
+	Short:   "Restore full history of a compacted issue",
+	Long: `Restore full history of a compacted issue.
 
-When an issue is compacted, the git commit hash is saved. This command:
+Compaction keeps a snapshot of each issue's original content (and, for tier 2,
+its events) in the database. When snapshots exist, this command shows the
+issue as it was before its first compaction, along with any issues rolled up
+into it by tier-2 compaction. No git access is needed.
+
+With --apply, the original content and events are written back: the issue and
+every issue rolled up into it return to their pre-compaction state and the
+snapshots are removed.
+
+Issues compacted before snapshots were recorded fall back to git history. When
+an issue is compacted, the git commit hash is saved. This command then:
 1. Reads the compacted_at_commit from the database
 2. Checks out that commit temporarily
 3. Reads the full issue from JSONL at that point in history
 4. Displays the full issue history (description, events, etc.)
 5. Returns to the current git state
 
-This is read-only and does not modify the database or git state.`,
+Without --apply this is read-only and does not modify the database or git state.
+
+Examples:
+  bd restore bd-42           # Show the original content
+  bd restore bd-42 --apply   # Undo compaction of bd-42 and its rolled-up children`,
 	Args: cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		issueID := args[0]
 		ctx := rootCtx
 
+		if restoreApply {
+			CheckReadonly("restore --apply")
+			if err := ensureDirectMode("restore --apply requires direct database access"); err != nil {
+				FatalError("%v", err)
+			}
+		} else if err := ensureStoreActive(); err != nil {
+			FatalError("%v", err)
+		}
+
+		if sqliteStore, ok := store.(*sqlite.SQLiteStorage); ok {
+			snapshots, err := sqliteStore.GetCompactionSnapshots(ctx, issueID)
+			if err != nil {
+				FatalError("failed to read compaction snapshots: %v", err)
+			}
+			if len(snapshots) > 0 {
+				if restoreApply {
+					runRestoreApply(sqliteStore, issueID)
+				} else {
+					runRestoreSnapshot(sqliteStore, issueID, snapshots)
+				}
+				return
+			}
+		}
+		if restoreApply {
+			fmt.Fprintf(os.Stderr, "Error: issue %s has no compaction snapshots\n", issueID)
+			fmt.Fprintf(os.Stderr, "Hint: run 'bd restore %s' without --apply to view it from git history\n", issueID)
+			os.Exit(1)
+		}
+
 		// Check if we're in a git repository
 		if !isGitRepo() {
 			fmt.Fprintf(os.Stderr, "Error: not in a git repository\n")
@@ -45,6 +89,10 @@ This is read-only and does not modify the database or git state.`,
 			fmt.Fprintf(os.Stderr, "Error: issue %s not found: %v\n", issueID, err)
 			os.Exit(1)
 		}
+		if issue == nil {
+			fmt.Fprintf(os.Stderr, "Error: issue %s not found\n", issueID)
+			os.Exit(1)
+		}
 
 		// Check if issue is compacted
 		if issue.CompactedAtCommit == nil || *issue.CompactedAtCommit == "" {
@@ -111,11 +159,93 @@ This is read-only and does not modify the database or git state.`,
 	},
 }
 
+var restoreApply bool
+
 func init() {
 	restoreCmd.Flags().BoolVar(&jsonOutput, "json", false, "Output restore results in JSON format")
+	restoreCmd.Flags().BoolVar(&restoreApply, "apply", false, "Write the original content back, undoing compaction")
 	rootCmd.AddCommand(restoreCmd)
 }
 
+// runRestoreSnapshot shows an issue as it was before its first compaction.
+func runRestoreSnapshot(s *sqlite.SQLiteStorage, issueID string, snapshots []*sqlite.CompactionSnapshot) {
+	ctx := rootCtx
+	issue, err := s.GetIssue(ctx, issueID)
+	if err != nil {
+		FatalError("failed to get issue: %v", err)
+	}
+	if issue == nil {
+		FatalError("issue %s not found", issueID)
+	}
+	original := issueFromSnapshot(issue, snapshots[0])
+
+	rolledUp, err := s.GetRollupMembers(ctx, issueID)
+	if err != nil {
+		FatalError("failed to get rolled-up issues: %v", err)
+	}
+	archivedEvents := 0
+	for _, snap := range snapshots {
+		archivedEvents += len(snap.Events)
+	}
+
+	if jsonOutput {
+		outputJSON(map[string]interface{}{
+			"issue":           original,
+			"snapshots":       len(snapshots),
+			"snapshot_time":   snapshots[0].SnapshotTime,
+			"rolled_up":       rolledUp,
+			"archived_events": archivedEvents,
+		})
+		return
+	}
+
+	displayIssue(original, "compaction snapshot "+ui.RenderWarn(snapshots[0].SnapshotTime.Format("2006-01-02 15:04")))
+	if archivedEvents > 0 {
+		fmt.Printf("%s %d archived events\n", ui.RenderBold("History:"), archivedEvents)
+	}
+	if len(rolledUp) > 0 {
+		fmt.Printf("%s %s\n", ui.RenderBold("Rolled up:"), strings.Join(rolledUp, ", "))
+	}
+	fmt.Printf("\nRun 'bd restore %s --apply' to undo the compaction.\n\n", issueID)
+}
+
+// runRestoreApply writes the pre-compaction content of an issue and its
+// rolled-up children back to the database.
+func runRestoreApply(s *sqlite.SQLiteStorage, issueID string) {
+	restored, err := s.RestoreCompaction(rootCtx, issueID, getActorWithGit())
+	if err != nil {
+		FatalError("%v", err)
+	}
+	markDirtyAndScheduleFlush()
+
+	if jsonOutput {
+		outputJSON(map[string]interface{}{
+			"issue_id": issueID,
+			"restored": restored,
+		})
+		return
+	}
+	fmt.Printf("%s Restored %s", ui.RenderPass("✓"), issueID)
+	if len(restored) > 1 {
+		fmt.Printf(" and %d rolled-up issues: %s", len(restored)-1, strings.Join(restored[1:], ", "))
+	}
+	fmt.Println()
+}
+
+// issueFromSnapshot returns issue with the content it had when snap was taken.
+func issueFromSnapshot(issue *types.Issue, snap *sqlite.CompactionSnapshot) *types.Issue {
+	original := *issue
+	original.Description = snap.Content.Description
+	original.Design = snap.Content.Design
+	original.Notes = snap.Content.Notes
+	original.AcceptanceCriteria = snap.Content.AcceptanceCriteria
+	original.CompactionLevel = snap.Content.CompactionLevel
+	original.CompactedAt = snap.Content.CompactedAt
+	original.CompactedAtCommit = snap.Content.CompactedAtCommit
+	original.OriginalSize = snap.Content.OriginalSize
+	return &original
+}
+
 // getCurrentGitHead returns the current HEAD reference (branch or commit)
 func getCurrentGitHead() (string, error) {
 	// Try to get symbolic ref (branch name) first
@@ -186,7 +316,13 @@ func readIssueFromJSONL(jsonlPath, issueID string) (*types.Issue, error) {
 
 // displayRestoredIssue displays the restored issue in a readable format
 func displayRestoredIssue(issue *types.Issue, commitHash string) {
-	fmt.Printf("\n%s %s (restored from git commit %s)\n", ui.RenderAccent("📜"), ui.RenderBold(issue.ID), ui.RenderWarn(commitHash[:8]))
+	displayIssue(issue, "git commit "+ui.RenderWarn(commitHash[:8]))
+}
+
+// displayIssue prints a historical version of an issue; source says where it
+// was restored from.
+func displayIssue(issue *types.Issue, source string) {
+	fmt.Printf("\n%s %s (restored from %s)\n", ui.RenderAccent("📜"), ui.RenderBold(issue.ID), source)
 	fmt.Printf("%s\n\n", ui.RenderBold(issue.Title))
 
 	if issue.Description != "" {
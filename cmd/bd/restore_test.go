@@ -6,6 +6,7 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/steveyegge/beads/internal/storage/sqlite"
 	"github.com/steveyegge/beads/internal/types"
 )
 
@@ -147,3 +148,35 @@ func TestGitCheckout_InvalidRef(t *testing.T) {
 		t.Log("gitCheckout returned nil - might not be in git repo or ref exists")
 	}
 }
+
+func TestIssueFromSnapshot(t *testing.T) {
+	issue := &types.Issue{
+		ID:              "bd-1",
+		Title:           "Epic",
+		Description:     "**Summary:** short",
+		CompactionLevel: 2,
+		OriginalSize:    120,
+	}
+	snap := &sqlite.CompactionSnapshot{
+		IssueID: "bd-1",
+		Content: sqlite.SnapshotContent{
+			Description: "The full original description",
+			Design:      "Original design",
+			Notes:       "Original notes",
+		},
+	}
+
+	original := issueFromSnapshot(issue, snap)
+	if original.Description != "The full original description" || original.Design != "Original design" || original.Notes != "Original notes" {
+		t.Errorf("content not restored: %+v", original)
+	}
+	if original.CompactionLevel != 0 || original.OriginalSize != 0 {
+		t.Errorf("compaction state = level %d, size %d; want 0, 0", original.CompactionLevel, original.OriginalSize)
+	}
+	if original.Title != "Epic" {
+		t.Errorf("Title = %q, want %q", original.Title, "Epic")
+	}
+	if issue.Description != "**Summary:** short" {
+		t.Error("issueFromSnapshot modified its input")
+	}
+}
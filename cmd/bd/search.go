@@ -4,12 +4,15 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/beads/internal/rpc"
+	"github.com/steveyegge/beads/internal/search"
 	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
 	"github.com/steveyegge/beads/internal/util"
 	"github.com/steveyegge/beads/internal/validation"
 )
@@ -18,10 +21,25 @@ var searchCmd = &cobra.Command{
 	Use:     "search [query]",
 	GroupID: "issues",
 	Short:   "Search issues by text query",
-	Long: `Search issues across title, description, and ID.
+	Long: `Full-text search across ID, title, description, design, acceptance
+criteria, notes and comments. Results are ranked by relevance (BM25) and show
+a snippet of the best-matching text with query terms highlighted.
+
+Query syntax:
+  login timeout           Both words must match (implicit AND)
+  "login timeout"         Exact phrase
+  auth*                   Prefix match
+  login OR signin         Either word
+  login NOT oauth         Exclude matches
+  (login OR auth) crash   Grouping
+  bd-5q                   Words with punctuation (like IDs) match as a prefix
+
+Operators must be upper-case; lower-case and/or/not are searched as words.
 
 Examples:
   bd search "authentication bug"
+  bd search '"connection reset" OR timeout*'
+  bd search 'login NOT oauth'
   bd search "login" --status open
   bd search "database" --label backend --limit 10
   bd search --query "performance" --assignee alice
@@ -30,6 +48,7 @@ Examples:
   bd search "bug" --created-after 2025-01-01
   bd search "refactor" --updated-after 2025-01-01 --priority-min 1
   bd search "bug" --sort priority
+  bd search "crash" --field severity=high --sort points
   bd search "task" --sort created --reverse`,
 	Run: func(cmd *cobra.Command, args []string) {
 		// Get query from args or --query flag
@@ -73,6 +92,12 @@ Examples:
 		priorityMinStr, _ := cmd.Flags().GetString("priority-min")
 		priorityMaxStr, _ := cmd.Flags().GetString("priority-max")
 
+		// Custom field filters
+		fieldFilters := parseFieldFilterFlags(cmd)
+
+		// Filter expression
+		where := parseWhereFlag(cmd)
+
 		// Normalize labels
 		labels = util.NormalizeLabels(labels)
 		labelsAny = util.NormalizeLabels(labelsAny)
@@ -104,6 +129,11 @@ Examples:
 			filter.LabelsAny = labelsAny
 		}
 
+		filter.CustomFields = fieldFilters
+		if where != nil {
+			filter.Where = where.String()
+		}
+
 		// Date ranges
 		if createdAfter != "" {
 			t, err := parseTimeFlag(createdAfter)
@@ -185,7 +215,8 @@ Examples:
 		// If daemon is running, use RPC
 		if daemonClient != nil {
 			listArgs := &rpc.ListArgs{
-				Query:     query, // This will search title/description/id with OR logic
+				Query:     query,
+				Ranked:    true,
 				Status:    status,
 				IssueType: issueType,
 				Assignee:  assignee,
@@ -223,6 +254,8 @@ Examples:
 			// Priority range
 			listArgs.PriorityMin = filter.PriorityMin
 			listArgs.PriorityMax = filter.PriorityMax
+			listArgs.CustomFields = filter.CustomFields
+			listArgs.Where = filter.Where
 
 			resp, err := daemonClient.List(listArgs)
 			if err != nil {
@@ -230,42 +263,36 @@ Examples:
 				os.Exit(1)
 			}
 
-			if jsonOutput {
-				var issuesWithCounts []*types.IssueWithCounts
-				if err := json.Unmarshal(resp.Data, &issuesWithCounts); err != nil {
-					fmt.Fprintf(os.Stderr, "Error parsing response: %v\n", err)
-					os.Exit(1)
-				}
-				outputJSON(issuesWithCounts)
-				return
-			}
-
-			var issues []*types.Issue
-			if err := json.Unmarshal(resp.Data, &issues); err != nil {
+			var results []*types.SearchResult
+			if err := json.Unmarshal(resp.Data, &results); err != nil {
 				fmt.Fprintf(os.Stderr, "Error parsing response: %v\n", err)
 				os.Exit(1)
 			}
 
-			// Apply sorting
-			sortIssues(issues, sortBy, reverse)
+			// Apply sorting (default: relevance order from the daemon)
+			sortSearchResults(results, sortBy, reverse)
 
-			outputSearchResults(issues, query, longFormat)
+			if jsonOutput {
+				outputJSON(results)
+				return
+			}
+
+			outputSearchResults(results, query, longFormat)
 			return
 		}
 
-		// Direct mode - search using store
-		// The query parameter in SearchIssues already searches across title, description, and id
-		issues, err := store.SearchIssues(ctx, query, filter)
+		// Direct mode - ranked full-text search using store
+		results, err := store.SearchIssuesRanked(ctx, query, filter)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 			os.Exit(1)
 		}
 
 		// If no issues found, check if git has issues and auto-import
-		if len(issues) == 0 {
+		if len(results) == 0 {
 			if checkAndAutoImport(ctx, store) {
 				// Re-run the search after import
-				issues, err = store.SearchIssues(ctx, query, filter)
+				results, err = store.SearchIssuesRanked(ctx, query, filter)
 				if err != nil {
 					fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 					os.Exit(1)
@@ -273,98 +300,125 @@ Examples:
 			}
 		}
 
-		// Apply sorting
-		sortIssues(issues, sortBy, reverse)
-
-		if jsonOutput {
-			// Get labels and dependency counts
-			issueIDs := make([]string, len(issues))
-			for i, issue := range issues {
-				issueIDs[i] = issue.ID
-			}
-			labelsMap, err := store.GetLabelsForIssues(ctx, issueIDs)
-			if err != nil {
-				fmt.Fprintf(os.Stderr, "Warning: failed to get labels: %v\n", err)
-				labelsMap = make(map[string][]string)
-			}
-			depCounts, err := store.GetDependencyCounts(ctx, issueIDs)
-			if err != nil {
-				fmt.Fprintf(os.Stderr, "Warning: failed to get dependency counts: %v\n", err)
-				depCounts = make(map[string]*types.DependencyCounts)
+		// Load labels for display and JSON output
+		issueIDs := make([]string, len(results))
+		for i, r := range results {
+			issueIDs[i] = r.ID
+		}
+		labelsMap, err := store.GetLabelsForIssues(ctx, issueIDs)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to get labels: %v\n", err)
+			labelsMap = make(map[string][]string)
+		}
+		depCounts, err := store.GetDependencyCounts(ctx, issueIDs)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to get dependency counts: %v\n", err)
+			depCounts = make(map[string]*types.DependencyCounts)
+		}
+		for _, r := range results {
+			r.Labels = labelsMap[r.ID]
+			if counts := depCounts[r.ID]; counts != nil {
+				r.DependencyCount = counts.DependencyCount
+				r.DependentCount = counts.DependentCount
 			}
+		}
 
-			// Populate labels
-			for _, issue := range issues {
-				issue.Labels = labelsMap[issue.ID]
-			}
+		// Apply sorting (default: relevance order)
+		sortSearchResults(results, sortBy, reverse)
 
-			// Build response with counts
-			issuesWithCounts := make([]*types.IssueWithCounts, len(issues))
-			for i, issue := range issues {
-				counts := depCounts[issue.ID]
-				if counts == nil {
-					counts = &types.DependencyCounts{DependencyCount: 0, DependentCount: 0}
-				}
-				issuesWithCounts[i] = &types.IssueWithCounts{
-					Issue:           issue,
-					DependencyCount: counts.DependencyCount,
-					DependentCount:  counts.DependentCount,
-				}
-			}
-			outputJSON(issuesWithCounts)
+		if jsonOutput {
+			outputJSON(results)
 			return
 		}
 
-		// Load labels for display
-		issueIDs := make([]string, len(issues))
-		for i, issue := range issues {
-			issueIDs[i] = issue.ID
-		}
-		labelsMap, _ := store.GetLabelsForIssues(ctx, issueIDs)
-		for _, issue := range issues {
-			issue.Labels = labelsMap[issue.ID]
+		outputSearchResults(results, query, longFormat)
+	},
+}
+
+// sortSearchResults re-sorts ranked results when --sort is given.
+// Without --sort, results keep their relevance order (--reverse flips it).
+func sortSearchResults(results []*types.SearchResult, sortBy string, reverse bool) {
+	if sortBy == "" {
+		if reverse {
+			slices.Reverse(results)
 		}
+		return
+	}
+	issues := make([]*types.Issue, len(results))
+	byIssue := make(map[*types.Issue]*types.SearchResult, len(results))
+	for i, r := range results {
+		issues[i] = r.Issue
+		byIssue[r.Issue] = r
+	}
+	sortIssues(issues, sortBy, reverse)
+	for i, issue := range issues {
+		results[i] = byIssue[issue]
+	}
+}
 
-		outputSearchResults(issues, query, longFormat)
-	},
+// renderSnippet converts search highlight markers into terminal styling.
+func renderSnippet(snippet string) string {
+	var b strings.Builder
+	for {
+		start := strings.Index(snippet, search.HighlightStart)
+		if start < 0 {
+			break
+		}
+		rest := snippet[start+len(search.HighlightStart):]
+		end := strings.Index(rest, search.HighlightEnd)
+		if end < 0 {
+			break
+		}
+		b.WriteString(ui.RenderMuted(snippet[:start]))
+		b.WriteString(ui.RenderAccent(rest[:end]))
+		snippet = rest[end+len(search.HighlightEnd):]
+	}
+	b.WriteString(ui.RenderMuted(snippet))
+	return b.String()
 }
 
-// outputSearchResults formats and displays search results
-func outputSearchResults(issues []*types.Issue, query string, longFormat bool) {
-	if len(issues) == 0 {
+// outputSearchResults formats and displays ranked search results
+func outputSearchResults(results []*types.SearchResult, query string, longFormat bool) {
+	if len(results) == 0 {
 		fmt.Printf("No issues found matching '%s'\n", query)
 		return
 	}
 
 	if longFormat {
 		// Long format: multi-line with details
-		fmt.Printf("\nFound %d issues matching '%s':\n\n", len(issues), query)
-		for _, issue := range issues {
-			fmt.Printf("%s [P%d] [%s] %s\n", issue.ID, issue.Priority, issue.IssueType, issue.Status)
-			fmt.Printf("  %s\n", issue.Title)
-			if issue.Assignee != "" {
-				fmt.Printf("  Assignee: %s\n", issue.Assignee)
+		fmt.Printf("\nFound %d issues matching '%s':\n\n", len(results), query)
+		for _, r := range results {
+			fmt.Printf("%s [P%d] [%s] %s (score %.2f)\n", r.ID, r.Priority, r.IssueType, r.Status, r.Score)
+			fmt.Printf("  %s\n", r.Title)
+			if r.Snippet != "" {
+				fmt.Printf("  %s\n", renderSnippet(r.Snippet))
+			}
+			if r.Assignee != "" {
+				fmt.Printf("  Assignee: %s\n", r.Assignee)
 			}
-			if len(issue.Labels) > 0 {
-				fmt.Printf("  Labels: %v\n", issue.Labels)
+			if len(r.Labels) > 0 {
+				fmt.Printf("  Labels: %v\n", r.Labels)
 			}
 			fmt.Println()
 		}
 	} else {
-		// Compact format: one line per issue
-		fmt.Printf("Found %d issues matching '%s':\n", len(issues), query)
-		for _, issue := range issues {
+		// Compact format: one line per issue, plus snippet
+		fmt.Printf("Found %d issues matching '%s':\n", len(results), query)
+		for _, r := range results {
 			labelsStr := ""
-			if len(issue.Labels) > 0 {
-				labelsStr = fmt.Sprintf(" %v", issue.Labels)
+			if len(r.Labels) > 0 {
+				labelsStr = fmt.Sprintf(" %v", r.Labels)
 			}
 			assigneeStr := ""
-			if issue.Assignee != "" {
-				assigneeStr = fmt.Sprintf(" @%s", issue.Assignee)
+			if r.Assignee != "" {
+				assigneeStr = fmt.Sprintf(" @%s", r.Assignee)
 			}
 			fmt.Printf("%s [P%d] [%s] %s%s%s - %s\n",
-				issue.ID, issue.Priority, issue.IssueType, issue.Status,
-				assigneeStr, labelsStr, issue.Title)
+				r.ID, r.Priority, r.IssueType, r.Status,
+				assigneeStr, labelsStr, r.Title)
+			if r.Snippet != "" {
+				fmt.Printf("    %s\n", renderSnippet(r.Snippet))
+			}
 		}
 	}
 }
@@ -378,7 +432,7 @@ func init() {
 	searchCmd.Flags().StringSlice("label-any", []string{}, "Filter by labels (OR: must have AT LEAST ONE)")
 	searchCmd.Flags().IntP("limit", "n", 50, "Limit results (default: 50)")
 	searchCmd.Flags().Bool("long", false, "Show detailed multi-line output for each issue")
-	searchCmd.Flags().String("sort", "", "Sort by field: priority, created, updated, closed, status, id, title, type, assignee")
+	searchCmd.Flags().String("sort", "", "Sort by field instead of relevance: priority, created, updated, closed, status, id, title, type, assignee, or a custom field")
 	searchCmd.Flags().BoolP("reverse", "r", false, "Reverse sort order")
 
 	// Date range flags
@@ -393,5 +447,11 @@ func init() {
 	searchCmd.Flags().String("priority-min", "", "Filter by minimum priority (inclusive, 0-4 or P0-P4)")
 	searchCmd.Flags().String("priority-max", "", "Filter by maximum priority (inclusive, 0-4 or P0-P4)")
 
+	// Custom field filters
+	searchCmd.Flags().StringArray("field", nil, "Filter by custom field: points>=3, severity=high, reviewer (set), reviewer= (unset) (repeatable)")
+
+	// Filter expression
+	searchCmd.Flags().String("where", "", whereFlagUsage)
+
 	rootCmd.AddCommand(searchCmd)
 }
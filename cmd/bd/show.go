@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/beads/internal/rpc"
@@ -149,6 +150,8 @@ var showCmd = &cobra.Command{
 					if details.Comments == nil {
 						details.Comments = []*types.Comment{}
 					}
+					attachments, _ := issueStore.GetAttachments(ctx, issue.ID)
+					details.Issue.Attachments = types.LiveAttachments(attachments)
 					// Compute parent from dependencies
 					for _, dep := range details.Dependencies {
 						if dep.DependencyType == types.DepParentChild {
@@ -257,6 +260,7 @@ var showCmd = &cobra.Command{
 					if len(details.Labels) > 0 {
 						fmt.Printf("\n%s %s\n", ui.RenderBold("LABELS:"), strings.Join(details.Labels, ", "))
 					}
+					printCustomFieldsSection(issue)
 
 					// Dependencies with semantic colors
 					if len(details.Dependencies) > 0 {
@@ -322,6 +326,8 @@ var showCmd = &cobra.Command{
 						}
 					}
 
+					printAttachmentsSection(details.Attachments)
+
 					fmt.Println()
 				}
 			}
@@ -403,6 +409,8 @@ var showCmd = &cobra.Command{
 				if details.Comments == nil {
 					details.Comments = []*types.Comment{}
 				}
+				attachments, _ := issueStore.GetAttachments(ctx, issue.ID)
+				details.Issue.Attachments = types.LiveAttachments(attachments)
 				// Compute parent from dependencies
 				for _, dep := range details.Dependencies {
 					if dep.DependencyType == types.DepParentChild {
@@ -458,6 +466,7 @@ var showCmd = &cobra.Command{
 			if len(labels) > 0 {
 				fmt.Printf("\n%s %s\n", ui.RenderBold("LABELS:"), strings.Join(labels, ", "))
 			}
+			printCustomFieldsSection(issue)
 
 			// Show dependencies with semantic colors
 			deps, _ := issueStore.GetDependencies(ctx, issue.ID)
@@ -541,6 +550,10 @@ var showCmd = &cobra.Command{
 				}
 			}
 
+			// Show attachments
+			attachments, _ := issueStore.GetAttachments(ctx, issue.ID)
+			printAttachmentsSection(attachments)
+
 			fmt.Println()
 			result.Close() // Close routed storage after each iteration
 		}
@@ -639,6 +652,13 @@ func formatIssueMetadata(issue *types.Issue) string {
 	if issue.Assignee != "" {
 		metaParts = append(metaParts, fmt.Sprintf("Assignee: %s", issue.Assignee))
 	}
+	if issue.Status == types.StatusInProgress && issue.LeaseExpiresAt != nil {
+		lease := fmt.Sprintf("Lease: until %s", issue.LeaseExpiresAt.Local().Format("2006-01-02 15:04"))
+		if issue.LeaseExpired(time.Now()) {
+			lease = ui.RenderWarn("Lease: expired " + formatRelativeTime(*issue.LeaseExpiresAt))
+		}
+		metaParts = append(metaParts, lease)
+	}
 
 	// Type with semantic color
 	typeStr := string(issue.IssueType)
@@ -654,7 +674,7 @@ func formatIssueMetadata(issue *types.Issue) string {
 		lines = append(lines, strings.Join(metaParts, " · "))
 	}
 
-	// Line 2: Created · Updated · Due/Defer
+	// Line 2: Created · Updated · Due/Defer/Recurrence
 	timeParts := []string{}
 	timeParts = append(timeParts, fmt.Sprintf("Created: %s", issue.CreatedAt.Format("2006-01-02")))
 	timeParts = append(timeParts, fmt.Sprintf("Updated: %s", issue.UpdatedAt.Format("2006-01-02")))
@@ -665,6 +685,9 @@ func formatIssueMetadata(issue *types.Issue) string {
 	if issue.DeferUntil != nil {
 		timeParts = append(timeParts, fmt.Sprintf("Deferred: %s", issue.DeferUntil.Format("2006-01-02")))
 	}
+	if issue.Recurrence != "" {
+		timeParts = append(timeParts, fmt.Sprintf("Recurs: %s", issue.Recurrence))
+	}
 	if len(timeParts) > 0 {
 		lines = append(lines, strings.Join(timeParts, " · "))
 	}
@@ -1074,13 +1097,9 @@ func containsStr(slice []string, val string) bool {
 }
 
 // showIssueAsOf displays issues as they existed at a specific commit or branch ref.
-// This requires a versioned storage backend (e.g., Dolt).
+// Dolt reads its own commits; SQLite reads the JSONL export at a git ref.
 func showIssueAsOf(ctx context.Context, args []string, ref string, shortMode bool) {
-	// Check if storage supports versioning
-	vs, ok := storage.AsVersioned(store)
-	if !ok {
-		FatalErrorRespectJSON("--as-of requires Dolt backend (current backend does not support versioning)")
-	}
+	vs := requireHistoryStorage("--as-of")
 
 	var allIssues []*types.Issue
 	for idx, id := range args {
@@ -1128,7 +1147,7 @@ func init() {
 	showCmd.Flags().Bool("short", false, "Show compact one-line output per issue")
 	showCmd.Flags().Bool("refs", false, "Show issues that reference this issue (reverse lookup)")
 	showCmd.Flags().Bool("children", false, "Show only the children of this issue")
-	showCmd.Flags().String("as-of", "", "Show issue as it existed at a specific commit hash or branch (requires Dolt)")
+	showCmd.Flags().String("as-of", "", "Show issue as it existed at a specific commit hash or branch (git ref with SQLite)")
 	showCmd.ValidArgsFunction = issueIDCompletion
 	rootCmd.AddCommand(showCmd)
 }
@@ -220,6 +220,28 @@ func exportToJSONLDeferred(ctx context.Context, jsonlPath string) (*ExportResult
 		issue.Comments = comments
 	}
 
+	// Populate work log for all issues
+	issueIDs := make([]string, len(issues))
+	for i, issue := range issues {
+		issueIDs[i] = issue.ID
+	}
+	workLog, err := store.GetWorkLogForIssues(ctx, issueIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get work log: %w", err)
+	}
+	for _, issue := range issues {
+		issue.WorkLog = workLog[issue.ID]
+	}
+
+	// Populate attachments for all issues
+	attachments, err := store.GetAttachmentsForIssues(ctx, issueIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get attachments: %w", err)
+	}
+	for _, issue := range issues {
+		issue.Attachments = attachments[issue.ID]
+	}
+
 	// Create temp file for atomic write
 	dir := filepath.Dir(jsonlPath)
 	base := filepath.Base(jsonlPath)
@@ -408,6 +430,24 @@ func performIncrementalExport(ctx context.Context, jsonlPath string, dirtyIDs []
 		issue.Comments = commentsMap[issue.ID]
 	}
 
+	// Get work log for dirty issues (batch query)
+	workLogMap, err := store.GetWorkLogForIssues(ctx, dirtyIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get work log: %w", err)
+	}
+	for _, issue := range dirtyIssues {
+		issue.WorkLog = workLogMap[issue.ID]
+	}
+
+	// Get attachments for dirty issues (batch query)
+	attachmentsMap, err := store.GetAttachmentsForIssues(ctx, dirtyIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get attachments: %w", err)
+	}
+	for _, issue := range dirtyIssues {
+		issue.Attachments = attachmentsMap[issue.ID]
+	}
+
 	// Update map with dirty issues
 	idSet := make(map[string]bool, len(allIDs))
 	for _, id := range allIDs {
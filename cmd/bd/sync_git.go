@@ -242,6 +242,7 @@ func gitCommitBeadsDir(ctx context.Context, message string) error {
 		filepath.Join(rc.BeadsDir, "issues.jsonl"),
 		filepath.Join(rc.BeadsDir, "deletions.jsonl"),
 		filepath.Join(rc.BeadsDir, "interactions.jsonl"),
+		filepath.Join(rc.BeadsDir, "views.jsonl"),
 		filepath.Join(rc.BeadsDir, "metadata.json"),
 	}
 
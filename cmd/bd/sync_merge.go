@@ -47,11 +47,14 @@ const (
 // - Labels: union of both
 // - Dependencies: union of both (by DependsOnID+Type)
 // - Comments: append from both (deduplicated by ID or content)
+// - Work log: union of both by entry ID (a stopped timer beats a running one)
+// - Attachments: union of both by ID (a detached attachment beats a live one)
+// - Custom fields: merged per field against base, LWW where both changed
 // - compaction_level: max strategy (highest value wins)
 // - estimated_minutes: manual strategy if configured (flags for user resolution)
 //
 // Also returns any manual conflicts that require user resolution.
-func mergeFieldLevel(_base, local, remote *beads.Issue) (*beads.Issue, []ManualConflict) {
+func mergeFieldLevel(base, local, remote *beads.Issue) (*beads.Issue, []ManualConflict) {
 	var manualConflicts []ManualConflict
 
 	// Determine which is newer for LWW scalars
@@ -142,6 +145,19 @@ func mergeFieldLevel(_base, local, remote *beads.Issue) (*beads.Issue, []ManualC
 	// Append merge: Comments (deduplicated)
 	merged.Comments = mergeComments(local.Comments, remote.Comments)
 
+	// Union merge: Work log (by entry ID)
+	merged.WorkLog = mergeWorkLogEntries(local.WorkLog, remote.WorkLog)
+
+	// Union merge: Attachments (by ID)
+	merged.Attachments = mergeAttachments(local.Attachments, remote.Attachments)
+
+	// Per-field merge: Custom fields (so edits to different fields both survive)
+	var baseFields map[string]string
+	if base != nil {
+		baseFields = base.CustomFields
+	}
+	merged.CustomFields = mergeCustomFields(baseFields, local.CustomFields, remote.CustomFields, localNewer)
+
 	return &merged, manualConflicts
 }
 
@@ -333,6 +349,113 @@ func mergeComments(local, remote []*beads.Comment) []*beads.Comment {
 	return result
 }
 
+// mergeWorkLogEntries performs union-merge on work-log entries by ID.
+// When both sides have an entry, a stopped timer wins over a running one;
+// otherwise the local version is kept.
+func mergeWorkLogEntries(local, remote []*beads.WorkLogEntry) []*beads.WorkLogEntry {
+	byID := make(map[string]*beads.WorkLogEntry)
+	for _, e := range local {
+		if e != nil {
+			byID[e.ID] = e
+		}
+	}
+	for _, e := range remote {
+		if e == nil {
+			continue
+		}
+		if existing, ok := byID[e.ID]; !ok || (existing.IsRunning() && !e.IsRunning()) {
+			byID[e.ID] = e
+		}
+	}
+
+	if len(byID) == 0 {
+		return nil
+	}
+	result := make([]*beads.WorkLogEntry, 0, len(byID))
+	for _, e := range byID {
+		result = append(result, e)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if !result[i].StartedAt.Equal(result[j].StartedAt) {
+			return result[i].StartedAt.Before(result[j].StartedAt)
+		}
+		return result[i].ID < result[j].ID
+	})
+	return result
+}
+
+// mergeAttachments performs union-merge on attachments by ID. When both
+// sides have an attachment, a detached one wins over a live one; otherwise
+// the local version is kept.
+func mergeAttachments(local, remote []*beads.Attachment) []*beads.Attachment {
+	byID := make(map[string]*beads.Attachment)
+	for _, a := range local {
+		if a != nil {
+			byID[a.ID] = a
+		}
+	}
+	for _, a := range remote {
+		if a == nil {
+			continue
+		}
+		if existing, ok := byID[a.ID]; !ok || (!existing.IsDetached() && a.IsDetached()) {
+			byID[a.ID] = a
+		}
+	}
+
+	if len(byID) == 0 {
+		return nil
+	}
+	result := make([]*beads.Attachment, 0, len(byID))
+	for _, a := range byID {
+		result = append(result, a)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if !result[i].CreatedAt.Equal(result[j].CreatedAt) {
+			return result[i].CreatedAt.Before(result[j].CreatedAt)
+		}
+		return result[i].ID < result[j].ID
+	})
+	return result
+}
+
+// mergeCustomFields merges custom field values one field at a time. A field
+// changed on only one side (relative to base) takes that side's value; one
+// changed on both takes the newer side's. A missing field counts as empty.
+func mergeCustomFields(base, local, remote map[string]string, localNewer bool) map[string]string {
+	var result map[string]string
+	set := func(name, value string) {
+		if value == "" {
+			return
+		}
+		if result == nil {
+			result = make(map[string]string)
+		}
+		result[name] = value
+	}
+	for _, side := range []map[string]string{local, remote} {
+		for name := range side {
+			if _, done := result[name]; done {
+				continue
+			}
+			l, r, b := local[name], remote[name], base[name]
+			switch {
+			case l == r:
+				set(name, l)
+			case base != nil && l == b:
+				set(name, r)
+			case base != nil && r == b:
+				set(name, l)
+			case localNewer:
+				set(name, l)
+			default:
+				set(name, r)
+			}
+		}
+	}
+	return result
+}
+
 // MergeIssues performs 3-way merge: base x local x remote -> merged
 //
 // Algorithm:
@@ -466,6 +589,9 @@ func MergeIssue(base, local, remote *beads.Issue) (*beads.Issue, string, []Manua
 	// - Labels use union (no data loss)
 	// - Dependencies use union (no data loss)
 	// - Comments use append (deduplicated)
+	// - Work log uses union (by entry ID)
+	// - Attachments use union (by ID)
+	// - Custom fields merge per field
 	// - compaction_level uses max (or configured strategy)
 	// - estimated_minutes uses configured strategy (may flag for manual resolution)
 	merged, manualConflicts := mergeFieldLevel(base, local, remote)
@@ -542,6 +668,21 @@ func issueEqual(a, b *beads.Issue) bool {
 		return false
 	}
 
+	// Work log (so a local time entry isn't dropped when only remote edited fields)
+	if !workLogEqual(a.WorkLog, b.WorkLog) {
+		return false
+	}
+
+	// Attachments (so a local attach or detach isn't dropped either)
+	if !attachmentsEqual(a.Attachments, b.Attachments) {
+		return false
+	}
+
+	// Custom fields
+	if !customFieldsEqual(a.CustomFields, b.CustomFields) {
+		return false
+	}
+
 	return true
 }
 
@@ -610,6 +751,54 @@ func timePtrEqual(a, b *time.Time) bool {
 	return a.Equal(*b)
 }
 
+func workLogEqual(a, b []*beads.WorkLogEntry) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	byID := make(map[string]*beads.WorkLogEntry, len(a))
+	for _, e := range a {
+		byID[e.ID] = e
+	}
+	for _, e := range b {
+		other, ok := byID[e.ID]
+		if !ok || other.Minutes != e.Minutes || other.Note != e.Note ||
+			!other.StartedAt.Equal(e.StartedAt) || !timePtrEqual(other.EndedAt, e.EndedAt) {
+			return false
+		}
+	}
+	return true
+}
+
+func attachmentsEqual(a, b []*beads.Attachment) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	byID := make(map[string]*beads.Attachment, len(a))
+	for _, att := range a {
+		byID[att.ID] = att
+	}
+	for _, att := range b {
+		other, ok := byID[att.ID]
+		if !ok || other.Name != att.Name || other.SHA256 != att.SHA256 ||
+			!timePtrEqual(other.DetachedAt, att.DetachedAt) {
+			return false
+		}
+	}
+	return true
+}
+
+func customFieldsEqual(a, b map[string]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for name, value := range a {
+		if other, ok := b[name]; !ok || other != value {
+			return false
+		}
+	}
+	return true
+}
+
 func stringSliceEqual(a, b []string) bool {
 	if len(a) != len(b) {
 		return false
@@ -1605,3 +1605,98 @@ func TestMergeResult_ManualConflicts(t *testing.T) {
 		t.Error("ManualConflicts should be initialized, got nil")
 	}
 }
+
+// TestMergeIssue_WorkLog tests that work-log entries from both sides survive a sync merge
+func TestMergeIssue_WorkLog(t *testing.T) {
+	now := time.Now()
+	started := now.Add(-time.Hour)
+	ended := now
+
+	base := makeTestIssue("bd-1234", "Base", types.StatusOpen, 1, now)
+
+	// Local only logged time; remote edited the title
+	local := makeTestIssue("bd-1234", "Base", types.StatusOpen, 1, now)
+	local.WorkLog = []*types.WorkLogEntry{
+		{ID: "wl-local", Actor: "alice", StartedAt: started},
+	}
+	remote := makeTestIssue("bd-1234", "Remote", types.StatusOpen, 1, now.Add(time.Hour))
+	remote.WorkLog = []*types.WorkLogEntry{
+		{ID: "wl-remote", Actor: "bob", Minutes: 20, StartedAt: started.Add(time.Minute), EndedAt: &ended},
+	}
+
+	merged, strategy, _ := MergeIssue(base, local, remote)
+	if strategy != StrategyMerged {
+		t.Fatalf("Expected strategy=%s, got %s", StrategyMerged, strategy)
+	}
+	if merged.Title != "Remote" {
+		t.Errorf("Expected remote title, got %q", merged.Title)
+	}
+	if len(merged.WorkLog) != 2 || merged.WorkLog[0].ID != "wl-local" || merged.WorkLog[1].ID != "wl-remote" {
+		t.Fatalf("Expected both work log entries, got %+v", merged.WorkLog)
+	}
+
+	// A stopped timer beats the running copy of the same entry
+	stopped := *local.WorkLog[0]
+	stopped.EndedAt = &ended
+	stopped.Minutes = 60
+	got := mergeWorkLogEntries(local.WorkLog, []*types.WorkLogEntry{&stopped})
+	if len(got) != 1 || got[0].IsRunning() || got[0].Minutes != 60 {
+		t.Errorf("Expected stopped entry to win, got %+v", got)
+	}
+}
+
+// TestMergeIssue_Attachments tests that attachments from both sides survive a sync merge
+func TestMergeIssue_Attachments(t *testing.T) {
+	now := time.Now()
+	base := makeTestIssue("bd-1234", "Base", types.StatusOpen, 1, now)
+
+	local := makeTestIssue("bd-1234", "Base", types.StatusOpen, 1, now)
+	local.Attachments = []*types.Attachment{
+		{ID: "att-local", Name: "trace.log", SHA256: "aaaa", CreatedAt: now},
+	}
+	remote := makeTestIssue("bd-1234", "Remote", types.StatusOpen, 1, now.Add(time.Hour))
+	remote.Attachments = []*types.Attachment{
+		{ID: "att-remote", Name: "shot.png", SHA256: "bbbb", CreatedAt: now.Add(time.Minute)},
+	}
+
+	merged, _, _ := MergeIssue(base, local, remote)
+	if len(merged.Attachments) != 2 || merged.Attachments[0].ID != "att-local" || merged.Attachments[1].ID != "att-remote" {
+		t.Fatalf("Expected both attachments, got %+v", merged.Attachments)
+	}
+
+	// A detached copy beats the live one
+	detached := *local.Attachments[0]
+	detachedAt := now.Add(time.Hour)
+	detached.DetachedAt = &detachedAt
+	got := mergeAttachments(local.Attachments, []*types.Attachment{&detached})
+	if len(got) != 1 || !got[0].IsDetached() {
+		t.Errorf("Expected detached attachment to win, got %+v", got)
+	}
+}
+
+// TestMergeIssue_CustomFields tests that custom fields merge field by field
+func TestMergeIssue_CustomFields(t *testing.T) {
+	now := time.Now()
+	base := makeTestIssue("bd-1234", "Base", types.StatusOpen, 1, now)
+	base.CustomFields = map[string]string{"points": "3", "team": "core"}
+
+	local := makeTestIssue("bd-1234", "Base", types.StatusOpen, 1, now.Add(time.Hour))
+	local.CustomFields = map[string]string{"points": "5", "team": "core"}
+	remote := makeTestIssue("bd-1234", "Remote", types.StatusOpen, 1, now.Add(2*time.Hour))
+	remote.CustomFields = map[string]string{"points": "3", "sprint": "2025-07"}
+
+	merged, strategy, _ := MergeIssue(base, local, remote)
+	if strategy != StrategyMerged {
+		t.Fatalf("Expected strategy %s, got %s", StrategyMerged, strategy)
+	}
+	want := map[string]string{"points": "5", "sprint": "2025-07"}
+	if !customFieldsEqual(merged.CustomFields, want) {
+		t.Errorf("Expected custom fields %v, got %v", want, merged.CustomFields)
+	}
+
+	// Both sides changed the same field - newer side wins
+	got := mergeCustomFields(base.CustomFields, map[string]string{"points": "8"}, map[string]string{"points": "13"}, false)
+	if got["points"] != "13" {
+		t.Errorf("Expected newer remote value 13, got %q", got["points"])
+	}
+}
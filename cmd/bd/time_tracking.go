@@ -0,0 +1,309 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+var timeCmd = &cobra.Command{
+	Use:     "time",
+	GroupID: "issues",
+	Short:   "Track time spent on issues",
+	Long: `Record actual time spent on issues as work-log entries.
+
+Entries record who did the work and when. They are exported to JSONL with
+the issue and merged across clones, so "bd report time" can compare actual
+time with estimates (bd create --estimate).
+
+Examples:
+  bd time start bd-123              # Start a timer
+  bd time stop bd-123               # Stop it and record the elapsed time
+  bd time log bd-123 1h30m          # Record time after the fact
+  bd time log bd-123 45m --at 2025-03-10 --note "review"
+  bd time list bd-123               # Show the work log and totals`,
+}
+
+var timeStartCmd = &cobra.Command{
+	Use:   "start <issue-id>",
+	Short: "Start a timer on an issue",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		CheckReadonly("time start")
+		note, _ := cmd.Flags().GetString("note")
+		ctx := rootCtx
+		issueID := resolveTimeIssueID(ctx, args[0])
+		who := getActorWithGit()
+
+		entries, err := store.GetWorkLog(ctx, issueID)
+		if err != nil {
+			FatalErrorRespectJSON("getting work log: %v", err)
+		}
+		if running := findRunningEntry(entries, who); running != nil {
+			FatalErrorRespectJSON("%s already has a timer running on %s (started %s)", who, issueID, running.StartedAt.Local().Format("2006-01-02 15:04"))
+		}
+
+		id, err := newRandomID("wl-")
+		if err != nil {
+			FatalErrorRespectJSON("starting timer: %v", err)
+		}
+		now := time.Now().UTC()
+		entry := &types.WorkLogEntry{
+			ID:        id,
+			IssueID:   issueID,
+			Actor:     who,
+			StartedAt: now,
+			Note:      note,
+			CreatedAt: now,
+		}
+		if err := store.SaveWorkLogEntry(ctx, entry); err != nil {
+			FatalErrorRespectJSON("starting timer: %v", err)
+		}
+		markDirtyAndScheduleFlush()
+
+		if jsonOutput {
+			outputJSON(entry)
+			return
+		}
+		fmt.Printf("%s Started timer on %s for %s\n", ui.RenderPass("✓"), ui.RenderID(issueID), who)
+	},
+}
+
+var timeStopCmd = &cobra.Command{
+	Use:   "stop <issue-id>",
+	Short: "Stop your running timer on an issue",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		CheckReadonly("time stop")
+		ctx := rootCtx
+		issueID := resolveTimeIssueID(ctx, args[0])
+		who := getActorWithGit()
+
+		entries, err := store.GetWorkLog(ctx, issueID)
+		if err != nil {
+			FatalErrorRespectJSON("getting work log: %v", err)
+		}
+		entry := findRunningEntry(entries, who)
+		if entry == nil {
+			FatalErrorRespectJSON("no timer running on %s for %s (start one with: bd time start %s)", issueID, who, issueID)
+		}
+
+		ended := time.Now().UTC()
+		entry.EndedAt = &ended
+		entry.Minutes = durationMinutes(ended.Sub(entry.StartedAt))
+		if cmd.Flags().Changed("note") {
+			entry.Note, _ = cmd.Flags().GetString("note")
+		}
+		if err := store.SaveWorkLogEntry(ctx, entry); err != nil {
+			FatalErrorRespectJSON("stopping timer: %v", err)
+		}
+		markDirtyAndScheduleFlush()
+
+		if jsonOutput {
+			outputJSON(entry)
+			return
+		}
+		fmt.Printf("%s Logged %s on %s\n", ui.RenderPass("✓"), formatMinutes(entry.Minutes), ui.RenderID(issueID))
+		printTimeTotals(ctx, issueID, entries)
+	},
+}
+
+var timeLogCmd = &cobra.Command{
+	Use:   "log <issue-id> <duration>",
+	Short: "Record time already spent on an issue",
+	Long: `Record time already spent on an issue.
+
+Durations use Go syntax plus days: 30m, 1h30m, 2h, 1d.
+By default the work is recorded as ending now; use --at to backfill.`,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		CheckReadonly("time log")
+		note, _ := cmd.Flags().GetString("note")
+		at, _ := cmd.Flags().GetString("at")
+
+		d, err := parseDurationString(args[1])
+		if err != nil || d <= 0 {
+			FatalErrorRespectJSON("invalid duration %q (use e.g. 30m, 1h30m, 1d)", args[1])
+		}
+		ended := time.Now().UTC()
+		if at != "" {
+			t, err := parseTimeFlag(at)
+			if err != nil {
+				FatalErrorRespectJSON("invalid --at: %v", err)
+			}
+			ended = t.UTC()
+		}
+
+		ctx := rootCtx
+		issueID := resolveTimeIssueID(ctx, args[0])
+		entries, err := store.GetWorkLog(ctx, issueID)
+		if err != nil {
+			FatalErrorRespectJSON("getting work log: %v", err)
+		}
+
+		id, err := newRandomID("wl-")
+		if err != nil {
+			FatalErrorRespectJSON("logging time: %v", err)
+		}
+		entry := &types.WorkLogEntry{
+			ID:        id,
+			IssueID:   issueID,
+			Actor:     getActorWithGit(),
+			Minutes:   durationMinutes(d),
+			StartedAt: ended.Add(-d),
+			EndedAt:   &ended,
+			Note:      note,
+			CreatedAt: time.Now().UTC(),
+		}
+		if err := store.SaveWorkLogEntry(ctx, entry); err != nil {
+			FatalErrorRespectJSON("logging time: %v", err)
+		}
+		markDirtyAndScheduleFlush()
+
+		if jsonOutput {
+			outputJSON(entry)
+			return
+		}
+		fmt.Printf("%s Logged %s on %s\n", ui.RenderPass("✓"), formatMinutes(entry.Minutes), ui.RenderID(issueID))
+		printTimeTotals(ctx, issueID, append(entries, entry))
+	},
+}
+
+var timeListCmd = &cobra.Command{
+	Use:   "list <issue-id>",
+	Short: "Show the work log of an issue",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := rootCtx
+		issueID := resolveTimeIssueID(ctx, args[0])
+
+		entries, err := store.GetWorkLog(ctx, issueID)
+		if err != nil {
+			FatalErrorRespectJSON("getting work log: %v", err)
+		}
+		if entries == nil {
+			entries = []*types.WorkLogEntry{}
+		}
+
+		if jsonOutput {
+			outputJSON(entries)
+			return
+		}
+		if len(entries) == 0 {
+			fmt.Printf("No time logged on %s\n", issueID)
+			return
+		}
+
+		fmt.Printf("\nWork log for %s:\n\n", ui.RenderID(issueID))
+		for _, e := range entries {
+			spent := formatMinutes(e.Minutes)
+			if e.IsRunning() {
+				spent = ui.RenderWarn("running " + formatMinutes(durationMinutes(time.Since(e.StartedAt))))
+			}
+			line := fmt.Sprintf("  %s  %-10s  %s", e.StartedAt.Local().Format("2006-01-02 15:04"), spent, e.Actor)
+			if e.Note != "" {
+				line += "  " + ui.RenderMuted(e.Note)
+			}
+			fmt.Println(line)
+		}
+		fmt.Println()
+		printTimeTotals(ctx, issueID, entries)
+	},
+}
+
+// resolveTimeIssueID switches to direct mode and resolves a partial issue ID.
+// Work-log entries are written straight to the database and reach JSONL
+// through the usual auto-flush.
+func resolveTimeIssueID(ctx context.Context, id string) string {
+	if err := ensureDirectMode("time tracking requires direct database access"); err != nil {
+		FatalErrorRespectJSON("%v", err)
+	}
+	fullID, err := utils.ResolvePartialID(ctx, store, id)
+	if err != nil {
+		FatalErrorRespectJSON("resolving %s: %v", id, err)
+	}
+	return fullID
+}
+
+// printTimeTotals prints logged time against the issue's estimate.
+func printTimeTotals(ctx context.Context, issueID string, entries []*types.WorkLogEntry) {
+	total := 0
+	for _, e := range entries {
+		total += e.Minutes
+	}
+	line := fmt.Sprintf("Total: %s", formatMinutes(total))
+	if issue, err := store.GetIssue(ctx, issueID); err == nil && issue != nil && issue.EstimatedMinutes != nil && *issue.EstimatedMinutes > 0 {
+		est := *issue.EstimatedMinutes
+		line += fmt.Sprintf(" of %s estimated (%.0f%%)", formatMinutes(est), 100*float64(total)/float64(est))
+	}
+	fmt.Println(line)
+}
+
+// findRunningEntry returns actor's running timer among entries, if any.
+func findRunningEntry(entries []*types.WorkLogEntry, actor string) *types.WorkLogEntry {
+	for _, e := range entries {
+		if e.IsRunning() && e.Actor == actor {
+			return e
+		}
+	}
+	return nil
+}
+
+// newRandomID returns prefix followed by 12 random hex digits. Work-log
+// entries ("wl-") and attachments ("att-") use random IDs so that records
+// created in different clones stay distinct when their JSONL is merged.
+func newRandomID(prefix string) (string, error) {
+	b := make([]byte, 6)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate id: %w", err)
+	}
+	return prefix + hex.EncodeToString(b), nil
+}
+
+// durationMinutes rounds d to whole minutes; any positive duration counts
+// as at least one minute.
+func durationMinutes(d time.Duration) int {
+	if d <= 0 {
+		return 0
+	}
+	return max(1, int(math.Round(d.Minutes())))
+}
+
+// formatMinutes renders minutes as e.g. "45m", "2h", "1h30m".
+func formatMinutes(minutes int) string {
+	sign := ""
+	if minutes < 0 {
+		sign = "-"
+		minutes = -minutes
+	}
+	h, m := minutes/60, minutes%60
+	switch {
+	case h == 0:
+		return fmt.Sprintf("%s%dm", sign, m)
+	case m == 0:
+		return fmt.Sprintf("%s%dh", sign, h)
+	default:
+		return fmt.Sprintf("%s%dh%02dm", sign, h, m)
+	}
+}
+
+func init() {
+	timeStartCmd.Flags().String("note", "", "Note describing the work")
+	timeStopCmd.Flags().String("note", "", "Note describing the work (replaces the start note)")
+	timeLogCmd.Flags().String("note", "", "Note describing the work")
+	timeLogCmd.Flags().String("at", "", "When the work ended (e.g. 2025-03-10, yesterday, -2h; default now)")
+
+	for _, c := range []*cobra.Command{timeStartCmd, timeStopCmd, timeLogCmd, timeListCmd} {
+		c.ValidArgsFunction = issueIDCompletion
+		timeCmd.AddCommand(c)
+	}
+	rootCmd.AddCommand(timeCmd)
+}
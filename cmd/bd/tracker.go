@@ -0,0 +1,188 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/linear"
+	"github.com/steveyegge/beads/internal/tracker"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// importTrackerIssues imports pulled tracker issues through the standard
+// import path, so linked issues are only updated when the remote is newer.
+func importTrackerIssues(ctx context.Context, issues []*types.Issue, dryRun bool) (int, int, int, error) {
+	result, err := importIssuesCore(ctx, dbPath, store, issues, ImportOptions{DryRun: dryRun})
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	return result.Created, result.Updated, result.Skipped, nil
+}
+
+// generateTrackerIDs assigns hash IDs to pulled issues that don't have one,
+// avoiding IDs already in use (including tombstones).
+func generateTrackerIDs(ctx context.Context, issues []*types.Issue, creator string, opts linear.IDGenerationOptions) error {
+	prefix, err := store.GetConfig(ctx, "issue_prefix")
+	if err != nil || prefix == "" {
+		prefix = "bd"
+	}
+
+	existingIssues, err := store.SearchIssues(ctx, "", types.IssueFilter{IncludeTombstones: true})
+	if err != nil {
+		return fmt.Errorf("failed to fetch existing issues for ID collision avoidance: %w", err)
+	}
+	opts.UsedIDs = make(map[string]bool, len(existingIssues))
+	for _, issue := range existingIssues {
+		if issue.ID != "" {
+			opts.UsedIDs[issue.ID] = true
+		}
+	}
+
+	return linear.GenerateIssueIDs(issues, prefix, creator, opts)
+}
+
+// newTrackerEngine creates a sync engine for adapter that imports through
+// importIssuesCore and generates hash IDs for new issues.
+func newTrackerEngine(ctx context.Context, adapter tracker.Adapter) *tracker.Engine {
+	engine := tracker.NewEngine(adapter, store, actor)
+	engine.Import = importTrackerIssues
+	engine.GenerateIDs = func(issues []*types.Issue) error {
+		return generateTrackerIDs(ctx, issues, adapter.Name()+"-import", linear.IDGenerationOptions{})
+	}
+	if jsonOutput {
+		engine.Out = io.Discard
+	}
+	return engine
+}
+
+// addTrackerSyncFlags registers the flags shared by all "<tracker> sync"
+// commands. remoteFlag names the tracker in --prefer-<remoteFlag>.
+func addTrackerSyncFlags(cmd *cobra.Command, remoteFlag, displayName string) {
+	cmd.Flags().Bool("pull", false, fmt.Sprintf("Pull issues from %s", displayName))
+	cmd.Flags().Bool("push", false, fmt.Sprintf("Push issues to %s", displayName))
+	cmd.Flags().Bool("dry-run", false, "Preview sync without making changes")
+	cmd.Flags().Bool("prefer-local", false, "Prefer local version on conflicts")
+	cmd.Flags().Bool("prefer-"+remoteFlag, false, fmt.Sprintf("Prefer %s version on conflicts", displayName))
+	cmd.Flags().String("conflict-policy", "", "Conflict policy: newest (default), prefer-local, prefer-remote, manual")
+	cmd.Flags().Bool("create-only", false, "Only create new issues, don't update existing")
+	cmd.Flags().Bool("update-refs", true, fmt.Sprintf("Update external_ref after creating %s issues", displayName))
+	cmd.Flags().String("state", "all", "Issue state to sync: open, closed, all")
+	cmd.Flags().StringSlice("type", nil, "Only sync issues of these types (can be repeated)")
+	cmd.Flags().StringSlice("exclude-type", nil, "Exclude issues of these types (can be repeated)")
+}
+
+// trackerConflictPolicy resolves --conflict-policy and the --prefer-* flags
+// into a single policy.
+func trackerConflictPolicy(cmd *cobra.Command, remoteFlag string) (tracker.ConflictPolicy, error) {
+	preferLocal, _ := cmd.Flags().GetBool("prefer-local")
+	preferRemote, _ := cmd.Flags().GetBool("prefer-" + remoteFlag)
+	policyName, _ := cmd.Flags().GetString("conflict-policy")
+
+	if preferLocal && preferRemote {
+		return "", fmt.Errorf("cannot use both --prefer-local and --prefer-%s", remoteFlag)
+	}
+
+	policy, err := tracker.ParseConflictPolicy(policyName)
+	if err != nil {
+		return "", err
+	}
+
+	var preferred tracker.ConflictPolicy
+	var flag string
+	switch {
+	case preferLocal:
+		preferred, flag = tracker.PolicyPreferLocal, "prefer-local"
+	case preferRemote:
+		preferred, flag = tracker.PolicyPreferRemote, "prefer-"+remoteFlag
+	default:
+		return policy, nil
+	}
+	if policyName != "" && policy != preferred {
+		return "", fmt.Errorf("--conflict-policy %s conflicts with --%s", policy, flag)
+	}
+	return preferred, nil
+}
+
+// runTrackerSync implements "<tracker> sync" on top of the shared engine.
+// validate checks the tracker's configuration; newEngine builds the engine
+// once the store is available.
+func runTrackerSync(cmd *cobra.Command, remoteFlag string, validate func() error, newEngine func(ctx context.Context) (*tracker.Engine, error)) {
+	pull, _ := cmd.Flags().GetBool("pull")
+	push, _ := cmd.Flags().GetBool("push")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	createOnly, _ := cmd.Flags().GetBool("create-only")
+	updateRefs, _ := cmd.Flags().GetBool("update-refs")
+	state, _ := cmd.Flags().GetString("state")
+	typeFilters, _ := cmd.Flags().GetStringSlice("type")
+	excludeTypes, _ := cmd.Flags().GetStringSlice("exclude-type")
+
+	// Block writes in readonly mode (sync modifies data)
+	if !dryRun {
+		CheckReadonly(cmd.Parent().Name() + " sync")
+	}
+
+	policy, err := trackerConflictPolicy(cmd, remoteFlag)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	if state != "open" && state != "closed" && state != "all" {
+		fmt.Fprintf(os.Stderr, "Error: invalid --state %q (expected open, closed, or all)\n", state)
+		os.Exit(1)
+	}
+
+	if err := ensureStoreActive(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: database not available: %v\n", err)
+		os.Exit(1)
+	}
+
+	if err := validate(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	ctx := rootCtx
+	engine, err := newEngine(ctx)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	result, err := engine.Sync(ctx, tracker.SyncOptions{
+		Pull:         pull,
+		Push:         push,
+		DryRun:       dryRun,
+		State:        state,
+		Policy:       policy,
+		CreateOnly:   createOnly,
+		UpdateRefs:   updateRefs,
+		Types:        typeFilters,
+		ExcludeTypes: excludeTypes,
+	})
+	if err != nil {
+		if jsonOutput {
+			outputJSON(result)
+		} else {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		}
+		os.Exit(1)
+	}
+
+	if jsonOutput {
+		outputJSON(result)
+	} else if dryRun {
+		fmt.Println("\n✓ Dry run complete (no changes made)")
+	} else {
+		fmt.Printf("\n✓ %s sync complete\n", engine.Adapter.DisplayName())
+		if len(result.Warnings) > 0 {
+			fmt.Println("\nWarnings:")
+			for _, w := range result.Warnings {
+				fmt.Printf("  - %s\n", w)
+			}
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/tracker"
+)
+
+func TestTrackerConflictPolicy(t *testing.T) {
+	tests := []struct {
+		args    []string
+		want    tracker.ConflictPolicy
+		wantErr bool
+	}{
+		{nil, tracker.PolicyNewest, false},
+		{[]string{"--prefer-local"}, tracker.PolicyPreferLocal, false},
+		{[]string{"--prefer-jira"}, tracker.PolicyPreferRemote, false},
+		{[]string{"--conflict-policy=manual"}, tracker.PolicyManual, false},
+		{[]string{"--conflict-policy=prefer-local", "--prefer-local"}, tracker.PolicyPreferLocal, false},
+		{[]string{"--prefer-local", "--prefer-jira"}, "", true},
+		{[]string{"--conflict-policy=manual", "--prefer-jira"}, "", true},
+		{[]string{"--conflict-policy=bogus"}, "", true},
+	}
+
+	for _, tt := range tests {
+		cmd := &cobra.Command{Use: "sync"}
+		addTrackerSyncFlags(cmd, "jira", "Jira")
+		if err := cmd.ParseFlags(tt.args); err != nil {
+			t.Fatalf("ParseFlags(%v) failed: %v", tt.args, err)
+		}
+
+		got, err := trackerConflictPolicy(cmd, "jira")
+		if (err != nil) != tt.wantErr {
+			t.Errorf("trackerConflictPolicy(%v) error = %v, wantErr %v", tt.args, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("trackerConflictPolicy(%v) = %q, want %q", tt.args, got, tt.want)
+		}
+	}
+}
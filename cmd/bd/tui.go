@@ -0,0 +1,254 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/beads"
+	"github.com/steveyegge/beads/internal/rpc"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+var tuiCmd = &cobra.Command{
+	Use:     "tui",
+	GroupID: "views",
+	Short:   "Interactive terminal UI with board, tree and detail panes",
+	Long: `Full-screen terminal UI for browsing and triaging issues.
+
+The left pane shows either a kanban board grouped by status or the epic/child
+tree. The right pane shows the selected issue's details and its dependency
+tree. When a daemon is running the UI refreshes as soon as anything changes;
+in direct mode press r to reload.
+
+Keys:
+` + tuiKeyHelp,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if !ui.IsTerminal() {
+			FatalError("bd tui needs an interactive terminal")
+		}
+		showClosed, _ := cmd.Flags().GetBool("all")
+
+		var exec tuiExecutor
+		var waiter *tuiWaiter
+		if daemonClient != nil {
+			exec = daemonClient
+			waiter = &tuiWaiter{socketPath: getSocketPath()}
+			defer waiter.close()
+		} else {
+			root := ""
+			if beadsDir := beads.FindBeadsDir(); beadsDir != "" {
+				root = filepath.Dir(beadsDir)
+			}
+			local := rpc.NewLocalClient(store, root, dbPath)
+			local.SetActor(actor)
+			local.OnMutation(func(rpc.MutationEvent) {
+				markDirtyAndScheduleFlush()
+			})
+			exec = local
+		}
+
+		// Query the terminal background before bubbletea owns the terminal;
+		// markdown rendering reuses the answer
+		ui.HasDarkBackground()
+
+		m := newTuiModel(&lockedExecutor{exec: exec}, waiter)
+		m.showClosed = showClosed
+		program := tea.NewProgram(m, tea.WithAltScreen(), tea.WithContext(rootCtx))
+		if _, err := program.Run(); err != nil && rootCtx.Err() == nil {
+			FatalError("tui: %v", err)
+		}
+	},
+}
+
+// tuiKeyHelp lists the key bindings, for --help and the ? overlay.
+const tuiKeyHelp = `  tab            switch between board and tree
+  arrows, hjkl   move (in the tree, h/l collapse and expand)
+  enter, space   collapse or expand the selected tree node
+  c              claim the selected issue
+  x              close the selected issue (prompts for a reason)
+  p              set priority (0-4)
+  L              add a label (prefix with - to remove it)
+  m              add a comment
+  D              toggle dependencies/dependents
+  a              show or hide closed issues
+  pgup, pgdn     scroll the detail pane
+  r              reload
+  ?              help
+  q              quit`
+
+// tuiExecutor runs RPC operations: the daemon client when a daemon is
+// running, or an rpc.LocalClient over direct storage otherwise.
+type tuiExecutor interface {
+	Execute(operation string, args interface{}) (*rpc.Response, error)
+}
+
+// lockedExecutor serializes operations. bubbletea runs commands
+// concurrently, and rpc.Client is not safe for concurrent use.
+type lockedExecutor struct {
+	mu   sync.Mutex
+	exec tuiExecutor
+}
+
+func (e *lockedExecutor) Execute(operation string, args interface{}) (*rpc.Response, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.exec.Execute(operation, args)
+}
+
+// tuiWaiter blocks on wait_for_mutations over its own daemon connection, so
+// a pending wait never holds up the UI's own operations.
+type tuiWaiter struct {
+	socketPath string
+	client     *rpc.Client
+}
+
+// tuiWaitTimeout is how long one wait_for_mutations call blocks.
+const tuiWaitTimeout = 30 * time.Second
+
+// wait returns the mutations after since (Unix ms), blocking until there
+// is at least one or the wait times out.
+func (w *tuiWaiter) wait(since int64) ([]rpc.MutationEvent, error) {
+	if w.client == nil {
+		client, err := connectDaemon(w.socketPath)
+		if err != nil {
+			return nil, err
+		}
+		w.client = client
+	}
+	resp, err := w.client.WaitForMutations(&rpc.WaitForMutationsArgs{
+		Since:   since,
+		Timeout: tuiWaitTimeout.Milliseconds(),
+	})
+	if err != nil {
+		w.close()
+		return nil, err
+	}
+	var events []rpc.MutationEvent
+	if err := json.Unmarshal(resp.Data, &events); err != nil {
+		return nil, fmt.Errorf("invalid mutations: %w", err)
+	}
+	return events, nil
+}
+
+func (w *tuiWaiter) close() {
+	if w.client != nil {
+		_ = w.client.Close()
+		w.client = nil
+	}
+}
+
+// Messages delivered to the model by commands
+type (
+	tuiIssuesMsg struct {
+		issues []rpc.GraphIssueSummary
+		err    error
+	}
+	tuiDetailMsg struct {
+		id      string
+		details *types.IssueDetails
+		deps    []*types.TreeNode
+		err     error
+	}
+	tuiActionMsg struct {
+		done string
+		err  error
+	}
+	tuiMutationsMsg struct {
+		since   int64
+		changed bool
+		err     error
+	}
+	tuiRetryWaitMsg struct{}
+)
+
+// tuiLoadIssues loads the issues shown on the board and in the tree.
+func tuiLoadIssues(exec tuiExecutor, showClosed bool) tea.Cmd {
+	return func() tea.Msg {
+		args := &rpc.GetGraphDataArgs{ExcludeStatus: []string{string(types.StatusTombstone)}}
+		if !showClosed {
+			args.ExcludeStatus = append(args.ExcludeStatus, string(types.StatusClosed))
+		}
+		resp, err := exec.Execute(rpc.OpGetGraphData, args)
+		if err != nil {
+			return tuiIssuesMsg{err: err}
+		}
+		var data rpc.GetGraphDataResponse
+		if err := json.Unmarshal(resp.Data, &data); err != nil {
+			return tuiIssuesMsg{err: fmt.Errorf("invalid issue list: %w", err)}
+		}
+		return tuiIssuesMsg{issues: data.Issues}
+	}
+}
+
+// tuiDepDepth limits the dependency tree shown in the dependency pane.
+const tuiDepDepth = 10
+
+// tuiLoadDetail loads an issue's details and dependency tree.
+func tuiLoadDetail(exec tuiExecutor, id string, reverse bool) tea.Cmd {
+	return func() tea.Msg {
+		msg := tuiDetailMsg{id: id}
+		resp, err := exec.Execute(rpc.OpShow, &rpc.ShowArgs{ID: id})
+		if err != nil {
+			msg.err = err
+			return msg
+		}
+		if err := json.Unmarshal(resp.Data, &msg.details); err != nil {
+			msg.err = fmt.Errorf("invalid issue details: %w", err)
+			return msg
+		}
+		resp, err = exec.Execute(rpc.OpDepTree, &rpc.DepTreeArgs{ID: id, MaxDepth: tuiDepDepth, Reverse: reverse})
+		if err != nil {
+			msg.err = err
+			return msg
+		}
+		if err := json.Unmarshal(resp.Data, &msg.deps); err != nil {
+			msg.err = fmt.Errorf("invalid dependency tree: %w", err)
+		}
+		return msg
+	}
+}
+
+// tuiRun runs a mutation and reports done on success.
+func tuiRun(exec tuiExecutor, done, operation string, args interface{}) tea.Cmd {
+	return func() tea.Msg {
+		if _, err := exec.Execute(operation, args); err != nil {
+			return tuiActionMsg{err: err}
+		}
+		return tuiActionMsg{done: done}
+	}
+}
+
+// tuiWaitForMutations waits for the daemon to report a change after since.
+func tuiWaitForMutations(w *tuiWaiter, since int64) tea.Cmd {
+	return func() tea.Msg {
+		events, err := w.wait(since)
+		if err != nil {
+			return tuiMutationsMsg{since: since, err: err}
+		}
+		for _, e := range events {
+			if ts := e.Timestamp.UnixMilli(); ts > since {
+				since = ts
+			}
+		}
+		return tuiMutationsMsg{since: since, changed: len(events) > 0}
+	}
+}
+
+// tuiRetryWait schedules another wait after the daemon connection failed.
+func tuiRetryWait() tea.Cmd {
+	return tea.Tick(5*time.Second, func(time.Time) tea.Msg {
+		return tuiRetryWaitMsg{}
+	})
+}
+
+func init() {
+	tuiCmd.Flags().BoolP("all", "a", false, "Include closed issues")
+	rootCmd.AddCommand(tuiCmd)
+}
@@ -0,0 +1,822 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/steveyegge/beads/internal/rpc"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+// tuiView is the content of the left pane.
+type tuiView int
+
+const (
+	tuiBoardView tuiView = iota
+	tuiTreeView
+)
+
+// tuiPrompt is the input the footer is asking for, if any.
+type tuiPrompt int
+
+const (
+	tuiNoPrompt tuiPrompt = iota
+	tuiClosePrompt
+	tuiPriorityPrompt
+	tuiLabelPrompt
+	tuiCommentPrompt
+)
+
+var tuiPromptLabels = map[tuiPrompt]string{
+	tuiClosePrompt:    "Close reason: ",
+	tuiPriorityPrompt: "Priority (0-4): ",
+	tuiLabelPrompt:    "Label (-label removes): ",
+	tuiCommentPrompt:  "Comment: ",
+}
+
+// tuiStatusOrder is the order of the board columns. Statuses not listed
+// follow in alphabetical order.
+var tuiStatusOrder = []types.Status{
+	types.StatusOpen,
+	types.StatusInProgress,
+	types.StatusReview,
+	types.StatusBlocked,
+	types.StatusHooked,
+	types.StatusDeferred,
+	types.StatusPinned,
+	types.StatusClosed,
+}
+
+// tuiColumn is one board column.
+type tuiColumn struct {
+	status string
+	issues []*rpc.GraphIssueSummary
+}
+
+// tuiTreeRow is one visible row of the epic/child tree.
+type tuiTreeRow struct {
+	issue       *rpc.GraphIssueSummary
+	depth       int
+	hasChildren bool
+}
+
+type tuiModel struct {
+	exec     tuiExecutor
+	waiter   *tuiWaiter // nil in direct mode
+	readonly bool
+
+	width, height int
+	view          tuiView
+	showClosed    bool
+	showHelp      bool
+	reverseDeps   bool
+	live          bool
+	since         int64
+
+	issues    []rpc.GraphIssueSummary
+	columns   []tuiColumn
+	col, row  int
+	tree      []tuiTreeRow
+	treeRow   int
+	collapsed map[string]bool
+
+	selected  string
+	details   *types.IssueDetails
+	deps      []*types.TreeNode
+	detailErr error
+	detail    viewport.Model
+
+	prompt tuiPrompt
+	input  textinput.Model
+
+	message string
+	err     error
+}
+
+func newTuiModel(exec tuiExecutor, waiter *tuiWaiter) *tuiModel {
+	input := textinput.New()
+	input.CharLimit = 500
+	return &tuiModel{
+		exec:      exec,
+		waiter:    waiter,
+		readonly:  readonlyMode,
+		since:     time.Now().UnixMilli(),
+		collapsed: map[string]bool{},
+		detail:    viewport.New(0, 0),
+		input:     input,
+	}
+}
+
+func (m *tuiModel) Init() tea.Cmd {
+	cmds := []tea.Cmd{tuiLoadIssues(m.exec, m.showClosed)}
+	if m.waiter != nil {
+		m.live = true
+		cmds = append(cmds, tuiWaitForMutations(m.waiter, m.since))
+	}
+	return tea.Batch(cmds...)
+}
+
+func (m *tuiModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		m.layoutDetail()
+		return m, nil
+
+	case tuiIssuesMsg:
+		if msg.err != nil {
+			m.err = msg.err
+			return m, nil
+		}
+		m.setIssues(msg.issues)
+		return m, m.reloadDetail()
+
+	case tuiDetailMsg:
+		if msg.id != m.selected {
+			return m, nil // selection moved on while loading
+		}
+		m.details, m.deps, m.detailErr = msg.details, msg.deps, msg.err
+		m.layoutDetail()
+		return m, nil
+
+	case tuiActionMsg:
+		if msg.err != nil {
+			m.err = msg.err
+			m.message = ""
+		} else {
+			m.err = nil
+			m.message = msg.done
+		}
+		return m, tuiLoadIssues(m.exec, m.showClosed)
+
+	case tuiMutationsMsg:
+		m.live = msg.err == nil
+		if msg.err != nil {
+			return m, tuiRetryWait()
+		}
+		m.since = msg.since
+		cmds := []tea.Cmd{tuiWaitForMutations(m.waiter, m.since)}
+		if msg.changed {
+			cmds = append(cmds, tuiLoadIssues(m.exec, m.showClosed))
+		}
+		return m, tea.Batch(cmds...)
+
+	case tuiRetryWaitMsg:
+		return m, tuiWaitForMutations(m.waiter, m.since)
+
+	case tea.KeyMsg:
+		if m.prompt != tuiNoPrompt {
+			return m.updatePrompt(msg)
+		}
+		return m.updateKey(msg)
+	}
+	return m, nil
+}
+
+// updateKey handles a key press outside of a prompt.
+func (m *tuiModel) updateKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.showHelp {
+		m.showHelp = false
+		return m, nil
+	}
+	switch msg.String() {
+	case "q", "ctrl+c":
+		return m, tea.Quit
+	case "?":
+		m.showHelp = true
+	case "tab":
+		if m.view == tuiBoardView {
+			m.view = tuiTreeView
+		} else {
+			m.view = tuiBoardView
+		}
+		m.selectID(m.selected)
+	case "up", "k":
+		m.move(0, -1)
+	case "down", "j":
+		m.move(0, 1)
+	case "left", "h":
+		if m.view == tuiTreeView {
+			m.collapseOrParent()
+		} else {
+			m.move(-1, 0)
+		}
+	case "right", "l":
+		if m.view == tuiTreeView {
+			m.expand()
+		} else {
+			m.move(1, 0)
+		}
+	case "enter", " ":
+		if m.view == tuiTreeView {
+			m.toggle()
+		}
+	case "home", "g":
+		m.move(0, -len(m.issues))
+	case "end", "G":
+		m.move(0, len(m.issues))
+	case "pgup", "ctrl+u":
+		m.detail.HalfPageUp()
+	case "pgdown", "ctrl+d":
+		m.detail.HalfPageDown()
+	case "r":
+		m.message, m.err = "", nil
+		return m, tuiLoadIssues(m.exec, m.showClosed)
+	case "a":
+		m.showClosed = !m.showClosed
+		return m, tuiLoadIssues(m.exec, m.showClosed)
+	case "D":
+		m.reverseDeps = !m.reverseDeps
+		return m, m.reloadDetail()
+	case "c":
+		if issue := m.current(); issue != nil && m.writable() {
+			return m, tuiRun(m.exec, "Claimed "+issue.ID, rpc.OpUpdate, &rpc.UpdateArgs{ID: issue.ID, Claim: true})
+		}
+	case "x":
+		return m, m.startPrompt(tuiClosePrompt)
+	case "p":
+		return m, m.startPrompt(tuiPriorityPrompt)
+	case "L":
+		return m, m.startPrompt(tuiLabelPrompt)
+	case "m":
+		return m, m.startPrompt(tuiCommentPrompt)
+	}
+	return m, m.syncSelection()
+}
+
+// writable reports whether mutations are allowed, and says why not.
+func (m *tuiModel) writable() bool {
+	if m.readonly {
+		m.err = fmt.Errorf("read-only mode")
+		return false
+	}
+	return true
+}
+
+func (m *tuiModel) startPrompt(prompt tuiPrompt) tea.Cmd {
+	if m.current() == nil || !m.writable() {
+		return nil
+	}
+	m.prompt = prompt
+	m.input.Prompt = tuiPromptLabels[prompt]
+	m.input.Reset()
+	if prompt == tuiPriorityPrompt {
+		m.input.SetValue(strconv.Itoa(m.current().Priority))
+		m.input.CursorEnd()
+	}
+	return m.input.Focus()
+}
+
+// updatePrompt handles a key press while the footer prompt is active.
+func (m *tuiModel) updatePrompt(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return m, tea.Quit
+	case "esc":
+		m.prompt = tuiNoPrompt
+		m.input.Blur()
+		return m, nil
+	case "enter":
+		prompt, value := m.prompt, strings.TrimSpace(m.input.Value())
+		m.prompt = tuiNoPrompt
+		m.input.Blur()
+		return m, m.submit(prompt, value)
+	}
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	return m, cmd
+}
+
+// submit runs the action for a completed prompt.
+func (m *tuiModel) submit(prompt tuiPrompt, value string) tea.Cmd {
+	issue := m.current()
+	if issue == nil {
+		return nil
+	}
+	id := issue.ID
+	switch prompt {
+	case tuiClosePrompt:
+		if value == "" {
+			value = "Completed"
+		}
+		return tuiRun(m.exec, "Closed "+id, rpc.OpClose, &rpc.CloseArgs{ID: id, Reason: value})
+	case tuiPriorityPrompt:
+		priority, err := strconv.Atoi(value)
+		if err != nil || priority < 0 || priority > 4 {
+			m.err = fmt.Errorf("priority must be 0-4, got %q", value)
+			return nil
+		}
+		return tuiRun(m.exec, fmt.Sprintf("Set %s to P%d", id, priority), rpc.OpUpdate, &rpc.UpdateArgs{ID: id, Priority: &priority})
+	case tuiLabelPrompt:
+		if label, ok := strings.CutPrefix(value, "-"); ok && label != "" {
+			return tuiRun(m.exec, fmt.Sprintf("Removed %s from %s", label, id), rpc.OpLabelRemove, &rpc.LabelRemoveArgs{ID: id, Label: label})
+		}
+		if value == "" {
+			return nil
+		}
+		return tuiRun(m.exec, fmt.Sprintf("Added %s to %s", value, id), rpc.OpLabelAdd, &rpc.LabelAddArgs{ID: id, Label: value})
+	case tuiCommentPrompt:
+		if value == "" {
+			return nil
+		}
+		return tuiRun(m.exec, "Commented on "+id, rpc.OpCommentAdd, &rpc.CommentAddArgs{ID: id, Author: actor, Text: value})
+	}
+	return nil
+}
+
+// setIssues replaces the loaded issues, keeping the selection if the
+// selected issue is still shown.
+func (m *tuiModel) setIssues(issues []rpc.GraphIssueSummary) {
+	m.issues = issues
+	m.columns = buildTuiBoard(issues, m.showClosed)
+	m.tree = buildTuiTree(issues, m.collapsed)
+	m.selectID(m.selected)
+}
+
+// selectID moves the cursor of the current view to the issue with the given
+// ID, or keeps it in range if that issue is not shown.
+func (m *tuiModel) selectID(id string) {
+	switch m.view {
+	case tuiBoardView:
+		for c, column := range m.columns {
+			for r, issue := range column.issues {
+				if issue.ID == id {
+					m.col, m.row = c, r
+					return
+				}
+			}
+		}
+	case tuiTreeView:
+		for r, row := range m.tree {
+			if row.issue.ID == id {
+				m.treeRow = r
+				return
+			}
+		}
+	}
+	m.move(0, 0)
+}
+
+// move moves the cursor by dc columns and dr rows, staying in range.
+func (m *tuiModel) move(dc, dr int) {
+	if m.view == tuiTreeView {
+		m.treeRow = clampInt(m.treeRow+dr, 0, len(m.tree)-1)
+		return
+	}
+	if len(m.columns) == 0 {
+		m.col, m.row = 0, 0
+		return
+	}
+	m.col = clampInt(m.col+dc, 0, len(m.columns)-1)
+	m.row = clampInt(m.row+dr, 0, len(m.columns[m.col].issues)-1)
+}
+
+func clampInt(v, lo, hi int) int {
+	if v > hi {
+		v = hi
+	}
+	if v < lo {
+		v = lo
+	}
+	return v
+}
+
+// current returns the issue under the cursor, or nil.
+func (m *tuiModel) current() *rpc.GraphIssueSummary {
+	switch m.view {
+	case tuiTreeView:
+		if m.treeRow < len(m.tree) {
+			return m.tree[m.treeRow].issue
+		}
+	default:
+		if m.col < len(m.columns) && m.row < len(m.columns[m.col].issues) {
+			return m.columns[m.col].issues[m.row]
+		}
+	}
+	return nil
+}
+
+// syncSelection loads the detail pane when the cursor moved to another issue.
+func (m *tuiModel) syncSelection() tea.Cmd {
+	id := ""
+	if issue := m.current(); issue != nil {
+		id = issue.ID
+	}
+	if id == m.selected {
+		return nil
+	}
+	m.selected = id
+	m.details, m.deps, m.detailErr = nil, nil, nil
+	m.layoutDetail()
+	return m.reloadDetail()
+}
+
+// reloadDetail reloads the detail pane for the issue under the cursor.
+func (m *tuiModel) reloadDetail() tea.Cmd {
+	if issue := m.current(); issue != nil {
+		m.selected = issue.ID
+		return tuiLoadDetail(m.exec, issue.ID, m.reverseDeps)
+	}
+	m.selected = ""
+	m.details, m.deps, m.detailErr = nil, nil, nil
+	m.layoutDetail()
+	return nil
+}
+
+// toggle collapses or expands the tree node under the cursor.
+func (m *tuiModel) toggle() {
+	if m.treeRow >= len(m.tree) || !m.tree[m.treeRow].hasChildren {
+		return
+	}
+	id := m.tree[m.treeRow].issue.ID
+	m.collapsed[id] = !m.collapsed[id]
+	m.tree = buildTuiTree(m.issues, m.collapsed)
+	m.selectID(id)
+}
+
+func (m *tuiModel) expand() {
+	if m.treeRow < len(m.tree) && m.collapsed[m.tree[m.treeRow].issue.ID] {
+		m.toggle()
+	}
+}
+
+// collapseOrParent collapses an expanded node, or moves to its parent.
+func (m *tuiModel) collapseOrParent() {
+	if m.treeRow >= len(m.tree) {
+		return
+	}
+	row := m.tree[m.treeRow]
+	if row.hasChildren && !m.collapsed[row.issue.ID] {
+		m.toggle()
+		return
+	}
+	for r := m.treeRow - 1; r >= 0; r-- {
+		if m.tree[r].depth < row.depth {
+			m.treeRow = r
+			return
+		}
+	}
+}
+
+// buildTuiBoard groups issues into board columns. Open, in progress and
+// blocked always have a column, closed has one when closed issues are shown,
+// and other statuses only when they have issues.
+func buildTuiBoard(issues []rpc.GraphIssueSummary, showClosed bool) []tuiColumn {
+	byStatus := map[string][]*rpc.GraphIssueSummary{}
+	for i := range issues {
+		byStatus[issues[i].Status] = append(byStatus[issues[i].Status], &issues[i])
+	}
+
+	var statuses []string
+	for _, status := range tuiStatusOrder {
+		s := string(status)
+		switch {
+		case status == types.StatusOpen, status == types.StatusInProgress, status == types.StatusBlocked:
+		case status == types.StatusClosed && showClosed:
+		case len(byStatus[s]) == 0:
+			continue
+		}
+		statuses = append(statuses, s)
+	}
+	var others []string
+	for status := range byStatus {
+		if !slices.Contains(tuiStatusOrder, types.Status(status)) {
+			others = append(others, status)
+		}
+	}
+	slices.Sort(others)
+	statuses = append(statuses, others...)
+
+	columns := make([]tuiColumn, 0, len(statuses))
+	for _, status := range statuses {
+		column := byStatus[status]
+		sortTuiIssues(column)
+		columns = append(columns, tuiColumn{status: status, issues: column})
+	}
+	return columns
+}
+
+// buildTuiTree lays out issues as a tree of parent-child relations, hiding
+// the children of collapsed issues. Issues whose parent is not loaded are
+// roots.
+func buildTuiTree(issues []rpc.GraphIssueSummary, collapsed map[string]bool) []tuiTreeRow {
+	byID := make(map[string]*rpc.GraphIssueSummary, len(issues))
+	for i := range issues {
+		byID[issues[i].ID] = &issues[i]
+	}
+	children := map[string][]*rpc.GraphIssueSummary{}
+	var roots []*rpc.GraphIssueSummary
+	for i := range issues {
+		issue := &issues[i]
+		parent := ""
+		for _, dep := range issue.Dependencies {
+			if dep.Type == string(types.DepParentChild) && byID[dep.DependsOnID] != nil {
+				parent = dep.DependsOnID
+				break
+			}
+		}
+		if parent == "" {
+			roots = append(roots, issue)
+		} else {
+			children[parent] = append(children[parent], issue)
+		}
+	}
+
+	var rows []tuiTreeRow
+	seen := map[string]bool{}
+	var walk func(issue *rpc.GraphIssueSummary, depth int)
+	walk = func(issue *rpc.GraphIssueSummary, depth int) {
+		if seen[issue.ID] {
+			return
+		}
+		seen[issue.ID] = true
+		kids := children[issue.ID]
+		rows = append(rows, tuiTreeRow{issue: issue, depth: depth, hasChildren: len(kids) > 0})
+		if collapsed[issue.ID] {
+			return
+		}
+		sortTuiIssues(kids)
+		for _, child := range kids {
+			walk(child, depth+1)
+		}
+	}
+	sortTuiIssues(roots)
+	for _, root := range roots {
+		walk(root, 0)
+	}
+	return rows
+}
+
+// sortTuiIssues orders issues by priority, then ID.
+func sortTuiIssues(issues []*rpc.GraphIssueSummary) {
+	slices.SortFunc(issues, func(a, b *rpc.GraphIssueSummary) int {
+		if a.Priority != b.Priority {
+			return a.Priority - b.Priority
+		}
+		return strings.Compare(a.ID, b.ID)
+	})
+}
+
+// Pane styles
+var (
+	tuiPaneStyle = lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(ui.ColorMuted)
+	tuiTitleStyle    = lipgloss.NewStyle().Bold(true).Foreground(ui.ColorAccent)
+	tuiSelectedStyle = lipgloss.NewStyle().Reverse(true)
+)
+
+// layoutDetail sizes the detail viewport and renders the selected issue
+// into it.
+func (m *tuiModel) layoutDetail() {
+	_, rightWidth := m.paneWidths()
+	detailHeight, _ := m.rightHeights()
+	m.detail.Width = max(rightWidth-2, 0)
+	m.detail.Height = max(detailHeight-3, 0)
+
+	switch {
+	case m.detailErr != nil:
+		m.detail.SetContent(ui.RenderFail(m.detailErr.Error()))
+	case m.details != nil:
+		m.detail.SetContent(ui.RenderMarkdownWidth(tuiDetailMarkdown(m.details), max(m.detail.Width-2, 20)))
+	default:
+		m.detail.SetContent("")
+	}
+	m.detail.GotoTop()
+}
+
+// bodyHeight is the height left for the panes after header and footer.
+func (m *tuiModel) bodyHeight() int {
+	return max(m.height-2, 0)
+}
+
+func (m *tuiModel) paneWidths() (left, right int) {
+	left = m.width * 55 / 100
+	return left, m.width - left
+}
+
+func (m *tuiModel) rightHeights() (detail, deps int) {
+	body := m.bodyHeight()
+	detail = body * 2 / 3
+	return detail, body - detail
+}
+
+func (m *tuiModel) View() string {
+	if m.width == 0 {
+		return "Loading..."
+	}
+	header := m.headerView()
+	footer := m.footerView()
+	if m.showHelp {
+		return lipgloss.JoinVertical(lipgloss.Left, header, m.pane("Help", tuiKeyHelp, m.width, m.bodyHeight()), footer)
+	}
+
+	leftWidth, rightWidth := m.paneWidths()
+	detailHeight, depsHeight := m.rightHeights()
+	var left string
+	if m.view == tuiTreeView {
+		left = m.pane("Tree", m.treeView(leftWidth-2, m.bodyHeight()-3), leftWidth, m.bodyHeight())
+	} else {
+		left = m.pane("Board", m.boardView(leftWidth-2, m.bodyHeight()-3), leftWidth, m.bodyHeight())
+	}
+	detailTitle := "Detail"
+	if m.selected != "" {
+		detailTitle += " " + m.selected
+	}
+	right := lipgloss.JoinVertical(lipgloss.Left,
+		m.pane(detailTitle, m.detail.View(), rightWidth, detailHeight),
+		m.pane(m.depsTitle(), m.depsView(), rightWidth, depsHeight),
+	)
+	body := lipgloss.JoinHorizontal(lipgloss.Top, left, right)
+	return lipgloss.JoinVertical(lipgloss.Left, header, body, footer)
+}
+
+// pane draws a bordered pane of the given outer size with a title line.
+func (m *tuiModel) pane(title, content string, width, height int) string {
+	innerWidth, innerHeight := max(width-2, 0), max(height-2, 0)
+	lines := strings.Split(content, "\n")
+	lines = append([]string{tuiTitleStyle.Render(title)}, lines...)
+	if len(lines) > innerHeight {
+		lines = lines[:innerHeight]
+	}
+	clip := lipgloss.NewStyle().MaxWidth(innerWidth)
+	for i, line := range lines {
+		lines[i] = clip.Render(line)
+	}
+	return tuiPaneStyle.Width(innerWidth).Height(innerHeight).Render(strings.Join(lines, "\n"))
+}
+
+func (m *tuiModel) headerView() string {
+	mode := "direct"
+	if m.waiter != nil {
+		mode = "daemon"
+		if m.live {
+			mode += " " + ui.RenderPass("live")
+		} else {
+			mode += " " + ui.RenderWarn("reconnecting")
+		}
+	}
+	tabs := []string{"Board", "Tree"}
+	tabs[m.view] = tuiSelectedStyle.Render(" " + tabs[m.view] + " ")
+	closed := ""
+	if m.showClosed {
+		closed = ", closed shown"
+	}
+	return fmt.Sprintf("%s  %s  %s  %s",
+		ui.RenderBold("beads"),
+		strings.Join(tabs, " "),
+		ui.RenderMuted(fmt.Sprintf("%d issues%s", len(m.issues), closed)),
+		ui.RenderMuted(mode))
+}
+
+func (m *tuiModel) footerView() string {
+	switch {
+	case m.prompt != tuiNoPrompt:
+		return m.input.View()
+	case m.err != nil:
+		return ui.RenderFail("Error: " + m.err.Error())
+	case m.message != "":
+		return ui.RenderPass(m.message)
+	default:
+		return ui.RenderMuted("tab view · c claim · x close · p priority · L label · m comment · D deps · a closed · r reload · ? help · q quit")
+	}
+}
+
+// boardView renders the kanban columns, scrolled so the cursor is visible.
+func (m *tuiModel) boardView(width, height int) string {
+	if len(m.columns) == 0 {
+		return ui.RenderMuted("No issues")
+	}
+	const minColumnWidth = 18
+	visible := clampInt(width/minColumnWidth, 1, len(m.columns))
+	first := clampInt(m.col-visible+1, 0, len(m.columns)-visible)
+	columnWidth := width / visible
+
+	var rendered []string
+	for c := first; c < first+visible; c++ {
+		column := m.columns[c]
+		header := ui.GetStatusStyle(column.status).Bold(true).
+			Render(fmt.Sprintf("%s (%d)", strings.ToUpper(strings.ReplaceAll(column.status, "_", " ")), len(column.issues)))
+		lines := []string{header}
+		rows := max(height-1, 1)
+		start := 0
+		if c == m.col && m.row >= rows {
+			start = m.row - rows + 1
+		}
+		for r := start; r < len(column.issues) && r < start+rows; r++ {
+			lines = append(lines, tuiIssueLine(column.issues[r], c == m.col && r == m.row))
+		}
+		// Clip each card to the column so long titles never wrap
+		clip := lipgloss.NewStyle().MaxWidth(max(columnWidth-1, 1))
+		for i, line := range lines {
+			lines[i] = clip.Render(line)
+		}
+		rendered = append(rendered, lipgloss.NewStyle().Width(columnWidth).Render(strings.Join(lines, "\n")))
+	}
+	return lipgloss.JoinHorizontal(lipgloss.Top, rendered...)
+}
+
+// treeView renders the epic/child tree, scrolled so the cursor is visible.
+func (m *tuiModel) treeView(width, height int) string {
+	if len(m.tree) == 0 {
+		return ui.RenderMuted("No issues")
+	}
+	rows := max(height, 1)
+	start := 0
+	if m.treeRow >= rows {
+		start = m.treeRow - rows + 1
+	}
+	var lines []string
+	for r := start; r < len(m.tree) && r < start+rows; r++ {
+		row := m.tree[r]
+		marker := "  "
+		if row.hasChildren {
+			marker = "▾ "
+			if m.collapsed[row.issue.ID] {
+				marker = "▸ "
+			}
+		}
+		prefix := strings.Repeat("  ", row.depth) + marker
+		lines = append(lines, prefix+ui.RenderStatusIcon(row.issue.Status)+" "+tuiIssueLine(row.issue, r == m.treeRow))
+	}
+	return lipgloss.NewStyle().MaxWidth(width).Render(strings.Join(lines, "\n"))
+}
+
+// tuiIssueLine formats an issue as "P1 bd-12 Title", highlighted when
+// selected.
+func tuiIssueLine(issue *rpc.GraphIssueSummary, selected bool) string {
+	if selected {
+		return tuiSelectedStyle.Render(fmt.Sprintf("P%d %s %s", issue.Priority, issue.ID, issue.Title))
+	}
+	if issue.Status == string(types.StatusClosed) {
+		return ui.StatusClosedStyle.Render(fmt.Sprintf("P%d %s %s", issue.Priority, issue.ID, issue.Title))
+	}
+	return fmt.Sprintf("%s %s %s", ui.RenderPriorityCompact(issue.Priority), ui.RenderID(issue.ID), issue.Title)
+}
+
+func (m *tuiModel) depsTitle() string {
+	if m.reverseDeps {
+		return "Dependents"
+	}
+	return "Dependencies"
+}
+
+// depsView renders the dependency tree of the selected issue.
+func (m *tuiModel) depsView() string {
+	if m.details == nil {
+		return ""
+	}
+	if len(m.deps) <= 1 {
+		return ui.RenderMuted("None")
+	}
+	direction := "down"
+	if m.reverseDeps {
+		direction = "up"
+	}
+	var buf bytes.Buffer
+	writeTree(&buf, m.deps, tuiDepDepth, direction)
+	return strings.TrimRight(buf.String(), "\n")
+}
+
+// tuiDetailMarkdown formats an issue for the detail pane.
+func tuiDetailMarkdown(d *types.IssueDetails) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n\n", d.Title)
+	fmt.Fprintf(&b, "**Status:** %s · **Priority:** P%d · **Type:** %s", d.Status, d.Priority, d.IssueType)
+	if d.Assignee != "" {
+		fmt.Fprintf(&b, " · **Assignee:** %s", d.Assignee)
+	}
+	b.WriteString("\n\n")
+	if len(d.Labels) > 0 {
+		fmt.Fprintf(&b, "**Labels:** %s\n\n", strings.Join(d.Labels, ", "))
+	}
+	if d.CloseReason != "" {
+		fmt.Fprintf(&b, "**Close reason:** %s\n\n", d.CloseReason)
+	}
+	for _, section := range []struct{ title, text string }{
+		{"Description", d.Description},
+		{"Design", d.Design},
+		{"Acceptance Criteria", d.AcceptanceCriteria},
+		{"Notes", d.Notes},
+	} {
+		if strings.TrimSpace(section.text) != "" {
+			fmt.Fprintf(&b, "## %s\n\n%s\n\n", section.title, section.text)
+		}
+	}
+	if len(d.Comments) > 0 {
+		fmt.Fprintf(&b, "## Comments (%d)\n\n", len(d.Comments))
+		for _, c := range d.Comments {
+			fmt.Fprintf(&b, "**%s** · %s\n\n%s\n\n", c.Author, c.CreatedAt.Local().Format("2006-01-02 15:04"), c.Text)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,289 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/cursor"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/steveyegge/beads/internal/rpc"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func tuiTestIssues() []rpc.GraphIssueSummary {
+	return []rpc.GraphIssueSummary{
+		{ID: "bd-1", Title: "Epic", Status: "open", Priority: 1, IssueType: "epic"},
+		{ID: "bd-2", Title: "Child A", Status: "in_progress", Priority: 2, IssueType: "task",
+			Dependencies: []rpc.GraphDependency{{DependsOnID: "bd-1", Type: "parent-child"}}},
+		{ID: "bd-3", Title: "Child B", Status: "open", Priority: 0, IssueType: "task",
+			Dependencies: []rpc.GraphDependency{{DependsOnID: "bd-1", Type: "parent-child"}, {DependsOnID: "bd-4", Type: "blocks"}}},
+		{ID: "bd-4", Title: "Loose", Status: "review", Priority: 2, IssueType: "bug"},
+	}
+}
+
+func TestBuildTuiBoard(t *testing.T) {
+	columns := buildTuiBoard(tuiTestIssues(), false)
+	var statuses []string
+	for _, c := range columns {
+		statuses = append(statuses, c.status)
+	}
+	// open, in_progress and blocked are always shown; review because it has issues
+	if got := strings.Join(statuses, ","); got != "open,in_progress,review,blocked" {
+		t.Fatalf("columns = %s", got)
+	}
+	if columns[0].issues[0].ID != "bd-3" || columns[0].issues[1].ID != "bd-1" {
+		t.Errorf("open column not sorted by priority: %s, %s", columns[0].issues[0].ID, columns[0].issues[1].ID)
+	}
+
+	columns = buildTuiBoard(tuiTestIssues(), true)
+	if last := columns[len(columns)-1].status; last != "closed" {
+		t.Errorf("last column with closed shown = %s, want closed", last)
+	}
+
+	columns = buildTuiBoard([]rpc.GraphIssueSummary{{ID: "bd-9", Status: "qa"}}, false)
+	if last := columns[len(columns)-1]; last.status != "qa" || len(last.issues) != 1 {
+		t.Errorf("custom status column = %+v", last)
+	}
+}
+
+func TestBuildTuiTree(t *testing.T) {
+	rows := buildTuiTree(tuiTestIssues(), map[string]bool{})
+	var got []string
+	for _, r := range rows {
+		got = append(got, strings.Repeat(">", r.depth)+r.issue.ID)
+	}
+	// Blocking dependencies do not nest; children sort by priority
+	if s := strings.Join(got, " "); s != "bd-1 >bd-3 >bd-2 bd-4" {
+		t.Fatalf("tree = %s", s)
+	}
+	if !rows[0].hasChildren || rows[1].hasChildren {
+		t.Errorf("hasChildren wrong: %+v", rows[:2])
+	}
+
+	rows = buildTuiTree(tuiTestIssues(), map[string]bool{"bd-1": true})
+	if len(rows) != 2 || rows[0].issue.ID != "bd-1" || rows[1].issue.ID != "bd-4" {
+		t.Errorf("collapsed tree has %d rows", len(rows))
+	}
+}
+
+func TestTuiDetailMarkdown(t *testing.T) {
+	d := &types.IssueDetails{
+		Issue: types.Issue{
+			ID: "bd-1", Title: "Fix login", Status: types.StatusOpen, Priority: 1,
+			IssueType: types.TypeBug, Assignee: "alice", Description: "It breaks.",
+		},
+		Labels:   []string{"auth"},
+		Comments: []*types.Comment{{Author: "bob", Text: "Seen it too"}},
+	}
+	md := tuiDetailMarkdown(d)
+	for _, want := range []string{"# Fix login", "**Priority:** P1", "**Assignee:** alice", "**Labels:** auth", "## Description\n\nIt breaks.", "## Comments (1)", "Seen it too"} {
+		if !strings.Contains(md, want) {
+			t.Errorf("markdown missing %q:\n%s", want, md)
+		}
+	}
+	if strings.Contains(md, "## Design") {
+		t.Errorf("empty sections should be omitted:\n%s", md)
+	}
+}
+
+// tuiFakeExecutor answers issue and detail loads and records mutations.
+type tuiFakeExecutor struct {
+	issues []rpc.GraphIssueSummary
+	calls  []string
+	args   []interface{}
+}
+
+func (f *tuiFakeExecutor) Execute(operation string, args interface{}) (*rpc.Response, error) {
+	f.calls = append(f.calls, operation)
+	f.args = append(f.args, args)
+	var data interface{}
+	switch operation {
+	case rpc.OpGetGraphData:
+		data = rpc.GetGraphDataResponse{Issues: f.issues}
+	case rpc.OpShow:
+		id := args.(*rpc.ShowArgs).ID
+		data = &types.IssueDetails{Issue: types.Issue{ID: id, Title: "Title of " + id}}
+	case rpc.OpDepTree:
+		id := args.(*rpc.DepTreeArgs).ID
+		data = []*types.TreeNode{{Issue: types.Issue{ID: id}}}
+	default:
+		data = struct{}{}
+	}
+	raw, _ := json.Marshal(data)
+	return &rpc.Response{Success: true, Data: raw}, nil
+}
+
+// tuiRunMsg delivers msg and feeds the resulting commands back into the model,
+// ignoring batches that would block (there are none without a waiter).
+func tuiRunMsg(t *testing.T, m *tuiModel, msg tea.Msg) {
+	t.Helper()
+	queue := []tea.Msg{msg}
+	for len(queue) > 0 {
+		next := queue[0]
+		queue = queue[1:]
+		_, cmd := m.Update(next)
+		queue = append(queue, tuiExpand(cmd)...)
+	}
+}
+
+func tuiExpand(cmd tea.Cmd) []tea.Msg {
+	if cmd == nil {
+		return nil
+	}
+	msg := cmd()
+	if batch, ok := msg.(tea.BatchMsg); ok {
+		var msgs []tea.Msg
+		for _, c := range batch {
+			msgs = append(msgs, tuiExpand(c)...)
+		}
+		return msgs
+	}
+	if _, ok := msg.(tea.QuitMsg); ok {
+		return nil
+	}
+	// Skip cursor blink and other internal messages of bubbles components
+	switch msg.(type) {
+	case tuiIssuesMsg, tuiDetailMsg, tuiActionMsg:
+		return []tea.Msg{msg}
+	}
+	return nil
+}
+
+func tuiKey(s string) tea.KeyMsg {
+	switch s {
+	case "enter":
+		return tea.KeyMsg{Type: tea.KeyEnter}
+	case "tab":
+		return tea.KeyMsg{Type: tea.KeyTab}
+	}
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)}
+}
+
+func newTestTuiModel(t *testing.T) (*tuiModel, *tuiFakeExecutor) {
+	t.Helper()
+	exec := &tuiFakeExecutor{issues: tuiTestIssues()}
+	m := newTuiModel(exec, nil)
+	m.readonly = false
+	m.input.Cursor.SetMode(cursor.CursorStatic) // no blink ticks to wait for
+	tuiRunMsg(t, m, tea.WindowSizeMsg{Width: 120, Height: 40})
+	for _, msg := range tuiExpand(m.Init()) {
+		tuiRunMsg(t, m, msg)
+	}
+	return m, exec
+}
+
+func TestTuiModel_Navigation(t *testing.T) {
+	m, _ := newTestTuiModel(t)
+	if m.selected != "bd-3" || m.details == nil || m.details.ID != "bd-3" {
+		t.Fatalf("initial selection = %q, details = %+v", m.selected, m.details)
+	}
+
+	tuiRunMsg(t, m, tuiKey("l"))
+	if m.selected != "bd-2" {
+		t.Errorf("after moving right selected = %q, want bd-2", m.selected)
+	}
+
+	// Switching to the tree keeps the selected issue
+	tuiRunMsg(t, m, tuiKey("tab"))
+	if m.view != tuiTreeView || m.current().ID != "bd-2" {
+		t.Fatalf("tree selection = %s", m.current().ID)
+	}
+	tuiRunMsg(t, m, tuiKey("h")) // leaf: move to parent
+	if m.current().ID != "bd-1" {
+		t.Fatalf("after h selected = %s, want bd-1", m.current().ID)
+	}
+	tuiRunMsg(t, m, tuiKey("h")) // expanded: collapse
+	if len(m.tree) != 2 {
+		t.Errorf("collapsed tree has %d rows, want 2", len(m.tree))
+	}
+	tuiRunMsg(t, m, tuiKey("enter"))
+	if len(m.tree) != 4 {
+		t.Errorf("expanded tree has %d rows, want 4", len(m.tree))
+	}
+
+	if view := m.View(); !strings.Contains(view, "Tree") || !strings.Contains(view, "Child A") {
+		t.Errorf("view missing tree content:\n%s", view)
+	}
+}
+
+func TestTuiModel_Actions(t *testing.T) {
+	m, exec := newTestTuiModel(t)
+
+	lastArgs := func(op string) interface{} {
+		for i := len(exec.calls) - 1; i >= 0; i-- {
+			if exec.calls[i] == op {
+				return exec.args[i]
+			}
+		}
+		t.Fatalf("no %s call in %v", op, exec.calls)
+		return nil
+	}
+	typeLine := func(prompt, text string) {
+		tuiRunMsg(t, m, tuiKey(prompt))
+		if m.prompt == tuiNoPrompt {
+			t.Fatalf("%s did not open a prompt", prompt)
+		}
+		m.input.SetValue(text)
+		tuiRunMsg(t, m, tuiKey("enter"))
+	}
+
+	tuiRunMsg(t, m, tuiKey("c"))
+	if args := lastArgs(rpc.OpUpdate).(*rpc.UpdateArgs); args.ID != "bd-3" || !args.Claim {
+		t.Errorf("claim args = %+v", args)
+	}
+	if m.message != "Claimed bd-3" {
+		t.Errorf("message = %q", m.message)
+	}
+
+	typeLine("p", "0")
+	if args := lastArgs(rpc.OpUpdate).(*rpc.UpdateArgs); args.Priority == nil || *args.Priority != 0 {
+		t.Errorf("priority args = %+v", args)
+	}
+	calls := len(exec.calls)
+	typeLine("p", "7")
+	if m.err == nil || len(exec.calls) != calls {
+		t.Errorf("invalid priority should be rejected without a call")
+	}
+
+	typeLine("L", "urgent")
+	if args := lastArgs(rpc.OpLabelAdd).(*rpc.LabelAddArgs); args.Label != "urgent" {
+		t.Errorf("label add args = %+v", args)
+	}
+	typeLine("L", "-urgent")
+	if args := lastArgs(rpc.OpLabelRemove).(*rpc.LabelRemoveArgs); args.Label != "urgent" {
+		t.Errorf("label remove args = %+v", args)
+	}
+
+	typeLine("m", "looks good")
+	if args := lastArgs(rpc.OpCommentAdd).(*rpc.CommentAddArgs); args.Text != "looks good" {
+		t.Errorf("comment args = %+v", args)
+	}
+
+	typeLine("x", "")
+	if args := lastArgs(rpc.OpClose).(*rpc.CloseArgs); args.ID != "bd-3" || args.Reason != "Completed" {
+		t.Errorf("close args = %+v", args)
+	}
+
+	// Escape cancels a prompt without running anything
+	calls = len(exec.calls)
+	tuiRunMsg(t, m, tuiKey("x"))
+	tuiRunMsg(t, m, tea.KeyMsg{Type: tea.KeyEsc})
+	if m.prompt != tuiNoPrompt || len(exec.calls) != calls {
+		t.Errorf("esc should cancel the prompt")
+	}
+}
+
+func TestTuiModel_Readonly(t *testing.T) {
+	m, exec := newTestTuiModel(t)
+	m.readonly = true
+	calls := len(exec.calls)
+	tuiRunMsg(t, m, tuiKey("c"))
+	tuiRunMsg(t, m, tuiKey("x"))
+	if len(exec.calls) != calls || m.prompt != tuiNoPrompt {
+		t.Errorf("read-only mode should block actions, calls: %v", exec.calls[calls:])
+	}
+	if m.err == nil {
+		t.Errorf("expected read-only error")
+	}
+}
@@ -165,6 +165,11 @@ create, update, show, or close operation).`,
 				updates["defer_until"] = t
 			}
 		}
+		if cmd.Flags().Changed("recur") {
+			recur := parseRecurFlag(cmd) // Empty string clears the rule
+			updates["recurrence"] = recur
+		}
+		fieldChanges := parseFieldChanges(cmd)
 		// Ephemeral/persistent flags
 		// Note: storage layer uses "wisp" field name, maps to "ephemeral" column
 		ephemeralChanged := cmd.Flags().Changed("ephemeral")
@@ -181,14 +186,29 @@ create, update, show, or close operation).`,
 
 		// Get claim flag
 		claimFlag, _ := cmd.Flags().GetBool("claim")
+		lease, _ := cmd.Flags().GetDuration("lease")
+		if cmd.Flags().Changed("lease") {
+			if !claimFlag {
+				FatalErrorRespectJSON("--lease requires --claim")
+			}
+			if lease <= 0 {
+				FatalErrorRespectJSON("--lease must be a positive duration (e.g. 30m)")
+			}
+		}
 
-		if len(updates) == 0 && !claimFlag {
+		if len(updates) == 0 && len(fieldChanges) == 0 && !claimFlag {
 			fmt.Println("No updates specified")
 			return
 		}
 
 		ctx := rootCtx
 
+		// --force lets status changes past the workflow rules; each one is audited
+		force, _ := cmd.Flags().GetBool("force")
+		if force {
+			ctx = validation.WithWorkflowForce(ctx)
+		}
+
 		// Resolve partial IDs first, checking for cross-rig routing
 		var resolvedIDs []string
 		var routedArgs []string // IDs that need cross-repo routing (bypass daemon)
@@ -291,6 +311,10 @@ create, update, show, or close operation).`,
 					empty := ""
 					updateArgs.DeferUntil = &empty
 				}
+				if recur, ok := updates["recurrence"].(string); ok {
+					updateArgs.Recurrence = &recur
+				}
+				updateArgs.CustomFields = fieldChanges
 				// Ephemeral/persistent
 				if wisp, ok := updates["wisp"].(bool); ok {
 					updateArgs.Ephemeral = &wisp
@@ -298,6 +322,10 @@ create, update, show, or close operation).`,
 
 				// Set claim flag for atomic claim operation
 				updateArgs.Claim = claimFlag
+				if lease > 0 {
+					updateArgs.Lease = lease.String()
+				}
+				updateArgs.Force = force
 
 				resp, err := daemonClient.Update(updateArgs)
 				if err != nil {
@@ -353,9 +381,10 @@ create, update, show, or close operation).`,
 
 				// Handle claim operation atomically
 				if claimFlag {
-					// Only fail if task is actively being worked on (has assignee AND is in_progress)
+					// Only fail if task is actively being worked on (has assignee AND is in_progress
+					// AND any lease is still live)
 					// Allow claiming open tasks even with stale assignees
-					if issue.Assignee != "" && issue.Status == "in_progress" {
+					if issue.IsClaimed(time.Now()) {
 						fmt.Fprintf(os.Stderr, "Error claiming %s: already claimed by %s\n", id, issue.Assignee)
 						result.Close()
 						continue
@@ -363,6 +392,8 @@ create, update, show, or close operation).`,
 					claimUpdates := map[string]interface{}{
 						"assignee": actor,
 						"status":   "in_progress",
+						// Re-claiming replaces (or clears) any earlier lease
+						"lease_expires_at": leaseDeadline(lease),
 					}
 					if err := issueStore.UpdateIssue(ctx, result.ResolvedID, claimUpdates, actor); err != nil {
 						fmt.Fprintf(os.Stderr, "Error claiming %s: %v\n", id, err)
@@ -378,6 +409,11 @@ create, update, show, or close operation).`,
 						regularUpdates[k] = v
 					}
 				}
+				if err := validation.CustomFieldUpdates(issue, fieldChanges, regularUpdates); err != nil {
+					fmt.Fprintf(os.Stderr, "Error updating %s: %v\n", id, err)
+					result.Close()
+					continue
+				}
 				if len(regularUpdates) > 0 {
 					if err := issueStore.UpdateIssue(ctx, result.ResolvedID, regularUpdates, actor); err != nil {
 						fmt.Fprintf(os.Stderr, "Error updating %s: %v\n", id, err)
@@ -467,9 +503,10 @@ create, update, show, or close operation).`,
 
 			// Handle claim operation atomically
 			if claimFlag {
-				// Only fail if task is actively being worked on (has assignee AND is in_progress)
+				// Only fail if task is actively being worked on (has assignee AND is in_progress
+				// AND any lease is still live)
 				// Allow claiming open tasks even with stale assignees
-				if issue.Assignee != "" && issue.Status == "in_progress" {
+				if issue.IsClaimed(time.Now()) {
 					fmt.Fprintf(os.Stderr, "Error claiming %s: already claimed by %s\n", id, issue.Assignee)
 					result.Close()
 					continue
@@ -478,6 +515,8 @@ create, update, show, or close operation).`,
 				claimUpdates := map[string]interface{}{
 					"assignee": actor,
 					"status":   "in_progress",
+					// Re-claiming replaces (or clears) any earlier lease
+					"lease_expires_at": leaseDeadline(lease),
 				}
 				if err := issueStore.UpdateIssue(ctx, result.ResolvedID, claimUpdates, actor); err != nil {
 					fmt.Fprintf(os.Stderr, "Error claiming %s: %v\n", id, err)
@@ -493,6 +532,11 @@ create, update, show, or close operation).`,
 					regularUpdates[k] = v
 				}
 			}
+			if err := validation.CustomFieldUpdates(issue, fieldChanges, regularUpdates); err != nil {
+				fmt.Fprintf(os.Stderr, "Error updating %s: %v\n", id, err)
+				result.Close()
+				continue
+			}
 			if len(regularUpdates) > 0 {
 				if err := issueStore.UpdateIssue(ctx, result.ResolvedID, regularUpdates, actor); err != nil {
 					fmt.Fprintf(os.Stderr, "Error updating %s: %v\n", id, err)
@@ -619,6 +663,8 @@ func init() {
 	updateCmd.Flags().StringSlice("set-labels", nil, "Set labels, replacing all existing (repeatable)")
 	updateCmd.Flags().String("parent", "", "New parent issue ID (reparents the issue, use empty string to remove parent)")
 	updateCmd.Flags().Bool("claim", false, "Atomically claim the issue (sets assignee to you, status to in_progress; fails if already claimed)")
+	updateCmd.Flags().Duration("lease", 0, "With --claim: release the claim after this long unless renewed with bd heartbeat (e.g. 30m)")
+	updateCmd.Flags().BoolP("force", "f", false, "Allow a status change the configured workflow rejects (recorded in the audit trail)")
 	updateCmd.Flags().String("session", "", "Claude Code session ID for status=closed (or set CLAUDE_SESSION_ID env var)")
 	// Time-based scheduling flags (GH#820)
 	// Examples:
@@ -631,6 +677,8 @@ func init() {
 	//   --defer=""          Clear defer (show in bd ready immediately)
 	updateCmd.Flags().String("due", "", "Due date/time (empty to clear). Formats: +6h, +1d, +2w, tomorrow, next monday, 2025-01-15")
 	updateCmd.Flags().String("defer", "", "Defer until date (empty to clear). Issue hidden from bd ready until then")
+	updateCmd.Flags().String("recur", "", `Recurrence rule (empty to clear), e.g. "every monday 09:00" or "0 9 * * 1-5"`)
+	updateCmd.Flags().StringArray("field", nil, "Set a custom field: name=value, empty value clears (repeatable, see 'bd fields')")
 	// Gate fields (bd-z6kw)
 	updateCmd.Flags().String("await-id", "", "Set gate await_id (e.g., GitHub run ID for gh:run gates)")
 	// Ephemeral/persistent flags
@@ -0,0 +1,368 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/beads"
+	"github.com/steveyegge/beads/internal/query"
+	"github.com/steveyegge/beads/internal/rpc"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+	"github.com/steveyegge/beads/internal/validation"
+	"github.com/steveyegge/beads/internal/views"
+)
+
+var viewsCmd = &cobra.Command{
+	Use:     "views",
+	GroupID: "issues",
+	Short:   "Manage saved views (named list queries)",
+	Long: `Manage saved views: named filter expressions with their own sort order,
+columns, output format and limit.
+
+Views are stored in the config table and in .beads/views.jsonl, which is
+committed with the rest of .beads so every clone (and every agent) shares
+them. Use a view with:
+
+  bd list --view <name>     # List matching issues
+  bd ready --view <name>    # Ready work matching the view
+  bd prime --view <name>    # Add the view's work queue to the prime context
+
+Set prime.view in config.yaml (or BD_PRIME_VIEW) to give each agent role
+its own work queue.
+
+Examples:
+  bd views save triage 'status:open AND priority<=1 AND NOT has:assignee' --sort created
+  bd views save frontend label:frontend --columns id,priority,title,assignee
+  bd views save mine 'assignee:alice' --format long --limit 0
+  bd views list
+  bd views delete triage`,
+}
+
+var viewSaveCmd = &cobra.Command{
+	Use:   "save <name> <expression...>",
+	Short: "Save a view",
+	Long: `Save a view. The remaining arguments are joined into a filter expression
+(the --where syntax of bd list, see 'bd list --help'). Saving a view with an
+existing name replaces it.`,
+	Args: cobra.MinimumNArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		CheckReadonly("views save")
+		sortBy, _ := cmd.Flags().GetString("sort")
+		reverse, _ := cmd.Flags().GetBool("reverse")
+		columns, _ := cmd.Flags().GetStringSlice("columns")
+		format, _ := cmd.Flags().GetString("format")
+		description, _ := cmd.Flags().GetString("description")
+
+		where := strings.Join(args[1:], " ")
+		q, err := query.Parse(where)
+		if err != nil {
+			FatalErrorRespectJSON("invalid expression: %v", err)
+		}
+		if err := validateViewColumns(columns); err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		v := &views.View{
+			Name:        args[0],
+			Description: description,
+			Where:       q.String(),
+			Sort:        sortBy,
+			Reverse:     reverse,
+			Columns:     columns,
+			Format:      format,
+		}
+		if cmd.Flags().Changed("limit") {
+			limit, _ := cmd.Flags().GetInt("limit")
+			v.Limit = &limit
+		}
+
+		ctx := rootCtx
+		if err := ensureDirectMode("views save requires direct database access"); err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		if err := views.Save(ctx, store, beads.FindBeadsDir(), v); err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+
+		if jsonOutput {
+			outputJSON(v)
+			return
+		}
+		fmt.Printf("%s Saved view %s\n", ui.RenderPass("✓"), v.Name)
+		fmt.Printf("  Use: bd list --view %s\n", v.Name)
+	},
+}
+
+var viewListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List saved views",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := rootCtx
+		if err := ensureDirectMode("views list requires direct database access"); err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		list, err := views.List(ctx, store, beads.FindBeadsDir())
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+
+		if jsonOutput {
+			if list == nil {
+				list = []*views.View{}
+			}
+			outputJSON(list)
+			return
+		}
+		if len(list) == 0 {
+			fmt.Println("No saved views (see 'bd views save --help')")
+			return
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		for _, v := range list {
+			_, _ = fmt.Fprintf(w, "%s\t%s\t%s\n", ui.RenderAccent(v.Name), v.Where, describeViewOptions(v))
+		}
+		_ = w.Flush()
+	},
+}
+
+var viewShowCmd = &cobra.Command{
+	Use:   "show <name>",
+	Short: "Show a saved view",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		v := loadView(rootCtx, args[0])
+		if jsonOutput {
+			outputJSON(v)
+			return
+		}
+		fmt.Printf("%s\n", ui.RenderAccent(v.Name))
+		if v.Description != "" {
+			fmt.Printf("  %s\n", v.Description)
+		}
+		fmt.Printf("  Where:   %s\n", v.Where)
+		if opts := describeViewOptions(v); opts != "" {
+			fmt.Printf("  Options: %s\n", opts)
+		}
+		fmt.Printf("  Updated: %s\n", v.UpdatedAt.Local().Format("2006-01-02 15:04"))
+	},
+}
+
+var viewDeleteCmd = &cobra.Command{
+	Use:   "delete <name>",
+	Short: "Delete a saved view",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		CheckReadonly("views delete")
+		ctx := rootCtx
+		if err := ensureDirectMode("views delete requires direct database access"); err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		if err := views.Delete(ctx, store, beads.FindBeadsDir(), args[0]); err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		if jsonOutput {
+			outputJSON(map[string]interface{}{"name": args[0], "deleted": true})
+			return
+		}
+		fmt.Printf("%s Deleted view %s\n", ui.RenderPass("✓"), args[0])
+	},
+}
+
+// daemonViewConfig reads view definitions through the daemon.
+type daemonViewConfig struct {
+	client *rpc.Client
+}
+
+func (d daemonViewConfig) GetConfig(_ context.Context, key string) (string, error) {
+	resp, err := d.client.GetConfig(&rpc.GetConfigArgs{Key: key})
+	if err != nil {
+		return "", err
+	}
+	return resp.Value, nil
+}
+
+// loadView returns the named view, exiting if it doesn't exist.
+func loadView(ctx context.Context, name string) *views.View {
+	var cfg views.Config
+	if daemonClient != nil {
+		cfg = daemonViewConfig{daemonClient}
+	} else {
+		if err := ensureStoreActive(); err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		cfg = store
+	}
+	v, err := views.Get(ctx, cfg, beads.FindBeadsDir(), name)
+	if err != nil {
+		FatalErrorRespectJSON("%v", err)
+	}
+	return v
+}
+
+// describeViewOptions summarizes a view's sort, columns, format and limit.
+func describeViewOptions(v *views.View) string {
+	var parts []string
+	if v.Sort != "" {
+		sort := "sort=" + v.Sort
+		if v.Reverse {
+			sort += " (reversed)"
+		}
+		parts = append(parts, sort)
+	}
+	if len(v.Columns) > 0 {
+		parts = append(parts, "columns="+strings.Join(v.Columns, ","))
+	}
+	if v.Format != "" {
+		parts = append(parts, "format="+v.Format)
+	}
+	if v.Limit != nil {
+		parts = append(parts, "limit="+strconv.Itoa(*v.Limit))
+	}
+	return strings.Join(parts, " ")
+}
+
+// applyView sets the flags of cmd that the view configures, unless they were
+// given on the command line, so explicit flags always win. The view's
+// expression is combined with --where. Flags that cmd doesn't have are
+// skipped.
+func applyView(cmd *cobra.Command, v *views.View) {
+	flags := cmd.Flags()
+	set := func(name, value string) {
+		if flags.Lookup(name) != nil && !flags.Changed(name) {
+			_ = flags.Set(name, value)
+		}
+	}
+
+	if where, _ := flags.GetString("where"); where != "" && v.Where != "" {
+		_ = flags.Set("where", "("+v.Where+") AND ("+where+")")
+	} else if v.Where != "" {
+		_ = flags.Set("where", v.Where)
+	}
+	if v.Sort != "" {
+		set("sort", v.Sort)
+	}
+	if v.Reverse {
+		set("reverse", "true")
+	}
+	if v.Limit != nil {
+		set("limit", strconv.Itoa(*v.Limit))
+	}
+
+	// Output settings only apply when no output flag was given
+	for _, name := range []string{"columns", "format", "long", "pretty", "tree", "json"} {
+		if flags.Changed(name) {
+			return
+		}
+	}
+	if len(v.Columns) > 0 {
+		set("columns", strings.Join(v.Columns, ","))
+	}
+	switch v.Format {
+	case "":
+	case "json":
+		jsonOutput = true
+	case "long", "pretty":
+		set(v.Format, "true")
+	default:
+		set("format", v.Format)
+	}
+}
+
+// viewColumns are the built-in columns of --columns tables. Custom fields
+// are also accepted.
+var viewColumns = []string{"id", "title", "status", "priority", "type", "assignee", "owner", "labels", "created", "updated", "due"}
+
+func validateViewColumns(columns []string) error {
+	for _, c := range columns {
+		if !isViewColumn(c) {
+			return fmt.Errorf("unknown column %q (valid: %s, or a custom field)", c, strings.Join(viewColumns, ", "))
+		}
+	}
+	return nil
+}
+
+func isViewColumn(name string) bool {
+	for _, c := range viewColumns {
+		if c == name {
+			return true
+		}
+	}
+	schema, err := validation.LoadFieldSchema()
+	return err == nil && schema.Field(name) != nil
+}
+
+// formatColumns renders issues as an aligned table of the given columns.
+// labels supplies issue labels when they aren't populated on the issues.
+func formatColumns(buf *strings.Builder, issues []*types.Issue, columns []string, labels map[string][]string) {
+	w := tabwriter.NewWriter(buf, 0, 0, 2, ' ', 0)
+	header := make([]string, len(columns))
+	for i, c := range columns {
+		header[i] = strings.ToUpper(c)
+	}
+	_, _ = fmt.Fprintln(w, strings.Join(header, "\t"))
+	for _, issue := range issues {
+		cells := make([]string, len(columns))
+		for i, c := range columns {
+			cells[i] = columnValue(issue, c, labels)
+		}
+		_, _ = fmt.Fprintln(w, strings.Join(cells, "\t"))
+	}
+	_ = w.Flush()
+}
+
+func columnValue(issue *types.Issue, column string, labels map[string][]string) string {
+	const date = "2006-01-02"
+	switch column {
+	case "id":
+		return issue.ID
+	case "title":
+		return issue.Title
+	case "status":
+		return string(issue.Status)
+	case "priority":
+		return fmt.Sprintf("P%d", issue.Priority)
+	case "type":
+		return string(issue.IssueType)
+	case "assignee":
+		return issue.Assignee
+	case "owner":
+		return issue.Owner
+	case "labels":
+		issueLabels := issue.Labels
+		if len(issueLabels) == 0 {
+			issueLabels = labels[issue.ID]
+		}
+		return strings.Join(issueLabels, ",")
+	case "created":
+		return issue.CreatedAt.Local().Format(date)
+	case "updated":
+		return issue.UpdatedAt.Local().Format(date)
+	case "due":
+		if issue.DueAt == nil {
+			return ""
+		}
+		return issue.DueAt.Local().Format(date)
+	}
+	return issue.CustomFields[column]
+}
+
+func init() {
+	viewSaveCmd.Flags().String("sort", "", "Sort field (bd list --sort) or ready sort policy (hybrid, priority, oldest)")
+	viewSaveCmd.Flags().BoolP("reverse", "r", false, "Reverse sort order")
+	viewSaveCmd.Flags().StringSlice("columns", nil, "Table columns: "+strings.Join(viewColumns, ",")+", or custom fields")
+	viewSaveCmd.Flags().String("format", "", "Output format: long, pretty, json, or a bd list --format value")
+	viewSaveCmd.Flags().IntP("limit", "n", 0, "Limit results (0 for unlimited; default: the command's own limit)")
+	viewSaveCmd.Flags().String("description", "", "What the view is for")
+
+	viewsCmd.AddCommand(viewSaveCmd)
+	viewsCmd.AddCommand(viewListCmd)
+	viewsCmd.AddCommand(viewShowCmd)
+	viewsCmd.AddCommand(viewDeleteCmd)
+	rootCmd.AddCommand(viewsCmd)
+}
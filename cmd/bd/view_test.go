@@ -0,0 +1,152 @@
+package main
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/views"
+)
+
+func newViewTestCmd() *cobra.Command {
+	cmd := &cobra.Command{Use: "list"}
+	cmd.Flags().String("where", "", "")
+	cmd.Flags().String("sort", "", "")
+	cmd.Flags().Bool("reverse", false, "")
+	cmd.Flags().Int("limit", 50, "")
+	cmd.Flags().StringSlice("columns", nil, "")
+	cmd.Flags().String("format", "", "")
+	cmd.Flags().Bool("long", false, "")
+	return cmd
+}
+
+func TestApplyView(t *testing.T) {
+	limit := 0
+	v := &views.View{
+		Name:    "triage",
+		Where:   "status:open priority<=1",
+		Sort:    "created",
+		Reverse: true,
+		Columns: []string{"id", "title"},
+		Format:  "long",
+		Limit:   &limit,
+	}
+
+	t.Run("view settings", func(t *testing.T) {
+		cmd := newViewTestCmd()
+		if err := cmd.ParseFlags(nil); err != nil {
+			t.Fatal(err)
+		}
+		applyView(cmd, v)
+		where, _ := cmd.Flags().GetString("where")
+		sortBy, _ := cmd.Flags().GetString("sort")
+		reverse, _ := cmd.Flags().GetBool("reverse")
+		gotLimit, _ := cmd.Flags().GetInt("limit")
+		columns, _ := cmd.Flags().GetStringSlice("columns")
+		long, _ := cmd.Flags().GetBool("long")
+		if where != v.Where || sortBy != "created" || !reverse || gotLimit != 0 || len(columns) != 2 || !long {
+			t.Errorf("applyView: where=%q sort=%q reverse=%v limit=%d columns=%v long=%v", where, sortBy, reverse, gotLimit, columns, long)
+		}
+	})
+
+	t.Run("explicit flags win", func(t *testing.T) {
+		cmd := newViewTestCmd()
+		if err := cmd.ParseFlags([]string{"--where", "label:ui", "--sort", "priority", "--limit", "5", "--format", "dot"}); err != nil {
+			t.Fatal(err)
+		}
+		applyView(cmd, v)
+		where, _ := cmd.Flags().GetString("where")
+		sortBy, _ := cmd.Flags().GetString("sort")
+		gotLimit, _ := cmd.Flags().GetInt("limit")
+		columns, _ := cmd.Flags().GetStringSlice("columns")
+		long, _ := cmd.Flags().GetBool("long")
+		if where != "(status:open priority<=1) AND (label:ui)" {
+			t.Errorf("where = %q, want the view and flag expressions combined", where)
+		}
+		if sortBy != "priority" || gotLimit != 5 {
+			t.Errorf("sort = %q, limit = %d, want the explicit flags", sortBy, gotLimit)
+		}
+		if len(columns) != 0 || long {
+			t.Errorf("columns = %v, long = %v, want the explicit --format to suppress the view's output settings", columns, long)
+		}
+	})
+}
+
+func TestFormatColumns(t *testing.T) {
+	issues := []*types.Issue{
+		{ID: "bd-1", Title: "Login timeout", Priority: 1, Status: types.StatusOpen, CustomFields: map[string]string{"points": "3"}},
+		{ID: "bd-22", Title: "Docs", Priority: 3, Status: types.StatusInProgress, Labels: []string{"docs"}},
+	}
+	var buf strings.Builder
+	formatColumns(&buf, issues, []string{"id", "priority", "labels", "points", "title"}, map[string][]string{"bd-1": {"security", "ui"}})
+	want := "ID     PRIORITY  LABELS       POINTS  TITLE\n" +
+		"bd-1   P1        security,ui  3       Login timeout\n" +
+		"bd-22  P3        docs                 Docs\n"
+	if buf.String() != want {
+		t.Errorf("formatColumns =\n%s\nwant\n%s", buf.String(), want)
+	}
+
+	if err := validateViewColumns([]string{"id", "bogus"}); err == nil {
+		t.Error("expected an unknown column to be rejected")
+	}
+}
+
+func TestWriteViewQueue(t *testing.T) {
+	tmpDir := t.TempDir()
+	s := newTestStore(t, filepath.Join(tmpDir, ".beads", "beads.db"))
+	ctx := context.Background()
+
+	for _, issue := range []*types.Issue{
+		{Title: "Fix login page", Priority: 1, IssueType: types.TypeBug, Status: types.StatusOpen},
+		{Title: "Tune queries", Priority: 2, IssueType: types.TypeTask, Status: types.StatusOpen},
+	} {
+		if err := s.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+	beadsDir := filepath.Join(tmpDir, ".beads")
+	if err := views.Save(ctx, s, beadsDir, &views.View{Name: "bugs", Where: "type:bug", Description: "Bug triage queue"}); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	var buf strings.Builder
+	if err := writeViewQueue(ctx, &buf, s, beadsDir, "bugs"); err != nil {
+		t.Fatalf("writeViewQueue failed: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{"# Work Queue (view: bugs)", "Bug triage queue", "Fix login page", "bd ready --view bugs"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "Tune queries") {
+		t.Errorf("output includes an issue outside the view:\n%s", out)
+	}
+
+	if err := writeViewQueue(ctx, &buf, s, beadsDir, "missing"); err == nil {
+		t.Error("expected an unknown view to fail")
+	}
+}
+
+func TestViewIsShowAlias(t *testing.T) {
+	// Saved views live under bd views; bd view stays the bd show alias
+	for _, tt := range []struct {
+		args []string
+		want *cobra.Command
+	}{
+		{[]string{"view", "bd-1"}, showCmd},
+		{[]string{"views", "list"}, viewListCmd},
+		{[]string{"views", "save"}, viewSaveCmd},
+	} {
+		got, _, err := rootCmd.Find(tt.args)
+		if err != nil {
+			t.Fatalf("Find(%v) failed: %v", tt.args, err)
+		}
+		if got != tt.want {
+			t.Errorf("Find(%v) = %s, want %s", tt.args, got.CommandPath(), tt.want.CommandPath())
+		}
+	}
+}
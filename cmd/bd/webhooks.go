@@ -0,0 +1,297 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/beads"
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/ui"
+	"github.com/steveyegge/beads/internal/webhooks"
+)
+
+// webhooksCmd is the root command for outbound webhooks.
+var webhooksCmd = &cobra.Command{
+	Use:     "webhooks",
+	GroupID: "advanced",
+	Short:   "Manage outbound webhooks for issue changes",
+	Long: `Manage outbound webhooks that the daemon POSTs for every mutation
+(create, update, delete, comment, status, ...).
+
+Webhooks are configured in .beads/config.yaml:
+
+  webhooks:
+    endpoints:
+      - name: ci
+        url: https://ci.example.com/beads
+        events: [create, status]        # Omit for all events
+        secret-env: BD_WEBHOOK_SECRET   # Or: secret: "..."
+    max-attempts: 8                     # Retries before dead-lettering
+    initial-backoff: 2s                 # Doubles after each failed attempt
+    max-backoff: 10m
+    timeout: 10s
+
+Each delivery is a JSON POST with headers:
+  X-Beads-Event       mutation type
+  X-Beads-Delivery    unique delivery ID
+  X-Beads-Timestamp   send time (unix seconds)
+  X-Beads-Signature   sha256=<hex HMAC-SHA256 of "<timestamp>.<body>"> (when a secret is set)
+
+Receivers should recompute the signature over the X-Beads-Timestamp value, a
+dot and the raw body, and reject deliveries whose timestamp is more than a few
+minutes old so a captured delivery cannot be replayed.
+
+Deliveries are queued in .beads/webhooks/queue/ until they succeed. Failed
+deliveries are retried with exponential backoff; those that exhaust their
+retries, or are rejected with a 4xx status, move to .beads/webhooks/dead-letter/.
+
+Webhooks are only delivered while the daemon is running.
+
+Examples:
+  bd webhooks list              # Show endpoints, queue and dead letters
+  bd webhooks test              # Send a ping to every endpoint
+  bd webhooks test ci           # Send a ping to one endpoint
+  bd webhooks replay --all      # Requeue all dead-lettered deliveries`,
+}
+
+var webhooksListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List webhook endpoints and delivery status",
+	Run: func(cmd *cobra.Command, args []string) {
+		cfg := config.GetWebhookConfig()
+		store := openWebhookStore()
+
+		pending, err := store.Pending()
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		dead, err := store.DeadLetters()
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+
+		if jsonOutput {
+			endpoints := make([]map[string]interface{}, len(cfg.Endpoints))
+			for i, e := range cfg.Endpoints {
+				endpoints[i] = map[string]interface{}{
+					"name":   e.Name,
+					"url":    e.URL,
+					"events": webhookEventsLabel(e),
+					"signed": e.SigningSecret() != "",
+				}
+			}
+			outputJSON(map[string]interface{}{
+				"endpoints":    endpoints,
+				"pending":      pending,
+				"dead_letters": dead,
+			})
+			return
+		}
+
+		if len(cfg.Endpoints) == 0 {
+			fmt.Println("No webhooks configured (see: bd webhooks --help)")
+		} else {
+			fmt.Printf("%s Webhook endpoints (%d):\n", ui.RenderAccent("🔗"), len(cfg.Endpoints))
+			for _, e := range cfg.Endpoints {
+				signed := ui.RenderWarn("unsigned")
+				if e.SigningSecret() != "" {
+					signed = ui.RenderPass("signed")
+				}
+				fmt.Printf("  %s  %s  [%s] %s\n", ui.RenderBold(e.Name), e.URL, webhookEventsLabel(e), signed)
+			}
+		}
+
+		fmt.Printf("\nPending deliveries: %d\n", len(pending))
+		for _, d := range pending {
+			fmt.Printf("  %s  %s %s → %s (attempt %d, next %s)\n",
+				d.ID, d.Event.Type, d.Event.IssueID, d.Endpoint, d.Attempts+1, formatNextAttempt(d.NextAttempt))
+			if d.LastError != "" {
+				fmt.Printf("    %s\n", ui.RenderMuted("last error: "+d.LastError))
+			}
+		}
+
+		fmt.Printf("\nDead letters: %d\n", len(dead))
+		for _, d := range dead {
+			fmt.Printf("  %s  %s %s → %s (%d attempt(s))\n",
+				d.ID, d.Event.Type, d.Event.IssueID, d.Endpoint, d.Attempts)
+			if d.LastError != "" {
+				fmt.Printf("    %s\n", ui.RenderFail(d.LastError))
+			}
+		}
+		if len(dead) > 0 {
+			fmt.Printf("\n%s Run 'bd webhooks replay --all' to retry dead-lettered deliveries\n", ui.RenderAccent("💡"))
+		}
+	},
+}
+
+var webhooksTestCmd = &cobra.Command{
+	Use:   "test [endpoint...]",
+	Short: "Send a test ping to webhook endpoints",
+	Long: `Send a signed "ping" event to each named endpoint (or all endpoints)
+and report the result. The ping is sent directly, not through the queue.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cfg := config.GetWebhookConfig()
+		endpoints := cfg.Endpoints
+		if len(args) > 0 {
+			endpoints = nil
+			for _, name := range args {
+				e, ok := findWebhookEndpoint(cfg, name)
+				if !ok {
+					FatalErrorRespectJSON("no webhook endpoint named %q", name)
+				}
+				endpoints = append(endpoints, e)
+			}
+		}
+		if len(endpoints) == 0 {
+			FatalErrorRespectJSON("no webhooks configured (see: bd webhooks --help)")
+		}
+
+		webhooks.UserAgent = "beads-webhooks/" + Version
+		client := &http.Client{Timeout: cfg.Timeout}
+		workspace := ""
+		if beadsDir := beads.FindBeadsDir(); beadsDir != "" {
+			workspace = filepath.Dir(beadsDir)
+		}
+
+		failed := 0
+		var results []map[string]interface{}
+		for _, e := range endpoints {
+			event := webhooks.Event{Type: webhooks.EventPing, Actor: actor, Timestamp: time.Now(), Workspace: workspace}
+			delivery := webhooks.NewDelivery(e.Name, event, time.Now())
+
+			start := time.Now()
+			err := webhooks.Send(context.Background(), client, e, delivery)
+			elapsed := time.Since(start)
+
+			result := map[string]interface{}{
+				"endpoint":    e.Name,
+				"url":         e.URL,
+				"success":     err == nil,
+				"duration_ms": elapsed.Milliseconds(),
+			}
+			if err != nil {
+				failed++
+				result["error"] = err.Error()
+			}
+			results = append(results, result)
+
+			if !jsonOutput {
+				if err != nil {
+					fmt.Printf("%s %s: %v\n", ui.RenderFail("✗"), e.Name, err)
+				} else {
+					fmt.Printf("%s %s: delivered in %s\n", ui.RenderPass("✓"), e.Name, elapsed.Round(time.Millisecond))
+				}
+			}
+		}
+
+		if jsonOutput {
+			outputJSON(results)
+		}
+		if failed > 0 {
+			os.Exit(1)
+		}
+	},
+}
+
+var webhooksReplayCmd = &cobra.Command{
+	Use:   "replay [delivery-id...]",
+	Short: "Requeue dead-lettered webhook deliveries",
+	Long: `Move dead-lettered deliveries back to the delivery queue with their
+retry count reset. Delivery IDs may be abbreviated to a unique prefix
+(see 'bd webhooks list'). The daemon delivers requeued items on its next
+queue scan.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		all, _ := cmd.Flags().GetBool("all")
+		if all == (len(args) > 0) {
+			FatalErrorRespectJSON("specify delivery IDs or --all (not both)")
+		}
+		store := openWebhookStore()
+
+		ids := args
+		if all {
+			dead, err := store.DeadLetters()
+			if err != nil {
+				FatalErrorRespectJSON("%v", err)
+			}
+			for _, d := range dead {
+				ids = append(ids, d.ID)
+			}
+		}
+
+		var replayed []string
+		for _, id := range ids {
+			d, err := store.Replay(id, time.Now())
+			if err != nil {
+				FatalErrorRespectJSON("%v", err)
+			}
+			replayed = append(replayed, d.ID)
+		}
+
+		if jsonOutput {
+			outputJSON(map[string]interface{}{"replayed": replayed})
+			return
+		}
+		if len(replayed) == 0 {
+			fmt.Println("No dead-lettered deliveries to replay")
+			return
+		}
+		fmt.Printf("%s Requeued %d delivery(s)\n", ui.RenderPass("✓"), len(replayed))
+		if running, _ := tryDaemonLock(beads.FindBeadsDir()); !running {
+			fmt.Printf("%s Daemon is not running; deliveries will be sent when it starts (bd daemon start)\n", ui.RenderWarn("⚠"))
+		}
+	},
+}
+
+// openWebhookStore opens the delivery store in the current .beads directory.
+func openWebhookStore() *webhooks.Store {
+	beadsDir := beads.FindBeadsDir()
+	if beadsDir == "" {
+		FatalErrorRespectJSON("not in a beads workspace (no .beads directory found)")
+	}
+	store, err := webhooks.NewStore(webhooksDir(beadsDir))
+	if err != nil {
+		FatalErrorRespectJSON("%v", err)
+	}
+	return store
+}
+
+func findWebhookEndpoint(cfg config.WebhookConfig, name string) (config.WebhookEndpoint, bool) {
+	for _, e := range cfg.Endpoints {
+		if e.Name == name {
+			return e, true
+		}
+	}
+	return config.WebhookEndpoint{}, false
+}
+
+// formatNextAttempt describes when a queued delivery is next due.
+func formatNextAttempt(t time.Time) string {
+	wait := time.Until(t)
+	if wait <= 0 {
+		return "now"
+	}
+	return "in " + wait.Round(time.Second).String()
+}
+
+// webhookEventsLabel formats an endpoint's event filter for display.
+func webhookEventsLabel(e config.WebhookEndpoint) string {
+	if len(e.Events) == 0 {
+		return "*"
+	}
+	return strings.Join(e.Events, ",")
+}
+
+func init() {
+	webhooksReplayCmd.Flags().Bool("all", false, "Replay every dead-lettered delivery")
+
+	webhooksCmd.AddCommand(webhooksListCmd)
+	webhooksCmd.AddCommand(webhooksTestCmd)
+	webhooksCmd.AddCommand(webhooksReplayCmd)
+	rootCmd.AddCommand(webhooksCmd)
+}
@@ -0,0 +1,23 @@
+package main
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/query"
+)
+
+// whereFlagUsage describes the --where flag of list, count and search.
+const whereFlagUsage = `Filter expression, e.g. 'status:open AND (priority<=1 OR label:security) AND updated>-7d AND NOT assignee:bot-*'`
+
+// parseWhereFlag parses --where so syntax errors are reported before any
+// query runs. Returns nil if the flag is not set.
+func parseWhereFlag(cmd *cobra.Command) *query.Query {
+	where, _ := cmd.Flags().GetString("where")
+	if where == "" {
+		return nil
+	}
+	q, err := query.Parse(where)
+	if err != nil {
+		FatalErrorRespectJSON("invalid --where: %v", err)
+	}
+	return q
+}
@@ -0,0 +1,279 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+	"github.com/steveyegge/beads/internal/validation"
+)
+
+// workflowCmd is the root command for the status workflow.
+var workflowCmd = &cobra.Command{
+	Use:     "workflow",
+	GroupID: "setup",
+	Short:   "Show the status workflow enforced on status changes",
+	Long: `Show the status workflow enforced on status changes.
+
+The workflow is configured per issue type in .beads/config.yaml:
+
+  workflow:
+    default:                        # Types without their own entry
+      transitions:
+        open: [in_progress, deferred]
+        in_progress: [review, blocked, open]
+        review: [closed, in_progress]
+        blocked: [in_progress]
+        deferred: [open]
+        closed: [open]
+        "*": [deferred]             # From any status
+      guards:
+        in_progress: [assignee]
+        closed: [close_reason, acceptance_criteria]
+    bug:
+      transitions: ...
+
+A status missing from transitions can't be left (tombstone is final unless
+listed). Guards name fields that must be set before an issue enters a status:
+assignee, close_reason (a reason other than the default "Closed"),
+acceptance_criteria, description, design, estimate.
+
+Every backend enforces the workflow in UpdateIssue and CloseIssue. Use
+'bd update --force' or 'bd close --force' to override; each override is
+recorded as a workflow_override event. Imports and tracker syncs are not
+checked. The daemon reads the workflow at startup; restart it after changes.`,
+}
+
+var workflowShowCmd = &cobra.Command{
+	Use:   "show [type]",
+	Short: "Show the workflow as a diagram",
+	Long: `Show the configured workflow for each issue type, or for one type.
+
+Examples:
+  bd workflow show                    # All configured types
+  bd workflow show bug                # The rules that apply to bugs
+  bd workflow show --format mermaid   # Mermaid state diagrams
+  bd workflow show --json`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		format, _ := cmd.Flags().GetString("format")
+		if format != "" && format != "text" && format != "mermaid" {
+			FatalErrorRespectJSON("invalid --format %q (valid: text, mermaid)", format)
+		}
+
+		wf, err := validation.LoadWorkflow()
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+
+		names := []string{}
+		if wf != nil {
+			names = wf.Types()
+		}
+		if len(args) == 1 && wf != nil {
+			issueType := types.IssueType(strings.ToLower(args[0]))
+			name := ""
+			if _, ok := wf.Rules[string(issueType)]; ok {
+				name = string(issueType)
+			} else if wf.RulesFor(issueType) != nil {
+				name = "default"
+			}
+			names = nil
+			if name != "" {
+				names = []string{name}
+			}
+		}
+
+		if jsonOutput {
+			out := make(map[string]workflowJSON, len(names))
+			for _, name := range names {
+				out[name] = newWorkflowJSON(wf.Rules[name])
+			}
+			outputJSON(out)
+			return
+		}
+
+		if len(names) == 0 {
+			if len(args) == 1 {
+				fmt.Printf("No workflow applies to type %q; its status changes are unrestricted.\n", args[0])
+			} else {
+				fmt.Println("No workflow configured; status changes are unrestricted.")
+				fmt.Println("Run 'bd workflow --help' for the config.yaml format.")
+			}
+			return
+		}
+		for i, name := range names {
+			if i > 0 {
+				fmt.Println()
+			}
+			if format == "mermaid" {
+				fmt.Print(renderWorkflowMermaid(name, wf.Rules[name]))
+			} else {
+				fmt.Print(renderWorkflowText(name, wf.Rules[name]))
+			}
+		}
+	},
+}
+
+// workflowJSON is the --json form of one type's workflow.
+type workflowJSON struct {
+	Transitions map[string][]string `json:"transitions"`
+	Guards      map[string][]string `json:"guards,omitempty"`
+}
+
+func newWorkflowJSON(rules *validation.WorkflowRules) workflowJSON {
+	out := workflowJSON{Transitions: make(map[string][]string, len(rules.Transitions))}
+	for from, targets := range rules.Transitions {
+		out.Transitions[string(from)] = statusStrings(targets)
+	}
+	if len(rules.Guards) > 0 {
+		out.Guards = make(map[string][]string, len(rules.Guards))
+		for to, guards := range rules.Guards {
+			for _, g := range guards {
+				out.Guards[string(to)] = append(out.Guards[string(to)], string(g))
+			}
+		}
+	}
+	return out
+}
+
+// workflowStatusOrder lists built-in statuses in lifecycle order for display.
+var workflowStatusOrder = []types.Status{
+	"*",
+	types.StatusOpen,
+	types.StatusInProgress,
+	types.StatusBlocked,
+	types.StatusDeferred,
+	types.StatusReview,
+	types.StatusHooked,
+	types.StatusPinned,
+	types.StatusClosed,
+	types.StatusTombstone,
+}
+
+// workflowStatuses returns every status the rules mention, built-ins in
+// lifecycle order followed by custom statuses alphabetically.
+func workflowStatuses(rules *validation.WorkflowRules) []types.Status {
+	seen := map[types.Status]bool{}
+	for from, targets := range rules.Transitions {
+		seen[from] = true
+		for _, to := range targets {
+			seen[to] = true
+		}
+	}
+	for to := range rules.Guards {
+		seen[to] = true
+	}
+	var statuses, custom []types.Status
+	for _, s := range workflowStatusOrder {
+		if seen[s] {
+			statuses = append(statuses, s)
+		}
+	}
+	for s := range seen {
+		if !slices.Contains(workflowStatusOrder, s) {
+			custom = append(custom, s)
+		}
+	}
+	sort.Slice(custom, func(i, j int) bool { return custom[i] < custom[j] })
+	return append(statuses, custom...)
+}
+
+// renderWorkflowText draws one type's workflow as an arrow list, with each
+// target's guards in brackets. Statuses that can't be left are marked final.
+func renderWorkflowText(name string, rules *validation.WorkflowRules) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%s %s\n", ui.RenderBold("Workflow:"), name)
+	statuses := workflowStatuses(rules)
+
+	if len(rules.Transitions) == 0 {
+		sb.WriteString("  Any status change is allowed\n")
+		for _, to := range statuses {
+			fmt.Fprintf(&sb, "  → %s %s\n", to, ui.RenderMuted("["+guardList(rules.Guards[to])+"]"))
+		}
+		return sb.String()
+	}
+
+	width := len("(any)")
+	for _, s := range statuses {
+		width = max(width, len(s))
+	}
+	for _, from := range statuses {
+		label := string(from)
+		if from == "*" {
+			label = "(any)"
+		}
+		targets := rules.Transitions[from]
+		if len(targets) == 0 {
+			if len(rules.Targets(from)) == 0 {
+				fmt.Fprintf(&sb, "  %-*s  %s\n", width, label, ui.RenderMuted("(final)"))
+			}
+			continue
+		}
+		parts := make([]string, len(targets))
+		for i, to := range targets {
+			parts[i] = string(to)
+			if to == "*" {
+				parts[i] = "(any)"
+			}
+			if guards := rules.Guards[to]; len(guards) > 0 {
+				parts[i] += " " + ui.RenderMuted("["+guardList(guards)+"]")
+			}
+		}
+		fmt.Fprintf(&sb, "  %-*s  → %s\n", width, label, strings.Join(parts, ", "))
+	}
+	return sb.String()
+}
+
+// renderWorkflowMermaid draws one type's workflow as a Mermaid state diagram.
+// The wildcard status is drawn as a state named "any".
+func renderWorkflowMermaid(name string, rules *validation.WorkflowRules) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "---\ntitle: %s\n---\nstateDiagram-v2\n", name)
+	statuses := workflowStatuses(rules)
+	if slices.Contains(statuses, types.StatusOpen) {
+		sb.WriteString("    [*] --> open\n")
+	}
+	state := func(s types.Status) string {
+		if s == "*" {
+			return "any"
+		}
+		return string(s)
+	}
+	for _, from := range statuses {
+		for _, to := range rules.Transitions[from] {
+			label := ""
+			if guards := rules.Guards[to]; len(guards) > 0 {
+				label = " : requires " + guardList(guards)
+			}
+			fmt.Fprintf(&sb, "    %s --> %s%s\n", state(from), state(to), label)
+		}
+	}
+	return sb.String()
+}
+
+func guardList(guards []validation.Guard) string {
+	names := make([]string, len(guards))
+	for i, g := range guards {
+		names[i] = string(g)
+	}
+	return strings.Join(names, ", ")
+}
+
+func statusStrings(statuses []types.Status) []string {
+	out := make([]string, len(statuses))
+	for i, s := range statuses {
+		out[i] = string(s)
+	}
+	return out
+}
+
+func init() {
+	workflowShowCmd.Flags().String("format", "", "Output format: text (default) or mermaid")
+	workflowCmd.AddCommand(workflowShowCmd)
+	rootCmd.AddCommand(workflowCmd)
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/validation"
+)
+
+func testWorkflowRules(t *testing.T) *validation.WorkflowRules {
+	t.Helper()
+	w, err := validation.ParseWorkflow(map[string]config.WorkflowRules{
+		"default": {
+			Transitions: map[string][]string{
+				"open":        {"in_progress"},
+				"in_progress": {"needs_qa", "open"},
+				"needs_qa":    {"closed"},
+				"closed":      {},
+				"*":           {"deferred"},
+			},
+			Guards: map[string][]string{"closed": {"close_reason"}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("ParseWorkflow() error = %v", err)
+	}
+	return w.Rules["default"]
+}
+
+func TestWorkflowStatuses(t *testing.T) {
+	got := workflowStatuses(testWorkflowRules(t))
+	want := "*,open,in_progress,deferred,closed,needs_qa"
+	if s := strings.Join(statusStrings(got), ","); s != want {
+		t.Errorf("workflowStatuses() = %s, want %s", s, want)
+	}
+}
+
+func TestRenderWorkflowText(t *testing.T) {
+	out := renderWorkflowText("default", testWorkflowRules(t))
+	for _, want := range []string{
+		"(any)        → deferred",
+		"open         → in_progress",
+		"in_progress  → needs_qa, open",
+		"needs_qa     → closed",
+		"close_reason",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+	// deferred can only use the wildcard rule, so it isn't final
+	if strings.Contains(out, "deferred     ") {
+		t.Errorf("deferred should not have its own line:\n%s", out)
+	}
+}
+
+func TestRenderWorkflowMermaid(t *testing.T) {
+	out := renderWorkflowMermaid("default", testWorkflowRules(t))
+	for _, want := range []string{
+		"stateDiagram-v2",
+		"[*] --> open",
+		"any --> deferred",
+		"needs_qa --> closed : requires close_reason",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
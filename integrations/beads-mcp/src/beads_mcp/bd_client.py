@@ -435,6 +435,8 @@ class BdCliClient(BdClientBase):
             args.extend(["--title", params.query])
         if params.unassigned:
             args.append("--no-assignee")
+        if params.where:
+            args.extend(["--where", params.where])
         if params.limit:
             args.extend(["--limit", str(params.limit)])
 
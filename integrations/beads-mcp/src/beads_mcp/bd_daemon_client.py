@@ -386,6 +386,8 @@ class BdDaemonClient(BdClientBase):
             args["query"] = params.query
         if params.unassigned:
             args["unassigned"] = params.unassigned
+        if params.where:
+            args["where"] = params.where
         if params.limit:
             args["limit"] = params.limit
 
@@ -238,6 +238,7 @@ class ListIssuesParams(BaseModel):
     labels_any: list[str] | None = None  # OR: must have at least one
     query: str | None = None  # Search in title (case-insensitive)
     unassigned: bool = False  # Filter to only unassigned issues
+    where: str | None = None  # Filter expression, e.g. "status:open AND label:ui"
     limit: int = Field(default=20, ge=1, le=100)  # Reduced to avoid MCP buffer overflow
 
 
@@ -391,6 +391,7 @@ async def get_tool_info(tool_name: str) -> dict[str, Any]:
                 "labels_any": "list[str] (optional) - OR filter: must have at least one",
                 "query": "str (optional) - Search in title (case-insensitive)",
                 "unassigned": "bool (default false) - Only unassigned issues",
+                "where": "str (optional) - Filter expression, e.g. 'status:open AND label:security AND updated>-7d'",
                 "limit": "int (1-100, default 20)",
                 "brief": "bool (default false) - Return only {id, title, status, priority}",
                 "fields": "list[str] (optional) - Custom field projection",
@@ -852,6 +853,7 @@ async def list_issues(
     labels_any: list[str] | None = None,
     query: str | None = None,
     unassigned: bool = False,
+    where: str | None = None,
     limit: int = 20,
     workspace_root: str | None = None,
     brief: bool = False,
@@ -869,6 +871,7 @@ async def list_issues(
         labels_any: Filter by labels (OR: must have at least one)
         query: Search in title (case-insensitive substring)
         unassigned: Filter to only unassigned issues
+        where: Filter expression, e.g. "status:open AND (priority<=1 OR label:security)"
         limit: Maximum issues to return (1-100, default 20)
         workspace_root: Workspace path override
         brief: If True, return only {id, title, status} (~97% smaller)
@@ -887,6 +890,7 @@ async def list_issues(
         labels_any=labels_any,
         query=query,
         unassigned=unassigned,
+        where=where,
         limit=limit,
     )
 
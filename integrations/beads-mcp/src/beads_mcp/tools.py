@@ -400,6 +400,7 @@ async def beads_list_issues(
     labels_any: Annotated[list[str] | None, "Filter by labels (OR: must have at least one)"] = None,
     query: Annotated[str | None, "Search in title (case-insensitive substring)"] = None,
     unassigned: Annotated[bool, "Filter to only unassigned issues"] = False,
+    where: Annotated[str | None, "Filter expression, e.g. 'status:open AND (priority<=1 OR label:security)'"] = None,
     limit: Annotated[int, "Maximum number of issues to return (1-100)"] = 20,
 ) -> list[Issue]:
     """List all issues with optional filters."""
@@ -414,6 +415,7 @@ async def beads_list_issues(
         labels_any=labels_any,
         query=query,
         unassigned=unassigned,
+        where=where,
         limit=limit,
     )
     return await client.list_issues(params)
@@ -0,0 +1,219 @@
+// Package attachments stores the content of issue attachments under
+// .beads/attachments. Blobs are content-addressed: each is named by the
+// SHA-256 of its content, so a file attached twice is stored once and blobs
+// written in different clones never conflict in git.
+package attachments
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// DirName is the directory under .beads that holds attachment blobs.
+const DirName = "attachments"
+
+// lfsAttributes routes blobs (but not the attributes file itself) through
+// git LFS.
+const lfsAttributes = "*/* filter=lfs diff=lfs merge=lfs -text\n"
+
+var (
+	// ErrTooLarge is returned by Put when content exceeds the size limit.
+	ErrTooLarge = errors.New("attachment exceeds size limit")
+	// ErrNotFound is returned when a blob is not in the store.
+	ErrNotFound = errors.New("attachment content not found")
+)
+
+// Blob describes a stored attachment blob.
+type Blob struct {
+	Hash    string
+	Size    int64
+	ModTime time.Time
+}
+
+// Store is a content-addressed blob store rooted at a directory. Blobs live
+// at <dir>/<first two hash characters>/<hash>.
+type Store struct {
+	dir string
+}
+
+// New returns the store for the .beads directory beadsDir.
+func New(beadsDir string) *Store {
+	return &Store{dir: filepath.Join(beadsDir, DirName)}
+}
+
+// Dir returns the root directory of the store.
+func (s *Store) Dir() string {
+	return s.dir
+}
+
+// ValidHash reports whether h is a lowercase hex SHA-256 digest. Hashes from
+// JSONL are checked before they are used to build paths.
+func ValidHash(h string) bool {
+	if len(h) != sha256.Size*2 {
+		return false
+	}
+	for _, c := range h {
+		if (c < '0' || c > '9') && (c < 'a' || c > 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+// Path returns where the blob with the given hash is stored.
+func (s *Store) Path(hash string) string {
+	return filepath.Join(s.dir, hash[:2], hash)
+}
+
+// Put stores the content of r and returns its hash and size. Content larger
+// than maxSize bytes is rejected with ErrTooLarge (maxSize <= 0 means no
+// limit). Storing content that is already present is a no-op.
+func (s *Store) Put(r io.Reader, maxSize int64) (string, int64, error) {
+	if err := os.MkdirAll(s.dir, 0o750); err != nil {
+		return "", 0, fmt.Errorf("failed to create attachment directory: %w", err)
+	}
+	tmp, err := os.CreateTemp(s.dir, ".tmp-*")
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to create temp file: %w", err)
+	}
+	tmpPath := tmp.Name()
+	defer func() { _ = os.Remove(tmpPath) }()
+
+	h := sha256.New()
+	src := r
+	if maxSize > 0 {
+		// Read one byte past the limit to detect oversize content
+		src = io.LimitReader(r, maxSize+1)
+	}
+	size, err := io.Copy(io.MultiWriter(tmp, h), src)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to write attachment: %w", err)
+	}
+	if maxSize > 0 && size > maxSize {
+		return "", 0, fmt.Errorf("%w (%d bytes)", ErrTooLarge, maxSize)
+	}
+
+	hash := hex.EncodeToString(h.Sum(nil))
+	dest := s.Path(hash)
+	if _, err := os.Stat(dest); err == nil {
+		return hash, size, nil
+	}
+	if err := os.MkdirAll(filepath.Dir(dest), 0o750); err != nil {
+		return "", 0, fmt.Errorf("failed to create attachment directory: %w", err)
+	}
+	if err := os.Rename(tmpPath, dest); err != nil {
+		return "", 0, fmt.Errorf("failed to store attachment: %w", err)
+	}
+	return hash, size, nil
+}
+
+// Open opens the blob with the given hash for reading.
+func (s *Store) Open(hash string) (*os.File, error) {
+	if !ValidHash(hash) {
+		return nil, fmt.Errorf("invalid attachment hash %q", hash)
+	}
+	// #nosec G304 - path is built from a validated hash
+	f, err := os.Open(s.Path(hash))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, hash)
+	}
+	return f, err
+}
+
+// Has reports whether the blob with the given hash is present.
+func (s *Store) Has(hash string) bool {
+	if !ValidHash(hash) {
+		return false
+	}
+	_, err := os.Stat(s.Path(hash))
+	return err == nil
+}
+
+// List returns all blobs in the store, sorted by hash.
+func (s *Store) List() ([]Blob, error) {
+	var blobs []Blob
+	err := filepath.WalkDir(s.dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				return filepath.SkipDir
+			}
+			return err
+		}
+		if d.IsDir() || !ValidHash(d.Name()) || filepath.Base(filepath.Dir(path)) != d.Name()[:2] {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		blobs = append(blobs, Blob{Hash: d.Name(), Size: info.Size(), ModTime: info.ModTime()})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list attachments: %w", err)
+	}
+	sort.Slice(blobs, func(i, j int) bool { return blobs[i].Hash < blobs[j].Hash })
+	return blobs, nil
+}
+
+// GC removes blobs whose hash is not in referenced. Blobs modified within
+// grace are kept, so content stored by a concurrent bd attach survives until
+// its metadata is saved. With dryRun nothing is removed. It returns the
+// blobs that were (or would be) removed.
+func (s *Store) GC(referenced map[string]bool, grace time.Duration, dryRun bool) ([]Blob, error) {
+	blobs, err := s.List()
+	if err != nil {
+		return nil, err
+	}
+	cutoff := time.Now().Add(-grace)
+	var removed []Blob
+	for _, b := range blobs {
+		if referenced[b.Hash] || b.ModTime.After(cutoff) {
+			continue
+		}
+		if !dryRun {
+			if err := os.Remove(s.Path(b.Hash)); err != nil && !errors.Is(err, os.ErrNotExist) {
+				return removed, fmt.Errorf("failed to remove attachment %s: %w", b.Hash, err)
+			}
+			// Drop the fan-out directory once it is empty
+			_ = os.Remove(filepath.Dir(s.Path(b.Hash)))
+		}
+		removed = append(removed, b)
+	}
+	return removed, nil
+}
+
+// EnableLFS writes a .gitattributes file that stores blobs with git LFS.
+// An existing .gitattributes is left alone.
+func (s *Store) EnableLFS() error {
+	path := filepath.Join(s.dir, ".gitattributes")
+	if _, err := os.Stat(path); err == nil {
+		return nil
+	}
+	if err := os.MkdirAll(s.dir, 0o750); err != nil {
+		return fmt.Errorf("failed to create attachment directory: %w", err)
+	}
+	// #nosec G306 - .gitattributes is committed alongside issues.jsonl
+	if err := os.WriteFile(path, []byte(lfsAttributes), 0o644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+// ShortHash abbreviates a hash for display.
+func ShortHash(hash string) string {
+	if len(hash) > 12 {
+		return hash[:12]
+	}
+	return hash
+}
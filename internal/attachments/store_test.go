@@ -0,0 +1,141 @@
+package attachments
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPutAndOpen(t *testing.T) {
+	s := New(t.TempDir())
+
+	hash, size, err := s.Put(strings.NewReader("hello world"), 0)
+	if err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+	// sha256("hello world")
+	if hash != "b94d27b9934d3e08a52e52d7da7dabfac484efe37a5380ee9088f7ace2efcde9" {
+		t.Errorf("hash = %s", hash)
+	}
+	if size != 11 {
+		t.Errorf("size = %d, want 11", size)
+	}
+	if !s.Has(hash) {
+		t.Error("Has = false after Put")
+	}
+	if want := filepath.Join(s.Dir(), "b9", hash); s.Path(hash) != want {
+		t.Errorf("Path = %s, want %s", s.Path(hash), want)
+	}
+
+	// Same content again is deduplicated
+	again, _, err := s.Put(strings.NewReader("hello world"), 0)
+	if err != nil || again != hash {
+		t.Fatalf("second Put = %s, %v", again, err)
+	}
+	blobs, err := s.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(blobs) != 1 {
+		t.Fatalf("List returned %d blobs, want 1 (temp files must be cleaned up)", len(blobs))
+	}
+
+	f, err := s.Open(hash)
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	defer f.Close()
+	data, _ := io.ReadAll(f)
+	if string(data) != "hello world" {
+		t.Errorf("content = %q", data)
+	}
+}
+
+func TestPutSizeLimit(t *testing.T) {
+	s := New(t.TempDir())
+
+	if _, _, err := s.Put(strings.NewReader("12345"), 5); err != nil {
+		t.Fatalf("content at the limit rejected: %v", err)
+	}
+	_, _, err := s.Put(strings.NewReader("123456"), 5)
+	if !errors.Is(err, ErrTooLarge) {
+		t.Fatalf("err = %v, want ErrTooLarge", err)
+	}
+	blobs, _ := s.List()
+	if len(blobs) != 1 {
+		t.Errorf("oversize content left %d blobs, want 1", len(blobs))
+	}
+}
+
+func TestOpenRejectsBadHash(t *testing.T) {
+	s := New(t.TempDir())
+	if _, err := s.Open("../../etc/passwd"); err == nil {
+		t.Error("Open accepted a path as hash")
+	}
+	_, err := s.Open(strings.Repeat("a", 64))
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("err = %v, want ErrNotFound", err)
+	}
+}
+
+func TestGC(t *testing.T) {
+	s := New(t.TempDir())
+
+	keep, _, _ := s.Put(strings.NewReader("referenced"), 0)
+	drop, _, _ := s.Put(strings.NewReader("orphaned"), 0)
+	fresh, _, _ := s.Put(strings.NewReader("just written"), 0)
+	old := time.Now().Add(-2 * time.Hour)
+	for _, h := range []string{keep, drop} {
+		if err := os.Chtimes(s.Path(h), old, old); err != nil {
+			t.Fatal(err)
+		}
+	}
+	referenced := map[string]bool{keep: true}
+
+	removed, err := s.GC(referenced, time.Hour, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(removed) != 1 || removed[0].Hash != drop {
+		t.Fatalf("dry run removed %v, want only %s", removed, drop)
+	}
+	if !s.Has(drop) {
+		t.Fatal("dry run deleted a blob")
+	}
+
+	if _, err := s.GC(referenced, time.Hour, false); err != nil {
+		t.Fatal(err)
+	}
+	if s.Has(drop) {
+		t.Error("unreferenced blob survived GC")
+	}
+	if !s.Has(keep) || !s.Has(fresh) {
+		t.Error("GC removed a referenced or recent blob")
+	}
+}
+
+func TestGCMissingDir(t *testing.T) {
+	s := New(filepath.Join(t.TempDir(), "missing"))
+	removed, err := s.GC(nil, 0, false)
+	if err != nil || len(removed) != 0 {
+		t.Errorf("GC on missing dir = %v, %v", removed, err)
+	}
+}
+
+func TestEnableLFS(t *testing.T) {
+	s := New(t.TempDir())
+	if err := s.EnableLFS(); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(filepath.Join(s.Dir(), ".gitattributes"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "filter=lfs") {
+		t.Errorf(".gitattributes = %q", data)
+	}
+}
@@ -311,6 +311,10 @@ type (
 	DependencyType = types.DependencyType
 	// Comment represents a user comment on an issue.
 	Comment = types.Comment
+	// WorkLogEntry records time spent on an issue.
+	WorkLogEntry = types.WorkLogEntry
+	// Attachment is a file attached to an issue.
+	Attachment = types.Attachment
 	// Event represents an audit log event.
 	Event = types.Event
 	// EventType represents the type of audit event.
@@ -365,6 +369,14 @@ const (
 	SortPolicyHybrid   = types.SortPolicyHybrid
 	SortPolicyPriority = types.SortPolicyPriority
 	SortPolicyOldest   = types.SortPolicyOldest
+
+	// Scored policies, ranked by internal/ranking
+	SortPolicyUnblock      = types.SortPolicyUnblock
+	SortPolicyCriticalPath = types.SortPolicyCriticalPath
+	SortPolicyDue          = types.SortPolicyDue
+	SortPolicyWSJF         = types.SortPolicyWSJF
+	SortPolicyAge          = types.SortPolicyAge
+	SortPolicyScore        = types.SortPolicyScore
 )
 
 // EventType constants
@@ -380,6 +392,9 @@ const (
 	EventLabelAdded        = types.EventLabelAdded
 	EventLabelRemoved      = types.EventLabelRemoved
 	EventCompacted         = types.EventCompacted
+	EventWorkflowOverride  = types.EventWorkflowOverride
+	EventClaimed           = types.EventClaimed
+	EventLeaseExpired      = types.EventLeaseExpired
 )
 
 // Storage provides the minimal interface for extension orchestration
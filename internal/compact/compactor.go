@@ -21,6 +21,9 @@ type Config struct {
 	DryRun       bool
 	AuditEnabled bool
 	Actor        string
+	Summarizer   string // anthropic (default), openai or extractive
+	Model        string // Backend default when empty
+	Endpoint     string // Base URL of an OpenAI-compatible API
 }
 
 // Compactor handles issue compaction using AI summarization.
@@ -37,10 +40,16 @@ type issueStore interface {
 	ApplyCompaction(ctx context.Context, issueID string, tier int, originalSize int, compactedSize int, commitHash string) error
 	AddComment(ctx context.Context, issueID, actor, comment string) error
 	MarkIssueDirty(ctx context.Context, issueID string) error
+	SnapshotIssue(ctx context.Context, issueID string, level int, compressedSize int) error
+	ArchiveRollup(ctx context.Context, epicID string, childIDs []string, summary, stub string, originalSize int, commitHash, actor, comment string) error
+	GetEpicDescendants(ctx context.Context, epicID string) ([]*types.Issue, error)
 }
 
+// summarizer is implemented by HaikuClient, OpenAIClient and
+// ExtractiveSummarizer; Config.Summarizer picks one.
 type summarizer interface {
 	SummarizeTier1(ctx context.Context, issue *types.Issue) (string, error)
+	SummarizeTier2(ctx context.Context, epic *types.Issue, children []*types.Issue) (string, error)
 }
 
 // New creates a new Compactor instance with the given configuration.
@@ -57,26 +66,22 @@ func New(store *sqlite.SQLiteStorage, apiKey string, config *Config) (*Compactor
 		config.APIKey = apiKey
 	}
 
-	var haikuClient summarizer
+	var client summarizer
 	var err error
 	if !config.DryRun {
-		haikuClient, err = NewHaikuClient(config.APIKey)
+		client, err = newSummarizer(config)
 		if err != nil {
 			if errors.Is(err, ErrAPIKeyRequired) {
 				config.DryRun = true
 			} else {
-				return nil, fmt.Errorf("failed to create Haiku client: %w", err)
+				return nil, fmt.Errorf("failed to create summarizer: %w", err)
 			}
 		}
 	}
-	if hc, ok := haikuClient.(*HaikuClient); ok {
-		hc.auditEnabled = config.AuditEnabled
-		hc.auditActor = config.Actor
-	}
 
 	return &Compactor{
 		store:      store,
-		summarizer: haikuClient,
+		summarizer: client,
 		config:     config,
 	}, nil
 }
@@ -86,6 +91,7 @@ type Result struct {
 	IssueID       string
 	OriginalSize  int
 	CompactedSize int
+	Children      []string // Issues rolled into a tier-2 summary
 	Err           error
 }
 
@@ -123,7 +129,7 @@ func (c *Compactor) CompactTier1(ctx context.Context, issueID string) error {
 	}
 	summary, err := c.summarizer.SummarizeTier1(ctx, issue)
 	if err != nil {
-		return fmt.Errorf("failed to summarize with Haiku: %w", err)
+		return fmt.Errorf("failed to summarize: %w", err)
 	}
 
 	compactedSize := len(summary)
@@ -136,6 +142,10 @@ func (c *Compactor) CompactTier1(ctx context.Context, issueID string) error {
 		return fmt.Errorf("compaction would increase size (%d → %d bytes), keeping original", originalSize, compactedSize)
 	}
 
+	if err := c.store.SnapshotIssue(ctx, issueID, 1, compactedSize); err != nil {
+		return fmt.Errorf("failed to snapshot issue: %w", err)
+	}
+
 	updates := map[string]interface{}{
 		"description":         summary,
 		"design":              "",
@@ -271,7 +281,7 @@ func (c *Compactor) compactSingleWithResult(ctx context.Context, issueID string,
 	}
 	summary, err := c.summarizer.SummarizeTier1(ctx, issue)
 	if err != nil {
-		return fmt.Errorf("failed to summarize with Haiku: %w", err)
+		return fmt.Errorf("failed to summarize: %w", err)
 	}
 
 	result.CompactedSize = len(summary)
@@ -284,6 +294,10 @@ func (c *Compactor) compactSingleWithResult(ctx context.Context, issueID string,
 		return fmt.Errorf("compaction would increase size (%d → %d bytes), keeping original", result.OriginalSize, result.CompactedSize)
 	}
 
+	if err := c.store.SnapshotIssue(ctx, issueID, 1, result.CompactedSize); err != nil {
+		return fmt.Errorf("failed to snapshot issue: %w", err)
+	}
+
 	updates := map[string]interface{}{
 		"description":         summary,
 		"design":              "",
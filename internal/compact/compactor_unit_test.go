@@ -18,6 +18,9 @@ type stubStore struct {
 	applyCompactionFn  func(context.Context, string, int, int, int, string) error
 	addCommentFn       func(context.Context, string, string, string) error
 	markDirtyFn        func(context.Context, string) error
+	snapshotFn         func(context.Context, string, int, int) error
+	rollupFn           func(context.Context, string, []string, string, string, int, string, string, string) error
+	descendantsFn      func(context.Context, string) ([]*types.Issue, error)
 }
 
 func (s *stubStore) CheckEligibility(ctx context.Context, issueID string, tier int) (bool, string, error) {
@@ -62,10 +65,32 @@ func (s *stubStore) MarkIssueDirty(ctx context.Context, issueID string) error {
 	return nil
 }
 
+func (s *stubStore) SnapshotIssue(ctx context.Context, issueID string, level int, compressedSize int) error {
+	if s.snapshotFn != nil {
+		return s.snapshotFn(ctx, issueID, level, compressedSize)
+	}
+	return nil
+}
+
+func (s *stubStore) ArchiveRollup(ctx context.Context, epicID string, childIDs []string, summary, stub string, originalSize int, commitHash, actor, comment string) error {
+	if s.rollupFn != nil {
+		return s.rollupFn(ctx, epicID, childIDs, summary, stub, originalSize, commitHash, actor, comment)
+	}
+	return nil
+}
+
+func (s *stubStore) GetEpicDescendants(ctx context.Context, epicID string) ([]*types.Issue, error) {
+	if s.descendantsFn != nil {
+		return s.descendantsFn(ctx, epicID)
+	}
+	return nil, nil
+}
+
 type stubSummarizer struct {
-	summary string
-	err     error
-	calls   int
+	summary  string
+	err      error
+	calls    int
+	children int
 }
 
 func (s *stubSummarizer) SummarizeTier1(ctx context.Context, issue *types.Issue) (string, error) {
@@ -73,6 +98,12 @@ func (s *stubSummarizer) SummarizeTier1(ctx context.Context, issue *types.Issue)
 	return s.summary, s.err
 }
 
+func (s *stubSummarizer) SummarizeTier2(ctx context.Context, epic *types.Issue, children []*types.Issue) (string, error) {
+	s.calls++
+	s.children = len(children)
+	return s.summary, s.err
+}
+
 func stubIssue() *types.Issue {
 	return &types.Issue{
 		ID:                 "bd-123",
@@ -289,3 +320,121 @@ func TestCompactTier1Batch_MixedResults(t *testing.T) {
 		t.Fatalf("summarizer should run once; got %d", summary.calls)
 	}
 }
+
+func TestCompactTier1_SnapshotsBeforeUpdate(t *testing.T) {
+	cleanup := withGitHash(t, "deadbeef\n")
+	t.Cleanup(cleanup)
+
+	var order []string
+	store := &stubStore{
+		checkEligibilityFn: func(context.Context, string, int) (bool, string, error) { return true, "", nil },
+		getIssueFn:         func(context.Context, string) (*types.Issue, error) { return stubIssue(), nil },
+		snapshotFn: func(ctx context.Context, id string, level, compressed int) error {
+			if level != 1 || compressed != len("short") {
+				t.Fatalf("snapshot level=%d compressed=%d", level, compressed)
+			}
+			order = append(order, "snapshot")
+			return nil
+		},
+		updateIssueFn: func(context.Context, string, map[string]interface{}, string) error {
+			order = append(order, "update")
+			return nil
+		},
+	}
+	c := &Compactor{store: store, summarizer: &stubSummarizer{summary: "short"}, config: &Config{}}
+
+	if err := c.CompactTier1(context.Background(), "bd-123"); err != nil {
+		t.Fatalf("CompactTier1 unexpected error: %v", err)
+	}
+	if strings.Join(order, ",") != "snapshot,update" {
+		t.Fatalf("expected snapshot before update, got %v", order)
+	}
+}
+
+func tier2Store(t *testing.T, archived map[string]string) *stubStore {
+	t.Helper()
+	epic := &types.Issue{ID: "bd-1", Title: "Epic", Description: strings.Repeat("E", 50), IssueType: types.TypeEpic, Status: types.StatusClosed}
+	return &stubStore{
+		checkEligibilityFn: func(ctx context.Context, id string, tier int) (bool, string, error) {
+			if tier != 2 {
+				t.Fatalf("expected tier 2 eligibility check, got %d", tier)
+			}
+			return true, "", nil
+		},
+		getIssueFn: func(context.Context, string) (*types.Issue, error) { return epic, nil },
+		descendantsFn: func(context.Context, string) ([]*types.Issue, error) {
+			return []*types.Issue{
+				{ID: "bd-1.1", Title: "Done", Description: strings.Repeat("A", 30), Status: types.StatusClosed},
+				{ID: "bd-1.2", Title: "Still open", Description: "x", Status: types.StatusOpen},
+				{ID: "bd-1.3", Title: "Pinned", Description: "y", Status: types.StatusClosed, Pinned: true},
+				{ID: "bd-1.4", Title: "Archived", Description: "z", Status: types.StatusClosed, CompactionLevel: 2},
+				{ID: "bd-1.5", Title: "Also done", Description: strings.Repeat("B", 20), Status: types.StatusClosed},
+			}, nil
+		},
+		rollupFn: func(ctx context.Context, epicID string, childIDs []string, summary, stub string, originalSize int, hash, actor, comment string) error {
+			if hash != "deadbeef" || originalSize != 100 || !strings.Contains(comment, "rolled up 2 children") {
+				t.Fatalf("rollup of %s: hash %q, size %d, comment %q", epicID, hash, originalSize, comment)
+			}
+			archived[epicID] = "|" + summary
+			for _, id := range childIDs {
+				archived[id] = epicID + "|" + stub
+			}
+			return nil
+		},
+	}
+}
+
+func TestCompactTier2_RollsUpChildren(t *testing.T) {
+	cleanup := withGitHash(t, "deadbeef\n")
+	t.Cleanup(cleanup)
+
+	archived := map[string]string{}
+	summary := &stubSummarizer{summary: "rollup"}
+	c := &Compactor{store: tier2Store(t, archived), summarizer: summary, config: &Config{}}
+
+	result, err := c.CompactTier2(context.Background(), "bd-1")
+	if err != nil {
+		t.Fatalf("CompactTier2 unexpected error: %v", err)
+	}
+	if summary.children != 2 {
+		t.Fatalf("summarizer got %d children, want 2", summary.children)
+	}
+	if strings.Join(result.Children, ",") != "bd-1.1,bd-1.5" {
+		t.Fatalf("rolled up %v", result.Children)
+	}
+	if result.OriginalSize != 100 || result.CompactedSize != len("rollup") {
+		t.Fatalf("sizes = %d → %d", result.OriginalSize, result.CompactedSize)
+	}
+	if archived["bd-1"] != "|rollup" {
+		t.Fatalf("epic archived as %q", archived["bd-1"])
+	}
+	for _, id := range []string{"bd-1.1", "bd-1.5"} {
+		if !strings.HasPrefix(archived[id], "bd-1|Archived into bd-1") {
+			t.Fatalf("%s archived as %q", id, archived[id])
+		}
+	}
+	if _, ok := archived["bd-1.2"]; ok || len(archived) != 3 {
+		t.Fatalf("unexpected archives: %v", archived)
+	}
+}
+
+func TestCompactTier2_DryRunAndSize(t *testing.T) {
+	archived := map[string]string{}
+	summary := &stubSummarizer{summary: "rollup"}
+	c := &Compactor{store: tier2Store(t, archived), summarizer: summary, config: &Config{DryRun: true}}
+
+	results, err := c.CompactTier2Batch(context.Background(), []string{"bd-1"})
+	if err != nil || len(results) != 1 || results[0].Err != nil || results[0].OriginalSize != 100 {
+		t.Fatalf("dry-run batch = %+v, %v", results, err)
+	}
+	if summary.calls != 0 || len(archived) != 0 {
+		t.Fatalf("dry run should not summarize or archive")
+	}
+
+	summary.summary = strings.Repeat("S", 200)
+	c.config.DryRun = false
+	_, err = c.CompactTier2(context.Background(), "bd-1")
+	if err == nil || !strings.Contains(err.Error(), "compaction would increase size") || len(archived) != 0 {
+		t.Fatalf("expected size error without archiving, got %v (%v)", err, archived)
+	}
+}
@@ -0,0 +1,116 @@
+package compact
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+const (
+	extractiveSentenceLimit = 240 // bytes per extracted passage
+)
+
+// ExtractiveSummarizer builds summaries from the issue text itself: the
+// leading sentences of each field and the titles of rolled-up children.
+// It is deterministic and needs no network or API key.
+type ExtractiveSummarizer struct{}
+
+// NewExtractiveSummarizer creates an extractive summarizer.
+func NewExtractiveSummarizer() *ExtractiveSummarizer {
+	return &ExtractiveSummarizer{}
+}
+
+// SummarizeTier1 keeps the opening of the description, the design and the
+// resolution in the same sections an LLM summary uses.
+func (e *ExtractiveSummarizer) SummarizeTier1(_ context.Context, issue *types.Issue) (string, error) {
+	var b strings.Builder
+	summary := leadSentences(issue.Description, 2)
+	if summary == "" {
+		summary = issue.Title
+	}
+	fmt.Fprintf(&b, "**Summary:** %s", summary)
+	if design := leadSentences(issue.Design, 1); design != "" {
+		fmt.Fprintf(&b, "\n\n**Key Decisions:** %s", design)
+	}
+	if resolution := resolutionOf(issue); resolution != "" {
+		fmt.Fprintf(&b, "\n\n**Resolution:** %s", resolution)
+	}
+	return b.String(), nil
+}
+
+// SummarizeTier2 keeps the opening of the epic description and one line per
+// child issue.
+func (e *ExtractiveSummarizer) SummarizeTier2(_ context.Context, epic *types.Issue, children []*types.Issue) (string, error) {
+	var b strings.Builder
+	summary := leadSentences(epic.Description, 2)
+	if summary == "" {
+		summary = epic.Title
+	}
+	fmt.Fprintf(&b, "**Summary:** %s", summary)
+	if resolution := resolutionOf(epic); resolution != "" {
+		fmt.Fprintf(&b, "\n\n**Resolution:** %s", resolution)
+	}
+	if len(children) > 0 {
+		fmt.Fprintf(&b, "\n\n**Children (%d):**", len(children))
+		for _, child := range children {
+			fmt.Fprintf(&b, "\n- %s: %s", child.ID, child.Title)
+			if child.CloseReason != "" {
+				fmt.Fprintf(&b, " (%s)", leadSentences(child.CloseReason, 1))
+			}
+		}
+	}
+	return b.String(), nil
+}
+
+func resolutionOf(issue *types.Issue) string {
+	if issue.CloseReason != "" {
+		return leadSentences(issue.CloseReason, 1)
+	}
+	return leadSentences(issue.Notes, 1)
+}
+
+// leadSentences returns the first n sentences of markdown text with headings,
+// list markers and line breaks flattened, capped at extractiveSentenceLimit.
+func leadSentences(text string, n int) string {
+	var words []string
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "```") {
+			continue
+		}
+		line = strings.TrimLeft(line, "-*+> ")
+		words = append(words, strings.Fields(line)...)
+	}
+	flat := strings.Join(words, " ")
+
+	end, found := 0, 0
+	for i := 0; i < len(flat) && found < n; i++ {
+		switch flat[i] {
+		case '.', '!', '?':
+			if i+1 == len(flat) || flat[i+1] == ' ' {
+				end = i + 1
+				found++
+			}
+		}
+	}
+	if found < n || end == 0 {
+		end = len(flat)
+	}
+	return truncateBytes(flat[:end], extractiveSentenceLimit)
+}
+
+// truncateBytes shortens s to at most limit bytes on a rune boundary,
+// marking the cut with an ellipsis.
+func truncateBytes(s string, limit int) string {
+	if len(s) <= limit {
+		return s
+	}
+	cut := limit - len("…")
+	for cut > 0 && !utf8.RuneStart(s[cut]) {
+		cut--
+	}
+	return strings.TrimRight(s[:cut], " ") + "…"
+}
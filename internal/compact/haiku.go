@@ -1,4 +1,5 @@
-// Package compact provides AI-powered issue compaction using Claude Haiku.
+// Package compact provides issue compaction with pluggable summarizers:
+// Claude Haiku, any OpenAI-compatible endpoint, or an offline extractive one.
 package compact
 
 import (
@@ -13,7 +14,6 @@ import (
 
 	"github.com/anthropics/anthropic-sdk-go"
 	"github.com/anthropics/anthropic-sdk-go/option"
-	"github.com/steveyegge/beads/internal/audit"
 	"github.com/steveyegge/beads/internal/types"
 )
 
@@ -31,6 +31,7 @@ type HaikuClient struct {
 	client         anthropic.Client
 	model          anthropic.Model
 	tier1Template  *template.Template
+	tier2Template  *template.Template
 	maxRetries     int
 	initialBackoff time.Duration
 	auditEnabled   bool
@@ -53,11 +54,16 @@ func NewHaikuClient(apiKey string) (*HaikuClient, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse tier1 template: %w", err)
 	}
+	tier2Tmpl, err := template.New("tier2").Parse(tier2PromptTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse tier2 template: %w", err)
+	}
 
 	return &HaikuClient{
 		client:         client,
 		model:          defaultModel,
 		tier1Template:  tier1Tmpl,
+		tier2Template:  tier2Tmpl,
 		maxRetries:     maxRetries,
 		initialBackoff: initialBackoff,
 	}, nil
@@ -70,21 +76,22 @@ func (h *HaikuClient) SummarizeTier1(ctx context.Context, issue *types.Issue) (s
 		return "", fmt.Errorf("failed to render prompt: %w", err)
 	}
 
+	return h.summarize(ctx, issue.ID, prompt)
+}
+
+// SummarizeTier2 rolls an epic and its children into one archival summary.
+func (h *HaikuClient) SummarizeTier2(ctx context.Context, epic *types.Issue, children []*types.Issue) (string, error) {
+	prompt, err := renderPrompt(h.tier2Template, newTier2Data(epic, children))
+	if err != nil {
+		return "", fmt.Errorf("failed to render prompt: %w", err)
+	}
+	return h.summarize(ctx, epic.ID, prompt)
+}
+
+func (h *HaikuClient) summarize(ctx context.Context, issueID, prompt string) (string, error) {
 	resp, callErr := h.callWithRetry(ctx, prompt)
 	if h.auditEnabled {
-		// Best-effort: never fail compaction because audit logging failed.
-		e := &audit.Entry{
-			Kind:     "llm_call",
-			Actor:    h.auditActor,
-			IssueID:  issue.ID,
-			Model:    string(h.model),
-			Prompt:   prompt,
-			Response: resp,
-		}
-		if callErr != nil {
-			e.Error = callErr.Error()
-		}
-		_, _ = audit.Append(e)
+		recordLLMCall(h.auditActor, issueID, string(h.model), prompt, resp, callErr)
 	}
 	return resp, callErr
 }
@@ -159,6 +166,11 @@ func isRetryable(err error) bool {
 		return false
 	}
 
+	var statusErr *openAIStatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode == 429 || statusErr.StatusCode >= 500
+	}
+
 	return false
 }
 
@@ -170,22 +182,18 @@ type tier1Data struct {
 	Notes              string
 }
 
-func (h *HaikuClient) renderTier1Prompt(issue *types.Issue) (string, error) {
-	var buf []byte
-	w := &bytesWriter{buf: buf}
-
-	data := tier1Data{
+func newTier1Data(issue *types.Issue) tier1Data {
+	return tier1Data{
 		Title:              issue.Title,
 		Description:        issue.Description,
 		Design:             issue.Design,
 		AcceptanceCriteria: issue.AcceptanceCriteria,
 		Notes:              issue.Notes,
 	}
+}
 
-	if err := h.tier1Template.Execute(w, data); err != nil {
-		return "", err
-	}
-	return string(w.buf), nil
+func (h *HaikuClient) renderTier1Prompt(issue *types.Issue) (string, error) {
+	return renderPrompt(h.tier1Template, newTier1Data(issue))
 }
 
 type bytesWriter struct {
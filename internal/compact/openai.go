@@ -0,0 +1,214 @@
+package compact
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+const (
+	defaultOpenAIEndpoint = "https://api.openai.com/v1"
+	defaultOpenAIModel    = "gpt-4o-mini"
+	openAIRequestTimeout  = 2 * time.Minute
+)
+
+// OpenAIClient summarizes issues through any OpenAI-compatible chat
+// completions endpoint, including local model servers (Ollama, llama.cpp,
+// vLLM, LM Studio). The API key is optional for servers that don't need one.
+type OpenAIClient struct {
+	httpClient     *http.Client
+	url            string
+	model          string
+	apiKey         string
+	tier1Template  *template.Template
+	tier2Template  *template.Template
+	maxRetries     int
+	initialBackoff time.Duration
+	auditEnabled   bool
+	auditActor     string
+}
+
+// openAIStatusError is a non-2xx response from the endpoint.
+type openAIStatusError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *openAIStatusError) Error() string {
+	return fmt.Sprintf("endpoint returned %d: %s", e.StatusCode, e.Message)
+}
+
+// NewOpenAIClient creates a client for the chat completions API under
+// endpoint (e.g. http://localhost:11434/v1). Empty endpoint and model fall
+// back to the OpenAI API and gpt-4o-mini.
+func NewOpenAIClient(endpoint, model, apiKey string) (*OpenAIClient, error) {
+	if endpoint == "" {
+		endpoint = defaultOpenAIEndpoint
+	}
+	if model == "" {
+		model = defaultOpenAIModel
+	}
+	url := strings.TrimRight(endpoint, "/")
+	if !strings.HasSuffix(url, "/chat/completions") {
+		url += "/chat/completions"
+	}
+	if !strings.HasPrefix(url, "http://") && !strings.HasPrefix(url, "https://") {
+		return nil, fmt.Errorf("invalid endpoint %q: must start with http:// or https://", endpoint)
+	}
+
+	tier1Tmpl, err := template.New("tier1").Parse(tier1PromptTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse tier1 template: %w", err)
+	}
+	tier2Tmpl, err := template.New("tier2").Parse(tier2PromptTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse tier2 template: %w", err)
+	}
+
+	return &OpenAIClient{
+		httpClient:     &http.Client{Timeout: openAIRequestTimeout},
+		url:            url,
+		model:          model,
+		apiKey:         apiKey,
+		tier1Template:  tier1Tmpl,
+		tier2Template:  tier2Tmpl,
+		maxRetries:     maxRetries,
+		initialBackoff: initialBackoff,
+	}, nil
+}
+
+// SummarizeTier1 creates a structured summary of an issue (Summary, Key Decisions, Resolution).
+func (o *OpenAIClient) SummarizeTier1(ctx context.Context, issue *types.Issue) (string, error) {
+	prompt, err := renderPrompt(o.tier1Template, newTier1Data(issue))
+	if err != nil {
+		return "", fmt.Errorf("failed to render prompt: %w", err)
+	}
+	return o.summarize(ctx, issue.ID, prompt)
+}
+
+// SummarizeTier2 rolls an epic and its children into one archival summary.
+func (o *OpenAIClient) SummarizeTier2(ctx context.Context, epic *types.Issue, children []*types.Issue) (string, error) {
+	prompt, err := renderPrompt(o.tier2Template, newTier2Data(epic, children))
+	if err != nil {
+		return "", fmt.Errorf("failed to render prompt: %w", err)
+	}
+	return o.summarize(ctx, epic.ID, prompt)
+}
+
+func (o *OpenAIClient) summarize(ctx context.Context, issueID, prompt string) (string, error) {
+	resp, callErr := o.callWithRetry(ctx, prompt)
+	if o.auditEnabled {
+		recordLLMCall(o.auditActor, issueID, o.model, prompt, resp, callErr)
+	}
+	return resp, callErr
+}
+
+type openAIMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type openAIRequest struct {
+	Model     string          `json:"model"`
+	Messages  []openAIMessage `json:"messages"`
+	MaxTokens int             `json:"max_tokens"`
+}
+
+type openAIResponse struct {
+	Choices []struct {
+		Message openAIMessage `json:"message"`
+	} `json:"choices"`
+	Error *struct {
+		Message string `json:"message"`
+	} `json:"error,omitempty"`
+}
+
+func (o *OpenAIClient) callWithRetry(ctx context.Context, prompt string) (string, error) {
+	body, err := json.Marshal(openAIRequest{
+		Model:     o.model,
+		Messages:  []openAIMessage{{Role: "user", Content: prompt}},
+		MaxTokens: 1024,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to encode request: %w", err)
+	}
+
+	var lastErr error
+	for attempt := 0; attempt <= o.maxRetries; attempt++ {
+		if attempt > 0 {
+			backoff := o.initialBackoff * time.Duration(math.Pow(2, float64(attempt-1)))
+			select {
+			case <-time.After(backoff):
+			case <-ctx.Done():
+				return "", ctx.Err()
+			}
+		}
+
+		text, err := o.call(ctx, body)
+		if err == nil {
+			return text, nil
+		}
+		lastErr = err
+
+		if ctx.Err() != nil {
+			return "", ctx.Err()
+		}
+		if !isRetryable(err) {
+			return "", fmt.Errorf("non-retryable error: %w", err)
+		}
+	}
+
+	return "", fmt.Errorf("failed after %d retries: %w", o.maxRetries+1, lastErr)
+}
+
+func (o *OpenAIClient) call(ctx context.Context, body []byte) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, o.url, bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if o.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+o.apiKey)
+	}
+
+	resp, err := o.httpClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 10*1024*1024))
+	if err != nil {
+		return "", fmt.Errorf("failed to read response: %w", err)
+	}
+
+	var parsed openAIResponse
+	parseErr := json.Unmarshal(data, &parsed)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		msg := strings.TrimSpace(string(data))
+		if parseErr == nil && parsed.Error != nil && parsed.Error.Message != "" {
+			msg = parsed.Error.Message
+		}
+		return "", &openAIStatusError{StatusCode: resp.StatusCode, Message: truncateBytes(msg, 200)}
+	}
+	if parseErr != nil {
+		return "", fmt.Errorf("unexpected response format: %w", parseErr)
+	}
+	if len(parsed.Choices) == 0 {
+		return "", fmt.Errorf("unexpected response format: no choices")
+	}
+	text := strings.TrimSpace(parsed.Choices[0].Message.Content)
+	if text == "" {
+		return "", fmt.Errorf("unexpected response format: empty message")
+	}
+	return text, nil
+}
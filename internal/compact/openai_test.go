@@ -0,0 +1,94 @@
+package compact
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestOpenAIClient_Summarize(t *testing.T) {
+	var got openAIRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/chat/completions" {
+			t.Errorf("path = %s", r.URL.Path)
+		}
+		if auth := r.Header.Get("Authorization"); auth != "Bearer sk-test" {
+			t.Errorf("authorization = %q", auth)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("bad request body: %v", err)
+		}
+		_, _ = w.Write([]byte(`{"choices":[{"message":{"role":"assistant","content":"  **Summary:** short  "}}]}`))
+	}))
+	defer server.Close()
+
+	client, err := NewOpenAIClient(server.URL+"/v1", "llama3", "sk-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	summary, err := client.SummarizeTier1(context.Background(), &types.Issue{ID: "bd-1", Title: "Fix login"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if summary != "**Summary:** short" {
+		t.Errorf("summary = %q", summary)
+	}
+	if got.Model != "llama3" || len(got.Messages) != 1 || !strings.Contains(got.Messages[0].Content, "Fix login") {
+		t.Errorf("request = %+v", got)
+	}
+}
+
+func TestOpenAIClient_Retries(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if r.Header.Get("Authorization") != "" {
+			t.Errorf("no key configured, got authorization header")
+		}
+		if calls == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = w.Write([]byte(`{"error":{"message":"loading model"}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"choices":[{"message":{"content":"ok"}}]}`))
+	}))
+	defer server.Close()
+
+	client, err := NewOpenAIClient(server.URL, "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.initialBackoff = time.Millisecond
+	summary, err := client.SummarizeTier2(context.Background(), &types.Issue{ID: "bd-1"}, nil)
+	if err != nil || summary != "ok" || calls != 2 {
+		t.Fatalf("summary = %q, err = %v, calls = %d", summary, err, calls)
+	}
+}
+
+func TestOpenAIClient_NonRetryable(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"error":{"message":"model not found"}}`))
+	}))
+	defer server.Close()
+
+	client, err := NewOpenAIClient(server.URL, "missing", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = client.SummarizeTier1(context.Background(), &types.Issue{ID: "bd-1"})
+	if err == nil || !strings.Contains(err.Error(), "model not found") || calls != 1 {
+		t.Fatalf("err = %v, calls = %d", err, calls)
+	}
+	if isRetryable(&openAIStatusError{StatusCode: 429}) != true {
+		t.Error("429 should be retryable")
+	}
+}
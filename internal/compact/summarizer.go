@@ -0,0 +1,172 @@
+package compact
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/anthropics/anthropic-sdk-go"
+	"github.com/steveyegge/beads/internal/audit"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// Summarizer backends selectable with Config.Summarizer or the
+// compact_summarizer config key.
+const (
+	SummarizerAnthropic  = "anthropic"
+	SummarizerOpenAI     = "openai"
+	SummarizerExtractive = "extractive"
+)
+
+// Summarizers lists the supported summarizer backends.
+var Summarizers = []string{SummarizerAnthropic, SummarizerOpenAI, SummarizerExtractive}
+
+type configStore interface {
+	GetConfig(ctx context.Context, key string) (string, error)
+}
+
+// LoadSummarizerConfig fills Summarizer, Model and Endpoint from the
+// compact_summarizer, compact_model and compact_endpoint config keys where
+// they are not already set.
+func (c *Config) LoadSummarizerConfig(ctx context.Context, store configStore) error {
+	for key, field := range map[string]*string{
+		"compact_summarizer": &c.Summarizer,
+		"compact_model":      &c.Model,
+		"compact_endpoint":   &c.Endpoint,
+	} {
+		if *field != "" {
+			continue
+		}
+		value, err := store.GetConfig(ctx, key)
+		if err != nil {
+			return fmt.Errorf("failed to get %s: %w", key, err)
+		}
+		*field = strings.TrimSpace(value)
+	}
+	return nil
+}
+
+// newSummarizer creates the backend named by config.Summarizer.
+func newSummarizer(config *Config) (summarizer, error) {
+	switch config.Summarizer {
+	case "", SummarizerAnthropic:
+		client, err := NewHaikuClient(config.APIKey)
+		if err != nil {
+			return nil, err
+		}
+		if config.Model != "" {
+			client.model = anthropic.Model(config.Model)
+		}
+		client.auditEnabled = config.AuditEnabled
+		client.auditActor = config.Actor
+		return client, nil
+	case SummarizerOpenAI:
+		client, err := NewOpenAIClient(config.Endpoint, config.Model, config.APIKey)
+		if err != nil {
+			return nil, err
+		}
+		client.auditEnabled = config.AuditEnabled
+		client.auditActor = config.Actor
+		return client, nil
+	case SummarizerExtractive:
+		return NewExtractiveSummarizer(), nil
+	}
+	return nil, fmt.Errorf("unknown summarizer %q (valid: %s)", config.Summarizer, strings.Join(Summarizers, ", "))
+}
+
+type tier2Child struct {
+	ID          string
+	Type        string
+	Title       string
+	CloseReason string
+	Content     string
+}
+
+type tier2Data struct {
+	Title    string
+	Content  string
+	Children []tier2Child
+}
+
+func newTier2Data(epic *types.Issue, children []*types.Issue) tier2Data {
+	data := tier2Data{Title: epic.Title, Content: issueContent(epic)}
+	for _, child := range children {
+		data.Children = append(data.Children, tier2Child{
+			ID:          child.ID,
+			Type:        string(child.IssueType),
+			Title:       child.Title,
+			CloseReason: child.CloseReason,
+			Content:     issueContent(child),
+		})
+	}
+	return data
+}
+
+// issueContent joins the compactable text fields of an issue.
+func issueContent(issue *types.Issue) string {
+	var parts []string
+	for _, field := range []struct{ label, text string }{
+		{"", issue.Description},
+		{"Design", issue.Design},
+		{"Acceptance Criteria", issue.AcceptanceCriteria},
+		{"Notes", issue.Notes},
+	} {
+		text := strings.TrimSpace(field.text)
+		if text == "" {
+			continue
+		}
+		if field.label != "" {
+			text = field.label + ": " + text
+		}
+		parts = append(parts, text)
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+func renderPrompt(tmpl *template.Template, data interface{}) (string, error) {
+	w := &bytesWriter{}
+	if err := tmpl.Execute(w, data); err != nil {
+		return "", err
+	}
+	return string(w.buf), nil
+}
+
+// recordLLMCall appends the prompt and response of a summarizer call to the
+// audit log. Best-effort: never fail compaction because audit logging failed.
+func recordLLMCall(actor, issueID, model, prompt, resp string, callErr error) {
+	e := &audit.Entry{
+		Kind:     "llm_call",
+		Actor:    actor,
+		IssueID:  issueID,
+		Model:    model,
+		Prompt:   prompt,
+		Response: resp,
+	}
+	if callErr != nil {
+		e.Error = callErr.Error()
+	}
+	_, _ = audit.Append(e)
+}
+
+const tier2PromptTemplate = `You are archiving a finished epic and all of its child issues for long-term storage. Roll everything into ONE archival summary. The output MUST be far shorter than the input while keeping what a future reader needs: what was delivered, the decisions that still matter, and which child issue did what.
+
+**Epic:** {{.Title}}
+
+{{.Content}}
+
+**Child issues ({{len .Children}}):**
+{{range .Children}}
+- {{.ID}} [{{.Type}}] {{.Title}}{{if .CloseReason}} (closed: {{.CloseReason}}){{end}}
+{{if .Content}}{{.Content}}
+{{end}}{{end}}
+
+IMPORTANT: Your summary must be much shorter than the original. Mention child issues by ID only where it helps.
+
+Provide a summary in this exact format:
+
+**Summary:** [2-3 concise sentences covering what the epic delivered and why]
+
+**Key Decisions:** [Brief bullet points of only the most important technical choices]
+
+**Children:** [One short line per notable child issue, prefixed with its ID]`
@@ -0,0 +1,136 @@
+package compact
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+type mapConfigStore map[string]string
+
+func (m mapConfigStore) GetConfig(_ context.Context, key string) (string, error) {
+	return m[key], nil
+}
+
+func TestLoadSummarizerConfig(t *testing.T) {
+	config := &Config{Model: "from-flag"}
+	store := mapConfigStore{
+		"compact_summarizer": "openai",
+		"compact_model":      "from-config",
+		"compact_endpoint":   " http://localhost:11434/v1 ",
+	}
+	if err := config.LoadSummarizerConfig(context.Background(), store); err != nil {
+		t.Fatal(err)
+	}
+	if config.Summarizer != "openai" || config.Model != "from-flag" || config.Endpoint != "http://localhost:11434/v1" {
+		t.Fatalf("config = %+v", config)
+	}
+}
+
+func TestNewSummarizer(t *testing.T) {
+	t.Setenv("ANTHROPIC_API_KEY", "")
+
+	if _, err := newSummarizer(&Config{}); err == nil {
+		t.Error("anthropic backend without a key should fail")
+	}
+	if s, err := newSummarizer(&Config{Summarizer: SummarizerExtractive}); err != nil {
+		t.Errorf("extractive: %v", err)
+	} else if _, ok := s.(*ExtractiveSummarizer); !ok {
+		t.Errorf("extractive backend is %T", s)
+	}
+	s, err := newSummarizer(&Config{Summarizer: SummarizerOpenAI, Endpoint: "http://localhost:8080/v1/", Model: "llama3"})
+	if err != nil {
+		t.Fatalf("openai: %v", err)
+	}
+	if client := s.(*OpenAIClient); client.url != "http://localhost:8080/v1/chat/completions" || client.model != "llama3" {
+		t.Errorf("openai client url=%s model=%s", client.url, client.model)
+	}
+	if _, err := newSummarizer(&Config{Summarizer: SummarizerOpenAI, Endpoint: "localhost:8080"}); err == nil {
+		t.Error("endpoint without scheme should fail")
+	}
+	if _, err := newSummarizer(&Config{Summarizer: "gpt"}); err == nil || !strings.Contains(err.Error(), "unknown summarizer") {
+		t.Errorf("unknown backend: %v", err)
+	}
+}
+
+func TestLeadSentences(t *testing.T) {
+	tests := []struct {
+		text string
+		n    int
+		want string
+	}{
+		{"First one. Second one! Third?", 2, "First one. Second one!"},
+		{"## Heading\n\n- item one. more\n- item two", 1, "item one."},
+		{"Version 1.2 is out. Done.", 1, "Version 1.2 is out."},
+		{"No terminator", 2, "No terminator"},
+		{"", 1, ""},
+	}
+	for _, tt := range tests {
+		if got := leadSentences(tt.text, tt.n); got != tt.want {
+			t.Errorf("leadSentences(%q, %d) = %q, want %q", tt.text, tt.n, got, tt.want)
+		}
+	}
+	long := strings.Repeat("word ", 100)
+	if got := leadSentences(long, 1); len(got) > extractiveSentenceLimit || !strings.HasSuffix(got, "…") {
+		t.Errorf("long text not truncated: %d bytes", len(got))
+	}
+}
+
+func TestExtractiveSummarizer(t *testing.T) {
+	e := NewExtractiveSummarizer()
+	issue := &types.Issue{
+		ID:          "bd-1",
+		Title:       "Fix login",
+		Description: "Users could not log in with OAuth. The callback dropped the state. Lots more detail follows here.",
+		Design:      "Keep state in a signed cookie. Rejected server sessions.",
+		CloseReason: "Fixed in v1.4",
+	}
+	got, err := e.SummarizeTier1(context.Background(), issue)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "**Summary:** Users could not log in with OAuth. The callback dropped the state.\n\n" +
+		"**Key Decisions:** Keep state in a signed cookie.\n\n" +
+		"**Resolution:** Fixed in v1.4"
+	if got != want {
+		t.Errorf("tier 1 summary:\n%s\nwant:\n%s", got, want)
+	}
+	again, _ := e.SummarizeTier1(context.Background(), issue)
+	if again != got {
+		t.Error("extractive summaries should be deterministic")
+	}
+
+	epic := &types.Issue{ID: "bd-9", Title: "Auth overhaul"}
+	children := []*types.Issue{
+		{ID: "bd-9.1", Title: "Cookies", CloseReason: "Done. Shipped."},
+		{ID: "bd-9.2", Title: "Sessions"},
+	}
+	got, err = e.SummarizeTier2(context.Background(), epic, children)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want = "**Summary:** Auth overhaul\n\n**Children (2):**\n- bd-9.1: Cookies (Done.)\n- bd-9.2: Sessions"
+	if got != want {
+		t.Errorf("tier 2 summary:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestRenderTier2Prompt(t *testing.T) {
+	client, err := NewOpenAIClient("", "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	epic := &types.Issue{ID: "bd-9", Title: "Auth overhaul", Description: "Replace sessions", Notes: "Ship by Q3"}
+	children := []*types.Issue{{ID: "bd-9.1", Title: "Cookies", IssueType: types.TypeTask, CloseReason: "Done", Design: "Signed"}}
+	prompt, err := renderPrompt(client.tier2Template, newTier2Data(epic, children))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"**Epic:** Auth overhaul", "Notes: Ship by Q3", "**Child issues (1):**", "- bd-9.1 [task] Cookies (closed: Done)", "Design: Signed", "**Children:**"} {
+		if !strings.Contains(prompt, want) {
+			t.Errorf("prompt missing %q:\n%s", want, prompt)
+		}
+	}
+}
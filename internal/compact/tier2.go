@@ -0,0 +1,166 @@
+package compact
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// CompactTier2 rolls a closed epic and its closed descendants into a single
+// archival summary on the epic. The original content and events of every
+// issue involved move to issue_snapshots, from where bd restore brings them
+// back. Issues other than epics are compacted on their own.
+func (c *Compactor) CompactTier2(ctx context.Context, issueID string) (*Result, error) {
+	result := &Result{IssueID: issueID}
+	epic, children, err := c.prepareTier2(ctx, issueID, result)
+	if err != nil {
+		return result, err
+	}
+
+	if c.config.DryRun {
+		return result, fmt.Errorf("dry-run: would roll up %s and %d children (original size: %d bytes)", issueID, len(children), result.OriginalSize)
+	}
+
+	return result, c.summarizeTier2(ctx, epic, children, result)
+}
+
+// CompactTier2Batch performs tier-2 compaction on multiple issues. Rollups are
+// done one at a time because nested epics share descendants.
+func (c *Compactor) CompactTier2Batch(ctx context.Context, issueIDs []string) ([]*Result, error) {
+	results := make([]*Result, 0, len(issueIDs))
+	for _, id := range issueIDs {
+		if ctx.Err() != nil {
+			return results, ctx.Err()
+		}
+		result := &Result{IssueID: id}
+		results = append(results, result)
+
+		epic, children, err := c.prepareTier2(ctx, id, result)
+		if err != nil {
+			result.Err = err
+			continue
+		}
+		if c.config.DryRun {
+			continue
+		}
+		if err := c.summarizeTier2(ctx, epic, children, result); err != nil {
+			result.Err = err
+		}
+	}
+	return results, nil
+}
+
+// ApplyTier2 performs tier-2 compaction of an issue with a summary written
+// elsewhere, such as an agent's summary passed to bd compact --apply. force
+// skips the eligibility and size checks.
+func (c *Compactor) ApplyTier2(ctx context.Context, issueID, summary, actor string, force bool) (*Result, error) {
+	result := &Result{IssueID: issueID}
+	var epic *types.Issue
+	var children []*types.Issue
+	var err error
+	if force {
+		epic, children, err = c.tier2Inputs(ctx, issueID, result)
+	} else {
+		epic, children, err = c.prepareTier2(ctx, issueID, result)
+	}
+	if err != nil {
+		return result, err
+	}
+
+	result.CompactedSize = len(summary)
+	if !force && result.CompactedSize >= result.OriginalSize {
+		return result, fmt.Errorf("summary (%d bytes) is not shorter than original (%d bytes)", result.CompactedSize, result.OriginalSize)
+	}
+	return result, c.applyTier2(ctx, epic, children, summary, actor, result)
+}
+
+func (c *Compactor) prepareTier2(ctx context.Context, issueID string, result *Result) (*types.Issue, []*types.Issue, error) {
+	if ctx.Err() != nil {
+		return nil, nil, ctx.Err()
+	}
+
+	eligible, reason, err := c.store.CheckEligibility(ctx, issueID, 2)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to verify eligibility: %w", err)
+	}
+	if !eligible {
+		if reason != "" {
+			return nil, nil, fmt.Errorf("issue %s is not eligible for Tier 2 compaction: %s", issueID, reason)
+		}
+		return nil, nil, fmt.Errorf("issue %s is not eligible for Tier 2 compaction", issueID)
+	}
+
+	return c.tier2Inputs(ctx, issueID, result)
+}
+
+// tier2Inputs loads the epic and the descendants that will be rolled into it:
+// closed, not pinned and not already archived.
+func (c *Compactor) tier2Inputs(ctx context.Context, issueID string, result *Result) (*types.Issue, []*types.Issue, error) {
+	epic, err := c.store.GetIssue(ctx, issueID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get issue: %w", err)
+	}
+	if epic == nil {
+		return nil, nil, fmt.Errorf("issue %s not found", issueID)
+	}
+	descendants, err := c.store.GetEpicDescendants(ctx, issueID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get children: %w", err)
+	}
+
+	var children []*types.Issue
+	result.OriginalSize = issueSize(epic)
+	for _, child := range descendants {
+		if child.Status != types.StatusClosed || child.Pinned || child.CompactionLevel >= 2 {
+			continue
+		}
+		children = append(children, child)
+		result.Children = append(result.Children, child.ID)
+		result.OriginalSize += issueSize(child)
+	}
+	return epic, children, nil
+}
+
+func (c *Compactor) summarizeTier2(ctx context.Context, epic *types.Issue, children []*types.Issue, result *Result) error {
+	if c.summarizer == nil {
+		return fmt.Errorf("summarizer not configured")
+	}
+	summary, err := c.summarizer.SummarizeTier2(ctx, epic, children)
+	if err != nil {
+		return fmt.Errorf("failed to summarize: %w", err)
+	}
+
+	result.CompactedSize = len(summary)
+	if result.CompactedSize >= result.OriginalSize {
+		warningMsg := fmt.Sprintf("Tier 2 compaction skipped: summary (%d bytes) not shorter than original (%d bytes)", result.CompactedSize, result.OriginalSize)
+		if err := c.store.AddComment(ctx, epic.ID, "compactor", warningMsg); err != nil {
+			return fmt.Errorf("failed to record warning: %w", err)
+		}
+		return fmt.Errorf("compaction would increase size (%d → %d bytes), keeping original", result.OriginalSize, result.CompactedSize)
+	}
+
+	return c.applyTier2(ctx, epic, children, summary, "compactor", result)
+}
+
+// applyTier2 archives the epic under summary and each child under a stub
+// pointing at the epic, in a single transaction so that a rollup is never
+// left half done.
+func (c *Compactor) applyTier2(ctx context.Context, epic *types.Issue, children []*types.Issue, summary, actor string, result *Result) error {
+	childIDs := make([]string, len(children))
+	for i, child := range children {
+		childIDs[i] = child.ID
+	}
+	stub := fmt.Sprintf("Archived into %s by tier 2 compaction. Run `bd restore %s` for the original.", epic.ID, epic.ID)
+	savingBytes := result.OriginalSize - len(summary)
+	eventData := fmt.Sprintf("Tier 2 compaction: rolled up %d children, %d → %d bytes (saved %d)", len(children), result.OriginalSize, len(summary), savingBytes)
+
+	if err := c.store.ArchiveRollup(ctx, epic.ID, childIDs, summary, stub, result.OriginalSize, GetCurrentCommitHash(), actor, eventData); err != nil {
+		return fmt.Errorf("failed to archive rollup: %w", err)
+	}
+	return nil
+}
+
+func issueSize(issue *types.Issue) int {
+	return len(issue.Description) + len(issue.Design) + len(issue.Notes) + len(issue.AcceptanceCriteria)
+}
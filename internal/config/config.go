@@ -138,6 +138,10 @@ func Initialize() error {
 
 	// Create command defaults
 	v.SetDefault("create.require-description", false)
+	v.SetDefault("create.warn-similar", true) // Warn when a new issue looks like an open one
+
+	// Near-duplicate detection (bd duplicates --similar, bd create warning)
+	v.SetDefault("duplicates.similarity-threshold", 0.35)
 
 	// Validation configuration defaults (bd-t7jq)
 	// Values: "warn" | "error" | "none"
@@ -164,6 +168,31 @@ func Initialize() error {
 	// Maps project names to paths for resolving external: blocked_by references
 	v.SetDefault("external_projects", map[string]string{})
 
+	// Outbound webhook retry policy (endpoints are configured under webhooks.endpoints)
+	v.SetDefault("webhooks.max-attempts", 8)
+	v.SetDefault("webhooks.initial-backoff", "2s")
+	v.SetDefault("webhooks.max-backoff", "10m")
+	v.SetDefault("webhooks.timeout", "10s")
+
+	// Daemon HTTP/JSON gateway (off by default; bearer token required)
+	v.SetDefault("daemon.http.enabled", false)
+	v.SetDefault("daemon.http.addr", DefaultHTTPGatewayAddr)
+	v.SetDefault("daemon.http.token-env", "BD_HTTP_TOKEN")
+
+	// Daemon Prometheus metrics listener (off by default; no auth)
+	v.SetDefault("daemon.metrics.enabled", false)
+	v.SetDefault("daemon.metrics.addr", DefaultMetricsAddr)
+
+	// Scan for closed recurring issues that still need their next occurrence
+	v.SetDefault("daemon.recurrence.interval", "1m")
+
+	// Return in_progress issues whose claim lease lapsed to open
+	v.SetDefault("daemon.leases.interval", "30s")
+
+	// Attachments: per-file size limit, and whether blobs go through git LFS
+	v.SetDefault("attachments.max-size", "10MB")
+	v.SetDefault("attachments.lfs", false)
+
 	// Read config file if it was found
 	if configFileSet {
 		if err := v.ReadInConfig(); err != nil {
@@ -359,6 +388,14 @@ func GetInt(key string) int {
 	return v.GetInt(key)
 }
 
+// GetFloat64 retrieves a floating-point configuration value
+func GetFloat64(key string) float64 {
+	if v == nil {
+		return 0
+	}
+	return v.GetFloat64(key)
+}
+
 // GetDuration retrieves a duration configuration value
 func GetDuration(key string) time.Duration {
 	if v == nil {
@@ -367,6 +404,14 @@ func GetDuration(key string) time.Duration {
 	return v.GetDuration(key)
 }
 
+// GetSizeInBytes retrieves a size configuration value such as "10MB"
+func GetSizeInBytes(key string) uint {
+	if v == nil {
+		return 0
+	}
+	return v.GetSizeInBytes(key)
+}
+
 // Set sets a configuration value
 func Set(key string, value interface{}) {
 	if v != nil {
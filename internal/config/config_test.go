@@ -247,6 +247,11 @@ func TestSetAndGet(t *testing.T) {
 	if got := GetInt("test-int"); got != 42 {
 		t.Errorf("GetInt(test-int) = %d, want 42", got)
 	}
+
+	Set("test-float", 0.35)
+	if got := GetFloat64("test-float"); got != 0.35 {
+		t.Errorf("GetFloat64(test-float) = %v, want 0.35", got)
+	}
 }
 
 func TestAllSettings(t *testing.T) {
@@ -426,6 +431,10 @@ func TestNilViperBehavior(t *testing.T) {
 		t.Errorf("GetInt with nil viper = %d, want 0", got)
 	}
 
+	if got := GetFloat64("any-key"); got != 0 {
+		t.Errorf("GetFloat64 with nil viper = %v, want 0", got)
+	}
+
 	if got := GetDuration("any-key"); got != 0 {
 		t.Errorf("GetDuration with nil viper = %v, want 0", got)
 	}
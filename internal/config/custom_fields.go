@@ -0,0 +1,50 @@
+package config
+
+import "fmt"
+
+// CustomFieldConfig defines one custom field in config.yaml.
+type CustomFieldConfig struct {
+	// Type is one of string, int, enum, date, user or url.
+	Type string `mapstructure:"type"`
+	// Values lists the allowed values of an enum field.
+	Values []string `mapstructure:"values"`
+	// Required lists the issue types that must have the field set; "*"
+	// requires it on every type.
+	Required []string `mapstructure:"required"`
+	// Description is shown by bd fields.
+	Description string `mapstructure:"description"`
+}
+
+// GetCustomFieldsConfig returns the custom field definitions keyed by field
+// name, or nil if none are configured.
+//
+// Config key: custom_fields
+// Example:
+//
+//	custom_fields:
+//	  points:
+//	    type: int
+//	    required: [feature, bug]
+//	  severity:
+//	    type: enum
+//	    values: [low, medium, high, critical]
+//	    required: [bug]
+//	  customer:
+//	    type: string
+//	    description: Customer who reported the issue
+//	  review_date:
+//	    type: date
+//	  reviewer:
+//	    type: user
+//	  spec:
+//	    type: url
+func GetCustomFieldsConfig() (map[string]CustomFieldConfig, error) {
+	if v == nil || !v.IsSet("custom_fields") {
+		return nil, nil
+	}
+	var fields map[string]CustomFieldConfig
+	if err := v.UnmarshalKey("custom_fields", &fields); err != nil {
+		return nil, fmt.Errorf("invalid custom_fields config: %w", err)
+	}
+	return fields, nil
+}
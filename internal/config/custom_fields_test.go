@@ -0,0 +1,60 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestGetCustomFieldsConfig(t *testing.T) {
+	tmpDir := t.TempDir()
+	beadsDir := filepath.Join(tmpDir, ".beads")
+	if err := os.MkdirAll(beadsDir, 0750); err != nil {
+		t.Fatalf("failed to create .beads directory: %v", err)
+	}
+	configContent := `
+custom_fields:
+  points:
+    type: int
+    required: [feature, bug]
+  severity:
+    type: enum
+    values: [low, high]
+    description: Impact on users
+`
+	if err := os.WriteFile(filepath.Join(beadsDir, "config.yaml"), []byte(configContent), 0600); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+	t.Chdir(tmpDir)
+
+	if err := Initialize(); err != nil {
+		t.Fatalf("Initialize() returned error: %v", err)
+	}
+
+	cfg, err := GetCustomFieldsConfig()
+	if err != nil {
+		t.Fatalf("GetCustomFieldsConfig() returned error: %v", err)
+	}
+	if len(cfg) != 2 {
+		t.Fatalf("GetCustomFieldsConfig() = %v, want 2 fields", cfg)
+	}
+	points := cfg["points"]
+	if points.Type != "int" || len(points.Required) != 2 || points.Required[1] != "bug" {
+		t.Errorf("points = %+v", points)
+	}
+	severity := cfg["severity"]
+	if severity.Type != "enum" || len(severity.Values) != 2 || severity.Description != "Impact on users" {
+		t.Errorf("severity = %+v", severity)
+	}
+}
+
+func TestGetCustomFieldsConfig_Unset(t *testing.T) {
+	t.Chdir(t.TempDir())
+	if err := Initialize(); err != nil {
+		t.Fatalf("Initialize() returned error: %v", err)
+	}
+	cfg, err := GetCustomFieldsConfig()
+	if err != nil || cfg != nil {
+		t.Errorf("GetCustomFieldsConfig() = %v, %v; want nil, nil", cfg, err)
+	}
+}
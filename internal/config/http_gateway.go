@@ -0,0 +1,81 @@
+package config
+
+import (
+	"net"
+	"os"
+	"strings"
+)
+
+// DefaultHTTPGatewayAddr is the listen address used when daemon.http.addr
+// is not set. It only accepts connections from the local machine.
+const DefaultHTTPGatewayAddr = "127.0.0.1:7734"
+
+// HTTPGatewayConfig holds the daemon's optional HTTP/JSON gateway settings.
+type HTTPGatewayConfig struct {
+	Enabled  bool
+	Addr     string // host:port to listen on
+	TokenEnv string // Environment variable holding the bearer token
+}
+
+// Token returns the bearer token from the environment variable named by
+// TokenEnv, or "" if none is set (the daemon then generates one).
+func (c HTTPGatewayConfig) Token() string {
+	if c.TokenEnv == "" {
+		return ""
+	}
+	return strings.TrimSpace(os.Getenv(c.TokenEnv))
+}
+
+// IsLoopback reports whether Addr only binds to the local machine.
+func (c HTTPGatewayConfig) IsLoopback() bool {
+	return isLoopbackAddr(c.Addr)
+}
+
+// GetHTTPGatewayConfig returns the HTTP gateway configuration.
+//
+// Config key: daemon.http
+// Example:
+//
+//	daemon:
+//	  http:
+//	    enabled: true
+//	    addr: 127.0.0.1:7734
+//	    token-env: BD_HTTP_TOKEN
+//
+// An addr without a port gets the default port appended.
+func GetHTTPGatewayConfig() HTTPGatewayConfig {
+	cfg := HTTPGatewayConfig{
+		Enabled:  GetBool("daemon.http.enabled"),
+		Addr:     strings.TrimSpace(GetString("daemon.http.addr")),
+		TokenEnv: strings.TrimSpace(GetString("daemon.http.token-env")),
+	}
+	cfg.Addr = listenAddrWithDefault(cfg.Addr, DefaultHTTPGatewayAddr)
+	return cfg
+}
+
+// listenAddrWithDefault fills in an empty addr, or the port of a bare host,
+// from def.
+func listenAddrWithDefault(addr, def string) string {
+	if addr == "" {
+		return def
+	}
+	if _, _, err := net.SplitHostPort(addr); err != nil {
+		_, port, _ := net.SplitHostPort(def)
+		return net.JoinHostPort(strings.Trim(addr, "[]"), port)
+	}
+	return addr
+}
+
+// isLoopbackAddr reports whether a host:port listen address only binds to
+// the local machine.
+func isLoopbackAddr(addr string) bool {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return false
+	}
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
@@ -0,0 +1,82 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestGetHTTPGatewayConfig(t *testing.T) {
+	tmpDir := t.TempDir()
+	configContent := `
+daemon:
+  http:
+    enabled: true
+    addr: 0.0.0.0
+    token-env: BD_TEST_HTTP_TOKEN
+`
+	beadsDir := filepath.Join(tmpDir, ".beads")
+	if err := os.MkdirAll(beadsDir, 0750); err != nil {
+		t.Fatalf("failed to create .beads directory: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(beadsDir, "config.yaml"), []byte(configContent), 0600); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+	t.Chdir(tmpDir)
+	t.Setenv("BD_TEST_HTTP_TOKEN", " secret \n")
+
+	if err := Initialize(); err != nil {
+		t.Fatalf("Initialize() returned error: %v", err)
+	}
+
+	cfg := GetHTTPGatewayConfig()
+	if !cfg.Enabled {
+		t.Error("Enabled = false, want true")
+	}
+	if cfg.Addr != "0.0.0.0:7734" {
+		t.Errorf("Addr = %q, want default port appended", cfg.Addr)
+	}
+	if cfg.IsLoopback() {
+		t.Error("0.0.0.0 should not be loopback")
+	}
+	if cfg.Token() != "secret" {
+		t.Errorf("Token() = %q, want secret", cfg.Token())
+	}
+}
+
+func TestGetHTTPGatewayConfigDefaults(t *testing.T) {
+	t.Chdir(t.TempDir())
+	if err := Initialize(); err != nil {
+		t.Fatalf("Initialize() returned error: %v", err)
+	}
+
+	cfg := GetHTTPGatewayConfig()
+	if cfg.Enabled {
+		t.Error("gateway should be disabled by default")
+	}
+	if cfg.Addr != DefaultHTTPGatewayAddr || !cfg.IsLoopback() {
+		t.Errorf("Addr = %q, want loopback default %q", cfg.Addr, DefaultHTTPGatewayAddr)
+	}
+	if cfg.TokenEnv != "BD_HTTP_TOKEN" {
+		t.Errorf("TokenEnv = %q, want BD_HTTP_TOKEN", cfg.TokenEnv)
+	}
+}
+
+func TestHTTPGatewayConfigIsLoopback(t *testing.T) {
+	tests := []struct {
+		addr string
+		want bool
+	}{
+		{"127.0.0.1:7734", true},
+		{"localhost:80", true},
+		{"[::1]:7734", true},
+		{"0.0.0.0:7734", false},
+		{":7734", false},
+		{"10.0.0.5:7734", false},
+	}
+	for _, tt := range tests {
+		if got := (HTTPGatewayConfig{Addr: tt.addr}).IsLoopback(); got != tt.want {
+			t.Errorf("IsLoopback(%q) = %v, want %v", tt.addr, got, tt.want)
+		}
+	}
+}
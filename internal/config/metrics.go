@@ -0,0 +1,37 @@
+package config
+
+import "strings"
+
+// DefaultMetricsAddr is the listen address used when daemon.metrics.addr is
+// not set. It only accepts connections from the local machine.
+const DefaultMetricsAddr = "127.0.0.1:9464"
+
+// MetricsConfig holds the daemon's Prometheus metrics listener settings.
+type MetricsConfig struct {
+	Enabled bool
+	Addr    string // host:port to listen on
+}
+
+// IsLoopback reports whether Addr only binds to the local machine.
+func (c MetricsConfig) IsLoopback() bool {
+	return isLoopbackAddr(c.Addr)
+}
+
+// GetMetricsConfig returns the metrics listener configuration.
+//
+// Config key: daemon.metrics
+// Example:
+//
+//	daemon:
+//	  metrics:
+//	    enabled: true
+//	    addr: 127.0.0.1:9464
+//
+// The listener serves only /metrics and is unauthenticated; the HTTP
+// gateway also serves /metrics behind its bearer token.
+func GetMetricsConfig() MetricsConfig {
+	return MetricsConfig{
+		Enabled: GetBool("daemon.metrics.enabled"),
+		Addr:    listenAddrWithDefault(strings.TrimSpace(GetString("daemon.metrics.addr")), DefaultMetricsAddr),
+	}
+}
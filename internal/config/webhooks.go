@@ -0,0 +1,97 @@
+package config
+
+import (
+	"os"
+	"strings"
+	"time"
+)
+
+// WebhookEndpoint is a single outbound webhook target from config.yaml.
+type WebhookEndpoint struct {
+	Name      string   `mapstructure:"name"`
+	URL       string   `mapstructure:"url"`
+	Events    []string `mapstructure:"events"`     // Mutation types to deliver; empty or "*" means all
+	Secret    string   `mapstructure:"secret"`     // HMAC-SHA256 signing secret (prefer secret-env)
+	SecretEnv string   `mapstructure:"secret-env"` // Environment variable holding the secret
+}
+
+// SigningSecret returns the endpoint's HMAC secret, preferring the
+// environment variable named by SecretEnv over the inline Secret.
+func (e WebhookEndpoint) SigningSecret() string {
+	if e.SecretEnv != "" {
+		if s := os.Getenv(e.SecretEnv); s != "" {
+			return s
+		}
+	}
+	return e.Secret
+}
+
+// Matches reports whether the endpoint subscribes to the given mutation type.
+func (e WebhookEndpoint) Matches(eventType string) bool {
+	if len(e.Events) == 0 {
+		return true
+	}
+	for _, ev := range e.Events {
+		ev = strings.TrimSpace(ev)
+		if ev == "*" || strings.EqualFold(ev, eventType) {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookConfig holds the webhook endpoints and the delivery retry policy.
+type WebhookConfig struct {
+	Endpoints      []WebhookEndpoint
+	MaxAttempts    int           // Attempts before a delivery is dead-lettered
+	InitialBackoff time.Duration // Delay before the first retry; doubles each attempt
+	MaxBackoff     time.Duration // Upper bound on the retry delay
+	Timeout        time.Duration // Per-request HTTP timeout
+}
+
+// GetWebhookConfig returns the webhook configuration.
+//
+// Config key: webhooks
+// Example:
+//
+//	webhooks:
+//	  endpoints:
+//	    - name: ci
+//	      url: https://ci.example.com/beads
+//	      events: [create, status]
+//	      secret-env: BD_WEBHOOK_SECRET
+//	  max-attempts: 8
+//	  initial-backoff: 2s
+//	  max-backoff: 10m
+//	  timeout: 10s
+//
+// Endpoints without a URL are skipped; unnamed endpoints are named after
+// their URL.
+func GetWebhookConfig() WebhookConfig {
+	cfg := WebhookConfig{
+		MaxAttempts:    GetInt("webhooks.max-attempts"),
+		InitialBackoff: GetDuration("webhooks.initial-backoff"),
+		MaxBackoff:     GetDuration("webhooks.max-backoff"),
+		Timeout:        GetDuration("webhooks.timeout"),
+	}
+	if v == nil {
+		return cfg
+	}
+
+	var endpoints []WebhookEndpoint
+	if err := v.UnmarshalKey("webhooks.endpoints", &endpoints); err != nil {
+		logConfigWarning("Warning: invalid webhooks.endpoints config: %v\n", err)
+		return cfg
+	}
+	for _, e := range endpoints {
+		if strings.TrimSpace(e.URL) == "" {
+			logConfigWarning("Warning: webhook endpoint %q has no url, skipping\n", e.Name)
+			continue
+		}
+		if e.Name == "" {
+			e.Name = e.URL
+		}
+		cfg.Endpoints = append(cfg.Endpoints, e)
+	}
+	return cfg
+}
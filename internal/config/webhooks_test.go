@@ -0,0 +1,80 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestGetWebhookConfig(t *testing.T) {
+	tmpDir := t.TempDir()
+	configContent := `
+webhooks:
+  endpoints:
+    - name: ci
+      url: https://ci.example.com/beads
+      events: [create, status]
+      secret-env: BD_TEST_WEBHOOK_SECRET
+    - url: https://chat.example.com/hook
+      secret: inline-secret
+    - name: broken
+  max-attempts: 3
+  initial-backoff: 500ms
+`
+	beadsDir := filepath.Join(tmpDir, ".beads")
+	if err := os.MkdirAll(beadsDir, 0750); err != nil {
+		t.Fatalf("failed to create .beads directory: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(beadsDir, "config.yaml"), []byte(configContent), 0600); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+	t.Chdir(tmpDir)
+	t.Setenv("BD_TEST_WEBHOOK_SECRET", "env-secret")
+
+	oldWarnings := ConfigWarnings
+	ConfigWarnings = false
+	defer func() { ConfigWarnings = oldWarnings }()
+
+	if err := Initialize(); err != nil {
+		t.Fatalf("Initialize() returned error: %v", err)
+	}
+
+	cfg := GetWebhookConfig()
+	if len(cfg.Endpoints) != 2 {
+		t.Fatalf("expected 2 endpoints (one without url skipped), got %d", len(cfg.Endpoints))
+	}
+
+	ci := cfg.Endpoints[0]
+	if ci.Name != "ci" || ci.URL != "https://ci.example.com/beads" {
+		t.Errorf("unexpected first endpoint: %+v", ci)
+	}
+	if ci.SigningSecret() != "env-secret" {
+		t.Errorf("SigningSecret() = %q, want env-secret", ci.SigningSecret())
+	}
+	if !ci.Matches("create") || !ci.Matches("STATUS") || ci.Matches("delete") {
+		t.Errorf("event filter mismatch for %v", ci.Events)
+	}
+
+	chat := cfg.Endpoints[1]
+	if chat.Name != chat.URL {
+		t.Errorf("unnamed endpoint should be named after its URL, got %q", chat.Name)
+	}
+	if chat.SigningSecret() != "inline-secret" {
+		t.Errorf("SigningSecret() = %q, want inline-secret", chat.SigningSecret())
+	}
+	if !chat.Matches("anything") {
+		t.Error("endpoint without event filter should match all events")
+	}
+
+	if cfg.MaxAttempts != 3 {
+		t.Errorf("MaxAttempts = %d, want 3", cfg.MaxAttempts)
+	}
+	if cfg.InitialBackoff != 500*time.Millisecond {
+		t.Errorf("InitialBackoff = %v, want 500ms", cfg.InitialBackoff)
+	}
+	// Unset values fall back to defaults
+	if cfg.MaxBackoff != 10*time.Minute || cfg.Timeout != 10*time.Second {
+		t.Errorf("defaults not applied: MaxBackoff=%v Timeout=%v", cfg.MaxBackoff, cfg.Timeout)
+	}
+}
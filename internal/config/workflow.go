@@ -0,0 +1,47 @@
+package config
+
+import "fmt"
+
+// WorkflowRules is the status workflow for one issue type in config.yaml.
+type WorkflowRules struct {
+	// Transitions maps a status to the statuses it may move to. "*" as a
+	// source applies to every status; "*" as a target allows any status.
+	Transitions map[string][]string `mapstructure:"transitions"`
+	// Guards maps a target status to the fields that must be set before an
+	// issue can move to it (assignee, close_reason, acceptance_criteria, ...).
+	Guards map[string][]string `mapstructure:"guards"`
+}
+
+// GetWorkflowConfig returns the status workflow per issue type, or nil if
+// no workflow is configured.
+//
+// Config key: workflow
+// Example:
+//
+//	workflow:
+//	  default:
+//	    transitions:
+//	      open: [in_progress, deferred]
+//	      in_progress: [review, blocked, open]
+//	      review: [closed, in_progress]
+//	      blocked: [in_progress]
+//	      deferred: [open]
+//	      closed: [open]
+//	    guards:
+//	      in_progress: [assignee]
+//	      closed: [close_reason]
+//	  bug:
+//	    transitions: ...
+//
+// Types without their own entry use "default"; when neither exists, the
+// type's status changes are unrestricted.
+func GetWorkflowConfig() (map[string]WorkflowRules, error) {
+	if v == nil || !v.IsSet("workflow") {
+		return nil, nil
+	}
+	var rules map[string]WorkflowRules
+	if err := v.UnmarshalKey("workflow", &rules); err != nil {
+		return nil, fmt.Errorf("invalid workflow config: %w", err)
+	}
+	return rules, nil
+}
@@ -0,0 +1,65 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestGetWorkflowConfig(t *testing.T) {
+	tmpDir := t.TempDir()
+	beadsDir := filepath.Join(tmpDir, ".beads")
+	if err := os.MkdirAll(beadsDir, 0750); err != nil {
+		t.Fatalf("failed to create .beads directory: %v", err)
+	}
+	configContent := `
+workflow:
+  default:
+    transitions:
+      open: [in_progress]
+      closed: []
+      "*": [deferred]
+    guards:
+      closed: [close_reason]
+`
+	if err := os.WriteFile(filepath.Join(beadsDir, "config.yaml"), []byte(configContent), 0600); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+	t.Chdir(tmpDir)
+
+	if err := Initialize(); err != nil {
+		t.Fatalf("Initialize() returned error: %v", err)
+	}
+
+	cfg, err := GetWorkflowConfig()
+	if err != nil {
+		t.Fatalf("GetWorkflowConfig() returned error: %v", err)
+	}
+	rules, ok := cfg["default"]
+	if !ok {
+		t.Fatalf("GetWorkflowConfig() = %v, want a default entry", cfg)
+	}
+	if got := rules.Transitions["open"]; len(got) != 1 || got[0] != "in_progress" {
+		t.Errorf("transitions[open] = %v, want [in_progress]", got)
+	}
+	if got, ok := rules.Transitions["closed"]; !ok || len(got) != 0 {
+		t.Errorf("transitions[closed] = %v (present %v), want empty list", got, ok)
+	}
+	if got := rules.Transitions["*"]; len(got) != 1 || got[0] != "deferred" {
+		t.Errorf("transitions[*] = %v, want [deferred]", got)
+	}
+	if got := rules.Guards["closed"]; len(got) != 1 || got[0] != "close_reason" {
+		t.Errorf("guards[closed] = %v, want [close_reason]", got)
+	}
+}
+
+func TestGetWorkflowConfig_Unset(t *testing.T) {
+	t.Chdir(t.TempDir())
+	if err := Initialize(); err != nil {
+		t.Fatalf("Initialize() returned error: %v", err)
+	}
+	cfg, err := GetWorkflowConfig()
+	if err != nil || cfg != nil {
+		t.Errorf("GetWorkflowConfig() = %v, %v; want nil, nil", cfg, err)
+	}
+}
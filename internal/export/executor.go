@@ -10,9 +10,11 @@ import (
 type DataType string
 
 const (
-	DataTypeCore     DataType = "core"       // Issues and dependencies
-	DataTypeLabels   DataType = "labels"     // Issue labels
-	DataTypeComments DataType = "comments"   // Issue comments
+	DataTypeCore        DataType = "core"        // Issues and dependencies
+	DataTypeLabels      DataType = "labels"      // Issue labels
+	DataTypeComments    DataType = "comments"    // Issue comments
+	DataTypeWorkLog     DataType = "work_log"    // Time-tracking entries
+	DataTypeAttachments DataType = "attachments" // Attachment metadata
 )
 
 // FetchResult holds the result of a data fetch operation
@@ -0,0 +1,177 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/beads/internal/tracker"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// Adapter connects a GitHub repository to the tracker sync engine.
+// Remote issues are identified as "#<number>".
+type Adapter struct {
+	client     *Client
+	config     *MappingConfig
+	milestones *MilestoneCache // Built on first use
+}
+
+var _ tracker.Adapter = (*Adapter)(nil)
+
+// NewAdapter creates a sync adapter for the client's repository.
+func NewAdapter(client *Client, config *MappingConfig) *Adapter {
+	if config == nil {
+		config = DefaultMappingConfig()
+	}
+	return &Adapter{client: client, config: config}
+}
+
+// Name returns "github".
+func (a *Adapter) Name() string { return "github" }
+
+// DisplayName returns "GitHub".
+func (a *Adapter) DisplayName() string { return "GitHub" }
+
+// SyncsLabels reports that labels are pushed to GitHub.
+func (a *Adapter) SyncsLabels() bool { return true }
+
+// remoteIssue wraps a GitHub issue for the sync engine.
+func remoteIssue(gi *Issue) *tracker.RemoteIssue {
+	return &tracker.RemoteIssue{ID: remoteID(gi.Number), UpdatedAt: gi.UpdatedAt, Data: gi}
+}
+
+// githubIssue returns the GitHub issue inside a remote issue.
+func githubIssue(remote *tracker.RemoteIssue) *Issue {
+	return remote.Data.(*Issue)
+}
+
+func remoteID(number int) string {
+	return "#" + strconv.Itoa(number)
+}
+
+// FetchIssues lists repository issues, optionally only those updated since
+// the given time.
+func (a *Adapter) FetchIssues(ctx context.Context, state string, since time.Time) ([]tracker.RemoteIssue, error) {
+	var issues []Issue
+	var err error
+	if since.IsZero() {
+		issues, err = a.client.FetchIssues(ctx, state)
+	} else {
+		issues, err = a.client.FetchIssuesSince(ctx, state, since)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	remote := make([]tracker.RemoteIssue, len(issues))
+	for i := range issues {
+		remote[i] = *remoteIssue(&issues[i])
+	}
+	return remote, nil
+}
+
+// FetchIssue fetches a single issue by "#<number>".
+func (a *Adapter) FetchIssue(ctx context.Context, id string) (*tracker.RemoteIssue, error) {
+	number, err := strconv.Atoi(strings.TrimPrefix(id, "#"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid GitHub issue %q", id)
+	}
+	gi, err := a.client.FetchIssue(ctx, number)
+	if err != nil || gi == nil {
+		return nil, err
+	}
+	return remoteIssue(gi), nil
+}
+
+// request builds the create/update payload for a local issue, resolving its
+// milestone label to a milestone number.
+func (a *Adapter) request(ctx context.Context, issue *types.Issue) (*IssueRequest, error) {
+	req, milestone := IssueToGitHubRequest(issue, a.config)
+	if milestone == "" {
+		return req, nil
+	}
+
+	// Milestones are resolved lazily so pushes without milestones don't
+	// need the extra API call.
+	if a.milestones == nil {
+		cache, err := BuildMilestoneCache(ctx, a.client)
+		if err != nil {
+			return req, fmt.Errorf("failed to resolve milestone %q for %s: %w", milestone, issue.ID, err)
+		}
+		a.milestones = cache
+	}
+	number, err := a.milestones.Number(ctx, milestone)
+	if err != nil {
+		return req, fmt.Errorf("failed to resolve milestone %q for %s: %w", milestone, issue.ID, err)
+	}
+	req.Milestone = &number
+	return req, nil
+}
+
+// CreateIssue creates a GitHub issue, closing it if the local issue is closed.
+func (a *Adapter) CreateIssue(ctx context.Context, issue *types.Issue) (*tracker.RemoteIssue, error) {
+	req, milestoneErr := a.request(ctx, issue)
+
+	gi, err := a.client.CreateIssue(ctx, req)
+	if gi == nil {
+		return nil, err
+	}
+	if err == nil {
+		err = milestoneErr
+	}
+	return remoteIssue(gi), err
+}
+
+// UpdateIssue overwrites a GitHub issue with the local version. If the
+// milestone can't be resolved, the issue keeps its current milestone.
+func (a *Adapter) UpdateIssue(ctx context.Context, remote *tracker.RemoteIssue, issue *types.Issue) error {
+	gi := githubIssue(remote)
+
+	req, milestoneErr := a.request(ctx, issue)
+	if milestoneErr != nil && gi.Milestone != nil {
+		number := gi.Milestone.Number
+		req.Milestone = &number
+	}
+
+	if _, err := a.client.UpdateIssue(ctx, gi.Number, req); err != nil {
+		return err
+	}
+	return milestoneErr
+}
+
+// ToLocal converts a GitHub issue, keeping local values that the label
+// mapping cannot represent.
+func (a *Adapter) ToLocal(remote *tracker.RemoteIssue, local *types.Issue) *types.Issue {
+	issue := IssueToBeads(githubIssue(remote), a.config)
+	if local != nil {
+		PreserveLocalFields(issue, local, a.config)
+	}
+	return issue
+}
+
+// Matches reports whether the GitHub issue already reflects the local issue.
+func (a *Adapter) Matches(local *types.Issue, remote *tracker.RemoteIssue) bool {
+	return IssueMatchesLocal(local, githubIssue(remote), a.config)
+}
+
+// IsExternalRef reports whether ref has the form "gh-<number>".
+func (a *Adapter) IsExternalRef(ref string) bool {
+	return IsGitHubExternalRef(ref)
+}
+
+// ExtractID returns "#<number>" for a "gh-<number>" external ref.
+func (a *Adapter) ExtractID(ref string) string {
+	number, ok := ParseExternalRef(ref)
+	if !ok {
+		return ""
+	}
+	return remoteID(number)
+}
+
+// ExternalRef returns "gh-<number>" for a GitHub issue.
+func (a *Adapter) ExternalRef(remote *tracker.RemoteIssue) string {
+	return FormatExternalRef(githubIssue(remote).Number)
+}
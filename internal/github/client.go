@@ -0,0 +1,383 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ExternalRefPrefix is the external_ref prefix for issues linked to GitHub.
+const ExternalRefPrefix = "gh-"
+
+// NewClient creates a new GitHub client for the given repository.
+func NewClient(token, owner, repo string) *Client {
+	return &Client{
+		Token:    token,
+		Owner:    owner,
+		Repo:     repo,
+		Endpoint: DefaultAPIEndpoint,
+		HTTPClient: &http.Client{
+			Timeout: DefaultTimeout,
+		},
+	}
+}
+
+// WithEndpoint returns a new client configured to use the specified API base URL.
+// This is useful for testing with mock servers or connecting to GitHub Enterprise.
+func (c *Client) WithEndpoint(endpoint string) *Client {
+	return &Client{
+		Token:      c.Token,
+		Owner:      c.Owner,
+		Repo:       c.Repo,
+		Endpoint:   strings.TrimRight(endpoint, "/"),
+		HTTPClient: c.HTTPClient,
+	}
+}
+
+// WithHTTPClient returns a new client configured to use the specified HTTP client.
+// This is useful for testing or customizing timeouts and transport settings.
+func (c *Client) WithHTTPClient(httpClient *http.Client) *Client {
+	return &Client{
+		Token:      c.Token,
+		Owner:      c.Owner,
+		Repo:       c.Repo,
+		Endpoint:   c.Endpoint,
+		HTTPClient: httpClient,
+	}
+}
+
+// ParseRepo splits an "owner/name" repository string.
+func ParseRepo(s string) (owner, repo string, err error) {
+	parts := strings.Split(strings.TrimSpace(s), "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("invalid repository %q (expected \"owner/name\")", s)
+	}
+	return parts[0], parts[1], nil
+}
+
+// repoURL returns the API URL for a path under the configured repository.
+func (c *Client) repoURL(path string) string {
+	return fmt.Sprintf("%s/repos/%s/%s%s", c.Endpoint, url.PathEscape(c.Owner), url.PathEscape(c.Repo), path)
+}
+
+// Do sends a REST request to the GitHub API and returns the response body
+// and headers. A non-nil body is encoded as JSON.
+// Handles rate limiting (429, or 403 with an exhausted quota) with exponential backoff.
+func (c *Client) Do(ctx context.Context, method, reqURL string, body interface{}) ([]byte, http.Header, error) {
+	var payload []byte
+	if body != nil {
+		var err error
+		payload, err = json.Marshal(body)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to marshal request: %w", err)
+		}
+	}
+
+	var lastErr error
+	for attempt := 0; attempt <= MaxRetries; attempt++ {
+		var reader io.Reader
+		if payload != nil {
+			reader = bytes.NewReader(payload)
+		}
+		httpReq, err := http.NewRequestWithContext(ctx, method, reqURL, reader)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to create request: %w", err)
+		}
+
+		httpReq.Header.Set("Accept", "application/vnd.github+json")
+		httpReq.Header.Set("X-GitHub-Api-Version", APIVersion)
+		if payload != nil {
+			httpReq.Header.Set("Content-Type", "application/json")
+		}
+		if c.Token != "" {
+			httpReq.Header.Set("Authorization", "Bearer "+c.Token)
+		}
+
+		resp, err := c.HTTPClient.Do(httpReq)
+		if err != nil {
+			lastErr = fmt.Errorf("request failed (attempt %d/%d): %w", attempt+1, MaxRetries+1, err)
+			continue
+		}
+
+		respBody, err := io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		if err != nil {
+			lastErr = fmt.Errorf("failed to read response (attempt %d/%d): %w", attempt+1, MaxRetries+1, err)
+			continue
+		}
+
+		if isRateLimited(resp) {
+			delay := RetryDelay * time.Duration(1<<attempt) // Exponential backoff
+			lastErr = fmt.Errorf("rate limited (attempt %d/%d), retrying after %v", attempt+1, MaxRetries+1, delay)
+			select {
+			case <-ctx.Done():
+				return nil, nil, ctx.Err()
+			case <-time.After(delay):
+				continue
+			}
+		}
+
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			return nil, resp.Header, &APIError{StatusCode: resp.StatusCode, Message: errorMessage(respBody)}
+		}
+
+		return respBody, resp.Header, nil
+	}
+
+	return nil, nil, fmt.Errorf("max retries (%d) exceeded: %w", MaxRetries+1, lastErr)
+}
+
+// isRateLimited reports whether a response indicates primary or secondary rate limiting.
+func isRateLimited(resp *http.Response) bool {
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+	return resp.StatusCode == http.StatusForbidden && resp.Header.Get("X-RateLimit-Remaining") == "0"
+}
+
+// errorMessage extracts the "message" field from a GitHub error body.
+func errorMessage(body []byte) string {
+	var apiErr struct {
+		Message string `json:"message"`
+	}
+	if err := json.Unmarshal(body, &apiErr); err == nil && apiErr.Message != "" {
+		return apiErr.Message
+	}
+	return strings.TrimSpace(string(body))
+}
+
+// nextPageURL returns the rel="next" URL from a Link header, or "".
+func nextPageURL(header http.Header) string {
+	for _, link := range strings.Split(header.Get("Link"), ",") {
+		parts := strings.Split(link, ";")
+		if len(parts) < 2 {
+			continue
+		}
+		for _, param := range parts[1:] {
+			if strings.TrimSpace(param) == `rel="next"` {
+				return strings.Trim(strings.TrimSpace(parts[0]), "<>")
+			}
+		}
+	}
+	return ""
+}
+
+// FetchIssues retrieves all issues in the repository with optional filtering by state.
+// state can be: "open", "closed", or "all". Pull requests are skipped.
+func (c *Client) FetchIssues(ctx context.Context, state string) ([]Issue, error) {
+	return c.fetchIssues(ctx, state, time.Time{})
+}
+
+// FetchIssuesSince retrieves issues that have been updated since the given time.
+// This enables incremental sync by only fetching issues modified after the last sync.
+// The state parameter can be: "open", "closed", or "all".
+func (c *Client) FetchIssuesSince(ctx context.Context, state string, since time.Time) ([]Issue, error) {
+	issues, err := c.fetchIssues(ctx, state, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch issues since %s: %w", since.UTC().Format(time.RFC3339), err)
+	}
+	return issues, nil
+}
+
+func (c *Client) fetchIssues(ctx context.Context, state string, since time.Time) ([]Issue, error) {
+	if state == "" {
+		state = "all"
+	}
+	params := url.Values{}
+	params.Set("state", state)
+	params.Set("per_page", strconv.Itoa(MaxPageSize))
+	params.Set("sort", "updated")
+	params.Set("direction", "asc")
+	if !since.IsZero() {
+		params.Set("since", since.UTC().Format(time.RFC3339))
+	}
+
+	var allIssues []Issue
+	next := c.repoURL("/issues") + "?" + params.Encode()
+	for next != "" {
+		data, header, err := c.Do(ctx, http.MethodGet, next, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch issues: %w", err)
+		}
+
+		var page []Issue
+		if err := json.Unmarshal(data, &page); err != nil {
+			return nil, fmt.Errorf("failed to parse issues response: %w", err)
+		}
+		for _, issue := range page {
+			if issue.PullRequest == nil {
+				allIssues = append(allIssues, issue)
+			}
+		}
+
+		next = nextPageURL(header)
+	}
+
+	return allIssues, nil
+}
+
+// FetchIssue retrieves a single issue by number.
+// Returns nil if the issue is not found.
+func (c *Client) FetchIssue(ctx context.Context, number int) (*Issue, error) {
+	data, _, err := c.Do(ctx, http.MethodGet, c.repoURL(fmt.Sprintf("/issues/%d", number)), nil)
+	if err != nil {
+		var apiErr *APIError
+		if errors.As(err, &apiErr) && (apiErr.StatusCode == http.StatusNotFound || apiErr.StatusCode == http.StatusGone) {
+			return nil, nil // Issue not found (or deleted)
+		}
+		return nil, fmt.Errorf("failed to fetch issue #%d: %w", number, err)
+	}
+
+	var issue Issue
+	if err := json.Unmarshal(data, &issue); err != nil {
+		return nil, fmt.Errorf("failed to parse issue response: %w", err)
+	}
+	return &issue, nil
+}
+
+// CreateIssue creates a new issue in the repository. GitHub always creates
+// issues open, so when req.State is "closed" the new issue is closed with a
+// follow-up update.
+func (c *Client) CreateIssue(ctx context.Context, req *IssueRequest) (*Issue, error) {
+	create := *req
+	create.State = ""
+	create.StateReason = ""
+
+	data, _, err := c.Do(ctx, http.MethodPost, c.repoURL("/issues"), &create)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create issue: %w", err)
+	}
+
+	var issue Issue
+	if err := json.Unmarshal(data, &issue); err != nil {
+		return nil, fmt.Errorf("failed to parse create response: %w", err)
+	}
+
+	if req.State == "closed" {
+		closed, err := c.UpdateIssue(ctx, issue.Number, req)
+		if err != nil {
+			return &issue, fmt.Errorf("created issue #%d but failed to close it: %w", issue.Number, err)
+		}
+		return closed, nil
+	}
+
+	return &issue, nil
+}
+
+// UpdateIssue replaces the fields of an existing issue with those in req.
+func (c *Client) UpdateIssue(ctx context.Context, number int, req *IssueRequest) (*Issue, error) {
+	data, _, err := c.Do(ctx, http.MethodPatch, c.repoURL(fmt.Sprintf("/issues/%d", number)), req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update issue #%d: %w", number, err)
+	}
+
+	var issue Issue
+	if err := json.Unmarshal(data, &issue); err != nil {
+		return nil, fmt.Errorf("failed to parse update response: %w", err)
+	}
+	return &issue, nil
+}
+
+// FetchMilestones retrieves all milestones (open and closed) in the repository.
+func (c *Client) FetchMilestones(ctx context.Context) ([]Milestone, error) {
+	var all []Milestone
+	next := c.repoURL("/milestones") + fmt.Sprintf("?state=all&per_page=%d", MaxPageSize)
+	for next != "" {
+		data, header, err := c.Do(ctx, http.MethodGet, next, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch milestones: %w", err)
+		}
+
+		var page []Milestone
+		if err := json.Unmarshal(data, &page); err != nil {
+			return nil, fmt.Errorf("failed to parse milestones response: %w", err)
+		}
+		all = append(all, page...)
+
+		next = nextPageURL(header)
+	}
+	return all, nil
+}
+
+// CreateMilestone creates a new open milestone with the given title.
+func (c *Client) CreateMilestone(ctx context.Context, title string) (*Milestone, error) {
+	data, _, err := c.Do(ctx, http.MethodPost, c.repoURL("/milestones"), map[string]string{"title": title})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create milestone %q: %w", title, err)
+	}
+
+	var milestone Milestone
+	if err := json.Unmarshal(data, &milestone); err != nil {
+		return nil, fmt.Errorf("failed to parse milestone response: %w", err)
+	}
+	return &milestone, nil
+}
+
+// MilestoneCache resolves milestone titles to numbers, creating missing
+// milestones on demand.
+type MilestoneCache struct {
+	client  *Client
+	byTitle map[string]int
+}
+
+// BuildMilestoneCache fetches and caches the repository's milestones.
+func BuildMilestoneCache(ctx context.Context, client *Client) (*MilestoneCache, error) {
+	milestones, err := client.FetchMilestones(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	cache := &MilestoneCache{
+		client:  client,
+		byTitle: make(map[string]int, len(milestones)),
+	}
+	for _, m := range milestones {
+		cache.byTitle[m.Title] = m.Number
+	}
+	return cache, nil
+}
+
+// Number returns the milestone number for title, creating the milestone if
+// it does not exist yet.
+func (mc *MilestoneCache) Number(ctx context.Context, title string) (int, error) {
+	if number, ok := mc.byTitle[title]; ok {
+		return number, nil
+	}
+	milestone, err := mc.client.CreateMilestone(ctx, title)
+	if err != nil {
+		return 0, err
+	}
+	mc.byTitle[milestone.Title] = milestone.Number
+	return milestone.Number, nil
+}
+
+// FormatExternalRef returns the external_ref for a GitHub issue number.
+func FormatExternalRef(number int) string {
+	return ExternalRefPrefix + strconv.Itoa(number)
+}
+
+// ParseExternalRef extracts the issue number from a "gh-<number>" external_ref.
+func ParseExternalRef(externalRef string) (int, bool) {
+	if !strings.HasPrefix(externalRef, ExternalRefPrefix) {
+		return 0, false
+	}
+	number, err := strconv.Atoi(strings.TrimPrefix(externalRef, ExternalRefPrefix))
+	if err != nil || number <= 0 {
+		return 0, false
+	}
+	return number, true
+}
+
+// IsGitHubExternalRef checks if an external_ref refers to a GitHub issue.
+func IsGitHubExternalRef(externalRef string) bool {
+	_, ok := ParseExternalRef(externalRef)
+	return ok
+}
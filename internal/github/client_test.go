@@ -0,0 +1,337 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewClient(t *testing.T) {
+	client := NewClient("token", "octo", "repo")
+	if client.Token != "token" || client.Owner != "octo" || client.Repo != "repo" {
+		t.Errorf("unexpected client fields: %+v", client)
+	}
+	if client.Endpoint != DefaultAPIEndpoint {
+		t.Errorf("Endpoint = %s, want %s", client.Endpoint, DefaultAPIEndpoint)
+	}
+	if client.HTTPClient == nil || client.HTTPClient.Timeout != DefaultTimeout {
+		t.Errorf("expected HTTP client with default timeout")
+	}
+}
+
+func TestWithEndpoint(t *testing.T) {
+	client := NewClient("token", "octo", "repo")
+	custom := client.WithEndpoint("https://ghe.example.com/api/v3/")
+
+	if custom.Endpoint != "https://ghe.example.com/api/v3" {
+		t.Errorf("Endpoint = %s, want trailing slash trimmed", custom.Endpoint)
+	}
+	if custom.Token != "token" || custom.Owner != "octo" || custom.Repo != "repo" {
+		t.Errorf("WithEndpoint should preserve other fields: %+v", custom)
+	}
+	if client.Endpoint != DefaultAPIEndpoint {
+		t.Errorf("WithEndpoint should not modify the original client")
+	}
+}
+
+func TestParseRepo(t *testing.T) {
+	tests := []struct {
+		input     string
+		wantOwner string
+		wantRepo  string
+		wantErr   bool
+	}{
+		{"octo/repo", "octo", "repo", false},
+		{" octo/repo ", "octo", "repo", false},
+		{"octo", "", "", true},
+		{"octo/", "", "", true},
+		{"a/b/c", "", "", true},
+		{"", "", "", true},
+	}
+	for _, tt := range tests {
+		owner, repo, err := ParseRepo(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseRepo(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			continue
+		}
+		if owner != tt.wantOwner || repo != tt.wantRepo {
+			t.Errorf("ParseRepo(%q) = %q, %q; want %q, %q", tt.input, owner, repo, tt.wantOwner, tt.wantRepo)
+		}
+	}
+}
+
+func TestExternalRef(t *testing.T) {
+	if got := FormatExternalRef(42); got != "gh-42" {
+		t.Errorf("FormatExternalRef(42) = %q, want gh-42", got)
+	}
+
+	tests := []struct {
+		ref    string
+		want   int
+		wantOK bool
+	}{
+		{"gh-42", 42, true},
+		{"gh-1", 1, true},
+		{"gh-0", 0, false},
+		{"gh-", 0, false},
+		{"gh-abc", 0, false},
+		{"GH-42", 0, false},
+		{"https://github.com/octo/repo/issues/42", 0, false},
+		{"https://linear.app/team/issue/TEAM-1", 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := ParseExternalRef(tt.ref)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("ParseExternalRef(%q) = %d, %v; want %d, %v", tt.ref, got, ok, tt.want, tt.wantOK)
+		}
+		if IsGitHubExternalRef(tt.ref) != tt.wantOK {
+			t.Errorf("IsGitHubExternalRef(%q) = %v, want %v", tt.ref, !tt.wantOK, tt.wantOK)
+		}
+	}
+}
+
+func TestNextPageURL(t *testing.T) {
+	header := http.Header{}
+	header.Set("Link", `<https://api.github.com/repos/o/r/issues?page=2>; rel="next", <https://api.github.com/repos/o/r/issues?page=5>; rel="last"`)
+	if got := nextPageURL(header); got != "https://api.github.com/repos/o/r/issues?page=2" {
+		t.Errorf("nextPageURL = %q", got)
+	}
+
+	header.Set("Link", `<https://api.github.com/repos/o/r/issues?page=1>; rel="first"`)
+	if got := nextPageURL(header); got != "" {
+		t.Errorf("nextPageURL without next = %q, want empty", got)
+	}
+	if got := nextPageURL(http.Header{}); got != "" {
+		t.Errorf("nextPageURL with no Link = %q, want empty", got)
+	}
+}
+
+func TestFetchIssuesPaginatesAndSkipsPullRequests(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repos/octo/repo/issues" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		if got := r.Header.Get("Authorization"); got != "Bearer test-token" {
+			t.Errorf("Authorization = %q", got)
+		}
+		if got := r.URL.Query().Get("state"); got != "open" {
+			t.Errorf("state = %q, want open", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("page") == "" {
+			w.Header().Set("Link", fmt.Sprintf(`<%s/repos/octo/repo/issues?state=open&page=2>; rel="next"`, server.URL))
+			fmt.Fprint(w, `[{"number":1,"title":"One","state":"open"},{"number":2,"title":"A PR","state":"open","pull_request":{}}]`)
+			return
+		}
+		fmt.Fprint(w, `[{"number":3,"title":"Three","state":"open"}]`)
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", "octo", "repo").WithEndpoint(server.URL)
+	issues, err := client.FetchIssues(context.Background(), "open")
+	if err != nil {
+		t.Fatalf("FetchIssues failed: %v", err)
+	}
+	if len(issues) != 2 {
+		t.Fatalf("got %d issues, want 2 (PR skipped): %+v", len(issues), issues)
+	}
+	if issues[0].Number != 1 || issues[1].Number != 3 {
+		t.Errorf("unexpected issue numbers: %d, %d", issues[0].Number, issues[1].Number)
+	}
+}
+
+func TestFetchIssuesSinceSendsSince(t *testing.T) {
+	since := time.Date(2025, 3, 4, 5, 6, 7, 0, time.UTC)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("since"); got != "2025-03-04T05:06:07Z" {
+			t.Errorf("since = %q", got)
+		}
+		fmt.Fprint(w, `[]`)
+	}))
+	defer server.Close()
+
+	client := NewClient("t", "octo", "repo").WithEndpoint(server.URL)
+	if _, err := client.FetchIssuesSince(context.Background(), "all", since); err != nil {
+		t.Fatalf("FetchIssuesSince failed: %v", err)
+	}
+}
+
+func TestFetchIssueNotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"message":"Not Found"}`)
+	}))
+	defer server.Close()
+
+	client := NewClient("t", "octo", "repo").WithEndpoint(server.URL)
+	issue, err := client.FetchIssue(context.Background(), 99)
+	if err != nil {
+		t.Fatalf("FetchIssue failed: %v", err)
+	}
+	if issue != nil {
+		t.Errorf("expected nil issue for 404, got %+v", issue)
+	}
+}
+
+func TestAPIErrorMessage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		fmt.Fprint(w, `{"message":"Validation Failed"}`)
+	}))
+	defer server.Close()
+
+	client := NewClient("t", "octo", "repo").WithEndpoint(server.URL)
+	_, err := client.UpdateIssue(context.Background(), 1, &IssueRequest{Title: "x"})
+	if err == nil || !strings.Contains(err.Error(), "Validation Failed") || !strings.Contains(err.Error(), "422") {
+		t.Errorf("expected validation error, got %v", err)
+	}
+}
+
+func TestCreateIssueClosesWhenRequested(t *testing.T) {
+	var methods []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		methods = append(methods, r.Method+" "+r.URL.Path)
+		var req map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+
+		switch r.Method {
+		case http.MethodPost:
+			if _, ok := req["state"]; ok {
+				t.Errorf("create payload should not include state: %v", req)
+			}
+			fmt.Fprint(w, `{"number":7,"title":"Done","state":"open"}`)
+		case http.MethodPatch:
+			if req["state"] != "closed" {
+				t.Errorf("follow-up update state = %v, want closed", req["state"])
+			}
+			fmt.Fprint(w, `{"number":7,"title":"Done","state":"closed"}`)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("t", "octo", "repo").WithEndpoint(server.URL)
+	issue, err := client.CreateIssue(context.Background(), &IssueRequest{Title: "Done", State: "closed", Labels: []string{}, Assignees: []string{}})
+	if err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	if issue.State != "closed" {
+		t.Errorf("State = %q, want closed", issue.State)
+	}
+	want := []string{"POST /repos/octo/repo/issues", "PATCH /repos/octo/repo/issues/7"}
+	if strings.Join(methods, ",") != strings.Join(want, ",") {
+		t.Errorf("requests = %v, want %v", methods, want)
+	}
+}
+
+func TestUpdateIssueSendsEmptySlices(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		if labels, ok := req["labels"].([]interface{}); !ok || len(labels) != 0 {
+			t.Errorf("labels = %#v, want empty array", req["labels"])
+		}
+		if v, ok := req["milestone"]; !ok || v != nil {
+			t.Errorf("milestone = %#v, want explicit null", v)
+		}
+		fmt.Fprint(w, `{"number":3,"state":"open"}`)
+	}))
+	defer server.Close()
+
+	client := NewClient("t", "octo", "repo").WithEndpoint(server.URL)
+	if _, err := client.UpdateIssue(context.Background(), 3, &IssueRequest{Title: "x", Labels: []string{}, Assignees: []string{}}); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+}
+
+func TestRateLimitRetry(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping rate limit backoff test in short mode")
+	}
+
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.Header().Set("X-RateLimit-Remaining", "0")
+			w.WriteHeader(http.StatusForbidden)
+			fmt.Fprint(w, `{"message":"API rate limit exceeded"}`)
+			return
+		}
+		fmt.Fprint(w, `{"number":1,"state":"open"}`)
+	}))
+	defer server.Close()
+
+	client := NewClient("t", "octo", "repo").WithEndpoint(server.URL)
+	issue, err := client.FetchIssue(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("FetchIssue failed: %v", err)
+	}
+	if issue == nil || attempts != 2 {
+		t.Errorf("expected success on second attempt, got issue=%v attempts=%d", issue, attempts)
+	}
+}
+
+func TestForbiddenWithoutRateLimitIsNotRetried(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.Header().Set("X-RateLimit-Remaining", "4999")
+		w.WriteHeader(http.StatusForbidden)
+		fmt.Fprint(w, `{"message":"Resource not accessible by personal access token"}`)
+	}))
+	defer server.Close()
+
+	client := NewClient("t", "octo", "repo").WithEndpoint(server.URL)
+	if _, err := client.FetchIssue(context.Background(), 1); err == nil {
+		t.Fatal("expected error")
+	}
+	if attempts != 1 {
+		t.Errorf("attempts = %d, want 1", attempts)
+	}
+}
+
+func TestMilestoneCache(t *testing.T) {
+	created := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			if r.URL.Query().Get("state") != "all" {
+				t.Errorf("milestones should be fetched with state=all")
+			}
+			fmt.Fprint(w, `[{"number":1,"title":"v1.0","state":"closed"}]`)
+		case http.MethodPost:
+			created++
+			var req map[string]string
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			fmt.Fprintf(w, `{"number":2,"title":%q,"state":"open"}`, req["title"])
+		}
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	client := NewClient("t", "octo", "repo").WithEndpoint(server.URL)
+	cache, err := BuildMilestoneCache(ctx, client)
+	if err != nil {
+		t.Fatalf("BuildMilestoneCache failed: %v", err)
+	}
+
+	if n, err := cache.Number(ctx, "v1.0"); err != nil || n != 1 {
+		t.Errorf("Number(v1.0) = %d, %v; want 1", n, err)
+	}
+	for i := 0; i < 2; i++ {
+		if n, err := cache.Number(ctx, "v2.0"); err != nil || n != 2 {
+			t.Errorf("Number(v2.0) = %d, %v; want 2", n, err)
+		}
+	}
+	if created != 1 {
+		t.Errorf("created %d milestones, want 1", created)
+	}
+}
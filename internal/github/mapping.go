@@ -0,0 +1,329 @@
+package github
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// DefaultPriority is the Beads priority given to GitHub issues without a
+// priority label (GitHub has no native priority field).
+const DefaultPriority = 2
+
+// DefaultMilestonePrefix is the label prefix used to carry a GitHub
+// milestone on a Beads issue (e.g., "milestone:v1.2").
+const DefaultMilestonePrefix = "milestone:"
+
+// MappingConfig holds configurable mappings between GitHub and Beads.
+// All maps use lowercase keys for case-insensitive matching.
+type MappingConfig struct {
+	// PriorityMap maps GitHub label names to Beads priorities (0-4).
+	// Key is lowercase label name, value is Beads priority.
+	PriorityMap map[string]int
+
+	// LabelTypeMap maps GitHub label names to Beads issue types.
+	// Key is lowercase label name, value is Beads issue type.
+	LabelTypeMap map[string]string
+
+	// MilestonePrefix is the Beads label prefix that carries the milestone.
+	MilestonePrefix string
+}
+
+// DefaultMappingConfig returns sensible default mappings.
+func DefaultMappingConfig() *MappingConfig {
+	return &MappingConfig{
+		// Beads priority: 0=critical, 1=high, 2=medium, 3=low, 4=backlog
+		PriorityMap: map[string]int{
+			"p0":                 0,
+			"p1":                 1,
+			"p2":                 2,
+			"p3":                 3,
+			"p4":                 4,
+			"priority: critical": 0,
+			"priority: high":     1,
+			"priority: medium":   2,
+			"priority: low":      3,
+		},
+		// Common GitHub label names for issue type inference
+		LabelTypeMap: map[string]string{
+			"bug":         "bug",
+			"defect":      "bug",
+			"feature":     "feature",
+			"enhancement": "feature",
+			"epic":        "epic",
+			"chore":       "chore",
+			"maintenance": "chore",
+			"task":        "task",
+		},
+		MilestonePrefix: DefaultMilestonePrefix,
+	}
+}
+
+// ConfigLoader is an interface for loading configuration values.
+// This allows the mapping package to be decoupled from the storage layer.
+type ConfigLoader interface {
+	GetAllConfig() (map[string]string, error)
+}
+
+// LoadMappingConfig loads mapping configuration from a config loader.
+// Config keys follow the pattern: github.<category>_map.<label> = <value>
+// Examples:
+//
+//	github.priority_map.urgent = 0      (label "urgent" -> Beads critical)
+//	github.label_type_map.regression = bug
+//	github.milestone_prefix = release:
+func LoadMappingConfig(loader ConfigLoader) *MappingConfig {
+	config := DefaultMappingConfig()
+
+	if loader == nil {
+		return config
+	}
+
+	allConfig, err := loader.GetAllConfig()
+	if err != nil {
+		return config
+	}
+
+	for key, value := range allConfig {
+		// Parse priority mappings: github.priority_map.<label>
+		if strings.HasPrefix(key, "github.priority_map.") {
+			label := strings.ToLower(strings.TrimPrefix(key, "github.priority_map."))
+			var priority int
+			if _, err := fmt.Sscanf(value, "%d", &priority); err == nil {
+				config.PriorityMap[label] = priority
+			}
+		}
+
+		// Parse label-to-type mappings: github.label_type_map.<label>
+		if strings.HasPrefix(key, "github.label_type_map.") {
+			label := strings.ToLower(strings.TrimPrefix(key, "github.label_type_map."))
+			config.LabelTypeMap[label] = value
+		}
+
+		if key == "github.milestone_prefix" && value != "" {
+			config.MilestonePrefix = value
+		}
+	}
+
+	return config
+}
+
+// BuildGitHubBody formats a Beads issue for GitHub's body field.
+// This mirrors the payload used during push to keep hash comparisons consistent.
+func BuildGitHubBody(issue *types.Issue) string {
+	body := issue.Description
+	if issue.AcceptanceCriteria != "" {
+		body += "\n\n## Acceptance Criteria\n" + issue.AcceptanceCriteria
+	}
+	if issue.Design != "" {
+		body += "\n\n## Design\n" + issue.Design
+	}
+	if issue.Notes != "" {
+		body += "\n\n## Notes\n" + issue.Notes
+	}
+	return body
+}
+
+// PriorityFromLabels returns the Beads priority for the first label with a
+// configured priority mapping.
+func PriorityFromLabels(labels []string, config *MappingConfig) (int, bool) {
+	for _, label := range labels {
+		if priority, ok := config.PriorityMap[strings.ToLower(label)]; ok {
+			return priority, true
+		}
+	}
+	return DefaultPriority, false
+}
+
+// TypeFromLabels returns the Beads issue type for the first label with a
+// configured type mapping.
+func TypeFromLabels(labels []string, config *MappingConfig) (types.IssueType, bool) {
+	for _, label := range labels {
+		if issueType, ok := config.LabelTypeMap[strings.ToLower(label)]; ok {
+			return parseIssueType(issueType), true
+		}
+	}
+	return types.TypeTask, false
+}
+
+// parseIssueType converts an issue type string to types.IssueType.
+func parseIssueType(s string) types.IssueType {
+	switch strings.ToLower(s) {
+	case "bug":
+		return types.TypeBug
+	case "feature":
+		return types.TypeFeature
+	case "epic":
+		return types.TypeEpic
+	case "chore":
+		return types.TypeChore
+	default:
+		return types.TypeTask
+	}
+}
+
+// MilestoneFromLabels returns the milestone title carried by a
+// "<prefix><title>" label, if any.
+func MilestoneFromLabels(labels []string, config *MappingConfig) (string, bool) {
+	for _, label := range labels {
+		if strings.HasPrefix(label, config.MilestonePrefix) {
+			if title := strings.TrimPrefix(label, config.MilestonePrefix); title != "" {
+				return title, true
+			}
+		}
+	}
+	return "", false
+}
+
+// IssueToBeads converts a GitHub issue to a Beads issue.
+//
+// Labels are copied as-is and also drive priority and issue type through
+// the configured maps. The milestone becomes a "<prefix><title>" label, the
+// first assignee becomes the Beads assignee, and open/closed state maps to
+// the open/closed statuses.
+func IssueToBeads(gi *Issue, config *MappingConfig) *types.Issue {
+	issue := &types.Issue{
+		Title:       gi.Title,
+		Description: gi.Body,
+		Status:      types.StatusOpen,
+		CreatedAt:   gi.CreatedAt,
+		UpdatedAt:   gi.UpdatedAt,
+	}
+
+	for _, label := range gi.Labels {
+		issue.Labels = append(issue.Labels, label.Name)
+	}
+	if gi.Milestone != nil && gi.Milestone.Title != "" {
+		issue.Labels = append(issue.Labels, config.MilestonePrefix+gi.Milestone.Title)
+	}
+
+	issue.Priority, _ = PriorityFromLabels(issue.Labels, config)
+	issue.IssueType, _ = TypeFromLabels(issue.Labels, config)
+
+	if len(gi.Assignees) > 0 {
+		issue.Assignee = gi.Assignees[0].Login
+	}
+
+	if gi.State == "closed" {
+		issue.Status = types.StatusClosed
+		if gi.ClosedAt != nil {
+			closedAt := *gi.ClosedAt
+			issue.ClosedAt = &closedAt
+		} else {
+			closedAt := gi.UpdatedAt
+			issue.ClosedAt = &closedAt
+		}
+	}
+
+	externalRef := FormatExternalRef(gi.Number)
+	issue.ExternalRef = &externalRef
+
+	return issue
+}
+
+// PreserveLocalFields copies onto a converted GitHub issue the local values
+// that GitHub has no way to express, so a pull does not clobber them:
+//   - priority and issue type, when no GitHub label maps to one
+//   - in_progress, blocked and other non-closed statuses, while the GitHub issue is open
+//   - design, acceptance criteria and notes, while the GitHub body is unchanged
+//     from what BuildGitHubBody produced for them
+//   - custom fields
+func PreserveLocalFields(remote, local *types.Issue, config *MappingConfig) {
+	if local == nil {
+		return
+	}
+	if remote.Description == BuildGitHubBody(local) {
+		remote.Description = local.Description
+		remote.AcceptanceCriteria = local.AcceptanceCriteria
+		remote.Design = local.Design
+		remote.Notes = local.Notes
+	}
+	if _, ok := PriorityFromLabels(remote.Labels, config); !ok {
+		remote.Priority = local.Priority
+	}
+	if _, ok := TypeFromLabels(remote.Labels, config); !ok {
+		remote.IssueType = local.IssueType
+	}
+	if remote.Status == types.StatusOpen && local.Status != types.StatusClosed && local.Status != types.StatusTombstone {
+		remote.Status = local.Status
+	}
+	remote.CustomFields = local.CustomFields
+}
+
+// IssueToGitHubRequest builds the create/update payload for a Beads issue.
+// Labels carrying the milestone are not sent as GitHub labels; the milestone
+// title is returned so the caller can resolve it to a milestone number.
+func IssueToGitHubRequest(issue *types.Issue, config *MappingConfig) (*IssueRequest, string) {
+	req := &IssueRequest{
+		Title:     issue.Title,
+		Body:      BuildGitHubBody(issue),
+		State:     "open",
+		Labels:    []string{},
+		Assignees: []string{},
+	}
+
+	milestone, _ := MilestoneFromLabels(issue.Labels, config)
+	for _, label := range issue.Labels {
+		if strings.HasPrefix(label, config.MilestonePrefix) {
+			continue
+		}
+		req.Labels = append(req.Labels, label)
+	}
+	sort.Strings(req.Labels)
+
+	if issue.Assignee != "" {
+		req.Assignees = append(req.Assignees, issue.Assignee)
+	}
+
+	if issue.Status == types.StatusClosed {
+		req.State = "closed"
+		req.StateReason = "completed"
+	}
+
+	return req, milestone
+}
+
+// NormalizeIssueForGitHubHash returns a copy of the issue using GitHub's body
+// formatting and clears fields not present in GitHub's model to avoid false conflicts.
+func NormalizeIssueForGitHubHash(issue *types.Issue) *types.Issue {
+	normalized := *issue
+	normalized.Description = BuildGitHubBody(issue)
+	normalized.AcceptanceCriteria = ""
+	normalized.Design = ""
+	normalized.Notes = ""
+	normalized.Owner = ""
+	normalized.CreatedBy = ""
+	return &normalized
+}
+
+// IssueMatchesLocal reports whether a GitHub issue carries the same content
+// as the local issue, comparing the fields both sides can represent
+// (including labels, which are not part of the content hash).
+func IssueMatchesLocal(local *types.Issue, gi *Issue, config *MappingConfig) bool {
+	remote := IssueToBeads(gi, config)
+	PreserveLocalFields(remote, local, config)
+	remote.ExternalRef = local.ExternalRef
+
+	if NormalizeIssueForGitHubHash(local).ComputeContentHash() != NormalizeIssueForGitHubHash(remote).ComputeContentHash() {
+		return false
+	}
+	return sameLabels(local.Labels, remote.Labels)
+}
+
+func sameLabels(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	sa := append([]string(nil), a...)
+	sb := append([]string(nil), b...)
+	sort.Strings(sa)
+	sort.Strings(sb)
+	for i := range sa {
+		if sa[i] != sb[i] {
+			return false
+		}
+	}
+	return true
+}
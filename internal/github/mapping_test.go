@@ -0,0 +1,236 @@
+package github
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func sampleIssue() *Issue {
+	created := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	updated := time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
+	return &Issue{
+		Number:    12,
+		Title:     "Login times out",
+		Body:      "Steps to reproduce",
+		State:     "open",
+		Labels:    []Label{{Name: "bug"}, {Name: "P1"}, {Name: "area/auth"}},
+		Assignees: []User{{Login: "octocat"}, {Login: "hubot"}},
+		Milestone: &Milestone{Number: 3, Title: "v1.2"},
+		CreatedAt: created,
+		UpdatedAt: updated,
+	}
+}
+
+func TestIssueToBeads(t *testing.T) {
+	config := DefaultMappingConfig()
+	issue := IssueToBeads(sampleIssue(), config)
+
+	if issue.Title != "Login times out" || issue.Description != "Steps to reproduce" {
+		t.Errorf("unexpected content: %q / %q", issue.Title, issue.Description)
+	}
+	if issue.Status != types.StatusOpen {
+		t.Errorf("Status = %s, want open", issue.Status)
+	}
+	if issue.Priority != 1 {
+		t.Errorf("Priority = %d, want 1 (from P1 label)", issue.Priority)
+	}
+	if issue.IssueType != types.TypeBug {
+		t.Errorf("IssueType = %s, want bug", issue.IssueType)
+	}
+	if issue.Assignee != "octocat" {
+		t.Errorf("Assignee = %q, want first assignee", issue.Assignee)
+	}
+	wantLabels := []string{"bug", "P1", "area/auth", "milestone:v1.2"}
+	if !reflect.DeepEqual(issue.Labels, wantLabels) {
+		t.Errorf("Labels = %v, want %v", issue.Labels, wantLabels)
+	}
+	if issue.ExternalRef == nil || *issue.ExternalRef != "gh-12" {
+		t.Errorf("ExternalRef = %v, want gh-12", issue.ExternalRef)
+	}
+	if issue.ClosedAt != nil {
+		t.Errorf("open issue should have no ClosedAt")
+	}
+}
+
+func TestIssueToBeadsClosedAndDefaults(t *testing.T) {
+	closedAt := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+	gi := &Issue{Number: 5, Title: "Old", State: "closed", ClosedAt: &closedAt}
+
+	issue := IssueToBeads(gi, DefaultMappingConfig())
+	if issue.Status != types.StatusClosed {
+		t.Errorf("Status = %s, want closed", issue.Status)
+	}
+	if issue.ClosedAt == nil || !issue.ClosedAt.Equal(closedAt) {
+		t.Errorf("ClosedAt = %v, want %v", issue.ClosedAt, closedAt)
+	}
+	if issue.Priority != DefaultPriority {
+		t.Errorf("Priority = %d, want default %d", issue.Priority, DefaultPriority)
+	}
+	if issue.IssueType != types.TypeTask {
+		t.Errorf("IssueType = %s, want task", issue.IssueType)
+	}
+	if issue.Assignee != "" || len(issue.Labels) != 0 {
+		t.Errorf("expected no assignee or labels, got %q %v", issue.Assignee, issue.Labels)
+	}
+}
+
+func TestPreserveLocalFields(t *testing.T) {
+	config := DefaultMappingConfig()
+	local := &types.Issue{
+		Description: "Steps",
+		Notes:       "Some notes",
+		Priority:    0,
+		IssueType:   types.TypeFeature,
+		Status:      types.StatusInProgress,
+	}
+
+	// GitHub issue with no priority/type labels whose body is what we pushed
+	gi := &Issue{Number: 1, Title: "x", Body: BuildGitHubBody(local), State: "open"}
+	remote := IssueToBeads(gi, config)
+	PreserveLocalFields(remote, local, config)
+
+	if remote.Priority != 0 || remote.IssueType != types.TypeFeature {
+		t.Errorf("unmapped priority/type should be kept: %d %s", remote.Priority, remote.IssueType)
+	}
+	if remote.Status != types.StatusInProgress {
+		t.Errorf("Status = %s, want local in_progress kept while open", remote.Status)
+	}
+	if remote.Description != "Steps" || remote.Notes != "Some notes" {
+		t.Errorf("unchanged body should keep local sections: %q / %q", remote.Description, remote.Notes)
+	}
+
+	// Labels and closed state on GitHub win
+	gi.Labels = []Label{{Name: "p3"}, {Name: "bug"}}
+	gi.State = "closed"
+	gi.Body = "Rewritten on GitHub"
+	remote = IssueToBeads(gi, config)
+	PreserveLocalFields(remote, local, config)
+
+	if remote.Priority != 3 || remote.IssueType != types.TypeBug {
+		t.Errorf("labeled priority/type should win: %d %s", remote.Priority, remote.IssueType)
+	}
+	if remote.Status != types.StatusClosed {
+		t.Errorf("Status = %s, want closed", remote.Status)
+	}
+	if remote.Description != "Rewritten on GitHub" || remote.Notes != "" {
+		t.Errorf("changed body should replace local sections: %q / %q", remote.Description, remote.Notes)
+	}
+}
+
+func TestIssueToGitHubRequest(t *testing.T) {
+	config := DefaultMappingConfig()
+	issue := &types.Issue{
+		Title:              "Add SSO",
+		Description:        "Support SAML",
+		AcceptanceCriteria: "Users can log in",
+		Status:             types.StatusClosed,
+		Assignee:           "octocat",
+		Labels:             []string{"enhancement", "milestone:v2", "area/auth"},
+	}
+
+	req, milestone := IssueToGitHubRequest(issue, config)
+	if req.Title != "Add SSO" {
+		t.Errorf("Title = %q", req.Title)
+	}
+	if req.Body != "Support SAML\n\n## Acceptance Criteria\nUsers can log in" {
+		t.Errorf("Body = %q", req.Body)
+	}
+	if req.State != "closed" || req.StateReason != "completed" {
+		t.Errorf("State = %q/%q, want closed/completed", req.State, req.StateReason)
+	}
+	if !reflect.DeepEqual(req.Labels, []string{"area/auth", "enhancement"}) {
+		t.Errorf("Labels = %v (milestone label should be excluded)", req.Labels)
+	}
+	if !reflect.DeepEqual(req.Assignees, []string{"octocat"}) {
+		t.Errorf("Assignees = %v", req.Assignees)
+	}
+	if milestone != "v2" {
+		t.Errorf("milestone = %q, want v2", milestone)
+	}
+
+	open := &types.Issue{Title: "t", Status: types.StatusBlocked}
+	req, milestone = IssueToGitHubRequest(open, config)
+	if req.State != "open" || req.Labels == nil || req.Assignees == nil || milestone != "" {
+		t.Errorf("unexpected request for open issue: %+v milestone=%q", req, milestone)
+	}
+}
+
+func TestIssueMatchesLocal(t *testing.T) {
+	config := DefaultMappingConfig()
+	gi := sampleIssue()
+	local := IssueToBeads(gi, config)
+	local.ID = "bd-abc"
+	local.CreatedBy = "someone"
+	local.Status = types.StatusInProgress
+
+	if !IssueMatchesLocal(local, gi, config) {
+		t.Error("expected converted issue to match its source")
+	}
+
+	local.Labels = append(local.Labels, "needs-triage")
+	if IssueMatchesLocal(local, gi, config) {
+		t.Error("expected label difference to be detected")
+	}
+
+	local = IssueToBeads(gi, config)
+	local.Title = "Changed locally"
+	if IssueMatchesLocal(local, gi, config) {
+		t.Error("expected title difference to be detected")
+	}
+}
+
+type mockConfigLoader struct {
+	config map[string]string
+}
+
+func (m *mockConfigLoader) GetAllConfig() (map[string]string, error) {
+	return m.config, nil
+}
+
+func TestLoadMappingConfig(t *testing.T) {
+	loader := &mockConfigLoader{config: map[string]string{
+		"github.priority_map.Urgent":       "0",
+		"github.priority_map.bad":          "not-a-number",
+		"github.label_type_map.Regression": "bug",
+		"github.milestone_prefix":          "release:",
+		"linear.priority_map.1":            "0",
+	}}
+
+	config := LoadMappingConfig(loader)
+	if config.PriorityMap["urgent"] != 0 {
+		t.Errorf("expected urgent -> 0")
+	}
+	if _, ok := config.PriorityMap["bad"]; ok {
+		t.Errorf("invalid priority should be ignored")
+	}
+	if config.PriorityMap["p1"] != 1 {
+		t.Errorf("defaults should be kept")
+	}
+	if config.LabelTypeMap["regression"] != "bug" {
+		t.Errorf("expected regression -> bug")
+	}
+	if config.MilestonePrefix != "release:" {
+		t.Errorf("MilestonePrefix = %q", config.MilestonePrefix)
+	}
+
+	issue := IssueToBeads(&Issue{Number: 1, Labels: []Label{{Name: "URGENT"}, {Name: "regression"}}, Milestone: &Milestone{Title: "v3"}}, config)
+	if issue.Priority != 0 || issue.IssueType != types.TypeBug {
+		t.Errorf("custom mappings not applied: %d %s", issue.Priority, issue.IssueType)
+	}
+	labels := append([]string(nil), issue.Labels...)
+	sort.Strings(labels)
+	if !reflect.DeepEqual(labels, []string{"URGENT", "regression", "release:v3"}) {
+		t.Errorf("Labels = %v", labels)
+	}
+}
+
+func TestLoadMappingConfigNilLoader(t *testing.T) {
+	config := LoadMappingConfig(nil)
+	if !reflect.DeepEqual(config, DefaultMappingConfig()) {
+		t.Error("nil loader should return defaults")
+	}
+}
@@ -0,0 +1,106 @@
+// Package github provides client and data types for the GitHub Issues REST API.
+//
+// This package handles all interactions with GitHub Issues, including
+// fetching, creating, and updating issues in a single repository. It provides
+// bidirectional mapping between GitHub's data model (labels, milestones,
+// assignees, open/closed state) and Beads' internal types. Linked issues use
+// external_ref values of the form "gh-<number>".
+package github
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// API configuration constants.
+const (
+	// DefaultAPIEndpoint is the GitHub REST API base URL.
+	// GitHub Enterprise Server uses https://<host>/api/v3.
+	DefaultAPIEndpoint = "https://api.github.com"
+
+	// APIVersion is the REST API version requested via X-GitHub-Api-Version.
+	APIVersion = "2022-11-28"
+
+	// DefaultTimeout is the default HTTP request timeout.
+	DefaultTimeout = 30 * time.Second
+
+	// MaxRetries is the maximum number of retries for rate-limited requests.
+	MaxRetries = 3
+
+	// RetryDelay is the base delay between retries (exponential backoff).
+	RetryDelay = time.Second
+
+	// MaxPageSize is the maximum number of issues to fetch per page.
+	MaxPageSize = 100
+)
+
+// Client provides methods to interact with the GitHub Issues REST API
+// for a single repository.
+type Client struct {
+	Token      string
+	Owner      string
+	Repo       string
+	Endpoint   string // REST API base URL (defaults to DefaultAPIEndpoint)
+	HTTPClient *http.Client
+}
+
+// Issue represents an issue from the GitHub API.
+// Pull requests are also returned by the issues endpoints; they have
+// PullRequest set and are skipped by the fetch methods.
+type Issue struct {
+	ID          int64      `json:"id"`
+	Number      int        `json:"number"`
+	Title       string     `json:"title"`
+	Body        string     `json:"body"`
+	State       string     `json:"state"`                  // "open" or "closed"
+	StateReason string     `json:"state_reason,omitempty"` // "completed", "not_planned", "reopened"
+	HTMLURL     string     `json:"html_url"`
+	Labels      []Label    `json:"labels"`
+	Assignees   []User     `json:"assignees"`
+	Milestone   *Milestone `json:"milestone"`
+	PullRequest *struct{}  `json:"pull_request,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	ClosedAt    *time.Time `json:"closed_at"`
+}
+
+// Label represents a label on a GitHub issue.
+type Label struct {
+	Name string `json:"name"`
+}
+
+// User represents a GitHub user.
+type User struct {
+	Login string `json:"login"`
+}
+
+// Milestone represents a repository milestone.
+type Milestone struct {
+	Number int    `json:"number"`
+	Title  string `json:"title"`
+	State  string `json:"state"`
+}
+
+// IssueRequest is the payload for creating or updating an issue.
+// Slices are sent even when empty so that updates can clear labels and
+// assignees; Milestone is sent as null when nil.
+type IssueRequest struct {
+	Title       string   `json:"title"`
+	Body        string   `json:"body"`
+	State       string   `json:"state,omitempty"`
+	StateReason string   `json:"state_reason,omitempty"`
+	Labels      []string `json:"labels"`
+	Assignees   []string `json:"assignees"`
+	Milestone   *int     `json:"milestone"`
+}
+
+// APIError is returned when the GitHub API responds with a non-2xx status.
+type APIError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("GitHub API error: %s (status %d)", e.Message, e.StatusCode)
+}
@@ -15,6 +15,10 @@ const (
 	EventCreate = "create"
 	EventUpdate = "update"
 	EventClose  = "close"
+
+	// EventLeaseExpired fires when the daemon returns an issue whose claim
+	// lease lapsed to open
+	EventLeaseExpired = "lease_expired"
 )
 
 // Hook file names
@@ -22,6 +26,8 @@ const (
 	HookOnCreate = "on_create"
 	HookOnUpdate = "on_update"
 	HookOnClose  = "on_close"
+
+	HookOnLeaseExpired = "on_lease_expired"
 )
 
 // Runner handles hook execution
@@ -118,6 +124,8 @@ func eventToHook(event string) string {
 		return HookOnUpdate
 	case EventClose:
 		return HookOnClose
+	case EventLeaseExpired:
+		return HookOnLeaseExpired
 	default:
 		return ""
 	}
@@ -44,6 +44,7 @@ func TestEventToHook(t *testing.T) {
 		{EventCreate, HookOnCreate},
 		{EventUpdate, HookOnUpdate},
 		{EventClose, HookOnClose},
+		{EventLeaseExpired, HookOnLeaseExpired},
 		{"unknown", ""},
 		{"", ""},
 	}
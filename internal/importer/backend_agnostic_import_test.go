@@ -5,6 +5,7 @@ package importer
 
 import (
 	"context"
+	"strings"
 	"testing"
 	"time"
 
@@ -112,3 +113,124 @@ func TestImportIssues_BackendAgnostic_DepsLabelsCommentsTombstone(t *testing.T)
 		t.Fatalf("expected DeletedAt preserved (%s), got %#v", deletedTS.Format(time.RFC3339Nano), b.DeletedAt)
 	}
 }
+
+func TestImportIssues_BackendAgnostic_WorkLog(t *testing.T) {
+	ctx := context.Background()
+	store := memory.New("")
+	if err := store.SetConfig(ctx, "issue_prefix", "test"); err != nil {
+		t.Fatalf("set issue_prefix: %v", err)
+	}
+
+	started := time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)
+	ended := started.Add(30 * time.Minute)
+	issue := &types.Issue{
+		ID:        "test-1",
+		Title:     "Issue A",
+		IssueType: types.TypeTask,
+		Status:    types.StatusOpen,
+		Priority:  2,
+		WorkLog: []*types.WorkLogEntry{
+			{ID: "wl-a", Actor: "alice", Minutes: 30, StartedAt: started, EndedAt: &ended, CreatedAt: ended},
+			{ID: "wl-b", Actor: "bob", StartedAt: started, CreatedAt: started},
+		},
+	}
+
+	if _, err := ImportIssues(ctx, "", store, []*types.Issue{issue}, Options{}); err != nil {
+		t.Fatalf("ImportIssues: %v", err)
+	}
+	entries, err := store.GetWorkLog(ctx, "test-1")
+	if err != nil {
+		t.Fatalf("GetWorkLog: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 work log entries, got %d", len(entries))
+	}
+
+	// Stop bob's timer locally, then re-import the stale running copy:
+	// the local stop must survive.
+	bobEnded := started.Add(time.Hour)
+	stopped := *issue.WorkLog[1]
+	stopped.IssueID = "test-1"
+	stopped.Minutes = 60
+	stopped.EndedAt = &bobEnded
+	if err := store.SaveWorkLogEntry(ctx, &stopped); err != nil {
+		t.Fatalf("SaveWorkLogEntry: %v", err)
+	}
+	// An edited entry from the JSONL replaces the local copy
+	issue.WorkLog[0].Minutes = 35
+
+	if _, err := ImportIssues(ctx, "", store, []*types.Issue{issue}, Options{}); err != nil {
+		t.Fatalf("ImportIssues (second): %v", err)
+	}
+	entries, err = store.GetWorkLog(ctx, "test-1")
+	if err != nil {
+		t.Fatalf("GetWorkLog: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 work log entries after re-import, got %d", len(entries))
+	}
+	byID := map[string]*types.WorkLogEntry{}
+	for _, e := range entries {
+		byID[e.ID] = e
+	}
+	if byID["wl-a"].Minutes != 35 {
+		t.Errorf("expected edited entry to be imported, got %d minutes", byID["wl-a"].Minutes)
+	}
+	if byID["wl-b"].IsRunning() || byID["wl-b"].Minutes != 60 {
+		t.Errorf("expected local stop to be kept, got %+v", byID["wl-b"])
+	}
+}
+
+func TestImportIssues_BackendAgnostic_Attachments(t *testing.T) {
+	ctx := context.Background()
+	store := memory.New("")
+	if err := store.SetConfig(ctx, "issue_prefix", "test"); err != nil {
+		t.Fatalf("set issue_prefix: %v", err)
+	}
+
+	created := time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)
+	issue := &types.Issue{
+		ID:        "test-1",
+		Title:     "Issue A",
+		IssueType: types.TypeTask,
+		Status:    types.StatusOpen,
+		Priority:  2,
+		Attachments: []*types.Attachment{
+			{ID: "att-a", Name: "trace.log", SHA256: strings.Repeat("a", 64), Size: 10, CreatedAt: created},
+			{ID: "att-b", Name: "shot.png", SHA256: strings.Repeat("b", 64), Size: 20, CreatedAt: created},
+		},
+	}
+
+	if _, err := ImportIssues(ctx, "", store, []*types.Issue{issue}, Options{}); err != nil {
+		t.Fatalf("ImportIssues: %v", err)
+	}
+	got, err := store.GetAttachments(ctx, "test-1")
+	if err != nil {
+		t.Fatalf("GetAttachments: %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("expected 2 attachments, got %d", len(got))
+	}
+
+	// Detach att-b locally, then re-import the stale live copy: the detach
+	// must survive. A detach coming from the JSONL is applied.
+	detached := created.Add(time.Hour)
+	local := *issue.Attachments[1]
+	local.IssueID = "test-1"
+	local.DetachedAt = &detached
+	if err := store.SaveAttachment(ctx, &local); err != nil {
+		t.Fatalf("SaveAttachment: %v", err)
+	}
+	issue.Attachments[0].DetachedAt = &detached
+
+	if _, err := ImportIssues(ctx, "", store, []*types.Issue{issue}, Options{}); err != nil {
+		t.Fatalf("ImportIssues (second): %v", err)
+	}
+	got, err = store.GetAttachments(ctx, "test-1")
+	if err != nil {
+		t.Fatalf("GetAttachments: %v", err)
+	}
+	if live := types.LiveAttachments(got); len(live) != 0 {
+		t.Errorf("expected both attachments detached, got live %+v", live)
+	}
+}
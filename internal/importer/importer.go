@@ -18,6 +18,7 @@ import (
 	"github.com/steveyegge/beads/internal/storage/sqlite"
 	"github.com/steveyegge/beads/internal/types"
 	"github.com/steveyegge/beads/internal/utils"
+	"github.com/steveyegge/beads/internal/validation"
 )
 
 // OrphanHandling defines how to handle hierarchical child issues whose parents are missing.
@@ -90,6 +91,10 @@ func ImportIssues(ctx context.Context, dbPath string, store storage.Storage, iss
 		return nil, fmt.Errorf("import requires an initialized storage backend")
 	}
 
+	// Imported status changes were made (and validated) in another clone or
+	// tracker, so the local workflow doesn't reject them
+	ctx = validation.WithoutWorkflow(ctx)
+
 	// Normalize Linear external_refs to canonical form to avoid slug-based duplicates.
 	for _, issue := range issues {
 		if issue.ExternalRef == nil || *issue.ExternalRef == "" {
@@ -207,6 +212,18 @@ func ImportIssues(ctx context.Context, dbPath string, store storage.Storage, iss
 		}
 	}
 
+	// Import work log. Entries carry stable IDs, so this runs after the
+	// issues they belong to are committed and needs no transaction support.
+	if err := importWorkLog(ctx, store, issues, opts); err != nil {
+		return nil, err
+	}
+
+	// Import attachment metadata the same way. Blob content travels with
+	// git under .beads/attachments and is not touched here.
+	if err := importAttachments(ctx, store, issues, opts); err != nil {
+		return nil, err
+	}
+
 	return result, nil
 }
 
@@ -609,6 +626,9 @@ func upsertIssues(ctx context.Context, store storage.Storage, issues []*types.Is
 					updates["acceptance_criteria"] = incoming.AcceptanceCriteria
 					updates["notes"] = incoming.Notes
 					updates["closed_at"] = incoming.ClosedAt
+					updates["recurrence"] = incoming.Recurrence
+					updates["custom_fields"] = incoming.CustomFields
+					updates["lease_expires_at"] = incoming.LeaseExpiresAt
 					// Pinned field: Only update if explicitly true in JSONL
 					// (omitempty means false values are absent, so false = don't change existing)
 					if incoming.Pinned {
@@ -714,6 +734,9 @@ func upsertIssues(ctx context.Context, store storage.Storage, issues []*types.Is
 				updates["acceptance_criteria"] = incoming.AcceptanceCriteria
 				updates["notes"] = incoming.Notes
 				updates["closed_at"] = incoming.ClosedAt
+				updates["recurrence"] = incoming.Recurrence
+				updates["custom_fields"] = incoming.CustomFields
+				updates["lease_expires_at"] = incoming.LeaseExpiresAt
 				// Pinned field: Only update if explicitly true in JSONL
 				// (omitempty means false values are absent, so false = don't change existing)
 				if incoming.Pinned {
@@ -937,6 +960,9 @@ func upsertIssuesTx(ctx context.Context, tx storage.Transaction, store storage.S
 						"acceptance_criteria": incoming.AcceptanceCriteria,
 						"notes":               incoming.Notes,
 						"closed_at":           incoming.ClosedAt,
+						"recurrence":          incoming.Recurrence,
+						"custom_fields":       incoming.CustomFields,
+						"lease_expires_at":    incoming.LeaseExpiresAt,
 					}
 					if incoming.Pinned {
 						updates["pinned"] = incoming.Pinned
@@ -1017,6 +1043,9 @@ func upsertIssuesTx(ctx context.Context, tx storage.Transaction, store storage.S
 					"acceptance_criteria": incoming.AcceptanceCriteria,
 					"notes":               incoming.Notes,
 					"closed_at":           incoming.ClosedAt,
+					"recurrence":          incoming.Recurrence,
+					"custom_fields":       incoming.CustomFields,
+					"lease_expires_at":    incoming.LeaseExpiresAt,
 				}
 				if incoming.Pinned {
 					updates["pinned"] = incoming.Pinned
@@ -1477,6 +1506,106 @@ func importComments(ctx context.Context, store storage.Storage, issues []*types.
 	return nil
 }
 
+// importWorkLog imports work-log entries for issues. Entries are matched by
+// ID; an existing entry is replaced when the incoming one differs, except that
+// a running timer never overwrites a local entry that has been stopped.
+func importWorkLog(ctx context.Context, store storage.Storage, issues []*types.Issue, opts Options) error {
+	for _, issue := range issues {
+		if len(issue.WorkLog) == 0 {
+			continue
+		}
+
+		current, err := store.GetWorkLog(ctx, issue.ID)
+		if err != nil {
+			return fmt.Errorf("error getting work log for %s: %w", issue.ID, err)
+		}
+		existing := make(map[string]*types.WorkLogEntry, len(current))
+		for _, e := range current {
+			existing[e.ID] = e
+		}
+
+		for _, entry := range issue.WorkLog {
+			if entry == nil || entry.ID == "" {
+				continue
+			}
+			if local, ok := existing[entry.ID]; ok {
+				if workLogEntryEqual(local, entry) || (entry.IsRunning() && !local.IsRunning()) {
+					continue
+				}
+			}
+			incoming := *entry
+			incoming.IssueID = issue.ID
+			if err := store.SaveWorkLogEntry(ctx, &incoming); err != nil {
+				if opts.Strict {
+					return fmt.Errorf("error importing work log entry %s for %s: %w", entry.ID, issue.ID, err)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func workLogEntryEqual(a, b *types.WorkLogEntry) bool {
+	if a.Actor != b.Actor || a.Minutes != b.Minutes || a.Note != b.Note || !a.StartedAt.Equal(b.StartedAt) {
+		return false
+	}
+	if a.EndedAt == nil || b.EndedAt == nil {
+		return a.EndedAt == nil && b.EndedAt == nil
+	}
+	return a.EndedAt.Equal(*b.EndedAt)
+}
+
+// importAttachments imports attachment metadata for issues. Attachments are
+// matched by ID; an existing one is replaced when the incoming one differs,
+// except that a stale live copy never revives an attachment detached locally.
+func importAttachments(ctx context.Context, store storage.Storage, issues []*types.Issue, opts Options) error {
+	for _, issue := range issues {
+		if len(issue.Attachments) == 0 {
+			continue
+		}
+
+		current, err := store.GetAttachments(ctx, issue.ID)
+		if err != nil {
+			return fmt.Errorf("error getting attachments for %s: %w", issue.ID, err)
+		}
+		existing := make(map[string]*types.Attachment, len(current))
+		for _, a := range current {
+			existing[a.ID] = a
+		}
+
+		for _, attachment := range issue.Attachments {
+			if attachment == nil || attachment.ID == "" {
+				continue
+			}
+			if local, ok := existing[attachment.ID]; ok {
+				if attachmentEqual(local, attachment) || (!attachment.IsDetached() && local.IsDetached()) {
+					continue
+				}
+			}
+			incoming := *attachment
+			incoming.IssueID = issue.ID
+			if err := store.SaveAttachment(ctx, &incoming); err != nil {
+				if opts.Strict {
+					return fmt.Errorf("error importing attachment %s for %s: %w", attachment.ID, issue.ID, err)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func attachmentEqual(a, b *types.Attachment) bool {
+	if a.Name != b.Name || a.SHA256 != b.SHA256 || a.Size != b.Size || a.MediaType != b.MediaType {
+		return false
+	}
+	if a.DetachedAt == nil || b.DetachedAt == nil {
+		return a.DetachedAt == nil && b.DetachedAt == nil
+	}
+	return a.DetachedAt.Equal(*b.DetachedAt)
+}
+
 // shouldProtectFromUpdate checks if an update should be skipped due to timestamp-aware protection (GH#865).
 // Returns true if the update should be skipped (local is newer), false if the update should proceed.
 // If the issue is not in the protection map, returns false (allow update).
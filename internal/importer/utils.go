@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/steveyegge/beads/internal/types"
 	"github.com/steveyegge/beads/internal/utils"
@@ -121,6 +122,30 @@ func (fc *fieldComparator) equalBool(existingVal bool, newVal interface{}) bool
 	}
 }
 
+func (fc *fieldComparator) equalStringMap(existing map[string]string, newVal interface{}) bool {
+	m, ok := newVal.(map[string]string)
+	if !ok || len(existing) != len(m) {
+		return false
+	}
+	for k, v := range existing {
+		if nv, ok := m[k]; !ok || nv != v {
+			return false
+		}
+	}
+	return true
+}
+
+func (fc *fieldComparator) equalTimePtr(existing *time.Time, newVal interface{}) bool {
+	t, ok := newVal.(*time.Time)
+	if !ok {
+		return false
+	}
+	if existing == nil || t == nil {
+		return existing == nil && t == nil
+	}
+	return existing.Equal(*t)
+}
+
 func (fc *fieldComparator) checkFieldChanged(key string, existing *types.Issue, newVal interface{}) bool {
 	switch key {
 	case "title":
@@ -145,6 +170,12 @@ func (fc *fieldComparator) checkFieldChanged(key string, existing *types.Issue,
 		return !fc.equalPtrStr(existing.ExternalRef, newVal)
 	case "pinned":
 		return !fc.equalBool(existing.Pinned, newVal)
+	case "recurrence":
+		return !fc.equalStr(existing.Recurrence, newVal)
+	case "custom_fields":
+		return !fc.equalStringMap(existing.CustomFields, newVal)
+	case "lease_expires_at":
+		return !fc.equalTimePtr(existing.LeaseExpiresAt, newVal)
 	default:
 		return false
 	}
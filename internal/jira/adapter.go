@@ -0,0 +1,150 @@
+package jira
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/beads/internal/tracker"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// Adapter connects a Jira project to the tracker sync engine.
+// Remote issues are identified by their Jira key (e.g., "PROJ-12").
+type Adapter struct {
+	client *Client
+	config *MappingConfig
+}
+
+var _ tracker.Adapter = (*Adapter)(nil)
+
+// NewAdapter creates a sync adapter for the client's project.
+func NewAdapter(client *Client, config *MappingConfig) *Adapter {
+	if config == nil {
+		config = DefaultMappingConfig()
+	}
+	if len(config.FieldMap) > 0 {
+		client = client.WithFields(config.FieldIDs()...)
+	}
+	return &Adapter{client: client, config: config}
+}
+
+// Name returns "jira".
+func (a *Adapter) Name() string { return "jira" }
+
+// DisplayName returns "Jira".
+func (a *Adapter) DisplayName() string { return "Jira" }
+
+// SyncsLabels reports that labels are pushed to Jira.
+func (a *Adapter) SyncsLabels() bool { return true }
+
+// remoteIssue wraps a Jira issue for the sync engine.
+func remoteIssue(ji *Issue) *tracker.RemoteIssue {
+	updated, _ := ParseTimestamp(ji.Fields.Updated)
+	return &tracker.RemoteIssue{ID: ji.Key, UpdatedAt: updated, Data: ji}
+}
+
+// jiraIssue returns the Jira issue inside a remote issue.
+func jiraIssue(remote *tracker.RemoteIssue) *Issue {
+	return remote.Data.(*Issue)
+}
+
+// FetchIssues searches the project, optionally only for issues updated
+// since the given time.
+func (a *Adapter) FetchIssues(ctx context.Context, state string, since time.Time) ([]tracker.RemoteIssue, error) {
+	var issues []Issue
+	var err error
+	if since.IsZero() {
+		issues, err = a.client.FetchIssues(ctx, state)
+	} else {
+		issues, err = a.client.FetchIssuesSince(ctx, state, since)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	remote := make([]tracker.RemoteIssue, len(issues))
+	for i := range issues {
+		remote[i] = *remoteIssue(&issues[i])
+	}
+	return remote, nil
+}
+
+// FetchIssue fetches a single issue by key.
+func (a *Adapter) FetchIssue(ctx context.Context, key string) (*tracker.RemoteIssue, error) {
+	ji, err := a.client.FetchIssue(ctx, key)
+	if err != nil || ji == nil {
+		return nil, err
+	}
+	return remoteIssue(ji), nil
+}
+
+// CreateIssue creates a Jira issue and transitions it to the mapped status.
+func (a *Adapter) CreateIssue(ctx context.Context, issue *types.Issue) (*tracker.RemoteIssue, error) {
+	created, err := a.client.CreateIssue(ctx, IssueToJiraRequest(issue, a.config))
+	if err != nil {
+		return nil, err
+	}
+
+	remote := &tracker.RemoteIssue{
+		ID:        created.Key,
+		UpdatedAt: time.Now(),
+		Data:      &Issue{ID: created.ID, Key: created.Key, Self: created.Self},
+	}
+
+	if issue.Status != types.StatusOpen {
+		if err := a.client.TransitionIssue(ctx, created.Key, StatusToJira(issue.Status, a.config)); err != nil {
+			return remote, err
+		}
+	}
+	return remote, nil
+}
+
+// UpdateIssue updates a Jira issue's fields, then transitions it if its
+// status no longer matches.
+func (a *Adapter) UpdateIssue(ctx context.Context, remote *tracker.RemoteIssue, issue *types.Issue) error {
+	if err := a.client.UpdateIssue(ctx, remote.ID, IssueToJiraRequest(issue, a.config)); err != nil {
+		return err
+	}
+
+	ji := jiraIssue(remote)
+	target := StatusToJira(issue.Status, a.config)
+	if ji.Fields.Status == nil || !strings.EqualFold(ji.Fields.Status.Name, target) {
+		if err := a.client.TransitionIssue(ctx, remote.ID, target); err != nil {
+			return fmt.Errorf("fields updated but status not changed: %w", err)
+		}
+	}
+	return nil
+}
+
+// ToLocal converts a Jira issue, keeping local values that Jira's mapping
+// cannot represent.
+func (a *Adapter) ToLocal(remote *tracker.RemoteIssue, local *types.Issue) *types.Issue {
+	ji := jiraIssue(remote)
+	issue := IssueToBeads(ji, a.client.URL, a.config)
+	if local != nil {
+		PreserveLocalFields(issue, ji, local, a.config)
+	}
+	return issue
+}
+
+// Matches reports whether the Jira issue already reflects the local issue.
+func (a *Adapter) Matches(local *types.Issue, remote *tracker.RemoteIssue) bool {
+	return IssueMatchesLocal(local, jiraIssue(remote), a.config)
+}
+
+// IsExternalRef reports whether ref is a browse URL on this Jira site.
+func (a *Adapter) IsExternalRef(ref string) bool {
+	return IsJiraExternalRef(ref, a.client.URL)
+}
+
+// ExtractID returns the issue key from a browse URL.
+func (a *Adapter) ExtractID(ref string) string {
+	return ExtractJiraKey(ref)
+}
+
+// ExternalRef returns the browse URL of a Jira issue.
+func (a *Adapter) ExternalRef(remote *tracker.RemoteIssue) string {
+	return FormatExternalRef(a.client.URL, remote.ID)
+}
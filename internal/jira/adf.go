@@ -0,0 +1,124 @@
+package jira
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// adfNode is a node in an Atlassian Document Format (ADF) document.
+type adfNode struct {
+	Type    string                 `json:"type"`
+	Text    string                 `json:"text,omitempty"`
+	Attrs   map[string]interface{} `json:"attrs,omitempty"`
+	Content []adfNode              `json:"content,omitempty"`
+}
+
+// DescriptionText returns the issue description as plain text, converting
+// ADF documents (v3 API) and passing plain strings (v2 API) through.
+func (f *IssueFields) DescriptionText() string {
+	return RichTextToPlain(f.Description)
+}
+
+// RichTextToPlain converts a Jira rich text field value to plain text.
+// The value may be a JSON string, an ADF document, or null.
+func RichTextToPlain(raw json.RawMessage) string {
+	if len(raw) == 0 || string(raw) == "null" {
+		return ""
+	}
+
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return s
+	}
+
+	var doc adfNode
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return ""
+	}
+	return adfToText(&doc)
+}
+
+// adfToText renders an ADF node as markdown-flavored plain text.
+func adfToText(node *adfNode) string {
+	if node.Type == "text" {
+		return node.Text
+	}
+
+	var children strings.Builder
+	for i := range node.Content {
+		children.WriteString(adfToText(&node.Content[i]))
+	}
+	text := children.String()
+
+	switch node.Type {
+	case "doc":
+		return strings.TrimSpace(text)
+	case "paragraph":
+		return text + "\n\n"
+	case "heading":
+		level := 1
+		if l, ok := node.Attrs["level"].(float64); ok && l >= 1 {
+			level = int(l)
+		}
+		return strings.Repeat("#", level) + " " + text + "\n\n"
+	case "listItem":
+		return "- " + strings.TrimSpace(text) + "\n"
+	case "codeBlock":
+		lang, _ := node.Attrs["language"].(string)
+		return fmt.Sprintf("```%s\n%s\n```\n\n", lang, text)
+	case "blockquote":
+		lines := strings.Split(strings.TrimSpace(text), "\n")
+		for i, line := range lines {
+			lines[i] = "> " + line
+		}
+		return strings.Join(lines, "\n") + "\n\n"
+	case "hardBreak":
+		return "\n"
+	case "rule":
+		return "---\n\n"
+	case "inlineCard":
+		u, _ := node.Attrs["url"].(string)
+		return u
+	case "mention":
+		name, _ := node.Attrs["text"].(string)
+		return "@" + strings.TrimPrefix(name, "@")
+	default:
+		return text
+	}
+}
+
+// TextToADF converts plain text to a minimal ADF document for the v3 API.
+// Blank-line separated blocks become paragraphs and single newlines become
+// hard breaks, so RichTextToPlain(TextToADF(s)) round-trips trimmed text.
+// Returns nil for empty text, which clears the field.
+func TextToADF(text string) interface{} {
+	text = strings.TrimSpace(strings.ReplaceAll(text, "\r\n", "\n"))
+	if text == "" {
+		return nil
+	}
+
+	var content []adfNode
+	for _, block := range strings.Split(text, "\n\n") {
+		block = strings.Trim(block, "\n")
+		if block == "" {
+			continue
+		}
+		para := adfNode{Type: "paragraph"}
+		for i, line := range strings.Split(block, "\n") {
+			if i > 0 {
+				para.Content = append(para.Content, adfNode{Type: "hardBreak"})
+			}
+			if line != "" {
+				para.Content = append(para.Content, adfNode{Type: "text", Text: line})
+			}
+		}
+		content = append(content, para)
+	}
+
+	return map[string]interface{}{
+		"type":    "doc",
+		"version": 1,
+		"content": content,
+	}
+}
@@ -0,0 +1,491 @@
+package jira
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// NewClient creates a new Jira client for the given site and project.
+// When username is empty the token is sent as a bearer token (Jira Server
+// personal access tokens); otherwise basic auth is used (Jira Cloud API tokens).
+func NewClient(siteURL, project, username, apiToken string) *Client {
+	siteURL = strings.TrimRight(siteURL, "/")
+	return &Client{
+		URL:        siteURL,
+		Endpoint:   siteURL,
+		Username:   username,
+		APIToken:   apiToken,
+		Project:    project,
+		APIVersion: DefaultAPIVersionFor(siteURL),
+		HTTPClient: &http.Client{
+			Timeout: DefaultTimeout,
+		},
+	}
+}
+
+// DefaultAPIVersionFor returns the REST API version to use for a Jira site:
+// version 3 for Jira Cloud (*.atlassian.net), version 2 otherwise.
+func DefaultAPIVersionFor(siteURL string) string {
+	if u, err := url.Parse(siteURL); err == nil && strings.HasSuffix(u.Hostname(), ".atlassian.net") {
+		return CloudAPIVersion
+	}
+	return ServerAPIVersion
+}
+
+// WithEndpoint returns a new client configured to send REST requests to the
+// specified base URL. Browse links in external refs still use the site URL.
+// This is useful for testing with mock servers or routing through a proxy.
+func (c *Client) WithEndpoint(endpoint string) *Client {
+	clone := *c
+	clone.Endpoint = strings.TrimRight(endpoint, "/")
+	return &clone
+}
+
+// WithHTTPClient returns a new client configured to use the specified HTTP client.
+// This is useful for testing or customizing timeouts and transport settings.
+func (c *Client) WithHTTPClient(httpClient *http.Client) *Client {
+	clone := *c
+	clone.HTTPClient = httpClient
+	return &clone
+}
+
+// WithAPIVersion returns a new client configured to use the specified REST
+// API version ("2" or "3").
+func (c *Client) WithAPIVersion(version string) *Client {
+	clone := *c
+	clone.APIVersion = version
+	return &clone
+}
+
+// WithFields returns a new client that also fetches the given fields, such
+// as custom fields mapped to Beads fields.
+func (c *Client) WithFields(fields ...string) *Client {
+	clone := *c
+	clone.Fields = fields
+	return &clone
+}
+
+// fieldList returns the fields parameter for issue requests.
+func (c *Client) fieldList() string {
+	if len(c.Fields) == 0 {
+		return searchFields
+	}
+	return searchFields + "," + strings.Join(c.Fields, ",")
+}
+
+// apiURL returns the URL of a REST resource, e.g. apiURL("/issue/PROJ-1").
+func (c *Client) apiURL(path string) string {
+	return fmt.Sprintf("%s/rest/api/%s%s", c.Endpoint, c.APIVersion, path)
+}
+
+// isCloudAPI reports whether the client talks to the v3 API, which uses ADF
+// for rich text and token-based search pagination.
+func (c *Client) isCloudAPI() bool {
+	return c.APIVersion == CloudAPIVersion
+}
+
+// Do sends a REST request to the Jira API and returns the response body.
+// A non-nil body is encoded as JSON. Handles rate limiting (429) and
+// temporary unavailability (503) with exponential backoff.
+func (c *Client) Do(ctx context.Context, method, reqURL string, body interface{}) ([]byte, error) {
+	var payload []byte
+	if body != nil {
+		var err error
+		payload, err = json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal request: %w", err)
+		}
+	}
+
+	var lastErr error
+	for attempt := 0; attempt <= MaxRetries; attempt++ {
+		var reader io.Reader
+		if payload != nil {
+			reader = bytes.NewReader(payload)
+		}
+		httpReq, err := http.NewRequestWithContext(ctx, method, reqURL, reader)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request: %w", err)
+		}
+
+		httpReq.Header.Set("Accept", "application/json")
+		if payload != nil {
+			httpReq.Header.Set("Content-Type", "application/json")
+		}
+		if c.Username != "" {
+			auth := base64.StdEncoding.EncodeToString([]byte(c.Username + ":" + c.APIToken))
+			httpReq.Header.Set("Authorization", "Basic "+auth)
+		} else if c.APIToken != "" {
+			httpReq.Header.Set("Authorization", "Bearer "+c.APIToken)
+		}
+
+		resp, err := c.HTTPClient.Do(httpReq)
+		if err != nil {
+			lastErr = fmt.Errorf("request failed (attempt %d/%d): %w", attempt+1, MaxRetries+1, err)
+			continue
+		}
+
+		respBody, err := io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		if err != nil {
+			lastErr = fmt.Errorf("failed to read response (attempt %d/%d): %w", attempt+1, MaxRetries+1, err)
+			continue
+		}
+
+		if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable {
+			delay := RetryDelay * time.Duration(1<<attempt) // Exponential backoff
+			lastErr = fmt.Errorf("rate limited (attempt %d/%d), retrying after %v", attempt+1, MaxRetries+1, delay)
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(delay):
+				continue
+			}
+		}
+
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			return nil, &APIError{StatusCode: resp.StatusCode, Messages: errorMessages(respBody)}
+		}
+
+		return respBody, nil
+	}
+
+	return nil, fmt.Errorf("max retries (%d) exceeded: %w", MaxRetries+1, lastErr)
+}
+
+// errorMessages extracts the messages from a Jira error body, which has the
+// shape {"errorMessages": [...], "errors": {"field": "message"}}.
+func errorMessages(body []byte) []string {
+	var apiErr struct {
+		ErrorMessages []string          `json:"errorMessages"`
+		Errors        map[string]string `json:"errors"`
+	}
+	if err := json.Unmarshal(body, &apiErr); err != nil {
+		if text := strings.TrimSpace(string(body)); text != "" {
+			return []string{text}
+		}
+		return nil
+	}
+
+	messages := append([]string(nil), apiErr.ErrorMessages...)
+	fields := make([]string, 0, len(apiErr.Errors))
+	for field := range apiErr.Errors {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	for _, field := range fields {
+		messages = append(messages, field+": "+apiErr.Errors[field])
+	}
+	return messages
+}
+
+func joinMessages(messages []string) string {
+	return strings.Join(messages, "; ")
+}
+
+// isNotFound reports whether err is a 404 response from the Jira API.
+func isNotFound(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
+
+// FetchIssues retrieves all issues in the project with optional filtering by state.
+// state can be: "open" (not in a done status), "closed" (done), or "all".
+func (c *Client) FetchIssues(ctx context.Context, state string) ([]Issue, error) {
+	return c.Search(ctx, c.projectJQL(state, ""))
+}
+
+// FetchIssuesSince retrieves issues that have been updated since the given time.
+// This enables incremental sync by only fetching issues modified after the last sync.
+// The state parameter can be: "open", "closed", or "all".
+func (c *Client) FetchIssuesSince(ctx context.Context, state string, since time.Time) ([]Issue, error) {
+	issues, err := c.Search(ctx, c.projectJQL(state, updatedSinceClause(since, time.Now())))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch issues since %s: %w", since.UTC().Format(time.RFC3339), err)
+	}
+	return issues, nil
+}
+
+// projectJQL builds the JQL query used to list project issues.
+func (c *Client) projectJQL(state, extra string) string {
+	clauses := []string{fmt.Sprintf("project = %s", quoteJQL(c.Project))}
+	switch state {
+	case "open":
+		clauses = append(clauses, "statusCategory != Done")
+	case "closed":
+		clauses = append(clauses, "statusCategory = Done")
+	}
+	if extra != "" {
+		clauses = append(clauses, extra)
+	}
+	return strings.Join(clauses, " AND ") + " ORDER BY updated ASC"
+}
+
+// updatedSinceClause returns a JQL clause matching issues updated at or after since.
+// JQL interprets absolute dates in the Jira user's time zone and only to the
+// minute, so the clause uses a relative offset ("-90m") rounded up by a minute,
+// which is time zone independent and never misses an update.
+func updatedSinceClause(since, now time.Time) string {
+	minutes := int(math.Ceil(now.Sub(since).Minutes())) + 1
+	if minutes < 1 {
+		minutes = 1
+	}
+	return fmt.Sprintf(`updated >= "-%dm"`, minutes)
+}
+
+// quoteJQL quotes a value for use in a JQL query.
+func quoteJQL(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+// Search runs a JQL query and returns all matching issues, following pagination.
+// The v3 API pages with nextPageToken; the v2 API pages with startAt/total.
+func (c *Client) Search(ctx context.Context, jql string) ([]Issue, error) {
+	var allIssues []Issue
+	startAt := 0
+	nextPageToken := ""
+
+	for {
+		params := url.Values{}
+		params.Set("jql", jql)
+		params.Set("fields", c.fieldList())
+		params.Set("maxResults", strconv.Itoa(MaxPageSize))
+
+		var reqURL string
+		if c.isCloudAPI() {
+			if nextPageToken != "" {
+				params.Set("nextPageToken", nextPageToken)
+			}
+			reqURL = c.apiURL("/search/jql") + "?" + params.Encode()
+		} else {
+			params.Set("startAt", strconv.Itoa(startAt))
+			reqURL = c.apiURL("/search") + "?" + params.Encode()
+		}
+
+		body, err := c.Do(ctx, http.MethodGet, reqURL, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to search issues: %w", err)
+		}
+
+		var page SearchResponse
+		if err := json.Unmarshal(body, &page); err != nil {
+			return nil, fmt.Errorf("failed to parse search response: %w", err)
+		}
+		allIssues = append(allIssues, page.Issues...)
+
+		if c.isCloudAPI() {
+			if page.IsLast || page.NextPageToken == "" {
+				break
+			}
+			nextPageToken = page.NextPageToken
+			continue
+		}
+
+		startAt += len(page.Issues)
+		if len(page.Issues) == 0 || startAt >= page.Total {
+			break
+		}
+	}
+
+	return allIssues, nil
+}
+
+// FetchIssue retrieves a single issue by key.
+// Returns nil if the issue doesn't exist or is not visible.
+func (c *Client) FetchIssue(ctx context.Context, key string) (*Issue, error) {
+	params := url.Values{}
+	params.Set("fields", c.fieldList())
+	reqURL := c.apiURL("/issue/"+url.PathEscape(key)) + "?" + params.Encode()
+
+	body, err := c.Do(ctx, http.MethodGet, reqURL, nil)
+	if err != nil {
+		if isNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to fetch issue %s: %w", key, err)
+	}
+
+	var issue Issue
+	if err := json.Unmarshal(body, &issue); err != nil {
+		return nil, fmt.Errorf("failed to parse issue response: %w", err)
+	}
+	return &issue, nil
+}
+
+// CreateIssue creates a new issue in the configured project.
+func (c *Client) CreateIssue(ctx context.Context, req *IssueRequest) (*CreateIssueResponse, error) {
+	fields := c.issueFields(req)
+	fields["project"] = map[string]string{"key": c.Project}
+
+	body, err := c.Do(ctx, http.MethodPost, c.apiURL("/issue"), map[string]interface{}{"fields": fields})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create issue: %w", err)
+	}
+
+	var created CreateIssueResponse
+	if err := json.Unmarshal(body, &created); err != nil {
+		return nil, fmt.Errorf("failed to parse create response: %w", err)
+	}
+	if created.Key == "" {
+		return nil, fmt.Errorf("issue creation reported success but returned no key")
+	}
+	return &created, nil
+}
+
+// UpdateIssue updates the fields of an existing issue.
+func (c *Client) UpdateIssue(ctx context.Context, key string, req *IssueRequest) error {
+	fields := c.issueFields(req)
+	if _, err := c.Do(ctx, http.MethodPut, c.apiURL("/issue/"+url.PathEscape(key)), map[string]interface{}{"fields": fields}); err != nil {
+		return fmt.Errorf("failed to update issue %s: %w", key, err)
+	}
+	return nil
+}
+
+// issueFields builds the "fields" payload for an issue request, encoding the
+// description as ADF for the v3 API.
+func (c *Client) issueFields(req *IssueRequest) map[string]interface{} {
+	labels := req.Labels
+	if labels == nil {
+		labels = []string{}
+	}
+	fields := map[string]interface{}{
+		"summary": req.Summary,
+		"labels":  labels,
+	}
+	if c.isCloudAPI() {
+		fields["description"] = TextToADF(req.Description)
+	} else {
+		fields["description"] = req.Description
+	}
+	if req.IssueType != "" {
+		fields["issuetype"] = map[string]string{"name": req.IssueType}
+	}
+	if req.Priority != "" {
+		fields["priority"] = map[string]string{"name": req.Priority}
+	}
+	for id, value := range req.Fields {
+		fields[id] = value
+	}
+	return fields
+}
+
+// GetTransitions returns the workflow transitions currently available on an issue.
+func (c *Client) GetTransitions(ctx context.Context, key string) ([]Transition, error) {
+	body, err := c.Do(ctx, http.MethodGet, c.apiURL("/issue/"+url.PathEscape(key)+"/transitions"), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch transitions for %s: %w", key, err)
+	}
+
+	var resp TransitionsResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse transitions response: %w", err)
+	}
+	return resp.Transitions, nil
+}
+
+// TransitionIssue moves an issue to the named status using one of its
+// available workflow transitions. Matching is case-insensitive and prefers
+// the transition's target status name over the transition name.
+func (c *Client) TransitionIssue(ctx context.Context, key, statusName string) error {
+	transitions, err := c.GetTransitions(ctx, key)
+	if err != nil {
+		return err
+	}
+
+	transition := FindTransition(transitions, statusName)
+	if transition == nil {
+		return fmt.Errorf("no transition to status %q available for %s", statusName, key)
+	}
+
+	payload := map[string]interface{}{
+		"transition": map[string]string{"id": transition.ID},
+	}
+	if _, err := c.Do(ctx, http.MethodPost, c.apiURL("/issue/"+url.PathEscape(key)+"/transitions"), payload); err != nil {
+		return fmt.Errorf("failed to transition %s to %q: %w", key, statusName, err)
+	}
+	return nil
+}
+
+// FindTransition returns the transition leading to the named status, or nil.
+func FindTransition(transitions []Transition, statusName string) *Transition {
+	for i := range transitions {
+		if strings.EqualFold(transitions[i].To.Name, statusName) {
+			return &transitions[i]
+		}
+	}
+	for i := range transitions {
+		if strings.EqualFold(transitions[i].Name, statusName) {
+			return &transitions[i]
+		}
+	}
+	return nil
+}
+
+// FormatExternalRef returns the browse URL used as external_ref for a Jira issue.
+func FormatExternalRef(siteURL, key string) string {
+	return strings.TrimRight(siteURL, "/") + "/browse/" + key
+}
+
+// IsJiraExternalRef checks if an external_ref URL matches the configured Jira instance.
+// It validates both the URL structure (/browse/PROJECT-123) and optionally the host.
+func IsJiraExternalRef(externalRef, siteURL string) bool {
+	if !strings.Contains(externalRef, "/browse/") {
+		return false
+	}
+
+	if siteURL != "" {
+		siteURL = strings.TrimRight(siteURL, "/")
+		if !strings.HasPrefix(externalRef, siteURL) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ExtractJiraKey extracts the Jira issue key from an external_ref URL.
+// For example, "https://company.atlassian.net/browse/PROJ-123" returns "PROJ-123".
+func ExtractJiraKey(externalRef string) string {
+	idx := strings.LastIndex(externalRef, "/browse/")
+	if idx == -1 {
+		return ""
+	}
+	return externalRef[idx+len("/browse/"):]
+}
+
+// ParseTimestamp parses Jira's timestamp format into a time.Time.
+// Jira uses ISO 8601 with timezone: 2024-01-15T10:30:00.000+0000 or 2024-01-15T10:30:00.000Z
+func ParseTimestamp(ts string) (time.Time, error) {
+	if ts == "" {
+		return time.Time{}, fmt.Errorf("empty timestamp")
+	}
+
+	formats := []string{
+		"2006-01-02T15:04:05.000-0700",
+		"2006-01-02T15:04:05.000Z",
+		"2006-01-02T15:04:05-0700",
+		"2006-01-02T15:04:05Z",
+		time.RFC3339,
+		time.RFC3339Nano,
+	}
+
+	for _, format := range formats {
+		if t, err := time.Parse(format, ts); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("unrecognized timestamp format: %s", ts)
+}
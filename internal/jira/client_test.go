@@ -0,0 +1,417 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewClient(t *testing.T) {
+	client := NewClient("https://company.atlassian.net/", "PROJ", "me@example.com", "token")
+
+	if client.URL != "https://company.atlassian.net" || client.Endpoint != client.URL {
+		t.Errorf("URL/Endpoint = %q/%q, want trimmed site URL", client.URL, client.Endpoint)
+	}
+	if client.APIVersion != CloudAPIVersion {
+		t.Errorf("APIVersion = %q, want %q for Jira Cloud", client.APIVersion, CloudAPIVersion)
+	}
+	if client.HTTPClient == nil || client.HTTPClient.Timeout != DefaultTimeout {
+		t.Error("expected HTTP client with default timeout")
+	}
+
+	server := NewClient("https://jira.company.com", "PROJ", "", "pat")
+	if server.APIVersion != ServerAPIVersion {
+		t.Errorf("APIVersion = %q, want %q for Jira Server", server.APIVersion, ServerAPIVersion)
+	}
+}
+
+func TestWithEndpointKeepsSiteURL(t *testing.T) {
+	client := NewClient("https://company.atlassian.net", "PROJ", "", "token")
+	proxied := client.WithEndpoint("http://localhost:8080/")
+
+	if proxied.Endpoint != "http://localhost:8080" {
+		t.Errorf("Endpoint = %q", proxied.Endpoint)
+	}
+	if proxied.URL != "https://company.atlassian.net" {
+		t.Errorf("URL = %q, browse links should keep the site URL", proxied.URL)
+	}
+	if client.Endpoint != "https://company.atlassian.net" {
+		t.Error("WithEndpoint should not modify the original client")
+	}
+}
+
+// newTestClient returns a client pointed at handler using the given API version.
+func newTestClient(t *testing.T, version string, handler http.HandlerFunc) *Client {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	return NewClient(server.URL, "PROJ", "me@example.com", "secret").
+		WithAPIVersion(version).
+		WithHTTPClient(server.Client())
+}
+
+func issueJSON(key string) map[string]interface{} {
+	return map[string]interface{}{
+		"id":  strings.TrimPrefix(key, "PROJ-"),
+		"key": key,
+		"fields": map[string]interface{}{
+			"summary": "Issue " + key,
+			"updated": "2025-01-02T03:04:05.000+0000",
+		},
+	}
+}
+
+func TestSearchPaginatesV2(t *testing.T) {
+	var startAts []string
+	client := newTestClient(t, ServerAPIVersion, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/api/2/search" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		startAt, _ := strconv.Atoi(r.URL.Query().Get("startAt"))
+		startAts = append(startAts, r.URL.Query().Get("startAt"))
+
+		var issues []map[string]interface{}
+		for i := startAt; i < startAt+2 && i < 3; i++ {
+			issues = append(issues, issueJSON(fmt.Sprintf("PROJ-%d", i+1)))
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"startAt": startAt, "maxResults": 2, "total": 3, "issues": issues,
+		})
+	})
+
+	issues, err := client.FetchIssues(context.Background(), "all")
+	if err != nil {
+		t.Fatalf("FetchIssues: %v", err)
+	}
+	if len(issues) != 3 || issues[2].Key != "PROJ-3" {
+		t.Fatalf("got %d issues: %+v", len(issues), issues)
+	}
+	if strings.Join(startAts, ",") != "0,2" {
+		t.Errorf("startAt sequence = %v, want 0,2", startAts)
+	}
+}
+
+func TestSearchPaginatesV3(t *testing.T) {
+	var tokens []string
+	client := newTestClient(t, CloudAPIVersion, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/api/3/search/jql" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		token := r.URL.Query().Get("nextPageToken")
+		tokens = append(tokens, token)
+
+		resp := map[string]interface{}{"issues": []interface{}{issueJSON("PROJ-1")}, "nextPageToken": "page2"}
+		if token == "page2" {
+			resp = map[string]interface{}{"issues": []interface{}{issueJSON("PROJ-2")}, "isLast": true}
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	})
+
+	issues, err := client.FetchIssues(context.Background(), "all")
+	if err != nil {
+		t.Fatalf("FetchIssues: %v", err)
+	}
+	if len(issues) != 2 || issues[1].Key != "PROJ-2" {
+		t.Fatalf("got %+v", issues)
+	}
+	if strings.Join(tokens, ",") != ",page2" {
+		t.Errorf("tokens = %v", tokens)
+	}
+}
+
+func TestFetchIssuesJQL(t *testing.T) {
+	var jqls []string
+	client := newTestClient(t, CloudAPIVersion, func(w http.ResponseWriter, r *http.Request) {
+		jqls = append(jqls, r.URL.Query().Get("jql"))
+		if auth := r.Header.Get("Authorization"); !strings.HasPrefix(auth, "Basic ") {
+			t.Errorf("Authorization = %q, want basic auth", auth)
+		}
+		_, _ = io.WriteString(w, `{"issues": [], "isLast": true}`)
+	})
+
+	ctx := context.Background()
+	if _, err := client.FetchIssues(ctx, "open"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.FetchIssuesSince(ctx, "closed", time.Now().Add(-90*time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+
+	if jqls[0] != `project = "PROJ" AND statusCategory != Done ORDER BY updated ASC` {
+		t.Errorf("open JQL = %q", jqls[0])
+	}
+	if !strings.HasPrefix(jqls[1], `project = "PROJ" AND statusCategory = Done AND updated >= "-`) {
+		t.Errorf("since JQL = %q", jqls[1])
+	}
+}
+
+func TestUpdatedSinceClause(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		since time.Time
+		want  string
+	}{
+		{now.Add(-90 * time.Minute), `updated >= "-91m"`},
+		{now.Add(-90*time.Minute - 10*time.Second), `updated >= "-92m"`},
+		{now.Add(time.Minute), `updated >= "-1m"`},
+	}
+	for _, tt := range tests {
+		if got := updatedSinceClause(tt.since, now); got != tt.want {
+			t.Errorf("updatedSinceClause(%v) = %q, want %q", now.Sub(tt.since), got, tt.want)
+		}
+	}
+}
+
+func TestBearerAuthWithoutUsername(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if auth := r.Header.Get("Authorization"); auth != "Bearer pat" {
+			t.Errorf("Authorization = %q, want bearer token", auth)
+		}
+		_, _ = io.WriteString(w, `{"key": "PROJ-1", "fields": {"summary": "x"}}`)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "PROJ", "", "pat")
+	if _, err := client.FetchIssue(context.Background(), "PROJ-1"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFetchIssueNotFound(t *testing.T) {
+	client := newTestClient(t, ServerAPIVersion, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = io.WriteString(w, `{"errorMessages": ["Issue does not exist or you do not have permission to see it."]}`)
+	})
+
+	issue, err := client.FetchIssue(context.Background(), "PROJ-404")
+	if err != nil || issue != nil {
+		t.Errorf("FetchIssue = %v, %v; want nil, nil", issue, err)
+	}
+}
+
+func TestAPIErrorMessages(t *testing.T) {
+	client := newTestClient(t, ServerAPIVersion, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = io.WriteString(w, `{"errorMessages": ["bad"], "errors": {"summary": "required", "issuetype": "invalid"}}`)
+	})
+
+	_, err := client.CreateIssue(context.Background(), &IssueRequest{})
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected APIError, got %v", err)
+	}
+	if apiErr.StatusCode != http.StatusBadRequest {
+		t.Errorf("StatusCode = %d", apiErr.StatusCode)
+	}
+	if !strings.Contains(err.Error(), "bad; issuetype: invalid; summary: required") {
+		t.Errorf("error = %v", err)
+	}
+}
+
+func TestRetryOnRateLimit(t *testing.T) {
+	attempts := 0
+	client := newTestClient(t, ServerAPIVersion, func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		_, _ = io.WriteString(w, `{"key": "PROJ-1", "fields": {}}`)
+	})
+
+	if _, err := client.FetchIssue(context.Background(), "PROJ-1"); err != nil {
+		t.Fatal(err)
+	}
+	if attempts != 2 {
+		t.Errorf("attempts = %d, want 2", attempts)
+	}
+}
+
+func TestCreateAndUpdatePayloads(t *testing.T) {
+	for _, version := range []string{ServerAPIVersion, CloudAPIVersion} {
+		t.Run("v"+version, func(t *testing.T) {
+			var bodies []map[string]interface{}
+			client := newTestClient(t, version, func(w http.ResponseWriter, r *http.Request) {
+				var body map[string]interface{}
+				_ = json.NewDecoder(r.Body).Decode(&body)
+				bodies = append(bodies, body)
+				switch r.Method {
+				case http.MethodPost:
+					_, _ = io.WriteString(w, `{"id": "10001", "key": "PROJ-7"}`)
+				case http.MethodPut:
+					if r.URL.Path != "/rest/api/"+version+"/issue/PROJ-7" {
+						t.Errorf("unexpected path %s", r.URL.Path)
+					}
+					w.WriteHeader(http.StatusNoContent)
+				}
+			})
+
+			req := &IssueRequest{Summary: "Title", Description: "Line one\nLine two", IssueType: "Bug", Priority: "High"}
+			created, err := client.CreateIssue(context.Background(), req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if created.Key != "PROJ-7" {
+				t.Errorf("Key = %q", created.Key)
+			}
+			if err := client.UpdateIssue(context.Background(), "PROJ-7", req); err != nil {
+				t.Fatal(err)
+			}
+
+			fields := bodies[0]["fields"].(map[string]interface{})
+			if fields["project"].(map[string]interface{})["key"] != "PROJ" {
+				t.Errorf("project = %v", fields["project"])
+			}
+			if fields["issuetype"].(map[string]interface{})["name"] != "Bug" ||
+				fields["priority"].(map[string]interface{})["name"] != "High" {
+				t.Errorf("issuetype/priority = %v/%v", fields["issuetype"], fields["priority"])
+			}
+			if labels, ok := fields["labels"].([]interface{}); !ok || len(labels) != 0 {
+				t.Errorf("labels = %#v, want empty list", fields["labels"])
+			}
+
+			raw, _ := json.Marshal(fields["description"])
+			if got := RichTextToPlain(raw); got != "Line one\nLine two" {
+				t.Errorf("description round-trip = %q", got)
+			}
+			if _, isString := fields["description"].(string); isString != (version == ServerAPIVersion) {
+				t.Errorf("description encoding = %T for v%s", fields["description"], version)
+			}
+
+			if _, ok := bodies[1]["fields"].(map[string]interface{})["project"]; ok {
+				t.Error("update should not send project")
+			}
+		})
+	}
+}
+
+func TestTransitionIssue(t *testing.T) {
+	var posted string
+	client := newTestClient(t, ServerAPIVersion, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			_, _ = io.WriteString(w, `{"transitions": [
+				{"id": "11", "name": "Start", "to": {"name": "In Progress"}},
+				{"id": "31", "name": "Finish", "to": {"name": "Done"}}
+			]}`)
+			return
+		}
+		var body struct {
+			Transition struct {
+				ID string `json:"id"`
+			} `json:"transition"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		posted = body.Transition.ID
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	ctx := context.Background()
+	if err := client.TransitionIssue(ctx, "PROJ-1", "done"); err != nil {
+		t.Fatal(err)
+	}
+	if posted != "31" {
+		t.Errorf("posted transition %q, want 31", posted)
+	}
+	if err := client.TransitionIssue(ctx, "PROJ-1", "Blocked"); err == nil {
+		t.Error("expected error when no transition leads to the status")
+	}
+}
+
+func TestFindTransitionFallsBackToName(t *testing.T) {
+	transitions := []Transition{{ID: "5", Name: "Reopen", To: Status{Name: "Open"}}}
+	if tr := FindTransition(transitions, "reopen"); tr == nil || tr.ID != "5" {
+		t.Errorf("FindTransition by name = %+v", tr)
+	}
+}
+
+func TestIsJiraExternalRef(t *testing.T) {
+	tests := []struct {
+		name        string
+		externalRef string
+		jiraURL     string
+		want        bool
+	}{
+		{"valid Jira Cloud URL", "https://company.atlassian.net/browse/PROJ-123", "https://company.atlassian.net", true},
+		{"trailing slash in config", "https://company.atlassian.net/browse/PROJ-123", "https://company.atlassian.net/", true},
+		{"valid Jira Server URL", "https://jira.company.com/browse/PROJ-456", "https://jira.company.com", true},
+		{"mismatched Jira host", "https://other.atlassian.net/browse/PROJ-123", "https://company.atlassian.net", false},
+		{"GitHub issue URL", "https://github.com/org/repo/issues/123", "https://company.atlassian.net", false},
+		{"empty external_ref", "", "https://company.atlassian.net", false},
+		{"no jiraURL configured - valid pattern", "https://any.atlassian.net/browse/PROJ-123", "", true},
+		{"no jiraURL configured - invalid pattern", "https://github.com/org/repo/issues/123", "", false},
+		{"browse in path but not Jira format", "https://example.com/browse/docs/page", "", true},
+		{"browse in path with jiraURL check", "https://example.com/browse/docs/page", "https://company.atlassian.net", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsJiraExternalRef(tt.externalRef, tt.jiraURL); got != tt.want {
+				t.Errorf("IsJiraExternalRef(%q, %q) = %v, want %v", tt.externalRef, tt.jiraURL, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtractJiraKey(t *testing.T) {
+	tests := []struct {
+		externalRef string
+		want        string
+	}{
+		{"https://company.atlassian.net/browse/PROJ-123", "PROJ-123"},
+		{"https://jira.company.com/browse/ISSUE-456", "ISSUE-456"},
+		{"https://company.atlassian.net/browse/ABC-789/some/path", "ABC-789/some/path"},
+		{"https://github.com/org/repo/issues/123", ""},
+		{"", ""},
+		{"https://example.com/browse/", ""},
+	}
+
+	for _, tt := range tests {
+		if got := ExtractJiraKey(tt.externalRef); got != tt.want {
+			t.Errorf("ExtractJiraKey(%q) = %q, want %q", tt.externalRef, got, tt.want)
+		}
+	}
+
+	if ref := FormatExternalRef("https://jira.company.com/", "PROJ-1"); ref != "https://jira.company.com/browse/PROJ-1" {
+		t.Errorf("FormatExternalRef = %q", ref)
+	}
+}
+
+func TestParseTimestamp(t *testing.T) {
+	tests := []struct {
+		timestamp string
+		wantErr   bool
+	}{
+		{"2024-01-15T10:30:00.000+0000", false},
+		{"2024-01-15T10:30:00.000Z", false},
+		{"2024-01-15T10:30:00+0000", false},
+		{"2024-01-15T10:30:00Z", false},
+		{"2024-06-15T10:30:00.000-0500", false},
+		{"", true},
+		{"not-a-timestamp", true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseTimestamp(tt.timestamp)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseTimestamp(%q) error = %v, wantErr %v", tt.timestamp, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && got.Year() != 2024 {
+			t.Errorf("ParseTimestamp(%q) year = %d", tt.timestamp, got.Year())
+		}
+	}
+
+	got, _ := ParseTimestamp("2024-06-15T10:30:00.000-0500")
+	if !got.Equal(time.Date(2024, 6, 15, 15, 30, 0, 0, time.UTC)) {
+		t.Errorf("offset not applied: %v", got)
+	}
+}
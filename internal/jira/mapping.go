@@ -0,0 +1,568 @@
+package jira
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// DefaultPriority is the Beads priority used when a Jira issue has no priority
+// or an unmapped one.
+const DefaultPriority = 2
+
+// MappingConfig holds configurable mappings between Jira and Beads.
+// Jira-side names are matched case-insensitively, treating underscores as
+// spaces, so "in_review" in config matches the Jira status "In Review".
+type MappingConfig struct {
+	// StatusMap maps Jira status names to Beads statuses.
+	// Statuses not listed fall back to their Jira status category.
+	StatusMap map[string]string
+
+	// PriorityMap maps Jira priority names to Beads priorities (0-4).
+	PriorityMap map[string]int
+
+	// TypeMap maps Jira issue type names to Beads issue types.
+	TypeMap map[string]string
+
+	// ReverseStatusMap maps Beads statuses to the Jira status to transition to.
+	ReverseStatusMap map[string]string
+
+	// ReversePriorityMap maps Beads priorities to Jira priority names.
+	ReversePriorityMap map[int]string
+
+	// ReverseTypeMap maps Beads issue types to Jira issue type names.
+	ReverseTypeMap map[string]string
+
+	// FieldMap maps Beads custom field names to Jira field IDs
+	// (e.g. "points" -> "customfield_10016", "due" -> "duedate").
+	FieldMap map[string]string
+
+	// FieldTypes holds the type of each Beads custom field (int, enum,
+	// user, ...), which decides how its value is sent to Jira.
+	FieldTypes map[string]string
+}
+
+// DefaultMappingConfig returns sensible default mappings for the stock Jira
+// workflows, priorities and issue types.
+func DefaultMappingConfig() *MappingConfig {
+	return &MappingConfig{
+		StatusMap: map[string]string{
+			"to do":                    "open",
+			"todo":                     "open",
+			"open":                     "open",
+			"backlog":                  "open",
+			"new":                      "open",
+			"selected for development": "open",
+			"in progress":              "in_progress",
+			"in development":           "in_progress",
+			"in review":                "in_progress",
+			"review":                   "in_progress",
+			"blocked":                  "blocked",
+			"on hold":                  "blocked",
+			"done":                     "closed",
+			"closed":                   "closed",
+			"resolved":                 "closed",
+			"complete":                 "closed",
+			"completed":                "closed",
+			"won't do":                 "closed",
+			"won't fix":                "closed",
+			"duplicate":                "closed",
+			"cannot reproduce":         "closed",
+		},
+		PriorityMap: map[string]int{
+			"highest":  0,
+			"critical": 0,
+			"blocker":  0,
+			"high":     1,
+			"major":    1,
+			"medium":   2,
+			"normal":   2,
+			"low":      3,
+			"minor":    3,
+			"lowest":   4,
+			"trivial":  4,
+		},
+		TypeMap: map[string]string{
+			"bug":            "bug",
+			"defect":         "bug",
+			"story":          "feature",
+			"feature":        "feature",
+			"new feature":    "feature",
+			"improvement":    "feature",
+			"enhancement":    "feature",
+			"task":           "task",
+			"sub-task":       "task",
+			"subtask":        "task",
+			"epic":           "epic",
+			"initiative":     "epic",
+			"technical task": "chore",
+			"technical debt": "chore",
+			"maintenance":    "chore",
+			"chore":          "chore",
+		},
+		ReverseStatusMap: map[string]string{
+			"open":        "To Do",
+			"in_progress": "In Progress",
+			"blocked":     "Blocked",
+			"deferred":    "To Do",
+			"closed":      "Done",
+		},
+		ReversePriorityMap: map[int]string{
+			0: "Highest",
+			1: "High",
+			2: "Medium",
+			3: "Low",
+			4: "Lowest",
+		},
+		ReverseTypeMap: map[string]string{
+			"bug":     "Bug",
+			"feature": "Story",
+			"task":    "Task",
+			"epic":    "Epic",
+			"chore":   "Task",
+		},
+		FieldMap:   map[string]string{},
+		FieldTypes: map[string]string{},
+	}
+}
+
+// ConfigLoader is an interface for loading configuration values.
+// This allows the mapping package to be decoupled from the storage layer.
+type ConfigLoader interface {
+	GetAllConfig() (map[string]string, error)
+}
+
+// LoadMappingConfig loads mapping configuration from a config loader.
+// Config keys follow the pattern: jira.<category>_map.<key> = <value>
+// Examples:
+//
+//	jira.status_map.in_review = in_progress    (Jira "In Review" -> Beads in_progress)
+//	jira.priority_map.critical = 0
+//	jira.type_map.story = feature
+//	jira.reverse_status_map.blocked = "On Hold" (Beads blocked -> Jira "On Hold")
+//	jira.reverse_priority_map.0 = Blocker
+//	jira.reverse_type_map.chore = Chore
+//	jira.field_map.points = customfield_10016  (Beads custom field -> Jira field ID)
+func LoadMappingConfig(loader ConfigLoader) *MappingConfig {
+	config := DefaultMappingConfig()
+
+	if loader == nil {
+		return config
+	}
+
+	allConfig, err := loader.GetAllConfig()
+	if err != nil {
+		return config
+	}
+
+	for key, value := range allConfig {
+		switch {
+		case strings.HasPrefix(key, "jira.status_map."):
+			config.StatusMap[normalizeName(strings.TrimPrefix(key, "jira.status_map."))] = value
+
+		case strings.HasPrefix(key, "jira.priority_map."):
+			if beadsPriority, err := parseIntValue(value); err == nil {
+				config.PriorityMap[normalizeName(strings.TrimPrefix(key, "jira.priority_map."))] = beadsPriority
+			}
+
+		case strings.HasPrefix(key, "jira.type_map."):
+			config.TypeMap[normalizeName(strings.TrimPrefix(key, "jira.type_map."))] = value
+
+		case strings.HasPrefix(key, "jira.reverse_status_map."):
+			config.ReverseStatusMap[strings.ToLower(strings.TrimPrefix(key, "jira.reverse_status_map."))] = value
+
+		case strings.HasPrefix(key, "jira.reverse_priority_map."):
+			if beadsPriority, err := parseIntValue(strings.TrimPrefix(key, "jira.reverse_priority_map.")); err == nil {
+				config.ReversePriorityMap[beadsPriority] = value
+			}
+
+		case strings.HasPrefix(key, "jira.reverse_type_map."):
+			config.ReverseTypeMap[strings.ToLower(strings.TrimPrefix(key, "jira.reverse_type_map."))] = value
+
+		case strings.HasPrefix(key, "jira.field_map."):
+			if id := strings.TrimSpace(value); id != "" {
+				config.FieldMap[strings.TrimPrefix(key, "jira.field_map.")] = id
+			}
+		}
+	}
+
+	return config
+}
+
+// FieldIDs returns the Jira field IDs that custom fields are mapped to, sorted.
+func (c *MappingConfig) FieldIDs() []string {
+	ids := make([]string, 0, len(c.FieldMap))
+	for _, id := range c.FieldMap {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// normalizeName lowercases a Jira name and treats underscores as spaces.
+func normalizeName(s string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(s), "_", " "))
+}
+
+// parseIntValue safely parses an integer from a string config value.
+func parseIntValue(s string) (int, error) {
+	var v int
+	_, err := fmt.Sscanf(s, "%d", &v)
+	return v, err
+}
+
+// StatusToBeads maps a Jira status to a Beads status.
+// Unmapped statuses fall back to their status category
+// (new -> open, indeterminate -> in_progress, done -> closed).
+func StatusToBeads(status *Status, config *MappingConfig) types.Status {
+	if status == nil {
+		return types.StatusOpen
+	}
+	if mapped, ok := config.StatusMap[normalizeName(status.Name)]; ok {
+		return types.Status(mapped)
+	}
+	if status.StatusCategory != nil {
+		switch status.StatusCategory.Key {
+		case "indeterminate":
+			return types.StatusInProgress
+		case "done":
+			return types.StatusClosed
+		}
+	}
+	return types.StatusOpen
+}
+
+// StatusToJira returns the Jira status name a Beads status should be transitioned to.
+func StatusToJira(status types.Status, config *MappingConfig) string {
+	if name, ok := config.ReverseStatusMap[string(status)]; ok {
+		return name
+	}
+	return config.ReverseStatusMap[string(types.StatusOpen)]
+}
+
+// PriorityToBeads maps a Jira priority to a Beads priority (0-4).
+func PriorityToBeads(priority *Priority, config *MappingConfig) int {
+	if priority == nil {
+		return DefaultPriority
+	}
+	if mapped, ok := config.PriorityMap[normalizeName(priority.Name)]; ok {
+		return mapped
+	}
+	return DefaultPriority
+}
+
+// PriorityToJira returns the Jira priority name for a Beads priority.
+func PriorityToJira(priority int, config *MappingConfig) string {
+	if name, ok := config.ReversePriorityMap[priority]; ok {
+		return name
+	}
+	return config.ReversePriorityMap[DefaultPriority]
+}
+
+// TypeToBeads maps a Jira issue type to a Beads issue type.
+func TypeToBeads(issueType *IssueType, config *MappingConfig) types.IssueType {
+	if issueType == nil {
+		return types.TypeTask
+	}
+	if mapped, ok := config.TypeMap[normalizeName(issueType.Name)]; ok {
+		return types.IssueType(mapped)
+	}
+	return types.TypeTask
+}
+
+// TypeToJira returns the Jira issue type name for a Beads issue type.
+func TypeToJira(issueType types.IssueType, config *MappingConfig) string {
+	if name, ok := config.ReverseTypeMap[string(issueType)]; ok {
+		return name
+	}
+	return config.ReverseTypeMap[string(types.TypeTask)]
+}
+
+// BuildJiraDescription formats a Beads issue for Jira's description field.
+// This mirrors the payload used during push to keep hash comparisons consistent.
+func BuildJiraDescription(issue *types.Issue) string {
+	description := issue.Description
+	if issue.AcceptanceCriteria != "" {
+		description += "\n\n## Acceptance Criteria\n" + issue.AcceptanceCriteria
+	}
+	if issue.Design != "" {
+		description += "\n\n## Design\n" + issue.Design
+	}
+	if issue.Notes != "" {
+		description += "\n\n## Notes\n" + issue.Notes
+	}
+	return strings.TrimSpace(description)
+}
+
+// IssueToBeads converts a Jira issue to a Beads issue. siteURL is used to
+// build the browse URL stored as external_ref.
+func IssueToBeads(ji *Issue, siteURL string, config *MappingConfig) *types.Issue {
+	fields := &ji.Fields
+	issue := &types.Issue{
+		Title:       fields.Summary,
+		Description: fields.DescriptionText(),
+		Status:      StatusToBeads(fields.Status, config),
+		Priority:    PriorityToBeads(fields.Priority, config),
+		IssueType:   TypeToBeads(fields.IssueType, config),
+	}
+
+	if created, err := ParseTimestamp(fields.Created); err == nil {
+		issue.CreatedAt = created
+	}
+	if updated, err := ParseTimestamp(fields.Updated); err == nil {
+		issue.UpdatedAt = updated
+	}
+
+	if fields.Assignee != nil {
+		issue.Assignee = userName(fields.Assignee)
+	}
+
+	if len(fields.Labels) > 0 {
+		issue.Labels = append([]string(nil), fields.Labels...)
+	}
+
+	if issue.Status == types.StatusClosed {
+		closedAt := issue.UpdatedAt
+		if resolved, err := ParseTimestamp(fields.ResolutionDate); err == nil {
+			closedAt = resolved
+		}
+		issue.ClosedAt = &closedAt
+	}
+
+	issue.CustomFields = CustomFieldsToBeads(fields, config)
+
+	externalRef := FormatExternalRef(siteURL, ji.Key)
+	issue.ExternalRef = &externalRef
+
+	return issue
+}
+
+// CustomFieldsToBeads returns the values of the Jira fields mapped to Beads
+// custom fields, keyed by field name. Select options and users are reduced to
+// their names, and date-times to the date for date fields. Returns nil if no
+// fields are mapped.
+func CustomFieldsToBeads(fields *IssueFields, config *MappingConfig) map[string]string {
+	if len(config.FieldMap) == 0 {
+		return nil
+	}
+	result := map[string]string{}
+	for name, id := range config.FieldMap {
+		value := fieldText(fields.Other[id])
+		if config.FieldTypes[name] == "date" && len(value) > len("2006-01-02") {
+			value = value[:len("2006-01-02")]
+		}
+		if value != "" {
+			result[name] = value
+		}
+	}
+	return result
+}
+
+// fieldText returns the value of a Jira field as text, or "" if it is empty.
+func fieldText(raw json.RawMessage) string {
+	var v interface{}
+	if len(raw) == 0 || json.Unmarshal(raw, &v) != nil {
+		return ""
+	}
+	return valueText(v)
+}
+
+func valueText(v interface{}) string {
+	switch v := v.(type) {
+	case string:
+		return strings.TrimSpace(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	case map[string]interface{}:
+		for _, key := range []string{"value", "displayName", "name", "key"} {
+			if s, ok := v[key].(string); ok {
+				return s
+			}
+		}
+	case []interface{}:
+		var parts []string
+		for _, elem := range v {
+			if s := valueText(elem); s != "" {
+				parts = append(parts, s)
+			}
+		}
+		return strings.Join(parts, ", ")
+	}
+	return ""
+}
+
+// CustomFieldsToJira returns the mapped custom fields of an issue keyed by
+// Jira field ID, in Jira's representation: int fields as numbers, enum fields
+// as select options and empty values as null. User fields are not pushed,
+// since Jira identifies users by account.
+func CustomFieldsToJira(issue *types.Issue, config *MappingConfig) map[string]interface{} {
+	if len(config.FieldMap) == 0 {
+		return nil
+	}
+	result := map[string]interface{}{}
+	for name, id := range config.FieldMap {
+		value := issue.CustomFields[name]
+		switch config.FieldTypes[name] {
+		case "user":
+			continue
+		case "int":
+			if n, err := strconv.ParseInt(value, 10, 64); err == nil {
+				result[id] = n
+				continue
+			}
+		case "enum":
+			if value != "" {
+				result[id] = map[string]string{"value": value}
+				continue
+			}
+		}
+		if value == "" {
+			result[id] = nil
+		} else {
+			result[id] = value
+		}
+	}
+	return result
+}
+
+// pushedFieldsMatch reports whether two issues agree on the custom fields
+// that are pushed to Jira.
+func pushedFieldsMatch(a, b *types.Issue, config *MappingConfig) bool {
+	for name := range config.FieldMap {
+		if config.FieldTypes[name] != "user" && a.CustomFields[name] != b.CustomFields[name] {
+			return false
+		}
+	}
+	return true
+}
+
+// userName returns the name Beads uses for a Jira user.
+func userName(u *User) string {
+	if u.DisplayName != "" {
+		return u.DisplayName
+	}
+	return u.Name
+}
+
+// PreserveLocalFields copies onto a converted Jira issue the local values
+// that Jira cannot distinguish, so a pull does not clobber them:
+//   - status, priority and issue type, when the Jira value is exactly what
+//     the local value maps to on push (e.g. chore and task both map to "Task")
+//   - design, acceptance criteria and notes, while the Jira description is
+//     unchanged from what BuildJiraDescription produced for them
+//   - the assignee, when the Jira issue is unassigned (assignees are not pushed)
+//   - custom fields not mapped to Jira, and mapped user fields while the Jira
+//     field is empty (they are not pushed either)
+func PreserveLocalFields(remote *types.Issue, ji *Issue, local *types.Issue, config *MappingConfig) {
+	if local == nil {
+		return
+	}
+	fields := &ji.Fields
+	if remote.Description == BuildJiraDescription(local) {
+		remote.Description = local.Description
+		remote.AcceptanceCriteria = local.AcceptanceCriteria
+		remote.Design = local.Design
+		remote.Notes = local.Notes
+	}
+	if fields.Status != nil && local.Status != types.StatusTombstone &&
+		strings.EqualFold(fields.Status.Name, StatusToJira(local.Status, config)) {
+		remote.Status = local.Status
+		if local.Status != types.StatusClosed {
+			remote.ClosedAt = nil
+		}
+	}
+	if fields.Priority != nil && strings.EqualFold(fields.Priority.Name, PriorityToJira(local.Priority, config)) {
+		remote.Priority = local.Priority
+	}
+	if fields.IssueType != nil && strings.EqualFold(fields.IssueType.Name, TypeToJira(local.IssueType, config)) {
+		remote.IssueType = local.IssueType
+	}
+	if remote.Assignee == "" {
+		remote.Assignee = local.Assignee
+	}
+	for name, value := range local.CustomFields {
+		if _, mapped := config.FieldMap[name]; mapped && config.FieldTypes[name] != "user" {
+			continue
+		}
+		if _, set := remote.CustomFields[name]; set {
+			continue
+		}
+		if remote.CustomFields == nil {
+			remote.CustomFields = map[string]string{}
+		}
+		remote.CustomFields[name] = value
+	}
+}
+
+// IssueToJiraRequest builds the create/update payload for a Beads issue.
+// Jira labels cannot contain whitespace, so such labels are not sent.
+func IssueToJiraRequest(issue *types.Issue, config *MappingConfig) *IssueRequest {
+	return &IssueRequest{
+		Summary:     issue.Title,
+		Description: BuildJiraDescription(issue),
+		IssueType:   TypeToJira(issue.IssueType, config),
+		Priority:    PriorityToJira(issue.Priority, config),
+		Labels:      jiraLabels(issue.Labels),
+		Fields:      CustomFieldsToJira(issue, config),
+	}
+}
+
+// jiraLabels returns the sorted labels that can be represented in Jira.
+func jiraLabels(labels []string) []string {
+	result := []string{}
+	for _, label := range labels {
+		if label == "" || strings.ContainsAny(label, " \t\n") {
+			continue
+		}
+		result = append(result, label)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// NormalizeIssueForJiraHash returns a copy of the issue containing only the
+// fields Jira can represent, using Jira's description formatting, so that
+// content hashes of local and remote versions can be compared without false
+// conflicts.
+func NormalizeIssueForJiraHash(issue *types.Issue) *types.Issue {
+	return &types.Issue{
+		Title:       issue.Title,
+		Description: BuildJiraDescription(issue),
+		Status:      issue.Status,
+		Priority:    issue.Priority,
+		IssueType:   issue.IssueType,
+		Assignee:    issue.Assignee,
+	}
+}
+
+// IssueMatchesLocal reports whether a Jira issue carries the same content as
+// the local issue, comparing the fields both sides can represent (including
+// labels and pushed custom fields, which are not part of the content hash).
+func IssueMatchesLocal(local *types.Issue, ji *Issue, config *MappingConfig) bool {
+	remote := IssueToBeads(ji, "", config)
+	PreserveLocalFields(remote, ji, local, config)
+
+	if NormalizeIssueForJiraHash(local).ComputeContentHash() != NormalizeIssueForJiraHash(remote).ComputeContentHash() {
+		return false
+	}
+	if !pushedFieldsMatch(local, remote, config) {
+		return false
+	}
+	want := jiraLabels(local.Labels)
+	have := jiraLabels(remote.Labels)
+	if len(want) != len(have) {
+		return false
+	}
+	for i := range want {
+		if want[i] != have[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,380 @@
+package jira
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+const testSiteURL = "https://company.atlassian.net"
+
+func sampleIssue() *Issue {
+	return &Issue{
+		ID:  "10001",
+		Key: "PROJ-12",
+		Fields: IssueFields{
+			Summary:     "Login times out",
+			Description: json.RawMessage(`"Steps to reproduce"`),
+			Status:      &Status{Name: "In Progress", StatusCategory: &StatusCategory{Key: "indeterminate"}},
+			Priority:    &Priority{Name: "High"},
+			IssueType:   &IssueType{Name: "Bug"},
+			Assignee:    &User{AccountID: "abc", DisplayName: "Ada Lovelace"},
+			Labels:      []string{"backend", "auth"},
+			Created:     "2025-01-01T00:00:00.000+0000",
+			Updated:     "2025-01-02T00:00:00.000+0000",
+		},
+	}
+}
+
+func TestIssueToBeads(t *testing.T) {
+	issue := IssueToBeads(sampleIssue(), testSiteURL, DefaultMappingConfig())
+
+	if issue.Title != "Login times out" || issue.Description != "Steps to reproduce" {
+		t.Errorf("unexpected content: %q / %q", issue.Title, issue.Description)
+	}
+	if issue.Status != types.StatusInProgress || issue.Priority != 1 || issue.IssueType != types.TypeBug {
+		t.Errorf("status/priority/type = %s/%d/%s", issue.Status, issue.Priority, issue.IssueType)
+	}
+	if issue.Assignee != "Ada Lovelace" {
+		t.Errorf("Assignee = %q", issue.Assignee)
+	}
+	if !reflect.DeepEqual(issue.Labels, []string{"backend", "auth"}) {
+		t.Errorf("Labels = %v", issue.Labels)
+	}
+	if !issue.UpdatedAt.Equal(time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("UpdatedAt = %v", issue.UpdatedAt)
+	}
+	if issue.ExternalRef == nil || *issue.ExternalRef != testSiteURL+"/browse/PROJ-12" {
+		t.Errorf("ExternalRef = %v", issue.ExternalRef)
+	}
+	if issue.ClosedAt != nil {
+		t.Error("open issue should have no ClosedAt")
+	}
+}
+
+func TestIssueToBeadsClosedAndDefaults(t *testing.T) {
+	ji := &Issue{Key: "PROJ-5", Fields: IssueFields{
+		Summary:        "Old",
+		Status:         &Status{Name: "Shipped", StatusCategory: &StatusCategory{Key: "done"}},
+		Updated:        "2025-02-02T00:00:00.000+0000",
+		ResolutionDate: "2025-02-01T00:00:00.000+0000",
+	}}
+
+	issue := IssueToBeads(ji, testSiteURL, DefaultMappingConfig())
+	if issue.Status != types.StatusClosed {
+		t.Errorf("Status = %s, want closed from status category", issue.Status)
+	}
+	if issue.ClosedAt == nil || !issue.ClosedAt.Equal(time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("ClosedAt = %v, want resolution date", issue.ClosedAt)
+	}
+	if issue.Priority != DefaultPriority || issue.IssueType != types.TypeTask {
+		t.Errorf("defaults not applied: %d %s", issue.Priority, issue.IssueType)
+	}
+}
+
+func TestStatusToBeads(t *testing.T) {
+	config := DefaultMappingConfig()
+	tests := []struct {
+		status *Status
+		want   types.Status
+	}{
+		{nil, types.StatusOpen},
+		{&Status{Name: "To Do"}, types.StatusOpen},
+		{&Status{Name: "ON HOLD"}, types.StatusBlocked},
+		{&Status{Name: "Won't Do"}, types.StatusClosed},
+		{&Status{Name: "QA", StatusCategory: &StatusCategory{Key: "indeterminate"}}, types.StatusInProgress},
+		{&Status{Name: "Triage", StatusCategory: &StatusCategory{Key: "new"}}, types.StatusOpen},
+	}
+	for _, tt := range tests {
+		if got := StatusToBeads(tt.status, config); got != tt.want {
+			t.Errorf("StatusToBeads(%+v) = %s, want %s", tt.status, got, tt.want)
+		}
+	}
+}
+
+func TestReverseMappings(t *testing.T) {
+	config := DefaultMappingConfig()
+	if got := StatusToJira(types.StatusClosed, config); got != "Done" {
+		t.Errorf("StatusToJira(closed) = %q", got)
+	}
+	if got := StatusToJira(types.StatusHooked, config); got != "To Do" {
+		t.Errorf("StatusToJira(hooked) = %q, want open fallback", got)
+	}
+	if got := PriorityToJira(0, config); got != "Highest" {
+		t.Errorf("PriorityToJira(0) = %q", got)
+	}
+	if got := PriorityToJira(9, config); got != "Medium" {
+		t.Errorf("PriorityToJira(9) = %q, want default", got)
+	}
+	if got := TypeToJira(types.TypeFeature, config); got != "Story" {
+		t.Errorf("TypeToJira(feature) = %q", got)
+	}
+}
+
+func TestIssueToJiraRequest(t *testing.T) {
+	issue := &types.Issue{
+		Title:              "Add SSO",
+		Description:        "Support SAML",
+		AcceptanceCriteria: "Users can log in",
+		Priority:           1,
+		IssueType:          types.TypeFeature,
+		Labels:             []string{"sso", "needs design", "auth"},
+	}
+
+	req := IssueToJiraRequest(issue, DefaultMappingConfig())
+	want := &IssueRequest{
+		Summary:     "Add SSO",
+		Description: "Support SAML\n\n## Acceptance Criteria\nUsers can log in",
+		IssueType:   "Story",
+		Priority:    "High",
+		Labels:      []string{"auth", "sso"},
+	}
+	if !reflect.DeepEqual(req, want) {
+		t.Errorf("IssueToJiraRequest = %+v, want %+v", req, want)
+	}
+}
+
+func TestPreserveLocalFields(t *testing.T) {
+	config := DefaultMappingConfig()
+	local := &types.Issue{
+		Title:       "x",
+		Description: "Steps",
+		Notes:       "Some notes",
+		Status:      types.StatusDeferred,
+		Priority:    1,
+		IssueType:   types.TypeChore,
+		Assignee:    "ada",
+	}
+
+	// Jira issue that holds exactly what we pushed for the local issue
+	ji := &Issue{Key: "PROJ-1", Fields: IssueFields{
+		Summary:     "x",
+		Description: json.RawMessage(`"` + "Steps\\n\\n## Notes\\nSome notes" + `"`),
+		Status:      &Status{Name: "To Do"},
+		Priority:    &Priority{Name: "High"},
+		IssueType:   &IssueType{Name: "Task"},
+	}}
+	remote := IssueToBeads(ji, testSiteURL, config)
+	PreserveLocalFields(remote, ji, local, config)
+
+	if remote.Status != types.StatusDeferred || remote.IssueType != types.TypeChore {
+		t.Errorf("lossy status/type should be kept: %s %s", remote.Status, remote.IssueType)
+	}
+	if remote.Description != "Steps" || remote.Notes != "Some notes" {
+		t.Errorf("unchanged description should keep local sections: %q / %q", remote.Description, remote.Notes)
+	}
+	if remote.Assignee != "ada" {
+		t.Errorf("Assignee = %q, want local kept while unassigned in Jira", remote.Assignee)
+	}
+	if !IssueMatchesLocal(local, ji, config) {
+		t.Error("expected pushed issue to match local")
+	}
+
+	// Changes made in Jira win
+	ji.Fields.Status = &Status{Name: "Done"}
+	ji.Fields.IssueType = &IssueType{Name: "Bug"}
+	ji.Fields.Description = json.RawMessage(`"Rewritten in Jira"`)
+	remote = IssueToBeads(ji, testSiteURL, config)
+	PreserveLocalFields(remote, ji, local, config)
+
+	if remote.Status != types.StatusClosed || remote.IssueType != types.TypeBug {
+		t.Errorf("Jira status/type should win: %s %s", remote.Status, remote.IssueType)
+	}
+	if remote.Description != "Rewritten in Jira" || remote.Notes != "" {
+		t.Errorf("changed description should replace local sections: %q / %q", remote.Description, remote.Notes)
+	}
+	if IssueMatchesLocal(local, ji, config) {
+		t.Error("expected changed issue not to match local")
+	}
+}
+
+func TestIssueMatchesLocalLabels(t *testing.T) {
+	config := DefaultMappingConfig()
+	ji := sampleIssue()
+	local := IssueToBeads(ji, testSiteURL, config)
+	local.ID = "bd-abc"
+	local.CreatedBy = "someone"
+
+	if !IssueMatchesLocal(local, ji, config) {
+		t.Error("expected converted issue to match its source")
+	}
+
+	local.Labels = append(local.Labels, "needs triage") // not representable in Jira
+	if !IssueMatchesLocal(local, ji, config) {
+		t.Error("labels with whitespace should be ignored")
+	}
+
+	local.Labels = append(local.Labels, "frontend")
+	if IssueMatchesLocal(local, ji, config) {
+		t.Error("expected label difference to be detected")
+	}
+}
+
+func TestCustomFields(t *testing.T) {
+	config := DefaultMappingConfig()
+	config.FieldMap = map[string]string{
+		"points":   "customfield_10016",
+		"severity": "customfield_10020",
+		"review":   "customfield_10030",
+		"reviewer": "customfield_10040",
+	}
+	config.FieldTypes = map[string]string{"points": "int", "severity": "enum", "review": "date", "reviewer": "user"}
+
+	var ji Issue
+	if err := json.Unmarshal([]byte(`{"key": "PROJ-7", "fields": {
+		"summary": "Crash",
+		"customfield_10016": 5.0,
+		"customfield_10020": {"id": "1", "value": "High"},
+		"customfield_10030": "2025-03-04T10:00:00.000+0000",
+		"customfield_10040": {"accountId": "x", "displayName": "Grace Hopper"},
+		"customfield_99999": "unmapped"
+	}}`), &ji); err != nil {
+		t.Fatal(err)
+	}
+	if ji.Fields.Summary != "Crash" {
+		t.Errorf("Summary = %q", ji.Fields.Summary)
+	}
+	if _, ok := ji.Fields.Other["summary"]; ok {
+		t.Error("known fields should not be kept in Other")
+	}
+
+	issue := IssueToBeads(&ji, testSiteURL, config)
+	want := map[string]string{"points": "5", "severity": "High", "review": "2025-03-04", "reviewer": "Grace Hopper"}
+	if !reflect.DeepEqual(issue.CustomFields, want) {
+		t.Errorf("CustomFields = %v, want %v", issue.CustomFields, want)
+	}
+
+	local := &types.Issue{CustomFields: map[string]string{"points": "8", "customer": "Acme"}}
+	got := CustomFieldsToJira(local, config)
+	wantJira := map[string]interface{}{
+		"customfield_10016": int64(8),
+		"customfield_10020": nil,
+		"customfield_10030": nil,
+	}
+	if !reflect.DeepEqual(got, wantJira) {
+		t.Errorf("CustomFieldsToJira() = %v, want %v", got, wantJira)
+	}
+
+	remote := IssueToBeads(&ji, testSiteURL, config)
+	local.CustomFields["reviewer"] = "grace"
+	PreserveLocalFields(remote, &ji, local, config)
+	if remote.CustomFields["customer"] != "Acme" || remote.CustomFields["points"] != "5" || remote.CustomFields["reviewer"] != "Grace Hopper" {
+		t.Errorf("preserved CustomFields = %v", remote.CustomFields)
+	}
+}
+
+func TestIssueMatchesLocalCustomFields(t *testing.T) {
+	config := DefaultMappingConfig()
+	config.FieldMap = map[string]string{"points": "customfield_10016", "reviewer": "customfield_10040"}
+	config.FieldTypes = map[string]string{"points": "int", "reviewer": "user"}
+	ji := sampleIssue()
+	ji.Fields.Other = map[string]json.RawMessage{"customfield_10016": json.RawMessage(`3`)}
+
+	local := IssueToBeads(ji, testSiteURL, config)
+	local.CustomFields["reviewer"] = "grace" // Not pushed
+	local.CustomFields["customer"] = "Acme"  // Not mapped
+	if !IssueMatchesLocal(local, ji, config) {
+		t.Error("expected issue to match when pushed fields agree")
+	}
+
+	local.CustomFields["points"] = "5"
+	if IssueMatchesLocal(local, ji, config) {
+		t.Error("expected custom field difference to be detected")
+	}
+}
+
+type mockConfigLoader struct {
+	config map[string]string
+}
+
+func (m *mockConfigLoader) GetAllConfig() (map[string]string, error) {
+	return m.config, nil
+}
+
+func TestLoadMappingConfig(t *testing.T) {
+	loader := &mockConfigLoader{config: map[string]string{
+		"jira.status_map.in_qa":          "review",
+		"jira.priority_map.P1":           "0",
+		"jira.priority_map.bad":          "not-a-number",
+		"jira.type_map.Spike":            "task",
+		"jira.reverse_status_map.review": "In QA",
+		"jira.reverse_priority_map.0":    "Blocker",
+		"jira.reverse_priority_map.x":    "ignored",
+		"jira.reverse_type_map.chore":    "Chore",
+		"jira.field_map.points":          "customfield_10016",
+		"linear.priority_map.1":          "0",
+	}}
+
+	config := LoadMappingConfig(loader)
+	if got := StatusToBeads(&Status{Name: "In QA"}, config); got != types.StatusReview {
+		t.Errorf("custom status map not applied: %s", got)
+	}
+	if got := PriorityToBeads(&Priority{Name: "p1"}, config); got != 0 {
+		t.Errorf("custom priority map not applied: %d", got)
+	}
+	if _, ok := config.PriorityMap["bad"]; ok {
+		t.Error("invalid priority should be ignored")
+	}
+	if got := TypeToBeads(&IssueType{Name: "SPIKE"}, config); got != types.TypeTask {
+		t.Errorf("custom type map not applied: %s", got)
+	}
+	if StatusToJira(types.StatusReview, config) != "In QA" ||
+		PriorityToJira(0, config) != "Blocker" ||
+		TypeToJira(types.TypeChore, config) != "Chore" {
+		t.Error("reverse maps not applied")
+	}
+	if config.PriorityMap["high"] != 1 || config.ReversePriorityMap[1] != "High" {
+		t.Error("defaults should be kept")
+	}
+	if !reflect.DeepEqual(config.FieldIDs(), []string{"customfield_10016"}) {
+		t.Errorf("FieldIDs() = %v", config.FieldIDs())
+	}
+}
+
+func TestLoadMappingConfigNilLoader(t *testing.T) {
+	if !reflect.DeepEqual(LoadMappingConfig(nil), DefaultMappingConfig()) {
+		t.Error("nil loader should return defaults")
+	}
+}
+
+func TestADFRoundTrip(t *testing.T) {
+	text := "First paragraph\nsecond line\n\n## Notes\nMore"
+	raw, err := json.Marshal(TextToADF(text))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := RichTextToPlain(raw); got != text {
+		t.Errorf("round-trip = %q, want %q", got, text)
+	}
+
+	if TextToADF("  \n") != nil {
+		t.Error("empty text should produce nil")
+	}
+	if RichTextToPlain(json.RawMessage("null")) != "" || RichTextToPlain(nil) != "" {
+		t.Error("null description should be empty")
+	}
+}
+
+func TestRichTextToPlainADFNodes(t *testing.T) {
+	doc := `{"type": "doc", "version": 1, "content": [
+		{"type": "heading", "attrs": {"level": 2}, "content": [{"type": "text", "text": "Title"}]},
+		{"type": "bulletList", "content": [
+			{"type": "listItem", "content": [{"type": "paragraph", "content": [{"type": "text", "text": "one"}]}]},
+			{"type": "listItem", "content": [{"type": "paragraph", "content": [{"type": "text", "text": "two"}]}]}
+		]},
+		{"type": "paragraph", "content": [
+			{"type": "text", "text": "cc "},
+			{"type": "mention", "attrs": {"text": "@ada"}},
+			{"type": "text", "text": " see "},
+			{"type": "inlineCard", "attrs": {"url": "https://example.com"}}
+		]}
+	]}`
+
+	want := "## Title\n\n- one\n- two\ncc @ada see https://example.com"
+	if got := RichTextToPlain(json.RawMessage(doc)); got != want {
+		t.Errorf("RichTextToPlain = %q, want %q", got, want)
+	}
+}
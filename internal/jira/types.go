@@ -0,0 +1,186 @@
+// Package jira provides client and data types for the Jira REST API.
+//
+// This package handles all interactions with Jira Cloud and Jira Server/Data
+// Center, including searching, creating, updating and transitioning issues.
+// It provides bidirectional mapping between Jira's data model and Beads'
+// internal types.
+package jira
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// API configuration constants.
+const (
+	// CloudAPIVersion is the REST API version used for Jira Cloud sites.
+	// Version 3 represents descriptions as Atlassian Document Format (ADF).
+	CloudAPIVersion = "3"
+
+	// ServerAPIVersion is the REST API version used for Jira Server and
+	// Data Center, which represent descriptions as plain strings.
+	ServerAPIVersion = "2"
+
+	// DefaultTimeout is the default HTTP request timeout.
+	DefaultTimeout = 30 * time.Second
+
+	// MaxRetries is the maximum number of retries for rate-limited requests.
+	MaxRetries = 3
+
+	// RetryDelay is the base delay between retries (exponential backoff).
+	RetryDelay = time.Second
+
+	// MaxPageSize is the maximum number of issues to fetch per page.
+	MaxPageSize = 100
+)
+
+// searchFields lists the issue fields requested from the search endpoints.
+const searchFields = "summary,description,status,priority,issuetype,assignee,reporter,labels,created,updated,resolutiondate"
+
+// Client provides methods to interact with the Jira REST API.
+type Client struct {
+	URL        string // Jira site URL, used for browse links (e.g., https://company.atlassian.net)
+	Endpoint   string // REST base URL (defaults to URL; override for proxies or tests)
+	Username   string // Email (Cloud) or username (Server); empty uses bearer token auth
+	APIToken   string
+	Project    string // Project key (e.g., "PROJ")
+	APIVersion string // REST API version: "2" or "3"
+	HTTPClient *http.Client
+	Fields     []string // Extra field IDs to fetch, e.g. mapped custom fields
+}
+
+// Issue represents an issue from the Jira API.
+type Issue struct {
+	ID     string      `json:"id"`
+	Key    string      `json:"key"` // e.g., "PROJ-123"
+	Self   string      `json:"self"`
+	Fields IssueFields `json:"fields"`
+}
+
+// IssueFields holds the fields of a Jira issue that Beads syncs.
+type IssueFields struct {
+	Summary        string          `json:"summary"`
+	Description    json.RawMessage `json:"description,omitempty"` // string (v2) or ADF document (v3)
+	Status         *Status         `json:"status,omitempty"`
+	Priority       *Priority       `json:"priority,omitempty"`
+	IssueType      *IssueType      `json:"issuetype,omitempty"`
+	Assignee       *User           `json:"assignee,omitempty"`
+	Reporter       *User           `json:"reporter,omitempty"`
+	Labels         []string        `json:"labels,omitempty"`
+	Created        string          `json:"created,omitempty"`
+	Updated        string          `json:"updated,omitempty"`
+	ResolutionDate string          `json:"resolutiondate,omitempty"`
+
+	// Other holds the raw values of any other fields returned, keyed by
+	// field ID (e.g. "customfield_10016").
+	Other map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON decodes the known fields and keeps the rest in Other.
+func (f *IssueFields) UnmarshalJSON(data []byte) error {
+	type plain IssueFields
+	if err := json.Unmarshal(data, (*plain)(f)); err != nil {
+		return err
+	}
+	var all map[string]json.RawMessage
+	if err := json.Unmarshal(data, &all); err != nil {
+		return err
+	}
+	for _, known := range strings.Split(searchFields, ",") {
+		delete(all, known)
+	}
+	f.Other = all
+	return nil
+}
+
+// Status represents a workflow status in Jira.
+type Status struct {
+	ID             string          `json:"id,omitempty"`
+	Name           string          `json:"name"`
+	StatusCategory *StatusCategory `json:"statusCategory,omitempty"`
+}
+
+// StatusCategory is the category a Jira status belongs to.
+// Key is one of "new", "indeterminate" or "done".
+type StatusCategory struct {
+	Key  string `json:"key"`
+	Name string `json:"name,omitempty"`
+}
+
+// Priority represents a Jira priority.
+type Priority struct {
+	ID   string `json:"id,omitempty"`
+	Name string `json:"name"`
+}
+
+// IssueType represents a Jira issue type.
+type IssueType struct {
+	ID   string `json:"id,omitempty"`
+	Name string `json:"name"`
+}
+
+// User represents a Jira user.
+type User struct {
+	AccountID    string `json:"accountId,omitempty"` // Jira Cloud
+	Name         string `json:"name,omitempty"`      // Jira Server
+	DisplayName  string `json:"displayName,omitempty"`
+	EmailAddress string `json:"emailAddress,omitempty"`
+}
+
+// SearchResponse represents a page of issues from a search endpoint.
+// The v2 endpoint paginates with startAt/total; the v3 /search/jql endpoint
+// uses nextPageToken/isLast.
+type SearchResponse struct {
+	Issues        []Issue `json:"issues"`
+	StartAt       int     `json:"startAt"`
+	MaxResults    int     `json:"maxResults"`
+	Total         int     `json:"total"`
+	NextPageToken string  `json:"nextPageToken,omitempty"`
+	IsLast        bool    `json:"isLast,omitempty"`
+}
+
+// Transition represents a workflow transition available on an issue.
+type Transition struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	To   Status `json:"to"`
+}
+
+// TransitionsResponse represents the response from the transitions endpoint.
+type TransitionsResponse struct {
+	Transitions []Transition `json:"transitions"`
+}
+
+// CreateIssueResponse represents the response from creating an issue.
+type CreateIssueResponse struct {
+	ID   string `json:"id"`
+	Key  string `json:"key"`
+	Self string `json:"self"`
+}
+
+// IssueRequest is the field payload for creating or updating an issue.
+// Status is not a settable field in Jira; it changes through transitions.
+type IssueRequest struct {
+	Summary     string
+	Description string
+	IssueType   string
+	Priority    string
+	Labels      []string
+	Fields      map[string]interface{} // Other fields by ID, in Jira's representation (nil clears)
+}
+
+// APIError is returned when the Jira API responds with a non-2xx status.
+type APIError struct {
+	StatusCode int
+	Messages   []string
+}
+
+func (e *APIError) Error() string {
+	if len(e.Messages) == 0 {
+		return fmt.Sprintf("Jira API error (status %d)", e.StatusCode)
+	}
+	return fmt.Sprintf("Jira API error: %s (status %d)", joinMessages(e.Messages), e.StatusCode)
+}
@@ -0,0 +1,188 @@
+package linear
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/steveyegge/beads/internal/tracker"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// Adapter connects a Linear team to the tracker sync engine.
+// Remote issues are identified by their Linear identifier (e.g., "TEAM-123").
+type Adapter struct {
+	client *Client
+	config *MappingConfig
+	states *StateCache // Built on first push
+}
+
+var (
+	_ tracker.Adapter           = (*Adapter)(nil)
+	_ tracker.DependencyAdapter = (*Adapter)(nil)
+)
+
+// NewAdapter creates a sync adapter for the client's team.
+func NewAdapter(client *Client, config *MappingConfig) *Adapter {
+	if config == nil {
+		config = DefaultMappingConfig()
+	}
+	return &Adapter{client: client, config: config}
+}
+
+// Name returns "linear".
+func (a *Adapter) Name() string { return "linear" }
+
+// DisplayName returns "Linear".
+func (a *Adapter) DisplayName() string { return "Linear" }
+
+// remoteIssue wraps a Linear issue for the sync engine.
+func remoteIssue(li *Issue) *tracker.RemoteIssue {
+	updated, _ := time.Parse(time.RFC3339, li.UpdatedAt)
+	return &tracker.RemoteIssue{ID: li.Identifier, UpdatedAt: updated, Data: li}
+}
+
+// linearIssue returns the Linear issue inside a remote issue.
+func linearIssue(remote *tracker.RemoteIssue) *Issue {
+	return remote.Data.(*Issue)
+}
+
+// stateCache returns the team's workflow states, fetching them once.
+func (a *Adapter) stateCache(ctx context.Context) (*StateCache, error) {
+	if a.states == nil {
+		cache, err := BuildStateCache(ctx, a.client)
+		if err != nil {
+			return nil, err
+		}
+		a.states = cache
+	}
+	return a.states, nil
+}
+
+// FetchIssues fetches team issues, optionally only those updated since the
+// given time.
+func (a *Adapter) FetchIssues(ctx context.Context, state string, since time.Time) ([]tracker.RemoteIssue, error) {
+	var issues []Issue
+	var err error
+	if since.IsZero() {
+		issues, err = a.client.FetchIssues(ctx, state)
+	} else {
+		issues, err = a.client.FetchIssuesSince(ctx, state, since)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	remote := make([]tracker.RemoteIssue, len(issues))
+	for i := range issues {
+		remote[i] = *remoteIssue(&issues[i])
+	}
+	return remote, nil
+}
+
+// FetchIssue fetches a single issue by identifier.
+func (a *Adapter) FetchIssue(ctx context.Context, identifier string) (*tracker.RemoteIssue, error) {
+	li, err := a.client.FetchIssueByIdentifier(ctx, identifier)
+	if err != nil || li == nil {
+		return nil, err
+	}
+	return remoteIssue(li), nil
+}
+
+// CreateIssue creates a Linear issue in the workflow state matching the
+// local status.
+func (a *Adapter) CreateIssue(ctx context.Context, issue *types.Issue) (*tracker.RemoteIssue, error) {
+	states, err := a.stateCache(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	li, err := a.client.CreateIssue(ctx, issue.Title, BuildLinearDescription(issue),
+		PriorityToLinear(issue.Priority, a.config), states.FindStateForBeadsStatus(issue.Status), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Mapped custom fields are set in a follow-up update
+	fields := map[string]interface{}{}
+	for field, value := range CustomFieldsToLinear(issue, a.config) {
+		if value != nil {
+			fields[field] = value
+		}
+	}
+	if len(fields) > 0 {
+		if _, err := a.client.UpdateIssue(ctx, li.ID, fields); err != nil {
+			return remoteIssue(li), fmt.Errorf("issue created but custom fields not set: %w", err)
+		}
+	}
+	return remoteIssue(li), nil
+}
+
+// UpdateIssue overwrites a Linear issue's title, description, priority,
+// state and mapped custom fields with the local version.
+func (a *Adapter) UpdateIssue(ctx context.Context, remote *tracker.RemoteIssue, issue *types.Issue) error {
+	states, err := a.stateCache(ctx)
+	if err != nil {
+		return err
+	}
+
+	updates := map[string]interface{}{
+		"title":       issue.Title,
+		"description": BuildLinearDescription(issue),
+	}
+	if priority := PriorityToLinear(issue.Priority, a.config); priority > 0 {
+		updates["priority"] = priority
+	}
+	if stateID := states.FindStateForBeadsStatus(issue.Status); stateID != "" {
+		updates["stateId"] = stateID
+	}
+	for field, value := range CustomFieldsToLinear(issue, a.config) {
+		updates[field] = value
+	}
+
+	_, err = a.client.UpdateIssue(ctx, linearIssue(remote).ID, updates)
+	return err
+}
+
+// ToLocal converts a Linear issue, keeping local custom fields that are not
+// mapped to Linear fields.
+func (a *Adapter) ToLocal(remote *tracker.RemoteIssue, local *types.Issue) *types.Issue {
+	issue := IssueToBeads(linearIssue(remote), a.config).Issue.(*types.Issue)
+	PreserveLocalFields(issue, local, a.config)
+	return issue
+}
+
+// Matches compares content hashes of the local issue and the Linear issue.
+func (a *Adapter) Matches(local *types.Issue, remote *tracker.RemoteIssue) bool {
+	return NormalizeIssueForLinearHash(local).ComputeContentHash() ==
+		a.ToLocal(remote, local).ComputeContentHash()
+}
+
+// IsExternalRef reports whether ref is a Linear issue URL.
+func (a *Adapter) IsExternalRef(ref string) bool {
+	return IsLinearExternalRef(ref)
+}
+
+// ExtractID returns the issue identifier from a Linear URL.
+func (a *Adapter) ExtractID(ref string) string {
+	return ExtractLinearIdentifier(ref)
+}
+
+// ExternalRef returns the canonical URL of a Linear issue.
+func (a *Adapter) ExternalRef(remote *tracker.RemoteIssue) string {
+	ref := linearIssue(remote).URL
+	if canonical, ok := CanonicalizeLinearExternalRef(ref); ok {
+		return canonical
+	}
+	return ref
+}
+
+// Dependencies maps the issue's parent and relations to beads dependencies.
+func (a *Adapter) Dependencies(remote *tracker.RemoteIssue) []tracker.Dependency {
+	conversion := IssueToBeads(linearIssue(remote), a.config)
+	deps := make([]tracker.Dependency, len(conversion.Dependencies))
+	for i, dep := range conversion.Dependencies {
+		deps[i] = tracker.Dependency{FromID: dep.FromLinearID, ToID: dep.ToLinearID, Type: dep.Type}
+	}
+	return deps
+}
@@ -31,6 +31,8 @@ const IssuesQuery = `
 				description
 				url
 				priority
+				estimate
+				dueDate
 				state {
 					id
 					name
@@ -521,6 +523,8 @@ func (c *Client) FetchIssueByIdentifier(ctx context.Context, identifier string)
 					description
 					url
 					priority
+					estimate
+					dueDate
 					state {
 						id
 						name
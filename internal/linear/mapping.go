@@ -2,6 +2,7 @@ package linear
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
@@ -137,8 +138,18 @@ type MappingConfig struct {
 	// RelationMap maps Linear relation types to Beads dependency types.
 	// Key is Linear relation type, value is Beads dependency type.
 	RelationMap map[string]string
+
+	// FieldMap maps Beads custom field names to Linear issue fields
+	// ("estimate" or "dueDate").
+	FieldMap map[string]string
 }
 
+// Linear issue fields that custom fields can be mapped to.
+const (
+	FieldEstimate = "estimate"
+	FieldDueDate  = "dueDate"
+)
+
 // DefaultMappingConfig returns sensible default mappings.
 func DefaultMappingConfig() *MappingConfig {
 	return &MappingConfig{
@@ -177,6 +188,7 @@ func DefaultMappingConfig() *MappingConfig {
 			"duplicate": "duplicates",
 			"related":   "related",
 		},
+		FieldMap: map[string]string{},
 	}
 }
 
@@ -194,6 +206,7 @@ type ConfigLoader interface {
 //	linear.state_map.started = in_progress
 //	linear.label_type_map.bug = bug
 //	linear.relation_map.blocks = blocks
+//	linear.field_map.points = estimate  (Beads custom field -> Linear field)
 func LoadMappingConfig(loader ConfigLoader) *MappingConfig {
 	config := DefaultMappingConfig()
 
@@ -233,6 +246,13 @@ func LoadMappingConfig(loader ConfigLoader) *MappingConfig {
 			relationType := strings.TrimPrefix(key, "linear.relation_map.")
 			config.RelationMap[relationType] = value
 		}
+
+		// Parse custom field mappings: linear.field_map.<beads_field>
+		if strings.HasPrefix(key, "linear.field_map.") {
+			if field := strings.TrimSpace(value); field == FieldEstimate || field == FieldDueDate {
+				config.FieldMap[strings.TrimPrefix(key, "linear.field_map.")] = field
+			}
+		}
 	}
 
 	return config
@@ -433,6 +453,8 @@ func IssueToBeads(li *Issue, config *MappingConfig) *IssueConversion {
 		}
 	}
 
+	issue.CustomFields = CustomFieldsToBeads(li, config)
+
 	externalRef := li.URL
 	if canonical, ok := CanonicalizeLinearExternalRef(externalRef); ok {
 		externalRef = canonical
@@ -491,6 +513,67 @@ func IssueToBeads(li *Issue, config *MappingConfig) *IssueConversion {
 	}
 }
 
+// CustomFieldsToBeads returns the values of the Linear fields mapped to Beads
+// custom fields, keyed by field name. Returns nil if no fields are mapped.
+func CustomFieldsToBeads(li *Issue, config *MappingConfig) map[string]string {
+	if len(config.FieldMap) == 0 {
+		return nil
+	}
+	result := map[string]string{}
+	for name, field := range config.FieldMap {
+		switch {
+		case field == FieldEstimate && li.Estimate != nil:
+			result[name] = strconv.FormatFloat(*li.Estimate, 'f', -1, 64)
+		case field == FieldDueDate && li.DueDate != "":
+			result[name] = li.DueDate
+		}
+	}
+	return result
+}
+
+// CustomFieldsToLinear returns the mapped custom fields of an issue as Linear
+// issue input fields. Empty values are sent as null to clear the field.
+func CustomFieldsToLinear(issue *types.Issue, config *MappingConfig) map[string]interface{} {
+	if len(config.FieldMap) == 0 {
+		return nil
+	}
+	result := map[string]interface{}{}
+	for name, field := range config.FieldMap {
+		value := issue.CustomFields[name]
+		if value == "" {
+			result[field] = nil
+			continue
+		}
+		if field == FieldEstimate {
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				continue // Linear estimates are whole points
+			}
+			result[field] = n
+			continue
+		}
+		result[field] = value
+	}
+	return result
+}
+
+// PreserveLocalFields copies onto a converted Linear issue the local custom
+// fields that are not mapped to Linear fields, so a pull does not clobber them.
+func PreserveLocalFields(remote, local *types.Issue, config *MappingConfig) {
+	if local == nil {
+		return
+	}
+	for name, value := range local.CustomFields {
+		if _, mapped := config.FieldMap[name]; mapped {
+			continue
+		}
+		if remote.CustomFields == nil {
+			remote.CustomFields = map[string]string{}
+		}
+		remote.CustomFields[name] = value
+	}
+}
+
 // BuildLinearToLocalUpdates creates an updates map from a Linear issue
 // to apply to a local Beads issue. This is used when Linear wins a conflict.
 func BuildLinearToLocalUpdates(li *Issue, config *MappingConfig) map[string]interface{} {
@@ -519,6 +519,8 @@ func TestLoadMappingConfig(t *testing.T) {
 			"linear.state_map.custom":     "in_progress",
 			"linear.label_type_map.story": "feature",
 			"linear.relation_map.parent":  "parent-child",
+			"linear.field_map.points":     "estimate",
+			"linear.field_map.sprint":     "cycle",
 		},
 	}
 
@@ -544,12 +546,43 @@ func TestLoadMappingConfig(t *testing.T) {
 		t.Errorf("RelationMap[parent] = %s, want parent-child", config.RelationMap["parent"])
 	}
 
+	// Check custom field mapping; unsupported Linear fields are ignored
+	if config.FieldMap["points"] != FieldEstimate {
+		t.Errorf("FieldMap[points] = %s, want estimate", config.FieldMap["points"])
+	}
+	if _, ok := config.FieldMap["sprint"]; ok {
+		t.Error("FieldMap[sprint] should be ignored")
+	}
+
 	// Check that defaults are preserved
 	if config.StateMap["started"] != "in_progress" {
 		t.Errorf("StateMap[started] = %s, want in_progress (default preserved)", config.StateMap["started"])
 	}
 }
 
+func TestCustomFields(t *testing.T) {
+	config := DefaultMappingConfig()
+	config.FieldMap = map[string]string{"points": FieldEstimate, "due": FieldDueDate}
+
+	estimate := 3.0
+	li := &Issue{Identifier: "TEAM-1", Estimate: &estimate}
+	issue := IssueToBeads(li, config).Issue.(*types.Issue)
+	if len(issue.CustomFields) != 1 || issue.CustomFields["points"] != "3" {
+		t.Errorf("CustomFields = %v, want points=3", issue.CustomFields)
+	}
+
+	local := &types.Issue{CustomFields: map[string]string{"due": "2025-06-01", "customer": "Acme"}}
+	fields := CustomFieldsToLinear(local, config)
+	if fields[FieldDueDate] != "2025-06-01" || fields[FieldEstimate] != nil || len(fields) != 2 {
+		t.Errorf("CustomFieldsToLinear() = %v", fields)
+	}
+
+	PreserveLocalFields(issue, local, config)
+	if issue.CustomFields["customer"] != "Acme" || issue.CustomFields["due"] != "" {
+		t.Errorf("preserved CustomFields = %v", issue.CustomFields)
+	}
+}
+
 func TestLoadMappingConfigNilLoader(t *testing.T) {
 	config := LoadMappingConfig(nil)
 
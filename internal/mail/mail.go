@@ -0,0 +1,358 @@
+// Package mail implements agent-to-agent messaging on top of beads.
+//
+// A message is an issue of type "message":
+//
+//   - Title holds the subject, Description the body and Sender the author.
+//   - Every recipient gets a "to:<address>" label. Assignee holds the first
+//     recipient, so messages also show up in assignee queries and in
+//     bd show --thread.
+//   - Read and archived state is kept per recipient with "read:<address>"
+//     and "archived:<address>" labels. A message is closed once every
+//     recipient archived it.
+//   - A reply is a new message with a replies-to dependency on the message
+//     it answers.
+//
+// Messages are ephemeral by default, so they stay out of the JSONL export;
+// Keep makes one persistent.
+package mail
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// TypeMessage is the issue type of mail messages. It is a custom type, so
+// it has to be listed in types.custom before mail can be sent.
+const TypeMessage types.IssueType = "message"
+
+// Label prefixes for per-recipient state.
+const (
+	LabelTo       = "to:"
+	LabelRead     = "read:"
+	LabelArchived = "archived:"
+)
+
+// Message is a message to send.
+type Message struct {
+	From    string
+	To      []string
+	Subject string
+	Body    string
+	Keep    bool // Persist the message instead of keeping it ephemeral
+}
+
+// InboxOptions filters an inbox listing.
+type InboxOptions struct {
+	Unread   bool // Only messages the recipient hasn't read
+	Archived bool // Include messages the recipient archived
+}
+
+// Send creates msg and returns the stored message.
+func Send(ctx context.Context, store storage.Storage, msg Message, actor string) (*types.Issue, error) {
+	return send(ctx, store, msg, "", actor)
+}
+
+func send(ctx context.Context, store storage.Storage, msg Message, replyTo, actor string) (*types.Issue, error) {
+	to := normalizeAddresses(msg.To)
+	if len(to) == 0 {
+		return nil, fmt.Errorf("message needs at least one recipient")
+	}
+	if strings.TrimSpace(msg.Subject) == "" {
+		return nil, fmt.Errorf("message needs a subject")
+	}
+	if msg.From == "" {
+		return nil, fmt.Errorf("message needs a sender")
+	}
+	if err := requireMessageType(ctx, store); err != nil {
+		return nil, err
+	}
+
+	issue := &types.Issue{
+		Title:       strings.TrimSpace(msg.Subject),
+		Description: msg.Body,
+		Status:      types.StatusOpen,
+		Priority:    2,
+		IssueType:   TypeMessage,
+		Sender:      msg.From,
+		Assignee:    to[0],
+		CreatedBy:   actor,
+		Ephemeral:   !msg.Keep,
+	}
+	// A message without its recipients or its thread link is never stored
+	err := store.RunInTransaction(ctx, func(tx storage.Transaction) error {
+		if err := tx.CreateIssue(ctx, issue, actor); err != nil {
+			return fmt.Errorf("failed to create message: %w", err)
+		}
+		for _, addr := range to {
+			if err := tx.AddLabel(ctx, issue.ID, LabelTo+addr, actor); err != nil {
+				return fmt.Errorf("failed to address %s to %s: %w", issue.ID, addr, err)
+			}
+		}
+		if replyTo != "" {
+			if err := tx.AddDependency(ctx, &types.Dependency{
+				IssueID:     issue.ID,
+				DependsOnID: replyTo,
+				Type:        types.DepRepliesTo,
+			}, actor); err != nil {
+				return fmt.Errorf("failed to link %s to %s: %w", issue.ID, replyTo, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return Get(ctx, store, issue.ID)
+}
+
+// Get returns the message id with its labels.
+func Get(ctx context.Context, store storage.Storage, id string) (*types.Issue, error) {
+	issue, err := store.GetIssue(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if issue == nil {
+		return nil, fmt.Errorf("message %s not found", id)
+	}
+	if issue.IssueType != TypeMessage {
+		return nil, fmt.Errorf("%s is not a message (type: %s)", id, issue.IssueType)
+	}
+	labels, err := store.GetLabels(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get labels of %s: %w", id, err)
+	}
+	issue.Labels = labels
+	return issue, nil
+}
+
+// Inbox returns the messages addressed to recipient, newest first.
+func Inbox(ctx context.Context, store storage.Storage, recipient string, opts InboxOptions) ([]*types.Issue, error) {
+	msgType := TypeMessage
+	filter := types.IssueFilter{IssueType: &msgType}
+	if !opts.Archived {
+		filter.ExcludeStatus = []types.Status{types.StatusClosed}
+	}
+
+	// Messages created before labels (or by other tools) only carry the
+	// recipient as assignee
+	byLabel := filter
+	byLabel.Labels = []string{LabelTo + recipient}
+	byAssignee := filter
+	byAssignee.Assignee = &recipient
+
+	seen := make(map[string]*types.Issue)
+	for _, f := range []types.IssueFilter{byLabel, byAssignee} {
+		found, err := store.SearchIssues(ctx, "", f)
+		if err != nil {
+			return nil, fmt.Errorf("failed to search messages: %w", err)
+		}
+		for _, issue := range found {
+			seen[issue.ID] = issue
+		}
+	}
+	ids := make([]string, 0, len(seen))
+	for id := range seen {
+		ids = append(ids, id)
+	}
+	labels, err := store.GetLabelsForIssues(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get message labels: %w", err)
+	}
+
+	var inbox []*types.Issue
+	for id, issue := range seen {
+		issue.Labels = labels[id]
+		if !opts.Archived && IsArchived(issue, recipient) {
+			continue
+		}
+		if opts.Unread && IsRead(issue, recipient) {
+			continue
+		}
+		inbox = append(inbox, issue)
+	}
+	sort.Slice(inbox, func(i, j int) bool {
+		if !inbox[i].CreatedAt.Equal(inbox[j].CreatedAt) {
+			return inbox[i].CreatedAt.After(inbox[j].CreatedAt)
+		}
+		return inbox[i].ID > inbox[j].ID
+	})
+	return inbox, nil
+}
+
+// MarkRead marks message id read for recipient and returns it.
+func MarkRead(ctx context.Context, store storage.Storage, id, recipient, actor string) (*types.Issue, error) {
+	msg, err := Get(ctx, store, id)
+	if err != nil {
+		return nil, err
+	}
+	if IsRead(msg, recipient) {
+		return msg, nil
+	}
+	if err := store.AddLabel(ctx, id, LabelRead+recipient, actor); err != nil {
+		return nil, fmt.Errorf("failed to mark %s read: %w", id, err)
+	}
+	msg.Labels = append(msg.Labels, LabelRead+recipient)
+	return msg, nil
+}
+
+// Reply answers message id on behalf of from. The reply goes to the
+// original sender, or to the original recipients when from sent it, and is
+// ephemeral unless either message is kept. The answered message is marked
+// read for from.
+func Reply(ctx context.Context, store storage.Storage, id, from, subject, body string, keep bool, actor string) (*types.Issue, error) {
+	parent, err := MarkRead(ctx, store, id, from, actor)
+	if err != nil {
+		return nil, err
+	}
+	to := []string{parent.Sender}
+	if parent.Sender == from || parent.Sender == "" {
+		to = Recipients(parent)
+	}
+	if subject == "" {
+		subject = parent.Title
+		if !strings.HasPrefix(strings.ToLower(subject), "re:") {
+			subject = "Re: " + subject
+		}
+	}
+	return send(ctx, store, Message{
+		From:    from,
+		To:      to,
+		Subject: subject,
+		Body:    body,
+		Keep:    keep || !parent.Ephemeral,
+	}, parent.ID, actor)
+}
+
+// Archive archives message id for recipient, which also marks it read.
+// Once every recipient archived the message it is closed; Archive reports
+// whether that happened.
+func Archive(ctx context.Context, store storage.Storage, id, recipient, actor string) (bool, error) {
+	msg, err := MarkRead(ctx, store, id, recipient, actor)
+	if err != nil {
+		return false, err
+	}
+	if !IsArchived(msg, recipient) {
+		if err := store.AddLabel(ctx, id, LabelArchived+recipient, actor); err != nil {
+			return false, fmt.Errorf("failed to archive %s: %w", id, err)
+		}
+		msg.Labels = append(msg.Labels, LabelArchived+recipient)
+	}
+	if msg.Status == types.StatusClosed {
+		return false, nil
+	}
+	for _, addr := range Recipients(msg) {
+		if !IsArchived(msg, addr) {
+			return false, nil
+		}
+	}
+	if err := store.CloseIssue(ctx, id, "Archived", actor, ""); err != nil {
+		return false, fmt.Errorf("failed to close %s: %w", id, err)
+	}
+	return true, nil
+}
+
+// NotifyWaiters sends every waiter of a closed gate a message saying so.
+// It does nothing when mail isn't set up: the message type isn't configured
+// and no mail.delegate is set.
+func NotifyWaiters(ctx context.Context, store storage.Storage, gate *types.Issue, actor string) ([]*types.Issue, error) {
+	if gate == nil || gate.IssueType != "gate" || len(gate.Waiters) == 0 {
+		return nil, nil
+	}
+	enabled, err := hasMessageType(ctx, store)
+	if err != nil {
+		return nil, err
+	}
+	if !enabled {
+		// With a delegate the user expects mail, so Send reports the
+		// missing type
+		if delegate, err := store.GetConfig(ctx, "mail.delegate"); err != nil || delegate == "" {
+			return nil, nil
+		}
+	}
+	body := fmt.Sprintf("Gate %s (%s) closed.", gate.ID, gate.Title)
+	if gate.CloseReason != "" {
+		body += "\nReason: " + gate.CloseReason
+	}
+	var sent []*types.Issue
+	for _, waiter := range normalizeAddresses(gate.Waiters) {
+		msg, err := Send(ctx, store, Message{
+			From:    actor,
+			To:      []string{waiter},
+			Subject: "Gate closed: " + gate.ID,
+			Body:    body,
+		}, actor)
+		if err != nil {
+			return sent, fmt.Errorf("failed to notify %s: %w", waiter, err)
+		}
+		sent = append(sent, msg)
+	}
+	return sent, nil
+}
+
+// Recipients returns the addresses a message was sent to.
+func Recipients(msg *types.Issue) []string {
+	var to []string
+	for _, label := range msg.Labels {
+		if addr, ok := strings.CutPrefix(label, LabelTo); ok {
+			to = append(to, addr)
+		}
+	}
+	if len(to) == 0 && msg.Assignee != "" {
+		to = append(to, msg.Assignee)
+	}
+	return to
+}
+
+// IsRead reports whether recipient has read msg.
+func IsRead(msg *types.Issue, recipient string) bool {
+	return slices.Contains(msg.Labels, LabelRead+recipient)
+}
+
+// IsArchived reports whether recipient has archived msg.
+func IsArchived(msg *types.Issue, recipient string) bool {
+	return slices.Contains(msg.Labels, LabelArchived+recipient)
+}
+
+// requireMessageType fails unless the message type is in types.custom. It
+// leaves the configuration alone and says how to fix it instead.
+func requireMessageType(ctx context.Context, store storage.Storage) error {
+	custom, err := store.GetCustomTypes(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to read custom types: %w", err)
+	}
+	if slices.Contains(custom, string(TypeMessage)) {
+		return nil
+	}
+	return fmt.Errorf("issue type %q is not configured; enable mail with: bd config set types.custom %q",
+		TypeMessage, strings.Join(append(custom, string(TypeMessage)), ","))
+}
+
+// hasMessageType reports whether the message type is in types.custom.
+func hasMessageType(ctx context.Context, store storage.Storage) (bool, error) {
+	custom, err := store.GetCustomTypes(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to read custom types: %w", err)
+	}
+	return slices.Contains(custom, string(TypeMessage)), nil
+}
+
+// normalizeAddresses trims addresses, splits comma-separated lists and
+// drops empty entries and duplicates.
+func normalizeAddresses(addrs []string) []string {
+	var out []string
+	for _, a := range addrs {
+		for _, part := range strings.Split(a, ",") {
+			part = strings.TrimSpace(part)
+			if part != "" && !slices.Contains(out, part) {
+				out = append(out, part)
+			}
+		}
+	}
+	return out
+}
@@ -0,0 +1,249 @@
+package mail
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/storage/sqlite"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// newTestStore returns a store with mail enabled, plus any extra custom types.
+func newTestStore(t *testing.T, custom string) *sqlite.SQLiteStorage {
+	t.Helper()
+	ctx := context.Background()
+	store, err := sqlite.New(ctx, filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("Failed to create store: %v", err)
+	}
+	t.Cleanup(func() { _ = store.Close() })
+	if err := store.SetConfig(ctx, "issue_prefix", "test"); err != nil {
+		t.Fatalf("Failed to set prefix: %v", err)
+	}
+	if err := store.SetConfig(ctx, "types.custom", custom); err != nil {
+		t.Fatalf("Failed to set custom types: %v", err)
+	}
+	return store
+}
+
+func inboxIDs(t *testing.T, store *sqlite.SQLiteStorage, recipient string, opts InboxOptions) []string {
+	t.Helper()
+	inbox, err := Inbox(context.Background(), store, recipient, opts)
+	if err != nil {
+		t.Fatalf("Inbox failed: %v", err)
+	}
+	ids := make([]string, len(inbox))
+	for i, msg := range inbox {
+		ids[i] = msg.ID
+	}
+	return ids
+}
+
+func TestSendAndInbox(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t, "message")
+
+	msg, err := Send(ctx, store, Message{From: "alice", To: []string{"bob, carol", "bob"}, Subject: "Standup", Body: "At ten"}, "alice")
+	if err != nil {
+		t.Fatalf("Send failed: %v", err)
+	}
+	if msg.IssueType != TypeMessage || msg.Sender != "alice" || msg.Assignee != "bob" || !msg.Ephemeral {
+		t.Errorf("message = %+v", msg)
+	}
+	if to := Recipients(msg); strings.Join(to, ",") != "bob,carol" {
+		t.Errorf("Recipients = %v, want bob,carol", to)
+	}
+
+	kept, err := Send(ctx, store, Message{From: "alice", To: []string{"carol"}, Subject: "Notes", Keep: true}, "alice")
+	if err != nil {
+		t.Fatalf("Send failed: %v", err)
+	}
+	if kept.Ephemeral {
+		t.Error("kept message is ephemeral")
+	}
+
+	// Messages from other tools only carry the assignee
+	legacy := &types.Issue{Title: "Old", Status: types.StatusOpen, Priority: 2, IssueType: TypeMessage, Sender: "dave", Assignee: "carol"}
+	if err := store.CreateIssue(ctx, legacy, "dave"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+
+	if ids := inboxIDs(t, store, "bob", InboxOptions{}); len(ids) != 1 || ids[0] != msg.ID {
+		t.Errorf("bob's inbox = %v, want [%s]", ids, msg.ID)
+	}
+	if ids := inboxIDs(t, store, "carol", InboxOptions{}); len(ids) != 3 {
+		t.Errorf("carol's inbox = %v, want 3 messages", ids)
+	}
+	if ids := inboxIDs(t, store, "alice", InboxOptions{}); len(ids) != 0 {
+		t.Errorf("alice's inbox = %v, want empty", ids)
+	}
+
+	for _, bad := range []Message{
+		{From: "alice", Subject: "Nobody"},
+		{From: "alice", To: []string{"bob"}, Subject: "  "},
+		{To: []string{"bob"}, Subject: "Anonymous"},
+	} {
+		if _, err := Send(ctx, store, bad, "alice"); err == nil {
+			t.Errorf("Send(%+v) succeeded, want error", bad)
+		}
+	}
+}
+
+func TestReadAndArchive(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t, "message")
+	msg, err := Send(ctx, store, Message{From: "alice", To: []string{"bob", "carol"}, Subject: "Deploy"}, "alice")
+	if err != nil {
+		t.Fatalf("Send failed: %v", err)
+	}
+
+	// Read state is per recipient
+	if _, err := MarkRead(ctx, store, msg.ID, "bob", "bob"); err != nil {
+		t.Fatalf("MarkRead failed: %v", err)
+	}
+	if ids := inboxIDs(t, store, "bob", InboxOptions{Unread: true}); len(ids) != 0 {
+		t.Errorf("bob's unread = %v, want empty", ids)
+	}
+	if ids := inboxIDs(t, store, "carol", InboxOptions{Unread: true}); len(ids) != 1 {
+		t.Errorf("carol's unread = %v, want the message", ids)
+	}
+
+	closed, err := Archive(ctx, store, msg.ID, "bob", "bob")
+	if err != nil || closed {
+		t.Fatalf("Archive(bob) = %v, %v; want archived but open", closed, err)
+	}
+	if ids := inboxIDs(t, store, "bob", InboxOptions{}); len(ids) != 0 {
+		t.Errorf("bob's inbox after archive = %v, want empty", ids)
+	}
+	if ids := inboxIDs(t, store, "bob", InboxOptions{Archived: true}); len(ids) != 1 {
+		t.Errorf("bob's inbox with archived = %v, want the message", ids)
+	}
+
+	// The last recipient to archive closes the message
+	closed, err = Archive(ctx, store, msg.ID, "carol", "carol")
+	if err != nil || !closed {
+		t.Fatalf("Archive(carol) = %v, %v; want closed", closed, err)
+	}
+	got, _ := Get(ctx, store, msg.ID)
+	if got.Status != types.StatusClosed || !IsRead(got, "carol") {
+		t.Errorf("after archive: status %s, labels %v", got.Status, got.Labels)
+	}
+
+	task := &types.Issue{Title: "Task", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, task, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	if _, err := MarkRead(ctx, store, task.ID, "bob", "bob"); err == nil || !strings.Contains(err.Error(), "not a message") {
+		t.Errorf("MarkRead on a task = %v, want not-a-message error", err)
+	}
+}
+
+func TestReply(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t, "message")
+	msg, err := Send(ctx, store, Message{From: "alice", To: []string{"bob"}, Subject: "Review", Keep: true}, "alice")
+	if err != nil {
+		t.Fatalf("Send failed: %v", err)
+	}
+
+	reply, err := Reply(ctx, store, msg.ID, "bob", "", "Looks good", false, "bob")
+	if err != nil {
+		t.Fatalf("Reply failed: %v", err)
+	}
+	if reply.Title != "Re: Review" || reply.Sender != "bob" || reply.Assignee != "alice" || reply.Ephemeral {
+		t.Errorf("reply = %+v", reply)
+	}
+	deps, _ := store.GetDependencyRecords(ctx, reply.ID)
+	if len(deps) != 1 || deps[0].DependsOnID != msg.ID || deps[0].Type != types.DepRepliesTo {
+		t.Errorf("reply deps = %+v, want replies-to %s", deps, msg.ID)
+	}
+	if got, _ := Get(ctx, store, msg.ID); !IsRead(got, "bob") {
+		t.Error("replying didn't mark the original read")
+	}
+
+	// Answering your own message goes to its recipients, without a second Re:
+	again, err := Reply(ctx, store, reply.ID, "bob", "", "Also merged", false, "bob")
+	if err != nil {
+		t.Fatalf("Reply failed: %v", err)
+	}
+	if again.Title != "Re: Review" || again.Assignee != "alice" {
+		t.Errorf("follow-up = %+v", again)
+	}
+}
+
+func TestNotifyWaiters(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t, "gate,message")
+	gate := &types.Issue{Title: "CI passes", Status: types.StatusOpen, Priority: 2, IssueType: "gate",
+		Waiters: []string{"polecats/toast", "polecats/nux"}}
+	if err := store.CreateIssue(ctx, gate, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	if err := store.CloseIssue(ctx, gate.ID, "Run succeeded", "witness", ""); err != nil {
+		t.Fatalf("CloseIssue failed: %v", err)
+	}
+	closed, _ := store.GetIssue(ctx, gate.ID)
+
+	sent, err := NotifyWaiters(ctx, store, closed, "witness")
+	if err != nil {
+		t.Fatalf("NotifyWaiters failed: %v", err)
+	}
+	if len(sent) != 2 || sent[0].Sender != "witness" || !strings.Contains(sent[0].Description, "Run succeeded") {
+		t.Fatalf("sent = %+v", sent)
+	}
+	if ids := inboxIDs(t, store, "polecats/nux", InboxOptions{Unread: true}); len(ids) != 1 || ids[0] != sent[1].ID {
+		t.Errorf("waiter inbox = %v, want [%s]", ids, sent[1].ID)
+	}
+}
+
+func TestSendRequiresMessageType(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t, "gate")
+
+	_, err := Send(ctx, store, Message{From: "alice", To: []string{"bob"}, Subject: "Hi"}, "alice")
+	if err == nil || !strings.Contains(err.Error(), `bd config set types.custom "gate,message"`) {
+		t.Errorf("Send without the message type = %v, want instructions to add it", err)
+	}
+	if custom, _ := store.GetCustomTypes(ctx); strings.Join(custom, ",") != "gate" {
+		t.Errorf("types.custom = %v, want it left unchanged", custom)
+	}
+}
+
+func TestReplyToMissingParentStoresNothing(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t, "message")
+
+	// The replies-to link fails, which must roll back the message too
+	_, err := send(ctx, store, Message{From: "bob", To: []string{"alice"}, Subject: "Re: Gone"}, "test-missing", "bob")
+	if err == nil || !strings.Contains(err.Error(), "failed to link") {
+		t.Fatalf("send with a missing parent = %v, want a link error", err)
+	}
+	if ids := inboxIDs(t, store, "alice", InboxOptions{Archived: true}); len(ids) != 0 {
+		t.Errorf("alice's inbox = %v, want nothing stored", ids)
+	}
+}
+
+func TestNotifyWaitersWithoutMail(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t, "gate")
+	gate := &types.Issue{Title: "CI passes", Status: types.StatusClosed, Priority: 2, IssueType: "gate",
+		Waiters: []string{"polecats/toast"}}
+	if err := store.CreateIssue(ctx, gate, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+
+	// Mail isn't set up, so there is nobody to notify and nothing to report
+	if sent, err := NotifyWaiters(ctx, store, gate, "witness"); err != nil || len(sent) != 0 {
+		t.Errorf("NotifyWaiters without mail = %v, %v; want nothing", sent, err)
+	}
+
+	// A delegate means mail is wanted, so the missing type is an error
+	if err := store.SetConfig(ctx, "mail.delegate", "gt mail"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NotifyWaiters(ctx, store, gate, "witness"); err == nil {
+		t.Error("NotifyWaiters with a delegate but no message type succeeded, want error")
+	}
+}
@@ -0,0 +1,85 @@
+package mcp
+
+import (
+	"reflect"
+	"strings"
+)
+
+// InputSchema returns the JSON Schema of a tool's arguments struct.
+//
+// Property names come from json tags. Fields without omitempty are required.
+// The jsonschema tag holds the property description, and an enum tag lists
+// allowed values separated by commas:
+//
+//	Status string `json:"status,omitempty" jsonschema:"New status" enum:"open,closed"`
+func InputSchema(v any) map[string]any {
+	schema := map[string]any{"type": "object", "properties": map[string]any{}}
+	if v == nil {
+		return schema
+	}
+	t := reflect.TypeOf(v)
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return schema
+	}
+
+	props := map[string]any{}
+	var required []string
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, opts, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		prop := typeSchema(field.Type)
+		if desc := field.Tag.Get("jsonschema"); desc != "" {
+			prop["description"] = desc
+		}
+		if enum := field.Tag.Get("enum"); enum != "" {
+			prop["enum"] = strings.Split(enum, ",")
+		}
+		props[name] = prop
+		if !strings.Contains(opts, "omitempty") {
+			required = append(required, name)
+		}
+	}
+	schema["properties"] = props
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
+
+// typeSchema maps a Go type onto a JSON Schema type.
+func typeSchema(t reflect.Type) map[string]any {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		return map[string]any{"type": "array", "items": typeSchema(t.Elem())}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": typeSchema(t.Elem())}
+	case reflect.Struct:
+		return InputSchema(reflect.New(t).Elem().Interface())
+	default:
+		return map[string]any{}
+	}
+}
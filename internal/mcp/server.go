@@ -0,0 +1,317 @@
+// Package mcp implements a Model Context Protocol server for beads.
+//
+// The server speaks JSON-RPC 2.0 over newline-delimited stdio, as MCP hosts
+// expect from a local server process. It exposes the beads tools (ready,
+// list, show, create, update, close, dep, stats, context) and bd prime
+// output as resources. Tools run as daemon RPC operations through an
+// Executor: an rpc.Client when a daemon is running, or an rpc.LocalClient
+// over direct storage otherwise.
+package mcp
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"sync"
+)
+
+// ProtocolVersion is the latest MCP protocol revision the server speaks.
+const ProtocolVersion = "2025-06-18"
+
+// supportedProtocolVersions lists the revisions the server can negotiate,
+// newest first.
+var supportedProtocolVersions = []string{ProtocolVersion, "2025-03-26", "2024-11-05"}
+
+// JSON-RPC error codes
+const (
+	codeParseError     = -32700
+	codeInvalidRequest = -32600
+	codeMethodNotFound = -32601
+	codeInvalidParams  = -32602
+	codeInternalError  = -32603
+)
+
+// maxMessageBytes caps the size of one incoming message.
+const maxMessageBytes = 10 << 20
+
+// Tool is an MCP tool. Input is the zero value of its arguments struct; the
+// input schema is derived from it.
+type Tool struct {
+	Name        string
+	Description string
+	Input       any
+	// Handler runs the tool with the raw arguments object. A returned error
+	// is reported to the model as a tool error, not a protocol error.
+	Handler func(ctx context.Context, args json.RawMessage) (any, error)
+}
+
+// Resource is a readable MCP resource.
+type Resource struct {
+	URI         string
+	Name        string
+	Description string
+	MimeType    string
+	Read        func(ctx context.Context) (string, error)
+}
+
+// Server is an MCP server with a fixed set of tools and resources.
+type Server struct {
+	name         string
+	version      string
+	instructions string
+	tools        []Tool
+	resources    []Resource
+
+	writeMu sync.Mutex
+}
+
+// NewServer returns a server that reports itself as name at version.
+// Instructions are sent to the host during initialization.
+func NewServer(name, version, instructions string) *Server {
+	return &Server{name: name, version: version, instructions: instructions}
+}
+
+// AddTool registers a tool.
+func (s *Server) AddTool(tool Tool) {
+	s.tools = append(s.tools, tool)
+}
+
+// AddResource registers a resource.
+func (s *Server) AddResource(resource Resource) {
+	s.resources = append(s.resources, resource)
+}
+
+// message is an incoming JSON-RPC request or notification.
+type message struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+// response is an outgoing JSON-RPC response.
+type response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  any             `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *rpcError) Error() string {
+	return e.Message
+}
+
+// Serve reads messages from in and writes responses to out until in is
+// closed or ctx is canceled. Requests are handled one at a time, in order.
+func (s *Server) Serve(ctx context.Context, in io.Reader, out io.Writer) error {
+	scanner := bufio.NewScanner(in)
+	scanner.Buffer(make([]byte, 64*1024), maxMessageBytes)
+	for scanner.Scan() {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		if resp := s.handleMessage(ctx, line); resp != nil {
+			if err := s.write(out, resp); err != nil {
+				return err
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read message: %w", err)
+	}
+	return nil
+}
+
+func (s *Server) write(out io.Writer, resp *response) error {
+	data, err := json.Marshal(resp)
+	if err != nil {
+		return fmt.Errorf("failed to marshal response: %w", err)
+	}
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+	if _, err := out.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write response: %w", err)
+	}
+	return nil
+}
+
+// handleMessage handles one message and returns its response, or nil for
+// notifications.
+func (s *Server) handleMessage(ctx context.Context, line []byte) *response {
+	var msg message
+	if err := json.Unmarshal(line, &msg); err != nil {
+		return &response{JSONRPC: "2.0", ID: json.RawMessage("null"), Error: &rpcError{Code: codeParseError, Message: "parse error: " + err.Error()}}
+	}
+	if len(msg.ID) == 0 {
+		// Notifications (initialized, cancelled, ...) need no reply
+		return nil
+	}
+	if msg.JSONRPC != "2.0" || msg.Method == "" {
+		return &response{JSONRPC: "2.0", ID: msg.ID, Error: &rpcError{Code: codeInvalidRequest, Message: "invalid request"}}
+	}
+
+	result, err := s.dispatch(ctx, msg.Method, msg.Params)
+	resp := &response{JSONRPC: "2.0", ID: msg.ID, Result: result}
+	if err != nil {
+		var re *rpcError
+		if !errors.As(err, &re) {
+			re = &rpcError{Code: codeInternalError, Message: err.Error()}
+		}
+		resp.Result = nil
+		resp.Error = re
+	}
+	return resp
+}
+
+func (s *Server) dispatch(ctx context.Context, method string, params json.RawMessage) (any, error) {
+	switch method {
+	case "initialize":
+		return s.initialize(params)
+	case "ping":
+		return struct{}{}, nil
+	case "tools/list":
+		return s.listTools(), nil
+	case "tools/call":
+		return s.callTool(ctx, params)
+	case "resources/list":
+		return s.listResources(), nil
+	case "resources/templates/list":
+		return map[string]any{"resourceTemplates": []any{}}, nil
+	case "resources/read":
+		return s.readResource(ctx, params)
+	default:
+		return nil, &rpcError{Code: codeMethodNotFound, Message: "method not found: " + method}
+	}
+}
+
+func (s *Server) initialize(params json.RawMessage) (any, error) {
+	var req struct {
+		ProtocolVersion string `json:"protocolVersion"`
+	}
+	if len(params) > 0 {
+		if err := json.Unmarshal(params, &req); err != nil {
+			return nil, &rpcError{Code: codeInvalidParams, Message: "invalid initialize params: " + err.Error()}
+		}
+	}
+	// Answer with the client's revision if we speak it, else our latest
+	version := ProtocolVersion
+	if slices.Contains(supportedProtocolVersions, req.ProtocolVersion) {
+		version = req.ProtocolVersion
+	}
+	result := map[string]any{
+		"protocolVersion": version,
+		"capabilities": map[string]any{
+			"tools":     map[string]any{"listChanged": false},
+			"resources": map[string]any{"listChanged": false, "subscribe": false},
+		},
+		"serverInfo": map[string]any{"name": s.name, "version": s.version},
+	}
+	if s.instructions != "" {
+		result["instructions"] = s.instructions
+	}
+	return result, nil
+}
+
+func (s *Server) listTools() any {
+	tools := make([]map[string]any, 0, len(s.tools))
+	for _, tool := range s.tools {
+		tools = append(tools, map[string]any{
+			"name":        tool.Name,
+			"description": tool.Description,
+			"inputSchema": InputSchema(tool.Input),
+		})
+	}
+	return map[string]any{"tools": tools}
+}
+
+// textContent is an MCP text content block.
+type textContent struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// toolResult is the result of tools/call.
+type toolResult struct {
+	Content []textContent `json:"content"`
+	IsError bool          `json:"isError,omitempty"`
+}
+
+func (s *Server) callTool(ctx context.Context, params json.RawMessage) (any, error) {
+	var req struct {
+		Name      string          `json:"name"`
+		Arguments json.RawMessage `json:"arguments"`
+	}
+	if err := json.Unmarshal(params, &req); err != nil {
+		return nil, &rpcError{Code: codeInvalidParams, Message: "invalid tools/call params: " + err.Error()}
+	}
+	idx := slices.IndexFunc(s.tools, func(t Tool) bool { return t.Name == req.Name })
+	if idx < 0 {
+		return nil, &rpcError{Code: codeInvalidParams, Message: "unknown tool: " + req.Name}
+	}
+	args := req.Arguments
+	if len(args) == 0 || string(args) == "null" {
+		args = json.RawMessage("{}")
+	}
+
+	out, err := s.tools[idx].Handler(ctx, args)
+	if err != nil {
+		return toolResult{Content: []textContent{{Type: "text", Text: err.Error()}}, IsError: true}, nil
+	}
+	text, ok := out.(string)
+	if !ok {
+		data, err := json.MarshalIndent(out, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal %s result: %w", req.Name, err)
+		}
+		text = string(data)
+	}
+	return toolResult{Content: []textContent{{Type: "text", Text: text}}}, nil
+}
+
+func (s *Server) listResources() any {
+	resources := make([]map[string]any, 0, len(s.resources))
+	for _, r := range s.resources {
+		resources = append(resources, map[string]any{
+			"uri":         r.URI,
+			"name":        r.Name,
+			"description": r.Description,
+			"mimeType":    r.MimeType,
+		})
+	}
+	return map[string]any{"resources": resources}
+}
+
+func (s *Server) readResource(ctx context.Context, params json.RawMessage) (any, error) {
+	var req struct {
+		URI string `json:"uri"`
+	}
+	if err := json.Unmarshal(params, &req); err != nil {
+		return nil, &rpcError{Code: codeInvalidParams, Message: "invalid resources/read params: " + err.Error()}
+	}
+	idx := slices.IndexFunc(s.resources, func(r Resource) bool { return r.URI == req.URI })
+	if idx < 0 {
+		// MCP reserves -32002 for unknown resources
+		return nil, &rpcError{Code: -32002, Message: "resource not found: " + req.URI}
+	}
+	r := s.resources[idx]
+	text, err := r.Read(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", r.URI, err)
+	}
+	return map[string]any{
+		"contents": []map[string]any{{"uri": r.URI, "mimeType": r.MimeType, "text": text}},
+	}, nil
+}
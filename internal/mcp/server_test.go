@@ -0,0 +1,333 @@
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/rpc"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// fakeExecutor records operations and answers from a table.
+type fakeExecutor struct {
+	calls   []string
+	args    map[string]json.RawMessage
+	results map[string]any
+}
+
+func newFakeExecutor() *fakeExecutor {
+	return &fakeExecutor{args: map[string]json.RawMessage{}, results: map[string]any{}}
+}
+
+func (f *fakeExecutor) Execute(operation string, args interface{}) (*rpc.Response, error) {
+	f.calls = append(f.calls, operation)
+	f.args[operation], _ = json.Marshal(args)
+	if operation == rpc.OpResolveID {
+		var a rpc.ResolveIDArgs
+		_ = json.Unmarshal(f.args[operation], &a)
+		if a.ID == "missing" {
+			resp := &rpc.Response{Error: "no issue found matching \"missing\""}
+			return resp, fmt.Errorf("operation failed: %s", resp.Error)
+		}
+		data, _ := json.Marshal("bd-" + strings.TrimPrefix(a.ID, "bd-"))
+		return &rpc.Response{Success: true, Data: data}, nil
+	}
+	data, _ := json.Marshal(f.results[operation])
+	return &rpc.Response{Success: true, Data: data}, nil
+}
+
+// serve sends messages to a beads server and returns the decoded responses.
+func serve(t *testing.T, exec Executor, messages ...string) []map[string]any {
+	t.Helper()
+	s := NewBeadsServer(Config{
+		Version:   "1.2.3",
+		Executor:  exec,
+		Workspace: Workspace{Root: "/work", Database: "/work/.beads/beads.db", Mode: "direct", Version: "1.2.3"},
+		Prime: func(w io.Writer, mcpMode bool) error {
+			_, err := fmt.Fprintf(w, "prime mcp=%v", mcpMode)
+			return err
+		},
+	})
+	var out bytes.Buffer
+	if err := s.Serve(context.Background(), strings.NewReader(strings.Join(messages, "\n")+"\n"), &out); err != nil {
+		t.Fatalf("Serve() error = %v", err)
+	}
+	var responses []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var resp map[string]any
+		if err := json.Unmarshal([]byte(line), &resp); err != nil {
+			t.Fatalf("invalid response %q: %v", line, err)
+		}
+		responses = append(responses, resp)
+	}
+	return responses
+}
+
+// toolText returns the text of a tools/call response and whether it is an error.
+func toolText(t *testing.T, resp map[string]any) (string, bool) {
+	t.Helper()
+	result, ok := resp["result"].(map[string]any)
+	if !ok {
+		t.Fatalf("response has no result: %v", resp)
+	}
+	content := result["content"].([]any)
+	isError, _ := result["isError"].(bool)
+	return content[0].(map[string]any)["text"].(string), isError
+}
+
+func call(id int, tool, args string) string {
+	return fmt.Sprintf(`{"jsonrpc":"2.0","id":%d,"method":"tools/call","params":{"name":%q,"arguments":%s}}`, id, tool, args)
+}
+
+func TestServe_Initialize(t *testing.T) {
+	responses := serve(t, newFakeExecutor(),
+		`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2024-11-05"}}`,
+		`{"jsonrpc":"2.0","method":"notifications/initialized"}`,
+		`{"jsonrpc":"2.0","id":2,"method":"initialize","params":{"protocolVersion":"1999-01-01"}}`,
+		`{"jsonrpc":"2.0","id":3,"method":"ping"}`,
+	)
+	if len(responses) != 3 {
+		t.Fatalf("got %d responses, want 3 (notifications get none)", len(responses))
+	}
+	result := responses[0]["result"].(map[string]any)
+	if result["protocolVersion"] != "2024-11-05" {
+		t.Errorf("protocolVersion = %v, want the client's supported revision", result["protocolVersion"])
+	}
+	if info := result["serverInfo"].(map[string]any); info["name"] != "beads" || info["version"] != "1.2.3" {
+		t.Errorf("serverInfo = %v", info)
+	}
+	if got := responses[1]["result"].(map[string]any)["protocolVersion"]; got != ProtocolVersion {
+		t.Errorf("protocolVersion for unknown revision = %v, want %s", got, ProtocolVersion)
+	}
+	if responses[2]["error"] != nil {
+		t.Errorf("ping error = %v", responses[2]["error"])
+	}
+}
+
+func TestServe_Errors(t *testing.T) {
+	responses := serve(t, newFakeExecutor(),
+		`not json`,
+		`{"jsonrpc":"2.0","id":1,"method":"tools/unknown"}`,
+		call(2, "nope", `{}`),
+		`{"jsonrpc":"2.0","id":3,"method":"resources/read","params":{"uri":"beads://nope"}}`,
+	)
+	wantCodes := []float64{codeParseError, codeMethodNotFound, codeInvalidParams, -32002}
+	for i, want := range wantCodes {
+		errObj, ok := responses[i]["error"].(map[string]any)
+		if !ok || errObj["code"] != want {
+			t.Errorf("response %d error = %v, want code %v", i, responses[i]["error"], want)
+		}
+	}
+}
+
+func TestServe_ToolsList(t *testing.T) {
+	responses := serve(t, newFakeExecutor(), `{"jsonrpc":"2.0","id":1,"method":"tools/list"}`)
+	tools := responses[0]["result"].(map[string]any)["tools"].([]any)
+	var names []string
+	schemas := map[string]map[string]any{}
+	for _, tool := range tools {
+		m := tool.(map[string]any)
+		names = append(names, m["name"].(string))
+		schemas[m["name"].(string)] = m["inputSchema"].(map[string]any)
+	}
+	if got := strings.Join(names, ","); got != "ready,list,count,show,create,update,close,dep,stats,context" {
+		t.Errorf("tools = %s", got)
+	}
+
+	show := schemas["show"]
+	if req := show["required"].([]any); len(req) != 1 || req[0] != "issue_id" {
+		t.Errorf("show required = %v, want [issue_id]", req)
+	}
+	props := show["properties"].(map[string]any)
+	if p := props["issue_id"].(map[string]any); p["type"] != "string" || p["description"] == nil {
+		t.Errorf("issue_id schema = %v", p)
+	}
+	ready := schemas["ready"]["properties"].(map[string]any)
+	if p := ready["labels"].(map[string]any); p["type"] != "array" || p["items"].(map[string]any)["type"] != "string" {
+		t.Errorf("labels schema = %v", p)
+	}
+	if p := ready["sort_policy"].(map[string]any); len(p["enum"].([]any)) != 9 {
+		t.Errorf("sort_policy schema = %v, want an enum", p)
+	}
+	if _, ok := schemas["stats"]["required"]; ok {
+		t.Error("stats should have no required arguments")
+	}
+}
+
+func TestTools_Create(t *testing.T) {
+	exec := newFakeExecutor()
+	exec.results[rpc.OpCreate] = types.Issue{ID: "bd-1", Title: "New"}
+	responses := serve(t, exec,
+		call(1, "create", `{"title":"New","workspace_root":"/ignored"}`),
+		call(2, "create", `{"title":"New","priority":0,"issue_type":"bug","brief":false}`),
+		call(3, "create", `{"title":"  "}`),
+	)
+
+	text, isErr := toolText(t, responses[0])
+	if isErr || !strings.Contains(text, `"action": "created"`) {
+		t.Errorf("brief create = %s", text)
+	}
+	text, _ = toolText(t, responses[1])
+	if !strings.Contains(text, `"title": "New"`) {
+		t.Errorf("full create = %s", text)
+	}
+	var args rpc.CreateArgs
+	_ = json.Unmarshal(exec.args[rpc.OpCreate], &args)
+	if args.Priority != 0 || args.IssueType != "bug" {
+		t.Errorf("create args = %+v, want priority 0 and type bug", args)
+	}
+	if _, isErr := toolText(t, responses[2]); !isErr {
+		t.Error("create without title should be a tool error")
+	}
+	if len(exec.calls) != 2 {
+		t.Errorf("calls = %v, want two creates", exec.calls)
+	}
+}
+
+func TestTools_CreateDefaults(t *testing.T) {
+	exec := newFakeExecutor()
+	exec.results[rpc.OpCreate] = types.Issue{ID: "bd-1"}
+	serve(t, exec, call(1, "create", `{"title":"New"}`))
+	var args rpc.CreateArgs
+	_ = json.Unmarshal(exec.args[rpc.OpCreate], &args)
+	if args.Priority != 2 || args.IssueType != "task" {
+		t.Errorf("create args = %+v, want priority 2 and type task", args)
+	}
+}
+
+func TestTools_ListAndReady(t *testing.T) {
+	exec := newFakeExecutor()
+	exec.results[rpc.OpList] = []*types.IssueWithCounts{{
+		Issue:           &types.Issue{ID: "bd-1", Title: "One", Status: types.StatusOpen, Description: "long text"},
+		DependencyCount: 2,
+	}}
+	exec.results[rpc.OpReady] = []*types.Issue{{ID: "bd-2", Title: "Two", Status: types.StatusOpen, Priority: 1}}
+	responses := serve(t, exec,
+		call(1, "list", `{}`),
+		call(2, "ready", `{"brief":true}`),
+	)
+
+	text, _ := toolText(t, responses[0])
+	if !strings.Contains(text, `"dependency_count": 2`) || strings.Contains(text, "long text") {
+		t.Errorf("list = %s, want compact issues with counts", text)
+	}
+	var listArgs rpc.ListArgs
+	_ = json.Unmarshal(exec.args[rpc.OpList], &listArgs)
+	if listArgs.Limit != defaultListLimit || len(listArgs.ExcludeStatus) != 1 || listArgs.ExcludeStatus[0] != "closed" {
+		t.Errorf("list args = %+v, want default limit and closed excluded", listArgs)
+	}
+
+	text, _ = toolText(t, responses[1])
+	if !strings.Contains(text, `"id": "bd-2"`) || strings.Contains(text, "issue_type") {
+		t.Errorf("brief ready = %s", text)
+	}
+	var readyArgs rpc.ReadyArgs
+	_ = json.Unmarshal(exec.args[rpc.OpReady], &readyArgs)
+	if readyArgs.Limit != defaultReadyLimit {
+		t.Errorf("ready limit = %d, want %d", readyArgs.Limit, defaultReadyLimit)
+	}
+}
+
+func TestTools_Where(t *testing.T) {
+	exec := newFakeExecutor()
+	exec.results[rpc.OpList] = []*types.IssueWithCounts{}
+	exec.results[rpc.OpCount] = map[string]int{"count": 3}
+	responses := serve(t, exec,
+		call(1, "list", `{"where":"status:closed AND label:ui"}`),
+		call(2, "count", `{"where":"priority<=1"}`),
+	)
+
+	var listArgs rpc.ListArgs
+	_ = json.Unmarshal(exec.args[rpc.OpList], &listArgs)
+	if listArgs.Where != "status:closed AND label:ui" || len(listArgs.ExcludeStatus) != 0 {
+		t.Errorf("list args = %+v, want where passed through and closed not excluded", listArgs)
+	}
+
+	text, _ := toolText(t, responses[1])
+	if !strings.Contains(text, `"count": 3`) {
+		t.Errorf("count = %s", text)
+	}
+	var countArgs rpc.CountArgs
+	_ = json.Unmarshal(exec.args[rpc.OpCount], &countArgs)
+	if countArgs.Where != "priority<=1" {
+		t.Errorf("count args = %+v, want where passed through", countArgs)
+	}
+}
+
+func TestTools_UpdateAndClose(t *testing.T) {
+	exec := newFakeExecutor()
+	exec.results[rpc.OpUpdate] = types.Issue{ID: "bd-1"}
+	exec.results[rpc.OpClose] = types.Issue{ID: "bd-1"}
+	responses := serve(t, exec,
+		call(1, "update", `{"issue_id":"1","status":"in_progress","assignee":"me"}`),
+		call(2, "update", `{"issue_id":"1","status":"closed","force":true}`),
+		call(3, "close", `{"issue_id":"missing"}`),
+	)
+
+	var updateArgs rpc.UpdateArgs
+	_ = json.Unmarshal(exec.args[rpc.OpUpdate], &updateArgs)
+	if updateArgs.ID != "bd-1" || updateArgs.Status == nil || *updateArgs.Status != "in_progress" {
+		t.Errorf("update args = %+v, want resolved ID and in_progress", updateArgs)
+	}
+
+	// Closing through update goes through the close operation
+	text, _ := toolText(t, responses[1])
+	if !strings.Contains(text, `"action": "closed"`) {
+		t.Errorf("update to closed = %s", text)
+	}
+	var closeArgs rpc.CloseArgs
+	_ = json.Unmarshal(exec.args[rpc.OpClose], &closeArgs)
+	if closeArgs.ID != "bd-1" || !closeArgs.Force || closeArgs.Reason == "" {
+		t.Errorf("close args = %+v", closeArgs)
+	}
+
+	text, isErr := toolText(t, responses[2])
+	if !isErr || !strings.Contains(text, "no issue found") {
+		t.Errorf("close missing = %s (error %v)", text, isErr)
+	}
+}
+
+func TestTools_DepAndContext(t *testing.T) {
+	exec := newFakeExecutor()
+	responses := serve(t, exec,
+		call(1, "dep", `{"issue_id":"1","depends_on_id":"2"}`),
+		call(2, "context", `{}`),
+		call(3, "context", `{"action":"set"}`),
+	)
+	text, _ := toolText(t, responses[0])
+	if text != "Added dependency: bd-1 depends on bd-2 (blocks)" {
+		t.Errorf("dep = %q", text)
+	}
+	text, _ = toolText(t, responses[1])
+	if !strings.Contains(text, `"workspace_root": "/work"`) || !strings.Contains(text, `"mode": "direct"`) {
+		t.Errorf("context = %s", text)
+	}
+	if _, isErr := toolText(t, responses[2]); !isErr {
+		t.Error("context set should be a tool error")
+	}
+}
+
+func TestServe_Resources(t *testing.T) {
+	responses := serve(t, newFakeExecutor(),
+		`{"jsonrpc":"2.0","id":1,"method":"resources/list"}`,
+		`{"jsonrpc":"2.0","id":2,"method":"resources/read","params":{"uri":"beads://prime"}}`,
+		`{"jsonrpc":"2.0","id":3,"method":"resources/read","params":{"uri":"beads://prime/full"}}`,
+	)
+	if resources := responses[0]["result"].(map[string]any)["resources"].([]any); len(resources) != 2 {
+		t.Errorf("resources = %v, want 2", resources)
+	}
+	for i, want := range []string{"prime mcp=true", "prime mcp=false"} {
+		contents := responses[i+1]["result"].(map[string]any)["contents"].([]any)
+		if text := contents[0].(map[string]any)["text"]; text != want {
+			t.Errorf("resource %d = %v, want %q", i, text, want)
+		}
+	}
+}
@@ -0,0 +1,564 @@
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/query"
+	"github.com/steveyegge/beads/internal/ranking"
+	"github.com/steveyegge/beads/internal/rpc"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// Executor runs daemon RPC operations. *rpc.Client and *rpc.LocalClient
+// both implement it.
+type Executor interface {
+	Execute(operation string, args interface{}) (*rpc.Response, error)
+}
+
+// Workspace describes what the server is bound to, for the context tool.
+type Workspace struct {
+	Root     string `json:"workspace_root"`
+	Database string `json:"database"`
+	Mode     string `json:"mode"` // "daemon" or "direct"
+	Actor    string `json:"actor,omitempty"`
+	Version  string `json:"version"`
+}
+
+// Config configures the beads MCP server.
+type Config struct {
+	Version   string
+	Executor  Executor
+	Workspace Workspace
+	// Prime writes bd prime output; mcpMode selects the compact variant.
+	Prime func(w io.Writer, mcpMode bool) error
+}
+
+// Default result sizes, matching the beads-mcp Python server
+const (
+	defaultReadyLimit = 10
+	defaultListLimit  = 20
+)
+
+// instructions is sent to hosts during initialization.
+const instructions = `Beads is a dependency-aware issue tracker. Use "ready" to find unblocked work, ` +
+	`"update" with status=in_progress to claim it, and "close" when done. File discovered work with ` +
+	`"create" and link it with "dep". Read the beads://prime resource for the session workflow.`
+
+// NewBeadsServer returns an MCP server exposing the beads tools and prime
+// resources.
+func NewBeadsServer(cfg Config) *Server {
+	t := &beadsTools{exec: cfg.Executor, workspace: cfg.Workspace}
+	s := NewServer("beads", cfg.Version, instructions)
+
+	s.AddTool(Tool{
+		Name:        "ready",
+		Description: "Find tasks that have no blockers and are ready to be worked on. Returns a compact format; use show for full details.",
+		Input:       readyInput{},
+		Handler:     handle(t.ready),
+	})
+	s.AddTool(Tool{
+		Name:        "list",
+		Description: "List issues with optional filters. Closed issues are excluded unless status is given (directly or in where).",
+		Input:       listInput{},
+		Handler:     handle(t.list),
+	})
+	s.AddTool(Tool{
+		Name:        "count",
+		Description: "Count issues matching a filter expression, optionally grouped by status, priority, type, assignee or label.",
+		Input:       countInput{},
+		Handler:     handle(t.count),
+	})
+	s.AddTool(Tool{
+		Name:        "show",
+		Description: "Show detailed information about an issue, including dependencies, dependents, labels and comments.",
+		Input:       showInput{},
+		Handler:     handle(t.show),
+	})
+	s.AddTool(Tool{
+		Name:        "create",
+		Description: "Create a new issue (bug, feature, task, epic or chore) with optional design, acceptance criteria, labels and dependencies.",
+		Input:       createInput{},
+		Handler:     handle(t.create),
+	})
+	s.AddTool(Tool{
+		Name:        "update",
+		Description: "Update an issue's status, priority, assignee, title, description, design, acceptance criteria or notes. Claim work with status=in_progress.",
+		Input:       updateInput{},
+		Handler:     handle(t.update),
+	})
+	s.AddTool(Tool{
+		Name:        "close",
+		Description: "Close (complete) an issue when the work is done.",
+		Input:       closeInput{},
+		Handler:     handle(t.close),
+	})
+	s.AddTool(Tool{
+		Name:        "dep",
+		Description: "Add a dependency between issues. Types: blocks (hard blocker), related (soft link), parent-child (epic/subtask), discovered-from (found during work).",
+		Input:       depInput{},
+		Handler:     handle(t.dep),
+	})
+	s.AddTool(Tool{
+		Name:        "stats",
+		Description: "Get statistics: total, open, in-progress, closed, blocked and ready issues, and average lead time.",
+		Input:       struct{}{},
+		Handler:     handle(t.stats),
+	})
+	s.AddTool(Tool{
+		Name:        "context",
+		Description: "Show the workspace this server is bound to: workspace root, database, daemon or direct mode, and actor.",
+		Input:       contextInput{},
+		Handler:     handle(t.context),
+	})
+
+	if cfg.Prime != nil {
+		s.AddResource(Resource{
+			URI:         "beads://prime",
+			Name:        "Beads workflow context",
+			Description: "Session workflow and close protocol for MCP users (bd prime --mcp)",
+			MimeType:    "text/markdown",
+			Read:        primeReader(cfg.Prime, true),
+		})
+		s.AddResource(Resource{
+			URI:         "beads://prime/full",
+			Name:        "Beads CLI reference",
+			Description: "Full workflow context with the bd command reference (bd prime --full)",
+			MimeType:    "text/markdown",
+			Read:        primeReader(cfg.Prime, false),
+		})
+	}
+	return s
+}
+
+func primeReader(prime func(io.Writer, bool) error, mcpMode bool) func(context.Context) (string, error) {
+	return func(context.Context) (string, error) {
+		var buf bytes.Buffer
+		if err := prime(&buf, mcpMode); err != nil {
+			return "", err
+		}
+		return buf.String(), nil
+	}
+}
+
+// handle adapts a typed tool function to a Tool handler.
+func handle[T any](fn func(ctx context.Context, in T) (any, error)) func(context.Context, json.RawMessage) (any, error) {
+	return func(ctx context.Context, raw json.RawMessage) (any, error) {
+		var in T
+		// Unknown fields are ignored: hosts configured for beads-mcp send
+		// workspace_root and projection options this server doesn't use
+		if err := json.Unmarshal(raw, &in); err != nil {
+			return nil, fmt.Errorf("invalid arguments: %w", err)
+		}
+		return fn(ctx, in)
+	}
+}
+
+type beadsTools struct {
+	exec      Executor
+	workspace Workspace
+}
+
+// execute runs an RPC operation and decodes its data into out, if non-nil.
+func (t *beadsTools) execute(op string, args, out any) error {
+	resp, err := t.exec.Execute(op, args)
+	if err != nil {
+		if resp != nil && resp.Error != "" {
+			return errors.New(resp.Error)
+		}
+		return err
+	}
+	if out != nil {
+		if err := json.Unmarshal(resp.Data, out); err != nil {
+			return fmt.Errorf("failed to parse %s response: %w", op, err)
+		}
+	}
+	return nil
+}
+
+// resolveID expands a partial issue ID.
+func (t *beadsTools) resolveID(id string) (string, error) {
+	id = strings.TrimSpace(id)
+	if id == "" {
+		return "", fmt.Errorf("issue_id is required")
+	}
+	var resolved string
+	if err := t.execute(rpc.OpResolveID, &rpc.ResolveIDArgs{ID: id}, &resolved); err != nil {
+		return "", err
+	}
+	return resolved, nil
+}
+
+// issueSummary is the compact issue form returned by ready and list.
+type issueSummary struct {
+	ID              string          `json:"id"`
+	Title           string          `json:"title"`
+	Status          types.Status    `json:"status"`
+	Priority        int             `json:"priority"`
+	IssueType       types.IssueType `json:"issue_type,omitempty"`
+	Assignee        string          `json:"assignee,omitempty"`
+	Labels          []string        `json:"labels,omitempty"`
+	DependencyCount int             `json:"dependency_count,omitempty"`
+	DependentCount  int             `json:"dependent_count,omitempty"`
+	Score           *ranking.Score  `json:"score,omitempty"` // Scored ready sort policies only
+}
+
+// briefIssue identifies an issue, for brief=true.
+type briefIssue struct {
+	ID       string       `json:"id"`
+	Title    string       `json:"title"`
+	Status   types.Status `json:"status"`
+	Priority int          `json:"priority"`
+}
+
+// operationResult reports a mutation, for brief=true.
+type operationResult struct {
+	ID      string `json:"id"`
+	Action  string `json:"action"`
+	Message string `json:"message,omitempty"`
+}
+
+func summarize(issue *types.Issue) issueSummary {
+	return issueSummary{
+		ID:        issue.ID,
+		Title:     issue.Title,
+		Status:    issue.Status,
+		Priority:  issue.Priority,
+		IssueType: issue.IssueType,
+		Assignee:  issue.Assignee,
+		Labels:    issue.Labels,
+	}
+}
+
+func brief(issue *types.Issue) briefIssue {
+	return briefIssue{ID: issue.ID, Title: issue.Title, Status: issue.Status, Priority: issue.Priority}
+}
+
+type readyInput struct {
+	Limit      int      `json:"limit,omitempty" jsonschema:"Maximum issues to return (default 10)"`
+	Priority   *int     `json:"priority,omitempty" jsonschema:"Filter by priority (0-4, 0 is highest)"`
+	Assignee   string   `json:"assignee,omitempty" jsonschema:"Filter by assignee"`
+	Unassigned bool     `json:"unassigned,omitempty" jsonschema:"Only unassigned issues"`
+	Type       string   `json:"issue_type,omitempty" jsonschema:"Filter by issue type"`
+	Labels     []string `json:"labels,omitempty" jsonschema:"Issues must have all of these labels"`
+	LabelsAny  []string `json:"labels_any,omitempty" jsonschema:"Issues must have at least one of these labels"`
+	ParentID   string   `json:"parent_id,omitempty" jsonschema:"Only descendants of this epic"`
+	SortPolicy string   `json:"sort_policy,omitempty" jsonschema:"Sort order (default hybrid). unblock, critical-path, due, wsjf, age and score rank by a score returned with each issue" enum:"hybrid,priority,oldest,unblock,critical-path,due,wsjf,age,score"`
+	Brief      bool     `json:"brief,omitempty" jsonschema:"Return only id, title, status and priority"`
+}
+
+func (t *beadsTools) ready(_ context.Context, in readyInput) (any, error) {
+	if in.Limit <= 0 {
+		in.Limit = defaultReadyLimit
+	}
+	var issues []*ranking.ScoredIssue
+	if err := t.execute(rpc.OpReady, &rpc.ReadyArgs{
+		Assignee:   in.Assignee,
+		Unassigned: in.Unassigned,
+		Priority:   in.Priority,
+		Type:       in.Type,
+		Limit:      in.Limit,
+		SortPolicy: in.SortPolicy,
+		Labels:     in.Labels,
+		LabelsAny:  in.LabelsAny,
+		ParentID:   in.ParentID,
+	}, &issues); err != nil {
+		return nil, err
+	}
+	if in.Brief {
+		out := make([]briefIssue, len(issues))
+		for i, issue := range issues {
+			out[i] = brief(issue.Issue)
+		}
+		return out, nil
+	}
+	out := make([]issueSummary, len(issues))
+	for i, issue := range issues {
+		out[i] = summarize(issue.Issue)
+		out[i].Score = issue.Score
+	}
+	return out, nil
+}
+
+type listInput struct {
+	Status     string   `json:"status,omitempty" jsonschema:"Filter by status (open, in_progress, blocked, deferred, review, closed, ...)"`
+	Priority   *int     `json:"priority,omitempty" jsonschema:"Filter by priority (0-4)"`
+	IssueType  string   `json:"issue_type,omitempty" jsonschema:"Filter by type (bug, feature, task, epic, chore, ...)"`
+	Assignee   string   `json:"assignee,omitempty" jsonschema:"Filter by assignee"`
+	Unassigned bool     `json:"unassigned,omitempty" jsonschema:"Only unassigned issues"`
+	Labels     []string `json:"labels,omitempty" jsonschema:"Issues must have all of these labels"`
+	LabelsAny  []string `json:"labels_any,omitempty" jsonschema:"Issues must have at least one of these labels"`
+	Query      string   `json:"query,omitempty" jsonschema:"Search text in titles, descriptions and IDs"`
+	ParentID   string   `json:"parent_id,omitempty" jsonschema:"Only children of this epic"`
+	Where      string   `json:"where,omitempty" jsonschema:"Filter expression, e.g. status:open AND (priority<=1 OR label:security) AND updated>-7d"`
+	Limit      int      `json:"limit,omitempty" jsonschema:"Maximum issues to return (default 20)"`
+	Brief      bool     `json:"brief,omitempty" jsonschema:"Return only id, title, status and priority"`
+}
+
+func (t *beadsTools) list(_ context.Context, in listInput) (any, error) {
+	if in.Limit <= 0 {
+		in.Limit = defaultListLimit
+	}
+	args := &rpc.ListArgs{
+		Query:      in.Query,
+		Status:     in.Status,
+		Priority:   in.Priority,
+		IssueType:  in.IssueType,
+		Assignee:   in.Assignee,
+		Labels:     in.Labels,
+		LabelsAny:  in.LabelsAny,
+		ParentID:   in.ParentID,
+		NoAssignee: in.Unassigned,
+		Where:      in.Where,
+		Limit:      in.Limit,
+	}
+	if in.Status == "" && !whereReferencesStatus(in.Where) {
+		// Like bd list, hide closed issues unless asked for
+		args.ExcludeStatus = []string{string(types.StatusClosed)}
+	}
+	var issues []*types.IssueWithCounts
+	if err := t.execute(rpc.OpList, args, &issues); err != nil {
+		return nil, err
+	}
+	if in.Brief {
+		out := make([]briefIssue, len(issues))
+		for i, issue := range issues {
+			out[i] = brief(issue.Issue)
+		}
+		return out, nil
+	}
+	out := make([]issueSummary, len(issues))
+	for i, issue := range issues {
+		out[i] = summarize(issue.Issue)
+		out[i].DependencyCount = issue.DependencyCount
+		out[i].DependentCount = issue.DependentCount
+	}
+	return out, nil
+}
+
+// whereReferencesStatus reports whether a filter expression tests status, in
+// which case list doesn't hide closed issues. Invalid expressions are left for
+// the daemon to reject.
+func whereReferencesStatus(where string) bool {
+	if where == "" {
+		return false
+	}
+	q, err := query.Parse(where)
+	return err == nil && q.References("status")
+}
+
+type countInput struct {
+	Where   string `json:"where,omitempty" jsonschema:"Filter expression, e.g. status:open AND label:security (all issues if empty)"`
+	GroupBy string `json:"group_by,omitempty" jsonschema:"Group counts by this field" enum:"status,priority,type,assignee,label"`
+}
+
+func (t *beadsTools) count(_ context.Context, in countInput) (any, error) {
+	var result map[string]any
+	if err := t.execute(rpc.OpCount, &rpc.CountArgs{Where: in.Where, GroupBy: in.GroupBy}, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+type showInput struct {
+	IssueID string `json:"issue_id" jsonschema:"Issue ID (e.g. bd-a1b2); a unique prefix is enough"`
+	Brief   bool   `json:"brief,omitempty" jsonschema:"Return only id, title, status and priority"`
+}
+
+func (t *beadsTools) show(_ context.Context, in showInput) (any, error) {
+	id, err := t.resolveID(in.IssueID)
+	if err != nil {
+		return nil, err
+	}
+	var details types.IssueDetails
+	if err := t.execute(rpc.OpShow, &rpc.ShowArgs{ID: id}, &details); err != nil {
+		return nil, err
+	}
+	if in.Brief {
+		return brief(&details.Issue), nil
+	}
+	return details, nil
+}
+
+type createInput struct {
+	Title       string   `json:"title" jsonschema:"Issue title"`
+	Description string   `json:"description,omitempty" jsonschema:"Why the issue exists and what needs to be done"`
+	Design      string   `json:"design,omitempty" jsonschema:"Design notes"`
+	Acceptance  string   `json:"acceptance,omitempty" jsonschema:"Acceptance criteria"`
+	ExternalRef string   `json:"external_ref,omitempty" jsonschema:"External reference (e.g. gh-9, jira-ABC)"`
+	Priority    *int     `json:"priority,omitempty" jsonschema:"Priority (0-4, default 2)"`
+	IssueType   string   `json:"issue_type,omitempty" jsonschema:"Issue type (default task)"`
+	Assignee    string   `json:"assignee,omitempty" jsonschema:"Assignee"`
+	Labels      []string `json:"labels,omitempty" jsonschema:"Labels to add"`
+	ID          string   `json:"id,omitempty" jsonschema:"Explicit issue ID"`
+	Parent      string   `json:"parent,omitempty" jsonschema:"Parent epic ID; the issue gets a hierarchical child ID"`
+	Deps        []string `json:"deps,omitempty" jsonschema:"Dependencies as 'type:id' or 'id' (e.g. discovered-from:bd-20, blocks:bd-15)"`
+	Brief       *bool    `json:"brief,omitempty" jsonschema:"Return only the new ID (default true); false returns the full issue"`
+}
+
+func (t *beadsTools) create(_ context.Context, in createInput) (any, error) {
+	if strings.TrimSpace(in.Title) == "" {
+		return nil, fmt.Errorf("title is required")
+	}
+	priority := 2
+	if in.Priority != nil {
+		priority = *in.Priority
+	}
+	issueType := in.IssueType
+	if issueType == "" {
+		issueType = string(types.TypeTask)
+	}
+	args := &rpc.CreateArgs{
+		ID:                 in.ID,
+		Parent:             in.Parent,
+		Title:              in.Title,
+		Description:        in.Description,
+		IssueType:          issueType,
+		Priority:           priority,
+		Design:             in.Design,
+		AcceptanceCriteria: in.Acceptance,
+		Assignee:           in.Assignee,
+		ExternalRef:        in.ExternalRef,
+		Labels:             in.Labels,
+		Dependencies:       in.Deps,
+		CreatedBy:          t.workspace.Actor,
+	}
+	var issue types.Issue
+	if err := t.execute(rpc.OpCreate, args, &issue); err != nil {
+		return nil, err
+	}
+	if in.Brief == nil || *in.Brief {
+		return operationResult{ID: issue.ID, Action: "created"}, nil
+	}
+	return issue, nil
+}
+
+type updateInput struct {
+	IssueID            string   `json:"issue_id" jsonschema:"Issue ID to update"`
+	Status             *string  `json:"status,omitempty" jsonschema:"New status (in_progress claims the issue; closed closes it)"`
+	Priority           *int     `json:"priority,omitempty" jsonschema:"New priority (0-4)"`
+	Assignee           *string  `json:"assignee,omitempty" jsonschema:"New assignee"`
+	Title              *string  `json:"title,omitempty" jsonschema:"New title"`
+	Description        *string  `json:"description,omitempty" jsonschema:"New description"`
+	Design             *string  `json:"design,omitempty" jsonschema:"New design notes"`
+	AcceptanceCriteria *string  `json:"acceptance_criteria,omitempty" jsonschema:"New acceptance criteria"`
+	Notes              *string  `json:"notes,omitempty" jsonschema:"New notes"`
+	ExternalRef        *string  `json:"external_ref,omitempty" jsonschema:"New external reference"`
+	AddLabels          []string `json:"add_labels,omitempty" jsonschema:"Labels to add"`
+	RemoveLabels       []string `json:"remove_labels,omitempty" jsonschema:"Labels to remove"`
+	Force              bool     `json:"force,omitempty" jsonschema:"Allow a status change the configured workflow rejects (audited)"`
+	Brief              *bool    `json:"brief,omitempty" jsonschema:"Return only the ID (default true); false returns the full issue"`
+}
+
+func (t *beadsTools) update(ctx context.Context, in updateInput) (any, error) {
+	// Closing goes through close so blockers, gates and pins are checked
+	if in.Status != nil && *in.Status == string(types.StatusClosed) {
+		return t.close(ctx, closeInput{IssueID: in.IssueID, Reason: "Closed via update", Force: in.Force, Brief: in.Brief})
+	}
+	id, err := t.resolveID(in.IssueID)
+	if err != nil {
+		return nil, err
+	}
+	args := &rpc.UpdateArgs{
+		ID:                 id,
+		Title:              in.Title,
+		Description:        in.Description,
+		Status:             in.Status,
+		Priority:           in.Priority,
+		Design:             in.Design,
+		AcceptanceCriteria: in.AcceptanceCriteria,
+		Notes:              in.Notes,
+		Assignee:           in.Assignee,
+		ExternalRef:        in.ExternalRef,
+		AddLabels:          in.AddLabels,
+		RemoveLabels:       in.RemoveLabels,
+		Force:              in.Force,
+	}
+	var issue types.Issue
+	if err := t.execute(rpc.OpUpdate, args, &issue); err != nil {
+		return nil, err
+	}
+	if in.Brief == nil || *in.Brief {
+		return operationResult{ID: id, Action: "updated"}, nil
+	}
+	return issue, nil
+}
+
+type closeInput struct {
+	IssueID string `json:"issue_id" jsonschema:"Issue ID to close"`
+	Reason  string `json:"reason,omitempty" jsonschema:"Why the issue is closed (default Completed)"`
+	Force   bool   `json:"force,omitempty" jsonschema:"Close even if pinned, blocked or rejected by the workflow (audited)"`
+	Brief   *bool  `json:"brief,omitempty" jsonschema:"Return only the ID (default true); false returns the full issue"`
+}
+
+func (t *beadsTools) close(_ context.Context, in closeInput) (any, error) {
+	id, err := t.resolveID(in.IssueID)
+	if err != nil {
+		return nil, err
+	}
+	reason := in.Reason
+	if reason == "" {
+		reason = "Completed"
+	}
+	var issue types.Issue
+	if err := t.execute(rpc.OpClose, &rpc.CloseArgs{ID: id, Reason: reason, Force: in.Force}, &issue); err != nil {
+		return nil, err
+	}
+	if in.Brief == nil || *in.Brief {
+		return operationResult{ID: id, Action: "closed", Message: reason}, nil
+	}
+	return issue, nil
+}
+
+type depInput struct {
+	IssueID     string `json:"issue_id" jsonschema:"The issue that depends on another"`
+	DependsOnID string `json:"depends_on_id" jsonschema:"The issue it depends on"`
+	DepType     string `json:"dep_type,omitempty" jsonschema:"Dependency type (default blocks)"`
+}
+
+func (t *beadsTools) dep(_ context.Context, in depInput) (any, error) {
+	from, err := t.resolveID(in.IssueID)
+	if err != nil {
+		return nil, err
+	}
+	to, err := t.resolveID(in.DependsOnID)
+	if err != nil {
+		return nil, err
+	}
+	depType := in.DepType
+	if depType == "" {
+		depType = string(types.DepBlocks)
+	}
+	if err := t.execute(rpc.OpDepAdd, &rpc.DepAddArgs{FromID: from, ToID: to, DepType: depType}, nil); err != nil {
+		return nil, err
+	}
+	return fmt.Sprintf("Added dependency: %s depends on %s (%s)", from, to, depType), nil
+}
+
+func (t *beadsTools) stats(_ context.Context, _ struct{}) (any, error) {
+	var stats types.Statistics
+	if err := t.execute(rpc.OpStats, nil, &stats); err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+type contextInput struct {
+	Action string `json:"action,omitempty" jsonschema:"Only show is supported; the server stays bound to the workspace it was started in" enum:"show"`
+}
+
+func (t *beadsTools) context(_ context.Context, in contextInput) (any, error) {
+	switch in.Action {
+	case "", "show":
+		return t.workspace, nil
+	case "set", "init":
+		return nil, fmt.Errorf("context %s is not supported: this server serves %s; start bd mcp serve in the other workspace (or pass --db)", in.Action, t.workspace.Root)
+	default:
+		return nil, fmt.Errorf("unknown action %q (valid: show)", in.Action)
+	}
+}
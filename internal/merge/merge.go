@@ -55,6 +55,10 @@ type Issue struct {
 	ClosedBySession string       `json:"closed_by_session,omitempty"` // Session that closed this issue (GH#891)
 	CreatedBy       string       `json:"created_by,omitempty"`
 	Dependencies []Dependency `json:"dependencies,omitempty"`
+	WorkLog      []WorkLogEntry `json:"work_log,omitempty"`
+	Attachments  []Attachment `json:"attachments,omitempty"`
+	Recurrence   string       `json:"recurrence,omitempty"`
+	CustomFields map[string]string `json:"custom_fields,omitempty"`
 	RawLine      string       `json:"-"` // Store original line for conflict output
 	// Tombstone fields: inline soft-delete support for merge
 	DeletedAt    string `json:"deleted_at,omitempty"`    // When the issue was deleted
@@ -74,6 +78,31 @@ type Dependency struct {
 	CreatedBy   string `json:"created_by"`
 }
 
+// WorkLogEntry represents a time-tracking entry on an issue
+type WorkLogEntry struct {
+	ID        string `json:"id"`
+	IssueID   string `json:"issue_id"`
+	Actor     string `json:"actor"`
+	Minutes   int    `json:"minutes"`
+	StartedAt string `json:"started_at"`
+	EndedAt   string `json:"ended_at,omitempty"`
+	Note      string `json:"note,omitempty"`
+	CreatedAt string `json:"created_at"`
+}
+
+// Attachment represents the metadata of a file attached to an issue
+type Attachment struct {
+	ID         string `json:"id"`
+	IssueID    string `json:"issue_id"`
+	Name       string `json:"name"`
+	SHA256     string `json:"sha256"`
+	Size       int64  `json:"size"`
+	MediaType  string `json:"media_type,omitempty"`
+	CreatedBy  string `json:"created_by,omitempty"`
+	CreatedAt  string `json:"created_at"`
+	DetachedAt string `json:"detached_at,omitempty"`
+}
+
 // IssueKey uniquely identifies an issue for matching
 type IssueKey struct {
 	ID        string
@@ -622,6 +651,20 @@ func mergeIssue(base, left, right Issue) (Issue, string) {
 	// Merge dependencies - proper 3-way merge where removals win
 	result.Dependencies = mergeDependencies(base.Dependencies, left.Dependencies, right.Dependencies)
 
+	// Merge work log - union by entry ID, removals win, stopped timers win
+	result.WorkLog = mergeWorkLog(base.WorkLog, left.WorkLog, right.WorkLog)
+
+	// Merge attachments - union by ID, detaching wins
+	result.Attachments = mergeAttachments(base.Attachments, left.Attachments, right.Attachments)
+
+	// Merge recurrence - a side that spawned the next occurrence cleared it,
+	// and mergeField keeps that change; on conflict, local (left) wins
+	result.Recurrence = mergeField(base.Recurrence, left.Recurrence, right.Recurrence)
+
+	// Merge custom fields - field by field, so edits to different fields
+	// both survive
+	result.CustomFields = mergeCustomFields(base.CustomFields, left.CustomFields, right.CustomFields)
+
 	// If status became tombstone via mergeStatus safety fallback,
 	// copy tombstone fields from whichever side has them
 	if result.Status == StatusTombstone {
@@ -923,3 +966,143 @@ func mergeDependencies(base, left, right []Dependency) []Dependency {
 	return result
 }
 
+// mergeByID performs a 3-way merge of records matched by the ID returned by
+// id. Additions from either side are kept, and a record removed on either
+// side stays removed, as with dependencies. A record present on both sides
+// is resolved by merge, which gets nil for base when the record is new on
+// both. The result is in first-seen order, left before right.
+func mergeByID[T any](base, left, right []T, id func(T) string, merge func(base *T, left, right T) T) []T {
+	byID := func(items []T) map[string]T {
+		m := make(map[string]T, len(items))
+		for _, item := range items {
+			m[id(item)] = item
+		}
+		return m
+	}
+	baseByID, leftByID, rightByID := byID(base), byID(left), byID(right)
+
+	var result []T
+	seen := make(map[string]bool)
+	for _, side := range [][]T{left, right} {
+		for _, item := range side {
+			key := id(item)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+
+			baseItem, inBase := baseByID[key]
+			leftItem, inLeft := leftByID[key]
+			rightItem, inRight := rightByID[key]
+
+			switch {
+			case inLeft && inRight:
+				var basePtr *T
+				if inBase {
+					basePtr = &baseItem
+				}
+				result = append(result, merge(basePtr, leftItem, rightItem))
+			case inBase:
+				// Removed on one side - removal wins
+				continue
+			case inLeft:
+				result = append(result, leftItem)
+			default:
+				result = append(result, rightItem)
+			}
+		}
+	}
+	return result
+}
+
+// mergeWorkLog performs a 3-way merge of work-log entries with mergeByID.
+// Entries are append-only, so additions from either side are kept. When
+// both sides changed the same entry, see mergeWorkLogEntry.
+func mergeWorkLog(base, left, right []WorkLogEntry) []WorkLogEntry {
+	result := mergeByID(base, left, right, func(e WorkLogEntry) string { return e.ID }, mergeWorkLogEntry)
+
+	// Sort chronologically for deterministic output (matches bd export order)
+	slices.SortFunc(result, func(a, b WorkLogEntry) int {
+		if c := cmp.Compare(a.StartedAt, b.StartedAt); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.ID, b.ID)
+	})
+
+	return result
+}
+
+// mergeWorkLogEntry picks the version of an entry present on both sides.
+// A side that didn't change the entry yields to the other; if both changed
+// it, a stopped timer beats a running one, and otherwise left wins.
+func mergeWorkLogEntry(base *WorkLogEntry, left, right WorkLogEntry) WorkLogEntry {
+	switch {
+	case left == right:
+		return left
+	case base != nil && left == *base:
+		return right
+	case base != nil && right == *base:
+		return left
+	case left.EndedAt == "" && right.EndedAt != "":
+		return right
+	default:
+		return left
+	}
+}
+
+// mergeCustomFields performs a 3-way merge of custom field values, one field
+// at a time. A missing field counts as empty, so clearing a field on one side
+// is a change like any other; on conflict, left wins.
+func mergeCustomFields(base, left, right map[string]string) map[string]string {
+	var result map[string]string
+	for _, side := range []map[string]string{left, right} {
+		for name := range side {
+			if _, done := result[name]; done {
+				continue
+			}
+			if value := mergeField(base[name], left[name], right[name]); value != "" {
+				if result == nil {
+					result = make(map[string]string)
+				}
+				result[name] = value
+			}
+		}
+	}
+	return result
+}
+
+// mergeAttachments performs a 3-way merge of attachments with mergeByID.
+// Detaching is a soft delete, so an attachment present on both sides is
+// merged by mergeAttachment; one removed outright on either side stays
+// removed, as with work-log entries.
+func mergeAttachments(base, left, right []Attachment) []Attachment {
+	result := mergeByID(base, left, right, func(a Attachment) string { return a.ID }, mergeAttachment)
+
+	// Sort by creation time for deterministic output (matches bd export order)
+	slices.SortFunc(result, func(a, b Attachment) int {
+		if c := cmp.Compare(a.CreatedAt, b.CreatedAt); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.ID, b.ID)
+	})
+
+	return result
+}
+
+// mergeAttachment picks the version of an attachment present on both sides.
+// A side that didn't change it yields to the other; if both changed it, a
+// detached attachment beats a live one, and otherwise left wins.
+func mergeAttachment(base *Attachment, left, right Attachment) Attachment {
+	switch {
+	case left == right:
+		return left
+	case base != nil && left == *base:
+		return right
+	case base != nil && right == *base:
+		return left
+	case left.DetachedAt == "" && right.DetachedAt != "":
+		return right
+	default:
+		return left
+	}
+}
@@ -2,8 +2,10 @@ package merge
 
 import (
 	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
+	"reflect"
 	"testing"
 	"time"
 )
@@ -272,6 +274,220 @@ func TestMergeDependencies(t *testing.T) {
 	}
 }
 
+// TestMergeWorkLog tests 3-way merging of work-log entries by ID
+func TestMergeWorkLog(t *testing.T) {
+	running := WorkLogEntry{ID: "wl-1", Actor: "alice", StartedAt: "2024-01-01T09:00:00Z"}
+	stopped := running
+	stopped.EndedAt = "2024-01-01T10:00:00Z"
+	stopped.Minutes = 60
+	logged := WorkLogEntry{ID: "wl-2", Actor: "bob", Minutes: 30, StartedAt: "2024-01-01T08:00:00Z", EndedAt: "2024-01-01T08:30:00Z"}
+	edited := logged
+	edited.Minutes = 45
+
+	tests := []struct {
+		name     string
+		base     []WorkLogEntry
+		left     []WorkLogEntry
+		right    []WorkLogEntry
+		expected []WorkLogEntry
+	}{
+		{
+			name:     "both sides add entries",
+			left:     []WorkLogEntry{running},
+			right:    []WorkLogEntry{logged},
+			expected: []WorkLogEntry{logged, running},
+		},
+		{
+			name:     "right stops a timer left did not touch",
+			base:     []WorkLogEntry{running},
+			left:     []WorkLogEntry{running},
+			right:    []WorkLogEntry{stopped},
+			expected: []WorkLogEntry{stopped},
+		},
+		{
+			name:     "stopped timer wins when both changed",
+			base:     []WorkLogEntry{running},
+			left:     []WorkLogEntry{func() WorkLogEntry { e := running; e.Note = "wip"; return e }()},
+			right:    []WorkLogEntry{stopped},
+			expected: []WorkLogEntry{stopped},
+		},
+		{
+			name:     "timer added on both sides, one stopped",
+			left:     []WorkLogEntry{stopped},
+			right:    []WorkLogEntry{running},
+			expected: []WorkLogEntry{stopped},
+		},
+		{
+			name:     "edit on one side is kept",
+			base:     []WorkLogEntry{logged},
+			left:     []WorkLogEntry{edited},
+			right:    []WorkLogEntry{logged},
+			expected: []WorkLogEntry{edited},
+		},
+		{
+			name:     "removal wins",
+			base:     []WorkLogEntry{logged, running},
+			left:     []WorkLogEntry{running},
+			right:    []WorkLogEntry{logged, running},
+			expected: []WorkLogEntry{running},
+		},
+		{
+			name:     "empty all sides",
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := mergeWorkLog(tt.base, tt.left, tt.right)
+			if len(result) != len(tt.expected) {
+				t.Fatalf("mergeWorkLog() returned %d entries, want %d: %+v", len(result), len(tt.expected), result)
+			}
+			for i := range tt.expected {
+				if result[i] != tt.expected[i] {
+					t.Errorf("entry %d = %+v, want %+v", i, result[i], tt.expected[i])
+				}
+			}
+		})
+	}
+}
+
+// TestMergeAttachments tests 3-way merging of attachments by ID
+func TestMergeAttachments(t *testing.T) {
+	log := Attachment{ID: "att-1", Name: "build.log", SHA256: "aaaa", Size: 10, CreatedAt: "2024-01-01T09:00:00Z"}
+	detached := log
+	detached.DetachedAt = "2024-01-02T09:00:00Z"
+	renamed := log
+	renamed.Name = "ci.log"
+	shot := Attachment{ID: "att-2", Name: "shot.png", SHA256: "bbbb", Size: 20, CreatedAt: "2024-01-01T08:00:00Z"}
+
+	tests := []struct {
+		name     string
+		base     []Attachment
+		left     []Attachment
+		right    []Attachment
+		expected []Attachment
+	}{
+		{
+			name:     "both sides attach",
+			left:     []Attachment{log},
+			right:    []Attachment{shot},
+			expected: []Attachment{shot, log},
+		},
+		{
+			name:     "right detaches",
+			base:     []Attachment{log},
+			left:     []Attachment{log},
+			right:    []Attachment{detached},
+			expected: []Attachment{detached},
+		},
+		{
+			name:     "detach wins when both changed",
+			base:     []Attachment{log},
+			left:     []Attachment{renamed},
+			right:    []Attachment{detached},
+			expected: []Attachment{detached},
+		},
+		{
+			name:     "removal wins",
+			base:     []Attachment{log, shot},
+			left:     []Attachment{shot},
+			right:    []Attachment{log, shot},
+			expected: []Attachment{shot},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := mergeAttachments(tt.base, tt.left, tt.right)
+			if len(result) != len(tt.expected) {
+				t.Fatalf("mergeAttachments() returned %d attachments, want %d: %+v", len(result), len(tt.expected), result)
+			}
+			for i := range tt.expected {
+				if result[i] != tt.expected[i] {
+					t.Errorf("attachment %d = %+v, want %+v", i, result[i], tt.expected[i])
+				}
+			}
+		})
+	}
+}
+
+// TestMerge3Way_WorkLog tests that work-log entries survive a full file merge
+func TestMerge3Way_WorkLog(t *testing.T) {
+	tmpDir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(tmpDir, name)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	const issue = `"id":"bd-1","title":"Task","status":"open","priority":2,"created_at":"2024-01-01T00:00:00Z","updated_at":"%s"`
+	base := write("base.jsonl", `{`+fmt.Sprintf(issue, "2024-01-01T00:00:00Z")+`}`+"\n")
+	left := write("left.jsonl", `{`+fmt.Sprintf(issue, "2024-01-02T00:00:00Z")+`,"work_log":[{"id":"wl-a","issue_id":"bd-1","actor":"alice","minutes":30,"started_at":"2024-01-02T09:00:00Z","ended_at":"2024-01-02T09:30:00Z","created_at":"2024-01-02T09:30:00Z"}]}`+"\n")
+	right := write("right.jsonl", `{`+fmt.Sprintf(issue, "2024-01-03T00:00:00Z")+`,"work_log":[{"id":"wl-b","issue_id":"bd-1","actor":"bob","minutes":15,"started_at":"2024-01-03T09:00:00Z","ended_at":"2024-01-03T09:15:00Z","created_at":"2024-01-03T09:15:00Z"}]}`+"\n")
+	output := filepath.Join(tmpDir, "output.jsonl")
+
+	if err := Merge3Way(output, base, left, right, false); err != nil {
+		t.Fatalf("Merge3Way failed: %v", err)
+	}
+
+	merged, err := readIssues(output)
+	if err != nil {
+		t.Fatalf("failed to read output: %v", err)
+	}
+	if len(merged) != 1 {
+		t.Fatalf("expected 1 issue, got %d", len(merged))
+	}
+	workLog := merged[0].WorkLog
+	if len(workLog) != 2 || workLog[0].ID != "wl-a" || workLog[1].ID != "wl-b" {
+		t.Errorf("expected work log [wl-a wl-b], got %+v", workLog)
+	}
+}
+
+// TestMergeIssue_Recurrence tests that clearing a rule on spawn survives the merge
+func TestMergeIssue_Recurrence(t *testing.T) {
+	base := Issue{ID: "bd-1", Status: "open", Recurrence: "every monday 09:00"}
+	left := base
+	left.Status = "closed"
+	left.Recurrence = ""
+	right := base
+	right.Title = "Renamed"
+
+	result, _ := mergeIssue(base, left, right)
+	if result.Recurrence != "" {
+		t.Errorf("Recurrence = %q, want cleared rule to win", result.Recurrence)
+	}
+
+	result, _ = mergeIssue(base, right, left)
+	if result.Recurrence != "" {
+		t.Errorf("Recurrence = %q, want cleared rule to win from the right side", result.Recurrence)
+	}
+}
+
+// TestMergeIssue_CustomFields tests that custom fields merge field by field
+func TestMergeIssue_CustomFields(t *testing.T) {
+	base := Issue{ID: "bd-1", Status: "open", CustomFields: map[string]string{"points": "3", "team": "core"}}
+	left := base
+	left.CustomFields = map[string]string{"points": "5", "team": "core"}
+	right := base
+	right.CustomFields = map[string]string{"points": "3", "sprint": "2025-07"}
+
+	result, _ := mergeIssue(base, left, right)
+	want := map[string]string{"points": "5", "sprint": "2025-07"}
+	if !reflect.DeepEqual(result.CustomFields, want) {
+		t.Errorf("CustomFields = %v, want %v", result.CustomFields, want)
+	}
+
+	// Conflicting edits to the same field - left wins
+	right.CustomFields = map[string]string{"points": "8", "team": "core"}
+	result, _ = mergeIssue(base, left, right)
+	if result.CustomFields["points"] != "5" {
+		t.Errorf("points = %q, want left value 5", result.CustomFields["points"])
+	}
+}
+
 // TestMaxTime tests timestamp merging (max wins)
 func TestMaxTime(t *testing.T) {
 	tests := []struct {
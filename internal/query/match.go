@@ -0,0 +1,274 @@
+package query
+
+import (
+	"strings"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// Resolver supplies the relations that predicates need when a query is
+// evaluated in memory.
+type Resolver interface {
+	Labels(issueID string) []string
+	Dependencies(issueID string) []*types.Dependency // What the issue depends on
+	Dependents(issueID string) []*types.Dependency   // What depends on the issue
+	Issue(id string) *types.Issue                    // nil if unknown
+}
+
+// Match reports whether the issue satisfies the query. It has the same
+// semantics as the compiled SQL.
+func (q *Query) Match(issue *types.Issue, r Resolver) bool {
+	return q.root.match(&env{issue: issue, r: r, now: q.now})
+}
+
+type env struct {
+	issue *types.Issue
+	r     Resolver
+	now   time.Time
+}
+
+func (n *andNode) match(e *env) bool {
+	for _, c := range n.children {
+		if !c.match(e) {
+			return false
+		}
+	}
+	return true
+}
+
+func (n *orNode) match(e *env) bool {
+	for _, c := range n.children {
+		if c.match(e) {
+			return true
+		}
+	}
+	return false
+}
+
+func (n *notNode) match(e *env) bool {
+	return !n.child.match(e)
+}
+
+func (n *textNode) match(e *env) bool {
+	return strings.Contains(strings.ToLower(e.issue.Title), n.text) ||
+		strings.Contains(strings.ToLower(e.issue.Description), n.text) ||
+		strings.Contains(strings.ToLower(e.issue.ID), n.text)
+}
+
+func (p *predicate) match(e *env) bool {
+	issue := e.issue
+	switch fieldSpecs[p.field].kind {
+	case kindText:
+		return p.matchText(textField(issue, p.field))
+	case kindContains:
+		value := strings.ToLower(textField(issue, p.field))
+		if p.glob {
+			return globMatch(p.value, value)
+		}
+		return strings.Contains(value, p.value)
+	case kindPriority:
+		return compareInts(issue.Priority, p.op, p.number)
+	case kindDate:
+		t := dateField(issue, p.field)
+		if t == nil {
+			return false
+		}
+		if p.op == ":" {
+			return !t.Before(p.from) && t.Before(p.to)
+		}
+		return compareTimes(*t, p.op, p.from)
+	case kindLabel:
+		for _, label := range e.r.Labels(issue.ID) {
+			if p.matchText(label) {
+				return true
+			}
+		}
+		return false
+	case kindDep:
+		return p.matchDep(e)
+	case kindHas:
+		return p.matchHas(e)
+	case kindIs:
+		return p.matchIs(e)
+	}
+	return false
+}
+
+// matchText compares a text value exactly or as a glob, ignoring case.
+func (p *predicate) matchText(value string) bool {
+	value = strings.ToLower(value)
+	if p.glob {
+		return globMatch(p.value, value)
+	}
+	return value == p.value
+}
+
+func (p *predicate) matchDep(e *env) bool {
+	id := e.issue.ID
+	switch p.field {
+	case "blocked-by":
+		return hasDependency(e.r.Dependencies(id), types.DepBlocks, func(d *types.Dependency) bool { return d.DependsOnID == p.value })
+	case "blocks":
+		return hasDependency(e.r.Dependents(id), types.DepBlocks, func(d *types.Dependency) bool { return d.IssueID == p.value })
+	case "parent":
+		return hasDependency(e.r.Dependencies(id), types.DepParentChild, func(d *types.Dependency) bool { return d.DependsOnID == p.value })
+	case "depends-on":
+		return hasDependency(e.r.Dependencies(id), "", func(d *types.Dependency) bool { return d.DependsOnID == p.value })
+	}
+	return false
+}
+
+func (p *predicate) matchHas(e *env) bool {
+	issue := e.issue
+	switch p.value {
+	case "assignee":
+		return issue.Assignee != ""
+	case "description":
+		return issue.Description != ""
+	case "notes":
+		return issue.Notes != ""
+	case "recurrence":
+		return issue.Recurrence != ""
+	case "external-ref":
+		return issue.ExternalRef != nil && *issue.ExternalRef != ""
+	case "due":
+		return issue.DueAt != nil
+	case "defer":
+		return issue.DeferUntil != nil
+	case "estimate":
+		return issue.EstimatedMinutes != nil
+	case "labels":
+		return len(e.r.Labels(issue.ID)) > 0
+	case "parent":
+		return hasDependency(e.r.Dependencies(issue.ID), types.DepParentChild, nil)
+	case "children":
+		return hasDependency(e.r.Dependents(issue.ID), types.DepParentChild, nil)
+	case "blockers":
+		return hasDependency(e.r.Dependencies(issue.ID), types.DepBlocks, nil)
+	}
+	return false
+}
+
+func (p *predicate) matchIs(e *env) bool {
+	issue := e.issue
+	switch p.value {
+	case "pinned":
+		return issue.Pinned
+	case "template":
+		return issue.IsTemplate
+	case "ephemeral":
+		return issue.Ephemeral
+	case "overdue":
+		return issue.DueAt != nil && issue.DueAt.Before(e.now) && issue.Status != types.StatusClosed
+	case "blocked":
+		return hasDependency(e.r.Dependencies(issue.ID), types.DepBlocks, func(d *types.Dependency) bool {
+			blocker := e.r.Issue(d.DependsOnID)
+			return blocker != nil && openStatus(blocker.Status)
+		})
+	}
+	return false
+}
+
+// hasDependency reports whether any dependency of the given type (any type
+// if empty) satisfies ok (or exists, if ok is nil).
+func hasDependency(deps []*types.Dependency, depType types.DependencyType, ok func(*types.Dependency) bool) bool {
+	for _, d := range deps {
+		if depType != "" && d.Type != depType {
+			continue
+		}
+		if ok == nil || ok(d) {
+			return true
+		}
+	}
+	return false
+}
+
+func textField(issue *types.Issue, field string) string {
+	switch field {
+	case "id":
+		return issue.ID
+	case "status":
+		return string(issue.Status)
+	case "type":
+		return string(issue.IssueType)
+	case "assignee":
+		return issue.Assignee
+	case "owner":
+		return issue.Owner
+	case "creator":
+		return issue.CreatedBy
+	case "title":
+		return issue.Title
+	case "description":
+		return issue.Description
+	case "notes":
+		return issue.Notes
+	}
+	return ""
+}
+
+func dateField(issue *types.Issue, field string) *time.Time {
+	switch field {
+	case "created":
+		return &issue.CreatedAt
+	case "updated":
+		return &issue.UpdatedAt
+	case "closed":
+		return issue.ClosedAt
+	case "due":
+		return issue.DueAt
+	case "defer":
+		return issue.DeferUntil
+	}
+	return nil
+}
+
+func compareInts(a int, op string, b int) bool {
+	switch op {
+	case "<":
+		return a < b
+	case "<=":
+		return a <= b
+	case ">":
+		return a > b
+	case ">=":
+		return a >= b
+	}
+	return a == b
+}
+
+func compareTimes(a time.Time, op string, b time.Time) bool {
+	switch op {
+	case "<":
+		return a.Before(b)
+	case "<=":
+		return !a.After(b)
+	case ">":
+		return a.After(b)
+	case ">=":
+		return !a.Before(b)
+	}
+	return a.Equal(b)
+}
+
+// globMatch reports whether s matches pattern, where "*" matches any run of
+// characters (including none).
+func globMatch(pattern, s string) bool {
+	parts := strings.Split(pattern, "*")
+	if len(parts) == 1 {
+		return pattern == s
+	}
+	if !strings.HasPrefix(s, parts[0]) {
+		return false
+	}
+	s = s[len(parts[0]):]
+	for _, part := range parts[1 : len(parts)-1] {
+		i := strings.Index(s, part)
+		if i < 0 {
+			return false
+		}
+		s = s[i+len(part):]
+	}
+	return strings.HasSuffix(s, parts[len(parts)-1])
+}
@@ -0,0 +1,532 @@
+// Package query implements the filter expression language used by
+// bd list --where, bd count --where and bd search --where.
+//
+// Expressions are parsed into a small AST that can be compiled to an SQL
+// WHERE clause (sqlite and dolt backends) or evaluated directly against
+// issues (memory backend), so every backend accepts the same syntax:
+//
+//	status:open AND (priority<=1 OR label:security)
+//	updated>-7d NOT assignee:bot-*
+//	type:bug,feature has:parent          comma-separated values match any
+//	blocked-by:bd-12                     dependency predicates
+//	"login timeout"                      bare words and phrases search text
+//
+// Predicates have the form field:value or field<op>value with op one of
+// = != < <= > >=. Adjacent predicates are ANDed; AND, OR and NOT must be
+// upper-case. Text matching is case-insensitive and "*" in a value matches
+// any run of characters. Dates accept anything internal/timeparsing does
+// ("-7d", "yesterday", "2025-01-31"); field:date matches that whole day.
+package query
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/steveyegge/beads/internal/timeparsing"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// Node is a parsed query expression.
+type Node interface {
+	// writeSQL renders the node as a boolean SQL expression that is never
+	// NULL, so NOT behaves as it does in memory.
+	writeSQL(c *sqlCompiler)
+	// match reports whether the issue satisfies the node.
+	match(e *env) bool
+}
+
+type andNode struct {
+	children []Node
+}
+
+type orNode struct {
+	children []Node
+}
+
+type notNode struct {
+	child Node
+}
+
+// predicate is a single field test, e.g. priority<=1.
+type predicate struct {
+	field string // Canonical field name
+	op    string // ":", "<", "<=", ">" or ">=" ("!=" is parsed as NOT ":")
+	value string // Lower-cased except for issue IDs
+
+	number int       // priority
+	from   time.Time // date fields; [from, to) for ":"
+	to     time.Time
+	glob   bool // Value contains "*"
+}
+
+// textNode matches bare words and phrases against title, description and ID.
+type textNode struct {
+	text string // Lower-cased
+}
+
+// Query is a parsed filter expression.
+type Query struct {
+	root   Node
+	raw    string
+	now    time.Time
+	fields map[string]bool
+}
+
+// String returns the original expression.
+func (q *Query) String() string {
+	return q.raw
+}
+
+// References reports whether the expression tests the given field, e.g. so
+// that bd list can skip its default status filter when status is queried.
+func (q *Query) References(field string) bool {
+	return q.fields[field]
+}
+
+// Parse parses a filter expression. Relative dates are resolved against the
+// current time.
+func Parse(input string) (*Query, error) {
+	return parse(input, time.Now())
+}
+
+func parse(input string, now time.Time) (*Query, error) {
+	items, err := lex(input)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{items: items, now: now, fields: map[string]bool{}}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.items) {
+		return nil, fmt.Errorf("unexpected %q in query", p.items[p.pos].text)
+	}
+	if root == nil {
+		return nil, fmt.Errorf("empty query")
+	}
+	return &Query{root: root, raw: input, now: now, fields: p.fields}, nil
+}
+
+// --- fields ---
+
+type fieldKind int
+
+const (
+	kindText     fieldKind = iota // exact match or glob
+	kindContains                  // substring match or glob
+	kindPriority
+	kindDate
+	kindLabel
+	kindDep
+	kindHas
+	kindIs
+)
+
+type fieldSpec struct {
+	kind   fieldKind
+	column string // issues column, for column-backed kinds
+}
+
+// fieldSpecs lists the queryable fields by canonical name.
+var fieldSpecs = map[string]fieldSpec{
+	"id":          {kind: kindText, column: "id"},
+	"status":      {kind: kindText, column: "status"},
+	"type":        {kind: kindText, column: "issue_type"},
+	"assignee":    {kind: kindText, column: "assignee"},
+	"owner":       {kind: kindText, column: "owner"},
+	"creator":     {kind: kindText, column: "created_by"},
+	"title":       {kind: kindContains, column: "title"},
+	"description": {kind: kindContains, column: "description"},
+	"notes":       {kind: kindContains, column: "notes"},
+	"priority":    {kind: kindPriority, column: "priority"},
+	"created":     {kind: kindDate, column: "created_at"},
+	"updated":     {kind: kindDate, column: "updated_at"},
+	"closed":      {kind: kindDate, column: "closed_at"},
+	"due":         {kind: kindDate, column: "due_at"},
+	"defer":       {kind: kindDate, column: "defer_until"},
+	"label":       {kind: kindLabel},
+	"blocked-by":  {kind: kindDep},
+	"blocks":      {kind: kindDep},
+	"parent":      {kind: kindDep},
+	"depends-on":  {kind: kindDep},
+	"has":         {kind: kindHas},
+	"is":          {kind: kindIs},
+}
+
+// fieldAliases maps alternative field names to canonical ones.
+var fieldAliases = map[string]string{
+	"desc":        "description",
+	"issue_type":  "type",
+	"p":           "priority",
+	"labels":      "label",
+	"created_by":  "creator",
+	"defer_until": "defer",
+	"due_at":      "due",
+}
+
+var hasValues = []string{"assignee", "blockers", "children", "description", "defer", "due", "estimate", "external-ref", "labels", "notes", "parent", "recurrence"}
+
+var isValues = []string{"blocked", "ephemeral", "overdue", "pinned", "template"}
+
+// --- lexer ---
+
+type itemKind int
+
+const (
+	itemTerm itemKind = iota
+	itemPhrase
+	itemAnd
+	itemOr
+	itemNot
+	itemLParen
+	itemRParen
+)
+
+type item struct {
+	kind itemKind
+	text string
+}
+
+func lex(input string) ([]item, error) {
+	var items []item
+	runes := []rune(input)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(':
+			items = append(items, item{kind: itemLParen, text: "("})
+			i++
+		case r == ')':
+			items = append(items, item{kind: itemRParen, text: ")"})
+			i++
+		case r == '"':
+			end := i + 1
+			for end < len(runes) && runes[end] != '"' {
+				end++
+			}
+			if end >= len(runes) {
+				return nil, fmt.Errorf("unterminated quote in query")
+			}
+			items = append(items, item{kind: itemPhrase, text: string(runes[i+1 : end])})
+			i = end + 1
+		default:
+			// A term runs to whitespace or a parenthesis; quoted sections
+			// (field:"two words") are kept together without the quotes.
+			var b strings.Builder
+			for i < len(runes) && !unicode.IsSpace(runes[i]) && runes[i] != '(' && runes[i] != ')' {
+				if runes[i] == '"' {
+					end := i + 1
+					for end < len(runes) && runes[end] != '"' {
+						end++
+					}
+					if end >= len(runes) {
+						return nil, fmt.Errorf("unterminated quote in query")
+					}
+					b.WriteString(string(runes[i+1 : end]))
+					i = end + 1
+					continue
+				}
+				b.WriteRune(runes[i])
+				i++
+			}
+			word := b.String()
+			switch word {
+			case "AND":
+				items = append(items, item{kind: itemAnd, text: word})
+			case "OR":
+				items = append(items, item{kind: itemOr, text: word})
+			case "NOT":
+				items = append(items, item{kind: itemNot, text: word})
+			default:
+				items = append(items, item{kind: itemTerm, text: word})
+			}
+		}
+	}
+	return items, nil
+}
+
+// --- parser ---
+
+type parser struct {
+	items  []item
+	pos    int
+	now    time.Time
+	fields map[string]bool
+}
+
+func (p *parser) peek() (item, bool) {
+	if p.pos >= len(p.items) {
+		return item{}, false
+	}
+	return p.items[p.pos], true
+}
+
+// parseOr: and ("OR" and)*
+func (p *parser) parseOr() (Node, error) {
+	var children []Node
+	for {
+		n, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		it, ok := p.peek()
+		if n == nil {
+			if ok && it.kind == itemOr {
+				return nil, fmt.Errorf("OR must follow a predicate")
+			}
+			break
+		}
+		children = append(children, n)
+		if !ok || it.kind != itemOr {
+			break
+		}
+		p.pos++
+		if next, ok := p.peek(); !ok || next.kind == itemOr || next.kind == itemAnd || next.kind == itemRParen {
+			return nil, fmt.Errorf("OR must be followed by a predicate")
+		}
+	}
+	switch len(children) {
+	case 0:
+		return nil, nil
+	case 1:
+		return children[0], nil
+	}
+	return &orNode{children: children}, nil
+}
+
+// parseAnd: unary (["AND"] unary)*
+func (p *parser) parseAnd() (Node, error) {
+	var children []Node
+	for {
+		it, ok := p.peek()
+		if !ok || it.kind == itemOr || it.kind == itemRParen {
+			break
+		}
+		if it.kind == itemAnd {
+			p.pos++
+			if len(children) == 0 {
+				return nil, fmt.Errorf("AND must follow a predicate")
+			}
+			if next, ok := p.peek(); !ok || next.kind == itemOr || next.kind == itemAnd || next.kind == itemRParen {
+				return nil, fmt.Errorf("AND must be followed by a predicate")
+			}
+			continue
+		}
+		n, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		children = append(children, n)
+	}
+	switch len(children) {
+	case 0:
+		return nil, nil
+	case 1:
+		return children[0], nil
+	}
+	return &andNode{children: children}, nil
+}
+
+// parseUnary: "NOT" unary | primary
+func (p *parser) parseUnary() (Node, error) {
+	it, _ := p.peek()
+	if it.kind != itemNot {
+		return p.parsePrimary()
+	}
+	p.pos++
+	if next, ok := p.peek(); !ok || next.kind == itemOr || next.kind == itemAnd || next.kind == itemRParen {
+		return nil, fmt.Errorf("NOT must be followed by a predicate")
+	}
+	child, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	return negate(child), nil
+}
+
+// parsePrimary: "(" or ")" | phrase | term
+func (p *parser) parsePrimary() (Node, error) {
+	it, _ := p.peek()
+	p.pos++
+	switch it.kind {
+	case itemLParen:
+		n, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if closing, ok := p.peek(); !ok || closing.kind != itemRParen {
+			return nil, fmt.Errorf("unbalanced parentheses in query")
+		}
+		p.pos++
+		if n == nil {
+			return nil, fmt.Errorf("empty parentheses in query")
+		}
+		return n, nil
+	case itemPhrase:
+		if strings.TrimSpace(it.text) == "" {
+			return nil, fmt.Errorf("empty phrase in query")
+		}
+		return &textNode{text: strings.ToLower(it.text)}, nil
+	case itemTerm:
+		return p.parseTerm(it.text)
+	}
+	return nil, fmt.Errorf("unexpected %q in query", it.text)
+}
+
+// parseTerm parses field<op>value, or a bare word searched as text.
+func (p *parser) parseTerm(term string) (Node, error) {
+	i := strings.IndexAny(term, ":=!<>")
+	if i <= 0 || !isFieldName(term[:i]) {
+		return &textNode{text: strings.ToLower(term)}, nil
+	}
+	name := strings.ToLower(term[:i])
+	if alias, ok := fieldAliases[name]; ok {
+		name = alias
+	}
+	spec, ok := fieldSpecs[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown field %q in query (valid: %s)", term[:i], strings.Join(fieldNames(), ", "))
+	}
+
+	rest := term[i:]
+	op := ""
+	for _, candidate := range []string{"!=", "<=", ">=", ":", "=", "<", ">"} {
+		if strings.HasPrefix(rest, candidate) {
+			op = candidate
+			break
+		}
+	}
+	if op == "" {
+		return nil, fmt.Errorf("invalid operator in %q", term)
+	}
+	value := strings.TrimSpace(rest[len(op):])
+	if value == "" {
+		return nil, fmt.Errorf("%q needs a value", term)
+	}
+	p.fields[name] = true
+
+	negated := op == "!="
+	if op == "=" || op == "!=" {
+		op = ":"
+	}
+	if op != ":" && spec.kind != kindPriority && spec.kind != kindDate {
+		return nil, fmt.Errorf("%s does not support %s (use %s:value)", name, op, name)
+	}
+
+	// Comma-separated values match any of them
+	var alternatives []Node
+	for _, v := range strings.Split(value, ",") {
+		v = strings.TrimSpace(v)
+		if v == "" {
+			continue
+		}
+		pred, err := p.newPredicate(name, spec, op, v)
+		if err != nil {
+			return nil, err
+		}
+		alternatives = append(alternatives, pred)
+	}
+	var n Node
+	switch len(alternatives) {
+	case 0:
+		return nil, fmt.Errorf("%q needs a value", term)
+	case 1:
+		n = alternatives[0]
+	default:
+		if op != ":" {
+			return nil, fmt.Errorf("value lists only work with %s:a,b", name)
+		}
+		n = &orNode{children: alternatives}
+	}
+	if negated {
+		n = negate(n)
+	}
+	return n, nil
+}
+
+func (p *parser) newPredicate(name string, spec fieldSpec, op, value string) (*predicate, error) {
+	pred := &predicate{field: name, op: op, value: value}
+	switch spec.kind {
+	case kindText, kindContains, kindLabel:
+		pred.value = strings.ToLower(value)
+		pred.glob = strings.Contains(value, "*")
+	case kindDep:
+		// Issue IDs are matched exactly
+	case kindPriority:
+		n, err := strconv.Atoi(strings.TrimPrefix(strings.ToLower(value), "p"))
+		if err != nil || n < 0 || n > 4 {
+			return nil, fmt.Errorf("invalid priority %q (expected 0-4 or P0-P4)", value)
+		}
+		pred.number = n
+	case kindDate:
+		t, err := timeparsing.ParseRelativeTime(value, p.now)
+		if err != nil {
+			return nil, fmt.Errorf("invalid date %q for %s: %w", value, name, err)
+		}
+		pred.from = t
+		if op == ":" {
+			pred.from = time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+			pred.to = pred.from.AddDate(0, 0, 1)
+		}
+	case kindHas, kindIs:
+		pred.value = strings.ToLower(value)
+		valid := hasValues
+		if spec.kind == kindIs {
+			valid = isValues
+		}
+		if pred.value == "label" {
+			pred.value = "labels"
+		}
+		if !containsString(valid, pred.value) {
+			return nil, fmt.Errorf("unknown %s:%s (valid: %s)", name, value, strings.Join(valid, ", "))
+		}
+	}
+	return pred, nil
+}
+
+func negate(n Node) Node {
+	if inner, ok := n.(*notNode); ok {
+		return inner.child // NOT NOT x == x
+	}
+	return &notNode{child: n}
+}
+
+func isFieldName(s string) bool {
+	for _, r := range s {
+		if (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') && r != '-' && r != '_' {
+			return false
+		}
+	}
+	return true
+}
+
+func fieldNames() []string {
+	names := make([]string, 0, len(fieldSpecs))
+	for name := range fieldSpecs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// openStatus reports whether an issue in the given status still blocks its
+// dependents.
+func openStatus(status types.Status) bool {
+	return status != types.StatusClosed && status != types.StatusTombstone
+}
@@ -0,0 +1,264 @@
+package query
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+var testNow = time.Date(2025, 6, 15, 12, 0, 0, 0, time.UTC)
+
+func mustParse(t *testing.T, input string) *Query {
+	t.Helper()
+	q, err := parse(input, testNow)
+	if err != nil {
+		t.Fatalf("parse(%q) failed: %v", input, err)
+	}
+	return q
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"", "empty query"},
+		{"   ", "empty query"},
+		{"bogus:1", "unknown field"},
+		{"status<open", "does not support <"},
+		{"priority:9", "invalid priority"},
+		{"updated>someday", "invalid date"},
+		{"has:wings", "unknown has:wings"},
+		{"is:happy", "unknown is:happy"},
+		{"status:", "needs a value"},
+		{"(status:open", "unbalanced parentheses"},
+		{"status:open)", "unexpected"},
+		{"()", "empty parentheses"},
+		{"AND status:open", "AND must follow"},
+		{"status:open OR", "OR must be followed"},
+		{"NOT", "NOT must be followed"},
+		{`title:"open`, "unterminated quote"},
+		{"priority<1,2", "value lists"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			_, err := parse(tt.input, testNow)
+			if err == nil {
+				t.Fatalf("parse(%q) succeeded, want error containing %q", tt.input, tt.want)
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("parse(%q) error = %q, want it to contain %q", tt.input, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestSQL(t *testing.T) {
+	tests := []struct {
+		input    string
+		wantSQL  string
+		wantArgs []interface{}
+	}{
+		{
+			"status:open",
+			"LOWER(COALESCE(status, '')) = ?",
+			[]interface{}{"open"},
+		},
+		{
+			"status:open AND (priority<=1 OR label:security)",
+			"(LOWER(COALESCE(status, '')) = ? AND (priority <= ? OR id IN (SELECT issue_id FROM labels WHERE LOWER(label) = ?)))",
+			[]interface{}{"open", 1, "security"},
+		},
+		{
+			"NOT assignee:bot-*",
+			`NOT (LOWER(COALESCE(assignee, '')) LIKE ? ESCAPE '\')`,
+			[]interface{}{"bot-%"},
+		},
+		{
+			"type:bug,feature",
+			"(LOWER(COALESCE(issue_type, '')) = ? OR LOWER(COALESCE(issue_type, '')) = ?)",
+			[]interface{}{"bug", "feature"},
+		},
+		{
+			"updated>-7d",
+			"(updated_at IS NOT NULL AND datetime(updated_at) > ?)",
+			[]interface{}{"2025-06-08 12:00:00"},
+		},
+		{
+			"created:2025-06-01",
+			"(created_at IS NOT NULL AND datetime(created_at) >= ? AND datetime(created_at) < ?)",
+			[]interface{}{"2025-06-01 00:00:00", "2025-06-02 00:00:00"},
+		},
+		{
+			"blocked-by:bd-12",
+			"id IN (SELECT issue_id FROM dependencies WHERE type = ? AND depends_on_id = ?)",
+			[]interface{}{"blocks", "bd-12"},
+		},
+		{
+			"has:parent",
+			"id IN (SELECT issue_id FROM dependencies WHERE type = ?)",
+			[]interface{}{"parent-child"},
+		},
+		{
+			"title:100%",
+			`LOWER(COALESCE(title, '')) LIKE ? ESCAPE '\'`,
+			[]interface{}{`%100\%%`},
+		},
+		{
+			"p!=P2",
+			"NOT (priority = ?)",
+			[]interface{}{2},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			sql, args := mustParse(t, tt.input).SQL(SQLite)
+			if sql != tt.wantSQL {
+				t.Errorf("SQL = %s\nwant  %s", sql, tt.wantSQL)
+			}
+			if !reflect.DeepEqual(args, tt.wantArgs) {
+				t.Errorf("args = %v, want %v", args, tt.wantArgs)
+			}
+		})
+	}
+
+	if sql, _ := mustParse(t, "assignee:bot-*").SQL(MySQL); strings.Contains(sql, "ESCAPE") {
+		t.Errorf("MySQL SQL should rely on the default escape: %s", sql)
+	}
+}
+
+func TestReferences(t *testing.T) {
+	q := mustParse(t, "NOT status:closed login")
+	if !q.References("status") {
+		t.Error("expected query to reference status")
+	}
+	if q.References("priority") {
+		t.Error("did not expect query to reference priority")
+	}
+}
+
+type fakeResolver struct {
+	issues map[string]*types.Issue
+	labels map[string][]string
+	deps   []*types.Dependency
+}
+
+func (r *fakeResolver) Labels(issueID string) []string {
+	return r.labels[issueID]
+}
+
+func (r *fakeResolver) Dependencies(issueID string) []*types.Dependency {
+	var result []*types.Dependency
+	for _, d := range r.deps {
+		if d.IssueID == issueID {
+			result = append(result, d)
+		}
+	}
+	return result
+}
+
+func (r *fakeResolver) Dependents(issueID string) []*types.Dependency {
+	var result []*types.Dependency
+	for _, d := range r.deps {
+		if d.DependsOnID == issueID {
+			result = append(result, d)
+		}
+	}
+	return result
+}
+
+func (r *fakeResolver) Issue(id string) *types.Issue {
+	return r.issues[id]
+}
+
+func TestMatch(t *testing.T) {
+	due := testNow.Add(-24 * time.Hour)
+	issues := []*types.Issue{
+		{ID: "bd-1", Title: "Login timeout", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeBug,
+			Assignee: "alice", CreatedAt: testNow.AddDate(0, 0, -30), UpdatedAt: testNow.AddDate(0, 0, -1), DueAt: &due},
+		{ID: "bd-2", Title: "Epic", Status: types.StatusInProgress, Priority: 2, IssueType: types.TypeEpic,
+			Assignee: "bot-ci", CreatedAt: testNow.AddDate(0, 0, -30), UpdatedAt: testNow.AddDate(0, 0, -30)},
+		{ID: "bd-3", Title: "Closed blocker", Status: types.StatusClosed, Priority: 0, IssueType: types.TypeTask,
+			CreatedAt: testNow.AddDate(0, 0, -2), UpdatedAt: testNow.AddDate(0, 0, -2), Pinned: true},
+		{ID: "bd-4", Title: "Open blocker", Status: types.StatusOpen, Priority: 3, IssueType: types.TypeTask,
+			Description: "Affects LOGIN", CreatedAt: testNow, UpdatedAt: testNow},
+	}
+	r := &fakeResolver{
+		issues: map[string]*types.Issue{},
+		labels: map[string][]string{"bd-1": {"security"}, "bd-4": {"Frontend"}},
+		deps: []*types.Dependency{
+			{IssueID: "bd-1", DependsOnID: "bd-2", Type: types.DepParentChild},
+			{IssueID: "bd-1", DependsOnID: "bd-4", Type: types.DepBlocks},
+			{IssueID: "bd-2", DependsOnID: "bd-3", Type: types.DepBlocks},
+		},
+	}
+	for _, issue := range issues {
+		r.issues[issue.ID] = issue
+	}
+
+	tests := []struct {
+		input string
+		want  []string
+	}{
+		{"status:open AND (priority<=1 OR label:security) AND updated>-7d AND NOT assignee:bot-*", []string{"bd-1"}},
+		{"status:open,in_progress", []string{"bd-1", "bd-2", "bd-4"}},
+		{"status!=open", []string{"bd-2", "bd-3"}},
+		{"priority>=P2", []string{"bd-2", "bd-4"}},
+		{"label:front*", []string{"bd-4"}},
+		{"login", []string{"bd-1", "bd-4"}},
+		{`"login timeout"`, []string{"bd-1"}},
+		{"title:*blocker", []string{"bd-3", "bd-4"}},
+		{"created:2025-06-15", []string{"bd-4"}},
+		{"due<today", []string{"bd-1"}},
+		{"blocked-by:bd-4", []string{"bd-1"}},
+		{"blocks:bd-1", []string{"bd-4"}},
+		{"parent:bd-2", []string{"bd-1"}},
+		{"depends-on:bd-3", []string{"bd-2"}},
+		{"has:parent", []string{"bd-1"}},
+		{"has:children", []string{"bd-2"}},
+		{"has:labels", []string{"bd-1", "bd-4"}},
+		{"NOT has:assignee", []string{"bd-3", "bd-4"}},
+		{"is:blocked", []string{"bd-1"}},
+		{"is:overdue", []string{"bd-1"}},
+		{"is:pinned", []string{"bd-3"}},
+		{"NOT NOT is:pinned", []string{"bd-3"}},
+		{"type:task priority:0 OR type:epic", []string{"bd-2", "bd-3"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			q := mustParse(t, tt.input)
+			var got []string
+			for _, issue := range issues {
+				if q.Match(issue, r) {
+					got = append(got, issue.ID)
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Match(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGlobMatch(t *testing.T) {
+	tests := []struct {
+		pattern, s string
+		want       bool
+	}{
+		{"bot-*", "bot-ci", true},
+		{"bot-*", "robot-ci", false},
+		{"*ci", "bot-ci", true},
+		{"b*t*i", "bot-ci", true},
+		{"b*x*i", "bot-ci", false},
+		{"*", "", true},
+		{"exact", "exact", true},
+	}
+	for _, tt := range tests {
+		if got := globMatch(tt.pattern, tt.s); got != tt.want {
+			t.Errorf("globMatch(%q, %q) = %v, want %v", tt.pattern, tt.s, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,207 @@
+package query
+
+import (
+	"strings"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// Dialect describes the SQL differences between storage backends.
+type Dialect struct {
+	// LikeEscape is appended to LIKE comparisons so that a backslash escapes
+	// "%" and "_" in patterns.
+	LikeEscape string
+	// TimeFunc normalizes a timestamp column to a UTC "YYYY-MM-DD HH:MM:SS"
+	// value before comparing. Empty for native DATETIME columns.
+	TimeFunc string
+}
+
+// Dialects of the SQL backends.
+var (
+	// SQLite stores timestamps as RFC3339 text in the writer's own time
+	// zone, so they only compare correctly through datetime().
+	SQLite = Dialect{LikeEscape: ` ESCAPE '\'`, TimeFunc: "datetime"}
+	MySQL  = Dialect{} // Backslash is already the LIKE escape (dolt)
+)
+
+// SQL compiles the query to a WHERE clause over unqualified issues columns,
+// with its bind arguments.
+func (q *Query) SQL(d Dialect) (string, []interface{}) {
+	c := &sqlCompiler{dialect: d, now: q.now}
+	q.root.writeSQL(c)
+	return c.b.String(), c.args
+}
+
+type sqlCompiler struct {
+	b       strings.Builder
+	args    []interface{}
+	dialect Dialect
+	now     time.Time
+}
+
+// write appends SQL and its bind arguments.
+func (c *sqlCompiler) write(sql string, args ...interface{}) {
+	c.b.WriteString(sql)
+	c.args = append(c.args, args...)
+}
+
+// like appends a case-insensitive LIKE comparison of expr with pattern.
+func (c *sqlCompiler) like(expr, pattern string) {
+	c.write("LOWER("+expr+") LIKE ?"+c.dialect.LikeEscape, pattern)
+}
+
+// timeColumn returns column as a comparable UTC timestamp.
+func (c *sqlCompiler) timeColumn(column string) string {
+	if c.dialect.TimeFunc == "" {
+		return column
+	}
+	return c.dialect.TimeFunc + "(" + column + ")"
+}
+
+func sqlTime(t time.Time) string {
+	return t.UTC().Format("2006-01-02 15:04:05")
+}
+
+// likePattern converts a glob ("*" matches anything) to a LIKE pattern.
+func likePattern(glob string) string {
+	return strings.ReplaceAll(escapeLike(glob), "*", "%")
+}
+
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
+
+func (n *andNode) writeSQL(c *sqlCompiler) {
+	writeJoined(c, n.children, " AND ")
+}
+
+func (n *orNode) writeSQL(c *sqlCompiler) {
+	writeJoined(c, n.children, " OR ")
+}
+
+func writeJoined(c *sqlCompiler, children []Node, sep string) {
+	c.write("(")
+	for i, child := range children {
+		if i > 0 {
+			c.write(sep)
+		}
+		child.writeSQL(c)
+	}
+	c.write(")")
+}
+
+func (n *notNode) writeSQL(c *sqlCompiler) {
+	c.write("NOT (")
+	n.child.writeSQL(c)
+	c.write(")")
+}
+
+func (n *textNode) writeSQL(c *sqlCompiler) {
+	pattern := "%" + escapeLike(n.text) + "%"
+	c.write("(")
+	c.like("title", pattern)
+	c.write(" OR ")
+	c.like("COALESCE(description, '')", pattern)
+	c.write(" OR ")
+	c.like("id", pattern)
+	c.write(")")
+}
+
+func (p *predicate) writeSQL(c *sqlCompiler) {
+	spec := fieldSpecs[p.field]
+	switch spec.kind {
+	case kindText:
+		column := "COALESCE(" + spec.column + ", '')"
+		if p.glob {
+			c.like(column, likePattern(p.value))
+		} else {
+			c.write("LOWER("+column+") = ?", p.value)
+		}
+	case kindContains:
+		pattern := "%" + escapeLike(p.value) + "%"
+		if p.glob {
+			pattern = likePattern(p.value)
+		}
+		c.like("COALESCE("+spec.column+", '')", pattern)
+	case kindPriority:
+		c.write(spec.column+" "+sqlOp(p.op)+" ?", p.number)
+	case kindDate:
+		col := c.timeColumn(spec.column)
+		if p.op == ":" {
+			c.write("("+spec.column+" IS NOT NULL AND "+col+" >= ? AND "+col+" < ?)", sqlTime(p.from), sqlTime(p.to))
+		} else {
+			c.write("("+spec.column+" IS NOT NULL AND "+col+" "+p.op+" ?)", sqlTime(p.from))
+		}
+	case kindLabel:
+		c.write("id IN (SELECT issue_id FROM labels WHERE ")
+		if p.glob {
+			c.like("label", likePattern(p.value))
+		} else {
+			c.write("LOWER(label) = ?", p.value)
+		}
+		c.write(")")
+	case kindDep:
+		switch p.field {
+		case "blocked-by":
+			c.write("id IN (SELECT issue_id FROM dependencies WHERE type = ? AND depends_on_id = ?)", string(types.DepBlocks), p.value)
+		case "blocks":
+			c.write("id IN (SELECT depends_on_id FROM dependencies WHERE type = ? AND issue_id = ?)", string(types.DepBlocks), p.value)
+		case "parent":
+			c.write("id IN (SELECT issue_id FROM dependencies WHERE type = ? AND depends_on_id = ?)", string(types.DepParentChild), p.value)
+		case "depends-on":
+			c.write("id IN (SELECT issue_id FROM dependencies WHERE depends_on_id = ?)", p.value)
+		}
+	case kindHas:
+		p.writeHasSQL(c)
+	case kindIs:
+		p.writeIsSQL(c)
+	}
+}
+
+func (p *predicate) writeHasSQL(c *sqlCompiler) {
+	switch p.value {
+	case "assignee", "description", "notes", "recurrence":
+		c.write("COALESCE(" + p.value + ", '') != ''")
+	case "external-ref":
+		c.write("COALESCE(external_ref, '') != ''")
+	case "due":
+		c.write("due_at IS NOT NULL")
+	case "defer":
+		c.write("defer_until IS NOT NULL")
+	case "estimate":
+		c.write("estimated_minutes IS NOT NULL")
+	case "labels":
+		c.write("id IN (SELECT issue_id FROM labels)")
+	case "parent":
+		c.write("id IN (SELECT issue_id FROM dependencies WHERE type = ?)", string(types.DepParentChild))
+	case "children":
+		c.write("id IN (SELECT depends_on_id FROM dependencies WHERE type = ?)", string(types.DepParentChild))
+	case "blockers":
+		c.write("id IN (SELECT issue_id FROM dependencies WHERE type = ?)", string(types.DepBlocks))
+	}
+}
+
+func (p *predicate) writeIsSQL(c *sqlCompiler) {
+	switch p.value {
+	case "pinned":
+		c.write("COALESCE(pinned, 0) = 1")
+	case "template":
+		c.write("COALESCE(is_template, 0) = 1")
+	case "ephemeral":
+		c.write("COALESCE(ephemeral, 0) = 1")
+	case "overdue":
+		c.write("(due_at IS NOT NULL AND "+c.timeColumn("due_at")+" < ? AND status != ?)", sqlTime(c.now), string(types.StatusClosed))
+	case "blocked":
+		c.write(`id IN (SELECT d.issue_id FROM dependencies d JOIN issues blocker ON blocker.id = d.depends_on_id
+			WHERE d.type = ? AND blocker.status NOT IN (?, ?))`,
+			string(types.DepBlocks), string(types.StatusClosed), string(types.StatusTombstone))
+	}
+}
+
+func sqlOp(op string) string {
+	if op == ":" {
+		return "="
+	}
+	return op
+}
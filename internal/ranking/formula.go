@@ -0,0 +1,179 @@
+package ranking
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// Factor is one input to a ranking formula. Larger values rank higher.
+type Factor string
+
+// Ranking factors
+const (
+	// FactorPriority is 4 for P0 down to 0 for P4
+	FactorPriority Factor = "priority"
+
+	// FactorUnblock counts the open issues that (transitively) wait on the issue
+	FactorUnblock Factor = "unblock"
+
+	// FactorCritical is the length of the longest chain of open issues
+	// waiting on the issue
+	FactorCritical Factor = "critical"
+
+	// FactorDue is due-date urgency: 0 without a due date, 0.5 a week out,
+	// 1 when due, rising to 2 two weeks overdue
+	FactorDue Factor = "due"
+
+	// FactorWSJF is weighted shortest job first: cost of delay divided by the
+	// estimate in hours (DefaultEstimateMinutes when unset)
+	FactorWSJF Factor = "wsjf"
+
+	// FactorAge rises from 0 towards 1 as the issue waits (half-way after
+	// AgeHalfLifeDays)
+	FactorAge Factor = "age"
+)
+
+// Factors lists the factors in the order score breakdowns show them.
+var Factors = []Factor{FactorPriority, FactorUnblock, FactorCritical, FactorDue, FactorWSJF, FactorAge}
+
+// ScoreConfigKey is the config key for the SortPolicyScore formula.
+const ScoreConfigKey = "ready.score"
+
+// DefaultScoreFormula is used by SortPolicyScore when ready.score is unset.
+const DefaultScoreFormula = "priority + 0.5*unblock + 0.5*critical + 2*due + age"
+
+// Term is one weighted factor of a Formula.
+type Term struct {
+	Factor Factor
+	Weight float64
+}
+
+// Formula is a weighted sum of factors.
+type Formula []Term
+
+// String renders the formula in the syntax ParseFormula accepts.
+func (f Formula) String() string {
+	var b strings.Builder
+	for i, t := range f {
+		w := t.Weight
+		switch {
+		case i == 0 && w < 0:
+			b.WriteString("-")
+			w = -w
+		case i > 0 && w < 0:
+			b.WriteString(" - ")
+			w = -w
+		case i > 0:
+			b.WriteString(" + ")
+		}
+		if w != 1 {
+			b.WriteString(strconv.FormatFloat(w, 'g', -1, 64))
+			b.WriteString("*")
+		}
+		b.WriteString(string(t.Factor))
+	}
+	return b.String()
+}
+
+// ParseFormula parses a weighted sum such as "3*unblock + 2*due - 0.5*age".
+// Each term is a factor, optionally multiplied by a number on either side.
+func ParseFormula(input string) (Formula, error) {
+	s := strings.ReplaceAll(input, " ", "")
+	if s == "" {
+		return nil, fmt.Errorf("empty formula")
+	}
+	// Split into signed terms
+	var terms []string
+	start := 0
+	for i := 1; i < len(s); i++ {
+		if (s[i] == '+' || s[i] == '-') && s[i-1] != '*' {
+			terms = append(terms, s[start:i])
+			start = i
+		}
+	}
+	terms = append(terms, s[start:])
+
+	var f Formula
+	for _, term := range terms {
+		t, err := parseTerm(term)
+		if err != nil {
+			return nil, fmt.Errorf("invalid formula %q: %w", input, err)
+		}
+		f = append(f, t)
+	}
+	return f, nil
+}
+
+func parseTerm(term string) (Term, error) {
+	sign := 1.0
+	switch {
+	case strings.HasPrefix(term, "+"):
+		term = term[1:]
+	case strings.HasPrefix(term, "-"):
+		sign, term = -1, term[1:]
+	}
+	if term == "" {
+		return Term{}, fmt.Errorf("missing term")
+	}
+
+	t := Term{Weight: sign}
+	for _, part := range strings.Split(term, "*") {
+		if w, err := strconv.ParseFloat(part, 64); err == nil {
+			if math.IsNaN(w) || math.IsInf(w, 0) {
+				return Term{}, fmt.Errorf("invalid weight %q", part)
+			}
+			t.Weight *= w
+			continue
+		}
+		if t.Factor != "" {
+			return Term{}, fmt.Errorf("term %q multiplies two factors", term)
+		}
+		if !isFactor(Factor(part)) {
+			return Term{}, fmt.Errorf("unknown factor %q (valid: %s)", part, factorNames())
+		}
+		t.Factor = Factor(part)
+	}
+	if t.Factor == "" {
+		return Term{}, fmt.Errorf("term %q has no factor", term)
+	}
+	return t, nil
+}
+
+func isFactor(f Factor) bool {
+	for _, known := range Factors {
+		if f == known {
+			return true
+		}
+	}
+	return false
+}
+
+func factorNames() string {
+	names := make([]string, len(Factors))
+	for i, f := range Factors {
+		names[i] = string(f)
+	}
+	return strings.Join(names, ", ")
+}
+
+// PolicyFormula returns the formula of a built-in scored policy. The
+// score policy has no built-in formula (see ScoreConfigKey).
+func PolicyFormula(policy types.SortPolicy) (Formula, bool) {
+	switch policy {
+	case types.SortPolicyUnblock:
+		return Formula{{FactorUnblock, 1}}, true
+	case types.SortPolicyCriticalPath:
+		return Formula{{FactorCritical, 1}}, true
+	case types.SortPolicyDue:
+		return Formula{{FactorDue, 1}}, true
+	case types.SortPolicyWSJF:
+		return Formula{{FactorWSJF, 1}}, true
+	case types.SortPolicyAge:
+		return Formula{{FactorAge, 1}}, true
+	}
+	return nil, false
+}
@@ -0,0 +1,373 @@
+// Package ranking scores ready work for bd ready --sort unblock, critical-path,
+// due, wsjf, age and score.
+//
+// Each policy is a weighted formula over a few factors computed per issue:
+// its priority, how much open work waits on it in the dependency graph, its
+// due date, its estimate and its age. The built-in policies use a single
+// factor; the score policy reads a user formula from the ready.score config:
+//
+//	bd config set ready.score "2*unblock + 3*due + priority - 0.5*wsjf"
+//
+// Every ranked issue carries a Score with the factor values and the weighted
+// contributions, so an agent can explain why it picked the issue.
+package ranking
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// DefaultEstimateMinutes is the job size WSJF assumes for unestimated issues.
+const DefaultEstimateMinutes = 120
+
+// AgeHalfLifeDays is the age at which the age factor reaches 0.5.
+const AgeHalfLifeDays = 14
+
+// minEstimateMinutes keeps tiny estimates from dominating WSJF.
+const minEstimateMinutes = 15
+
+// Source is the part of storage.Storage that ranking needs.
+type Source interface {
+	GetReadyWork(ctx context.Context, filter types.WorkFilter) ([]*types.Issue, error)
+	GetAllDependencyRecords(ctx context.Context) (map[string][]*types.Dependency, error)
+	SearchIssues(ctx context.Context, query string, filter types.IssueFilter) ([]*types.Issue, error)
+	GetConfig(ctx context.Context, key string) (string, error)
+}
+
+// ScoredIssue is a ready issue with its score. Score is nil for unscored
+// sort policies, so the JSON matches a plain issue.
+type ScoredIssue struct {
+	*types.Issue
+	Score *Score `json:"score,omitempty"`
+}
+
+// Score explains an issue's rank.
+type Score struct {
+	Policy     types.SortPolicy `json:"policy"`
+	Formula    string           `json:"formula"`
+	Total      float64          `json:"total"`
+	Components []Component      `json:"components"` // One per formula term
+	Inputs     Inputs           `json:"inputs"`
+}
+
+// Component is one weighted term of the score.
+type Component struct {
+	Factor       Factor  `json:"factor"`
+	Value        float64 `json:"value"`
+	Weight       float64 `json:"weight"`
+	Contribution float64 `json:"contribution"`
+}
+
+// Inputs are the raw facts the factors were computed from.
+type Inputs struct {
+	Priority        int      `json:"priority"`
+	Unblocks        int      `json:"unblocks"`      // Open issues waiting on this one, transitively
+	CriticalPath    int      `json:"critical_path"` // Longest chain of open issues waiting on this one
+	DueInDays       *float64 `json:"due_in_days,omitempty"`
+	EstimateMinutes int      `json:"estimate_minutes"`
+	EstimateDefault bool     `json:"estimate_default,omitempty"` // No estimate, DefaultEstimateMinutes used
+	AgeDays         float64  `json:"age_days"`
+}
+
+// ReadyWork returns the ready work for filter. For scored sort policies it
+// ranks every candidate and then applies the limit; other policies are passed
+// straight to the store.
+func ReadyWork(ctx context.Context, s Source, filter types.WorkFilter) ([]*ScoredIssue, error) {
+	if !filter.SortPolicy.IsScored() {
+		issues, err := s.GetReadyWork(ctx, filter)
+		if err != nil {
+			return nil, err
+		}
+		return Unscored(issues), nil
+	}
+
+	formula, err := PolicyFormulaFromConfig(ctx, s, filter.SortPolicy)
+	if err != nil {
+		return nil, err
+	}
+	candidates := filter
+	candidates.SortPolicy = types.SortPolicyPriority
+	candidates.Limit = 0
+	issues, err := s.GetReadyWork(ctx, candidates)
+	if err != nil {
+		return nil, err
+	}
+	g, err := LoadGraph(ctx, s)
+	if err != nil {
+		return nil, err
+	}
+
+	ranked := Rank(issues, g, filter.SortPolicy, formula, time.Now())
+	if filter.Limit > 0 && len(ranked) > filter.Limit {
+		ranked = ranked[:filter.Limit]
+	}
+	return ranked, nil
+}
+
+// PolicyFormulaFromConfig returns the formula for a scored policy, reading
+// ready.score for SortPolicyScore.
+func PolicyFormulaFromConfig(ctx context.Context, s Source, policy types.SortPolicy) (Formula, error) {
+	if f, ok := PolicyFormula(policy); ok {
+		return f, nil
+	}
+	if policy != types.SortPolicyScore {
+		return nil, fmt.Errorf("sort policy %q is not scored", policy)
+	}
+	value, err := s.GetConfig(ctx, ScoreConfigKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", ScoreConfigKey, err)
+	}
+	if value == "" {
+		value = DefaultScoreFormula
+	}
+	f, err := ParseFormula(value)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", ScoreConfigKey, err)
+	}
+	return f, nil
+}
+
+// Unscored wraps issues without scores.
+func Unscored(issues []*types.Issue) []*ScoredIssue {
+	result := make([]*ScoredIssue, len(issues))
+	for i, issue := range issues {
+		result[i] = &ScoredIssue{Issue: issue}
+	}
+	return result
+}
+
+// Issues unwraps scored issues.
+func Issues(scored []*ScoredIssue) []*types.Issue {
+	result := make([]*types.Issue, len(scored))
+	for i, s := range scored {
+		result[i] = s.Issue
+	}
+	return result
+}
+
+// Graph is the open part of the dependency graph, as waiters: for each
+// issue, the open issues that wait on it. A Graph caches results and is not
+// safe for concurrent use.
+type Graph struct {
+	waiters map[string][]string
+	chains  map[string][]string // waiters without the edges that close a cycle
+	depth   map[string]int      // CriticalPath results, shared by every call
+}
+
+// waitingTypes are the dependency types where the dependent cannot start
+// until the dependency closes. Parent-child is excluded: closing a child
+// doesn't let its parent start.
+var waitingTypes = map[types.DependencyType]bool{
+	types.DepBlocks:            true,
+	types.DepConditionalBlocks: true,
+	types.DepWaitsFor:          true,
+}
+
+// LoadGraph reads the dependency graph between open issues.
+func LoadGraph(ctx context.Context, s Source) (*Graph, error) {
+	deps, err := s.GetAllDependencyRecords(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load dependencies: %w", err)
+	}
+	open, err := s.SearchIssues(ctx, "", types.IssueFilter{ExcludeStatus: []types.Status{types.StatusClosed}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load open issues: %w", err)
+	}
+	openIDs := make(map[string]bool, len(open))
+	for _, issue := range open {
+		openIDs[issue.ID] = true
+	}
+	return NewGraph(deps, openIDs), nil
+}
+
+// NewGraph builds a Graph from dependency records (keyed by dependent issue)
+// and the set of open issue IDs.
+func NewGraph(deps map[string][]*types.Dependency, open map[string]bool) *Graph {
+	g := &Graph{waiters: make(map[string][]string)}
+	for issueID, list := range deps {
+		if !open[issueID] {
+			continue
+		}
+		for _, dep := range list {
+			if waitingTypes[dep.Type] {
+				g.waiters[dep.DependsOnID] = append(g.waiters[dep.DependsOnID], issueID)
+			}
+		}
+	}
+	for id := range g.waiters {
+		sort.Strings(g.waiters[id])
+	}
+	g.breakCycles()
+	return g
+}
+
+// breakCycles fills chains with the waiters minus every edge that closes a
+// cycle. The edges are found by a depth-first walk in ID order, so which
+// edge of a cycle is dropped doesn't depend on which issue is ranked first.
+func (g *Graph) breakCycles() {
+	g.chains = make(map[string][]string, len(g.waiters))
+	g.depth = make(map[string]int)
+	ids := make([]string, 0, len(g.waiters))
+	for id := range g.waiters {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	const (
+		visiting = 1
+		done     = 2
+	)
+	state := make(map[string]int)
+	var visit func(id string)
+	visit = func(id string) {
+		state[id] = visiting
+		for _, w := range g.waiters[id] {
+			switch state[w] {
+			case visiting:
+				continue // Closes a cycle
+			case 0:
+				visit(w)
+			}
+			g.chains[id] = append(g.chains[id], w)
+		}
+		state[id] = done
+	}
+	for _, id := range ids {
+		if state[id] == 0 {
+			visit(id)
+		}
+	}
+}
+
+// Unblocks counts the open issues that wait on id, directly or transitively.
+func (g *Graph) Unblocks(id string) int {
+	seen := map[string]bool{id: true}
+	queue := []string{id}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, w := range g.waiters[current] {
+			if !seen[w] {
+				seen[w] = true
+				queue = append(queue, w)
+			}
+		}
+	}
+	return len(seen) - 1
+}
+
+// CriticalPath returns the length of the longest chain of open issues
+// waiting on id. Cycles are broken when the graph is built (see
+// breakCycles), so the chains form a DAG and results are cached for the
+// lifetime of the graph.
+func (g *Graph) CriticalPath(id string) int {
+	if n, ok := g.depth[id]; ok {
+		return n
+	}
+	best := 0
+	for _, w := range g.chains[id] {
+		if n := 1 + g.CriticalPath(w); n > best {
+			best = n
+		}
+	}
+	g.depth[id] = best
+	return best
+}
+
+// Rank scores issues with formula and sorts them best first. Ties go to
+// the higher priority, then the older issue.
+func Rank(issues []*types.Issue, g *Graph, policy types.SortPolicy, formula Formula, now time.Time) []*ScoredIssue {
+	ranked := make([]*ScoredIssue, len(issues))
+	for i, issue := range issues {
+		ranked[i] = &ScoredIssue{Issue: issue, Score: score(issue, g, policy, formula, now)}
+	}
+	sort.SliceStable(ranked, func(i, j int) bool {
+		a, b := ranked[i], ranked[j]
+		if a.Score.Total != b.Score.Total {
+			return a.Score.Total > b.Score.Total
+		}
+		if a.Priority != b.Priority {
+			return a.Priority < b.Priority
+		}
+		if !a.CreatedAt.Equal(b.CreatedAt) {
+			return a.CreatedAt.Before(b.CreatedAt)
+		}
+		return a.ID < b.ID
+	})
+	return ranked
+}
+
+func score(issue *types.Issue, g *Graph, policy types.SortPolicy, formula Formula, now time.Time) *Score {
+	in := Inputs{
+		Priority:     issue.Priority,
+		Unblocks:     g.Unblocks(issue.ID),
+		CriticalPath: g.CriticalPath(issue.ID),
+		AgeDays:      round(now.Sub(issue.CreatedAt).Hours() / 24),
+	}
+	if issue.DueAt != nil {
+		days := round(issue.DueAt.Sub(now).Hours() / 24)
+		in.DueInDays = &days
+	}
+	if issue.EstimatedMinutes != nil && *issue.EstimatedMinutes > 0 {
+		in.EstimateMinutes = *issue.EstimatedMinutes
+	} else {
+		in.EstimateMinutes = DefaultEstimateMinutes
+		in.EstimateDefault = true
+	}
+
+	values := factorValues(in)
+	s := &Score{Policy: policy, Formula: formula.String(), Inputs: in}
+	for _, t := range formula {
+		c := Component{Factor: t.Factor, Value: values[t.Factor], Weight: t.Weight}
+		c.Contribution = round(c.Value * c.Weight)
+		s.Components = append(s.Components, c)
+		s.Total += c.Value * c.Weight
+	}
+	s.Total = round(s.Total)
+	return s
+}
+
+// factorValues computes every factor from the inputs.
+func factorValues(in Inputs) map[Factor]float64 {
+	priority := float64(4 - in.Priority)
+	if priority < 0 {
+		priority = 0
+	}
+
+	due := 0.0
+	if in.DueInDays != nil {
+		if days := *in.DueInDays; days >= 0 {
+			due = 7 / (7 + days)
+		} else {
+			due = 1 + math.Min(-days, 14)/14
+		}
+	}
+
+	// Cost of delay: business value + time criticality + work it enables
+	costOfDelay := (priority + 1) + 4*due + float64(in.Unblocks)
+	hours := float64(max(in.EstimateMinutes, minEstimateMinutes)) / 60
+
+	age := 0.0
+	if in.AgeDays > 0 {
+		age = 1 - math.Pow(2, -in.AgeDays/AgeHalfLifeDays)
+	}
+
+	return map[Factor]float64{
+		FactorPriority: priority,
+		FactorUnblock:  float64(in.Unblocks),
+		FactorCritical: float64(in.CriticalPath),
+		FactorDue:      round(due),
+		FactorWSJF:     round(costOfDelay / hours),
+		FactorAge:      round(age),
+	}
+}
+
+// round keeps scores readable in JSON.
+func round(v float64) float64 {
+	return math.Round(v*1000) / 1000
+}
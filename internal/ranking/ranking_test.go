@@ -0,0 +1,256 @@
+package ranking
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage/memory"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+var testNow = time.Date(2025, 6, 15, 12, 0, 0, 0, time.UTC)
+
+func TestParseFormula(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"unblock", "unblock"},
+		{"3*unblock + 2*due - 0.5*age", "3*unblock + 2*due - 0.5*age"},
+		{"due*2+priority", "2*due + priority"},
+		{"-age + wsjf", "-age + wsjf"},
+		{"2 * -1 * critical", "-2*critical"},
+	}
+	for _, tt := range tests {
+		f, err := ParseFormula(tt.input)
+		if err != nil {
+			t.Errorf("ParseFormula(%q) failed: %v", tt.input, err)
+			continue
+		}
+		if got := f.String(); got != tt.want {
+			t.Errorf("ParseFormula(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+
+	for _, bad := range []string{"", "speed", "2", "unblock*due", "unblock +", "nan*due"} {
+		if _, err := ParseFormula(bad); err == nil {
+			t.Errorf("ParseFormula(%q) succeeded, want error", bad)
+		}
+	}
+}
+
+func TestGraph(t *testing.T) {
+	// a <- b <- c <- d, a <- e, and a cycle x <-> y
+	deps := map[string][]*types.Dependency{
+		"b": {{IssueID: "b", DependsOnID: "a", Type: types.DepBlocks}},
+		"c": {{IssueID: "c", DependsOnID: "b", Type: types.DepConditionalBlocks}},
+		"d": {{IssueID: "d", DependsOnID: "c", Type: types.DepWaitsFor}},
+		"e": {
+			{IssueID: "e", DependsOnID: "a", Type: types.DepBlocks},
+			{IssueID: "e", DependsOnID: "b", Type: types.DepParentChild}, // Not waiting
+		},
+		"f": {{IssueID: "f", DependsOnID: "a", Type: types.DepBlocks}}, // Closed
+		"x": {{IssueID: "x", DependsOnID: "y", Type: types.DepBlocks}},
+		"y": {{IssueID: "y", DependsOnID: "x", Type: types.DepBlocks}},
+	}
+	open := map[string]bool{"a": true, "b": true, "c": true, "d": true, "e": true, "x": true, "y": true}
+	g := NewGraph(deps, open)
+
+	tests := []struct {
+		id               string
+		unblocks, length int
+	}{
+		{"a", 4, 3},
+		{"b", 2, 2},
+		{"d", 0, 0},
+		{"x", 1, 1},
+		{"y", 1, 0}, // The walk starts at x, so x waiting on y is the edge dropped
+	}
+	for _, tt := range tests {
+		if got := g.Unblocks(tt.id); got != tt.unblocks {
+			t.Errorf("Unblocks(%s) = %d, want %d", tt.id, got, tt.unblocks)
+		}
+		if got := g.CriticalPath(tt.id); got != tt.length {
+			t.Errorf("CriticalPath(%s) = %d, want %d", tt.id, got, tt.length)
+		}
+	}
+}
+
+func TestCriticalPathCycleOrder(t *testing.T) {
+	// A cycle a <- b <- c <- a with a tail d waiting on c
+	deps := map[string][]*types.Dependency{
+		"a": {{IssueID: "a", DependsOnID: "c", Type: types.DepBlocks}},
+		"b": {{IssueID: "b", DependsOnID: "a", Type: types.DepBlocks}},
+		"c": {{IssueID: "c", DependsOnID: "b", Type: types.DepBlocks}},
+		"d": {{IssueID: "d", DependsOnID: "c", Type: types.DepBlocks}},
+	}
+	open := map[string]bool{"a": true, "b": true, "c": true, "d": true}
+	want := map[string]int{"a": 3, "b": 2, "c": 1, "d": 0}
+
+	// Results don't depend on which issue is asked about first
+	for _, order := range [][]string{{"a", "b", "c", "d"}, {"d", "c", "b", "a"}, {"c", "a", "d", "b"}} {
+		g := NewGraph(deps, open)
+		for _, id := range order {
+			if got := g.CriticalPath(id); got != want[id] {
+				t.Errorf("order %v: CriticalPath(%s) = %d, want %d", order, id, got, want[id])
+			}
+		}
+	}
+}
+
+func TestRank(t *testing.T) {
+	due := func(days float64) *time.Time {
+		d := testNow.Add(time.Duration(days * 24 * float64(time.Hour)))
+		return &d
+	}
+	estimate := func(minutes int) *int { return &minutes }
+	created := testNow.AddDate(0, 0, -1)
+
+	// urgent is P0 but unblocks nothing; enabler is P2 and unblocks ten issues
+	issues := []*types.Issue{
+		{ID: "urgent", Priority: 0, CreatedAt: created, EstimatedMinutes: estimate(480)},
+		{ID: "enabler", Priority: 2, CreatedAt: created, EstimatedMinutes: estimate(60)},
+		{ID: "overdue", Priority: 3, CreatedAt: created, DueAt: due(-3)},
+		{ID: "stale", Priority: 3, CreatedAt: testNow.AddDate(0, 0, -60)},
+	}
+	deps := map[string][]*types.Dependency{}
+	open := map[string]bool{}
+	for i := 0; i < 10; i++ {
+		id := fmt.Sprintf("waiter-%d", i)
+		deps[id] = []*types.Dependency{{IssueID: id, DependsOnID: "enabler", Type: types.DepBlocks}}
+		open[id] = true
+	}
+	g := NewGraph(deps, open)
+
+	tests := []struct {
+		policy types.SortPolicy
+		first  string
+	}{
+		{types.SortPolicyUnblock, "enabler"},
+		{types.SortPolicyCriticalPath, "enabler"},
+		{types.SortPolicyDue, "overdue"},
+		{types.SortPolicyWSJF, "enabler"},
+		{types.SortPolicyAge, "stale"},
+	}
+	for _, tt := range tests {
+		formula, _ := PolicyFormula(tt.policy)
+		ranked := Rank(issues, g, tt.policy, formula, testNow)
+		if ranked[0].ID != tt.first {
+			t.Errorf("%s ranked %s first, want %s", tt.policy, ranked[0].ID, tt.first)
+		}
+	}
+
+	// Ties fall back to priority order
+	formula, _ := PolicyFormula(types.SortPolicyUnblock)
+	ranked := Rank(issues, g, types.SortPolicyUnblock, formula, testNow)
+	if ranked[1].ID != "urgent" {
+		t.Errorf("second by unblock = %s, want urgent (P0 breaks the tie)", ranked[1].ID)
+	}
+
+	// The breakdown explains the total
+	formula, _ = ParseFormula("2*unblock + priority")
+	ranked = Rank(issues, g, types.SortPolicyScore, formula, testNow)
+	s := ranked[0].Score
+	if ranked[0].ID != "enabler" || s.Total != 22 || len(s.Components) != 2 ||
+		s.Components[0].Contribution != 20 || s.Components[1].Value != 2 {
+		t.Errorf("score breakdown = %+v", s)
+	}
+	if s.Inputs.Unblocks != 10 || s.Inputs.EstimateMinutes != 60 || s.Inputs.EstimateDefault {
+		t.Errorf("score inputs = %+v", s.Inputs)
+	}
+	if in := ranked[len(ranked)-1].Score.Inputs; !in.EstimateDefault || in.EstimateMinutes != DefaultEstimateMinutes {
+		t.Errorf("unestimated issue inputs = %+v", in)
+	}
+}
+
+func TestFactorValues(t *testing.T) {
+	days := func(d float64) *float64 { return &d }
+	tests := []struct {
+		name   string
+		in     Inputs
+		factor Factor
+		want   float64
+	}{
+		{"no due date", Inputs{}, FactorDue, 0},
+		{"due in a week", Inputs{DueInDays: days(7)}, FactorDue, 0.5},
+		{"due now", Inputs{DueInDays: days(0)}, FactorDue, 1},
+		{"a week overdue", Inputs{DueInDays: days(-7)}, FactorDue, 1.5},
+		{"a month overdue", Inputs{DueInDays: days(-30)}, FactorDue, 2},
+		{"P0", Inputs{Priority: 0}, FactorPriority, 4},
+		{"age half-life", Inputs{AgeDays: AgeHalfLifeDays}, FactorAge, 0.5},
+		{"wsjf", Inputs{Priority: 2, Unblocks: 3, EstimateMinutes: 120}, FactorWSJF, 3},
+	}
+	for _, tt := range tests {
+		if got := factorValues(tt.in)[tt.factor]; got != tt.want {
+			t.Errorf("%s: %s = %v, want %v", tt.name, tt.factor, got, tt.want)
+		}
+	}
+}
+
+func TestReadyWork(t *testing.T) {
+	ctx := context.Background()
+	store := memory.New("")
+	create := func(title string, priority int) *types.Issue {
+		t.Helper()
+		issue := &types.Issue{Title: title, Status: types.StatusOpen, Priority: priority, IssueType: types.TypeTask}
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+		return issue
+	}
+	if err := store.SetConfig(ctx, "issue_prefix", "bd"); err != nil {
+		t.Fatal(err)
+	}
+	top := create("Top priority", 0)
+	enabler := create("Enabler", 3)
+	for i := 0; i < 3; i++ {
+		waiter := create(fmt.Sprintf("Waiter %d", i), 1)
+		if err := store.AddDependency(ctx, &types.Dependency{IssueID: waiter.ID, DependsOnID: enabler.ID, Type: types.DepBlocks}, "test"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ranked, err := ReadyWork(ctx, store, types.WorkFilter{SortPolicy: types.SortPolicyUnblock, Limit: 1})
+	if err != nil {
+		t.Fatalf("ReadyWork failed: %v", err)
+	}
+	if len(ranked) != 1 || ranked[0].ID != enabler.ID || ranked[0].Score.Inputs.Unblocks != 3 {
+		t.Fatalf("ReadyWork(unblock) = %+v, want only %s unblocking 3", ranked, enabler.ID)
+	}
+
+	// The score policy reads its formula from config
+	if err := store.SetConfig(ctx, ScoreConfigKey, "priority"); err != nil {
+		t.Fatal(err)
+	}
+	ranked, err = ReadyWork(ctx, store, types.WorkFilter{SortPolicy: types.SortPolicyScore})
+	if err != nil {
+		t.Fatalf("ReadyWork failed: %v", err)
+	}
+	if ranked[0].ID != top.ID || ranked[0].Score.Formula != "priority" {
+		t.Errorf("ReadyWork(score) ranked %s first with %q", ranked[0].ID, ranked[0].Score.Formula)
+	}
+
+	if err := store.SetConfig(ctx, ScoreConfigKey, "luck"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ReadyWork(ctx, store, types.WorkFilter{SortPolicy: types.SortPolicyScore}); err == nil || !strings.Contains(err.Error(), ScoreConfigKey) {
+		t.Errorf("expected a %s error, got %v", ScoreConfigKey, err)
+	}
+
+	// Unscored policies keep the plain issue JSON
+	plain, err := ReadyWork(ctx, store, types.WorkFilter{SortPolicy: types.SortPolicyPriority})
+	if err != nil {
+		t.Fatalf("ReadyWork failed: %v", err)
+	}
+	data, err := json.Marshal(plain[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), `"score"`) || !strings.Contains(string(data), `"id":"`+top.ID+`"`) {
+		t.Errorf("unscored JSON = %s", data)
+	}
+}
@@ -0,0 +1,183 @@
+// Package recurrence spawns the next occurrence of recurring issues.
+//
+// An issue becomes recurring when its Recurrence field holds a rule that
+// timeparsing.ParseRecurrence accepts ("every monday 09:00", "every 2 weeks",
+// "0 9 * * 1-5"). When the issue is closed, Spawn creates the next
+// occurrence:
+//
+//   - Content, priority, type, assignee, estimate and labels are copied.
+//   - Outgoing dependencies are copied, so the occurrence keeps its parent
+//     epic and blockers.
+//   - The occurrence is deferred until the rule's next time and linked back
+//     to the closed issue with a caused-by edge.
+//   - The rule moves to the occurrence, so each closed issue spawns once.
+//
+// The daemon calls Spawn when it closes an issue and SpawnDue periodically,
+// which also covers issues closed in other clones and imported via sync.
+package recurrence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/timeparsing"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// Spawn creates the next occurrence of the closed recurring issue id.
+// It returns nil if the issue is not closed or has no recurrence rule, or if
+// another process (a close handler, a daemon scan) spawned it first.
+func Spawn(ctx context.Context, store storage.Storage, id, actor string, now time.Time) (*types.Issue, error) {
+	closed, err := store.GetIssue(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get issue %s: %w", id, err)
+	}
+	if closed == nil || closed.Status != types.StatusClosed || closed.Recurrence == "" {
+		return nil, nil
+	}
+
+	rule, err := timeparsing.ParseRecurrence(closed.Recurrence)
+	if err != nil {
+		return nil, fmt.Errorf("issue %s: %w", id, err)
+	}
+	next := rule.Next(occurrenceTime(closed), now)
+
+	labels, err := store.GetLabels(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get labels of %s: %w", id, err)
+	}
+	deps, err := store.GetDependencyRecords(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get dependencies of %s: %w", id, err)
+	}
+
+	// Claim the rule first: only the caller that clears it spawns, however
+	// many processes race here. It is restored if the occurrence can't be
+	// created.
+	won, err := store.ClearRecurrence(ctx, id, closed.Recurrence, actor)
+	if err != nil {
+		return nil, fmt.Errorf("failed to clear recurrence of %s: %w", id, err)
+	}
+	if !won {
+		return nil, nil
+	}
+
+	issue := nextOccurrence(closed, next, actor)
+	if err := createOccurrence(ctx, store, closed, issue, labels, deps, actor); err != nil {
+		if restoreErr := store.UpdateIssue(ctx, id, map[string]interface{}{"recurrence": closed.Recurrence}, actor); restoreErr != nil {
+			err = errors.Join(err, fmt.Errorf("failed to restore recurrence of %s: %w", id, restoreErr))
+		}
+		return nil, err
+	}
+	return issue, nil
+}
+
+// SpawnDue spawns the next occurrence of every closed issue that still has a
+// recurrence rule. Issues that fail are reported in the returned error but
+// don't stop the others.
+func SpawnDue(ctx context.Context, store storage.Storage, actor string, now time.Time) ([]*types.Issue, error) {
+	status := types.StatusClosed
+	candidates, err := store.SearchIssues(ctx, "", types.IssueFilter{Status: &status, Recurring: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find closed recurring issues: %w", err)
+	}
+
+	var spawned []*types.Issue
+	var errs []error
+	for _, candidate := range candidates {
+		issue, err := Spawn(ctx, store, candidate.ID, actor, now)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if issue != nil {
+			spawned = append(spawned, issue)
+		}
+	}
+	return spawned, errors.Join(errs...)
+}
+
+// occurrenceTime returns when the closed issue was scheduled: its defer
+// time, else its due time, else its creation time.
+func occurrenceTime(issue *types.Issue) time.Time {
+	switch {
+	case issue.DeferUntil != nil:
+		return *issue.DeferUntil
+	case issue.DueAt != nil:
+		return *issue.DueAt
+	default:
+		return issue.CreatedAt
+	}
+}
+
+// nextOccurrence builds the issue for the occurrence at next. If the closed
+// issue was due some time after its occurrence, the new one is due the same
+// time after next.
+func nextOccurrence(closed *types.Issue, next time.Time, actor string) *types.Issue {
+	issue := &types.Issue{
+		Title:              closed.Title,
+		Description:        closed.Description,
+		Design:             closed.Design,
+		AcceptanceCriteria: closed.AcceptanceCriteria,
+		Status:             types.StatusOpen,
+		Priority:           closed.Priority,
+		IssueType:          closed.IssueType,
+		Assignee:           closed.Assignee,
+		Owner:              closed.Owner,
+		EstimatedMinutes:   closed.EstimatedMinutes,
+		CreatedBy:          actor,
+		DeferUntil:         &next,
+		Recurrence:         closed.Recurrence,
+		MolType:            closed.MolType,
+		WorkType:           closed.WorkType,
+	}
+	if closed.DueAt != nil {
+		due := next
+		if closed.DeferUntil != nil && closed.DueAt.After(*closed.DeferUntil) {
+			due = next.Add(closed.DueAt.Sub(*closed.DeferUntil))
+		}
+		issue.DueAt = &due
+	}
+	return issue
+}
+
+// createOccurrence creates issue with the closed issue's labels and outgoing
+// dependencies, and links it back with a caused-by edge. It all happens in
+// one transaction, so a failure leaves no half-built occurrence behind.
+func createOccurrence(ctx context.Context, store storage.Storage, closed, issue *types.Issue, labels []string, deps []*types.Dependency, actor string) error {
+	return store.RunInTransaction(ctx, func(tx storage.Transaction) error {
+		if err := tx.CreateIssue(ctx, issue, actor); err != nil {
+			return fmt.Errorf("failed to create next occurrence of %s: %w", closed.ID, err)
+		}
+		for _, label := range labels {
+			if err := tx.AddLabel(ctx, issue.ID, label, actor); err != nil {
+				return fmt.Errorf("failed to add label %q to %s: %w", label, issue.ID, err)
+			}
+		}
+		for _, dep := range deps {
+			// The previous occurrence's own caused-by link stays behind
+			if dep.Type == types.DepCausedBy {
+				continue
+			}
+			if err := tx.AddDependency(ctx, &types.Dependency{
+				IssueID:     issue.ID,
+				DependsOnID: dep.DependsOnID,
+				Type:        dep.Type,
+				Metadata:    dep.Metadata,
+			}, actor); err != nil {
+				return fmt.Errorf("failed to copy dependency %s -> %s: %w", issue.ID, dep.DependsOnID, err)
+			}
+		}
+		if err := tx.AddDependency(ctx, &types.Dependency{
+			IssueID:     issue.ID,
+			DependsOnID: closed.ID,
+			Type:        types.DepCausedBy,
+		}, actor); err != nil {
+			return fmt.Errorf("failed to link %s to %s: %w", issue.ID, closed.ID, err)
+		}
+		return nil
+	})
+}
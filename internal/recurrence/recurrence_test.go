@@ -0,0 +1,205 @@
+package recurrence
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage/sqlite"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func newTestStore(t *testing.T) *sqlite.SQLiteStorage {
+	t.Helper()
+	ctx := context.Background()
+	store, err := sqlite.New(ctx, filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("Failed to create store: %v", err)
+	}
+	t.Cleanup(func() { _ = store.Close() })
+	if err := store.SetConfig(ctx, "issue_prefix", "test"); err != nil {
+		t.Fatalf("Failed to set prefix: %v", err)
+	}
+	return store
+}
+
+func TestSpawn(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+
+	epic := &types.Issue{Title: "Ops", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeEpic}
+	if err := store.CreateIssue(ctx, epic, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+
+	// Monday, January 6, 2025 at 9:00, due by 17:00
+	occurrence := time.Date(2025, 1, 6, 9, 0, 0, 0, time.Local)
+	due := occurrence.Add(8 * time.Hour)
+	estimate := 30
+	issue := &types.Issue{
+		Title:            "Rotate credentials",
+		Description:      "Rotate the staging credentials",
+		Status:           types.StatusOpen,
+		Priority:         1,
+		IssueType:        types.TypeChore,
+		Assignee:         "alice",
+		EstimatedMinutes: &estimate,
+		DeferUntil:       &occurrence,
+		DueAt:            &due,
+		Recurrence:       "every monday 09:00",
+	}
+	if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	if err := store.AddLabel(ctx, issue.ID, "ops", "test"); err != nil {
+		t.Fatalf("AddLabel failed: %v", err)
+	}
+	if err := store.AddDependency(ctx, &types.Dependency{IssueID: issue.ID, DependsOnID: epic.ID, Type: types.DepParentChild}, "test"); err != nil {
+		t.Fatalf("AddDependency failed: %v", err)
+	}
+
+	// Open issues don't spawn
+	now := time.Date(2025, 1, 8, 10, 0, 0, 0, time.Local)
+	if next, err := Spawn(ctx, store, issue.ID, "test", now); err != nil || next != nil {
+		t.Fatalf("Spawn on open issue = %v, %v; want nil, nil", next, err)
+	}
+
+	if err := store.CloseIssue(ctx, issue.ID, "done", "test", ""); err != nil {
+		t.Fatalf("CloseIssue failed: %v", err)
+	}
+	next, err := Spawn(ctx, store, issue.ID, "daemon", now)
+	if err != nil {
+		t.Fatalf("Spawn failed: %v", err)
+	}
+	if next == nil {
+		t.Fatal("expected a new occurrence")
+	}
+
+	got, err := store.GetIssue(ctx, next.ID)
+	if err != nil || got == nil {
+		t.Fatalf("GetIssue(%s) = %v, %v", next.ID, got, err)
+	}
+	if got.Status != types.StatusOpen || got.Title != issue.Title || got.Assignee != "alice" || got.Priority != 1 {
+		t.Errorf("occurrence fields not copied: %+v", got)
+	}
+	if got.EstimatedMinutes == nil || *got.EstimatedMinutes != 30 {
+		t.Errorf("estimate not copied: %v", got.EstimatedMinutes)
+	}
+	if got.Recurrence != "every monday 09:00" {
+		t.Errorf("recurrence = %q, want rule moved to the occurrence", got.Recurrence)
+	}
+	wantDefer := time.Date(2025, 1, 13, 9, 0, 0, 0, time.Local)
+	if got.DeferUntil == nil || !got.DeferUntil.Equal(wantDefer) {
+		t.Errorf("defer_until = %v, want %v", got.DeferUntil, wantDefer)
+	}
+	if got.DueAt == nil || !got.DueAt.Equal(wantDefer.Add(8*time.Hour)) {
+		t.Errorf("due_at = %v, want %v", got.DueAt, wantDefer.Add(8*time.Hour))
+	}
+	if len(got.Labels) != 1 || got.Labels[0] != "ops" {
+		t.Errorf("labels = %v, want [ops]", got.Labels)
+	}
+
+	deps, err := store.GetDependencyRecords(ctx, next.ID)
+	if err != nil {
+		t.Fatalf("GetDependencyRecords failed: %v", err)
+	}
+	depTypes := map[string]types.DependencyType{}
+	for _, dep := range deps {
+		depTypes[dep.DependsOnID] = dep.Type
+	}
+	if depTypes[epic.ID] != types.DepParentChild || depTypes[issue.ID] != types.DepCausedBy || len(deps) != 2 {
+		t.Errorf("dependencies = %v, want parent-child to epic and caused-by to %s", depTypes, issue.ID)
+	}
+
+	// The closed issue gave up its rule, so it doesn't spawn again
+	closed, _ := store.GetIssue(ctx, issue.ID)
+	if closed.Recurrence != "" {
+		t.Errorf("closed issue still has recurrence %q", closed.Recurrence)
+	}
+	if again, err := Spawn(ctx, store, issue.ID, "daemon", now); err != nil || again != nil {
+		t.Errorf("second Spawn = %v, %v; want nil, nil", again, err)
+	}
+}
+
+func TestSpawnDue(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+
+	anchor := time.Date(2025, 1, 6, 9, 0, 0, 0, time.Local)
+	var ids []string
+	for _, rule := range []string{"every 2 weeks", "not a rule", ""} {
+		issue := &types.Issue{Title: "Task " + rule, Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask, DeferUntil: &anchor, Recurrence: rule}
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+		if err := store.CloseIssue(ctx, issue.ID, "done", "test", ""); err != nil {
+			t.Fatalf("CloseIssue failed: %v", err)
+		}
+		ids = append(ids, issue.ID)
+	}
+
+	now := time.Date(2025, 1, 8, 10, 0, 0, 0, time.Local)
+	spawned, err := SpawnDue(ctx, store, "daemon", now)
+	if err == nil {
+		t.Error("expected an error for the invalid rule")
+	}
+	if len(spawned) != 1 {
+		t.Fatalf("spawned %d occurrences, want 1", len(spawned))
+	}
+	want := anchor.AddDate(0, 0, 14)
+	if spawned[0].DeferUntil == nil || !spawned[0].DeferUntil.Equal(want) {
+		t.Errorf("defer_until = %v, want %v", spawned[0].DeferUntil, want)
+	}
+
+	// The invalid rule is left in place for the user to fix
+	invalid, _ := store.GetIssue(ctx, ids[1])
+	if invalid.Recurrence != "not a rule" {
+		t.Errorf("invalid rule changed to %q", invalid.Recurrence)
+	}
+
+	spawned, _ = SpawnDue(ctx, store, "daemon", now)
+	if len(spawned) != 0 {
+		t.Errorf("second SpawnDue spawned %d occurrences, want 0", len(spawned))
+	}
+}
+
+func TestSpawnFailureLeavesNoOccurrence(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+
+	task := &types.Issue{Title: "Not an epic", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	anchor := time.Date(2025, 1, 6, 9, 0, 0, 0, time.Local)
+	epic := &types.Issue{Title: "Quarterly review", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeEpic, DeferUntil: &anchor, Recurrence: "every 2 weeks"}
+	for _, issue := range []*types.Issue{task, epic} {
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+	// An epic can't be the child of a task, so copying this edge fails after
+	// the occurrence was created
+	if _, err := store.UnderlyingDB().ExecContext(ctx, `
+		INSERT INTO dependencies (issue_id, depends_on_id, type, created_by) VALUES (?, ?, 'parent-child', 'test')
+	`, epic.ID, task.ID); err != nil {
+		t.Fatalf("failed to insert dependency: %v", err)
+	}
+	if err := store.CloseIssue(ctx, epic.ID, "done", "test", ""); err != nil {
+		t.Fatalf("CloseIssue failed: %v", err)
+	}
+
+	now := time.Date(2025, 1, 8, 10, 0, 0, 0, time.Local)
+	if next, err := Spawn(ctx, store, epic.ID, "daemon", now); err == nil || next != nil {
+		t.Fatalf("Spawn = %v, %v; want a copy error", next, err)
+	}
+	open, err := store.SearchIssues(ctx, "Quarterly review", types.IssueFilter{ExcludeStatus: []types.Status{types.StatusClosed}})
+	if err != nil {
+		t.Fatalf("SearchIssues failed: %v", err)
+	}
+	if len(open) != 0 {
+		t.Errorf("failed spawn left %d occurrences behind", len(open))
+	}
+	closed, _ := store.GetIssue(ctx, epic.ID)
+	if closed.Recurrence != "every 2 weeks" {
+		t.Errorf("recurrence = %q, want the rule restored", closed.Recurrence)
+	}
+}
@@ -305,6 +305,11 @@ func (c *Client) Update(args *UpdateArgs) (*Response, error) {
 	return c.Execute(OpUpdate, args)
 }
 
+// Heartbeat renews the caller's lease on a claimed issue via the daemon.
+func (c *Client) Heartbeat(args *HeartbeatArgs) (*Response, error) {
+	return c.Execute(OpHeartbeat, args)
+}
+
 // CloseIssue marks an issue as closed via the daemon.
 func (c *Client) CloseIssue(args *CloseArgs) (*Response, error) {
 	return c.Execute(OpClose, args)
@@ -386,6 +391,11 @@ func (c *Client) RemoveDependency(args *DepRemoveArgs) (*Response, error) {
 	return c.Execute(OpDepRemove, args)
 }
 
+// DepTree retrieves the dependency tree for an issue via the daemon
+func (c *Client) DepTree(args *DepTreeArgs) (*Response, error) {
+	return c.Execute(OpDepTree, args)
+}
+
 // AddLabel adds a label via the daemon
 func (c *Client) AddLabel(args *LabelAddArgs) (*Response, error) {
 	return c.Execute(OpLabelAdd, args)
@@ -0,0 +1,546 @@
+package rpc
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+const (
+	// httpDefaultActor is the actor recorded for HTTP requests that don't
+	// send an X-Beads-Actor header.
+	httpDefaultActor = "http"
+
+	// httpMaxBodyBytes caps request bodies accepted by the gateway.
+	httpMaxBodyBytes = 10 << 20
+
+	// httpEventsHeartbeat is how long an event stream waits for mutations
+	// before sending a keepalive comment.
+	httpEventsHeartbeat = 15 * time.Second
+)
+
+// httpRoute maps one HTTP endpoint onto an RPC operation. The same table
+// drives request routing and the generated OpenAPI document.
+type httpRoute struct {
+	Method  string
+	Path    string // ServeMux path; {wildcards} become args fields
+	Op      string
+	Summary string
+	Args    any  // Zero value of the operation's args type, or nil
+	Result  any  // Zero value of the response data type, or nil for an untyped object
+	Query   bool // Args come from the query string instead of a JSON body
+	Status  int  // Success status code (default 200)
+	// IDField is the args field filled from the {id} wildcard (default "id").
+	// If the field is a list, the ID becomes a one-element list.
+	IDField string
+	// ActorField, if set, is filled with the request actor when absent.
+	ActorField string
+}
+
+// httpRoutes lists the endpoints exposed by the HTTP gateway.
+var httpRoutes = []httpRoute{
+	{Method: http.MethodGet, Path: "/v1/issues", Op: OpList, Summary: "List issues", Args: ListArgs{}, Result: []*types.IssueWithCounts{}, Query: true},
+	{Method: http.MethodPost, Path: "/v1/issues", Op: OpCreate, Summary: "Create an issue", Args: CreateArgs{}, Result: types.Issue{}, Status: http.StatusCreated},
+	{Method: http.MethodGet, Path: "/v1/issues/{id}", Op: OpShow, Summary: "Show an issue with its dependencies and comments", Args: ShowArgs{}, Result: types.IssueDetails{}, Query: true},
+	{Method: http.MethodPatch, Path: "/v1/issues/{id}", Op: OpUpdate, Summary: "Update an issue", Args: UpdateArgs{}, Result: types.Issue{}},
+	{Method: http.MethodDelete, Path: "/v1/issues/{id}", Op: OpDelete, Summary: "Delete an issue", Args: DeleteArgs{}, Query: true, IDField: "ids"},
+	{Method: http.MethodPost, Path: "/v1/issues/{id}/close", Op: OpClose, Summary: "Close an issue", Args: CloseArgs{}, Result: types.Issue{}},
+	{Method: http.MethodGet, Path: "/v1/issues/{id}/tree", Op: OpDepTree, Summary: "Show the dependency tree of an issue", Args: DepTreeArgs{}, Result: []*types.TreeNode{}, Query: true},
+	{Method: http.MethodGet, Path: "/v1/issues/{id}/comments", Op: OpCommentList, Summary: "List comments on an issue", Args: CommentListArgs{}, Result: []*types.Comment{}, Query: true},
+	{Method: http.MethodPost, Path: "/v1/issues/{id}/comments", Op: OpCommentAdd, Summary: "Add a comment to an issue", Args: CommentAddArgs{}, Result: types.Comment{}, Status: http.StatusCreated, ActorField: "author"},
+	{Method: http.MethodPost, Path: "/v1/issues/{id}/labels", Op: OpLabelAdd, Summary: "Add a label to an issue", Args: LabelAddArgs{}},
+	{Method: http.MethodDelete, Path: "/v1/issues/{id}/labels/{label}", Op: OpLabelRemove, Summary: "Remove a label from an issue", Args: LabelRemoveArgs{}, Query: true},
+	{Method: http.MethodPost, Path: "/v1/issues/{id}/dependencies", Op: OpDepAdd, Summary: "Add a dependency", Args: DepAddArgs{}, IDField: "from_id"},
+	{Method: http.MethodDelete, Path: "/v1/issues/{id}/dependencies/{to_id}", Op: OpDepRemove, Summary: "Remove a dependency", Args: DepRemoveArgs{}, Query: true, IDField: "from_id"},
+	{Method: http.MethodGet, Path: "/v1/ready", Op: OpReady, Summary: "List ready work", Args: ReadyArgs{}, Result: []*types.Issue{}, Query: true},
+	{Method: http.MethodGet, Path: "/v1/blocked", Op: OpBlocked, Summary: "List blocked issues", Args: BlockedArgs{}, Result: []*types.BlockedIssue{}, Query: true},
+	{Method: http.MethodGet, Path: "/v1/stale", Op: OpStale, Summary: "List stale issues", Args: StaleArgs{}, Result: []*types.Issue{}, Query: true},
+	{Method: http.MethodGet, Path: "/v1/count", Op: OpCount, Summary: "Count issues", Args: CountArgs{}, Query: true},
+	{Method: http.MethodGet, Path: "/v1/stats", Op: OpStats, Summary: "Show issue statistics", Result: types.Statistics{}, Query: true},
+	{Method: http.MethodGet, Path: "/v1/epics", Op: OpEpicStatus, Summary: "Show epic completion status", Args: EpicStatusArgs{}, Result: []*types.EpicStatus{}, Query: true},
+	{Method: http.MethodGet, Path: "/v1/status", Op: OpStatus, Summary: "Show daemon status", Result: StatusResponse{}, Query: true},
+	{Method: http.MethodGet, Path: "/v1/health", Op: OpHealth, Summary: "Check daemon health", Result: HealthResponse{}, Query: true},
+}
+
+// httpBlockedOps are operations the generic /v1/ops endpoint refuses.
+var httpBlockedOps = map[string]bool{
+	OpShutdown: true, // Use "bd daemon stop" on the host
+}
+
+// httpMutationEvent is the JSON shape of a mutation on the event stream.
+// It matches the webhook payload so consumers can share decoding code.
+type httpMutationEvent struct {
+	Type      string    `json:"type"`
+	IssueID   string    `json:"issue_id,omitempty"`
+	Title     string    `json:"title,omitempty"`
+	Assignee  string    `json:"assignee,omitempty"`
+	Actor     string    `json:"actor,omitempty"`
+	Timestamp time.Time `json:"timestamp"`
+	OldStatus string    `json:"old_status,omitempty"`
+	NewStatus string    `json:"new_status,omitempty"`
+	ParentID  string    `json:"parent_id,omitempty"`
+	StepCount int       `json:"step_count,omitempty"`
+}
+
+// newHTTPMutationEvent converts a mutation to its event stream shape.
+func newHTTPMutationEvent(m MutationEvent) httpMutationEvent {
+	return httpMutationEvent{
+		Type:      m.Type,
+		IssueID:   m.IssueID,
+		Title:     m.Title,
+		Assignee:  m.Assignee,
+		Actor:     m.Actor,
+		Timestamp: m.Timestamp,
+		OldStatus: m.OldStatus,
+		NewStatus: m.NewStatus,
+		ParentID:  m.ParentID,
+		StepCount: m.StepCount,
+	}
+}
+
+// httpError is the JSON body of every non-2xx gateway response.
+type httpError struct {
+	Error string `json:"error"`
+}
+
+// HTTPGateway exposes the daemon's RPC operations as an HTTP/JSON API with
+// bearer-token auth, a generated OpenAPI document and a Server-Sent Events
+// stream of mutations. Requests are dispatched in-process through the same
+// handler as socket clients, so behavior and metrics are shared.
+type HTTPGateway struct {
+	server    *Server
+	token     string
+	heartbeat time.Duration
+	mux       *http.ServeMux
+}
+
+// NewHTTPGateway creates a gateway for server. Every endpoint except the
+// OpenAPI document requires "Authorization: Bearer <token>"; token must not
+// be empty.
+func NewHTTPGateway(server *Server, token string) *HTTPGateway {
+	g := &HTTPGateway{
+		server:    server,
+		token:     token,
+		heartbeat: httpEventsHeartbeat,
+		mux:       http.NewServeMux(),
+	}
+	for _, route := range httpRoutes {
+		g.mux.Handle(route.Method+" "+route.Path, g.authenticated(func(w http.ResponseWriter, r *http.Request) {
+			g.serveRoute(w, r, route)
+		}))
+	}
+	g.mux.Handle("POST /v1/ops/{operation}", g.authenticated(g.serveOp))
+	g.mux.Handle("GET /v1/events", g.authenticated(g.serveEvents))
+	g.mux.Handle("GET /metrics", g.authenticated(server.MetricsHandler().ServeHTTP))
+	g.mux.HandleFunc("GET /v1/openapi.json", g.serveOpenAPI)
+	g.mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		writeHTTPError(w, http.StatusNotFound, fmt.Sprintf("no route for %s %s", r.Method, r.URL.Path))
+	})
+	return g
+}
+
+// Handler returns the gateway's HTTP handler.
+func (g *HTTPGateway) Handler() http.Handler {
+	return g.mux
+}
+
+// Serve accepts HTTP connections on ln until ctx is canceled or the RPC
+// server shuts down. It returns nil on a clean shutdown.
+func (g *HTTPGateway) Serve(ctx context.Context, ln net.Listener) error {
+	srv := &http.Server{
+		Handler:           g.mux,
+		ReadHeaderTimeout: 10 * time.Second,
+		// No WriteTimeout: event streams are long-lived
+	}
+
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-g.server.shutdownChan:
+		}
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+		if err := srv.Shutdown(shutdownCtx); err != nil {
+			// Open event streams don't finish on their own
+			_ = srv.Close()
+		}
+	}()
+
+	if err := srv.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// authenticated wraps next with bearer-token verification.
+func (g *HTTPGateway) authenticated(next http.HandlerFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth := r.Header.Get("Authorization")
+		token, ok := strings.CutPrefix(auth, "Bearer ")
+		if !ok || g.token == "" || subtle.ConstantTimeCompare([]byte(strings.TrimSpace(token)), []byte(g.token)) != 1 {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="beads"`)
+			writeHTTPError(w, http.StatusUnauthorized, "missing or invalid bearer token")
+			return
+		}
+		next(w, r)
+	})
+}
+
+// newRequest builds an RPC request for an HTTP call. The database binding
+// is always the daemon's own, since the gateway can't reach another one.
+func (g *HTTPGateway) newRequest(r *http.Request, op string, args json.RawMessage) *Request {
+	actor := strings.TrimSpace(r.Header.Get("X-Beads-Actor"))
+	if actor == "" {
+		actor = httpDefaultActor
+	}
+	req := &Request{
+		Operation: op,
+		Args:      args,
+		Actor:     actor,
+		RequestID: r.Header.Get("X-Request-Id"),
+	}
+	if g.server.storage != nil {
+		req.ExpectedDB = g.server.storage.Path()
+	}
+	return req
+}
+
+// serveRoute handles one entry of httpRoutes.
+func (g *HTTPGateway) serveRoute(w http.ResponseWriter, r *http.Request, route httpRoute) {
+	args := map[string]any{}
+	var err error
+	if route.Query {
+		args, err = queryArgs(r, route.Args)
+	} else {
+		err = decodeBody(w, r, &args)
+	}
+	if err != nil {
+		writeHTTPError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	for _, name := range pathWildcards(route.Path) {
+		value := r.PathValue(name)
+		field := name
+		if name == "id" && route.IDField != "" {
+			field = route.IDField
+		}
+		if isListField(route.Args, field) {
+			args[field] = []string{value}
+		} else {
+			args[field] = value
+		}
+	}
+
+	req := g.newRequest(r, route.Op, nil)
+	if route.ActorField != "" {
+		if v, _ := args[route.ActorField].(string); v == "" {
+			args[route.ActorField] = req.Actor
+		}
+	}
+
+	req.Args, _ = json.Marshal(args)
+	status := route.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	writeRPCResponse(w, g.server.handleRequest(req), status)
+}
+
+// serveOp handles POST /v1/ops/{operation}: the body is passed through as
+// the operation's args, for operations without a dedicated route.
+func (g *HTTPGateway) serveOp(w http.ResponseWriter, r *http.Request) {
+	op := r.PathValue("operation")
+	if httpBlockedOps[op] {
+		writeHTTPError(w, http.StatusForbidden, fmt.Sprintf("operation %s is not available over HTTP", op))
+		return
+	}
+
+	var args json.RawMessage
+	if err := decodeBody(w, r, &args); err != nil {
+		writeHTTPError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if len(args) == 0 {
+		args = json.RawMessage("{}")
+	}
+	writeRPCResponse(w, g.server.handleRequest(g.newRequest(r, op, args)), http.StatusOK)
+}
+
+// serveEvents streams mutations as Server-Sent Events, backed by
+// wait_for_mutations. Each event's id is its mutation sequence number, so
+// reconnecting clients resume via Last-Event-ID. Without a since parameter
+// or Last-Event-ID only new mutations are sent.
+func (g *HTTPGateway) serveEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeHTTPError(w, http.StatusInternalServerError, "streaming not supported")
+		return
+	}
+
+	afterSeq := g.server.lastMutationSeq()
+	cursor := r.Header.Get("Last-Event-ID")
+	if cursor == "" {
+		cursor = r.URL.Query().Get("since")
+	}
+	if cursor != "" {
+		seq, err := strconv.ParseUint(cursor, 10, 64)
+		if err != nil {
+			writeHTTPError(w, http.StatusBadRequest, fmt.Sprintf("invalid event id %q", cursor))
+			return
+		}
+		afterSeq = seq
+	}
+
+	wanted := map[string]bool{}
+	for _, t := range r.URL.Query()["type"] {
+		for _, part := range strings.Split(t, ",") {
+			if part = strings.TrimSpace(part); part != "" {
+				wanted[part] = true
+			}
+		}
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	_, _ = io.WriteString(w, ": connected\n\n")
+	flusher.Flush()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-g.server.shutdownChan:
+			return
+		default:
+		}
+
+		args, _ := json.Marshal(WaitForMutationsArgs{AfterSeq: afterSeq, Timeout: g.heartbeat.Milliseconds()})
+		resp := g.server.handleRequest(g.newRequest(r, OpWaitForMutations, args))
+		if !resp.Success {
+			data, _ := json.Marshal(httpError{Error: resp.Error})
+			_, _ = fmt.Fprintf(w, "event: error\ndata: %s\n\n", data)
+			flusher.Flush()
+			return
+		}
+
+		var mutations []MutationEvent
+		_ = json.Unmarshal(resp.Data, &mutations)
+
+		var err error
+		if len(mutations) == 0 {
+			_, err = io.WriteString(w, ": keepalive\n\n")
+		}
+		for _, m := range mutations {
+			if m.Seq > afterSeq {
+				afterSeq = m.Seq
+			}
+			if len(wanted) > 0 && !wanted[m.Type] {
+				continue
+			}
+			data, _ := json.Marshal(newHTTPMutationEvent(m))
+			if _, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", m.Seq, m.Type, data); err != nil {
+				break
+			}
+		}
+		if err != nil {
+			return
+		}
+		flusher.Flush()
+	}
+}
+
+// serveOpenAPI serves the generated OpenAPI document. It is unauthenticated
+// so tooling can discover the API before it has a token.
+func (g *HTTPGateway) serveOpenAPI(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	_ = enc.Encode(OpenAPISpec())
+}
+
+// writeRPCResponse translates an RPC response into an HTTP response.
+func writeRPCResponse(w http.ResponseWriter, resp Response, status int) {
+	if !resp.Success {
+		writeHTTPError(w, httpStatusForError(resp.Error), resp.Error)
+		return
+	}
+	if len(resp.Data) == 0 || string(resp.Data) == "null" {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, _ = w.Write(resp.Data)
+}
+
+// writeHTTPError writes a JSON error body.
+func writeHTTPError(w http.ResponseWriter, status int, msg string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(httpError{Error: msg})
+}
+
+// httpStatusForError picks a status code for an RPC error message.
+func httpStatusForError(msg string) int {
+	lower := strings.ToLower(msg)
+	switch {
+	case strings.HasPrefix(lower, "unknown operation"):
+		return http.StatusNotFound
+	case strings.Contains(lower, "not found"):
+		return http.StatusNotFound
+	default:
+		return http.StatusBadRequest
+	}
+}
+
+// decodeBody decodes a JSON request body into v. An empty body leaves v
+// untouched.
+func decodeBody(w http.ResponseWriter, r *http.Request, v any) error {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, httpMaxBodyBytes))
+	if err != nil {
+		return fmt.Errorf("failed to read request body: %w", err)
+	}
+	if len(strings.TrimSpace(string(body))) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(body, v); err != nil {
+		return fmt.Errorf("invalid JSON body: %w", err)
+	}
+	return nil
+}
+
+// queryArgs converts query parameters into an args object using the JSON
+// field names and types of argsType. Lists may be given as repeated or
+// comma-separated parameters.
+func queryArgs(r *http.Request, argsType any) (map[string]any, error) {
+	args := map[string]any{}
+	fields := jsonFields(argsType)
+	for name, values := range r.URL.Query() {
+		t, ok := fields[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown query parameter %q", name)
+		}
+		v, err := parseQueryValue(t, values)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", name, err)
+		}
+		args[name] = v
+	}
+	return args, nil
+}
+
+func parseQueryValue(t reflect.Type, values []string) (any, error) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	last := values[len(values)-1]
+	switch t.Kind() {
+	case reflect.String:
+		return last, nil
+	case reflect.Bool:
+		if last == "" {
+			return true, nil
+		}
+		return strconv.ParseBool(last)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.ParseInt(last, 10, 64)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.ParseUint(last, 10, 64)
+	case reflect.Float32, reflect.Float64:
+		return strconv.ParseFloat(last, 64)
+	case reflect.Slice:
+		var list []string
+		for _, v := range values {
+			for _, part := range strings.Split(v, ",") {
+				if part = strings.TrimSpace(part); part != "" {
+					list = append(list, part)
+				}
+			}
+		}
+		return list, nil
+	default:
+		return nil, fmt.Errorf("not supported as a query parameter")
+	}
+}
+
+// jsonFields returns the JSON field names of a struct value mapped to
+// their Go types, following embedded structs like encoding/json does.
+func jsonFields(v any) map[string]reflect.Type {
+	fields := map[string]reflect.Type{}
+	if v == nil {
+		return fields
+	}
+	collectJSONFields(reflect.TypeOf(v), fields)
+	return fields
+}
+
+func collectJSONFields(t reflect.Type, fields map[string]reflect.Type) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return
+	}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, _, skip := jsonFieldName(f)
+		if skip {
+			continue
+		}
+		if f.Anonymous && name == "" {
+			collectJSONFields(f.Type, fields)
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		if _, exists := fields[name]; !exists {
+			fields[name] = f.Type
+		}
+	}
+}
+
+// jsonFieldName parses a field's json tag. name is empty when the tag
+// doesn't set one; skip is true for unexported or "-" fields.
+func jsonFieldName(f reflect.StructField) (name string, omitempty bool, skip bool) {
+	if !f.IsExported() && !f.Anonymous {
+		return "", false, true
+	}
+	tag := f.Tag.Get("json")
+	if tag == "-" {
+		return "", false, true
+	}
+	name, opts, _ := strings.Cut(tag, ",")
+	return name, strings.Contains(opts, "omitempty"), false
+}
+
+// isListField reports whether field of argsType is a slice.
+func isListField(argsType any, field string) bool {
+	t, ok := jsonFields(argsType)[field]
+	return ok && t.Kind() == reflect.Slice
+}
+
+// pathWildcards returns the {wildcard} names in a route path, in order.
+func pathWildcards(path string) []string {
+	var names []string
+	for _, segment := range strings.Split(path, "/") {
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			names = append(names, strings.Trim(segment, "{}"))
+		}
+	}
+	return names
+}
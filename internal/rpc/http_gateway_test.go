@@ -0,0 +1,418 @@
+package rpc
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage/memory"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+const testGatewayToken = "test-token"
+
+func newTestGateway(t *testing.T) (*Server, *httptest.Server) {
+	t.Helper()
+	store := memory.New("/tmp/test.jsonl")
+	server := NewServer("/tmp/test.sock", store, "/tmp", "/tmp/test.db")
+	gateway := NewHTTPGateway(server, testGatewayToken)
+	gateway.heartbeat = 50 * time.Millisecond
+	ts := httptest.NewServer(gateway.Handler())
+	t.Cleanup(ts.Close)
+	return server, ts
+}
+
+// gatewayDo sends an authenticated request and returns the status and body.
+func gatewayDo(t *testing.T, ts *httptest.Server, method, path string, body any) (int, []byte) {
+	t.Helper()
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			t.Fatalf("marshal body: %v", err)
+		}
+		reader = bytes.NewReader(data)
+	}
+	req, err := http.NewRequest(method, ts.URL+path, reader)
+	if err != nil {
+		t.Fatalf("new request: %v", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+testGatewayToken)
+	req.Header.Set("X-Beads-Actor", "dashboard")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("%s %s: %v", method, path, err)
+	}
+	defer resp.Body.Close()
+	data, _ := io.ReadAll(resp.Body)
+	return resp.StatusCode, data
+}
+
+func TestHTTPGatewayAuth(t *testing.T) {
+	_, ts := newTestGateway(t)
+
+	tests := []struct {
+		name   string
+		header string
+		want   int
+	}{
+		{"missing", "", http.StatusUnauthorized},
+		{"wrong token", "Bearer nope", http.StatusUnauthorized},
+		{"wrong scheme", "Basic " + testGatewayToken, http.StatusUnauthorized},
+		{"valid", "Bearer " + testGatewayToken, http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest(http.MethodGet, ts.URL+"/v1/issues", nil)
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp.Body.Close()
+			if resp.StatusCode != tt.want {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tt.want)
+			}
+		})
+	}
+
+	// The OpenAPI document is public
+	resp, err := http.Get(ts.URL + "/v1/openapi.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("openapi status = %d, want 200", resp.StatusCode)
+	}
+}
+
+func TestHTTPGatewayIssueLifecycle(t *testing.T) {
+	_, ts := newTestGateway(t)
+
+	status, body := gatewayDo(t, ts, http.MethodPost, "/v1/issues", CreateArgs{Title: "From HTTP", IssueType: "task", Priority: 1})
+	if status != http.StatusCreated {
+		t.Fatalf("create status = %d: %s", status, body)
+	}
+	var created types.Issue
+	if err := json.Unmarshal(body, &created); err != nil {
+		t.Fatalf("decode created issue: %v", err)
+	}
+	if created.ID == "" || created.Title != "From HTTP" {
+		t.Fatalf("unexpected created issue: %+v", created)
+	}
+
+	status, body = gatewayDo(t, ts, http.MethodPatch, "/v1/issues/"+created.ID, map[string]any{"status": "in_progress"})
+	if status != http.StatusOK {
+		t.Fatalf("update status = %d: %s", status, body)
+	}
+
+	status, body = gatewayDo(t, ts, http.MethodPost, "/v1/issues/"+created.ID+"/labels", map[string]any{"label": "web"})
+	if status != http.StatusOK && status != http.StatusNoContent {
+		t.Fatalf("label add status = %d: %s", status, body)
+	}
+
+	status, body = gatewayDo(t, ts, http.MethodPost, "/v1/issues/"+created.ID+"/comments", map[string]any{"text": "hello"})
+	if status != http.StatusCreated {
+		t.Fatalf("comment add status = %d: %s", status, body)
+	}
+	var comment types.Comment
+	if err := json.Unmarshal(body, &comment); err != nil {
+		t.Fatalf("decode comment: %v", err)
+	}
+	if comment.Author != "dashboard" {
+		t.Errorf("comment author = %q, want actor from X-Beads-Actor", comment.Author)
+	}
+
+	status, body = gatewayDo(t, ts, http.MethodGet, "/v1/issues/"+created.ID, nil)
+	if status != http.StatusOK {
+		t.Fatalf("show status = %d: %s", status, body)
+	}
+	var details types.IssueDetails
+	if err := json.Unmarshal(body, &details); err != nil {
+		t.Fatalf("decode details: %v", err)
+	}
+	if details.Status != types.StatusInProgress {
+		t.Errorf("status = %s, want in_progress", details.Status)
+	}
+	if len(details.Labels) != 1 || details.Labels[0] != "web" {
+		t.Errorf("labels = %v, want [web]", details.Labels)
+	}
+
+	status, body = gatewayDo(t, ts, http.MethodGet, "/v1/issues?status=in_progress&labels=web", nil)
+	if status != http.StatusOK {
+		t.Fatalf("list status = %d: %s", status, body)
+	}
+	var listed []*types.IssueWithCounts
+	if err := json.Unmarshal(body, &listed); err != nil {
+		t.Fatalf("decode list: %v", err)
+	}
+	if len(listed) != 1 || listed[0].ID != created.ID {
+		t.Errorf("list returned %d issues, want %s", len(listed), created.ID)
+	}
+
+	status, body = gatewayDo(t, ts, http.MethodGet, "/v1/issues/"+created.ID+"/tree", nil)
+	if status != http.StatusOK {
+		t.Fatalf("tree status = %d: %s", status, body)
+	}
+	var tree []*types.TreeNode
+	if err := json.Unmarshal(body, &tree); err != nil {
+		t.Fatalf("decode tree: %v", err)
+	}
+	if len(tree) != 1 || tree[0].ID != created.ID {
+		t.Errorf("tree = %+v, want root %s", tree, created.ID)
+	}
+
+	status, body = gatewayDo(t, ts, http.MethodPost, "/v1/issues/"+created.ID+"/close", map[string]any{"reason": "done"})
+	if status != http.StatusOK {
+		t.Fatalf("close status = %d: %s", status, body)
+	}
+	var closed types.Issue
+	if err := json.Unmarshal(body, &closed); err != nil {
+		t.Fatalf("decode closed issue: %v", err)
+	}
+	if closed.Status != types.StatusClosed {
+		t.Errorf("status after close = %s", closed.Status)
+	}
+}
+
+func TestHTTPGatewayErrors(t *testing.T) {
+	_, ts := newTestGateway(t)
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		want   int
+	}{
+		{"missing issue", http.MethodGet, "/v1/issues/bd-missing", http.StatusNotFound},
+		{"unknown query parameter", http.MethodGet, "/v1/issues?bogus=1", http.StatusBadRequest},
+		{"bad query value", http.MethodGet, "/v1/issues?priority=high", http.StatusBadRequest},
+		{"unknown route", http.MethodGet, "/v1/nope", http.StatusNotFound},
+		{"unknown operation", http.MethodPost, "/v1/ops/nope", http.StatusNotFound},
+		{"blocked operation", http.MethodPost, "/v1/ops/shutdown", http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, body := gatewayDo(t, ts, tt.method, tt.path, nil)
+			if status != tt.want {
+				t.Fatalf("status = %d, want %d: %s", status, tt.want, body)
+			}
+			var e httpError
+			if err := json.Unmarshal(body, &e); err != nil || e.Error == "" {
+				t.Errorf("expected JSON error body, got %s", body)
+			}
+		})
+	}
+}
+
+func TestHTTPGatewayGenericOp(t *testing.T) {
+	_, ts := newTestGateway(t)
+
+	status, body := gatewayDo(t, ts, http.MethodPost, "/v1/ops/ping", nil)
+	if status != http.StatusOK {
+		t.Fatalf("ping status = %d: %s", status, body)
+	}
+	var pong PingResponse
+	if err := json.Unmarshal(body, &pong); err != nil || pong.Message != "pong" {
+		t.Errorf("unexpected ping response: %s", body)
+	}
+}
+
+func TestHTTPGatewayEvents(t *testing.T) {
+	server, ts := newTestGateway(t)
+
+	req, _ := http.NewRequest(http.MethodGet, ts.URL+"/v1/events?type=create", nil)
+	req.Header.Set("Authorization", "Bearer "+testGatewayToken)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Fatalf("content type = %q", ct)
+	}
+
+	reader := bufio.NewReader(resp.Body)
+	if line, _ := reader.ReadString('\n'); !strings.HasPrefix(line, ": connected") {
+		t.Fatalf("first line = %q", line)
+	}
+
+	server.emitMutation(MutationUpdate, "bd-1", "Filtered out", "")
+	server.emitMutation(MutationCreate, "bd-2", "Streamed", "")
+
+	type sseEvent struct{ id, event, data string }
+	var got sseEvent
+	deadline := time.After(5 * time.Second)
+	lines := make(chan string)
+	go func() {
+		for {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				close(lines)
+				return
+			}
+			lines <- strings.TrimRight(line, "\n")
+		}
+	}()
+	for got.data == "" {
+		select {
+		case line, ok := <-lines:
+			if !ok {
+				t.Fatal("stream closed before event")
+			}
+			switch {
+			case strings.HasPrefix(line, "id: "):
+				got.id = strings.TrimPrefix(line, "id: ")
+			case strings.HasPrefix(line, "event: "):
+				got.event = strings.TrimPrefix(line, "event: ")
+			case strings.HasPrefix(line, "data: "):
+				got.data = strings.TrimPrefix(line, "data: ")
+			}
+		case <-deadline:
+			t.Fatal("timed out waiting for event")
+		}
+	}
+
+	if got.event != MutationCreate || got.id == "" {
+		t.Errorf("event = %+v, want create with id", got)
+	}
+	var payload httpMutationEvent
+	if err := json.Unmarshal([]byte(got.data), &payload); err != nil {
+		t.Fatalf("decode event data: %v", err)
+	}
+	if payload.IssueID != "bd-2" || payload.Title != "Streamed" {
+		t.Errorf("payload = %+v", payload)
+	}
+}
+
+func TestHTTPGatewayEventsResumeWithinMillisecond(t *testing.T) {
+	server, ts := newTestGateway(t)
+
+	at := time.Now()
+	server.emitRichMutation(MutationEvent{Type: MutationCreate, IssueID: "bd-1", Timestamp: at})
+	server.emitRichMutation(MutationEvent{Type: MutationCreate, IssueID: "bd-2", Timestamp: at})
+	first := server.GetRecentMutations(0)[0]
+
+	req, _ := http.NewRequest(http.MethodGet, ts.URL+"/v1/events", nil)
+	req.Header.Set("Authorization", "Bearer "+testGatewayToken)
+	req.Header.Set("Last-Event-ID", strconv.FormatUint(first.Seq, 10))
+	client := &http.Client{Timeout: 5 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	var id, data string
+	reader := bufio.NewReader(resp.Body)
+	for data == "" {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			t.Fatalf("reading stream: %v", err)
+		}
+		line = strings.TrimRight(line, "\n")
+		switch {
+		case strings.HasPrefix(line, "id: "):
+			id = strings.TrimPrefix(line, "id: ")
+		case strings.HasPrefix(line, "data: "):
+			data = strings.TrimPrefix(line, "data: ")
+		}
+	}
+
+	if want := strconv.FormatUint(first.Seq+1, 10); id != want {
+		t.Errorf("id = %s, want %s", id, want)
+	}
+	var payload httpMutationEvent
+	if err := json.Unmarshal([]byte(data), &payload); err != nil || payload.IssueID != "bd-2" {
+		t.Errorf("resumed at %s (%v), want the second event bd-2", data, err)
+	}
+}
+
+func TestWaitForMutationsLeavesEventsForDaemon(t *testing.T) {
+	store := memory.New("/tmp/test.jsonl")
+	server := NewServer("/tmp/test.sock", store, "/tmp", "/tmp/test.db")
+
+	done := make(chan Response)
+	go func() {
+		args, _ := json.Marshal(WaitForMutationsArgs{Since: time.Now().UnixMilli(), Timeout: 5000})
+		done <- server.handleWaitForMutations(&Request{Operation: OpWaitForMutations, Args: args})
+	}()
+
+	time.Sleep(20 * time.Millisecond)
+	server.emitMutation(MutationCreate, "bd-1", "Woken", "")
+
+	select {
+	case resp := <-done:
+		var mutations []MutationEvent
+		if err := json.Unmarshal(resp.Data, &mutations); err != nil || len(mutations) != 1 {
+			t.Fatalf("waiter got %s", resp.Data)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("waiter was not woken by mutation")
+	}
+
+	select {
+	case m := <-server.MutationChan():
+		if m.IssueID != "bd-1" {
+			t.Errorf("daemon got %+v", m)
+		}
+	default:
+		t.Fatal("waiter consumed the event meant for the daemon loop")
+	}
+}
+
+func TestOpenAPISpec(t *testing.T) {
+	spec := OpenAPISpec()
+	data, err := json.Marshal(spec)
+	if err != nil {
+		t.Fatalf("spec does not marshal: %v", err)
+	}
+
+	var doc struct {
+		OpenAPI    string                               `json:"openapi"`
+		Paths      map[string]map[string]map[string]any `json:"paths"`
+		Components struct {
+			Schemas map[string]struct {
+				Properties map[string]any `json:"properties"`
+			} `json:"schemas"`
+		} `json:"components"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatalf("decode spec: %v", err)
+	}
+
+	for _, route := range httpRoutes {
+		op, ok := doc.Paths[route.Path][strings.ToLower(route.Method)]
+		if !ok {
+			t.Errorf("spec missing %s %s", route.Method, route.Path)
+			continue
+		}
+		if op["operationId"] != route.Op {
+			t.Errorf("%s %s operationId = %v, want %s", route.Method, route.Path, op["operationId"], route.Op)
+		}
+	}
+	if _, ok := doc.Paths["/v1/events"]["get"]; !ok {
+		t.Error("spec missing event stream")
+	}
+
+	createArgs, ok := doc.Components.Schemas["CreateArgs"]
+	if !ok {
+		t.Fatal("spec missing CreateArgs schema")
+	}
+	if _, ok := createArgs.Properties["title"]; !ok {
+		t.Error("CreateArgs schema missing title property")
+	}
+	if _, ok := doc.Components.Schemas["Issue"].Properties["created_at"]; !ok {
+		t.Error("Issue schema missing created_at property")
+	}
+}
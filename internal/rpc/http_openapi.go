@@ -0,0 +1,280 @@
+package rpc
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	timeType       = reflect.TypeOf(time.Time{})
+	durationType   = reflect.TypeOf(time.Duration(0))
+	rawMessageType = reflect.TypeOf(json.RawMessage(nil))
+)
+
+// OpenAPISpec returns an OpenAPI 3.1 document describing the HTTP gateway.
+// Schemas are generated from the protocol's args and result types, so the
+// document stays in sync with the RPC protocol.
+func OpenAPISpec() map[string]any {
+	gen := &openAPIGenerator{schemas: map[string]any{}}
+	paths := map[string]map[string]any{}
+	addOperation := func(method, path string, op map[string]any) {
+		if paths[path] == nil {
+			paths[path] = map[string]any{}
+		}
+		paths[path][strings.ToLower(method)] = op
+	}
+
+	for _, route := range httpRoutes {
+		addOperation(route.Method, route.Path, gen.routeOperation(route))
+	}
+
+	addOperation(http.MethodPost, "/v1/ops/{operation}", map[string]any{
+		"operationId": "execute",
+		"summary":     "Execute any daemon operation by name",
+		"description": "The request body is passed through as the operation's args and the response data is returned as-is. Use this for operations without a dedicated route.",
+		"parameters": []any{map[string]any{
+			"name": "operation", "in": "path", "required": true,
+			"schema": map[string]any{"type": "string"},
+		}},
+		"requestBody": map[string]any{
+			"content": jsonContent(map[string]any{"type": "object"}),
+		},
+		"responses": gen.responses(http.StatusOK, nil),
+	})
+	addOperation(http.MethodGet, "/v1/events", map[string]any{
+		"operationId": "events",
+		"summary":     "Stream mutation events (Server-Sent Events)",
+		"description": "Each event's id is its mutation sequence number; send it back as Last-Event-ID or ?since= to resume.",
+		"parameters": []any{
+			map[string]any{"name": "since", "in": "query", "schema": map[string]any{"type": "integer"}},
+			map[string]any{"name": "type", "in": "query", "schema": map[string]any{"type": "array", "items": map[string]any{"type": "string"}}},
+		},
+		"responses": map[string]any{
+			"200": map[string]any{
+				"description": "Event stream",
+				"content": map[string]any{"text/event-stream": map[string]any{
+					"schema": gen.schemaFor(reflect.TypeOf(httpMutationEvent{})),
+				}},
+			},
+			"401": gen.errorResponse("Missing or invalid bearer token"),
+		},
+	})
+	addOperation(http.MethodGet, "/metrics", map[string]any{
+		"operationId": "metrics",
+		"summary":     "Daemon metrics in Prometheus text format",
+		"responses": map[string]any{
+			"200": map[string]any{
+				"description": "Prometheus text exposition format",
+				"content":     map[string]any{"text/plain": map[string]any{"schema": map[string]any{"type": "string"}}},
+			},
+			"401": gen.errorResponse("Missing or invalid bearer token"),
+		},
+	})
+	addOperation(http.MethodGet, "/v1/openapi.json", map[string]any{
+		"operationId": "openapi",
+		"summary":     "This document",
+		"security":    []any{},
+		"responses": map[string]any{
+			"200": map[string]any{"description": "OpenAPI document", "content": jsonContent(map[string]any{"type": "object"})},
+		},
+	})
+
+	return map[string]any{
+		"openapi": "3.1.0",
+		"info": map[string]any{
+			"title":       "beads daemon API",
+			"version":     ServerVersion,
+			"description": "HTTP/JSON gateway to the bd daemon RPC protocol.",
+		},
+		"security": []any{map[string]any{"bearerAuth": []any{}}},
+		"paths":    paths,
+		"components": map[string]any{
+			"securitySchemes": map[string]any{
+				"bearerAuth": map[string]any{"type": "http", "scheme": "bearer"},
+			},
+			"schemas": gen.schemas,
+		},
+	}
+}
+
+// openAPIGenerator accumulates component schemas for named struct types.
+type openAPIGenerator struct {
+	schemas map[string]any
+}
+
+func (g *openAPIGenerator) routeOperation(route httpRoute) map[string]any {
+	op := map[string]any{
+		"operationId": route.Op,
+		"summary":     route.Summary,
+	}
+
+	wildcards := map[string]bool{}
+	var params []any
+	for _, name := range pathWildcards(route.Path) {
+		wildcards[name] = true
+		if name == "id" && route.IDField != "" {
+			wildcards[route.IDField] = true
+		}
+		params = append(params, map[string]any{
+			"name": name, "in": "path", "required": true,
+			"schema": map[string]any{"type": "string"},
+		})
+	}
+
+	if route.Query {
+		fields := jsonFields(route.Args)
+		names := make([]string, 0, len(fields))
+		for name := range fields {
+			if !wildcards[name] {
+				names = append(names, name)
+			}
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			params = append(params, map[string]any{
+				"name": name, "in": "query",
+				"schema": g.schemaFor(fields[name]),
+			})
+		}
+	} else if route.Args != nil {
+		op["requestBody"] = map[string]any{
+			"description": "Path parameters override the matching body fields.",
+			"content":     jsonContent(g.schemaFor(reflect.TypeOf(route.Args))),
+		}
+	}
+	if len(params) > 0 {
+		op["parameters"] = params
+	}
+
+	status := route.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	var result reflect.Type
+	if route.Result != nil {
+		result = reflect.TypeOf(route.Result)
+	}
+	op["responses"] = g.responses(status, result)
+	return op
+}
+
+func (g *openAPIGenerator) responses(status int, result reflect.Type) map[string]any {
+	schema := map[string]any{"type": "object"}
+	if result != nil {
+		schema = g.schemaFor(result)
+	}
+	return map[string]any{
+		strconv.Itoa(status): map[string]any{
+			"description": http.StatusText(status),
+			"content":     jsonContent(schema),
+		},
+		"204": map[string]any{"description": "Success with no response data"},
+		"400": g.errorResponse("Invalid request or rejected operation"),
+		"401": g.errorResponse("Missing or invalid bearer token"),
+		"404": g.errorResponse("Issue or operation not found"),
+	}
+}
+
+func (g *openAPIGenerator) errorResponse(description string) map[string]any {
+	return map[string]any{
+		"description": description,
+		"content":     jsonContent(g.schemaFor(reflect.TypeOf(httpError{}))),
+	}
+}
+
+// schemaFor returns the JSON schema for t, registering named structs as
+// components and referencing them.
+func (g *openAPIGenerator) schemaFor(t reflect.Type) map[string]any {
+	switch t {
+	case timeType:
+		return map[string]any{"type": "string", "format": "date-time"}
+	case durationType:
+		return map[string]any{"type": "integer", "description": "Duration in nanoseconds"}
+	case rawMessageType:
+		return map[string]any{}
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		return g.schemaFor(t.Elem())
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return map[string]any{"type": "string", "format": "byte"}
+		}
+		return map[string]any{"type": "array", "items": g.schemaFor(t.Elem())}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": g.schemaFor(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return g.structSchema(t)
+		}
+		name := t.Name()
+		if _, ok := g.schemas[name]; !ok {
+			g.schemas[name] = map[string]any{} // Placeholder for recursive types
+			g.schemas[name] = g.structSchema(t)
+		}
+		return map[string]any{"$ref": "#/components/schemas/" + name}
+	default:
+		return map[string]any{}
+	}
+}
+
+func (g *openAPIGenerator) structSchema(t reflect.Type) map[string]any {
+	props := map[string]any{}
+	var required []string
+	g.addStructFields(t, props, &required)
+	schema := map[string]any{"type": "object", "properties": props}
+	if len(required) > 0 {
+		sort.Strings(required)
+		schema["required"] = required
+	}
+	return schema
+}
+
+func (g *openAPIGenerator) addStructFields(t reflect.Type, props map[string]any, required *[]string) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, omitempty, skip := jsonFieldName(f)
+		if skip {
+			continue
+		}
+		if f.Anonymous && name == "" {
+			ft := f.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				g.addStructFields(ft, props, required)
+				continue
+			}
+		}
+		if name == "" {
+			name = f.Name
+		}
+		if _, exists := props[name]; exists {
+			continue
+		}
+		props[name] = g.schemaFor(f.Type)
+		if !omitempty && f.Type.Kind() != reflect.Pointer {
+			*required = append(*required, name)
+		}
+	}
+}
+
+func jsonContent(schema map[string]any) map[string]any {
+	return map[string]any{"application/json": map[string]any{"schema": schema}}
+}
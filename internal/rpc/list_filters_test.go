@@ -738,3 +738,45 @@ func listArgsToFilter(args *ListArgs, t *testing.T) *types.IssueFilter {
 
 	return filter
 }
+
+func TestListRankedIncludesCounts(t *testing.T) {
+	tmpDir := t.TempDir()
+	dbPath := filepath.Join(tmpDir, "test.db")
+	store := newTestStore(t, dbPath)
+	defer store.Close()
+	server := NewServer(newTestSocketPath(t), store, tmpDir, dbPath)
+	ctx := context.Background()
+
+	login := &types.Issue{Title: "Login times out", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeBug}
+	blocked := &types.Issue{Title: "Release", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	for _, issue := range []*types.Issue{login, blocked} {
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+	if err := store.AddDependency(ctx, &types.Dependency{IssueID: blocked.ID, DependsOnID: login.ID, Type: types.DepBlocks}, "test"); err != nil {
+		t.Fatalf("AddDependency failed: %v", err)
+	}
+	if err := store.AddLabel(ctx, login.ID, "auth", "test"); err != nil {
+		t.Fatalf("AddLabel failed: %v", err)
+	}
+
+	resp := server.handleList(leaseRequest(t, OpList, "test", ListArgs{Query: "login", Ranked: true}))
+	if !resp.Success {
+		t.Fatalf("list failed: %s", resp.Error)
+	}
+	var results []map[string]interface{}
+	if err := json.Unmarshal(resp.Data, &results); err != nil {
+		t.Fatalf("failed to parse results: %v", err)
+	}
+	if len(results) != 1 || results[0]["id"] != login.ID {
+		t.Fatalf("results = %v, want %s", results, login.ID)
+	}
+	r := results[0]
+	if r["dependent_count"] != float64(1) || r["dependency_count"] != float64(0) || r["score"] == nil {
+		t.Errorf("result = %v, want dependency counts and a score", r)
+	}
+	if labels, _ := r["labels"].([]interface{}); len(labels) != 1 || labels[0] != "auth" {
+		t.Errorf("labels = %v, want [auth]", r["labels"])
+	}
+}
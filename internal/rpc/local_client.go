@@ -0,0 +1,54 @@
+package rpc
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/steveyegge/beads/internal/storage"
+)
+
+// LocalClient runs RPC operations in-process against a storage backend,
+// using the daemon's request handlers. Long-running commands such as
+// "bd mcp serve" use it when no daemon is running, so both paths accept the
+// same arguments and return the same responses.
+type LocalClient struct {
+	server *Server
+	actor  string
+}
+
+// NewLocalClient returns a client that serves operations from store.
+func NewLocalClient(store storage.Storage, workspacePath, dbPath string) *LocalClient {
+	return &LocalClient{server: NewServer("", store, workspacePath, dbPath)}
+}
+
+// SetActor sets the actor recorded for mutations.
+func (c *LocalClient) SetActor(actor string) {
+	c.actor = actor
+}
+
+// OnMutation registers a function called after every mutation, e.g. to
+// schedule a JSONL export.
+func (c *LocalClient) OnMutation(listener func(MutationEvent)) {
+	c.server.OnMutation(listener)
+}
+
+// Execute runs an operation. Like Client.Execute, it returns the response
+// together with an error when the operation fails.
+func (c *LocalClient) Execute(operation string, args interface{}) (*Response, error) {
+	argsJSON, err := json.Marshal(args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal args: %w", err)
+	}
+	req := &Request{
+		Operation:     operation,
+		Args:          argsJSON,
+		Actor:         c.actor,
+		ClientVersion: ClientVersion,
+		ExpectedDB:    c.server.storage.Path(),
+	}
+	resp := c.server.handleRequest(req)
+	if !resp.Success {
+		return &resp, fmt.Errorf("operation failed: %s", resp.Error)
+	}
+	return &resp, nil
+}
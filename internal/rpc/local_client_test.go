@@ -0,0 +1,60 @@
+package rpc
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestLocalClient(t *testing.T) {
+	tmpDir := t.TempDir()
+	dbPath := filepath.Join(tmpDir, ".beads", "beads.db")
+	store := newTestStore(t, dbPath)
+	defer store.Close()
+
+	client := NewLocalClient(store, tmpDir, dbPath)
+	client.SetActor("agent")
+	events := make(chan MutationEvent, 1)
+	client.OnMutation(func(e MutationEvent) {
+		events <- e
+	})
+
+	resp, err := client.Execute(OpCreate, &CreateArgs{Title: "Local issue", Description: "Created in-process", IssueType: "task", Priority: 1, CreatedBy: "agent"})
+	if err != nil {
+		t.Fatalf("create failed: %v", err)
+	}
+	var issue types.Issue
+	if err := json.Unmarshal(resp.Data, &issue); err != nil {
+		t.Fatalf("failed to parse create response: %v", err)
+	}
+	select {
+	case e := <-events:
+		if e.Type != MutationCreate || e.IssueID != issue.ID {
+			t.Errorf("mutation = %+v, want a create of %s", e, issue.ID)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("listener did not see the create of %s", issue.ID)
+	}
+
+	resp, err = client.Execute(OpShow, &ShowArgs{ID: issue.ID})
+	if err != nil {
+		t.Fatalf("show failed: %v", err)
+	}
+	var details types.IssueDetails
+	if err := json.Unmarshal(resp.Data, &details); err != nil {
+		t.Fatalf("failed to parse show response: %v", err)
+	}
+	if details.Title != "Local issue" || details.CreatedBy != "agent" {
+		t.Errorf("show = %q by %q, want Local issue by agent", details.Title, details.CreatedBy)
+	}
+
+	// Failed operations return the response along with the error, like Client
+	resp, err = client.Execute(OpShow, &ShowArgs{ID: "bd-missing"})
+	if err == nil || resp == nil || !strings.Contains(resp.Error, "not found") {
+		t.Errorf("show missing = %+v, %v; want not found error", resp, err)
+	}
+}
@@ -18,6 +18,11 @@ type Metrics struct {
 	requestErrors  map[string]int64           // operation -> error count
 	requestLatency map[string][]time.Duration // operation -> latency samples (bounded slice)
 	maxSamples     int
+	// operation -> cumulative latency histogram (unbounded, for Prometheus)
+	requestHistograms map[string]*latencyHistogram
+
+	// Sync metrics: stage -> outcome counters and last outcome times
+	syncStages map[string]*syncStageMetrics
 
 	// Connection metrics
 	totalConns    int64
@@ -35,6 +40,9 @@ func NewMetrics() *Metrics {
 		requestLatency: make(map[string][]time.Duration),
 		maxSamples:     1000, // Keep last 1000 samples per operation
 		startTime:      time.Now(),
+
+		requestHistograms: make(map[string]*latencyHistogram),
+		syncStages:        make(map[string]*syncStageMetrics),
 	}
 }
 
@@ -53,6 +61,13 @@ func (m *Metrics) RecordRequest(operation string, latency time.Duration) {
 	}
 	samples = append(samples, latency)
 	m.requestLatency[operation] = samples
+
+	h := m.requestHistograms[operation]
+	if h == nil {
+		h = newLatencyHistogram()
+		m.requestHistograms[operation] = h
+	}
+	h.observe(latency)
 }
 
 // RecordError records a failed request
@@ -63,6 +78,35 @@ func (m *Metrics) RecordError(operation string) {
 	m.requestErrors[operation]++
 }
 
+// Sync stages recorded with RecordSync
+const (
+	SyncStageExport = "export"
+	SyncStageImport = "import"
+	SyncStageCommit = "commit"
+	SyncStagePush   = "push"
+	SyncStagePull   = "pull"
+)
+
+// RecordSync records the outcome of a sync stage (one of the SyncStage*
+// constants). A nil err counts as a success.
+func (m *Metrics) RecordSync(stage string, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	sm := m.syncStages[stage]
+	if sm == nil {
+		sm = &syncStageMetrics{}
+		m.syncStages[stage] = sm
+	}
+	if err != nil {
+		sm.failures++
+		sm.lastFailure = time.Now()
+	} else {
+		sm.successes++
+		sm.lastSuccess = time.Now()
+	}
+}
+
 // RecordConnection records a new connection
 func (m *Metrics) RecordConnection() {
 	atomic.AddInt64(&m.totalConns, 1)
@@ -235,6 +279,44 @@ func calculateLatencyStats(samples []time.Duration) LatencyStats {
 	}
 }
 
+// latencyBuckets are the histogram upper bounds in seconds. The top buckets
+// cover wait_for_mutations, which blocks for up to 30s by design.
+var latencyBuckets = []float64{0.001, 0.0025, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30}
+
+// latencyHistogram counts observations per bucket (non-cumulative; the
+// exporter accumulates them) plus the total count and sum in seconds.
+type latencyHistogram struct {
+	buckets []uint64 // len(latencyBuckets)+1; the last is +Inf
+	count   uint64
+	sum     float64
+}
+
+func newLatencyHistogram() *latencyHistogram {
+	return &latencyHistogram{buckets: make([]uint64, len(latencyBuckets)+1)}
+}
+
+func (h *latencyHistogram) observe(d time.Duration) {
+	seconds := d.Seconds()
+	i := sort.SearchFloat64s(latencyBuckets, seconds)
+	h.buckets[i]++
+	h.count++
+	h.sum += seconds
+}
+
+func (h *latencyHistogram) clone() *latencyHistogram {
+	c := *h
+	c.buckets = append([]uint64(nil), h.buckets...)
+	return &c
+}
+
+// syncStageMetrics tracks the outcomes of one sync stage.
+type syncStageMetrics struct {
+	successes   int64
+	failures    int64
+	lastSuccess time.Time
+	lastFailure time.Time
+}
+
 func minInt(a, b int) int {
 	if a < b {
 		return a
@@ -0,0 +1,275 @@
+package rpc
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// PrometheusContentType is the content type of WritePrometheus output.
+const PrometheusContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// promSnapshot is a copy of the counters needed for a Prometheus scrape.
+type promSnapshot struct {
+	counts        map[string]int64
+	errors        map[string]int64
+	histograms    map[string]*latencyHistogram
+	syncStages    map[string]syncStageMetrics
+	totalConns    int64
+	rejectedConns int64
+	uptime        time.Duration
+}
+
+func (m *Metrics) promSnapshot() promSnapshot {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	snap := promSnapshot{
+		counts:        make(map[string]int64, len(m.requestCounts)),
+		errors:        make(map[string]int64, len(m.requestErrors)),
+		histograms:    make(map[string]*latencyHistogram, len(m.requestHistograms)),
+		syncStages:    make(map[string]syncStageMetrics, len(m.syncStages)),
+		totalConns:    atomic.LoadInt64(&m.totalConns),
+		rejectedConns: atomic.LoadInt64(&m.rejectedConns),
+		uptime:        time.Since(m.startTime),
+	}
+	for op, n := range m.requestCounts {
+		snap.counts[op] = n
+	}
+	for op, n := range m.requestErrors {
+		snap.errors[op] = n
+	}
+	for op, h := range m.requestHistograms {
+		snap.histograms[op] = h.clone()
+	}
+	for stage, sm := range m.syncStages {
+		snap.syncStages[stage] = *sm
+	}
+	return snap
+}
+
+// MetricsHandler returns an HTTP handler that serves WritePrometheus.
+func (s *Server) MetricsHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", PrometheusContentType)
+		// On a write error the headers are already sent; the truncated
+		// body fails the scrape, which is the signal Prometheus needs
+		_ = s.WritePrometheus(r.Context(), w)
+	})
+}
+
+// WritePrometheus writes the daemon's request, connection and sync metrics,
+// plus storage gauges (issue counts, dirty backlog, last JSONL sync), in the
+// Prometheus text exposition format.
+func (s *Server) WritePrometheus(ctx context.Context, w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	p := &promWriter{w: bw}
+	snap := s.metrics.promSnapshot()
+
+	p.family("beads_daemon_info", "gauge", "Daemon build information.")
+	p.sample("beads_daemon_info", 1, "version", ServerVersion)
+
+	p.family("beads_daemon_uptime_seconds", "gauge", "Time since the daemon started.")
+	p.sample("beads_daemon_uptime_seconds", snap.uptime.Seconds())
+
+	ops := sortedKeys(snap.counts)
+
+	p.family("beads_daemon_requests_total", "counter", "RPC requests handled, by operation.")
+	for _, op := range ops {
+		p.sample("beads_daemon_requests_total", float64(snap.counts[op]), "operation", op)
+	}
+
+	p.family("beads_daemon_request_errors_total", "counter", "RPC requests that failed, by operation.")
+	for _, op := range sortedKeys(snap.errors) {
+		p.sample("beads_daemon_request_errors_total", float64(snap.errors[op]), "operation", op)
+	}
+
+	p.family("beads_daemon_request_duration_seconds", "histogram", "RPC request latency, by operation.")
+	for _, op := range ops {
+		h := snap.histograms[op]
+		if h == nil {
+			continue
+		}
+		var cumulative uint64
+		for i, bound := range latencyBuckets {
+			cumulative += h.buckets[i]
+			p.sample("beads_daemon_request_duration_seconds_bucket", float64(cumulative), "operation", op, "le", formatFloat(bound))
+		}
+		p.sample("beads_daemon_request_duration_seconds_bucket", float64(h.count), "operation", op, "le", "+Inf")
+		p.sample("beads_daemon_request_duration_seconds_sum", h.sum, "operation", op)
+		p.sample("beads_daemon_request_duration_seconds_count", float64(h.count), "operation", op)
+	}
+
+	p.family("beads_daemon_connections_total", "counter", "Client connections accepted.")
+	p.sample("beads_daemon_connections_total", float64(snap.totalConns))
+	p.family("beads_daemon_connections_rejected_total", "counter", "Client connections rejected at the connection limit.")
+	p.sample("beads_daemon_connections_rejected_total", float64(snap.rejectedConns))
+	p.family("beads_daemon_connections_active", "gauge", "Client connections currently open.")
+	p.sample("beads_daemon_connections_active", float64(atomic.LoadInt32(&s.activeConns)))
+
+	p.family("beads_daemon_mutation_events_dropped", "gauge", "Mutation events dropped since the daemon last drained the counter.")
+	p.sample("beads_daemon_mutation_events_dropped", float64(s.droppedEvents.Load()))
+	p.family("beads_daemon_listener_events_dropped_total", "counter", "Mutation events dropped because a listener, such as the webhook outbox, fell behind.")
+	p.sample("beads_daemon_listener_events_dropped_total", float64(s.droppedListenerEvents.Load()))
+
+	stages := make([]string, 0, len(snap.syncStages))
+	for stage := range snap.syncStages {
+		stages = append(stages, stage)
+	}
+	sort.Strings(stages)
+
+	p.family("beads_sync_total", "counter", "Sync stage runs, by stage and result.")
+	for _, stage := range stages {
+		sm := snap.syncStages[stage]
+		p.sample("beads_sync_total", float64(sm.successes), "stage", stage, "result", "success")
+		p.sample("beads_sync_total", float64(sm.failures), "stage", stage, "result", "failure")
+	}
+	p.family("beads_sync_last_success_timestamp_seconds", "gauge", "Unix time of the last successful run of each sync stage.")
+	for _, stage := range stages {
+		if t := snap.syncStages[stage].lastSuccess; !t.IsZero() {
+			p.sample("beads_sync_last_success_timestamp_seconds", unixSeconds(t), "stage", stage)
+		}
+	}
+	p.family("beads_sync_last_failure_timestamp_seconds", "gauge", "Unix time of the last failed run of each sync stage.")
+	for _, stage := range stages {
+		if t := snap.syncStages[stage].lastFailure; !t.IsZero() {
+			p.sample("beads_sync_last_failure_timestamp_seconds", unixSeconds(t), "stage", stage)
+		}
+	}
+
+	s.writeStorageMetrics(ctx, p)
+
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+	p.family("beads_daemon_goroutines", "gauge", "Goroutines in the daemon process.")
+	p.sample("beads_daemon_goroutines", float64(runtime.NumGoroutine()))
+	p.family("beads_daemon_memory_alloc_bytes", "gauge", "Heap bytes allocated and in use.")
+	p.sample("beads_daemon_memory_alloc_bytes", float64(mem.Alloc))
+
+	if p.err != nil {
+		return p.err
+	}
+	return bw.Flush()
+}
+
+// writeStorageMetrics writes gauges read from storage. Storage errors are
+// reported through beads_storage_up rather than failing the scrape.
+func (s *Server) writeStorageMetrics(ctx context.Context, p *promWriter) {
+	ctx, cancel := context.WithTimeout(ctx, s.requestTimeout)
+	defer cancel()
+
+	up := 1.0
+	if s.storage == nil {
+		up = 0
+	} else {
+		if stats, err := s.storage.GetStatistics(ctx); err != nil {
+			up = 0
+		} else {
+			writeIssueGauges(p, stats)
+		}
+
+		if dirty, err := s.storage.GetDirtyIssues(ctx); err != nil {
+			up = 0
+		} else {
+			p.family("beads_dirty_issues", "gauge", "Issues changed since the last JSONL export.")
+			p.sample("beads_dirty_issues", float64(len(dirty)))
+		}
+
+		if value, err := s.storage.GetMetadata(ctx, "last_import_time"); err == nil && value != "" {
+			if t, err := time.Parse(time.RFC3339Nano, value); err == nil {
+				p.family("beads_jsonl_last_sync_timestamp_seconds", "gauge", "Unix time the database and JSONL were last in sync (last import or export).")
+				p.sample("beads_jsonl_last_sync_timestamp_seconds", unixSeconds(t))
+			}
+		}
+	}
+
+	p.family("beads_storage_up", "gauge", "Whether the last storage read for this scrape succeeded.")
+	p.sample("beads_storage_up", up)
+}
+
+func writeIssueGauges(p *promWriter, stats *types.Statistics) {
+	p.family("beads_issues", "gauge", "Issues by status.")
+	byStatus := []struct {
+		status types.Status
+		count  int
+	}{
+		{types.StatusOpen, stats.OpenIssues},
+		{types.StatusInProgress, stats.InProgressIssues},
+		{types.StatusDeferred, stats.DeferredIssues},
+		{types.StatusClosed, stats.ClosedIssues},
+		{types.StatusTombstone, stats.TombstoneIssues},
+	}
+	for _, sc := range byStatus {
+		p.sample("beads_issues", float64(sc.count), "status", string(sc.status))
+	}
+
+	p.family("beads_issues_ready", "gauge", "Open issues with no open blockers.")
+	p.sample("beads_issues_ready", float64(stats.ReadyIssues))
+	p.family("beads_issues_blocked", "gauge", "Issues blocked by open dependencies.")
+	p.sample("beads_issues_blocked", float64(stats.BlockedIssues))
+	p.family("beads_issues_pinned", "gauge", "Pinned issues.")
+	p.sample("beads_issues_pinned", float64(stats.PinnedIssues))
+}
+
+// promWriter writes Prometheus text format, remembering the first error.
+type promWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (p *promWriter) family(name, typ, help string) {
+	p.printf("# HELP %s %s\n# TYPE %s %s\n", name, help, name, typ)
+}
+
+// sample writes one sample; labels are name/value pairs.
+func (p *promWriter) sample(name string, value float64, labels ...string) {
+	if len(labels) == 0 {
+		p.printf("%s %s\n", name, formatFloat(value))
+		return
+	}
+	pairs := make([]string, 0, len(labels)/2)
+	for i := 0; i+1 < len(labels); i += 2 {
+		pairs = append(pairs, labels[i]+`="`+escapeLabelValue(labels[i+1])+`"`)
+	}
+	p.printf("%s{%s} %s\n", name, strings.Join(pairs, ","), formatFloat(value))
+}
+
+func (p *promWriter) printf(format string, args ...any) {
+	if p.err != nil {
+		return
+	}
+	_, p.err = fmt.Fprintf(p.w, format, args...)
+}
+
+var labelValueEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func escapeLabelValue(v string) string {
+	return labelValueEscaper.Replace(v)
+}
+
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+func unixSeconds(t time.Time) float64 {
+	return float64(t.UnixNano()) / 1e9
+}
+
+func sortedKeys(m map[string]int64) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
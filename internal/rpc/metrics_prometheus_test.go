@@ -0,0 +1,109 @@
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage/memory"
+)
+
+// promLine matches a sample line of the Prometheus text format.
+var promLine = regexp.MustCompile(`^[a-zA-Z_:][a-zA-Z0-9_:]*(\{[a-zA-Z_][a-zA-Z0-9_]*="(?:[^"\\]|\\.)*"(,[a-zA-Z_][a-zA-Z0-9_]*="(?:[^"\\]|\\.)*")*\})? \S+$`)
+
+func TestWritePrometheus(t *testing.T) {
+	store := memory.New("/tmp/test.jsonl")
+	server := NewServer("/tmp/test.sock", store, "/tmp", "/tmp/test.db")
+	ctx := context.Background()
+
+	args, _ := json.Marshal(CreateArgs{Title: "Metric me", IssueType: "task", Priority: 2})
+	if resp := server.handleRequest(&Request{Operation: OpCreate, Args: args, ExpectedDB: store.Path()}); !resp.Success {
+		t.Fatalf("create failed: %s", resp.Error)
+	}
+	server.handleRequest(&Request{Operation: "bogus", ExpectedDB: store.Path()})
+	server.metrics.RecordSync(SyncStageExport, nil)
+	server.metrics.RecordSync(SyncStagePull, errors.New("offline"))
+	if err := store.SetMetadata(ctx, "last_import_time", time.Unix(1700000000, 0).Format(time.RFC3339Nano)); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := server.WritePrometheus(ctx, &buf); err != nil {
+		t.Fatalf("WritePrometheus() error = %v", err)
+	}
+	out := buf.String()
+
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		if strings.HasPrefix(line, "# HELP ") || strings.HasPrefix(line, "# TYPE ") {
+			continue
+		}
+		if !promLine.MatchString(line) {
+			t.Errorf("malformed line: %q", line)
+		}
+	}
+
+	want := []string{
+		`# TYPE beads_daemon_request_duration_seconds histogram`,
+		`beads_daemon_requests_total{operation="create"} 1`,
+		`beads_daemon_request_errors_total{operation="bogus"} 1`,
+		`beads_daemon_request_duration_seconds_bucket{operation="create",le="+Inf"} 1`,
+		`beads_daemon_request_duration_seconds_count{operation="create"} 1`,
+		`beads_issues{status="open"} 1`,
+		`beads_issues{status="closed"} 0`,
+		`beads_issues_ready 1`,
+		`beads_dirty_issues `,
+		`beads_jsonl_last_sync_timestamp_seconds 1.7e+09`,
+		`beads_sync_total{stage="export",result="success"} 1`,
+		`beads_sync_total{stage="pull",result="failure"} 1`,
+		`beads_sync_last_failure_timestamp_seconds{stage="pull"} `,
+		`beads_storage_up 1`,
+	}
+	for _, w := range want {
+		if !strings.Contains(out, w) {
+			t.Errorf("output missing %q", w)
+		}
+	}
+	if strings.Contains(out, `beads_sync_last_success_timestamp_seconds{stage="pull"}`) {
+		t.Error("stage without successes should have no last-success sample")
+	}
+}
+
+func TestEscapeLabelValue(t *testing.T) {
+	if got := escapeLabelValue("a\"b\\c\nd"); got != `a\"b\\c\nd` {
+		t.Errorf("escapeLabelValue() = %q", got)
+	}
+}
+
+func TestHTTPGatewayMetrics(t *testing.T) {
+	_, ts := newTestGateway(t)
+
+	resp, err := http.Get(ts.URL + "/metrics")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("unauthenticated status = %d, want 401", resp.StatusCode)
+	}
+
+	status, body := gatewayDo(t, ts, http.MethodGet, "/metrics", nil)
+	if status != http.StatusOK {
+		t.Fatalf("status = %d", status)
+	}
+	if !strings.Contains(string(body), "beads_daemon_uptime_seconds") {
+		t.Errorf("unexpected metrics body:\n%s", body)
+	}
+
+	rec := httptest.NewRecorder()
+	NewServer("/tmp/test.sock", memory.New(""), "/tmp", "/tmp/test.db").MetricsHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if ct := rec.Header().Get("Content-Type"); ct != PrometheusContentType {
+		t.Errorf("Content-Type = %q", ct)
+	}
+}
@@ -1,6 +1,7 @@
 package rpc
 
 import (
+	"errors"
 	"testing"
 	"time"
 )
@@ -236,3 +237,44 @@ func TestMinHelper(t *testing.T) {
 		t.Error("min(7, 7) should be 7")
 	}
 }
+
+func TestLatencyHistogram(t *testing.T) {
+	m := NewMetrics()
+	m.maxSamples = 2 // The histogram must not be bounded by the sample window
+
+	m.RecordRequest("list", 500*time.Microsecond) // le 0.001
+	m.RecordRequest("list", 3*time.Millisecond)   // le 0.005
+	m.RecordRequest("list", 5*time.Millisecond)   // le 0.005 (bounds are inclusive)
+	m.RecordRequest("list", 45*time.Second)       // +Inf
+
+	m.mu.RLock()
+	h := m.requestHistograms["list"].clone()
+	m.mu.RUnlock()
+
+	if h.count != 4 {
+		t.Errorf("count = %d, want 4", h.count)
+	}
+	if h.buckets[0] != 1 || h.buckets[2] != 2 || h.buckets[len(latencyBuckets)] != 1 {
+		t.Errorf("buckets = %v", h.buckets)
+	}
+	if want := 45.0085; h.sum < want-1e-9 || h.sum > want+1e-9 {
+		t.Errorf("sum = %v, want %v", h.sum, want)
+	}
+}
+
+func TestRecordSync(t *testing.T) {
+	m := NewMetrics()
+	m.RecordSync(SyncStageExport, nil)
+	m.RecordSync(SyncStageExport, nil)
+	m.RecordSync(SyncStagePush, errors.New("rejected"))
+
+	snap := m.promSnapshot()
+	export := snap.syncStages[SyncStageExport]
+	if export.successes != 2 || export.failures != 0 || export.lastSuccess.IsZero() {
+		t.Errorf("export = %+v", export)
+	}
+	push := snap.syncStages[SyncStagePush]
+	if push.successes != 0 || push.failures != 1 || push.lastFailure.IsZero() {
+		t.Errorf("push = %+v", push)
+	}
+}
@@ -48,6 +48,7 @@ const (
 	OpGetParentIDs        = "get_parent_ids"
 	OpGetGraphData        = "get_graph_data"
 	OpWaitForMutations    = "wait_for_mutations"
+	OpHeartbeat           = "heartbeat"
 
 	// Gate operations
 	OpGateCreate = "gate_create"
@@ -115,6 +116,9 @@ type CreateArgs struct {
 	// Time-based scheduling fields (GH#820)
 	DueAt      string `json:"due_at,omitempty"`      // Relative or ISO format due date
 	DeferUntil string `json:"defer_until,omitempty"` // Relative or ISO format defer date
+	Recurrence string `json:"recurrence,omitempty"`  // Recurrence rule ("every monday 09:00", cron)
+	// Custom field values by name, validated against custom_fields in config.yaml
+	CustomFields map[string]string `json:"custom_fields,omitempty"`
 }
 
 // UpdateArgs represents arguments for the update operation
@@ -161,10 +165,16 @@ type UpdateArgs struct {
 	EventTarget   *string `json:"event_target,omitempty"`   // Entity URI or bead ID affected
 	EventPayload  *string `json:"event_payload,omitempty"`  // Event-specific JSON data
 	// Work queue claim operation
-	Claim bool `json:"claim,omitempty"` // If true, atomically claim issue (set assignee+status, fail if already claimed)
+	Claim bool   `json:"claim,omitempty"` // If true, atomically claim issue (set assignee+status, fail if already claimed)
+	Lease string `json:"lease,omitempty"` // With Claim: duration (e.g. "30m") after which the claim lapses unless renewed
+	// Force a status change the configured workflow rejects (recorded as a workflow_override event)
+	Force bool `json:"force,omitempty"`
 	// Time-based scheduling fields (GH#820)
 	DueAt      *string `json:"due_at,omitempty"`      // Relative or ISO format due date
 	DeferUntil *string `json:"defer_until,omitempty"` // Relative or ISO format defer date
+	Recurrence *string `json:"recurrence,omitempty"`  // Recurrence rule; empty string clears it
+	// Custom field changes by name; an empty value clears the field
+	CustomFields map[string]string `json:"custom_fields,omitempty"`
 	// Gate fields
 	AwaitID *string  `json:"await_id,omitempty"` // Condition identifier for gates (run ID, PR number, etc.)
 	Waiters []string `json:"waiters,omitempty"`  // Mail addresses to notify when gate clears
@@ -178,7 +188,7 @@ type CloseArgs struct {
 	Reason      string `json:"reason,omitempty"`
 	Session     string `json:"session,omitempty"`      // Claude Code session ID that closed this issue
 	SuggestNext bool   `json:"suggest_next,omitempty"` // Return newly unblocked issues (GH#679)
-	Force       bool   `json:"force,omitempty"`        // Force close even with open blockers (GH#962)
+	Force       bool   `json:"force,omitempty"`        // Force close even with open blockers (GH#962) or workflow rules
 }
 
 // CloseResult is returned when SuggestNext is true (GH#679)
@@ -260,9 +270,21 @@ type ListArgs struct {
 	DueAfter    string `json:"due_after,omitempty"`    // ISO 8601 format
 	DueBefore   string `json:"due_before,omitempty"`   // ISO 8601 format
 	Overdue     bool   `json:"overdue,omitempty"`      // Filter issues where due_at < now
+	Recurring   bool   `json:"recurring,omitempty"`    // Filter issues with a recurrence rule
+
+	// Custom field filters (AND semantics)
+	CustomFields []types.CustomFieldFilter `json:"custom_fields,omitempty"`
+
+	// Filter expression (see internal/query)
+	Where string `json:"where,omitempty"`
 
 	// Staleness control (bd-dpkdm)
 	AllowStale bool `json:"allow_stale,omitempty"` // Skip staleness check, return potentially stale data
+
+	// Full-text search: treat Query as a ranked search query and return
+	// []*types.SearchResult (IssueWithCounts plus score and snippet) instead
+	// of []*types.IssueWithCounts
+	Ranked bool `json:"ranked,omitempty"`
 }
 
 // CountArgs represents arguments for the count operation
@@ -299,6 +321,9 @@ type CountArgs struct {
 	PriorityMin *int `json:"priority_min,omitempty"`
 	PriorityMax *int `json:"priority_max,omitempty"`
 
+	// Filter expression (see internal/query)
+	Where string `json:"where,omitempty"`
+
 	// Grouping option (only one can be specified)
 	GroupBy string `json:"group_by,omitempty"` // "status", "priority", "type", "assignee", "label"
 }
@@ -326,6 +351,7 @@ type ReadyArgs struct {
 	ParentID        string   `json:"parent_id,omitempty"`        // Filter to descendants of this bead/epic
 	MolType         string   `json:"mol_type,omitempty"`         // Filter by molecule type: swarm, patrol, or work
 	IncludeDeferred bool     `json:"include_deferred,omitempty"` // Include issues with future defer_until (GH#820)
+	Where           string   `json:"where,omitempty"`            // Filter expression (see internal/query)
 }
 
 // BlockedArgs represents arguments for the blocked operation
@@ -362,6 +388,7 @@ type DepRemoveArgs struct {
 type DepTreeArgs struct {
 	ID       string `json:"id"`
 	MaxDepth int    `json:"max_depth,omitempty"`
+	Reverse  bool   `json:"reverse,omitempty"` // Show dependents instead of dependencies
 }
 
 // LabelAddArgs represents arguments for adding a label
@@ -466,6 +493,11 @@ type CompactArgs struct {
 	APIKey    string `json:"api_key,omitempty"`
 	Workers   int    `json:"workers,omitempty"`
 	BatchSize int    `json:"batch_size,omitempty"`
+
+	// Summarizer backend; empty fields fall back to the daemon's config
+	Summarizer string `json:"summarizer,omitempty"`
+	Model      string `json:"model,omitempty"`
+	Endpoint   string `json:"endpoint,omitempty"`
 }
 
 // CompactStatsArgs represents arguments for compact stats operation
@@ -484,16 +516,18 @@ type CompactResponse struct {
 	Reduction    string            `json:"reduction,omitempty"`
 	Duration     string            `json:"duration,omitempty"`
 	DryRun       bool              `json:"dry_run,omitempty"`
+	RolledUp     []string          `json:"rolled_up,omitempty"` // Tier 2: children archived into the epic
 }
 
 // CompactResult represents the result of compacting a single issue
 type CompactResult struct {
-	IssueID       string `json:"issue_id"`
-	Success       bool   `json:"success"`
-	Error         string `json:"error,omitempty"`
-	OriginalSize  int    `json:"original_size,omitempty"`
-	CompactedSize int    `json:"compacted_size,omitempty"`
-	Reduction     string `json:"reduction,omitempty"`
+	IssueID       string   `json:"issue_id"`
+	Success       bool     `json:"success"`
+	Error         string   `json:"error,omitempty"`
+	OriginalSize  int      `json:"original_size,omitempty"`
+	CompactedSize int      `json:"compacted_size,omitempty"`
+	Reduction     string   `json:"reduction,omitempty"`
+	RolledUp      []string `json:"rolled_up,omitempty"`
 }
 
 // CompactStatsData represents compaction statistics
@@ -523,8 +557,9 @@ type GetMutationsArgs struct {
 
 // WaitForMutationsArgs represents arguments for waiting on mutations
 type WaitForMutationsArgs struct {
-	Since   int64 `json:"since"`   // Unix timestamp in ms - return mutations after this time
-	Timeout int64 `json:"timeout"` // Max wait time in ms (0 = default 30s)
+	Since    int64  `json:"since"`               // Unix timestamp in ms - return mutations after this time
+	AfterSeq uint64 `json:"after_seq,omitempty"` // Return mutations with a higher Seq (0 = no filter)
+	Timeout  int64  `json:"timeout"`             // Max wait time in ms (0 = default 30s)
 }
 
 // Gate operations
@@ -578,15 +613,24 @@ type GetWorkerStatusArgs struct {
 
 // WorkerStatus represents the status of a single worker and their current work
 type WorkerStatus struct {
-	Assignee      string `json:"assignee"`                 // Worker identifier
-	MoleculeID    string `json:"molecule_id,omitempty"`    // Parent molecule/epic ID (if working on a step)
-	MoleculeTitle string `json:"molecule_title,omitempty"` // Parent molecule/epic title
-	CurrentStep   int    `json:"current_step,omitempty"`   // Current step number (1-indexed)
-	TotalSteps    int    `json:"total_steps,omitempty"`    // Total number of steps in molecule
-	StepID        string `json:"step_id,omitempty"`        // Current step issue ID
-	StepTitle     string `json:"step_title,omitempty"`     // Current step issue title
-	LastActivity  string `json:"last_activity"`            // ISO 8601 timestamp of last update
-	Status        string `json:"status"`                   // Current work status (in_progress, blocked, etc.)
+	Assignee       string `json:"assignee"`                   // Worker identifier
+	MoleculeID     string `json:"molecule_id,omitempty"`      // Parent molecule/epic ID (if working on a step)
+	MoleculeTitle  string `json:"molecule_title,omitempty"`   // Parent molecule/epic title
+	CurrentStep    int    `json:"current_step,omitempty"`     // Current step number (1-indexed)
+	TotalSteps     int    `json:"total_steps,omitempty"`      // Total number of steps in molecule
+	StepID         string `json:"step_id,omitempty"`          // Current step issue ID
+	StepTitle      string `json:"step_title,omitempty"`       // Current step issue title
+	LastActivity   string `json:"last_activity"`              // ISO 8601 timestamp of last update
+	Status         string `json:"status"`                     // Current work status (in_progress, blocked, etc.)
+	LeaseExpiresAt string `json:"lease_expires_at,omitempty"` // ISO 8601 deadline of a leased claim
+	LeaseExpired   bool   `json:"lease_expired,omitempty"`    // Lease lapsed; the daemon will reopen the issue
+}
+
+// HeartbeatArgs represents arguments for the heartbeat operation, which
+// renews the caller's lease on a claimed issue
+type HeartbeatArgs struct {
+	ID    string `json:"id"`
+	Lease string `json:"lease"` // New lease duration from now (e.g. "30m")
 }
 
 // GetWorkerStatusResponse is the response for get_worker_status operation
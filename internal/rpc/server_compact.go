@@ -38,11 +38,22 @@ func (s *Server) handleCompact(req *Request) Response {
 		APIKey:      args.APIKey,
 		Concurrency: args.Workers,
 		DryRun:      args.DryRun,
+		Summarizer:  args.Summarizer,
+		Model:       args.Model,
+		Endpoint:    args.Endpoint,
 	}
 	if config.Concurrency <= 0 {
 		config.Concurrency = 5
 	}
 
+	ctx := s.reqCtx(req)
+	if err := config.LoadSummarizerConfig(ctx, sqliteStore); err != nil {
+		return Response{
+			Success: false,
+			Error:   err.Error(),
+		}
+	}
+
 	compactor, err := compact.New(sqliteStore, args.APIKey, config)
 	if err != nil {
 		return Response{
@@ -51,7 +62,6 @@ func (s *Server) handleCompact(req *Request) Response {
 		}
 	}
 
-	ctx := s.reqCtx(req)
 	startTime := time.Now()
 
 	if args.IssueID != "" {
@@ -82,12 +92,21 @@ func (s *Server) handleCompact(req *Request) Response {
 		originalSize := len(issue.Description) + len(issue.Design) + len(issue.Notes) + len(issue.AcceptanceCriteria)
 
 		if args.DryRun {
+			var rolledUp []string
+			if args.Tier == 2 {
+				// In dry-run mode CompactTier2 only measures the rollup
+				if tier2, _ := compactor.CompactTier2(ctx, args.IssueID); tier2 != nil {
+					originalSize = tier2.OriginalSize
+					rolledUp = tier2.Children
+				}
+			}
 			result := CompactResponse{
 				Success:      true,
 				IssueID:      args.IssueID,
 				OriginalSize: originalSize,
 				Reduction:    "70-80%",
 				DryRun:       true,
+				RolledUp:     rolledUp,
 			}
 			data, _ := json.Marshal(result)
 			return Response{
@@ -96,13 +115,16 @@ func (s *Server) handleCompact(req *Request) Response {
 			}
 		}
 
-		if args.Tier == 1 {
-			err = compactor.CompactTier1(ctx, args.IssueID)
-		} else {
-			return Response{
-				Success: false,
-				Error:   "Tier 2 compaction not yet implemented",
+		var rolledUp []string
+		if args.Tier == 2 {
+			var tier2 *compact.Result
+			tier2, err = compactor.CompactTier2(ctx, args.IssueID)
+			if tier2 != nil {
+				originalSize = tier2.OriginalSize
+				rolledUp = tier2.Children
 			}
+		} else {
+			err = compactor.CompactTier1(ctx, args.IssueID)
 		}
 
 		if err != nil {
@@ -126,6 +148,7 @@ func (s *Server) handleCompact(req *Request) Response {
 			CompactedSize: compactedSize,
 			Reduction:     fmt.Sprintf("%.1f%%", float64(originalSize-compactedSize)/float64(originalSize)*100),
 			Duration:      duration.String(),
+			RolledUp:      rolledUp,
 		}
 		data, _ := json.Marshal(result)
 		return Response{
@@ -180,7 +203,12 @@ func (s *Server) handleCompact(req *Request) Response {
 			issueIDs[i] = c.IssueID
 		}
 
-		batchResults, err := compactor.CompactTier1Batch(ctx, issueIDs)
+		var batchResults []*compact.Result
+		if args.Tier == 2 {
+			batchResults, err = compactor.CompactTier2Batch(ctx, issueIDs)
+		} else {
+			batchResults, err = compactor.CompactTier1Batch(ctx, issueIDs)
+		}
 		if err != nil {
 			return Response{
 				Success: false,
@@ -195,6 +223,7 @@ func (s *Server) handleCompact(req *Request) Response {
 				Success:       r.Err == nil,
 				OriginalSize:  r.OriginalSize,
 				CompactedSize: r.CompactedSize,
+				RolledUp:      r.Children,
 			}
 			if r.Err != nil {
 				result.Error = r.Err.Error()
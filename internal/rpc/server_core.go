@@ -56,6 +56,19 @@ type Server struct {
 	recentMutations   []MutationEvent
 	recentMutationsMu sync.RWMutex
 	maxMutationBuffer int
+	// Sequence number of the last emitted mutation (guarded by recentMutationsMu)
+	mutationSeq uint64
+	// Closed and replaced on every mutation to wake wait_for_mutations callers
+	// without consuming events from mutationChan (guarded by recentMutationsMu)
+	mutationNotify chan struct{}
+	// Mutation listeners (e.g. TUI refresh), fed through one buffered
+	// channel each; events a full channel can't take are counted and dropped
+	mutationListeners     []chan MutationEvent
+	// Listeners that must see every event (e.g. the webhook outbox), called
+	// inline on the emitting goroutine (guarded by mutationListenersMu)
+	syncMutationListeners []func(MutationEvent)
+	mutationListenersMu   sync.RWMutex
+	droppedListenerEvents atomic.Int64
 	// Daemon configuration (set via SetConfig after creation)
 	autoCommit   bool
 	autoPush     bool
@@ -86,6 +99,9 @@ type MutationEvent struct {
 	Assignee  string    // Issue assignee for display context (may be empty)
 	Actor     string    // Who performed the action (may differ from assignee)
 	Timestamp time.Time
+	// Seq increases by one per mutation on this server, so events emitted in
+	// the same millisecond still have distinct, ordered cursors
+	Seq uint64 `json:"seq,omitempty"`
 	// Optional metadata for richer events (used by status, bonded, etc.)
 	OldStatus string `json:"old_status,omitempty"` // Previous status (for status events)
 	NewStatus string `json:"new_status,omitempty"` // New status (for status events)
@@ -135,6 +151,7 @@ func NewServer(socketPath string, store storage.Storage, workspacePath string, d
 		mutationChan:      make(chan MutationEvent, mutationBufferSize), // Configurable buffer
 		recentMutations:   make([]MutationEvent, 0, 100),
 		maxMutationBuffer: 100,
+		mutationNotify:    make(chan struct{}),
 	}
 	s.lastActivityTime.Store(time.Now())
 	return s
@@ -162,6 +179,20 @@ func (s *Server) emitRichMutation(event MutationEvent) {
 		event.Timestamp = time.Now()
 	}
 
+	// Number the event and store it in the recent mutations buffer for
+	// polling. Both happen under one lock so the buffer is in Seq order.
+	s.recentMutationsMu.Lock()
+	s.mutationSeq++
+	event.Seq = s.mutationSeq
+	s.recentMutations = append(s.recentMutations, event)
+	// Keep buffer size limited (circular buffer behavior)
+	if len(s.recentMutations) > s.maxMutationBuffer {
+		s.recentMutations = s.recentMutations[1:]
+	}
+	close(s.mutationNotify)
+	s.mutationNotify = make(chan struct{})
+	s.recentMutationsMu.Unlock()
+
 	// Send to mutation channel for daemon
 	select {
 	case s.mutationChan <- event:
@@ -171,14 +202,66 @@ func (s *Server) emitRichMutation(event MutationEvent) {
 		s.droppedEvents.Add(1)
 	}
 
-	// Store in recent mutations buffer for polling
-	s.recentMutationsMu.Lock()
-	s.recentMutations = append(s.recentMutations, event)
-	// Keep buffer size limited (circular buffer behavior)
-	if len(s.recentMutations) > s.maxMutationBuffer {
-		s.recentMutations = s.recentMutations[1:]
+	// Notify listeners. Sync listeners run first, on this goroutine, so
+	// they never miss an event. Like mutationChan, an async listener that
+	// falls a full buffer behind misses events rather than holding up the
+	// request.
+	s.mutationListenersMu.RLock()
+	syncListeners := s.syncMutationListeners
+	listeners := s.mutationListeners
+	s.mutationListenersMu.RUnlock()
+	for _, listener := range syncListeners {
+		listener(event)
 	}
-	s.recentMutationsMu.Unlock()
+	for _, events := range listeners {
+		select {
+		case events <- event:
+		default:
+			s.droppedListenerEvents.Add(1)
+		}
+	}
+}
+
+// OnMutation registers a listener that is called for every mutation event,
+// in order, after the event is recorded. Each listener runs on its own
+// goroutine, so slow listeners stay off the RPC request path. A listener
+// that falls a full buffer behind misses events, which are counted in
+// beads_daemon_listener_events_dropped_total; use OnMutationSync for
+// listeners that can't afford that. Events still buffered when
+// the server stops are delivered before the goroutine exits.
+func (s *Server) OnMutation(listener func(MutationEvent)) {
+	events := make(chan MutationEvent, cap(s.mutationChan))
+	s.mutationListenersMu.Lock()
+	s.mutationListeners = append(s.mutationListeners, events)
+	s.mutationListenersMu.Unlock()
+
+	go func() {
+		for {
+			select {
+			case event := <-events:
+				listener(event)
+			case <-s.shutdownChan:
+				for {
+					select {
+					case event := <-events:
+						listener(event)
+					default:
+						return
+					}
+				}
+			}
+		}
+	}()
+}
+
+// OnMutationSync registers a listener that is called for every mutation
+// event on the goroutine that emitted it, before the request returns. Use
+// it for listeners that must not miss events, like the webhook outbox,
+// and keep the listener fast: it runs on the RPC request path.
+func (s *Server) OnMutationSync(listener func(MutationEvent)) {
+	s.mutationListenersMu.Lock()
+	s.syncMutationListeners = append(s.syncMutationListeners, listener)
+	s.mutationListenersMu.Unlock()
 }
 
 // MutationChan returns the mutation event channel for the daemon to consume
@@ -186,6 +269,12 @@ func (s *Server) MutationChan() <-chan MutationEvent {
 	return s.mutationChan
 }
 
+// Metrics returns the server's metrics collector, so the daemon can record
+// sync outcomes alongside request metrics.
+func (s *Server) Metrics() *Metrics {
+	return s.metrics
+}
+
 // SetConfig sets the daemon configuration for status reporting
 func (s *Server) SetConfig(autoCommit, autoPush, autoPull, localMode bool, syncInterval, daemonMode string) {
 	s.mu.Lock()
@@ -205,16 +294,33 @@ func (s *Server) ResetDroppedEventsCount() int64 {
 
 // GetRecentMutations returns mutations since the given timestamp
 func (s *Server) GetRecentMutations(sinceMillis int64) []MutationEvent {
+	mutations, _ := s.recentMutationsSince(sinceMillis, 0)
+	return mutations
+}
+
+// lastMutationSeq returns the sequence number of the most recent mutation,
+// or 0 if there has been none.
+func (s *Server) lastMutationSeq() uint64 {
+	s.recentMutationsMu.RLock()
+	defer s.recentMutationsMu.RUnlock()
+	return s.mutationSeq
+}
+
+// recentMutationsSince returns mutations after the given timestamp and
+// sequence number together with a channel that is closed on the next
+// mutation. Both are read under the same lock so a waiter cannot miss an
+// event emitted in between.
+func (s *Server) recentMutationsSince(sinceMillis int64, afterSeq uint64) ([]MutationEvent, <-chan struct{}) {
 	s.recentMutationsMu.RLock()
 	defer s.recentMutationsMu.RUnlock()
 
 	var result []MutationEvent
 	for _, m := range s.recentMutations {
-		if m.Timestamp.UnixMilli() > sinceMillis {
+		if m.Timestamp.UnixMilli() > sinceMillis && m.Seq > afterSeq {
 			result = append(result, m)
 		}
 	}
-	return result
+	return result, s.mutationNotify
 }
 
 // handleGetMutations handles the get_mutations RPC operation
@@ -255,7 +361,7 @@ func (s *Server) handleWaitForMutations(req *Request) Response {
 	}
 
 	// First check for existing mutations since the timestamp
-	mutations := s.GetRecentMutations(args.Since)
+	mutations, notify := s.recentMutationsSince(args.Since, args.AfterSeq)
 	if len(mutations) > 0 {
 		data, _ := json.Marshal(mutations)
 		return Response{
@@ -269,19 +375,12 @@ func (s *Server) handleWaitForMutations(req *Request) Response {
 	defer timer.Stop()
 
 	select {
-	case _, ok := <-s.mutationChan:
-		if !ok {
-			// Channel closed (server shutting down)
-			data, _ := json.Marshal([]MutationEvent{})
-			return Response{
-				Success: true,
-				Data:    data,
-			}
-		}
+	case <-notify:
 		// Got a mutation notification. The mutation is already stored in the
 		// recent buffer by emitRichMutation(), so we just return all mutations
-		// since the requested timestamp.
-		mutations := s.GetRecentMutations(args.Since)
+		// since the requested timestamp. Waiting on the notify channel rather
+		// than mutationChan leaves events for the daemon's event loop.
+		mutations, _ := s.recentMutationsSince(args.Since, args.AfterSeq)
 		data, _ := json.Marshal(mutations)
 		return Response{
 			Success: true,
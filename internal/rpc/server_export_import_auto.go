@@ -137,6 +137,58 @@ func (s *Server) handleExport(req *Request) Response {
 		issue.Comments = allComments[issue.ID]
 	}
 
+	// Populate work log for all issues (enrichment data)
+	var allWorkLog map[string][]*types.WorkLogEntry
+	result = export.FetchWithPolicy(ctx, cfg, export.DataTypeWorkLog, "get work log", func() error {
+		var err error
+		allWorkLog, err = store.GetWorkLogForIssues(ctx, issueIDs)
+		return err
+	})
+	if result.Err != nil {
+		return Response{
+			Success: false,
+			Error:   fmt.Sprintf("failed to get work log: %v", result.Err),
+		}
+	}
+	if !result.Success {
+		// Work log fetch failed but policy allows continuing
+		allWorkLog = make(map[string][]*types.WorkLogEntry) // Empty map
+		if manifest != nil {
+			manifest.PartialData = append(manifest.PartialData, "work_log")
+			manifest.Warnings = append(manifest.Warnings, result.Warnings...)
+			manifest.Complete = false
+		}
+	}
+	for _, issue := range issues {
+		issue.WorkLog = allWorkLog[issue.ID]
+	}
+
+	// Populate attachments for all issues (enrichment data)
+	var allAttachments map[string][]*types.Attachment
+	result = export.FetchWithPolicy(ctx, cfg, export.DataTypeAttachments, "get attachments", func() error {
+		var err error
+		allAttachments, err = store.GetAttachmentsForIssues(ctx, issueIDs)
+		return err
+	})
+	if result.Err != nil {
+		return Response{
+			Success: false,
+			Error:   fmt.Sprintf("failed to get attachments: %v", result.Err),
+		}
+	}
+	if !result.Success {
+		// Attachment fetch failed but policy allows continuing
+		allAttachments = make(map[string][]*types.Attachment) // Empty map
+		if manifest != nil {
+			manifest.PartialData = append(manifest.PartialData, "attachments")
+			manifest.Warnings = append(manifest.Warnings, result.Warnings...)
+			manifest.Complete = false
+		}
+	}
+	for _, issue := range issues {
+		issue.Attachments = allAttachments[issue.ID]
+	}
+
 	// Create temp file for atomic write
 	dir := filepath.Dir(exportArgs.JSONLPath)
 	base := filepath.Base(exportArgs.JSONLPath)
@@ -259,9 +311,11 @@ func (s *Server) checkAndAutoImportIfStale(req *Request) error {
 	ctx := s.reqCtx(req)
 
 	// Get database path from storage
+	// Only SQLite databases are kept in step with the JSONL this way; other
+	// backends (e.g. Dolt in a local client) have nothing to check.
 	sqliteStore, ok := store.(*sqlite.SQLiteStorage)
 	if !ok {
-		return fmt.Errorf("storage is not SQLiteStorage")
+		return nil
 	}
 	dbPath := sqliteStore.Path()
 
@@ -374,6 +428,7 @@ func (s *Server) checkAndAutoImportIfStale(req *Request) error {
 
 	// Perform import with timeout (still synchronous but won't hang forever)
 	err = autoimport.AutoImportIfNewer(importCtx, store, dbPath, notify, importFunc, onChanged)
+	s.metrics.RecordSync(SyncStageImport, err)
 	if err != nil {
 		if importCtx.Err() == context.DeadlineExceeded {
 			fmt.Fprintf(os.Stderr, "Error: auto-import timed out after 5s. Run 'bd sync --import-only' manually.\n")
@@ -533,6 +588,42 @@ func (s *Server) triggerExport(ctx context.Context, store storage.Storage, dbPat
 		issue.Comments = allComments[issue.ID]
 	}
 
+	// Populate work log for all issues (enrichment data)
+	var allWorkLog map[string][]*types.WorkLogEntry
+	result = export.FetchWithPolicy(ctx, cfg, export.DataTypeWorkLog, "get work log", func() error {
+		var err error
+		allWorkLog, err = store.GetWorkLogForIssues(ctx, issueIDs)
+		return err
+	})
+	if result.Err != nil {
+		return fmt.Errorf("failed to get work log: %w", result.Err)
+	}
+	if !result.Success {
+		// Work log fetch failed but policy allows continuing
+		allWorkLog = make(map[string][]*types.WorkLogEntry) // Empty map
+	}
+	for _, issue := range allIssues {
+		issue.WorkLog = allWorkLog[issue.ID]
+	}
+
+	// Populate attachments for all issues (enrichment data)
+	var allAttachments map[string][]*types.Attachment
+	result = export.FetchWithPolicy(ctx, cfg, export.DataTypeAttachments, "get attachments", func() error {
+		var err error
+		allAttachments, err = store.GetAttachmentsForIssues(ctx, issueIDs)
+		return err
+	})
+	if result.Err != nil {
+		return fmt.Errorf("failed to get attachments: %w", result.Err)
+	}
+	if !result.Success {
+		// Attachment fetch failed but policy allows continuing
+		allAttachments = make(map[string][]*types.Attachment) // Empty map
+	}
+	for _, issue := range allIssues {
+		issue.Attachments = allAttachments[issue.ID]
+	}
+
 	// Write to JSONL file with atomic replace (temp file + rename)
 	dir := filepath.Dir(jsonlPath)
 	base := filepath.Base(jsonlPath)
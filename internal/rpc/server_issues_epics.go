@@ -8,10 +8,14 @@ import (
 	"strings"
 	"time"
 
+	"github.com/steveyegge/beads/internal/query"
+	"github.com/steveyegge/beads/internal/ranking"
 	"github.com/steveyegge/beads/internal/storage/sqlite"
+	"github.com/steveyegge/beads/internal/timeparsing"
 	"github.com/steveyegge/beads/internal/types"
 	"github.com/steveyegge/beads/internal/util"
 	"github.com/steveyegge/beads/internal/utils"
+	"github.com/steveyegge/beads/internal/validation"
 )
 
 // containsLabel checks if a label exists in the list
@@ -187,6 +191,14 @@ func updatesFromArgs(a UpdateArgs) (map[string]interface{}, error) {
 			}
 		}
 	}
+	if a.Recurrence != nil {
+		if *a.Recurrence != "" {
+			if _, err := timeparsing.ParseRecurrence(*a.Recurrence); err != nil {
+				return nil, fmt.Errorf("invalid recurrence %q: %w", *a.Recurrence, err)
+			}
+		}
+		u["recurrence"] = *a.Recurrence
+	}
 	return u, nil
 }
 
@@ -286,6 +298,24 @@ func (s *Server) handleCreate(req *Request) Response {
 		}
 	}
 
+	if createArgs.Recurrence != "" {
+		if _, err := timeparsing.ParseRecurrence(createArgs.Recurrence); err != nil {
+			return Response{
+				Success: false,
+				Error:   fmt.Sprintf("invalid recurrence %q: %v", createArgs.Recurrence, err),
+			}
+		}
+	}
+
+	// Validate custom fields against the schema in config.yaml
+	customFields, err := validation.CustomFieldsForCreate(types.IssueType(createArgs.IssueType).Normalize(), createArgs.CustomFields)
+	if err != nil {
+		return Response{
+			Success: false,
+			Error:   err.Error(),
+		}
+	}
+
 	issue := &types.Issue{
 		ID:                 issueID,
 		Title:              createArgs.Title,
@@ -320,6 +350,9 @@ func (s *Server) handleCreate(req *Request) Response {
 		// Time-based scheduling (GH#820, GH#950, GH#952)
 		DueAt:      dueAt,
 		DeferUntil: deferUntil,
+		Recurrence: createArgs.Recurrence,
+		// Custom fields
+		CustomFields: customFields,
 	}
 	
 	// Check if any dependencies are discovered-from type
@@ -541,6 +574,9 @@ func (s *Server) handleUpdate(req *Request) Response {
 	}
 
 	ctx := s.reqCtx(req)
+	if updateArgs.Force {
+		ctx = validation.WithWorkflowForce(ctx)
+	}
 
 	// Check if issue is a template (beads-1ra): templates are read-only
 	issue, err := store.GetIssue(ctx, updateArgs.ID)
@@ -567,8 +603,21 @@ func (s *Server) handleUpdate(req *Request) Response {
 
 	// Handle claim operation atomically using ClaimIssue to prevent race conditions
 	// when multiple agents try to claim the same task simultaneously
+	if updateArgs.Lease != "" && !updateArgs.Claim {
+		return Response{
+			Success: false,
+			Error:   "lease requires claim",
+		}
+	}
 	if updateArgs.Claim {
-		claimed, err := store.ClaimIssue(ctx, updateArgs.ID, actor)
+		leaseUntil, err := leaseDeadline(updateArgs.Lease)
+		if err != nil {
+			return Response{
+				Success: false,
+				Error:   err.Error(),
+			}
+		}
+		claimed, err := store.ClaimIssueWithLease(ctx, updateArgs.ID, actor, leaseUntil)
 		if err != nil {
 			return Response{
 				Success: false,
@@ -596,6 +645,12 @@ func (s *Server) handleUpdate(req *Request) Response {
 			Error:   err.Error(),
 		}
 	}
+	if err := validation.CustomFieldUpdates(issue, updateArgs.CustomFields, updates); err != nil {
+		return Response{
+			Success: false,
+			Error:   err.Error(),
+		}
+	}
 
 	// Apply regular field updates if any
 	if len(updates) > 0 {
@@ -813,6 +868,9 @@ func (s *Server) handleClose(req *Request) Response {
 	}
 
 	ctx := s.reqCtx(req)
+	if closeArgs.Force {
+		ctx = validation.WithWorkflowForce(ctx)
+	}
 
 	// Check if issue is a template (beads-1ra): templates are read-only
 	issue, err := store.GetIssue(ctx, closeArgs.ID)
@@ -869,8 +927,18 @@ func (s *Server) handleClose(req *Request) Response {
 		NewStatus: "closed",
 	})
 
+	// Recurring issues spawn their next occurrence on close
+	if issue != nil && issue.Recurrence != "" {
+		s.spawnNextOccurrence(ctx, closeArgs.ID, s.reqActor(req))
+	}
+
 	closedIssue, _ := store.GetIssue(ctx, closeArgs.ID)
 
+	// Closing a gate wakes its waiters
+	if closedIssue != nil && closedIssue.IssueType == "gate" {
+		s.notifyGateWaiters(ctx, closedIssue, s.reqActor(req))
+	}
+
 	// If SuggestNext is requested, find newly unblocked issues (GH#679)
 	if closeArgs.SuggestNext {
 		unblocked, err := store.GetNewlyUnblockedByClose(ctx, closeArgs.ID)
@@ -1237,6 +1305,17 @@ func (s *Server) handleList(req *Request) Response {
 
 	// Time-based scheduling filters (GH#820)
 	filter.Deferred = listArgs.Deferred
+	filter.Recurring = listArgs.Recurring
+	filter.CustomFields = listArgs.CustomFields
+	if listArgs.Where != "" {
+		if _, err := query.Parse(listArgs.Where); err != nil {
+			return Response{
+				Success: false,
+				Error:   fmt.Sprintf("invalid --where: %v", err),
+			}
+		}
+		filter.Where = listArgs.Where
+	}
 	if listArgs.DeferAfter != "" {
 		t, err := parseTimeRPC(listArgs.DeferAfter)
 		if err != nil {
@@ -1289,6 +1368,47 @@ func (s *Server) handleList(req *Request) Response {
 	}
 
 	ctx := s.reqCtx(req)
+
+	if listArgs.Ranked {
+		results, err := store.SearchIssuesRanked(ctx, listArgs.Query, filter)
+		if err != nil {
+			return Response{
+				Success: false,
+				Error:   fmt.Sprintf("failed to search issues: %v", err),
+			}
+		}
+		ids := make([]string, len(results))
+		for i, r := range results {
+			ids[i] = r.ID
+		}
+		labelsMap, err := store.GetLabelsForIssues(ctx, ids)
+		if err != nil {
+			return Response{
+				Success: false,
+				Error:   fmt.Sprintf("failed to get labels: %v", err),
+			}
+		}
+		depCounts, err := store.GetDependencyCounts(ctx, ids)
+		if err != nil {
+			return Response{
+				Success: false,
+				Error:   fmt.Sprintf("failed to get dependency counts: %v", err),
+			}
+		}
+		for _, r := range results {
+			r.Labels = labelsMap[r.ID]
+			if counts := depCounts[r.ID]; counts != nil {
+				r.DependencyCount = counts.DependencyCount
+				r.DependentCount = counts.DependentCount
+			}
+		}
+		data, _ := json.Marshal(results)
+		return Response{
+			Success: true,
+			Data:    data,
+		}
+	}
+
 	issues, err := store.SearchIssues(ctx, listArgs.Query, filter)
 	if err != nil {
 		return Response{
@@ -1459,6 +1579,17 @@ func (s *Server) handleCount(req *Request) Response {
 	filter.PriorityMin = countArgs.PriorityMin
 	filter.PriorityMax = countArgs.PriorityMax
 
+	// Filter expression
+	if countArgs.Where != "" {
+		if _, err := query.Parse(countArgs.Where); err != nil {
+			return Response{
+				Success: false,
+				Error:   fmt.Sprintf("invalid --where: %v", err),
+			}
+		}
+		filter.Where = countArgs.Where
+	}
+
 	ctx := s.reqCtx(req)
 	issues, err := store.SearchIssues(ctx, countArgs.Query, filter)
 	if err != nil {
@@ -1657,6 +1788,10 @@ func (s *Server) handleShow(req *Request) Response {
 	// Fetch comments
 	comments, _ := store.GetIssueComments(ctx, issue.ID)
 
+	// Fetch attachments (detached ones are kept only for sync)
+	attachments, _ := store.GetAttachments(ctx, issue.ID)
+	issue.Attachments = types.LiveAttachments(attachments)
+
 	// Ensure non-nil slices for consistent JSON serialization (GH#bd-rrtu)
 	// Without this, omitempty removes empty arrays from JSON, breaking frontend type guards
 	if labels == nil {
@@ -1715,6 +1850,7 @@ func (s *Server) handleReady(req *Request) Response {
 		Labels:          util.NormalizeLabels(readyArgs.Labels),
 		LabelsAny:       util.NormalizeLabels(readyArgs.LabelsAny),
 		IncludeDeferred: readyArgs.IncludeDeferred, // GH#820
+		Where:           readyArgs.Where,
 	}
 	if readyArgs.Assignee != "" && !readyArgs.Unassigned {
 		wf.Assignee = &readyArgs.Assignee
@@ -1728,7 +1864,7 @@ func (s *Server) handleReady(req *Request) Response {
 	}
 
 	ctx := s.reqCtx(req)
-	issues, err := store.GetReadyWork(ctx, wf)
+	issues, err := ranking.ReadyWork(ctx, store, wf)
 	if err != nil {
 		return Response{
 			Success: false,
@@ -2459,6 +2595,9 @@ func (s *Server) handleGateClose(req *Request) Response {
 	})
 
 	closedGate, _ := store.GetIssue(ctx, gateID)
+	if closedGate != nil {
+		s.notifyGateWaiters(ctx, closedGate, s.reqActor(req))
+	}
 	data, _ := json.Marshal(closedGate)
 	return Response{
 		Success: true,
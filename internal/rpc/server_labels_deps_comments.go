@@ -145,6 +145,42 @@ func (s *Server) handleDepRemove(req *Request) Response {
 	)
 }
 
+func (s *Server) handleDepTree(req *Request) Response {
+	var treeArgs DepTreeArgs
+	if err := json.Unmarshal(req.Args, &treeArgs); err != nil {
+		return Response{
+			Success: false,
+			Error:   fmt.Sprintf("invalid dep tree args: %v", err),
+		}
+	}
+
+	maxDepth := treeArgs.MaxDepth
+	if maxDepth <= 0 {
+		maxDepth = 50
+	}
+
+	ctx := s.reqCtx(req)
+	tree, err := s.storage.GetDependencyTree(ctx, treeArgs.ID, maxDepth, false, treeArgs.Reverse)
+	if err != nil {
+		return Response{
+			Success: false,
+			Error:   fmt.Sprintf("failed to get dependency tree: %v", err),
+		}
+	}
+	if len(tree) == 0 {
+		return Response{
+			Success: false,
+			Error:   fmt.Sprintf("issue not found: %s", treeArgs.ID),
+		}
+	}
+
+	data, _ := json.Marshal(tree)
+	return Response{
+		Success: true,
+		Data:    data,
+	}
+}
+
 func (s *Server) handleLabelAdd(req *Request) Response {
 	var labelArgs LabelAddArgs
 	return s.handleSimpleStoreOp(req, &labelArgs, "label add", func(ctx context.Context, store storage.Storage, actor string) error {
@@ -0,0 +1,98 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// leaseDeadline turns a lease duration such as "30m" into a deadline from
+// now. An empty lease means a claim that never lapses (nil).
+func leaseDeadline(lease string) (*time.Time, error) {
+	if lease == "" {
+		return nil, nil
+	}
+	d, err := time.ParseDuration(lease)
+	if err != nil {
+		return nil, fmt.Errorf("invalid lease %q: %w", lease, err)
+	}
+	if d <= 0 {
+		return nil, fmt.Errorf("invalid lease %q: must be positive", lease)
+	}
+	deadline := time.Now().Add(d)
+	return &deadline, nil
+}
+
+func (s *Server) handleHeartbeat(req *Request) Response {
+	var args HeartbeatArgs
+	if err := json.Unmarshal(req.Args, &args); err != nil {
+		return Response{
+			Success: false,
+			Error:   fmt.Sprintf("invalid heartbeat args: %v", err),
+		}
+	}
+	if s.storage == nil {
+		return Response{
+			Success: false,
+			Error:   "storage not available",
+		}
+	}
+	leaseUntil, err := leaseDeadline(args.Lease)
+	if err == nil && leaseUntil == nil {
+		err = fmt.Errorf("lease duration required")
+	}
+	if err != nil {
+		return Response{
+			Success: false,
+			Error:   err.Error(),
+		}
+	}
+
+	ctx := s.reqCtx(req)
+	actor := s.reqActor(req)
+	if err := s.storage.RenewLease(ctx, args.ID, actor, *leaseUntil); err != nil {
+		return Response{
+			Success: false,
+			Error:   fmt.Sprintf("failed to renew lease: %v", err),
+		}
+	}
+
+	issue, err := s.storage.GetIssue(ctx, args.ID)
+	if err != nil || issue == nil {
+		return Response{
+			Success: false,
+			Error:   fmt.Sprintf("failed to get renewed issue: %v", err),
+		}
+	}
+	// No mutation event: a renewal changes only the lease, which isn't
+	// exported, so there is nothing to sync and no edit to report
+
+	data, _ := json.Marshal(issue)
+	return Response{
+		Success: true,
+		Data:    data,
+	}
+}
+
+// ReclaimExpiredLeases returns issues whose claim lease lapsed to open,
+// emitting a status mutation for each. The daemon calls it periodically.
+func (s *Server) ReclaimExpiredLeases(ctx context.Context) ([]*types.Issue, error) {
+	if s.storage == nil {
+		return nil, nil
+	}
+	reclaimed, err := s.storage.ReclaimExpiredLeases(ctx, time.Now(), "daemon")
+	for _, issue := range reclaimed {
+		s.emitRichMutation(MutationEvent{
+			Type:      MutationStatus,
+			IssueID:   issue.ID,
+			Title:     issue.Title,
+			Actor:     "daemon",
+			OldStatus: string(types.StatusInProgress),
+			NewStatus: string(issue.Status),
+		})
+	}
+	return reclaimed, err
+}
@@ -0,0 +1,122 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage/memory"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func leaseRequest(t *testing.T, op, actor string, args interface{}) *Request {
+	t.Helper()
+	data, err := json.Marshal(args)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &Request{Operation: op, Args: data, Actor: actor}
+}
+
+func TestLeasedClaim(t *testing.T) {
+	store := memory.New("/tmp/test.jsonl")
+	server := NewServer("/tmp/test.sock", store, "/tmp", "/tmp/test.db")
+	ctx := context.Background()
+	issue := &types.Issue{Title: "Leased task", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, issue, "test-user"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+
+	resp := server.handleUpdate(leaseRequest(t, OpUpdate, "agent-1", UpdateArgs{ID: issue.ID, Lease: "30m"}))
+	if resp.Success || !strings.Contains(resp.Error, "lease requires claim") {
+		t.Errorf("lease without claim = %+v, want error", resp)
+	}
+	resp = server.handleUpdate(leaseRequest(t, OpUpdate, "agent-1", UpdateArgs{ID: issue.ID, Claim: true, Lease: "soon"}))
+	if resp.Success || !strings.Contains(resp.Error, "invalid lease") {
+		t.Errorf("bad lease = %+v, want error", resp)
+	}
+
+	resp = server.handleUpdate(leaseRequest(t, OpUpdate, "agent-1", UpdateArgs{ID: issue.ID, Claim: true, Lease: "30m"}))
+	if !resp.Success {
+		t.Fatalf("leased claim failed: %s", resp.Error)
+	}
+	var claimed types.Issue
+	if err := json.Unmarshal(resp.Data, &claimed); err != nil {
+		t.Fatal(err)
+	}
+	if claimed.LeaseExpiresAt == nil || time.Until(*claimed.LeaseExpiresAt) < 29*time.Minute {
+		t.Errorf("LeaseExpiresAt = %v, want about 30m from now", claimed.LeaseExpiresAt)
+	}
+
+	// Only the holder can renew
+	resp = server.handleHeartbeat(leaseRequest(t, OpHeartbeat, "agent-2", HeartbeatArgs{ID: issue.ID, Lease: "1h"}))
+	if resp.Success || !strings.Contains(resp.Error, "claimed by agent-1") {
+		t.Errorf("heartbeat by another agent = %+v, want error", resp)
+	}
+	before := len(server.GetRecentMutations(0))
+	resp = server.handleHeartbeat(leaseRequest(t, OpHeartbeat, "agent-1", HeartbeatArgs{ID: issue.ID, Lease: "1h"}))
+	if !resp.Success {
+		t.Fatalf("heartbeat failed: %s", resp.Error)
+	}
+	if after := len(server.GetRecentMutations(0)); after != before {
+		t.Errorf("heartbeat emitted %d mutation(s), want none", after-before)
+	}
+	var renewed types.Issue
+	if err := json.Unmarshal(resp.Data, &renewed); err != nil {
+		t.Fatal(err)
+	}
+	if renewed.LeaseExpiresAt == nil || time.Until(*renewed.LeaseExpiresAt) < 59*time.Minute {
+		t.Errorf("renewed LeaseExpiresAt = %v, want about 1h from now", renewed.LeaseExpiresAt)
+	}
+	if !renewed.UpdatedAt.Equal(claimed.UpdatedAt) {
+		t.Errorf("heartbeat moved UpdatedAt from %v to %v", claimed.UpdatedAt, renewed.UpdatedAt)
+	}
+
+	// Worker status shows the deadline
+	resp = server.handleGetWorkerStatus(leaseRequest(t, OpGetWorkerStatus, "", GetWorkerStatusArgs{}))
+	var status GetWorkerStatusResponse
+	if err := json.Unmarshal(resp.Data, &status); err != nil {
+		t.Fatal(err)
+	}
+	if len(status.Workers) != 1 || status.Workers[0].LeaseExpiresAt == "" || status.Workers[0].LeaseExpired {
+		t.Errorf("workers = %+v, want one with a live lease", status.Workers)
+	}
+}
+
+func TestReclaimExpiredLeases(t *testing.T) {
+	store := memory.New("/tmp/test.jsonl")
+	server := NewServer("/tmp/test.sock", store, "/tmp", "/tmp/test.db")
+	ctx := context.Background()
+	issue := &types.Issue{Title: "Abandoned", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, issue, "test-user"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	past := time.Now().Add(-time.Minute)
+	if _, err := store.ClaimIssueWithLease(ctx, issue.ID, "agent-1", &past); err != nil {
+		t.Fatalf("ClaimIssueWithLease failed: %v", err)
+	}
+
+	resp := server.handleGetWorkerStatus(leaseRequest(t, OpGetWorkerStatus, "", GetWorkerStatusArgs{}))
+	var status GetWorkerStatusResponse
+	if err := json.Unmarshal(resp.Data, &status); err != nil {
+		t.Fatal(err)
+	}
+	if len(status.Workers) != 1 || !status.Workers[0].LeaseExpired {
+		t.Errorf("workers = %+v, want one with an expired lease", status.Workers)
+	}
+
+	reclaimed, err := server.ReclaimExpiredLeases(ctx)
+	if err != nil {
+		t.Fatalf("ReclaimExpiredLeases failed: %v", err)
+	}
+	if len(reclaimed) != 1 || reclaimed[0].Status != types.StatusOpen || reclaimed[0].Assignee != "" {
+		t.Fatalf("reclaimed = %+v, want %s back to open", reclaimed, issue.ID)
+	}
+
+	mutations := server.GetRecentMutations(0)
+	if len(mutations) != 1 || mutations[0].Type != MutationStatus || mutations[0].OldStatus != "in_progress" || mutations[0].NewStatus != "open" {
+		t.Errorf("mutations = %+v, want one in_progress -> open status event", mutations)
+	}
+}
@@ -0,0 +1,22 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/steveyegge/beads/internal/mail"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// notifyGateWaiters mails the waiters of a just-closed gate. Failures don't
+// fail the close.
+func (s *Server) notifyGateWaiters(ctx context.Context, gate *types.Issue, actor string) {
+	sent, err := mail.NotifyWaiters(ctx, s.storage, gate, actor)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to notify waiters of %s: %v\n", gate.ID, err)
+	}
+	for _, msg := range sent {
+		s.emitMutation(MutationCreate, msg.ID, msg.Title, msg.Assignee)
+	}
+}
@@ -0,0 +1,47 @@
+package rpc
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/mail"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestGateCloseNotifiesWaiters(t *testing.T) {
+	tmpDir := t.TempDir()
+	dbPath := filepath.Join(tmpDir, "test.db")
+	store := newTestStore(t, dbPath)
+	defer store.Close()
+	server := NewServer(newTestSocketPath(t), store, tmpDir, dbPath)
+	ctx := context.Background()
+	if err := store.SetConfig(ctx, "types.custom", "gate,message"); err != nil {
+		t.Fatal(err)
+	}
+	gate := &types.Issue{Title: "CI passes", Status: types.StatusOpen, Priority: 2, IssueType: "gate", Waiters: []string{"polecats/toast"}}
+	if err := store.CreateIssue(ctx, gate, "test-user"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+
+	resp := server.handleClose(leaseRequest(t, OpClose, "witness", CloseArgs{ID: gate.ID, Reason: "Run succeeded"}))
+	if !resp.Success {
+		t.Fatalf("close failed: %s", resp.Error)
+	}
+
+	inbox, err := mail.Inbox(ctx, store, "polecats/toast", mail.InboxOptions{Unread: true})
+	if err != nil {
+		t.Fatalf("Inbox failed: %v", err)
+	}
+	if len(inbox) != 1 || inbox[0].Sender != "witness" || inbox[0].Title != "Gate closed: "+gate.ID {
+		t.Fatalf("waiter inbox = %+v, want one gate-closed message from witness", inbox)
+	}
+
+	var created bool
+	for _, m := range server.GetRecentMutations(0) {
+		created = created || (m.Type == MutationCreate && m.IssueID == inbox[0].ID)
+	}
+	if !created {
+		t.Error("expected a create mutation for the notification")
+	}
+}
@@ -3,6 +3,8 @@ package rpc
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -76,6 +78,91 @@ func TestEmitMutation(t *testing.T) {
 	}
 }
 
+func TestOnMutation(t *testing.T) {
+	store := memory.New("/tmp/test.jsonl")
+	server := NewServer("/tmp/test.sock", store, "/tmp", "/tmp/test.db")
+
+	events := make(chan MutationEvent, 2)
+	server.OnMutation(func(event MutationEvent) {
+		events <- event
+	})
+
+	server.emitMutation(MutationCreate, "bd-1", "Issue 1", "")
+	server.emitRichMutation(MutationEvent{
+		Type:      MutationStatus,
+		IssueID:   "bd-1",
+		OldStatus: "open",
+		NewStatus: "closed",
+	})
+
+	var got []MutationEvent
+	for len(got) < 2 {
+		select {
+		case event := <-events:
+			got = append(got, event)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("expected listener to see 2 events, got %d", len(got))
+		}
+	}
+	if got[1].Type != MutationStatus || got[1].NewStatus != "closed" {
+		t.Errorf("unexpected second event: %+v", got[1])
+	}
+	if got[0].Timestamp.IsZero() {
+		t.Error("expected listener events to be timestamped")
+	}
+}
+
+func TestOnMutationSlowListenerDoesNotBlock(t *testing.T) {
+	t.Setenv("BEADS_MUTATION_BUFFER", "2")
+	store := memory.New("/tmp/test.jsonl")
+	server := NewServer("/tmp/test.sock", store, "/tmp", "/tmp/test.db")
+	defer close(server.shutdownChan)
+
+	release := make(chan struct{})
+	server.OnMutation(func(MutationEvent) { <-release })
+	defer close(release)
+
+	// The listener holds one event and buffers two; the rest are dropped
+	// instead of making the emitter wait
+	done := make(chan struct{})
+	go func() {
+		for i := 0; i < 10; i++ {
+			server.emitMutation(MutationUpdate, "bd-1", "Issue 1", "")
+		}
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("emitting blocked on a stuck listener")
+	}
+	if dropped := server.droppedListenerEvents.Load(); dropped < 7 {
+		t.Errorf("dropped %d listener events, want at least 7", dropped)
+	}
+}
+
+func TestOnMutationSyncSeesEveryEvent(t *testing.T) {
+	t.Setenv("BEADS_MUTATION_BUFFER", "2")
+	store := memory.New("/tmp/test.jsonl")
+	server := NewServer("/tmp/test.sock", store, "/tmp", "/tmp/test.db")
+
+	var got []string
+	server.OnMutationSync(func(event MutationEvent) {
+		got = append(got, event.IssueID)
+	})
+
+	// Far more events than any buffer holds; none may be dropped
+	for i := 0; i < 20; i++ {
+		server.emitMutation(MutationUpdate, fmt.Sprintf("bd-%d", i), "", "")
+	}
+	if len(got) != 20 {
+		t.Fatalf("sync listener saw %d events, want 20", len(got))
+	}
+	if got[0] != "bd-0" || got[19] != "bd-19" {
+		t.Errorf("events out of order: %v", got)
+	}
+}
+
 func TestGetRecentMutations_EmptyBuffer(t *testing.T) {
 	store := memory.New("/tmp/test.jsonl")
 	server := NewServer("/tmp/test.sock", store, "/tmp", "/tmp/test.db")
@@ -462,6 +549,67 @@ func TestHandleClose_EmitsStatusMutation(t *testing.T) {
 	}
 }
 
+// TestHandleClose_SpawnsNextOccurrence verifies that closing a recurring issue
+// creates its next occurrence and emits a create mutation for it
+func TestHandleClose_SpawnsNextOccurrence(t *testing.T) {
+	tmpDir := t.TempDir()
+	dbPath := filepath.Join(tmpDir, "test.db")
+	store := newTestStore(t, dbPath)
+	defer store.Close()
+	server := NewServer(newTestSocketPath(t), store, tmpDir, dbPath)
+
+	createJSON, _ := json.Marshal(CreateArgs{
+		Title:      "Weekly review",
+		IssueType:  "task",
+		Priority:   2,
+		Recurrence: "every monday 09:00",
+	})
+	createResp := server.handleCreate(&Request{Operation: OpCreate, Args: createJSON, Actor: "test-user"})
+	if !createResp.Success {
+		t.Fatalf("failed to create test issue: %s", createResp.Error)
+	}
+	var created types.Issue
+	if err := json.Unmarshal(createResp.Data, &created); err != nil {
+		t.Fatalf("failed to parse created issue: %v", err)
+	}
+	if created.Recurrence != "every monday 09:00" {
+		t.Fatalf("expected recurrence to be stored, got %q", created.Recurrence)
+	}
+
+	time.Sleep(10 * time.Millisecond)
+	checkpoint := time.Now().UnixMilli()
+	time.Sleep(10 * time.Millisecond)
+
+	closeJSON, _ := json.Marshal(CloseArgs{ID: created.ID, Reason: "done"})
+	closeResp := server.handleClose(&Request{Operation: OpClose, Args: closeJSON, Actor: "test-user"})
+	if !closeResp.Success {
+		t.Fatalf("close operation failed: %s", closeResp.Error)
+	}
+
+	var nextID string
+	for _, m := range server.GetRecentMutations(checkpoint) {
+		if m.Type == MutationCreate {
+			nextID = m.IssueID
+		}
+	}
+	if nextID == "" {
+		t.Fatal("expected a create mutation for the next occurrence")
+	}
+	next, err := store.GetIssue(context.Background(), nextID)
+	if err != nil || next == nil {
+		t.Fatalf("GetIssue(%s) = %v, %v", nextID, next, err)
+	}
+	if next.Status != types.StatusOpen || next.Recurrence != "every monday 09:00" || next.DeferUntil == nil {
+		t.Errorf("unexpected next occurrence: %+v", next)
+	}
+
+	// An invalid rule is rejected on create
+	badJSON, _ := json.Marshal(CreateArgs{Title: "Bad", IssueType: "task", Recurrence: "every blursday"})
+	if resp := server.handleCreate(&Request{Operation: OpCreate, Args: badJSON, Actor: "test-user"}); resp.Success {
+		t.Error("expected create with an invalid recurrence to fail")
+	}
+}
+
 // TestHandleUpdate_EmitsStatusMutationOnStatusChange verifies that status updates emit MutationStatus
 func TestHandleUpdate_EmitsStatusMutationOnStatusChange(t *testing.T) {
 	store := memory.New("/tmp/test.jsonl")
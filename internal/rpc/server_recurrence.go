@@ -0,0 +1,39 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/steveyegge/beads/internal/recurrence"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// spawnNextOccurrence creates the next occurrence of a just-closed recurring
+// issue. Failures don't fail the close: the rule stays in place and the
+// periodic scan (SpawnRecurring) retries it.
+func (s *Server) spawnNextOccurrence(ctx context.Context, id, actor string) {
+	next, err := recurrence.Spawn(ctx, s.storage, id, actor, time.Now())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to spawn next occurrence of %s: %v\n", id, err)
+		return
+	}
+	if next != nil {
+		s.emitMutation(MutationCreate, next.ID, next.Title, next.Assignee)
+	}
+}
+
+// SpawnRecurring spawns the next occurrence of every closed issue that still
+// has a recurrence rule, such as issues closed in direct mode or imported
+// from another clone. The daemon calls it periodically.
+func (s *Server) SpawnRecurring(ctx context.Context) ([]*types.Issue, error) {
+	if s.storage == nil {
+		return nil, nil
+	}
+	spawned, err := recurrence.SpawnDue(ctx, s.storage, "daemon", time.Now())
+	for _, next := range spawned {
+		s.emitMutation(MutationCreate, next.ID, next.Title, next.Assignee)
+	}
+	return spawned, err
+}
@@ -198,6 +198,8 @@ func (s *Server) handleRequest(req *Request) Response {
 		resp = s.handleDepAdd(req)
 	case OpDepRemove:
 		resp = s.handleDepRemove(req)
+	case OpDepTree:
+		resp = s.handleDepTree(req)
 	case OpLabelAdd:
 		resp = s.handleLabelAdd(req)
 	case OpLabelRemove:
@@ -227,6 +229,8 @@ func (s *Server) handleRequest(req *Request) Response {
 		resp = s.handleGetMoleculeProgress(req)
 	case OpGetWorkerStatus:
 		resp = s.handleGetWorkerStatus(req)
+	case OpHeartbeat:
+		resp = s.handleHeartbeat(req)
 	case OpGetConfig:
 		resp = s.handleGetConfig(req)
 	case OpMolStale:
@@ -455,6 +459,10 @@ func (s *Server) handleGetWorkerStatus(req *Request) Response {
 			LastActivity: issue.UpdatedAt.Format(time.RFC3339),
 			Status:       string(issue.Status),
 		}
+		if issue.LeaseExpiresAt != nil {
+			worker.LeaseExpiresAt = issue.LeaseExpiresAt.Format(time.RFC3339)
+			worker.LeaseExpired = issue.LeaseExpired(time.Now())
+		}
 
 		// Check if this issue is a child of a molecule/epic (has parent-child dependency)
 		deps, err := s.storage.GetDependencyRecords(ctx, issue.ID)
@@ -0,0 +1,441 @@
+// Package search implements the full-text query language used by bd search.
+//
+// Queries are parsed into a small AST that can be rendered as an SQLite FTS5
+// MATCH expression (sqlite backend) or evaluated directly against documents
+// (memory and dolt backends), so every backend accepts the same syntax:
+//
+//	login timeout          both terms must match (implicit AND)
+//	"login timeout"        exact phrase
+//	auth*                  prefix match
+//	login OR signin        either term
+//	login NOT oauth        exclude matches
+//	(login OR auth) crash  grouping
+//
+// Operators must be upper-case, as in FTS5; lower-case "and"/"or"/"not" are
+// searched as ordinary words. Bare words containing punctuation, such as issue
+// IDs ("bd-5q"), are searched as a phrase whose last token is a prefix, so
+// partial IDs keep working.
+package search
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// Node is a parsed query expression.
+type Node interface {
+	// writeFTS5 renders the node as FTS5 MATCH syntax.
+	writeFTS5(b *strings.Builder)
+	// match reports whether the document satisfies the node.
+	match(d *Document) bool
+	// collect appends all non-negated phrases to out.
+	collect(out *[]*Phrase, negated bool)
+}
+
+// Phrase is a sequence of tokens that must appear consecutively in one column.
+// If Prefix is set, the last token matches any token starting with it.
+type Phrase struct {
+	Tokens []string
+	Prefix bool
+}
+
+type andNode struct {
+	children []Node
+}
+
+type orNode struct {
+	children []Node
+}
+
+type notNode struct {
+	child Node
+}
+
+// Query is a parsed search query.
+type Query struct {
+	root    Node
+	phrases []*Phrase // positive phrases, used for ranking and highlighting
+	raw     string
+}
+
+// String returns the original query text.
+func (q *Query) String() string {
+	return q.raw
+}
+
+// Phrases returns the non-negated phrases of the query in order of appearance.
+func (q *Query) Phrases() []*Phrase {
+	return q.phrases
+}
+
+// FTS5 renders the query as an SQLite FTS5 MATCH expression.
+// Every token is quoted, so user input can never inject FTS5 column filters
+// or syntax errors.
+func (q *Query) FTS5() string {
+	var b strings.Builder
+	q.root.writeFTS5(&b)
+	return b.String()
+}
+
+// Match reports whether the document satisfies the query.
+func (q *Query) Match(d *Document) bool {
+	return q.root.match(d)
+}
+
+// Parse parses a search query. It returns an error for malformed queries
+// (unbalanced quotes or parentheses, dangling operators) and for queries that
+// contain no searchable terms or only negated terms.
+func Parse(input string) (*Query, error) {
+	lex, err := lexQuery(input)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{items: lex}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if root == nil {
+		return nil, fmt.Errorf("search query %q contains no searchable terms", input)
+	}
+	if p.pos < len(p.items) {
+		return nil, fmt.Errorf("unexpected %q in search query", p.items[p.pos].text)
+	}
+	q := &Query{root: root, raw: input}
+	root.collect(&q.phrases, false)
+	if len(q.phrases) == 0 {
+		return nil, fmt.Errorf("search query %q contains only negated terms", input)
+	}
+	return q, nil
+}
+
+// --- lexer ---
+
+type itemKind int
+
+const (
+	itemWord itemKind = iota
+	itemPhrase
+	itemAnd
+	itemOr
+	itemNot
+	itemLParen
+	itemRParen
+)
+
+type item struct {
+	kind   itemKind
+	text   string
+	prefix bool
+}
+
+func lexQuery(input string) ([]item, error) {
+	var items []item
+	runes := []rune(input)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(':
+			items = append(items, item{kind: itemLParen, text: "("})
+			i++
+		case r == ')':
+			items = append(items, item{kind: itemRParen, text: ")"})
+			i++
+		case r == '"':
+			end := i + 1
+			for end < len(runes) && runes[end] != '"' {
+				end++
+			}
+			if end >= len(runes) {
+				return nil, fmt.Errorf("unterminated phrase in search query")
+			}
+			it := item{kind: itemPhrase, text: string(runes[i+1 : end])}
+			i = end + 1
+			if i < len(runes) && runes[i] == '*' {
+				it.prefix = true
+				i++
+			}
+			items = append(items, it)
+		default:
+			end := i
+			for end < len(runes) && !unicode.IsSpace(runes[end]) && runes[end] != '(' && runes[end] != ')' && runes[end] != '"' {
+				end++
+			}
+			word := string(runes[i:end])
+			i = end
+			switch word {
+			case "AND":
+				items = append(items, item{kind: itemAnd, text: word})
+			case "OR":
+				items = append(items, item{kind: itemOr, text: word})
+			case "NOT":
+				items = append(items, item{kind: itemNot, text: word})
+			default:
+				it := item{kind: itemWord, text: word}
+				if strings.HasSuffix(word, "*") {
+					it.prefix = true
+					it.text = strings.TrimRight(word, "*")
+				}
+				items = append(items, it)
+			}
+		}
+	}
+	return items, nil
+}
+
+// --- parser ---
+
+type parser struct {
+	items []item
+	pos   int
+}
+
+func (p *parser) peek() (item, bool) {
+	if p.pos >= len(p.items) {
+		return item{}, false
+	}
+	return p.items[p.pos], true
+}
+
+// parseOr: and ("OR" and)*
+func (p *parser) parseOr() (Node, error) {
+	var children []Node
+	for {
+		n, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		if n != nil {
+			children = append(children, n)
+		}
+		it, ok := p.peek()
+		if !ok || it.kind != itemOr {
+			break
+		}
+		p.pos++
+		if len(children) == 0 {
+			return nil, fmt.Errorf("OR must follow a search term")
+		}
+		if next, ok := p.peek(); !ok || next.kind == itemOr || next.kind == itemRParen {
+			return nil, fmt.Errorf("OR must be followed by a search term")
+		}
+	}
+	switch len(children) {
+	case 0:
+		return nil, nil
+	case 1:
+		return children[0], nil
+	}
+	return &orNode{children: children}, nil
+}
+
+// parseAnd: unary (["AND"] unary)*
+func (p *parser) parseAnd() (Node, error) {
+	var children []Node
+	positive := 0
+	for {
+		it, ok := p.peek()
+		if !ok || it.kind == itemOr || it.kind == itemRParen {
+			break
+		}
+		if it.kind == itemAnd {
+			p.pos++
+			if len(children) == 0 {
+				return nil, fmt.Errorf("AND must follow a search term")
+			}
+			if next, ok := p.peek(); !ok || next.kind == itemOr || next.kind == itemAnd || next.kind == itemRParen {
+				return nil, fmt.Errorf("AND must be followed by a search term")
+			}
+			continue
+		}
+		n, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		if n == nil {
+			continue
+		}
+		if _, neg := n.(*notNode); !neg {
+			positive++
+		}
+		children = append(children, n)
+	}
+	if len(children) == 0 {
+		return nil, nil
+	}
+	if positive == 0 {
+		// FTS5 NOT is a binary operator, so a group of only negations
+		// cannot be expressed (and would match nearly everything anyway).
+		return nil, fmt.Errorf("NOT must be combined with at least one positive search term")
+	}
+	if len(children) == 1 {
+		return children[0], nil
+	}
+	return &andNode{children: children}, nil
+}
+
+// parseUnary: "NOT" unary | primary
+func (p *parser) parseUnary() (Node, error) {
+	it, _ := p.peek()
+	if it.kind != itemNot {
+		return p.parsePrimary()
+	}
+	p.pos++
+	if _, ok := p.peek(); !ok {
+		return nil, fmt.Errorf("NOT must be followed by a search term")
+	}
+	child, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	if child == nil {
+		return nil, nil
+	}
+	if inner, ok := child.(*notNode); ok {
+		return inner.child, nil // NOT NOT x == x
+	}
+	return &notNode{child: child}, nil
+}
+
+// parsePrimary: "(" or ")" | phrase | word
+func (p *parser) parsePrimary() (Node, error) {
+	it, _ := p.peek()
+	p.pos++
+	switch it.kind {
+	case itemLParen:
+		n, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if closing, ok := p.peek(); !ok || closing.kind != itemRParen {
+			return nil, fmt.Errorf("unbalanced parentheses in search query")
+		}
+		p.pos++
+		return n, nil
+	case itemPhrase:
+		tokens := TokenStrings(it.text)
+		if len(tokens) == 0 {
+			return nil, nil
+		}
+		return &Phrase{Tokens: tokens, Prefix: it.prefix}, nil
+	case itemWord:
+		tokens := TokenStrings(it.text)
+		if len(tokens) == 0 {
+			return nil, nil
+		}
+		// Identifier-like words ("bd-5q") become a phrase whose last token
+		// is a prefix so that partial IDs match.
+		return &Phrase{Tokens: tokens, Prefix: it.prefix || len(tokens) > 1}, nil
+	}
+	return nil, fmt.Errorf("unexpected %q in search query", it.text)
+}
+
+// --- FTS5 rendering ---
+
+func (ph *Phrase) writeFTS5(b *strings.Builder) {
+	b.WriteByte('"')
+	b.WriteString(strings.Join(ph.Tokens, " "))
+	b.WriteByte('"')
+	if ph.Prefix {
+		b.WriteByte('*')
+	}
+}
+
+func (n *andNode) writeFTS5(b *strings.Builder) {
+	var negated []Node
+	b.WriteByte('(')
+	first := true
+	for _, c := range n.children {
+		if not, ok := c.(*notNode); ok {
+			negated = append(negated, not.child)
+			continue
+		}
+		if !first {
+			b.WriteString(" AND ")
+		}
+		first = false
+		c.writeFTS5(b)
+	}
+	b.WriteByte(')')
+	for _, c := range negated {
+		b.WriteString(" NOT (")
+		c.writeFTS5(b)
+		b.WriteByte(')')
+	}
+}
+
+func (n *orNode) writeFTS5(b *strings.Builder) {
+	b.WriteByte('(')
+	for i, c := range n.children {
+		if i > 0 {
+			b.WriteString(" OR ")
+		}
+		c.writeFTS5(b)
+	}
+	b.WriteByte(')')
+}
+
+func (n *notNode) writeFTS5(b *strings.Builder) {
+	// Only reachable for malformed trees; parseAnd always pairs NOT with a
+	// positive sibling and andNode renders negations itself.
+	b.WriteString("NOT ")
+	n.child.writeFTS5(b)
+}
+
+// --- evaluation ---
+
+func (ph *Phrase) match(d *Document) bool {
+	for c := range d.columns {
+		if d.phraseHits(ph, c) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func (n *andNode) match(d *Document) bool {
+	for _, c := range n.children {
+		if !c.match(d) {
+			return false
+		}
+	}
+	return true
+}
+
+func (n *orNode) match(d *Document) bool {
+	for _, c := range n.children {
+		if c.match(d) {
+			return true
+		}
+	}
+	return false
+}
+
+func (n *notNode) match(d *Document) bool {
+	return !n.child.match(d)
+}
+
+func (ph *Phrase) collect(out *[]*Phrase, negated bool) {
+	if !negated {
+		*out = append(*out, ph)
+	}
+}
+
+func (n *andNode) collect(out *[]*Phrase, negated bool) {
+	for _, c := range n.children {
+		c.collect(out, negated)
+	}
+}
+
+func (n *orNode) collect(out *[]*Phrase, negated bool) {
+	for _, c := range n.children {
+		c.collect(out, negated)
+	}
+}
+
+func (n *notNode) collect(out *[]*Phrase, negated bool) {
+	n.child.collect(out, !negated)
+}
@@ -0,0 +1,77 @@
+package search
+
+import (
+	"testing"
+)
+
+func TestParseFTS5(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"single term", "login", `"login"`},
+		{"implicit and", "login timeout", `("login" AND "timeout")`},
+		{"explicit and", "login AND timeout", `("login" AND "timeout")`},
+		{"phrase", `"login timeout"`, `"login timeout"`},
+		{"prefix", "auth*", `"auth"*`},
+		{"phrase prefix", `"login time"*`, `"login time"*`},
+		{"or", "login OR signin", `("login" OR "signin")`},
+		{"not", "login NOT oauth", `("login") NOT ("oauth")`},
+		{"grouping", "(login OR auth) crash", `(("login" OR "auth") AND "crash")`},
+		{"id becomes prefix phrase", "bd-5q", `"bd 5q"*`},
+		{"lowercase operators are words", "this or that", `("this" AND "or" AND "that")`},
+		{"case folding", "LOGIN", `"login"`},
+		{"double negation", "login NOT NOT oauth", `("login" AND "oauth")`},
+		{"punctuation only terms dropped", "login --", `"login"`},
+		{"fts5 syntax is quoted", "title:secret", `"title secret"*`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q, err := Parse(tt.input)
+			if err != nil {
+				t.Fatalf("Parse(%q) error: %v", tt.input, err)
+			}
+			if got := q.FTS5(); got != tt.want {
+				t.Errorf("Parse(%q).FTS5() = %s, want %s", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []string{
+		"",
+		"   ",
+		"--",
+		`"unterminated`,
+		"(login",
+		"login)",
+		"NOT login",
+		"login OR",
+		"OR login",
+		"login AND",
+		"login OR NOT oauth",
+	}
+	for _, input := range tests {
+		t.Run(input, func(t *testing.T) {
+			if _, err := Parse(input); err == nil {
+				t.Errorf("Parse(%q) expected error, got nil", input)
+			}
+		})
+	}
+}
+
+func TestParsePhrases(t *testing.T) {
+	q, err := Parse(`(login OR "sign in") NOT oauth`)
+	if err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+	phrases := q.Phrases()
+	if len(phrases) != 2 {
+		t.Fatalf("expected 2 positive phrases, got %d", len(phrases))
+	}
+	if phrases[0].Tokens[0] != "login" || len(phrases[1].Tokens) != 2 {
+		t.Errorf("unexpected phrases: %+v %+v", phrases[0], phrases[1])
+	}
+}
@@ -0,0 +1,318 @@
+package search
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// Columns lists the indexed fields in index order. The sqlite FTS5 table
+// (issues_fts) uses the same column order so BM25 weights line up.
+var Columns = []string{"id", "title", "description", "design", "acceptance_criteria", "notes", "comments"}
+
+// ColumnWeights are the BM25 weights for Columns: ID and title hits count
+// most, comments least.
+var ColumnWeights = []float64{10, 10, 5, 2, 2, 2, 1}
+
+// Highlight markers wrapped around matched terms in snippets.
+const (
+	HighlightStart  = "**"
+	HighlightEnd    = "**"
+	SnippetEllipsis = "..."
+)
+
+// snippetTokens is the maximum number of tokens in a snippet.
+const snippetTokens = 16
+
+// BM25 parameters (same as SQLite FTS5).
+const (
+	bm25K1 = 1.2
+	bm25B  = 0.75
+)
+
+type token struct {
+	text       string // lower-cased
+	start, end int    // byte offsets into the source text
+}
+
+// tokenize splits text into lower-cased alphanumeric tokens, mirroring the
+// FTS5 unicode61 tokenizer closely enough for consistent cross-backend results.
+func tokenize(text string) []token {
+	var tokens []token
+	start := -1
+	for i, r := range text {
+		isTok := unicode.IsLetter(r) || unicode.IsNumber(r)
+		if isTok && start < 0 {
+			start = i
+		} else if !isTok && start >= 0 {
+			tokens = append(tokens, token{text: strings.ToLower(text[start:i]), start: start, end: i})
+			start = -1
+		}
+	}
+	if start >= 0 {
+		tokens = append(tokens, token{text: strings.ToLower(text[start:]), start: start, end: len(text)})
+	}
+	return tokens
+}
+
+// TokenStrings returns the lower-cased search tokens of text.
+func TokenStrings(text string) []string {
+	tokens := tokenize(text)
+	out := make([]string, len(tokens))
+	for i, t := range tokens {
+		out[i] = t.text
+	}
+	return out
+}
+
+// Document is a tokenized issue, one entry per element of Columns.
+type Document struct {
+	Issue   *types.Issue
+	texts   []string
+	columns [][]token
+	length  int
+}
+
+// NewDocument builds a searchable document from an issue and its comments.
+func NewDocument(issue *types.Issue, comments []*types.Comment) *Document {
+	commentTexts := make([]string, len(comments))
+	for i, c := range comments {
+		commentTexts[i] = c.Text
+	}
+	texts := []string{
+		issue.ID,
+		issue.Title,
+		issue.Description,
+		issue.Design,
+		issue.AcceptanceCriteria,
+		issue.Notes,
+		strings.Join(commentTexts, " "),
+	}
+	d := &Document{Issue: issue, texts: texts, columns: make([][]token, len(texts))}
+	for i, t := range texts {
+		d.columns[i] = tokenize(t)
+		d.length += len(d.columns[i])
+	}
+	return d
+}
+
+// phraseHits counts occurrences of the phrase in column c.
+func (d *Document) phraseHits(ph *Phrase, c int) int {
+	return len(d.phraseMatches(ph, c))
+}
+
+// phraseMatches returns the starting token offsets of phrase matches in column c.
+func (d *Document) phraseMatches(ph *Phrase, c int) []int {
+	toks := d.columns[c]
+	n := len(ph.Tokens)
+	var starts []int
+	for i := 0; i+n <= len(toks); i++ {
+		ok := true
+		for j, want := range ph.Tokens {
+			got := toks[i+j].text
+			if j == n-1 && ph.Prefix {
+				if !strings.HasPrefix(got, want) {
+					ok = false
+					break
+				}
+			} else if got != want {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			starts = append(starts, i)
+		}
+	}
+	return starts
+}
+
+// Rank filters docs by the query and scores matches with BM25 using the
+// same formula and column weights as SQLite FTS5, so results are ordered
+// consistently across backends. Results are sorted by descending score,
+// ties broken by priority then ID. A limit <= 0 returns all matches.
+func Rank(q *Query, docs []*Document, limit int) []*types.SearchResult {
+	total := len(docs)
+	if total == 0 {
+		return nil
+	}
+
+	// Document frequency per phrase and average document length
+	docFreq := make([]int, len(q.phrases))
+	totalLen := 0
+	for _, d := range docs {
+		totalLen += d.length
+		for i, ph := range q.phrases {
+			if ph.match(d) {
+				docFreq[i]++
+			}
+		}
+	}
+	avgLen := float64(totalLen) / float64(total)
+	if avgLen == 0 {
+		avgLen = 1
+	}
+	idf := make([]float64, len(q.phrases))
+	for i, n := range docFreq {
+		v := math.Log((float64(total) - float64(n) + 0.5) / (float64(n) + 0.5))
+		if v <= 0 {
+			v = 1e-6
+		}
+		idf[i] = v
+	}
+
+	var results []*types.SearchResult
+	for _, d := range docs {
+		if !q.Match(d) {
+			continue
+		}
+		score := 0.0
+		for i, ph := range q.phrases {
+			weighted := 0.0
+			for c := range d.columns {
+				weighted += ColumnWeights[c] * float64(d.phraseHits(ph, c))
+			}
+			if weighted == 0 {
+				continue
+			}
+			score += idf[i] * (weighted * (bm25K1 + 1)) /
+				(weighted + bm25K1*(1-bm25B+bm25B*float64(d.length)/avgLen))
+		}
+		results = append(results, &types.SearchResult{
+			IssueWithCounts: types.IssueWithCounts{Issue: d.Issue},
+			Score:           score,
+			Snippet:         Snippet(q, d),
+		})
+	}
+
+	SortResults(results)
+	if limit > 0 && len(results) > limit {
+		results = results[:limit]
+	}
+	return results
+}
+
+// SortResults orders results by descending score, then priority, then ID.
+func SortResults(results []*types.SearchResult) {
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		if results[i].Priority != results[j].Priority {
+			return results[i].Priority < results[j].Priority
+		}
+		return results[i].ID < results[j].ID
+	})
+}
+
+// RankIssues is a convenience wrapper for backends without a native index:
+// it builds documents from issues and their comments and ranks them.
+func RankIssues(q *Query, issues []*types.Issue, comments map[string][]*types.Comment, limit int) []*types.SearchResult {
+	docs := make([]*Document, len(issues))
+	for i, issue := range issues {
+		docs[i] = NewDocument(issue, comments[issue.ID])
+	}
+	return Rank(q, docs, limit)
+}
+
+// Snippet returns a short excerpt of the best-matching text column with
+// query terms wrapped in HighlightStart/HighlightEnd. The ID column is never
+// used for snippets since the ID is always displayed alongside the hit.
+// Returns "" if only the ID matched.
+func Snippet(q *Query, d *Document) string {
+	bestCol, bestHits := -1, 0
+	var bestMarks map[int]bool
+	for c := 1; c < len(d.columns); c++ {
+		marks := make(map[int]bool)
+		hits := 0
+		for _, ph := range q.phrases {
+			for _, start := range d.phraseMatches(ph, c) {
+				hits++
+				for k := 0; k < len(ph.Tokens); k++ {
+					marks[start+k] = true
+				}
+			}
+		}
+		if hits > bestHits {
+			bestCol, bestHits, bestMarks = c, hits, marks
+		}
+	}
+	if bestCol < 0 {
+		return ""
+	}
+
+	toks := d.columns[bestCol]
+	text := d.texts[bestCol]
+
+	// Start the window a couple of tokens before the first highlighted token
+	first := len(toks)
+	for i := range toks {
+		if bestMarks[i] {
+			first = i
+			break
+		}
+	}
+	start := first - 2
+	if start < 0 {
+		start = 0
+	}
+	end := start + snippetTokens
+	if end > len(toks) {
+		end = len(toks)
+		start = end - snippetTokens
+		if start < 0 {
+			start = 0
+		}
+	}
+
+	var b strings.Builder
+	if start > 0 {
+		b.WriteString(SnippetEllipsis)
+	}
+	pos := toks[start].start
+	for i := start; i < end; i++ {
+		b.WriteString(collapseSpace(text[pos:toks[i].start]))
+		if bestMarks[i] {
+			b.WriteString(HighlightStart)
+			b.WriteString(text[toks[i].start:toks[i].end])
+			b.WriteString(HighlightEnd)
+		} else {
+			b.WriteString(text[toks[i].start:toks[i].end])
+		}
+		pos = toks[i].end
+	}
+	if end < len(toks) {
+		b.WriteString(SnippetEllipsis)
+	} else {
+		b.WriteString(collapseSpace(text[pos:]))
+	}
+	return strings.TrimSpace(b.String())
+}
+
+// collapseSpace replaces runs of whitespace (including newlines) with a
+// single space so snippets stay on one line.
+func collapseSpace(s string) string {
+	if !strings.ContainsFunc(s, unicode.IsSpace) {
+		return s
+	}
+	var b strings.Builder
+	space := false
+	for len(s) > 0 {
+		r, size := utf8.DecodeRuneInString(s)
+		s = s[size:]
+		if unicode.IsSpace(r) {
+			if !space {
+				b.WriteByte(' ')
+			}
+			space = true
+			continue
+		}
+		space = false
+		b.WriteRune(r)
+	}
+	return b.String()
+}
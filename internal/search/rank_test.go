@@ -0,0 +1,106 @@
+package search
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func testIssues() []*types.Issue {
+	return []*types.Issue{
+		{ID: "bd-a1", Title: "Fix login timeout", Description: "Users are logged out after 30s", Priority: 1},
+		{ID: "bd-b2", Title: "Add dark mode", Description: "Theme support for the login page", Priority: 2},
+		{ID: "bd-c3", Title: "Refactor storage", Description: "Split queries into smaller files", Priority: 2},
+		{ID: "bd-5qx", Title: "OAuth login flow", Description: "Login via OAuth providers", Priority: 3},
+	}
+}
+
+func resultIDs(results []*types.SearchResult) []string {
+	ids := make([]string, len(results))
+	for i, r := range results {
+		ids[i] = r.ID
+	}
+	return ids
+}
+
+func TestRankIssues(t *testing.T) {
+	issues := testIssues()
+	comments := map[string][]*types.Comment{
+		"bd-c3": {{IssueID: "bd-c3", Text: "This also fixes a timeout in export"}},
+	}
+
+	tests := []struct {
+		name  string
+		query string
+		want  []string
+	}{
+		{"title beats description", "login", []string{"bd-5qx", "bd-a1", "bd-b2"}},
+		{"implicit and", "login timeout", []string{"bd-a1"}},
+		{"comments are searched", "export", []string{"bd-c3"}},
+		{"or", "storage OR dark", []string{"bd-b2", "bd-c3"}},
+		{"not", "login NOT oauth", []string{"bd-a1", "bd-b2"}},
+		{"prefix", "refact*", []string{"bd-c3"}},
+		{"phrase", `"logged out"`, []string{"bd-a1"}},
+		{"phrase order matters", `"out logged"`, nil},
+		{"partial id", "bd-5q", []string{"bd-5qx"}},
+		{"no match", "kubernetes", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q, err := Parse(tt.query)
+			if err != nil {
+				t.Fatalf("Parse(%q) error: %v", tt.query, err)
+			}
+			got := resultIDs(RankIssues(q, issues, comments, 0))
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("RankIssues(%q) = %v, want %v", tt.query, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRankLimitAndScores(t *testing.T) {
+	q, err := Parse("login")
+	if err != nil {
+		t.Fatal(err)
+	}
+	results := RankIssues(q, testIssues(), nil, 2)
+	if len(results) != 2 {
+		t.Fatalf("expected 2 results with limit, got %d", len(results))
+	}
+	if results[0].Score < results[1].Score {
+		t.Errorf("results not sorted by descending score: %v, %v", results[0].Score, results[1].Score)
+	}
+	if results[1].Score <= 0 {
+		t.Errorf("expected positive score, got %v", results[1].Score)
+	}
+}
+
+func TestSnippet(t *testing.T) {
+	issue := &types.Issue{
+		ID:          "bd-1",
+		Title:       "Unrelated title",
+		Description: "One two three four five six seven eight nine ten eleven twelve.\nThe login\n  times out after thirty seconds of inactivity on the settings page when idle for long periods",
+	}
+	q, err := Parse("login")
+	if err != nil {
+		t.Fatal(err)
+	}
+	snippet := Snippet(q, NewDocument(issue, nil))
+	if !strings.Contains(snippet, HighlightStart+"login"+HighlightEnd) {
+		t.Errorf("snippet missing highlighted term: %q", snippet)
+	}
+	if !strings.HasPrefix(snippet, SnippetEllipsis) || !strings.HasSuffix(snippet, SnippetEllipsis) {
+		t.Errorf("expected snippet to be elided on both sides: %q", snippet)
+	}
+	if strings.Contains(snippet, "\n") {
+		t.Errorf("snippet should be single-line: %q", snippet)
+	}
+
+	// ID-only matches produce no snippet
+	q, _ = Parse("bd-1")
+	if got := Snippet(q, NewDocument(issue, nil)); got != "" {
+		t.Errorf("expected empty snippet for ID-only match, got %q", got)
+	}
+}
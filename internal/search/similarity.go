@@ -0,0 +1,447 @@
+package search
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// DefaultSimilarityThreshold is the minimum Similarity score at which two
+// issues are reported as near-duplicates. Issue titles are short, so
+// genuine rewordings ("Fix login timeout" / "login times out after 30s")
+// typically score 0.35-0.5 while unrelated issues stay well below 0.3.
+const DefaultSimilarityThreshold = 0.35
+
+// titleWeight is how many times title terms are counted relative to
+// description terms. Titles are short and carry most of the intent.
+const titleWeight = 2
+
+// maxSharedTerms caps the number of terms listed in a Similarity explanation.
+const maxSharedTerms = 5
+
+// stopwords are common English words, plus the verbs almost every issue
+// title starts with, that carry no signal for duplicate detection. Kept
+// deliberately small: words like "not" or "after" can matter.
+var stopwords = map[string]bool{
+	"a": true, "an": true, "and": true, "are": true, "as": true, "at": true,
+	"be": true, "by": true, "for": true, "from": true, "in": true, "is": true,
+	"it": true, "of": true, "on": true, "or": true, "that": true, "the": true,
+	"this": true, "to": true, "was": true, "we": true, "when": true, "with": true,
+	"add": true, "bug": true, "fix": true, "issue": true, "should": true, "update": true,
+}
+
+// CandidateQuery returns a full-text query (see Parse) matching issues that
+// share a distinctive word with title or description, using at most
+// maxTerms words. It narrows the issues worth scoring against a new one;
+// an empty result means the text has no usable words.
+func CandidateQuery(title, description string, maxTerms int) string {
+	var terms []string
+	seen := make(map[string]bool)
+	for _, tok := range TokenStrings(title + "\n" + description) {
+		if stopwords[tok] || len(tok) < 3 {
+			continue
+		}
+		// Match inflections too: "timeouts" searches for timeout*
+		term := tok
+		if stem := Stem(tok); strings.HasPrefix(tok, stem) {
+			term = stem + "*"
+		}
+		if seen[term] {
+			continue
+		}
+		seen[term] = true
+		terms = append(terms, term)
+		if len(terms) == maxTerms {
+			break
+		}
+	}
+	return strings.Join(terms, " OR ")
+}
+
+// Similarity explains how closely two issues match. Score is the mean of
+// the TF-IDF cosine similarity over stemmed words (Words) and over
+// character trigrams (Shingles); the trigram half catches variants such as
+// "timeout" vs "times out" that share no whole word.
+type Similarity struct {
+	A           *types.Issue `json:"-"`
+	B           *types.Issue `json:"-"`
+	Score       float64      `json:"score"`
+	Words       float64      `json:"word_similarity"`
+	Shingles    float64      `json:"shingle_similarity"`
+	SharedTerms []string     `json:"shared_terms"`
+}
+
+// Explain returns a one-line, human-readable summary of the score.
+func (s *Similarity) Explain() string {
+	explanation := fmt.Sprintf("%.0f%% similar (words %.0f%%, trigrams %.0f%%)",
+		s.Score*100, s.Words*100, s.Shingles*100)
+	if len(s.SharedTerms) > 0 {
+		explanation += "; shared: " + strings.Join(s.SharedTerms, ", ")
+	}
+	return explanation
+}
+
+// similarityDoc is the sparse TF-IDF representation of one issue.
+type similarityDoc struct {
+	issue    *types.Issue
+	words    map[string]float64 // stem -> raw term frequency, later TF-IDF weight
+	shingles map[string]float64 // trigram -> raw frequency, later TF-IDF weight
+	surface  map[string]string  // stem -> first surface form, for explanations
+}
+
+// Corpus holds TF-IDF vectors for a set of issues so that pairwise
+// similarities can be computed. IDF is computed over the whole corpus (or a
+// larger set, see NewCorpusWithFrequencies), so terms that appear in most
+// issues (e.g. the project name) carry little weight.
+type Corpus struct {
+	docs  []*similarityDoc
+	index map[string]int // issue ID -> position in docs
+}
+
+// NewCorpus vectorizes the title and description of each issue.
+func NewCorpus(issues []*types.Issue) *Corpus {
+	c := vectorize(issues)
+	df := newFrequencies()
+	for _, d := range c.docs {
+		df.count(d)
+	}
+	c.weight(df)
+	return c
+}
+
+// NewCorpusWithFrequencies is NewCorpus with IDF taken from df rather than
+// from issues. Scoring a handful of candidates against the frequencies of
+// every open issue gives the same scores bd duplicates --similar computes,
+// so one threshold means the same thing in both.
+func NewCorpusWithFrequencies(issues []*types.Issue, df *Frequencies) *Corpus {
+	c := vectorize(issues)
+	c.weight(df)
+	return c
+}
+
+// Frequencies holds the document frequencies of words and trigrams over a
+// set of issues.
+type Frequencies struct {
+	n        int
+	words    map[string]int
+	shingles map[string]int
+}
+
+// NewFrequencies counts the document frequencies of terms over the title
+// and description of each issue.
+func NewFrequencies(issues []*types.Issue) *Frequencies {
+	df := newFrequencies()
+	for _, issue := range issues {
+		df.count(newSimilarityDoc(issue))
+	}
+	return df
+}
+
+// NewSampleFrequencies counts document frequencies over sample, a subset
+// of total issues, and computes IDF as if over all of them. When sample
+// holds every issue that shares a term with the issues being compared (as
+// a full-text search for their words returns) the scores match
+// NewFrequencies over the whole set, without loading it.
+func NewSampleFrequencies(sample []*types.Issue, total int) *Frequencies {
+	df := NewFrequencies(sample)
+	if total > df.n {
+		df.n = total
+	}
+	return df
+}
+
+func newFrequencies() *Frequencies {
+	return &Frequencies{words: make(map[string]int), shingles: make(map[string]int)}
+}
+
+func (df *Frequencies) count(d *similarityDoc) {
+	df.n++
+	for term := range d.words {
+		df.words[term]++
+	}
+	for term := range d.shingles {
+		df.shingles[term]++
+	}
+}
+
+func vectorize(issues []*types.Issue) *Corpus {
+	c := &Corpus{
+		docs:  make([]*similarityDoc, len(issues)),
+		index: make(map[string]int, len(issues)),
+	}
+	for i, issue := range issues {
+		c.docs[i] = newSimilarityDoc(issue)
+		c.index[issue.ID] = i
+	}
+	return c
+}
+
+// weight turns the raw term frequencies of every document into TF-IDF
+// weights.
+func (c *Corpus) weight(df *Frequencies) {
+	n := float64(df.n)
+	for _, d := range c.docs {
+		applyIDF(d.words, df.words, n)
+		applyIDF(d.shingles, df.shingles, n)
+	}
+}
+
+func newSimilarityDoc(issue *types.Issue) *similarityDoc {
+	d := &similarityDoc{
+		issue:    issue,
+		words:    make(map[string]float64),
+		shingles: make(map[string]float64),
+		surface:  make(map[string]string),
+	}
+	d.add(issue.Title, titleWeight)
+	d.add(issue.Description, 1)
+	return d
+}
+
+func (d *similarityDoc) add(text string, weight float64) {
+	prev, prevTok := "", ""
+	for _, tok := range TokenStrings(text) {
+		if stopwords[tok] {
+			prev, prevTok = "", ""
+			continue
+		}
+		stem := Stem(tok)
+		d.words[stem] += weight
+		if _, ok := d.surface[stem]; !ok {
+			d.surface[stem] = tok
+		}
+		// Adjacent words also count as a compound at half weight, so
+		// "times out" matches "timeout" and "log in" matches "login".
+		if prev != "" {
+			compound := prev + stem
+			d.words[compound] += weight / 2
+			if _, ok := d.surface[compound]; !ok {
+				d.surface[compound] = prevTok + " " + tok
+			}
+		}
+		prev, prevTok = stem, tok
+		padded := "_" + tok + "_"
+		runes := []rune(padded)
+		for i := 0; i+3 <= len(runes); i++ {
+			d.shingles[string(runes[i:i+3])] += weight
+		}
+	}
+}
+
+// applyIDF converts raw term frequencies to smoothed TF-IDF weights and
+// normalizes the vector to unit length.
+func applyIDF(vec map[string]float64, df map[string]int, n float64) {
+	norm := 0.0
+	for term, tf := range vec {
+		w := (1 + math.Log(tf)) * (1 + math.Log((1+n)/(1+float64(df[term]))))
+		vec[term] = w
+		norm += w * w
+	}
+	if norm == 0 {
+		return
+	}
+	norm = math.Sqrt(norm)
+	for term := range vec {
+		vec[term] /= norm
+	}
+}
+
+func cosine(a, b map[string]float64) float64 {
+	if len(a) > len(b) {
+		a, b = b, a
+	}
+	dot := 0.0
+	for term, w := range a {
+		dot += w * b[term]
+	}
+	return dot
+}
+
+// compare computes the similarity between docs i and j.
+func (c *Corpus) compare(i, j int) *Similarity {
+	a, b := c.docs[i], c.docs[j]
+	words := cosine(a.words, b.words)
+	shingles := cosine(a.shingles, b.shingles)
+
+	// Explain with the shared words that contribute most to the score
+	type contribution struct {
+		term   string
+		weight float64
+	}
+	var shared []contribution
+	for term, w := range a.words {
+		if bw, ok := b.words[term]; ok {
+			shared = append(shared, contribution{a.surface[term], w * bw})
+		}
+	}
+	sort.Slice(shared, func(x, y int) bool {
+		if shared[x].weight != shared[y].weight {
+			return shared[x].weight > shared[y].weight
+		}
+		return shared[x].term < shared[y].term
+	})
+	if len(shared) > maxSharedTerms {
+		shared = shared[:maxSharedTerms]
+	}
+	terms := make([]string, len(shared))
+	for k, s := range shared {
+		terms[k] = s.term
+	}
+
+	return &Similarity{
+		A:           a.issue,
+		B:           b.issue,
+		Score:       (words + shingles) / 2,
+		Words:       words,
+		Shingles:    shingles,
+		SharedTerms: terms,
+	}
+}
+
+// Compare returns the similarity between two issues in the corpus, or nil
+// if either ID is unknown.
+func (c *Corpus) Compare(aID, bID string) *Similarity {
+	i, ok := c.index[aID]
+	if !ok {
+		return nil
+	}
+	j, ok := c.index[bID]
+	if !ok {
+		return nil
+	}
+	return c.compare(i, j)
+}
+
+// Pairs returns every pair of issues whose similarity is at least threshold,
+// most similar first. Issues with different statuses are never paired, in
+// line with exact duplicate detection.
+func (c *Corpus) Pairs(threshold float64) []*Similarity {
+	var pairs []*Similarity
+	for i := range c.docs {
+		for j := i + 1; j < len(c.docs); j++ {
+			if c.docs[i].issue.Status != c.docs[j].issue.Status {
+				continue
+			}
+			if sim := c.compare(i, j); sim.Score >= threshold {
+				pairs = append(pairs, sim)
+			}
+		}
+	}
+	sortSimilarities(pairs)
+	return pairs
+}
+
+// Similar returns the issues in the corpus that are at least threshold
+// similar to the issue at index i, most similar first. A limit <= 0 returns
+// all matches.
+func (c *Corpus) Similar(i int, threshold float64, limit int) []*Similarity {
+	var matches []*Similarity
+	for j := range c.docs {
+		if j == i {
+			continue
+		}
+		if sim := c.compare(i, j); sim.Score >= threshold {
+			matches = append(matches, sim)
+		}
+	}
+	sortSimilarities(matches)
+	if limit > 0 && len(matches) > limit {
+		matches = matches[:limit]
+	}
+	return matches
+}
+
+func sortSimilarities(sims []*Similarity) {
+	sort.SliceStable(sims, func(i, j int) bool {
+		if sims[i].Score != sims[j].Score {
+			return sims[i].Score > sims[j].Score
+		}
+		if sims[i].A.ID != sims[j].A.ID {
+			return sims[i].A.ID < sims[j].A.ID
+		}
+		return sims[i].B.ID < sims[j].B.ID
+	})
+}
+
+// Cluster groups issues connected by similar pairs (single-linkage), so
+// that A~B and B~C yield one cluster {A, B, C}. Clusters and their members
+// are ordered by ID for stable output.
+func Cluster(pairs []*Similarity) [][]*types.Issue {
+	parent := make(map[string]string)
+	issues := make(map[string]*types.Issue)
+	var find func(id string) string
+	find = func(id string) string {
+		if parent[id] != id {
+			parent[id] = find(parent[id])
+		}
+		return parent[id]
+	}
+	for _, p := range pairs {
+		for _, issue := range []*types.Issue{p.A, p.B} {
+			if _, ok := parent[issue.ID]; !ok {
+				parent[issue.ID] = issue.ID
+				issues[issue.ID] = issue
+			}
+		}
+		ra, rb := find(p.A.ID), find(p.B.ID)
+		if ra != rb {
+			if ra < rb {
+				parent[rb] = ra
+			} else {
+				parent[ra] = rb
+			}
+		}
+	}
+
+	groups := make(map[string][]*types.Issue)
+	for id, issue := range issues {
+		root := find(id)
+		groups[root] = append(groups[root], issue)
+	}
+	clusters := make([][]*types.Issue, 0, len(groups))
+	for _, group := range groups {
+		sort.Slice(group, func(i, j int) bool { return group[i].ID < group[j].ID })
+		clusters = append(clusters, group)
+	}
+	sort.Slice(clusters, func(i, j int) bool { return clusters[i][0].ID < clusters[j][0].ID })
+	return clusters
+}
+
+// Stem reduces an English word to a crude stem by stripping common
+// inflectional suffixes ("times" -> "time", "failing" -> "fail"). It is
+// intentionally conservative; the trigram half of Similarity covers the rest.
+func Stem(word string) string {
+	if len(word) <= 3 {
+		return word
+	}
+	switch {
+	case strings.HasSuffix(word, "ies") && len(word) > 4:
+		return word[:len(word)-3] + "y"
+	case strings.HasSuffix(word, "sses"):
+		return word[:len(word)-2]
+	case strings.HasSuffix(word, "ss"), strings.HasSuffix(word, "us"):
+		return word
+	case strings.HasSuffix(word, "ing") && len(word) > 5:
+		return trimDoubled(word[:len(word)-3])
+	case strings.HasSuffix(word, "ed") && len(word) > 4:
+		return trimDoubled(word[:len(word)-2])
+	case strings.HasSuffix(word, "es") && len(word) > 4 && strings.ContainsAny(word[len(word)-3:len(word)-2], "sxz"):
+		return word[:len(word)-2]
+	case strings.HasSuffix(word, "s"):
+		return word[:len(word)-1]
+	}
+	return word
+}
+
+// trimDoubled undoes consonant doubling before a stripped suffix
+// ("stopped" -> "stopp" -> "stop").
+func trimDoubled(s string) string {
+	n := len(s)
+	if n >= 2 && s[n-1] == s[n-2] && !strings.ContainsRune("aeioulsz", rune(s[n-1])) {
+		return s[:n-1]
+	}
+	return s
+}
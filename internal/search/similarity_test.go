@@ -0,0 +1,219 @@
+package search
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func similarityFixture() []*types.Issue {
+	return []*types.Issue{
+		{ID: "bd-1", Title: "Fix login timeout", Status: types.StatusOpen},
+		{ID: "bd-2", Title: "login times out after 30s", Status: types.StatusOpen},
+		{ID: "bd-3", Title: "Add dark mode to settings page", Status: types.StatusOpen},
+		{ID: "bd-4", Title: "Update README installation steps", Status: types.StatusOpen},
+		{ID: "bd-5", Title: "Settings page should support dark mode", Status: types.StatusOpen},
+		{ID: "bd-6", Title: "Crash when exporting empty database", Status: types.StatusOpen},
+		{ID: "bd-7", Title: "Fix typo in login page", Status: types.StatusOpen},
+		{ID: "bd-8", Title: "Fix flaky export test", Status: types.StatusOpen},
+		{ID: "bd-9", Title: "Session timeout is too short", Status: types.StatusOpen},
+	}
+}
+
+func pairIDs(pairs []*Similarity) []string {
+	ids := make([]string, len(pairs))
+	for i, p := range pairs {
+		ids[i] = p.A.ID + "~" + p.B.ID
+	}
+	return ids
+}
+
+func TestCorpusPairs(t *testing.T) {
+	c := NewCorpus(similarityFixture())
+	pairs := c.Pairs(DefaultSimilarityThreshold)
+
+	got := pairIDs(pairs)
+	want := []string{"bd-3~bd-5", "bd-1~bd-2"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Fatalf("Pairs() = %v, want %v", got, want)
+	}
+	for _, p := range pairs {
+		if p.Score < DefaultSimilarityThreshold || p.Score > 1.0001 {
+			t.Errorf("%s~%s score %f out of range", p.A.ID, p.B.ID, p.Score)
+		}
+	}
+}
+
+func TestCorpusPairsIgnoresStatus(t *testing.T) {
+	issues := []*types.Issue{
+		{ID: "bd-1", Title: "Fix login timeout", Status: types.StatusOpen},
+		{ID: "bd-2", Title: "Fix login timeout", Status: types.StatusInProgress},
+	}
+	if pairs := NewCorpus(issues).Pairs(0.1); len(pairs) != 0 {
+		t.Errorf("expected no pairs across statuses, got %v", pairIDs(pairs))
+	}
+}
+
+func TestCorpusIdenticalIssues(t *testing.T) {
+	issues := []*types.Issue{
+		{ID: "bd-1", Title: "Sync drops comments", Description: "Comments vanish after bd sync", Status: types.StatusOpen},
+		{ID: "bd-2", Title: "Sync drops comments", Description: "Comments vanish after bd sync", Status: types.StatusOpen},
+	}
+	pairs := NewCorpus(issues).Pairs(0.99)
+	if len(pairs) != 1 {
+		t.Fatalf("expected identical issues to pair, got %d pairs", len(pairs))
+	}
+}
+
+func TestSimilarExplain(t *testing.T) {
+	c := NewCorpus(similarityFixture())
+	matches := c.Similar(0, DefaultSimilarityThreshold, 0)
+	if len(matches) != 1 || matches[0].B.ID != "bd-2" {
+		t.Fatalf("Similar(bd-1) = %v, want [bd-1~bd-2]", pairIDs(matches))
+	}
+
+	m := matches[0]
+	for _, term := range []string{"login", "timeout"} {
+		found := false
+		for _, shared := range m.SharedTerms {
+			if shared == term {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("SharedTerms = %v, missing %q", m.SharedTerms, term)
+		}
+	}
+	explanation := m.Explain()
+	if !strings.Contains(explanation, "% similar") || !strings.Contains(explanation, "shared: ") {
+		t.Errorf("Explain() = %q", explanation)
+	}
+}
+
+func TestCorpusCompare(t *testing.T) {
+	c := NewCorpus(similarityFixture())
+	sim := c.Compare("bd-5", "bd-3")
+	if sim == nil || sim.A.ID != "bd-5" || sim.B.ID != "bd-3" {
+		t.Fatalf("Compare(bd-5, bd-3) = %+v", sim)
+	}
+	if sim.Score < DefaultSimilarityThreshold {
+		t.Errorf("Compare(bd-5, bd-3) score = %f, want >= %f", sim.Score, DefaultSimilarityThreshold)
+	}
+	if c.Compare("bd-5", "bd-missing") != nil {
+		t.Error("Compare with unknown ID should return nil")
+	}
+}
+
+func TestSimilarLimit(t *testing.T) {
+	c := NewCorpus(similarityFixture())
+	if matches := c.Similar(0, 0, 2); len(matches) != 2 {
+		t.Errorf("Similar with limit 2 returned %d matches", len(matches))
+	}
+}
+
+func TestCluster(t *testing.T) {
+	a := &types.Issue{ID: "bd-a"}
+	b := &types.Issue{ID: "bd-b"}
+	c := &types.Issue{ID: "bd-c"}
+	d := &types.Issue{ID: "bd-d"}
+	e := &types.Issue{ID: "bd-e"}
+
+	clusters := Cluster([]*Similarity{
+		{A: c, B: b},
+		{A: a, B: b},
+		{A: d, B: e},
+	})
+	if len(clusters) != 2 {
+		t.Fatalf("expected 2 clusters, got %d", len(clusters))
+	}
+	var got []string
+	for _, cluster := range clusters {
+		var ids []string
+		for _, issue := range cluster {
+			ids = append(ids, issue.ID)
+		}
+		got = append(got, strings.Join(ids, ","))
+	}
+	if got[0] != "bd-a,bd-b,bd-c" || got[1] != "bd-d,bd-e" {
+		t.Errorf("Cluster() = %v", got)
+	}
+}
+
+func TestStem(t *testing.T) {
+	tests := map[string]string{
+		"times":    "time",
+		"failing":  "fail",
+		"stopped":  "stop",
+		"issues":   "issue",
+		"queries":  "query",
+		"fixes":    "fix",
+		"status":   "status",
+		"class":    "class",
+		"out":      "out",
+		"settings": "setting",
+	}
+	for in, want := range tests {
+		if got := Stem(in); got != want {
+			t.Errorf("Stem(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestCandidateQuery(t *testing.T) {
+	tests := []struct {
+		title, description string
+		want               string
+	}{
+		{"Fix login timeouts", "", "login* OR timeout*"},
+		{"Login times out", "The login page hangs", "login* OR time* OR out* OR page* OR hang*"},
+		{"Fix the bug", "", ""},
+	}
+	for _, tt := range tests {
+		if got := CandidateQuery(tt.title, tt.description, 5); got != tt.want {
+			t.Errorf("CandidateQuery(%q, %q) = %q, want %q", tt.title, tt.description, got, tt.want)
+		}
+	}
+	if _, err := Parse(CandidateQuery("Crash on empty database export", "", 3)); err != nil {
+		t.Errorf("candidate query doesn't parse: %v", err)
+	}
+}
+
+func TestCorpusWithFrequencies(t *testing.T) {
+	issues := similarityFixture()
+	full := NewCorpus(issues)
+
+	// Scoring a subset against the frequencies of every issue gives the
+	// scores of the full corpus; its own frequencies don't
+	subset := []*types.Issue{issues[0], issues[1], issues[6]}
+	weighted := NewCorpusWithFrequencies(subset, NewFrequencies(issues))
+	local := NewCorpus(subset)
+	for _, pair := range [][2]string{{"bd-1", "bd-2"}, {"bd-1", "bd-7"}} {
+		want := full.Compare(pair[0], pair[1]).Score
+		if got := weighted.Compare(pair[0], pair[1]).Score; math.Abs(got-want) > 1e-9 {
+			t.Errorf("%s~%s = %f with full frequencies, want %f", pair[0], pair[1], got, want)
+		}
+	}
+	if got, want := local.Compare("bd-1", "bd-2").Score, full.Compare("bd-1", "bd-2").Score; math.Abs(got-want) < 1e-9 {
+		t.Errorf("subset frequencies gave the full-corpus score %f; the test doesn't discriminate", got)
+	}
+}
+
+func TestSampleFrequencies(t *testing.T) {
+	issues := similarityFixture()
+	subset := []*types.Issue{issues[0], issues[1]}
+
+	// Issues sharing no term with the subset only change the issue count,
+	// so a sample scaled to the full count scores like the full corpus
+	all := append([]*types.Issue{}, subset...)
+	for i := 0; i < 20; i++ {
+		all = append(all, &types.Issue{ID: fmt.Sprintf("bd-x%d", i), Title: "Quarry roadmap zebra"})
+	}
+	want := NewCorpus(all).Compare("bd-1", "bd-2").Score
+	sampled := NewCorpusWithFrequencies(subset, NewSampleFrequencies(subset, len(all)))
+	if got := sampled.Compare("bd-1", "bd-2").Score; math.Abs(got-want) > 1e-9 {
+		t.Errorf("bd-1~bd-2 = %f with sample frequencies, want %f", got, want)
+	}
+}
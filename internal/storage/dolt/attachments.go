@@ -0,0 +1,130 @@
+package dolt
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// SaveAttachment inserts attachment metadata, or replaces the attachment with the same ID
+func (s *DoltStore) SaveAttachment(ctx context.Context, attachment *types.Attachment) error {
+	if attachment.ID == "" {
+		return fmt.Errorf("attachment ID is required")
+	}
+
+	// Verify issue exists
+	var exists bool
+	if err := s.db.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM issues WHERE id = ?)`, attachment.IssueID).Scan(&exists); err != nil {
+		return fmt.Errorf("failed to check issue existence: %w", err)
+	}
+	if !exists {
+		return fmt.Errorf("issue %s not found", attachment.IssueID)
+	}
+
+	if attachment.CreatedAt.IsZero() {
+		attachment.CreatedAt = time.Now().UTC()
+	}
+	var detachedAt interface{}
+	if attachment.DetachedAt != nil {
+		detachedAt = attachment.DetachedAt.UTC()
+	}
+
+	if _, err := s.db.ExecContext(ctx, `
+		INSERT INTO attachments (id, issue_id, name, sha256, size, media_type, created_by, created_at, detached_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE
+			issue_id = VALUES(issue_id),
+			name = VALUES(name),
+			sha256 = VALUES(sha256),
+			size = VALUES(size),
+			media_type = VALUES(media_type),
+			detached_at = VALUES(detached_at)
+	`, attachment.ID, attachment.IssueID, attachment.Name, attachment.SHA256, attachment.Size,
+		attachment.MediaType, attachment.CreatedBy, attachment.CreatedAt.UTC(), detachedAt); err != nil {
+		return fmt.Errorf("failed to save attachment: %w", err)
+	}
+
+	// Mark issue dirty for incremental JSONL export
+	if _, err := s.db.ExecContext(ctx, `
+		INSERT INTO dirty_issues (issue_id, marked_at)
+		VALUES (?, ?)
+		ON DUPLICATE KEY UPDATE marked_at = VALUES(marked_at)
+	`, attachment.IssueID, time.Now().UTC()); err != nil {
+		return fmt.Errorf("failed to mark issue dirty: %w", err)
+	}
+
+	return nil
+}
+
+// GetAttachments retrieves the attachments of an issue, oldest first
+func (s *DoltStore) GetAttachments(ctx context.Context, issueID string) ([]*types.Attachment, error) {
+	result, err := s.GetAttachmentsForIssues(ctx, []string{issueID})
+	if err != nil {
+		return nil, err
+	}
+	return result[issueID], nil
+}
+
+// GetAttachmentsForIssues retrieves attachments for multiple issues
+func (s *DoltStore) GetAttachmentsForIssues(ctx context.Context, issueIDs []string) (map[string][]*types.Attachment, error) {
+	if len(issueIDs) == 0 {
+		return make(map[string][]*types.Attachment), nil
+	}
+
+	placeholders := make([]string, len(issueIDs))
+	args := make([]interface{}, len(issueIDs))
+	for i, id := range issueIDs {
+		placeholders[i] = "?"
+		args[i] = id
+	}
+
+	// nolint:gosec // G201: placeholders contains only ? markers, actual values passed via args
+	query := fmt.Sprintf(`
+		SELECT id, issue_id, name, sha256, size, media_type, created_by, created_at, detached_at
+		FROM attachments
+		WHERE issue_id IN (%s)
+		ORDER BY issue_id, created_at ASC, id ASC
+	`, joinStrings(placeholders, ","))
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get attachments: %w", err)
+	}
+	defer rows.Close()
+
+	result := make(map[string][]*types.Attachment)
+	for rows.Next() {
+		var a types.Attachment
+		var detachedAt sql.NullTime
+		if err := rows.Scan(&a.ID, &a.IssueID, &a.Name, &a.SHA256, &a.Size, &a.MediaType, &a.CreatedBy, &a.CreatedAt, &detachedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan attachment: %w", err)
+		}
+		if detachedAt.Valid {
+			a.DetachedAt = &detachedAt.Time
+		}
+		result[a.IssueID] = append(result[a.IssueID], &a)
+	}
+	return result, rows.Err()
+}
+
+// GetAttachmentHashes returns the content hashes of all attachments that haven't been detached
+func (s *DoltStore) GetAttachmentHashes(ctx context.Context) (map[string]bool, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT DISTINCT sha256 FROM attachments WHERE detached_at IS NULL`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get attachment hashes: %w", err)
+	}
+	defer rows.Close()
+
+	hashes := make(map[string]bool)
+	for rows.Next() {
+		var hash string
+		if err := rows.Scan(&hash); err != nil {
+			return nil, fmt.Errorf("failed to scan attachment hash: %w", err)
+		}
+		hashes[hash] = true
+	}
+	return hashes, rows.Err()
+}
@@ -629,7 +629,7 @@ func (s *DoltStore) GetIssuesByIDs(ctx context.Context, ids []string) ([]*types.
 		       await_type, await_id, timeout_ns, waiters,
 		       hook_bead, role_bead, agent_state, last_activity, role_type, rig, mol_type,
 		       event_kind, actor, target, payload,
-		       due_at, defer_until,
+		       due_at, defer_until, recurrence, custom_fields, lease_expires_at,
 		       quality_score, work_type, source_system
 		FROM issues
 		WHERE id IN (%s)
@@ -657,11 +657,11 @@ func (s *DoltStore) GetIssuesByIDs(ctx context.Context, ids []string) ([]*types.
 func scanIssueRow(rows *sql.Rows) (*types.Issue, error) {
 	var issue types.Issue
 	var createdAtStr, updatedAtStr sql.NullString // TEXT columns - must parse manually
-	var closedAt, compactedAt, deletedAt, lastActivity, dueAt, deferUntil sql.NullTime
+	var closedAt, compactedAt, deletedAt, lastActivity, dueAt, deferUntil, leaseExpiresAt sql.NullTime
 	var estimatedMinutes, originalSize, timeoutNs sql.NullInt64
 	var assignee, externalRef, compactedAtCommit, owner sql.NullString
 	var contentHash, sourceRepo, closeReason, deletedBy, deleteReason, originalType sql.NullString
-	var workType, sourceSystem sql.NullString
+	var workType, sourceSystem, recurrence, customFields sql.NullString
 	var sender, molType, eventKind, actor, target, payload sql.NullString
 	var awaitType, awaitID, waiters sql.NullString
 	var hookBead, roleBead, agentState, roleType, rig sql.NullString
@@ -679,7 +679,7 @@ func scanIssueRow(rows *sql.Rows) (*types.Issue, error) {
 		&awaitType, &awaitID, &timeoutNs, &waiters,
 		&hookBead, &roleBead, &agentState, &lastActivity, &roleType, &rig, &molType,
 		&eventKind, &actor, &target, &payload,
-		&dueAt, &deferUntil,
+		&dueAt, &deferUntil, &recurrence, &customFields, &leaseExpiresAt,
 		&qualityScore, &workType, &sourceSystem,
 	); err != nil {
 		return nil, fmt.Errorf("failed to scan issue row: %w", err)
@@ -806,6 +806,15 @@ func scanIssueRow(rows *sql.Rows) (*types.Issue, error) {
 	if deferUntil.Valid {
 		issue.DeferUntil = &deferUntil.Time
 	}
+	if recurrence.Valid {
+		issue.Recurrence = recurrence.String
+	}
+	if customFields.Valid {
+		issue.CustomFields = parseJSONStringMap(customFields.String)
+	}
+	if leaseExpiresAt.Valid {
+		issue.LeaseExpiresAt = &leaseExpiresAt.Time
+	}
 	if qualityScore.Valid {
 		qs := float32(qualityScore.Float64)
 		issue.QualityScore = &qs
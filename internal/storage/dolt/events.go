@@ -63,6 +63,55 @@ func (s *DoltStore) GetEvents(ctx context.Context, issueID string, limit int) ([
 	return events, rows.Err()
 }
 
+// GetEventsForIssues retrieves events for multiple issues, oldest first
+func (s *DoltStore) GetEventsForIssues(ctx context.Context, issueIDs []string) (map[string][]*types.Event, error) {
+	if len(issueIDs) == 0 {
+		return make(map[string][]*types.Event), nil
+	}
+
+	placeholders := make([]string, len(issueIDs))
+	args := make([]interface{}, len(issueIDs))
+	for i, id := range issueIDs {
+		placeholders[i] = "?"
+		args[i] = id
+	}
+
+	// nolint:gosec // G201: placeholders contains only ? markers, actual values passed via args
+	query := fmt.Sprintf(`
+		SELECT id, issue_id, event_type, actor, old_value, new_value, comment, created_at
+		FROM events
+		WHERE issue_id IN (%s)
+		ORDER BY issue_id, created_at ASC, id ASC
+	`, joinStrings(placeholders, ","))
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get events: %w", err)
+	}
+	defer rows.Close()
+
+	result := make(map[string][]*types.Event)
+	for rows.Next() {
+		var event types.Event
+		var oldValue, newValue, comment sql.NullString
+		if err := rows.Scan(&event.ID, &event.IssueID, &event.EventType, &event.Actor,
+			&oldValue, &newValue, &comment, &event.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan event: %w", err)
+		}
+		if oldValue.Valid {
+			event.OldValue = &oldValue.String
+		}
+		if newValue.Valid {
+			event.NewValue = &newValue.String
+		}
+		if comment.Valid {
+			event.Comment = &comment.String
+		}
+		result[event.IssueID] = append(result[event.IssueID], &event)
+	}
+	return result, rows.Err()
+}
+
 // AddIssueComment adds a comment to an issue (structured comment)
 func (s *DoltStore) AddIssueComment(ctx context.Context, issueID, author, text string) (*types.Comment, error) {
 	return s.ImportIssueComment(ctx, issueID, author, text, time.Now().UTC())
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/validation"
 )
 
 // CreateIssue creates a new issue
@@ -231,38 +232,7 @@ func (s *DoltStore) GetIssueByExternalRef(ctx context.Context, externalRef strin
 // Returns (true, nil) if claim succeeded, (false, nil) if already claimed by someone else,
 // or (false, error) if the operation failed.
 func (s *DoltStore) ClaimIssue(ctx context.Context, id string, assignee string) (bool, error) {
-	now := time.Now().UTC()
-
-	// Atomic claim: succeeds if assignee is empty OR status is not in_progress
-	// This allows claiming open tasks even with stale assignees
-	result, err := s.db.ExecContext(ctx, `
-		UPDATE issues
-		SET assignee = ?, status = ?, updated_at = ?
-		WHERE id = ? AND (assignee = '' OR assignee IS NULL OR status != 'in_progress')
-	`, assignee, string(types.StatusInProgress), now, id)
-	if err != nil {
-		return false, fmt.Errorf("failed to claim issue: %w", err)
-	}
-
-	rowsAffected, err := result.RowsAffected()
-	if err != nil {
-		return false, fmt.Errorf("failed to get rows affected: %w", err)
-	}
-
-	if rowsAffected == 0 {
-		// Either issue doesn't exist or is already claimed
-		issue, err := s.GetIssue(ctx, id)
-		if err != nil {
-			return false, fmt.Errorf("failed to check issue exists: %w", err)
-		}
-		if issue == nil {
-			return false, fmt.Errorf("issue %s not found", id)
-		}
-		// Issue exists but already claimed
-		return false, nil
-	}
-
-	return true, nil
+	return s.ClaimIssueWithLease(ctx, id, assignee, nil)
 }
 
 // UpdateIssue updates fields on an issue
@@ -275,6 +245,12 @@ func (s *DoltStore) UpdateIssue(ctx context.Context, id string, updates map[stri
 		return fmt.Errorf("issue %s not found", id)
 	}
 
+	// Enforce the status workflow from config.yaml
+	override, err := validation.CheckWorkflow(ctx, oldIssue, updates)
+	if err != nil {
+		return err
+	}
+
 	// Build update query
 	setClauses := []string{"updated_at = ?"}
 	args := []interface{}{time.Now().UTC()}
@@ -288,12 +264,27 @@ func (s *DoltStore) UpdateIssue(ctx context.Context, id string, updates map[stri
 		if key == "wisp" {
 			columnName = "ephemeral"
 		}
+		if key == "custom_fields" {
+			fields, ok := value.(map[string]string)
+			if !ok {
+				return fmt.Errorf("custom_fields must be map[string]string, got %T", value)
+			}
+			value = formatJSONStringMap(fields)
+		}
+		if key == "waiters" {
+			waiters, ok := value.([]string)
+			if !ok {
+				return fmt.Errorf("waiters must be []string, got %T", value)
+			}
+			value = formatJSONStringArray(waiters)
+		}
 		setClauses = append(setClauses, fmt.Sprintf("`%s` = ?", columnName))
 		args = append(args, value)
 	}
 
-	// Auto-manage closed_at
+	// Auto-manage closed_at and the claim lease
 	setClauses, args = manageClosedAt(oldIssue, updates, setClauses, args)
+	setClauses, args = manageLease(oldIssue, updates, setClauses, args)
 
 	args = append(args, id)
 
@@ -317,6 +308,9 @@ func (s *DoltStore) UpdateIssue(ctx context.Context, id string, updates map[stri
 	if err := recordEvent(ctx, tx, id, eventType, actor, string(oldData), string(newData)); err != nil {
 		return fmt.Errorf("failed to record event: %w", err)
 	}
+	if err := recordWorkflowOverride(ctx, tx, id, actor, override); err != nil {
+		return err
+	}
 
 	if err := markDirty(ctx, tx, id); err != nil {
 		return fmt.Errorf("failed to mark dirty: %w", err)
@@ -327,6 +321,12 @@ func (s *DoltStore) UpdateIssue(ctx context.Context, id string, updates map[stri
 
 // CloseIssue closes an issue with a reason
 func (s *DoltStore) CloseIssue(ctx context.Context, id string, reason string, actor string, session string) error {
+	// Enforce the status workflow from config.yaml
+	override, err := validation.CheckWorkflowByID(ctx, s.GetIssue, id, validation.CloseUpdates(reason))
+	if err != nil {
+		return err
+	}
+
 	now := time.Now().UTC()
 
 	tx, err := s.db.BeginTx(ctx, nil)
@@ -354,6 +354,9 @@ func (s *DoltStore) CloseIssue(ctx context.Context, id string, reason string, ac
 	if err := recordEvent(ctx, tx, id, types.EventClosed, actor, "", reason); err != nil {
 		return fmt.Errorf("failed to record event: %w", err)
 	}
+	if err := recordWorkflowOverride(ctx, tx, id, actor, override); err != nil {
+		return err
+	}
 
 	if err := markDirty(ctx, tx, id); err != nil {
 		return fmt.Errorf("failed to mark dirty: %w", err)
@@ -416,7 +419,7 @@ func insertIssue(ctx context.Context, tx *sql.Tx, issue *types.Issue) error {
 			event_kind, actor, target, payload,
 			await_type, await_id, timeout_ns, waiters,
 			hook_bead, role_bead, agent_state, last_activity, role_type, rig,
-			due_at, defer_until
+			due_at, defer_until, recurrence, custom_fields, lease_expires_at
 		) VALUES (
 			?, ?, ?, ?, ?, ?, ?,
 			?, ?, ?, ?, ?,
@@ -428,7 +431,7 @@ func insertIssue(ctx context.Context, tx *sql.Tx, issue *types.Issue) error {
 			?, ?, ?, ?,
 			?, ?, ?, ?,
 			?, ?, ?, ?, ?, ?,
-			?, ?
+			?, ?, ?, ?, ?
 		)
 	`,
 		issue.ID, issue.ContentHash, issue.Title, issue.Description, issue.Design, issue.AcceptanceCriteria, issue.Notes,
@@ -441,7 +444,7 @@ func insertIssue(ctx context.Context, tx *sql.Tx, issue *types.Issue) error {
 		issue.EventKind, issue.Actor, issue.Target, issue.Payload,
 		issue.AwaitType, issue.AwaitID, issue.Timeout.Nanoseconds(), formatJSONStringArray(issue.Waiters),
 		issue.HookBead, issue.RoleBead, issue.AgentState, issue.LastActivity, issue.RoleType, issue.Rig,
-		issue.DueAt, issue.DeferUntil,
+		issue.DueAt, issue.DeferUntil, issue.Recurrence, formatJSONStringMap(issue.CustomFields), issue.LeaseExpiresAt,
 	)
 	return err
 }
@@ -449,11 +452,11 @@ func insertIssue(ctx context.Context, tx *sql.Tx, issue *types.Issue) error {
 func scanIssue(ctx context.Context, db *sql.DB, id string) (*types.Issue, error) {
 	var issue types.Issue
 	var createdAtStr, updatedAtStr sql.NullString // TEXT columns - must parse manually
-	var closedAt, compactedAt, deletedAt, lastActivity, dueAt, deferUntil sql.NullTime
+	var closedAt, compactedAt, deletedAt, lastActivity, dueAt, deferUntil, leaseExpiresAt sql.NullTime
 	var estimatedMinutes, originalSize, timeoutNs sql.NullInt64
 	var assignee, externalRef, compactedAtCommit, owner sql.NullString
 	var contentHash, sourceRepo, closeReason, deletedBy, deleteReason, originalType sql.NullString
-	var workType, sourceSystem sql.NullString
+	var workType, sourceSystem, recurrence, customFields sql.NullString
 	var sender, molType, eventKind, actor, target, payload sql.NullString
 	var awaitType, awaitID, waiters sql.NullString
 	var hookBead, roleBead, agentState, roleType, rig sql.NullString
@@ -470,7 +473,7 @@ func scanIssue(ctx context.Context, db *sql.DB, id string) (*types.Issue, error)
 		       await_type, await_id, timeout_ns, waiters,
 		       hook_bead, role_bead, agent_state, last_activity, role_type, rig, mol_type,
 		       event_kind, actor, target, payload,
-		       due_at, defer_until,
+		       due_at, defer_until, recurrence, custom_fields, lease_expires_at,
 		       quality_score, work_type, source_system
 		FROM issues
 		WHERE id = ?
@@ -485,7 +488,7 @@ func scanIssue(ctx context.Context, db *sql.DB, id string) (*types.Issue, error)
 		&awaitType, &awaitID, &timeoutNs, &waiters,
 		&hookBead, &roleBead, &agentState, &lastActivity, &roleType, &rig, &molType,
 		&eventKind, &actor, &target, &payload,
-		&dueAt, &deferUntil,
+		&dueAt, &deferUntil, &recurrence, &customFields, &leaseExpiresAt,
 		&qualityScore, &workType, &sourceSystem,
 	)
 
@@ -617,6 +620,15 @@ func scanIssue(ctx context.Context, db *sql.DB, id string) (*types.Issue, error)
 	if deferUntil.Valid {
 		issue.DeferUntil = &deferUntil.Time
 	}
+	if recurrence.Valid {
+		issue.Recurrence = recurrence.String
+	}
+	if customFields.Valid {
+		issue.CustomFields = parseJSONStringMap(customFields.String)
+	}
+	if leaseExpiresAt.Valid {
+		issue.LeaseExpiresAt = &leaseExpiresAt.Time
+	}
 	if qualityScore.Valid {
 		qs := float32(qualityScore.Float64)
 		issue.QualityScore = &qs
@@ -669,7 +681,8 @@ func isAllowedUpdateField(key string) bool {
 		"hook_bead": true, "role_bead": true, "agent_state": true, "last_activity": true,
 		"role_type": true, "rig": true, "mol_type": true,
 		"event_category": true, "event_actor": true, "event_target": true, "event_payload": true,
-		"due_at": true, "defer_until": true, "await_id": true,
+		"due_at": true, "defer_until": true, "recurrence": true, "await_id": true,
+		"custom_fields": true, "lease_expires_at": true, "waiters": true,
 	}
 	return allowed[key]
 }
@@ -703,6 +716,32 @@ func manageClosedAt(oldIssue *types.Issue, updates map[string]interface{}, setCl
 	return setClauses, args
 }
 
+// manageLease clears the claim lease when an update moves the status or
+// assignee and doesn't set lease_expires_at, as claims do.
+func manageLease(oldIssue *types.Issue, updates map[string]interface{}, setClauses []string, args []interface{}) ([]string, []interface{}) {
+	_, hasExplicitLease := updates["lease_expires_at"]
+	if oldIssue.LeaseExpiresAt == nil || hasExplicitLease {
+		return setClauses, args
+	}
+
+	changed := false
+	switch v := updates["status"].(type) {
+	case string:
+		changed = v != string(oldIssue.Status)
+	case types.Status:
+		changed = v != oldIssue.Status
+	}
+	if value, hasAssignee := updates["assignee"]; hasAssignee {
+		assignee, _ := value.(string) // nil unassigns
+		changed = changed || assignee != oldIssue.Assignee
+	}
+	if changed {
+		setClauses = append(setClauses, "lease_expires_at = ?")
+		args = append(args, nil)
+	}
+	return setClauses, args
+}
+
 func determineEventType(oldIssue *types.Issue, updates map[string]interface{}) types.EventType {
 	statusVal, hasStatus := updates["status"]
 	if !hasStatus {
@@ -773,3 +812,25 @@ func formatJSONStringArray(arr []string) string {
 	}
 	return string(data)
 }
+
+func parseJSONStringMap(s string) map[string]string {
+	if s == "" {
+		return nil
+	}
+	var result map[string]string
+	if err := json.Unmarshal([]byte(s), &result); err != nil || len(result) == 0 {
+		return nil
+	}
+	return result
+}
+
+func formatJSONStringMap(m map[string]string) string {
+	if len(m) == 0 {
+		return ""
+	}
+	data, err := json.Marshal(m)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
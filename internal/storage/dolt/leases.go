@@ -0,0 +1,188 @@
+package dolt
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// ClaimIssueWithLease is ClaimIssue with a claim that lapses at leaseUntil
+// (nil for no lease). An in_progress issue whose lease has lapsed can be
+// claimed again without waiting for the daemon to reclaim it.
+func (s *DoltStore) ClaimIssueWithLease(ctx context.Context, id string, assignee string, leaseUntil *time.Time) (bool, error) {
+	now := time.Now().UTC()
+	if leaseUntil != nil {
+		utc := leaseUntil.UTC()
+		leaseUntil = &utc
+	}
+
+	// Atomic claim: succeeds if assignee is empty OR status is not in_progress
+	// OR the current holder's lease has lapsed. This allows claiming open tasks
+	// even with stale assignees.
+	result, err := s.db.ExecContext(ctx, `
+		UPDATE issues
+		SET assignee = ?, status = ?, lease_expires_at = ?, updated_at = ?
+		WHERE id = ? AND (assignee = '' OR assignee IS NULL OR status != 'in_progress'
+			OR (lease_expires_at IS NOT NULL AND lease_expires_at <= ?))
+	`, assignee, string(types.StatusInProgress), leaseUntil, now, id, now)
+	if err != nil {
+		return false, fmt.Errorf("failed to claim issue: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		// Either issue doesn't exist or is already claimed
+		issue, err := s.GetIssue(ctx, id)
+		if err != nil {
+			return false, fmt.Errorf("failed to check issue exists: %w", err)
+		}
+		if issue == nil {
+			return false, fmt.Errorf("issue %s not found", id)
+		}
+		// Issue exists but already claimed
+		return false, nil
+	}
+
+	return true, nil
+}
+
+// RenewLease moves the lease deadline of an in_progress issue assigned to
+// assignee. Only lease_expires_at changes: the lease is runtime state, so a
+// heartbeat neither counts as an edit (updated_at) nor marks the issue for
+// export.
+func (s *DoltStore) RenewLease(ctx context.Context, id string, assignee string, leaseUntil time.Time) error {
+	result, err := s.db.ExecContext(ctx, `
+		UPDATE issues
+		SET lease_expires_at = ?
+		WHERE id = ? AND status = ? AND assignee = ?
+	`, leaseUntil.UTC(), id, string(types.StatusInProgress), assignee)
+	if err != nil {
+		return fmt.Errorf("failed to renew lease: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		issue, err := s.GetIssue(ctx, id)
+		if err != nil {
+			return fmt.Errorf("failed to check issue exists: %w", err)
+		}
+		return leaseNotHeldError(id, assignee, issue)
+	}
+	return nil
+}
+
+// ReclaimExpiredLeases returns in_progress issues whose lease lapsed at or
+// before now to open and unassigned. Each issue is updated in its own
+// transaction, guarded so that a claim or renewal that raced the scan wins.
+func (s *DoltStore) ReclaimExpiredLeases(ctx context.Context, now time.Time, actor string) ([]*types.Issue, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id FROM issues
+		WHERE status = ? AND lease_expires_at IS NOT NULL AND lease_expires_at <= ?
+		ORDER BY lease_expires_at, id
+	`, string(types.StatusInProgress), now.UTC())
+	if err != nil {
+		return nil, fmt.Errorf("failed to find expired leases: %w", err)
+	}
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			_ = rows.Close()
+			return nil, fmt.Errorf("failed to scan expired lease: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	_ = rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to find expired leases: %w", err)
+	}
+
+	var reclaimed []*types.Issue
+	for _, id := range ids {
+		issue, err := s.reclaimLease(ctx, id, now, actor)
+		if err != nil {
+			return reclaimed, err
+		}
+		if issue != nil {
+			reclaimed = append(reclaimed, issue)
+		}
+	}
+	return reclaimed, nil
+}
+
+// reclaimLease reopens one issue if its lease is still lapsed. It returns
+// nil if the issue was claimed or renewed in the meantime.
+func (s *DoltStore) reclaimLease(ctx context.Context, id string, now time.Time, actor string) (*types.Issue, error) {
+	issue, err := s.GetIssue(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get issue for reclaim: %w", err)
+	}
+	if issue == nil || !issue.LeaseExpired(now) {
+		return nil, nil
+	}
+	oldValue, _ := json.Marshal(map[string]interface{}{
+		"assignee":         issue.Assignee,
+		"status":           issue.Status,
+		"lease_expires_at": issue.LeaseExpiresAt,
+	})
+
+	reopened := *issue
+	reopened.Status = types.StatusOpen
+	reopened.Assignee = ""
+	reopened.LeaseExpiresAt = nil
+	reopened.UpdatedAt = time.Now().UTC()
+	reopened.ContentHash = reopened.ComputeContentHash()
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	result, err := tx.ExecContext(ctx, `
+		UPDATE issues
+		SET status = ?, assignee = '', lease_expires_at = NULL, content_hash = ?, updated_at = ?
+		WHERE id = ? AND status = ? AND assignee = ?
+		  AND lease_expires_at IS NOT NULL AND lease_expires_at <= ?
+	`, string(types.StatusOpen), reopened.ContentHash, reopened.UpdatedAt,
+		id, string(types.StatusInProgress), issue.Assignee, now.UTC())
+	if err != nil {
+		return nil, fmt.Errorf("failed to reclaim lease: %w", err)
+	}
+	if n, err := result.RowsAffected(); err != nil || n == 0 {
+		return nil, err
+	}
+
+	if err := recordEvent(ctx, tx, id, types.EventLeaseExpired, actor, string(oldValue), `{"status":"open"}`); err != nil {
+		return nil, fmt.Errorf("failed to record event: %w", err)
+	}
+	if err := markDirty(ctx, tx, id); err != nil {
+		return nil, fmt.Errorf("failed to mark dirty: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit reclaim: %w", err)
+	}
+	return &reopened, nil
+}
+
+// leaseNotHeldError explains why a lease can't be renewed. issue is nil if
+// it doesn't exist.
+func leaseNotHeldError(id, assignee string, issue *types.Issue) error {
+	switch {
+	case issue == nil:
+		return fmt.Errorf("issue %s not found", id)
+	case issue.Status != types.StatusInProgress:
+		return fmt.Errorf("issue %s is %s, not claimed (claim it again with bd update --claim)", id, issue.Status)
+	default:
+		return fmt.Errorf("issue %s is claimed by %s, not %s", id, issue.Assignee, assignee)
+	}
+}
@@ -4,9 +4,12 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/steveyegge/beads/internal/query"
+	"github.com/steveyegge/beads/internal/search"
 	"github.com/steveyegge/beads/internal/types"
 )
 
@@ -196,11 +199,31 @@ func (s *DoltStore) SearchIssues(ctx context.Context, query string, filter types
 	if filter.Deferred {
 		whereClauses = append(whereClauses, "defer_until IS NOT NULL")
 	}
+	if filter.Recurring {
+		whereClauses = append(whereClauses, "recurrence != ''")
+	}
 	if filter.Overdue {
 		whereClauses = append(whereClauses, "due_at IS NOT NULL AND due_at < ? AND status != ?")
 		args = append(args, time.Now().UTC().Format(time.RFC3339), types.StatusClosed)
 	}
 
+	// Custom field filters
+	for _, f := range filter.CustomFields {
+		clause, clauseArgs := customFieldClause(f)
+		whereClauses = append(whereClauses, clause)
+		args = append(args, clauseArgs...)
+	}
+
+	// Filter expression
+	if filter.Where != "" {
+		clause, clauseArgs, err := whereExpressionClause(filter.Where)
+		if err != nil {
+			return nil, err
+		}
+		whereClauses = append(whereClauses, clause)
+		args = append(args, clauseArgs...)
+	}
+
 	whereSQL := ""
 	if len(whereClauses) > 0 {
 		whereSQL = "WHERE " + strings.Join(whereClauses, " AND ")
@@ -228,13 +251,48 @@ func (s *DoltStore) SearchIssues(ctx context.Context, query string, filter types
 	return s.scanIssueIDs(ctx, rows)
 }
 
+// SearchIssuesRanked runs a full-text query and returns BM25-ranked hits.
+// Candidates are narrowed with SearchIssues and scored in Go using the same
+// formula as the sqlite FTS5 index, so results match across backends.
+func (s *DoltStore) SearchIssuesRanked(ctx context.Context, query string, filter types.IssueFilter) ([]*types.SearchResult, error) {
+	q, err := search.Parse(query)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := filter.Limit
+	filter.Limit = 0
+	candidates, err := s.SearchIssues(ctx, "", filter)
+	if err != nil {
+		return nil, err
+	}
+	if len(candidates) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]string, len(candidates))
+	for i, issue := range candidates {
+		ids[i] = issue.ID
+	}
+	comments, err := s.GetCommentsForIssues(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load comments for search: %w", err)
+	}
+
+	return search.RankIssues(q, candidates, comments, limit), nil
+}
+
 // GetReadyWork returns issues that are ready to work on (not blocked)
 func (s *DoltStore) GetReadyWork(ctx context.Context, filter types.WorkFilter) ([]*types.Issue, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	whereClauses := []string{"status = 'open'", "(ephemeral = 0 OR ephemeral IS NULL)"}
-	args := []interface{}{}
+	// An in_progress issue whose claim lease has lapsed is available again
+	whereClauses := []string{
+		"(status = 'open' OR (status = 'in_progress' AND lease_expires_at IS NOT NULL AND lease_expires_at <= ?))",
+		"(ephemeral = 0 OR ephemeral IS NULL)",
+	}
+	args := []interface{}{time.Now().UTC()}
 
 	if filter.Priority != nil {
 		whereClauses = append(whereClauses, "priority = ?")
@@ -254,6 +312,14 @@ func (s *DoltStore) GetReadyWork(ctx context.Context, filter types.WorkFilter) (
 			args = append(args, label)
 		}
 	}
+	if filter.Where != "" {
+		clause, clauseArgs, err := whereExpressionClause(filter.Where)
+		if err != nil {
+			return nil, err
+		}
+		whereClauses = append(whereClauses, clause)
+		args = append(args, clauseArgs...)
+	}
 
 	// Exclude blocked issues using subquery
 	whereClauses = append(whereClauses, `
@@ -567,3 +633,52 @@ func (s *DoltStore) GetNextChildID(ctx context.Context, parentID string) (string
 
 	return fmt.Sprintf("%s.%d", parentID, nextChild), nil
 }
+
+// whereExpressionClause compiles a filter expression (--where) to a WHERE
+// clause. SearchIssues names its text search parameter query, which shadows
+// the package.
+func whereExpressionClause(where string) (string, []interface{}, error) {
+	q, err := query.Parse(where)
+	if err != nil {
+		return "", nil, err
+	}
+	clause, args := q.SQL(query.MySQL)
+	return clause, args, nil
+}
+
+// customFieldClause builds the WHERE clause for a custom field filter, with
+// the semantics of types.CustomFieldFilter.Matches.
+func customFieldClause(f types.CustomFieldFilter) (string, []interface{}) {
+	// Names are interpolated into the JSON path, so only well-formed names are
+	// accepted; anything else can't be a configured field and matches nothing
+	if !types.ValidCustomFieldName(f.Name) {
+		return "1 = 0", nil
+	}
+	column := fmt.Sprintf(`JSON_UNQUOTE(JSON_EXTRACT(COALESCE(NULLIF(custom_fields, ''), '{}'), '$."%s"'))`, f.Name)
+
+	switch f.Op {
+	case types.CustomFieldSet:
+		return column + " IS NOT NULL", nil
+	case types.CustomFieldMissing:
+		return column + " IS NULL", nil
+	}
+
+	var value interface{} = f.Value
+	if f.Numeric {
+		n, err := strconv.ParseInt(f.Value, 10, 64)
+		if err != nil {
+			return "1 = 0", nil
+		}
+		column = "CAST(" + column + " AS SIGNED)"
+		value = n
+	}
+
+	switch f.Op {
+	case types.CustomFieldEq, types.CustomFieldLt, types.CustomFieldLe, types.CustomFieldGt, types.CustomFieldGe:
+		return fmt.Sprintf("%s %s ?", column, f.Op), []interface{}{value}
+	case types.CustomFieldNe:
+		return fmt.Sprintf("(%s IS NULL OR %s != ?)", column, column), []interface{}{value}
+	default:
+		return "1 = 0", nil
+	}
+}
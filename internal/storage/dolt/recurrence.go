@@ -0,0 +1,56 @@
+package dolt
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// ClearRecurrence clears the recurrence rule of issue id if it is still rule,
+// so that only one process spawns the next occurrence.
+func (s *DoltStore) ClearRecurrence(ctx context.Context, id string, rule string, actor string) (bool, error) {
+	if rule == "" {
+		return false, nil
+	}
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	result, err := tx.ExecContext(ctx, `
+		UPDATE issues SET recurrence = '', updated_at = ?
+		WHERE id = ? AND recurrence = ?
+	`, time.Now().UTC(), id, rule)
+	if err != nil {
+		return false, fmt.Errorf("failed to clear recurrence: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		// Lost the race or never had the rule; tell which before returning
+		_ = tx.Rollback()
+		issue, err := s.GetIssue(ctx, id)
+		if err != nil {
+			return false, fmt.Errorf("failed to check issue exists: %w", err)
+		}
+		if issue == nil {
+			return false, fmt.Errorf("issue %s not found", id)
+		}
+		return false, nil
+	}
+
+	oldValue, _ := json.Marshal(map[string]string{"recurrence": rule})
+	if err := recordEvent(ctx, tx, id, types.EventUpdated, actor, string(oldValue), `{"recurrence":""}`); err != nil {
+		return false, fmt.Errorf("failed to record event: %w", err)
+	}
+	if err := markDirty(ctx, tx, id); err != nil {
+		return false, fmt.Errorf("failed to mark dirty: %w", err)
+	}
+	return true, tx.Commit()
+}
@@ -63,6 +63,18 @@ func (s *DoltStore) UpdateIssueID(ctx context.Context, oldID, newID string, issu
 		return fmt.Errorf("failed to update comments: %w", err)
 	}
 
+	// Update references in work log
+	_, err = tx.ExecContext(ctx, `UPDATE work_log SET issue_id = ? WHERE issue_id = ?`, newID, oldID)
+	if err != nil {
+		return fmt.Errorf("failed to update work log: %w", err)
+	}
+
+	// Update references in attachments
+	_, err = tx.ExecContext(ctx, `UPDATE attachments SET issue_id = ? WHERE issue_id = ?`, newID, oldID)
+	if err != nil {
+		return fmt.Errorf("failed to update attachments: %w", err)
+	}
+
 	// Update dirty_issues
 	_, err = tx.ExecContext(ctx, `
 		INSERT INTO dirty_issues (issue_id, marked_at)
@@ -73,6 +73,10 @@ CREATE TABLE IF NOT EXISTS issues (
     -- Time-based scheduling fields
     due_at DATETIME,
     defer_until DATETIME,
+    recurrence VARCHAR(255) DEFAULT '',
+    -- Custom field values as a JSON object keyed by field name
+    custom_fields TEXT DEFAULT '',
+    lease_expires_at DATETIME,
     INDEX idx_issues_status (status),
     INDEX idx_issues_priority (priority),
     INDEX idx_issues_assignee (assignee),
@@ -119,6 +123,37 @@ CREATE TABLE IF NOT EXISTS comments (
     CONSTRAINT fk_comments_issue FOREIGN KEY (issue_id) REFERENCES issues(id) ON DELETE CASCADE
 );
 
+-- Work log table (time tracking)
+CREATE TABLE IF NOT EXISTS work_log (
+    id VARCHAR(64) PRIMARY KEY,
+    issue_id VARCHAR(255) NOT NULL,
+    actor VARCHAR(255) NOT NULL,
+    minutes INT NOT NULL DEFAULT 0,
+    started_at DATETIME NOT NULL,
+    ended_at DATETIME,
+    note TEXT,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_work_log_issue (issue_id),
+    INDEX idx_work_log_started_at (started_at),
+    CONSTRAINT fk_work_log_issue FOREIGN KEY (issue_id) REFERENCES issues(id) ON DELETE CASCADE
+);
+
+-- Attachments table (metadata only, content lives in .beads/attachments)
+CREATE TABLE IF NOT EXISTS attachments (
+    id VARCHAR(64) PRIMARY KEY,
+    issue_id VARCHAR(255) NOT NULL,
+    name VARCHAR(1024) NOT NULL,
+    sha256 CHAR(64) NOT NULL,
+    size BIGINT NOT NULL DEFAULT 0,
+    media_type VARCHAR(255) NOT NULL DEFAULT '',
+    created_by VARCHAR(255) NOT NULL DEFAULT '',
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    detached_at DATETIME,
+    INDEX idx_attachments_issue (issue_id),
+    INDEX idx_attachments_sha256 (sha256),
+    CONSTRAINT fk_attachments_issue FOREIGN KEY (issue_id) REFERENCES issues(id) ON DELETE CASCADE
+);
+
 -- Events table (audit trail)
 CREATE TABLE IF NOT EXISTS events (
     id BIGINT AUTO_INCREMENT PRIMARY KEY,
@@ -265,6 +300,24 @@ INSERT IGNORE INTO config (` + "`key`" + `, value) VALUES
     ('auto_compact_enabled', 'false');
 `
 
+// schemaColumn is a column added to an existing table after it was first
+// created.
+type schemaColumn struct {
+	table      string
+	column     string
+	definition string
+}
+
+// addedColumns lists columns added since the tables in schema were first
+// shipped. CREATE TABLE IF NOT EXISTS leaves an existing table alone, so
+// initSchema adds any of these that are missing, mirroring the SQLite
+// migrations. New tables (work_log, attachments) need no entry here.
+var addedColumns = []schemaColumn{
+	{"issues", "recurrence", "VARCHAR(255) DEFAULT ''"}, // sqlite 043
+	{"issues", "custom_fields", "TEXT DEFAULT ''"},      // sqlite 045
+	{"issues", "lease_expires_at", "DATETIME"},          // sqlite 046
+}
+
 // readyIssuesView is a MySQL-compatible view for ready work
 // Note: Dolt supports recursive CTEs like SQLite
 const readyIssuesView = `
@@ -0,0 +1,89 @@
+package dolt
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// TestInitSchemaUpgradesOldDatabase opens a database created before the
+// recurrence, custom field, lease, work log and attachment schema changes
+// and checks that reopening it adds them.
+func TestInitSchemaUpgradesOldDatabase(t *testing.T) {
+	skipIfNoDolt(t)
+	ctx, cancel := testContext(t)
+	defer cancel()
+
+	tmpDir, err := os.MkdirTemp("", "dolt-test-*")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+	cfg := &Config{
+		Path:           tmpDir,
+		CommitterName:  "test",
+		CommitterEmail: "test@example.com",
+		Database:       "testdb",
+	}
+
+	// Roll a fresh database back to the pre-series schema
+	store, err := New(ctx, cfg)
+	if err != nil {
+		t.Fatalf("failed to create Dolt store: %v", err)
+	}
+	for _, stmt := range []string{
+		"DROP VIEW ready_issues",
+		"DROP VIEW blocked_issues",
+		"DROP TABLE work_log",
+		"DROP TABLE attachments",
+		"ALTER TABLE issues DROP COLUMN recurrence",
+		"ALTER TABLE issues DROP COLUMN custom_fields",
+		"ALTER TABLE issues DROP COLUMN lease_expires_at",
+	} {
+		if _, err := store.db.ExecContext(ctx, stmt); err != nil {
+			store.Close()
+			t.Fatalf("%s: %v", stmt, err)
+		}
+	}
+	store.Close()
+
+	store, err = New(ctx, cfg)
+	if err != nil {
+		t.Fatalf("failed to reopen old database: %v", err)
+	}
+	defer store.Close()
+	if err := store.SetConfig(ctx, "issue_prefix", "test"); err != nil {
+		t.Fatalf("failed to set prefix: %v", err)
+	}
+
+	lease := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+	issue := &types.Issue{
+		Title:          "Upgraded",
+		Status:         types.StatusInProgress,
+		Assignee:       "agent-1",
+		Priority:       2,
+		IssueType:      types.TypeTask,
+		Recurrence:     "every monday",
+		CustomFields:   map[string]string{"team": "core"},
+		LeaseExpiresAt: &lease,
+	}
+	if err := store.CreateIssue(ctx, issue, "tester"); err != nil {
+		t.Fatalf("CreateIssue on upgraded database failed: %v", err)
+	}
+	got, err := store.GetIssue(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetIssue on upgraded database failed: %v", err)
+	}
+	if got.Recurrence != "every monday" || got.CustomFields["team"] != "core" || got.LeaseExpiresAt == nil {
+		t.Errorf("upgraded issue = recurrence %q, fields %v, lease %v", got.Recurrence, got.CustomFields, got.LeaseExpiresAt)
+	}
+
+	for _, table := range []string{"work_log", "attachments"} {
+		var count int
+		if err := store.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM "+table).Scan(&count); err != nil {
+			t.Errorf("%s table missing after upgrade: %v", table, err)
+		}
+	}
+}
@@ -332,6 +332,11 @@ func (s *DoltStore) initSchema(ctx context.Context) error {
 		}
 	}
 
+	// Add columns that databases created before them are missing
+	if err := s.addMissingColumns(ctx); err != nil {
+		return err
+	}
+
 	// Insert default config values
 	for _, stmt := range splitStatements(defaultConfig) {
 		stmt = strings.TrimSpace(stmt)
@@ -357,6 +362,29 @@ func (s *DoltStore) initSchema(ctx context.Context) error {
 	return nil
 }
 
+// addMissingColumns adds each of addedColumns that its table lacks. It is
+// idempotent, so it runs on every open.
+func (s *DoltStore) addMissingColumns(ctx context.Context) error {
+	for _, c := range addedColumns {
+		var count int
+		err := s.db.QueryRowContext(ctx, `
+			SELECT COUNT(*) FROM information_schema.columns
+			WHERE table_schema = DATABASE() AND table_name = ? AND column_name = ?
+		`, c.table, c.column).Scan(&count)
+		if err != nil {
+			return fmt.Errorf("failed to check for %s.%s column: %w", c.table, c.column, err)
+		}
+		if count > 0 {
+			continue
+		}
+		stmt := fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", c.table, c.column, c.definition)
+		if _, err := s.db.ExecContext(ctx, stmt); err != nil {
+			return fmt.Errorf("failed to add %s.%s column: %w", c.table, c.column, err)
+		}
+	}
+	return nil
+}
+
 // splitStatements splits a SQL script into individual statements
 func splitStatements(script string) []string {
 	var statements []string
@@ -9,6 +9,7 @@ import (
 
 	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/validation"
 )
 
 // doltTransaction implements storage.Transaction for Dolt
@@ -127,6 +128,12 @@ func (t *doltTransaction) SearchIssues(ctx context.Context, query string, filter
 
 // UpdateIssue updates an issue within the transaction
 func (t *doltTransaction) UpdateIssue(ctx context.Context, id string, updates map[string]interface{}, actor string) error {
+	// Enforce the status workflow from config.yaml
+	override, err := validation.CheckWorkflowByID(ctx, t.GetIssue, id, updates)
+	if err != nil {
+		return err
+	}
+
 	setClauses := []string{"updated_at = ?"}
 	args := []interface{}{time.Now().UTC()}
 
@@ -145,18 +152,28 @@ func (t *doltTransaction) UpdateIssue(ctx context.Context, id string, updates ma
 	args = append(args, id)
 	// nolint:gosec // G201: setClauses contains only column names (e.g. "status = ?"), actual values passed via args
 	query := fmt.Sprintf("UPDATE issues SET %s WHERE id = ?", strings.Join(setClauses, ", "))
-	_, err := t.tx.ExecContext(ctx, query, args...)
-	return err
+	if _, err := t.tx.ExecContext(ctx, query, args...); err != nil {
+		return err
+	}
+	return recordWorkflowOverride(ctx, t.tx, id, actor, override)
 }
 
 // CloseIssue closes an issue within the transaction
 func (t *doltTransaction) CloseIssue(ctx context.Context, id string, reason string, actor string, session string) error {
+	// Enforce the status workflow from config.yaml
+	override, err := validation.CheckWorkflowByID(ctx, t.GetIssue, id, validation.CloseUpdates(reason))
+	if err != nil {
+		return err
+	}
+
 	now := time.Now().UTC()
-	_, err := t.tx.ExecContext(ctx, `
+	if _, err := t.tx.ExecContext(ctx, `
 		UPDATE issues SET status = ?, closed_at = ?, updated_at = ?, close_reason = ?, closed_by_session = ?
 		WHERE id = ?
-	`, types.StatusClosed, now, now, reason, session, id)
-	return err
+	`, types.StatusClosed, now, now, reason, session, id); err != nil {
+		return err
+	}
+	return recordWorkflowOverride(ctx, t.tx, id, actor, override)
 }
 
 // DeleteIssue deletes an issue within the transaction
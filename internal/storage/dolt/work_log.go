@@ -0,0 +1,112 @@
+package dolt
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// SaveWorkLogEntry inserts a work-log entry, or replaces the entry with the same ID
+func (s *DoltStore) SaveWorkLogEntry(ctx context.Context, entry *types.WorkLogEntry) error {
+	if entry.ID == "" {
+		return fmt.Errorf("work log entry ID is required")
+	}
+
+	// Verify issue exists
+	var exists bool
+	if err := s.db.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM issues WHERE id = ?)`, entry.IssueID).Scan(&exists); err != nil {
+		return fmt.Errorf("failed to check issue existence: %w", err)
+	}
+	if !exists {
+		return fmt.Errorf("issue %s not found", entry.IssueID)
+	}
+
+	if entry.CreatedAt.IsZero() {
+		entry.CreatedAt = time.Now().UTC()
+	}
+	var endedAt interface{}
+	if entry.EndedAt != nil {
+		endedAt = entry.EndedAt.UTC()
+	}
+
+	if _, err := s.db.ExecContext(ctx, `
+		INSERT INTO work_log (id, issue_id, actor, minutes, started_at, ended_at, note, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE
+			issue_id = VALUES(issue_id),
+			actor = VALUES(actor),
+			minutes = VALUES(minutes),
+			started_at = VALUES(started_at),
+			ended_at = VALUES(ended_at),
+			note = VALUES(note)
+	`, entry.ID, entry.IssueID, entry.Actor, entry.Minutes, entry.StartedAt.UTC(), endedAt, entry.Note, entry.CreatedAt.UTC()); err != nil {
+		return fmt.Errorf("failed to save work log entry: %w", err)
+	}
+
+	// Mark issue dirty for incremental JSONL export
+	if _, err := s.db.ExecContext(ctx, `
+		INSERT INTO dirty_issues (issue_id, marked_at)
+		VALUES (?, ?)
+		ON DUPLICATE KEY UPDATE marked_at = VALUES(marked_at)
+	`, entry.IssueID, time.Now().UTC()); err != nil {
+		return fmt.Errorf("failed to mark issue dirty: %w", err)
+	}
+
+	return nil
+}
+
+// GetWorkLog retrieves the work log of an issue, oldest first
+func (s *DoltStore) GetWorkLog(ctx context.Context, issueID string) ([]*types.WorkLogEntry, error) {
+	result, err := s.GetWorkLogForIssues(ctx, []string{issueID})
+	if err != nil {
+		return nil, err
+	}
+	return result[issueID], nil
+}
+
+// GetWorkLogForIssues retrieves work-log entries for multiple issues
+func (s *DoltStore) GetWorkLogForIssues(ctx context.Context, issueIDs []string) (map[string][]*types.WorkLogEntry, error) {
+	if len(issueIDs) == 0 {
+		return make(map[string][]*types.WorkLogEntry), nil
+	}
+
+	placeholders := make([]string, len(issueIDs))
+	args := make([]interface{}, len(issueIDs))
+	for i, id := range issueIDs {
+		placeholders[i] = "?"
+		args[i] = id
+	}
+
+	// nolint:gosec // G201: placeholders contains only ? markers, actual values passed via args
+	query := fmt.Sprintf(`
+		SELECT id, issue_id, actor, minutes, started_at, ended_at, note, created_at
+		FROM work_log
+		WHERE issue_id IN (%s)
+		ORDER BY issue_id, started_at ASC, id ASC
+	`, joinStrings(placeholders, ","))
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get work log: %w", err)
+	}
+	defer rows.Close()
+
+	result := make(map[string][]*types.WorkLogEntry)
+	for rows.Next() {
+		var e types.WorkLogEntry
+		var endedAt sql.NullTime
+		var note sql.NullString
+		if err := rows.Scan(&e.ID, &e.IssueID, &e.Actor, &e.Minutes, &e.StartedAt, &endedAt, &note, &e.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan work log entry: %w", err)
+		}
+		if endedAt.Valid {
+			e.EndedAt = &endedAt.Time
+		}
+		e.Note = note.String
+		result[e.IssueID] = append(result[e.IssueID], &e)
+	}
+	return result, rows.Err()
+}
@@ -0,0 +1,26 @@
+package dolt
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/validation"
+)
+
+// recordWorkflowOverride records a status change that was forced past the
+// configured workflow (see validation.WithWorkflowForce).
+func recordWorkflowOverride(ctx context.Context, tx *sql.Tx, id, actor string, override *validation.TransitionError) error {
+	if override == nil {
+		return nil
+	}
+	_, err := tx.ExecContext(ctx, `
+		INSERT INTO events (issue_id, event_type, actor, old_value, new_value, comment)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`, id, types.EventWorkflowOverride, actor, string(override.From), string(override.To), override.Reason)
+	if err != nil {
+		return fmt.Errorf("failed to record workflow override: %w", err)
+	}
+	return nil
+}
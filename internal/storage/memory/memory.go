@@ -13,8 +13,11 @@ import (
 	"time"
 
 	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/query"
+	"github.com/steveyegge/beads/internal/search"
 	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/validation"
 )
 
 // MemoryStorage implements the Storage interface using in-memory data structures
@@ -22,14 +25,16 @@ type MemoryStorage struct {
 	mu sync.RWMutex // Protects all maps
 
 	// Core data
-	issues       map[string]*types.Issue        // ID -> Issue
-	dependencies map[string][]*types.Dependency // IssueID -> Dependencies
-	labels       map[string][]string            // IssueID -> Labels
-	events       map[string][]*types.Event      // IssueID -> Events
-	comments     map[string][]*types.Comment    // IssueID -> Comments
-	config       map[string]string              // Config key-value pairs
-	metadata     map[string]string              // Metadata key-value pairs
-	counters     map[string]int                 // Prefix -> Last ID
+	issues       map[string]*types.Issue          // ID -> Issue
+	dependencies map[string][]*types.Dependency   // IssueID -> Dependencies
+	labels       map[string][]string              // IssueID -> Labels
+	events       map[string][]*types.Event        // IssueID -> Events
+	comments     map[string][]*types.Comment      // IssueID -> Comments
+	workLog      map[string][]*types.WorkLogEntry // IssueID -> Work log entries
+	attachments  map[string][]*types.Attachment   // IssueID -> Attachments
+	config       map[string]string                // Config key-value pairs
+	metadata     map[string]string                // Metadata key-value pairs
+	counters     map[string]int                   // Prefix -> Last ID
 
 	// Indexes for O(1) lookups
 	externalRefToID map[string]string // ExternalRef -> IssueID
@@ -49,6 +54,8 @@ func New(jsonlPath string) *MemoryStorage {
 		labels:          make(map[string][]string),
 		events:          make(map[string][]*types.Event),
 		comments:        make(map[string][]*types.Comment),
+		workLog:         make(map[string][]*types.WorkLogEntry),
+		attachments:     make(map[string][]*types.Attachment),
 		config:          make(map[string]string),
 		metadata:        make(map[string]string),
 		counters:        make(map[string]int),
@@ -92,6 +99,16 @@ func (m *MemoryStorage) LoadFromIssues(issues []*types.Issue) error {
 			m.comments[issue.ID] = issue.Comments
 		}
 
+		// Store work log
+		if len(issue.WorkLog) > 0 {
+			m.workLog[issue.ID] = issue.WorkLog
+		}
+
+		// Store attachments
+		if len(issue.Attachments) > 0 {
+			m.attachments[issue.ID] = issue.Attachments
+		}
+
 		// Update counter based on issue ID
 		prefix, num := extractPrefixAndNumber(issue.ID)
 		if prefix != "" && num > 0 {
@@ -137,6 +154,16 @@ func (m *MemoryStorage) GetAllIssues() []*types.Issue {
 			issueCopy.Comments = comments
 		}
 
+		// Attach work log
+		if entries, ok := m.workLog[issue.ID]; ok {
+			issueCopy.WorkLog = entries
+		}
+
+		// Attach attachments
+		if attachments, ok := m.attachments[issue.ID]; ok {
+			issueCopy.Attachments = attachments
+		}
+
 		issues = append(issues, &issueCopy)
 	}
 
@@ -384,6 +411,12 @@ func (m *MemoryStorage) GetIssueByExternalRef(ctx context.Context, externalRef s
 // Returns (true, nil) if claim succeeded, (false, nil) if already claimed by someone else,
 // or (false, error) if the operation failed.
 func (m *MemoryStorage) ClaimIssue(ctx context.Context, id string, assignee string) (bool, error) {
+	return m.ClaimIssueWithLease(ctx, id, assignee, nil)
+}
+
+// ClaimIssueWithLease is ClaimIssue with a claim that lapses at leaseUntil
+// (nil for no lease).
+func (m *MemoryStorage) ClaimIssueWithLease(ctx context.Context, id string, assignee string, leaseUntil *time.Time) (bool, error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
@@ -392,20 +425,95 @@ func (m *MemoryStorage) ClaimIssue(ctx context.Context, id string, assignee stri
 		return false, fmt.Errorf("issue %s not found", id)
 	}
 
-	// Only fail if task is actively being worked on (has assignee AND is in_progress)
-	// Allow claiming open tasks even with stale assignees
-	if issue.Assignee != "" && issue.Status == types.StatusInProgress {
+	// Only fail if task is actively being worked on (has assignee AND is in_progress
+	// AND the lease, if any, hasn't lapsed). Allow claiming open tasks even with stale assignees
+	now := time.Now()
+	if issue.IsClaimed(now) {
 		return false, nil
 	}
 
 	// Claim it
 	issue.Assignee = assignee
 	issue.Status = types.StatusInProgress
-	issue.UpdatedAt = time.Now()
+	issue.LeaseExpiresAt = leaseUntil
+	issue.UpdatedAt = now
+	m.dirty[id] = true
 
 	return true, nil
 }
 
+// RenewLease moves the lease deadline of an in_progress issue assigned to assignee.
+func (m *MemoryStorage) RenewLease(ctx context.Context, id string, assignee string, leaseUntil time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	issue, exists := m.issues[id]
+	switch {
+	case !exists:
+		return fmt.Errorf("issue %s not found", id)
+	case issue.Status != types.StatusInProgress:
+		return fmt.Errorf("issue %s is %s, not claimed (claim it again with bd update --claim)", id, issue.Status)
+	case issue.Assignee != assignee:
+		return fmt.Errorf("issue %s is claimed by %s, not %s", id, issue.Assignee, assignee)
+	}
+
+	// The lease is runtime state: not an edit, and nothing to export
+	issue.LeaseExpiresAt = &leaseUntil
+	return nil
+}
+
+// ClearRecurrence clears the recurrence rule of issue id if it is still rule.
+func (m *MemoryStorage) ClearRecurrence(ctx context.Context, id string, rule string, actor string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	issue, exists := m.issues[id]
+	if !exists {
+		return false, fmt.Errorf("issue %s not found", id)
+	}
+	if rule == "" || issue.Recurrence != rule {
+		return false, nil
+	}
+	issue.Recurrence = ""
+	issue.UpdatedAt = time.Now()
+	m.dirty[id] = true
+	return true, nil
+}
+
+// ReclaimExpiredLeases returns in_progress issues whose lease lapsed at or
+// before now to open and unassigned.
+func (m *MemoryStorage) ReclaimExpiredLeases(ctx context.Context, now time.Time, actor string) ([]*types.Issue, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var reclaimed []*types.Issue
+	for id, issue := range m.issues {
+		if !issue.LeaseExpired(now) {
+			continue
+		}
+		oldAssignee := issue.Assignee
+		issue.Status = types.StatusOpen
+		issue.Assignee = ""
+		issue.LeaseExpiresAt = nil
+		issue.UpdatedAt = time.Now()
+		m.dirty[id] = true
+
+		newValue := string(types.StatusOpen)
+		m.events[id] = append(m.events[id], &types.Event{
+			IssueID:   id,
+			EventType: types.EventLeaseExpired,
+			Actor:     actor,
+			OldValue:  &oldAssignee,
+			NewValue:  &newValue,
+			CreatedAt: issue.UpdatedAt,
+		})
+		issueCopy := *issue
+		reclaimed = append(reclaimed, &issueCopy)
+	}
+	sort.Slice(reclaimed, func(i, j int) bool { return reclaimed[i].ID < reclaimed[j].ID })
+	return reclaimed, nil
+}
+
 // UpdateIssue updates fields on an issue
 func (m *MemoryStorage) UpdateIssue(ctx context.Context, id string, updates map[string]interface{}, actor string) error {
 	m.mu.Lock()
@@ -416,8 +524,15 @@ func (m *MemoryStorage) UpdateIssue(ctx context.Context, id string, updates map[
 		return fmt.Errorf("issue %s not found", id)
 	}
 
+	// Enforce the status workflow from config.yaml (CloseIssue goes through here too)
+	override, err := validation.CheckWorkflow(ctx, issue, updates)
+	if err != nil {
+		return err
+	}
+
 	now := time.Now()
 	issue.UpdatedAt = now
+	oldStatus, oldAssignee := issue.Status, issue.Assignee
 
 	// Apply updates
 	for key, value := range updates {
@@ -496,9 +611,40 @@ func (m *MemoryStorage) UpdateIssue(ctx context.Context, id string, updates map[
 			if v, ok := value.(string); ok {
 				issue.ClosedBySession = v
 			}
+		case "recurrence":
+			if v, ok := value.(string); ok {
+				issue.Recurrence = v
+			} else if value == nil {
+				issue.Recurrence = ""
+			}
+		case "custom_fields":
+			if v, ok := value.(map[string]string); ok {
+				issue.CustomFields = v
+			} else if value == nil {
+				issue.CustomFields = nil
+			}
+		case "waiters":
+			if v, ok := value.([]string); ok {
+				issue.Waiters = v
+			}
+		case "lease_expires_at":
+			switch v := value.(type) {
+			case time.Time:
+				issue.LeaseExpiresAt = &v
+			case *time.Time:
+				issue.LeaseExpiresAt = v
+			case nil:
+				issue.LeaseExpiresAt = nil
+			}
 		}
 	}
 
+	// A lease only survives updates that keep the claim; claims set it anew
+	if _, hasLease := updates["lease_expires_at"]; !hasLease &&
+		(issue.Status != oldStatus || issue.Assignee != oldAssignee) {
+		issue.LeaseExpiresAt = nil
+	}
+
 	m.dirty[id] = true
 
 	// Record event
@@ -517,6 +663,19 @@ func (m *MemoryStorage) UpdateIssue(ctx context.Context, id string, updates map[
 	}
 	m.events[id] = append(m.events[id], event)
 
+	if override != nil {
+		from, to := string(override.From), string(override.To)
+		m.events[id] = append(m.events[id], &types.Event{
+			IssueID:   id,
+			EventType: types.EventWorkflowOverride,
+			Actor:     actor,
+			OldValue:  &from,
+			NewValue:  &to,
+			Comment:   &override.Reason,
+			CreatedAt: now,
+		})
+	}
+
 	return nil
 }
 
@@ -592,6 +751,8 @@ func (m *MemoryStorage) DeleteIssue(ctx context.Context, id string) error {
 	delete(m.labels, id)
 	delete(m.events, id)
 	delete(m.comments, id)
+	delete(m.workLog, id)
+	delete(m.attachments, id)
 	delete(m.dirty, id)
 
 	return nil
@@ -602,6 +763,12 @@ func (m *MemoryStorage) SearchIssues(ctx context.Context, query string, filter t
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 
+	where, err := parseWhereExpression(filter.Where)
+	if err != nil {
+		return nil, err
+	}
+	resolver := memoryResolver{m}
+
 	var results []*types.Issue
 
 	for _, issue := range m.issues {
@@ -618,6 +785,15 @@ func (m *MemoryStorage) SearchIssues(ctx context.Context, query string, filter t
 		if filter.Assignee != nil && issue.Assignee != *filter.Assignee {
 			continue
 		}
+		if filter.Recurring && issue.Recurrence == "" {
+			continue
+		}
+		if !matchesCustomFields(issue, filter.CustomFields) {
+			continue
+		}
+		if where != nil && !where.Match(issue, resolver) {
+			continue
+		}
 
 		// Query search (title, description, or ID)
 		if query != "" {
@@ -714,6 +890,81 @@ func (m *MemoryStorage) SearchIssues(ctx context.Context, query string, filter t
 	return results, nil
 }
 
+// matchesCustomFields reports whether the issue satisfies every custom field filter
+func matchesCustomFields(issue *types.Issue, filters []types.CustomFieldFilter) bool {
+	for _, f := range filters {
+		if !f.Matches(issue.CustomFields) {
+			return false
+		}
+	}
+	return true
+}
+
+// parseWhereExpression parses a filter expression (--where), returning nil if
+// there is none. SearchIssues names its text search parameter query, which
+// shadows the package.
+func parseWhereExpression(where string) (*query.Query, error) {
+	if where == "" {
+		return nil, nil
+	}
+	return query.Parse(where)
+}
+
+// memoryResolver looks up issue relations for query.Match. Callers hold m.mu.
+type memoryResolver struct {
+	m *MemoryStorage
+}
+
+func (r memoryResolver) Labels(issueID string) []string {
+	return r.m.labels[issueID]
+}
+
+func (r memoryResolver) Dependencies(issueID string) []*types.Dependency {
+	return r.m.dependencies[issueID]
+}
+
+func (r memoryResolver) Dependents(issueID string) []*types.Dependency {
+	var dependents []*types.Dependency
+	for _, deps := range r.m.dependencies {
+		for _, dep := range deps {
+			if dep.DependsOnID == issueID {
+				dependents = append(dependents, dep)
+			}
+		}
+	}
+	return dependents
+}
+
+func (r memoryResolver) Issue(id string) *types.Issue {
+	return r.m.issues[id]
+}
+
+// SearchIssuesRanked runs a full-text query and returns BM25-ranked hits.
+// The memory backend has no index, so candidates are filtered with
+// SearchIssues and scored in Go using the same formula as the sqlite FTS5 index.
+func (m *MemoryStorage) SearchIssuesRanked(ctx context.Context, query string, filter types.IssueFilter) ([]*types.SearchResult, error) {
+	q, err := search.Parse(query)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := filter.Limit
+	filter.Limit = 0
+	candidates, err := m.SearchIssues(ctx, "", filter)
+	if err != nil {
+		return nil, err
+	}
+
+	m.mu.RLock()
+	comments := make(map[string][]*types.Comment, len(candidates))
+	for _, issue := range candidates {
+		comments[issue.ID] = m.comments[issue.ID]
+	}
+	m.mu.RUnlock()
+
+	return search.RankIssues(q, candidates, comments, limit), nil
+}
+
 // AddDependency adds a dependency between issues
 func (m *MemoryStorage) AddDependency(ctx context.Context, dep *types.Dependency, actor string) error {
 	m.mu.Lock()
@@ -1126,7 +1377,13 @@ func (m *MemoryStorage) GetReadyWork(ctx context.Context, filter types.WorkFilte
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 
+	where, err := parseWhereExpression(filter.Where)
+	if err != nil {
+		return nil, err
+	}
+
 	var results []*types.Issue
+	now := time.Now()
 
 	for _, issue := range m.issues {
 		// Skip pinned issues - they are context markers, not actionable work (bd-o9o)
@@ -1134,12 +1391,14 @@ func (m *MemoryStorage) GetReadyWork(ctx context.Context, filter types.WorkFilte
 			continue
 		}
 
-		// Status filtering: default to open OR in_progress if not specified
+		// Status filtering: default to open OR in_progress if not specified.
+		// A claim whose lease lapsed counts as open and unassigned.
+		leaseExpired := issue.LeaseExpired(now)
 		if filter.Status == "" {
 			if issue.Status != types.StatusOpen && issue.Status != types.StatusInProgress {
 				continue
 			}
-		} else if issue.Status != filter.Status {
+		} else if issue.Status != filter.Status && !(filter.Status == types.StatusOpen && leaseExpired) {
 			continue
 		}
 
@@ -1165,7 +1424,7 @@ func (m *MemoryStorage) GetReadyWork(ctx context.Context, filter types.WorkFilte
 
 		// Unassigned takes precedence over Assignee filter
 		if filter.Unassigned {
-			if issue.Assignee != "" {
+			if issue.Assignee != "" && !leaseExpired {
 				continue
 			}
 		} else if filter.Assignee != nil {
@@ -1221,6 +1480,10 @@ func (m *MemoryStorage) GetReadyWork(ctx context.Context, filter types.WorkFilte
 			continue
 		}
 
+		if where != nil && !where.Match(issue, memoryResolver{m}) {
+			continue
+		}
+
 		issueCopy := *issue
 		if deps, ok := m.dependencies[issue.ID]; ok {
 			issueCopy.Dependencies = deps
@@ -1240,6 +1503,10 @@ func (m *MemoryStorage) GetReadyWork(ctx context.Context, filter types.WorkFilte
 	if sortPolicy == "" {
 		sortPolicy = types.SortPolicyHybrid
 	}
+	// Scores are applied by internal/ranking on top of this order
+	if sortPolicy.IsScored() {
+		sortPolicy = types.SortPolicyPriority
+	}
 
 	switch sortPolicy {
 	case types.SortPolicyOldest:
@@ -1511,6 +1778,19 @@ func (m *MemoryStorage) GetEvents(ctx context.Context, issueID string, limit int
 	return events, nil
 }
 
+func (m *MemoryStorage) GetEventsForIssues(ctx context.Context, issueIDs []string) (map[string][]*types.Event, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	result := make(map[string][]*types.Event)
+	for _, issueID := range issueIDs {
+		if events, exists := m.events[issueID]; exists {
+			result[issueID] = events
+		}
+	}
+	return result, nil
+}
+
 func (m *MemoryStorage) AddIssueComment(ctx context.Context, issueID, author, text string) (*types.Comment, error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -1567,6 +1847,140 @@ func (m *MemoryStorage) GetCommentsForIssues(ctx context.Context, issueIDs []str
 	return result, nil
 }
 
+func (m *MemoryStorage) SaveWorkLogEntry(ctx context.Context, entry *types.WorkLogEntry) error {
+	if entry.ID == "" {
+		return fmt.Errorf("work log entry ID is required")
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.issues[entry.IssueID]; !ok {
+		return fmt.Errorf("issue %s not found", entry.IssueID)
+	}
+	if entry.CreatedAt.IsZero() {
+		entry.CreatedAt = time.Now()
+	}
+
+	entryCopy := *entry
+	entries := m.workLog[entry.IssueID]
+	replaced := false
+	for i, existing := range entries {
+		if existing.ID == entry.ID {
+			entryCopy.CreatedAt = existing.CreatedAt
+			entries[i] = &entryCopy
+			replaced = true
+			break
+		}
+	}
+	if !replaced {
+		entries = append(entries, &entryCopy)
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].StartedAt.Before(entries[j].StartedAt)
+	})
+
+	m.workLog[entry.IssueID] = entries
+	m.dirty[entry.IssueID] = true
+
+	return nil
+}
+
+func (m *MemoryStorage) GetWorkLog(ctx context.Context, issueID string) ([]*types.WorkLogEntry, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.workLog[issueID], nil
+}
+
+func (m *MemoryStorage) GetWorkLogForIssues(ctx context.Context, issueIDs []string) (map[string][]*types.WorkLogEntry, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	result := make(map[string][]*types.WorkLogEntry)
+	for _, issueID := range issueIDs {
+		if entries, exists := m.workLog[issueID]; exists {
+			result[issueID] = entries
+		}
+	}
+	return result, nil
+}
+
+func (m *MemoryStorage) SaveAttachment(ctx context.Context, attachment *types.Attachment) error {
+	if attachment.ID == "" {
+		return fmt.Errorf("attachment ID is required")
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.issues[attachment.IssueID]; !ok {
+		return fmt.Errorf("issue %s not found", attachment.IssueID)
+	}
+	if attachment.CreatedAt.IsZero() {
+		attachment.CreatedAt = time.Now()
+	}
+
+	attachmentCopy := *attachment
+	attachments := m.attachments[attachment.IssueID]
+	replaced := false
+	for i, existing := range attachments {
+		if existing.ID == attachment.ID {
+			attachmentCopy.CreatedAt = existing.CreatedAt
+			attachmentCopy.CreatedBy = existing.CreatedBy
+			attachments[i] = &attachmentCopy
+			replaced = true
+			break
+		}
+	}
+	if !replaced {
+		attachments = append(attachments, &attachmentCopy)
+	}
+	sort.SliceStable(attachments, func(i, j int) bool {
+		return attachments[i].CreatedAt.Before(attachments[j].CreatedAt)
+	})
+
+	m.attachments[attachment.IssueID] = attachments
+	m.dirty[attachment.IssueID] = true
+
+	return nil
+}
+
+func (m *MemoryStorage) GetAttachments(ctx context.Context, issueID string) ([]*types.Attachment, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.attachments[issueID], nil
+}
+
+func (m *MemoryStorage) GetAttachmentsForIssues(ctx context.Context, issueIDs []string) (map[string][]*types.Attachment, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	result := make(map[string][]*types.Attachment)
+	for _, issueID := range issueIDs {
+		if attachments, exists := m.attachments[issueID]; exists {
+			result[issueID] = attachments
+		}
+	}
+	return result, nil
+}
+
+func (m *MemoryStorage) GetAttachmentHashes(ctx context.Context) (map[string]bool, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	hashes := make(map[string]bool)
+	for _, attachments := range m.attachments {
+		for _, a := range attachments {
+			if !a.IsDetached() {
+				hashes[a.SHA256] = true
+			}
+		}
+	}
+	return hashes, nil
+}
+
 func (m *MemoryStorage) GetStatistics(ctx context.Context) (*types.Statistics, error) {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
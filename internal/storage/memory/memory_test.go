@@ -8,6 +8,7 @@ import (
 
 	"github.com/steveyegge/beads/internal/config"
 	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/validation"
 )
 
 func setupTestMemory(t *testing.T) *MemoryStorage {
@@ -417,6 +418,48 @@ func TestSearchIssues(t *testing.T) {
 	}
 }
 
+func TestSearchIssuesRanked(t *testing.T) {
+	store := setupTestMemory(t)
+	defer store.Close()
+
+	ctx := context.Background()
+	titleHit := &types.Issue{Title: "Fix login timeout", Priority: 1, IssueType: types.TypeBug, Status: types.StatusOpen}
+	descHit := &types.Issue{Title: "Add dark mode", Description: "Theme for the login page", Priority: 2, IssueType: types.TypeTask, Status: types.StatusOpen}
+	commentHit := &types.Issue{Title: "Refactor storage", Priority: 2, IssueType: types.TypeTask, Status: types.StatusOpen}
+	for _, issue := range []*types.Issue{titleHit, descHit, commentHit} {
+		if err := store.CreateIssue(ctx, issue, "test-user"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+	if _, err := store.AddIssueComment(ctx, commentHit.ID, "alice", "Also touches the login handler"); err != nil {
+		t.Fatalf("AddIssueComment failed: %v", err)
+	}
+
+	results, err := store.SearchIssuesRanked(ctx, "login", types.IssueFilter{})
+	if err != nil {
+		t.Fatalf("SearchIssuesRanked failed: %v", err)
+	}
+	if len(results) != 3 {
+		t.Fatalf("expected 3 results, got %d", len(results))
+	}
+	if results[0].ID != titleHit.ID || results[2].ID != commentHit.ID {
+		t.Errorf("unexpected ranking: %s, %s, %s", results[0].ID, results[1].ID, results[2].ID)
+	}
+
+	bug := types.TypeBug
+	results, err = store.SearchIssuesRanked(ctx, "login", types.IssueFilter{IssueType: &bug})
+	if err != nil {
+		t.Fatalf("SearchIssuesRanked failed: %v", err)
+	}
+	if len(results) != 1 || results[0].ID != titleHit.ID {
+		t.Errorf("expected filter to narrow results to %s, got %d results", titleHit.ID, len(results))
+	}
+
+	if _, err := store.SearchIssuesRanked(ctx, "NOT login", types.IssueFilter{}); err == nil {
+		t.Error("expected error for negation-only query")
+	}
+}
+
 func TestDependencies(t *testing.T) {
 	store := setupTestMemory(t)
 	defer store.Close()
@@ -1573,3 +1616,123 @@ func TestGetParentIDsMixedExistence(t *testing.T) {
 		t.Errorf("Expected parent ID %s, got %s", epic.ID, info.ParentID)
 	}
 }
+
+func TestUpdateIssue_Workflow(t *testing.T) {
+	store := setupTestMemory(t)
+	ctx := context.Background()
+	if err := config.Initialize(); err != nil {
+		t.Fatalf("failed to initialize config: %v", err)
+	}
+	config.Set("workflow", map[string]interface{}{
+		"default": map[string]interface{}{
+			"transitions": map[string]interface{}{
+				"open":   []string{"review"},
+				"review": []string{"closed"},
+			},
+		},
+	})
+	t.Cleanup(func() { config.Set("workflow", nil) })
+
+	issue := &types.Issue{Title: "Task", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	if err := store.CloseIssue(ctx, issue.ID, "done", "test", ""); err == nil {
+		t.Fatal("CloseIssue from open succeeded, want workflow error")
+	}
+	if err := store.CloseIssue(validation.WithWorkflowForce(ctx), issue.ID, "done", "test", ""); err != nil {
+		t.Fatalf("forced CloseIssue failed: %v", err)
+	}
+
+	events, err := store.GetEvents(ctx, issue.ID, 0)
+	if err != nil {
+		t.Fatalf("GetEvents failed: %v", err)
+	}
+	found := false
+	for _, e := range events {
+		if e.EventType == types.EventWorkflowOverride {
+			found = e.NewValue != nil && *e.NewValue == string(types.StatusClosed)
+		}
+	}
+	if !found {
+		t.Error("expected a workflow_override event to closed")
+	}
+}
+
+func TestLeasedClaims(t *testing.T) {
+	store := setupTestMemory(t)
+	defer store.Close()
+	ctx := context.Background()
+
+	abandoned := &types.Issue{Title: "Abandoned", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	active := &types.Issue{Title: "Active", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	for _, issue := range []*types.Issue{abandoned, active} {
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+	past, future := time.Now().Add(-time.Minute), time.Now().Add(time.Hour)
+	if ok, err := store.ClaimIssueWithLease(ctx, abandoned.ID, "agent-1", &past); err != nil || !ok {
+		t.Fatalf("ClaimIssueWithLease = %v, %v", ok, err)
+	}
+	if ok, err := store.ClaimIssueWithLease(ctx, active.ID, "agent-1", &future); err != nil || !ok {
+		t.Fatalf("ClaimIssueWithLease = %v, %v", ok, err)
+	}
+	if ok, _ := store.ClaimIssueWithLease(ctx, active.ID, "agent-2", nil); ok {
+		t.Error("claimed an issue with a live lease")
+	}
+	if err := store.RenewLease(ctx, abandoned.ID, "agent-2", future); err == nil {
+		t.Error("RenewLease by a non-holder succeeded")
+	}
+
+	ready, err := store.GetReadyWork(ctx, types.WorkFilter{Status: types.StatusOpen, Unassigned: true})
+	if err != nil {
+		t.Fatalf("GetReadyWork failed: %v", err)
+	}
+	if len(ready) != 1 || ready[0].ID != abandoned.ID {
+		t.Errorf("ready = %v, want only %s", ready, abandoned.ID)
+	}
+
+	reclaimed, err := store.ReclaimExpiredLeases(ctx, time.Now(), "daemon")
+	if err != nil {
+		t.Fatalf("ReclaimExpiredLeases failed: %v", err)
+	}
+	if len(reclaimed) != 1 || reclaimed[0].ID != abandoned.ID {
+		t.Fatalf("reclaimed = %v, want only %s", reclaimed, abandoned.ID)
+	}
+	got, _ := store.GetIssue(ctx, abandoned.ID)
+	if got.Status != types.StatusOpen || got.Assignee != "" || got.LeaseExpiresAt != nil {
+		t.Errorf("reclaimed issue: status %s, assignee %q, lease %v", got.Status, got.Assignee, got.LeaseExpiresAt)
+	}
+}
+
+func TestManualReassignmentClearsLease(t *testing.T) {
+	store := setupTestMemory(t)
+	defer store.Close()
+	ctx := context.Background()
+
+	issue := &types.Issue{Title: "Leased", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	lease := time.Now().Add(time.Hour)
+	if ok, err := store.ClaimIssueWithLease(ctx, issue.ID, "agent-1", &lease); err != nil || !ok {
+		t.Fatalf("ClaimIssueWithLease = %v, %v", ok, err)
+	}
+	if err := store.UpdateIssue(ctx, issue.ID, map[string]interface{}{"notes": "halfway"}, "agent-1"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	if got, _ := store.GetIssue(ctx, issue.ID); got.LeaseExpiresAt == nil {
+		t.Fatal("lease cleared by an update that kept the claim")
+	}
+
+	if err := store.UpdateIssue(ctx, issue.ID, map[string]interface{}{"assignee": "agent-2"}, "lead"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	if got, _ := store.GetIssue(ctx, issue.ID); got.LeaseExpiresAt != nil {
+		t.Errorf("lease after reassignment = %v, want none", got.LeaseExpiresAt)
+	}
+	if reclaimed, _ := store.ReclaimExpiredLeases(ctx, lease.Add(time.Hour), "daemon"); len(reclaimed) != 0 {
+		t.Errorf("reclaimed = %v, want nothing", reclaimed)
+	}
+}
@@ -0,0 +1,145 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// SaveAttachment inserts attachment metadata, or replaces the attachment with
+// the same ID. Timestamps are stored as given so imports don't drift.
+func (s *SQLiteStorage) SaveAttachment(ctx context.Context, attachment *types.Attachment) error {
+	if attachment.ID == "" {
+		return fmt.Errorf("attachment ID is required")
+	}
+
+	// Verify issue exists
+	var exists bool
+	err := s.db.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM issues WHERE id = ?)`, attachment.IssueID).Scan(&exists)
+	if err != nil {
+		return fmt.Errorf("failed to check issue existence: %w", err)
+	}
+	if !exists {
+		return fmt.Errorf("issue %s not found", attachment.IssueID)
+	}
+
+	if attachment.CreatedAt.IsZero() {
+		attachment.CreatedAt = time.Now().UTC()
+	}
+	var detachedAt interface{}
+	if attachment.DetachedAt != nil {
+		detachedAt = attachment.DetachedAt.UTC().Format(time.RFC3339Nano)
+	}
+
+	_, err = s.db.ExecContext(ctx, `
+		INSERT INTO attachments (id, issue_id, name, sha256, size, media_type, created_by, created_at, detached_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (id) DO UPDATE SET
+			issue_id = excluded.issue_id,
+			name = excluded.name,
+			sha256 = excluded.sha256,
+			size = excluded.size,
+			media_type = excluded.media_type,
+			detached_at = excluded.detached_at
+	`, attachment.ID, attachment.IssueID, attachment.Name, attachment.SHA256, attachment.Size,
+		attachment.MediaType, attachment.CreatedBy,
+		attachment.CreatedAt.UTC().Format(time.RFC3339Nano), detachedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save attachment: %w", err)
+	}
+
+	// Mark issue as dirty for JSONL export
+	if err := s.MarkIssueDirty(ctx, attachment.IssueID); err != nil {
+		return fmt.Errorf("failed to mark issue dirty: %w", err)
+	}
+
+	return nil
+}
+
+// GetAttachments retrieves the attachments of an issue, oldest first,
+// including detached ones
+func (s *SQLiteStorage) GetAttachments(ctx context.Context, issueID string) ([]*types.Attachment, error) {
+	result, err := s.GetAttachmentsForIssues(ctx, []string{issueID})
+	if err != nil {
+		return nil, err
+	}
+	return result[issueID], nil
+}
+
+// GetAttachmentsForIssues fetches attachments for multiple issues in a single query
+// Returns a map of issue_id -> []*Attachment
+func (s *SQLiteStorage) GetAttachmentsForIssues(ctx context.Context, issueIDs []string) (map[string][]*types.Attachment, error) {
+	if len(issueIDs) == 0 {
+		return make(map[string][]*types.Attachment), nil
+	}
+
+	// Hold read lock during database operations to prevent reconnect() from
+	// closing the connection mid-query (GH#607 race condition fix)
+	s.reconnectMu.RLock()
+	defer s.reconnectMu.RUnlock()
+
+	placeholders := make([]interface{}, len(issueIDs))
+	for i, id := range issueIDs {
+		placeholders[i] = id
+	}
+
+	query := fmt.Sprintf(`
+		SELECT id, issue_id, name, sha256, size, media_type, created_by, created_at, detached_at
+		FROM attachments
+		WHERE issue_id IN (%s)
+		ORDER BY issue_id, created_at ASC, id ASC
+	`, buildPlaceholders(len(issueIDs))) // #nosec G201 -- placeholders are generated internally
+
+	rows, err := s.db.QueryContext(ctx, query, placeholders...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to batch get attachments: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	result := make(map[string][]*types.Attachment)
+	for rows.Next() {
+		a := &types.Attachment{}
+		var detachedAt sql.NullTime
+		err := rows.Scan(&a.ID, &a.IssueID, &a.Name, &a.SHA256, &a.Size, &a.MediaType,
+			&a.CreatedBy, &a.CreatedAt, &detachedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan attachment: %w", err)
+		}
+		if detachedAt.Valid {
+			a.DetachedAt = &detachedAt.Time
+		}
+		result[a.IssueID] = append(result[a.IssueID], a)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating attachments: %w", err)
+	}
+
+	return result, nil
+}
+
+// GetAttachmentHashes returns the content hashes of all attachments that
+// haven't been detached
+func (s *SQLiteStorage) GetAttachmentHashes(ctx context.Context) (map[string]bool, error) {
+	s.reconnectMu.RLock()
+	defer s.reconnectMu.RUnlock()
+
+	rows, err := s.db.QueryContext(ctx, `SELECT DISTINCT sha256 FROM attachments WHERE detached_at IS NULL`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get attachment hashes: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	hashes := make(map[string]bool)
+	for rows.Next() {
+		var hash string
+		if err := rows.Scan(&hash); err != nil {
+			return nil, fmt.Errorf("failed to scan attachment hash: %w", err)
+		}
+		hashes[hash] = true
+	}
+	return hashes, rows.Err()
+}
@@ -0,0 +1,125 @@
+package sqlite
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestSaveAttachment(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	issue := &types.Issue{
+		Title:     "Test issue",
+		Status:    types.StatusOpen,
+		Priority:  1,
+		IssueType: types.TypeTask,
+	}
+	if err := store.CreateIssue(ctx, issue, "test-user"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+
+	created := time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)
+	logHash := strings.Repeat("a", 64)
+	patchHash := strings.Repeat("b", 64)
+	log := &types.Attachment{
+		ID:        "att-log",
+		IssueID:   issue.ID,
+		Name:      "build.log",
+		SHA256:    logHash,
+		Size:      1200,
+		MediaType: "text/plain",
+		CreatedBy: "alice",
+		CreatedAt: created.Add(time.Hour),
+	}
+	patch := &types.Attachment{
+		ID:        "att-patch",
+		IssueID:   issue.ID,
+		Name:      "fix.patch",
+		SHA256:    patchHash,
+		Size:      300,
+		CreatedBy: "bob",
+		CreatedAt: created,
+	}
+	for _, a := range []*types.Attachment{log, patch} {
+		if err := store.SaveAttachment(ctx, a); err != nil {
+			t.Fatalf("SaveAttachment failed: %v", err)
+		}
+	}
+
+	got, err := store.GetAttachments(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetAttachments failed: %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("expected 2 attachments, got %d", len(got))
+	}
+	if got[0].ID != "att-patch" || got[1].ID != "att-log" {
+		t.Errorf("attachments not ordered by created_at: %s, %s", got[0].ID, got[1].ID)
+	}
+	if got[1].Name != "build.log" || got[1].Size != 1200 || got[1].MediaType != "text/plain" || !got[1].CreatedAt.Equal(log.CreatedAt) {
+		t.Errorf("attachment not round-tripped: %+v", got[1])
+	}
+
+	hashes, err := store.GetAttachmentHashes(ctx)
+	if err != nil {
+		t.Fatalf("GetAttachmentHashes failed: %v", err)
+	}
+	if !hashes[logHash] || !hashes[patchHash] {
+		t.Errorf("hashes = %v, want both attachments", hashes)
+	}
+
+	// Detaching replaces the row and drops the hash from the referenced set
+	detached := created.Add(2 * time.Hour)
+	patch.DetachedAt = &detached
+	if err := store.SaveAttachment(ctx, patch); err != nil {
+		t.Fatalf("SaveAttachment (detach) failed: %v", err)
+	}
+	got, _ = store.GetAttachments(ctx, issue.ID)
+	if len(got) != 2 || !got[0].IsDetached() || got[1].IsDetached() {
+		t.Fatalf("detach not saved: %+v", got)
+	}
+	if live := types.LiveAttachments(got); len(live) != 1 || live[0].ID != "att-log" {
+		t.Errorf("LiveAttachments = %v", live)
+	}
+	hashes, _ = store.GetAttachmentHashes(ctx)
+	if hashes[patchHash] || !hashes[logHash] {
+		t.Errorf("hashes after detach = %v", hashes)
+	}
+
+	// Saving marks the issue dirty for export
+	dirty, err := store.GetDirtyIssues(ctx)
+	if err != nil {
+		t.Fatalf("GetDirtyIssues failed: %v", err)
+	}
+	found := false
+	for _, id := range dirty {
+		if id == issue.ID {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("issue not marked dirty")
+	}
+
+	// Unknown issue is rejected
+	orphan := &types.Attachment{ID: "att-x", IssueID: "bd-missing", Name: "x", SHA256: logHash}
+	if err := store.SaveAttachment(ctx, orphan); err == nil {
+		t.Error("expected error for missing issue")
+	}
+
+	// Deleting the issue removes its attachments
+	if err := store.DeleteIssue(ctx, issue.ID); err != nil {
+		t.Fatalf("DeleteIssue failed: %v", err)
+	}
+	hashes, _ = store.GetAttachmentHashes(ctx)
+	if len(hashes) != 0 {
+		t.Errorf("hashes after delete = %v", hashes)
+	}
+}
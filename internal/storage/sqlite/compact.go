@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/steveyegge/beads/internal/types"
@@ -116,9 +117,10 @@ func (s *SQLiteStorage) GetTier1Candidates(ctx context.Context) ([]*CompactionCa
 // Criteria:
 // - Status = closed
 // - Closed for at least compact_tier2_days
-// - No open dependents within compact_tier2_dep_levels depth
-// - Already at compaction_level = 1
-// - Either has many commits (compact_tier2_commits) or many dependent issues
+// - No open blocking dependents
+// - Either a closed epic whose descendants are all closed and not pinned
+//   (rolled up into one archival summary), or an issue already at
+//   compaction_level = 1 with many events (compact_tier2_commits)
 func (s *SQLiteStorage) GetTier2Candidates(ctx context.Context) ([]*CompactionCandidate, error) {
 	// Get configuration
 	daysStr, err := s.GetConfig(ctx, "compact_tier2_days")
@@ -187,6 +189,88 @@ func (s *SQLiteStorage) GetTier2Candidates(ctx context.Context) ([]*CompactionCa
 		return nil, fmt.Errorf("rows iteration error: %w", err)
 	}
 
+	epics, err := s.getTier2EpicCandidates(ctx, daysStr)
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]bool, len(candidates))
+	for _, c := range candidates {
+		seen[c.IssueID] = true
+	}
+	for _, c := range epics {
+		if !seen[c.IssueID] {
+			candidates = append(candidates, c)
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].ClosedAt.Before(candidates[j].ClosedAt)
+	})
+
+	return candidates, nil
+}
+
+// getTier2EpicCandidates returns closed epics that can be rolled up with their
+// descendants. OriginalSize covers the epic and its descendants; DependentCount
+// is the number of descendants.
+func (s *SQLiteStorage) getTier2EpicCandidates(ctx context.Context, daysStr string) ([]*CompactionCandidate, error) {
+	query := `
+		WITH RECURSIVE descendants(root_id, issue_id) AS (
+		  SELECT depends_on_id, issue_id FROM dependencies WHERE type = 'parent-child'
+		  UNION
+		  SELECT ds.root_id, d.issue_id FROM descendants ds
+		  JOIN dependencies d ON d.depends_on_id = ds.issue_id AND d.type = 'parent-child'
+		)
+		SELECT
+		  i.id,
+		  i.closed_at,
+		  LENGTH(i.description) + LENGTH(i.design) + LENGTH(i.notes) + LENGTH(i.acceptance_criteria)
+		    + COALESCE((
+		      SELECT SUM(LENGTH(c.description) + LENGTH(c.design) + LENGTH(c.notes) + LENGTH(c.acceptance_criteria))
+		      FROM descendants ds JOIN issues c ON c.id = ds.issue_id
+		      WHERE ds.root_id = i.id AND c.status = 'closed' AND COALESCE(c.compaction_level, 0) < 2
+		    ), 0) as original_size,
+		  0 as estimated_size,
+		  (SELECT COUNT(*) FROM descendants ds WHERE ds.root_id = i.id) as dependent_count
+		FROM issues i
+		WHERE i.issue_type = 'epic'
+		  AND i.status = 'closed'
+		  AND i.closed_at IS NOT NULL
+		  AND i.closed_at <= datetime('now', '-' || CAST(? AS INTEGER) || ' days')
+		  AND COALESCE(i.compaction_level, 0) < 2
+		  AND COALESCE(i.pinned, 0) = 0
+		  AND NOT EXISTS (
+		    -- Every descendant must be finished
+		    SELECT 1 FROM descendants ds JOIN issues c ON c.id = ds.issue_id
+		    WHERE ds.root_id = i.id
+		      AND (c.status NOT IN ('closed', 'tombstone') OR COALESCE(c.pinned, 0) = 1)
+		  )
+		  AND NOT EXISTS (
+		    SELECT 1 FROM dependencies d
+		    JOIN issues dep ON d.issue_id = dep.id
+		    WHERE d.depends_on_id = i.id
+		      AND d.type = 'blocks'
+		      AND dep.status IN ('open', 'in_progress', 'blocked', 'deferred', 'hooked')
+		  )
+		ORDER BY i.closed_at ASC
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, daysStr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query tier2 epic candidates: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var candidates []*CompactionCandidate
+	for rows.Next() {
+		var c CompactionCandidate
+		if err := rows.Scan(&c.IssueID, &c.ClosedAt, &c.OriginalSize, &c.EstimatedSize, &c.DependentCount); err != nil {
+			return nil, fmt.Errorf("failed to scan candidate: %w", err)
+		}
+		candidates = append(candidates, &c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
 	return candidates, nil
 }
 
@@ -248,8 +332,8 @@ func (s *SQLiteStorage) CheckEligibility(ctx context.Context, issueID string, ti
 		return false, "issue has open dependents or not closed long enough", nil
 		
 	case 2:
-		if compactionLevel != 1 {
-			return false, "issue must be at compaction level 1 for tier 2", nil
+		if compactionLevel >= 2 {
+			return false, "issue is already compacted to tier 2", nil
 		}
 		
 		// Check if it appears in tier2 candidates
@@ -264,6 +348,9 @@ func (s *SQLiteStorage) CheckEligibility(ctx context.Context, issueID string, ti
 			}
 		}
 		
+		if compactionLevel != 1 {
+			return false, "issue must be a closed epic with all children closed, or at compaction level 1", nil
+		}
 		return false, "issue has open dependents, not closed long enough, or insufficient events", nil
 	}
 	
@@ -273,53 +360,56 @@ func (s *SQLiteStorage) CheckEligibility(ctx context.Context, issueID string, ti
 // ApplyCompaction updates the compaction metadata for an issue after successfully compacting it.
 // This sets compaction_level, compacted_at, compacted_at_commit, and original_size fields.
 func (s *SQLiteStorage) ApplyCompaction(ctx context.Context, issueID string, level int, originalSize int, compressedSize int, commitHash string) error {
-	now := time.Now().UTC()
-	
 	return s.withTx(ctx, func(tx *sql.Tx) error {
-		var commitHashPtr *string
-		if commitHash != "" {
-			commitHashPtr = &commitHash
-		}
-		
-		res, err := tx.ExecContext(ctx, `
-			UPDATE issues
-			SET compaction_level = ?,
-			    compacted_at = ?,
-			    compacted_at_commit = ?,
-			    original_size = ?,
-			    updated_at = ?
-			WHERE id = ?
-		`, level, now, commitHashPtr, originalSize, now, issueID)
-		
-		if err != nil {
-			return fmt.Errorf("failed to apply compaction metadata: %w", err)
-		}
-		
-		rows, err := res.RowsAffected()
-		if err != nil {
-			return fmt.Errorf("failed to get rows affected: %w", err)
-		}
-		if rows == 0 {
-			return fmt.Errorf("issue %s not found", issueID)
-		}
-		
-		reductionPct := 0.0
-		if originalSize > 0 {
-			reductionPct = (1.0 - float64(compressedSize)/float64(originalSize)) * 100
-		}
-		
-		eventData := fmt.Sprintf(`{"tier":%d,"original_size":%d,"compressed_size":%d,"reduction_pct":%.1f}`,
-			level, originalSize, compressedSize, reductionPct)
-		
-		_, err = tx.ExecContext(ctx, `
-			INSERT INTO events (issue_id, event_type, actor, comment)
-			VALUES (?, ?, 'compactor', ?)
-		`, issueID, types.EventCompacted, eventData)
-		
-		if err != nil {
-			return fmt.Errorf("failed to record compaction event: %w", err)
-		}
-		
-		return nil
+		return applyCompactionTx(ctx, tx, issueID, level, originalSize, compressedSize, commitHash, time.Now().UTC())
 	})
 }
+
+// applyCompactionTx is ApplyCompaction inside tx.
+func applyCompactionTx(ctx context.Context, tx *sql.Tx, issueID string, level int, originalSize int, compressedSize int, commitHash string, now time.Time) error {
+	var commitHashPtr *string
+	if commitHash != "" {
+		commitHashPtr = &commitHash
+	}
+	
+	res, err := tx.ExecContext(ctx, `
+		UPDATE issues
+		SET compaction_level = ?,
+		    compacted_at = ?,
+		    compacted_at_commit = ?,
+		    original_size = ?,
+		    updated_at = ?
+		WHERE id = ?
+	`, level, now, commitHashPtr, originalSize, now, issueID)
+	
+	if err != nil {
+		return fmt.Errorf("failed to apply compaction metadata: %w", err)
+	}
+	
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("issue %s not found", issueID)
+	}
+	
+	reductionPct := 0.0
+	if originalSize > 0 {
+		reductionPct = (1.0 - float64(compressedSize)/float64(originalSize)) * 100
+	}
+	
+	eventData := fmt.Sprintf(`{"tier":%d,"original_size":%d,"compressed_size":%d,"reduction_pct":%.1f}`,
+		level, originalSize, compressedSize, reductionPct)
+	
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO events (issue_id, event_type, actor, comment)
+		VALUES (?, ?, 'compactor', ?)
+	`, issueID, types.EventCompacted, eventData)
+	
+	if err != nil {
+		return fmt.Errorf("failed to record compaction event: %w", err)
+	}
+	
+	return nil
+}
@@ -0,0 +1,492 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// SnapshotContent is the pre-compaction state of an issue kept in
+// issue_snapshots.original_content so that compaction can be undone.
+type SnapshotContent struct {
+	Description        string     `json:"description"`
+	Design             string     `json:"design,omitempty"`
+	Notes              string     `json:"notes,omitempty"`
+	AcceptanceCriteria string     `json:"acceptance_criteria,omitempty"`
+	CompactionLevel    int        `json:"compaction_level"`
+	CompactedAt        *time.Time `json:"compacted_at,omitempty"`
+	CompactedAtCommit  *string    `json:"compacted_at_commit,omitempty"`
+	OriginalSize       int        `json:"original_size,omitempty"`
+	// RollupOf is the epic whose tier-2 summary absorbed this issue
+	RollupOf string `json:"rollup_of,omitempty"`
+}
+
+// CompactionSnapshot is one row of issue_snapshots.
+type CompactionSnapshot struct {
+	ID              int64
+	IssueID         string
+	SnapshotTime    time.Time
+	CompactionLevel int // Level the issue was compacted to
+	OriginalSize    int
+	CompressedSize  int
+	Content         SnapshotContent
+	Events          []*types.Event // Events archived by tier-2 compaction
+}
+
+// SnapshotIssue records the current content of an issue before it is
+// compacted to level, so that bd restore can bring it back.
+func (s *SQLiteStorage) SnapshotIssue(ctx context.Context, issueID string, level int, compressedSize int) error {
+	return s.withTx(ctx, func(tx *sql.Tx) error {
+		return insertSnapshot(ctx, tx, issueID, level, compressedSize, "", false)
+	})
+}
+
+// ArchiveIssue snapshots an issue, moves its events into the snapshot and
+// replaces its content with summary, all in one transaction. rollupOf names
+// the epic the issue was rolled into (empty for the epic itself).
+// ApplyCompaction records the new level afterwards.
+func (s *SQLiteStorage) ArchiveIssue(ctx context.Context, issueID string, level int, summary, rollupOf string) error {
+	return s.withTx(ctx, func(tx *sql.Tx) error {
+		return archiveIssueTx(ctx, tx, issueID, level, summary, rollupOf, time.Now())
+	})
+}
+
+// ArchiveRollup applies a tier-2 rollup in one transaction: the epic is
+// archived under summary, each child under stub with the epic as rollup_of,
+// all of them move to compaction level 2 and comment is recorded on the
+// epic. originalSize is the size of the epic and its children together.
+// If any issue fails, nothing is archived.
+func (s *SQLiteStorage) ArchiveRollup(ctx context.Context, epicID string, childIDs []string, summary, stub string, originalSize int, commitHash, actor, comment string) error {
+	return s.withTx(ctx, func(tx *sql.Tx) error {
+		now := time.Now()
+		if err := archiveIssueTx(ctx, tx, epicID, 2, summary, "", now); err != nil {
+			return err
+		}
+		if err := applyCompactionTx(ctx, tx, epicID, 2, originalSize, len(summary), commitHash, now); err != nil {
+			return err
+		}
+		for _, id := range childIDs {
+			child, err := getIssueTx(ctx, tx, id)
+			if err != nil {
+				return err
+			}
+			if child == nil {
+				return fmt.Errorf("issue %s not found", id)
+			}
+			size := len(child.Description) + len(child.Design) + len(child.Notes) + len(child.AcceptanceCriteria)
+			if err := archiveIssueTx(ctx, tx, id, 2, stub, epicID, now); err != nil {
+				return err
+			}
+			if err := applyCompactionTx(ctx, tx, id, 2, size, len(stub), commitHash, now); err != nil {
+				return err
+			}
+		}
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO events (issue_id, event_type, actor, comment)
+			VALUES (?, ?, ?, ?)
+		`, epicID, types.EventCommented, actor, comment)
+		if err != nil {
+			return fmt.Errorf("failed to record rollup: %w", err)
+		}
+		return nil
+	})
+}
+
+// archiveIssueTx is ArchiveIssue inside tx. The content hash is computed
+// from the issue as read in tx, so it matches the row being replaced.
+func archiveIssueTx(ctx context.Context, tx *sql.Tx, issueID string, level int, summary, rollupOf string, now time.Time) error {
+	issue, err := getIssueTx(ctx, tx, issueID)
+	if err != nil {
+		return err
+	}
+	if issue == nil {
+		return fmt.Errorf("issue %s not found", issueID)
+	}
+	issue.Description = summary
+	issue.Design = ""
+	issue.Notes = ""
+	issue.AcceptanceCriteria = ""
+
+	if err := insertSnapshot(ctx, tx, issueID, level, len(summary), rollupOf, true); err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx, `
+		UPDATE issues
+		SET description = ?, design = '', notes = '', acceptance_criteria = '',
+		    content_hash = ?, updated_at = ?
+		WHERE id = ?
+	`, summary, issue.ComputeContentHash(), now, issueID)
+	if err != nil {
+		return fmt.Errorf("failed to replace content of %s: %w", issueID, err)
+	}
+	return markDirtyTx(ctx, tx, issueID, now)
+}
+
+// GetCompactionSnapshots returns the snapshots of an issue, oldest first.
+func (s *SQLiteStorage) GetCompactionSnapshots(ctx context.Context, issueID string) ([]*CompactionSnapshot, error) {
+	return compactionSnapshots(ctx, s.db, issueID)
+}
+
+// queryer runs queries on either *sql.DB or *sql.Tx.
+type queryer interface {
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+}
+
+func compactionSnapshots(ctx context.Context, q queryer, issueID string) ([]*CompactionSnapshot, error) {
+	rows, err := q.QueryContext(ctx, `
+		SELECT id, issue_id, snapshot_time, compaction_level, original_size,
+		       compressed_size, original_content, archived_events
+		FROM issue_snapshots
+		WHERE issue_id = ?
+		ORDER BY id ASC
+	`, issueID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query snapshots: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var snapshots []*CompactionSnapshot
+	for rows.Next() {
+		var snap CompactionSnapshot
+		var content string
+		var events sql.NullString
+		if err := rows.Scan(&snap.ID, &snap.IssueID, &snap.SnapshotTime, &snap.CompactionLevel,
+			&snap.OriginalSize, &snap.CompressedSize, &content, &events); err != nil {
+			return nil, fmt.Errorf("failed to scan snapshot: %w", err)
+		}
+		if err := json.Unmarshal([]byte(content), &snap.Content); err != nil {
+			return nil, fmt.Errorf("snapshot %d of %s has invalid content: %w", snap.ID, issueID, err)
+		}
+		if events.Valid && events.String != "" {
+			if err := json.Unmarshal([]byte(events.String), &snap.Events); err != nil {
+				return nil, fmt.Errorf("snapshot %d of %s has invalid events: %w", snap.ID, issueID, err)
+			}
+		}
+		snapshots = append(snapshots, &snap)
+	}
+	return snapshots, rows.Err()
+}
+
+// GetRollupMembers returns the issues that were rolled into epicID by tier-2
+// compaction.
+func (s *SQLiteStorage) GetRollupMembers(ctx context.Context, epicID string) ([]string, error) {
+	return rollupMembers(ctx, s.db, epicID)
+}
+
+func rollupMembers(ctx context.Context, q queryer, epicID string) ([]string, error) {
+	rows, err := q.QueryContext(ctx, `
+		SELECT DISTINCT issue_id
+		FROM issue_snapshots
+		WHERE json_extract(original_content, '$.rollup_of') = ?
+		ORDER BY issue_id
+	`, epicID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query rollup members: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan rollup member: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// RestoreCompaction undoes compaction of an issue from its snapshots: the
+// original content and compaction metadata come back, archived events are
+// re-inserted with their original timestamps and the snapshots are removed.
+// Issues rolled into it by tier-2 compaction are restored as well.
+// Returns the IDs of all restored issues.
+func (s *SQLiteStorage) RestoreCompaction(ctx context.Context, issueID string, actor string) ([]string, error) {
+	var restored []string
+	err := s.withTx(ctx, func(tx *sql.Tx) error {
+		members, err := rollupMembers(ctx, tx, issueID)
+		if err != nil {
+			return err
+		}
+		ids := []string{issueID}
+		for _, id := range members {
+			if id != issueID {
+				ids = append(ids, id)
+			}
+		}
+
+		now := time.Now()
+		for _, id := range ids {
+			snapshots, err := compactionSnapshots(ctx, tx, id)
+			if err != nil {
+				return err
+			}
+			if len(snapshots) == 0 {
+				if id == issueID {
+					return fmt.Errorf("issue %s has no compaction snapshots", issueID)
+				}
+				continue
+			}
+			issue, err := getIssueTx(ctx, tx, id)
+			if err != nil {
+				return err
+			}
+			if issue == nil {
+				continue
+			}
+			original := snapshots[0].Content
+			issue.Description = original.Description
+			issue.Design = original.Design
+			issue.Notes = original.Notes
+			issue.AcceptanceCriteria = original.AcceptanceCriteria
+			if err := restoreIssueTx(ctx, tx, id, snapshots, issue.ComputeContentHash(), actor, now); err != nil {
+				return err
+			}
+			restored = append(restored, id)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return restored, nil
+}
+
+// restoreIssueTx brings back the original content of an issue from its
+// snapshots inside tx, re-inserts archived events with their original
+// timestamps and removes the snapshots.
+func restoreIssueTx(ctx context.Context, tx *sql.Tx, id string, snapshots []*CompactionSnapshot, contentHash, actor string, now time.Time) error {
+	original := snapshots[0].Content
+	var originalSize interface{}
+	if original.OriginalSize > 0 {
+		originalSize = original.OriginalSize
+	}
+	_, err := tx.ExecContext(ctx, `
+		UPDATE issues
+		SET description = ?, design = ?, notes = ?, acceptance_criteria = ?,
+		    compaction_level = ?, compacted_at = ?, compacted_at_commit = ?,
+		    original_size = ?, content_hash = ?, updated_at = ?
+		WHERE id = ?
+	`, original.Description, original.Design, original.Notes, original.AcceptanceCriteria,
+		original.CompactionLevel, original.CompactedAt, original.CompactedAtCommit,
+		originalSize, contentHash, now, id)
+	if err != nil {
+		return fmt.Errorf("failed to restore %s: %w", id, err)
+	}
+
+	for _, snap := range snapshots {
+		for _, e := range snap.Events {
+			_, err := tx.ExecContext(ctx, `
+				INSERT INTO events (issue_id, event_type, actor, old_value, new_value, comment, created_at)
+				VALUES (?, ?, ?, ?, ?, ?, ?)
+			`, id, e.EventType, e.Actor, e.OldValue, e.NewValue, e.Comment, e.CreatedAt)
+			if err != nil {
+				return fmt.Errorf("failed to restore events of %s: %w", id, err)
+			}
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM issue_snapshots WHERE issue_id = ?`, id); err != nil {
+		return fmt.Errorf("failed to delete snapshots of %s: %w", id, err)
+	}
+
+	comment := fmt.Sprintf("Restored from compaction snapshot (level %d → %d)",
+		snapshots[len(snapshots)-1].CompactionLevel, original.CompactionLevel)
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO events (issue_id, event_type, actor, comment)
+		VALUES (?, ?, ?, ?)
+	`, id, types.EventUpdated, actor, comment)
+	if err != nil {
+		return fmt.Errorf("failed to record restore event: %w", err)
+	}
+
+	return markDirtyTx(ctx, tx, id, now)
+}
+
+// GetEpicDescendants returns every issue below epicID in the parent-child
+// hierarchy, skipping tombstones.
+func (s *SQLiteStorage) GetEpicDescendants(ctx context.Context, epicID string) ([]*types.Issue, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		WITH RECURSIVE descendants(id) AS (
+		  SELECT issue_id FROM dependencies
+		  WHERE depends_on_id = ? AND type = 'parent-child'
+		  UNION
+		  SELECT d.issue_id FROM dependencies d
+		  JOIN descendants ds ON d.depends_on_id = ds.id
+		  WHERE d.type = 'parent-child'
+		)
+		SELECT i.id FROM descendants ds
+		JOIN issues i ON i.id = ds.id
+		WHERE i.status != 'tombstone'
+		ORDER BY i.id
+	`, epicID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query descendants: %w", err)
+	}
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			_ = rows.Close()
+			return nil, fmt.Errorf("failed to scan descendant: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	_ = rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	issues := make([]*types.Issue, 0, len(ids))
+	for _, id := range ids {
+		issue, err := s.GetIssue(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get descendant %s: %w", id, err)
+		}
+		if issue != nil {
+			issues = append(issues, issue)
+		}
+	}
+	return issues, nil
+}
+
+// insertSnapshot saves the current content of an issue into issue_snapshots.
+// With archiveEvents the issue's events are copied into the snapshot and
+// deleted from the events table.
+func insertSnapshot(ctx context.Context, tx *sql.Tx, issueID string, level, compressedSize int, rollupOf string, archiveEvents bool) error {
+	var content SnapshotContent
+	var compactedAt sql.NullTime
+	var commit sql.NullString
+	err := tx.QueryRowContext(ctx, `
+		SELECT description, design, notes, acceptance_criteria,
+		       COALESCE(compaction_level, 0), compacted_at, compacted_at_commit,
+		       COALESCE(original_size, 0)
+		FROM issues
+		WHERE id = ?
+	`, issueID).Scan(&content.Description, &content.Design, &content.Notes, &content.AcceptanceCriteria,
+		&content.CompactionLevel, &compactedAt, &commit, &content.OriginalSize)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("issue %s not found", issueID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read issue content: %w", err)
+	}
+	if compactedAt.Valid {
+		content.CompactedAt = &compactedAt.Time
+	}
+	if commit.Valid {
+		content.CompactedAtCommit = &commit.String
+	}
+	content.RollupOf = rollupOf
+
+	contentJSON, err := json.Marshal(content)
+	if err != nil {
+		return fmt.Errorf("failed to encode snapshot: %w", err)
+	}
+
+	var archived interface{}
+	if archiveEvents {
+		events, err := eventsTx(ctx, tx, issueID)
+		if err != nil {
+			return err
+		}
+		eventsJSON, err := json.Marshal(events)
+		if err != nil {
+			return fmt.Errorf("failed to encode archived events: %w", err)
+		}
+		archived = string(eventsJSON)
+		if _, err := tx.ExecContext(ctx, `DELETE FROM events WHERE issue_id = ?`, issueID); err != nil {
+			return fmt.Errorf("failed to archive events: %w", err)
+		}
+	}
+
+	size := len(content.Description) + len(content.Design) + len(content.Notes) + len(content.AcceptanceCriteria)
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO issue_snapshots (issue_id, snapshot_time, compaction_level, original_size,
+		                             compressed_size, original_content, archived_events)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`, issueID, time.Now().UTC(), level, size, compressedSize, string(contentJSON), archived)
+	if err != nil {
+		return fmt.Errorf("failed to save snapshot: %w", err)
+	}
+	return nil
+}
+
+// eventsTx returns the events of an issue inside tx, oldest first.
+func eventsTx(ctx context.Context, tx *sql.Tx, issueID string) ([]*types.Event, error) {
+	rows, err := tx.QueryContext(ctx, `
+		SELECT id, issue_id, event_type, actor, old_value, new_value, comment, created_at
+		FROM events
+		WHERE issue_id = ?
+		ORDER BY created_at ASC, id ASC
+	`, issueID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get events: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	events := []*types.Event{}
+	for rows.Next() {
+		var event types.Event
+		var oldValue, newValue, comment sql.NullString
+		if err := rows.Scan(&event.ID, &event.IssueID, &event.EventType, &event.Actor,
+			&oldValue, &newValue, &comment, &event.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan event: %w", err)
+		}
+		if oldValue.Valid {
+			event.OldValue = &oldValue.String
+		}
+		if newValue.Valid {
+			event.NewValue = &newValue.String
+		}
+		if comment.Valid {
+			event.Comment = &comment.String
+		}
+		events = append(events, &event)
+	}
+	return events, rows.Err()
+}
+
+// getIssueTx returns an issue (without labels) inside tx, or nil if it
+// doesn't exist.
+func getIssueTx(ctx context.Context, tx *sql.Tx, issueID string) (*types.Issue, error) {
+	row := tx.QueryRowContext(ctx, `
+		SELECT id, content_hash, title, description, design, acceptance_criteria, notes,
+		       status, priority, issue_type, assignee, estimated_minutes,
+		       created_at, created_by, owner, updated_at, closed_at, external_ref,
+		       compaction_level, compacted_at, compacted_at_commit, original_size, source_repo, close_reason,
+		       deleted_at, deleted_by, delete_reason, original_type,
+		       sender, ephemeral, pinned, is_template, crystallizes,
+		       await_type, await_id, timeout_ns, waiters,
+		       hook_bead, role_bead, agent_state, last_activity, role_type, rig, mol_type,
+		       due_at, defer_until, recurrence, custom_fields, lease_expires_at
+		FROM issues
+		WHERE id = ?
+	`, issueID)
+	issue, err := scanIssueRow(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get issue %s: %w", issueID, err)
+	}
+	return issue, nil
+}
+
+// markDirtyTx flags an issue for incremental export inside tx.
+func markDirtyTx(ctx context.Context, tx *sql.Tx, issueID string, now time.Time) error {
+	_, err := tx.ExecContext(ctx, `
+		INSERT INTO dirty_issues (issue_id, marked_at)
+		VALUES (?, ?)
+		ON CONFLICT (issue_id) DO UPDATE SET marked_at = excluded.marked_at
+	`, issueID, now)
+	if err != nil {
+		return fmt.Errorf("failed to mark issue dirty: %w", err)
+	}
+	return nil
+}
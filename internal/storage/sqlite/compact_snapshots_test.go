@@ -0,0 +1,284 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// createRollupFixture creates a closed epic bd-1 with closed children bd-1.1
+// and bd-1.2, where bd-1.2 has a closed child bd-1.2.1.
+func createRollupFixture(t *testing.T, store *SQLiteStorage) {
+	t.Helper()
+	ctx := context.Background()
+	closedAt := timePtr(time.Now().Add(-100 * 24 * time.Hour))
+	issues := []*types.Issue{
+		{ID: "bd-1", Title: "Epic", Description: "Epic description", Design: "Epic design", IssueType: types.TypeEpic},
+		{ID: "bd-1.1", Title: "Child one", Description: "First child", Notes: "child notes", IssueType: types.TypeTask},
+		{ID: "bd-1.2", Title: "Child two", Description: "Second child", IssueType: types.TypeTask},
+		{ID: "bd-1.2.1", Title: "Grandchild", Description: "Nested", IssueType: types.TypeTask},
+	}
+	for _, issue := range issues {
+		issue.Status = types.StatusClosed
+		issue.Priority = 2
+		issue.ClosedAt = closedAt
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue %s: %v", issue.ID, err)
+		}
+	}
+	for child, parent := range map[string]string{"bd-1.1": "bd-1", "bd-1.2": "bd-1", "bd-1.2.1": "bd-1.2"} {
+		dep := &types.Dependency{IssueID: child, DependsOnID: parent, Type: types.DepParentChild}
+		if err := store.AddDependency(ctx, dep, "test"); err != nil {
+			t.Fatalf("AddDependency %s: %v", child, err)
+		}
+	}
+}
+
+func TestGetTier2Candidates_Epics(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+	createRollupFixture(t, store)
+
+	candidates, err := store.GetTier2Candidates(ctx)
+	if err != nil {
+		t.Fatalf("GetTier2Candidates failed: %v", err)
+	}
+	if len(candidates) != 1 || candidates[0].IssueID != "bd-1" {
+		t.Fatalf("expected epic bd-1 as the only candidate, got %+v", candidates)
+	}
+	if c := candidates[0]; c.DependentCount != 3 || c.OriginalSize < len("Epic descriptionEpic designFirst child") {
+		t.Errorf("candidate = %+v", c)
+	}
+	if eligible, reason, err := store.CheckEligibility(ctx, "bd-1", 2); err != nil || !eligible {
+		t.Errorf("epic not eligible: %s %v", reason, err)
+	}
+
+	// An open grandchild makes the epic ineligible
+	if err := store.UpdateIssue(ctx, "bd-1.2.1", map[string]interface{}{"status": "open"}, "test"); err != nil {
+		t.Fatal(err)
+	}
+	candidates, err = store.GetTier2Candidates(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(candidates) != 0 {
+		t.Errorf("expected no candidates with an open grandchild, got %+v", candidates)
+	}
+	if eligible, _, _ := store.CheckEligibility(ctx, "bd-1", 2); eligible {
+		t.Error("epic with open grandchild should not be eligible")
+	}
+
+	descendants, err := store.GetEpicDescendants(ctx, "bd-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(descendants) != 3 {
+		t.Errorf("expected 3 descendants, got %d", len(descendants))
+	}
+}
+
+func TestSnapshotIssueAndRestore(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+	createRollupFixture(t, store)
+
+	if err := store.SnapshotIssue(ctx, "bd-1.1", 1, 5); err != nil {
+		t.Fatalf("SnapshotIssue failed: %v", err)
+	}
+	updates := map[string]interface{}{"description": "short", "notes": ""}
+	if err := store.UpdateIssue(ctx, "bd-1.1", updates, "compactor"); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.ApplyCompaction(ctx, "bd-1.1", 1, 23, 5, "abc123"); err != nil {
+		t.Fatal(err)
+	}
+
+	snapshots, err := store.GetCompactionSnapshots(ctx, "bd-1.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(snapshots) != 1 || snapshots[0].Content.Description != "First child" || snapshots[0].Content.CompactionLevel != 0 || len(snapshots[0].Events) != 0 {
+		t.Fatalf("snapshots = %+v", snapshots)
+	}
+
+	restored, err := store.RestoreCompaction(ctx, "bd-1.1", "tester")
+	if err != nil {
+		t.Fatalf("RestoreCompaction failed: %v", err)
+	}
+	if len(restored) != 1 {
+		t.Errorf("restored = %v", restored)
+	}
+	issue, err := store.GetIssue(ctx, "bd-1.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if issue.Description != "First child" || issue.Notes != "child notes" || issue.CompactionLevel != 0 || issue.CompactedAtCommit != nil {
+		t.Errorf("issue not restored: %+v", issue)
+	}
+	if _, err := store.RestoreCompaction(ctx, "bd-1.1", "tester"); err == nil {
+		t.Error("second restore should fail without snapshots")
+	}
+}
+
+func TestArchiveIssueAndRestoreRollup(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+	createRollupFixture(t, store)
+
+	if err := store.AddComment(ctx, "bd-1.1", "alice", "worth keeping"); err != nil {
+		t.Fatal(err)
+	}
+	eventsBefore, err := store.GetEvents(ctx, "bd-1.1", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := store.ArchiveIssue(ctx, "bd-1", 2, "rollup summary", ""); err != nil {
+		t.Fatalf("ArchiveIssue epic: %v", err)
+	}
+	for _, id := range []string{"bd-1.1", "bd-1.2"} {
+		if err := store.ArchiveIssue(ctx, id, 2, "Archived into bd-1", "bd-1"); err != nil {
+			t.Fatalf("ArchiveIssue %s: %v", id, err)
+		}
+		if err := store.ApplyCompaction(ctx, id, 2, 20, 18, ""); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	child, err := store.GetIssue(ctx, "bd-1.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if child.Description != "Archived into bd-1" || child.Notes != "" || child.CompactionLevel != 2 {
+		t.Errorf("child not archived: %+v", child)
+	}
+	events, err := store.GetEvents(ctx, "bd-1.1", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 1 || events[0].EventType != types.EventCompacted {
+		t.Errorf("expected only the compaction event to remain, got %d events", len(events))
+	}
+	members, err := store.GetRollupMembers(ctx, "bd-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(members) != 2 {
+		t.Errorf("rollup members = %v", members)
+	}
+
+	restored, err := store.RestoreCompaction(ctx, "bd-1", "tester")
+	if err != nil {
+		t.Fatalf("RestoreCompaction failed: %v", err)
+	}
+	if len(restored) != 3 {
+		t.Errorf("restored = %v, want epic and two children", restored)
+	}
+
+	epic, err := store.GetIssue(ctx, "bd-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if epic.Description != "Epic description" || epic.Design != "Epic design" {
+		t.Errorf("epic not restored: %+v", epic)
+	}
+	child, err = store.GetIssue(ctx, "bd-1.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if child.Description != "First child" || child.Notes != "child notes" || child.CompactionLevel != 0 {
+		t.Errorf("child not restored: %+v", child)
+	}
+
+	// Archived events come back with their original timestamps, followed by
+	// the compaction and restore events
+	events, err = store.GetEvents(ctx, "bd-1.1", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != len(eventsBefore)+2 {
+		t.Fatalf("got %d events after restore, want %d", len(events), len(eventsBefore)+2)
+	}
+	commentAt := func(events []*types.Event) *time.Time {
+		for _, e := range events {
+			if e.Comment != nil && *e.Comment == "worth keeping" {
+				return &e.CreatedAt
+			}
+		}
+		return nil
+	}
+	before, after := commentAt(eventsBefore), commentAt(events)
+	if after == nil {
+		t.Error("archived comment not restored")
+	} else if !after.Equal(*before) {
+		t.Errorf("comment timestamp changed: %v → %v", *before, *after)
+	}
+	if snapshots, _ := store.GetCompactionSnapshots(ctx, "bd-1.2"); len(snapshots) != 0 {
+		t.Errorf("snapshots not cleaned up: %d left", len(snapshots))
+	}
+}
+
+func TestArchiveRollup(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+	createRollupFixture(t, store)
+
+	// A child that can't be archived leaves the whole rollup undone
+	err := store.ArchiveRollup(ctx, "bd-1", []string{"bd-1.1", "bd-missing"}, "rollup summary", "Archived into bd-1", 60, "abc123", "compactor", "rolled up")
+	if err == nil {
+		t.Fatal("ArchiveRollup with a missing child should fail")
+	}
+	for _, id := range []string{"bd-1", "bd-1.1"} {
+		issue, err := store.GetIssue(ctx, id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if issue.CompactionLevel != 0 || issue.Description == "rollup summary" || issue.Description == "Archived into bd-1" {
+			t.Errorf("%s archived by a failed rollup: %+v", id, issue)
+		}
+		if snapshots, _ := store.GetCompactionSnapshots(ctx, id); len(snapshots) != 0 {
+			t.Errorf("%s has %d snapshots after a failed rollup", id, len(snapshots))
+		}
+	}
+
+	if err := store.ArchiveRollup(ctx, "bd-1", []string{"bd-1.1", "bd-1.2"}, "rollup summary", "Archived into bd-1", 60, "abc123", "compactor", "rolled up"); err != nil {
+		t.Fatalf("ArchiveRollup failed: %v", err)
+	}
+	epic, err := store.GetIssue(ctx, "bd-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if epic.Description != "rollup summary" || epic.Design != "" || epic.CompactionLevel != 2 || epic.OriginalSize != 60 {
+		t.Errorf("epic not archived: %+v", epic)
+	}
+	if want := epic.ComputeContentHash(); epic.ContentHash != want {
+		t.Errorf("epic content hash = %s, want %s", epic.ContentHash, want)
+	}
+	child, err := store.GetIssue(ctx, "bd-1.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if child.Description != "Archived into bd-1" || child.CompactionLevel != 2 || child.OriginalSize != len("First child")+len("child notes") {
+		t.Errorf("child not archived: %+v", child)
+	}
+	events, err := store.GetEvents(ctx, "bd-1", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var commented bool
+	for _, e := range events {
+		commented = commented || (e.EventType == types.EventCommented && e.Comment != nil && *e.Comment == "rolled up")
+	}
+	if !commented {
+		t.Error("rollup comment not recorded on the epic")
+	}
+	if members, _ := store.GetRollupMembers(ctx, "bd-1"); len(members) != 2 {
+		t.Errorf("rollup members = %v", members)
+	}
+}
@@ -0,0 +1,93 @@
+package sqlite
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestCustomFields(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	create := func(title string, fields map[string]string) *types.Issue {
+		t.Helper()
+		issue := &types.Issue{Title: title, Status: types.StatusOpen, Priority: 2, IssueType: types.TypeBug, CustomFields: fields}
+		if err := store.CreateIssue(ctx, issue, "test-user"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+		return issue
+	}
+	small := create("Small", map[string]string{"points": "2", "severity": "low"})
+	large := create("Large", map[string]string{"points": "13", "severity": "high"})
+	none := create("None", nil)
+
+	got, err := store.GetIssue(ctx, large.ID)
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if !reflect.DeepEqual(got.CustomFields, large.CustomFields) {
+		t.Errorf("CustomFields = %v, want %v", got.CustomFields, large.CustomFields)
+	}
+
+	search := func(filters ...types.CustomFieldFilter) []string {
+		t.Helper()
+		issues, err := store.SearchIssues(ctx, "", types.IssueFilter{CustomFields: filters})
+		if err != nil {
+			t.Fatalf("SearchIssues failed: %v", err)
+		}
+		var ids []string
+		for _, issue := range issues {
+			ids = append(ids, issue.ID)
+		}
+		sort.Strings(ids)
+		return ids
+	}
+	sorted := func(ids ...string) []string {
+		sort.Strings(ids)
+		return ids
+	}
+
+	tests := []struct {
+		name   string
+		filter types.CustomFieldFilter
+		want   []string
+	}{
+		{"numeric compare", types.CustomFieldFilter{Name: "points", Op: ">=", Value: "3", Numeric: true}, []string{large.ID}},
+		{"string compare", types.CustomFieldFilter{Name: "points", Op: ">=", Value: "3"}, nil},
+		{"equal", types.CustomFieldFilter{Name: "severity", Op: "=", Value: "low"}, []string{small.ID}},
+		{"not equal includes missing", types.CustomFieldFilter{Name: "severity", Op: "!=", Value: "low"}, sorted(large.ID, none.ID)},
+		{"set", types.CustomFieldFilter{Name: "points", Op: "set"}, sorted(small.ID, large.ID)},
+		{"unset", types.CustomFieldFilter{Name: "points", Op: "unset"}, []string{none.ID}},
+		{"invalid name", types.CustomFieldFilter{Name: `x"]`, Op: "set"}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := search(tt.filter); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SearchIssues(%+v) = %v, want %v", tt.filter, got, tt.want)
+			}
+		})
+	}
+
+	// Updates replace the whole map; an empty map clears the fields
+	if err := store.UpdateIssue(ctx, small.ID, map[string]interface{}{"custom_fields": map[string]string{"points": "5"}}, "test-user"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	if got := search(types.CustomFieldFilter{Name: "points", Op: "=", Value: "5", Numeric: true}); !reflect.DeepEqual(got, []string{small.ID}) {
+		t.Errorf("after update = %v, want %v", got, []string{small.ID})
+	}
+	if err := store.UpdateIssue(ctx, small.ID, map[string]interface{}{"custom_fields": map[string]string{}}, "test-user"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	if got, _ := store.GetIssue(ctx, small.ID); len(got.CustomFields) != 0 {
+		t.Errorf("cleared CustomFields = %v", got.CustomFields)
+	}
+
+	if err := store.UpdateIssue(ctx, small.ID, map[string]interface{}{"custom_fields": map[string]string{"Bad Name": "x"}}, "test-user"); err == nil {
+		t.Error("expected invalid custom field name to be rejected")
+	}
+}
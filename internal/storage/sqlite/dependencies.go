@@ -924,6 +924,9 @@ func (s *SQLiteStorage) scanIssues(ctx context.Context, rows *sql.Rows) ([]*type
 		// Time-based scheduling fields
 		var dueAt sql.NullTime
 		var deferUntil sql.NullTime
+		var recurrence sql.NullString
+		var customFields sql.NullString
+		var leaseExpiresAt sql.NullTime
 
 		err := rows.Scan(
 			&issue.ID, &contentHash, &issue.Title, &issue.Description, &issue.Design,
@@ -934,7 +937,7 @@ func (s *SQLiteStorage) scanIssues(ctx context.Context, rows *sql.Rows) ([]*type
 			&sender, &wisp, &pinned, &isTemplate, &crystallizes,
 			&awaitType, &awaitID, &timeoutNs, &waiters,
 			&hookBead, &roleBead, &agentState, &lastActivity, &roleType, &rig, &molType,
-			&dueAt, &deferUntil,
+			&dueAt, &deferUntil, &recurrence, &customFields, &leaseExpiresAt,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan issue: %w", err)
@@ -1044,6 +1047,15 @@ func (s *SQLiteStorage) scanIssues(ctx context.Context, rows *sql.Rows) ([]*type
 		if deferUntil.Valid {
 			issue.DeferUntil = &deferUntil.Time
 		}
+		if recurrence.Valid {
+			issue.Recurrence = recurrence.String
+		}
+		if customFields.Valid {
+			issue.CustomFields = parseJSONStringMap(customFields.String)
+		}
+		if leaseExpiresAt.Valid {
+			issue.LeaseExpiresAt = &leaseExpiresAt.Time
+		}
 
 		issues = append(issues, &issue)
 		issueIDs = append(issueIDs, issue.ID)
@@ -363,3 +363,59 @@ func TestAddCommentNotFound(t *testing.T) {
 		t.Errorf("Expected error to contain %q, got %q", expectedError, err.Error())
 	}
 }
+
+func TestGetEventsForIssues(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	var ids []string
+	for _, title := range []string{"First", "Second", "Quiet"} {
+		issue := &types.Issue{Title: title, Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+		if err := store.CreateIssue(ctx, issue, "test-user"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+		ids = append(ids, issue.ID)
+	}
+
+	if err := store.UpdateIssue(ctx, ids[0], map[string]interface{}{"status": string(types.StatusInProgress)}, "test-user"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	if err := store.CloseIssue(ctx, ids[0], "Done", "test-user", ""); err != nil {
+		t.Fatalf("CloseIssue failed: %v", err)
+	}
+	if err := store.AddComment(ctx, ids[1], testUserAlice, "A comment"); err != nil {
+		t.Fatalf("AddComment failed: %v", err)
+	}
+
+	result, err := store.GetEventsForIssues(ctx, ids[:2])
+	if err != nil {
+		t.Fatalf("GetEventsForIssues failed: %v", err)
+	}
+	if _, ok := result[ids[2]]; ok {
+		t.Errorf("events returned for an issue that was not requested")
+	}
+
+	var got []types.EventType
+	for _, e := range result[ids[0]] {
+		got = append(got, e.EventType)
+	}
+	want := []types.EventType{types.EventCreated, types.EventStatusChanged, types.EventClosed}
+	if len(got) != len(want) {
+		t.Fatalf("events for %s = %v, want %v", ids[0], got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("event %d = %s, want %s (oldest first)", i, got[i], want[i])
+		}
+	}
+	if len(result[ids[1]]) != 2 {
+		t.Errorf("expected 2 events for %s, got %d", ids[1], len(result[ids[1]]))
+	}
+
+	empty, err := store.GetEventsForIssues(ctx, nil)
+	if err != nil || len(empty) != 0 {
+		t.Errorf("GetEventsForIssues(nil) = %v, %v", empty, err)
+	}
+}
@@ -100,3 +100,26 @@ func TestGateFieldsPreservedAcrossConnections(t *testing.T) {
 		t.Errorf("Waiters was cleared! expected [system], got %v", retrieved2.Waiters)
 	}
 }
+
+// TestUpdateGateWaiters covers bd gate add-waiter, which sets the whole
+// waiters list through UpdateIssue.
+func TestUpdateGateWaiters(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	gate := &types.Issue{Title: "CI gate", Status: types.StatusOpen, Priority: 1, IssueType: "gate", Waiters: []string{"system"}}
+	if err := store.CreateIssue(ctx, gate, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	if err := store.UpdateIssue(ctx, gate.ID, map[string]interface{}{"waiters": []string{"system", "polecats/toast"}}, "test"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	got, err := store.GetIssue(ctx, gate.ID)
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if len(got.Waiters) != 2 || got.Waiters[1] != "polecats/toast" {
+		t.Errorf("Waiters = %v, want [system polecats/toast]", got.Waiters)
+	}
+}
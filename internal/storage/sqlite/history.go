@@ -0,0 +1,484 @@
+package sqlite
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+// SQLite keeps no history of its own, so history queries read the git log of
+// the JSONL export instead. Each commit is reduced to the issues it changed
+// (issue ID -> new line hash, empty when removed), taken from git's diff
+// against its parent, and cached in the metadata table. Commits never change,
+// so cache entries only go stale when history is rewritten; those are pruned,
+// and only the most recent historyCacheLimit commits are cached at all.
+
+var _ storage.HistoryStorage = (*SQLiteStorage)(nil)
+
+// historyCachePrefix prefixes the per-commit change set keys in metadata.
+const historyCachePrefix = "jsonl_history:"
+
+// historyCacheLimit caps the number of cached commits. Older commits are
+// read from git on every query.
+const historyCacheLimit = 1000
+
+// jsonlCommit is a commit that touched the JSONL file.
+type jsonlCommit struct {
+	Hash      string
+	Committer string
+	Date      time.Time
+}
+
+// jsonlHistory runs git against the repository holding the JSONL file.
+type jsonlHistory struct {
+	dir  string // Directory containing the JSONL file
+	path string // JSONL path relative to the repository root
+}
+
+// jsonlHistory locates the JSONL export next to the database and the git
+// repository that tracks it.
+func (s *SQLiteStorage) jsonlHistory(ctx context.Context) (*jsonlHistory, error) {
+	if s.dbPath == "" || s.dbPath == ":memory:" || strings.HasPrefix(s.dbPath, "file::memory:") {
+		return nil, fmt.Errorf("history requires a file-backed database")
+	}
+	jsonlPath := utils.FindJSONLInDir(filepath.Dir(s.dbPath))
+	h := &jsonlHistory{dir: filepath.Dir(jsonlPath)}
+	prefix, err := h.git(ctx, "rev-parse", "--show-prefix")
+	if err != nil {
+		return nil, fmt.Errorf("history requires %s to be tracked in a git repository: %w", filepath.Base(jsonlPath), err)
+	}
+	h.path = strings.TrimSpace(string(prefix)) + filepath.Base(jsonlPath)
+	return h, nil
+}
+
+func (h *jsonlHistory) git(ctx context.Context, args ...string) ([]byte, error) {
+	// #nosec G204 -- fixed git subcommands; refs are validated by resolve
+	cmd := exec.CommandContext(ctx, "git", append([]string{"-C", h.dir}, args...)...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("git %s: %s", args[0], msg)
+		}
+		return nil, fmt.Errorf("git %s: %w", args[0], err)
+	}
+	return out, nil
+}
+
+// commits lists the commits that touched the JSONL file, newest first.
+func (h *jsonlHistory) commits(ctx context.Context) ([]jsonlCommit, error) {
+	out, err := h.git(ctx, "log", "--format=%H%x00%cn%x00%cI", "--", ":(top)"+h.path)
+	if err != nil {
+		return nil, err
+	}
+	var commits []jsonlCommit
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		fields := strings.Split(line, "\x00")
+		if len(fields) != 3 {
+			continue
+		}
+		date, err := time.Parse(time.RFC3339, fields[2])
+		if err != nil {
+			return nil, fmt.Errorf("git log: invalid date %q: %w", fields[2], err)
+		}
+		commits = append(commits, jsonlCommit{Hash: fields[0], Committer: fields[1], Date: date})
+	}
+	return commits, nil
+}
+
+// resolve turns a ref (commit, branch, tag, HEAD~3) into a commit hash.
+func (h *jsonlHistory) resolve(ctx context.Context, ref string) (string, error) {
+	if ref == "" || strings.HasPrefix(ref, "-") {
+		return "", fmt.Errorf("invalid ref %q", ref)
+	}
+	out, err := h.git(ctx, "rev-parse", "--verify", "--quiet", ref+"^{commit}")
+	if err != nil {
+		return "", fmt.Errorf("unknown ref %q", ref)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// snapshots reads the JSONL file at each commit with a single git cat-file
+// process. A commit where the file does not exist gets an empty snapshot.
+func (h *jsonlHistory) snapshots(ctx context.Context, commits []string) (map[string]map[string][]byte, error) {
+	result := make(map[string]map[string][]byte, len(commits))
+	if len(commits) == 0 {
+		return result, nil
+	}
+	var input strings.Builder
+	for _, c := range commits {
+		fmt.Fprintf(&input, "%s:%s\n", c, h.path)
+	}
+	// #nosec G204 -- fixed git subcommand
+	cmd := exec.CommandContext(ctx, "git", "-C", h.dir, "cat-file", "--batch")
+	cmd.Stdin = strings.NewReader(input.String())
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("git cat-file: %w", err)
+	}
+
+	r := bufio.NewReader(bytes.NewReader(out))
+	for _, c := range commits {
+		header, err := r.ReadString('\n')
+		if err != nil {
+			return nil, fmt.Errorf("git cat-file: unexpected end of output")
+		}
+		fields := strings.Fields(header)
+		if len(fields) != 3 || fields[1] != "blob" {
+			result[c] = map[string][]byte{} // missing at this commit
+			continue
+		}
+		size, err := strconv.Atoi(fields[2])
+		if err != nil {
+			return nil, fmt.Errorf("git cat-file: bad header %q", strings.TrimSpace(header))
+		}
+		blob := make([]byte, size+1) // Content plus trailing newline
+		if _, err := io.ReadFull(r, blob); err != nil {
+			return nil, fmt.Errorf("git cat-file: %w", err)
+		}
+		result[c] = parseJSONLSnapshot(blob[:size])
+	}
+	return result, nil
+}
+
+// parseJSONLSnapshot maps issue IDs to their JSONL lines. Lines that are not
+// issues (or not JSON, e.g. unresolved conflict markers) are skipped.
+func parseJSONLSnapshot(data []byte) map[string][]byte {
+	lines := map[string][]byte{}
+	for _, line := range bytes.Split(data, []byte("\n")) {
+		line = bytes.TrimSpace(line)
+		if id := jsonlLineID(line); id != "" {
+			lines[id] = line
+		}
+	}
+	return lines
+}
+
+// jsonlLineID returns the issue ID of a trimmed JSONL line, or "" if the
+// line is not an issue.
+func jsonlLineID(line []byte) string {
+	if len(line) == 0 {
+		return ""
+	}
+	var head struct {
+		ID string `json:"id"`
+	}
+	if json.Unmarshal(line, &head) != nil {
+		return ""
+	}
+	return head.ID
+}
+
+// decodeJSONLIssue decodes an exported issue, treating tombstones as absent.
+func decodeJSONLIssue(line []byte) (*types.Issue, error) {
+	if line == nil {
+		return nil, nil
+	}
+	var issue types.Issue
+	if err := json.Unmarshal(line, &issue); err != nil {
+		return nil, err
+	}
+	if issue.IsTombstone() {
+		return nil, nil
+	}
+	return &issue, nil
+}
+
+func lineHash(line []byte) string {
+	sum := sha256.Sum256(line)
+	return hex.EncodeToString(sum[:8])
+}
+
+// changes reads the issues each commit changed with a single git log
+// process, diffing merges against their first parent. A line that only moved
+// within the file is not a change.
+func (h *jsonlHistory) changes(ctx context.Context, commits []string) (map[string]map[string]string, error) {
+	result := make(map[string]map[string]string, len(commits))
+	if len(commits) == 0 {
+		return result, nil
+	}
+	for _, c := range commits {
+		result[c] = map[string]string{}
+	}
+	// #nosec G204 -- fixed git subcommand; commits come from git log
+	cmd := exec.CommandContext(ctx, "git", "-C", h.dir, "log", "--no-walk=unsorted", "--stdin",
+		"-m", "--first-parent", "--patch", "--unified=0", "--no-color", "--no-ext-diff",
+		"--format=%x00%H", "--", ":(top)"+h.path)
+	cmd.Stdin = strings.NewReader(strings.Join(commits, "\n") + "\n")
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("git log: %w", err)
+	}
+
+	var commit string
+	var added, removed map[string]string
+	flush := func() {
+		if result[commit] == nil {
+			return
+		}
+		for id, hash := range added {
+			if removed[id] != hash {
+				result[commit][id] = hash
+			}
+		}
+		for id := range removed {
+			if _, ok := added[id]; !ok {
+				result[commit][id] = ""
+			}
+		}
+	}
+	inHunk := false
+	for _, line := range bytes.Split(out, []byte("\n")) {
+		switch {
+		case len(line) > 0 && line[0] == 0:
+			flush()
+			commit = string(bytes.TrimSpace(line[1:]))
+			added, removed = map[string]string{}, map[string]string{}
+			inHunk = false
+		case bytes.HasPrefix(line, []byte("diff --git ")):
+			inHunk = false
+		case bytes.HasPrefix(line, []byte("@@")):
+			inHunk = true
+		case inHunk && len(line) > 0 && (line[0] == '+' || line[0] == '-'):
+			content := bytes.TrimSpace(line[1:])
+			id := jsonlLineID(content)
+			if id == "" {
+				continue
+			}
+			if line[0] == '+' {
+				added[id] = lineHash(content)
+			} else {
+				removed[id] = lineHash(content)
+			}
+		}
+	}
+	flush()
+	return result, nil
+}
+
+// historyChanges returns the change set of each commit, reading uncached
+// commits from git. It caches the most recent historyCacheLimit commits and
+// drops cache entries for any other commit.
+func (s *SQLiteStorage) historyChanges(ctx context.Context, h *jsonlHistory, commits []jsonlCommit) (map[string]map[string]string, error) {
+	cached, err := s.historyCache(ctx)
+	if err != nil {
+		return nil, err
+	}
+	keep := make(map[string]bool, historyCacheLimit)
+	changes := make(map[string]map[string]string, len(commits))
+	var missing []string
+	for i, c := range commits {
+		if i < historyCacheLimit {
+			keep[c.Hash] = true
+		}
+		var set map[string]string
+		if data, ok := cached[c.Hash]; ok && json.Unmarshal([]byte(data), &set) == nil && set != nil {
+			changes[c.Hash] = set
+			continue
+		}
+		missing = append(missing, c.Hash)
+	}
+
+	read, err := h.changes(ctx, missing)
+	if err != nil {
+		return nil, err
+	}
+	// Caching is best effort: a read-only store just reads git every time
+	for _, hash := range missing {
+		changes[hash] = read[hash]
+		if !keep[hash] {
+			continue
+		}
+		if data, err := json.Marshal(read[hash]); err == nil {
+			_ = s.SetMetadata(ctx, historyCachePrefix+hash, string(data))
+		}
+	}
+	for hash := range cached {
+		if !keep[hash] {
+			_ = s.deleteHistoryCache(ctx, hash)
+		}
+	}
+	return changes, nil
+}
+
+// historyCache returns the cached change sets by commit hash.
+func (s *SQLiteStorage) historyCache(ctx context.Context) (map[string]string, error) {
+	s.checkFreshness()
+	s.reconnectMu.RLock()
+	defer s.reconnectMu.RUnlock()
+
+	rows, err := s.db.QueryContext(ctx, `SELECT key, value FROM metadata WHERE substr(key, 1, ?) = ?`,
+		len(historyCachePrefix), historyCachePrefix)
+	if err != nil {
+		return nil, wrapDBError("read history cache", err)
+	}
+	defer rows.Close()
+	cached := map[string]string{}
+	for rows.Next() {
+		var key, value string
+		if err := rows.Scan(&key, &value); err != nil {
+			return nil, wrapDBError("read history cache", err)
+		}
+		cached[strings.TrimPrefix(key, historyCachePrefix)] = value
+	}
+	return cached, wrapDBError("read history cache", rows.Err())
+}
+
+func (s *SQLiteStorage) deleteHistoryCache(ctx context.Context, hash string) error {
+	s.reconnectMu.RLock()
+	defer s.reconnectMu.RUnlock()
+
+	_, err := s.db.ExecContext(ctx, `DELETE FROM metadata WHERE key = ?`, historyCachePrefix+hash)
+	return wrapDBError("prune history cache", err)
+}
+
+// History returns the versions of an issue recorded in the git history of
+// the JSONL export, most recent first. Only commits that changed the issue
+// are listed; a commit that removed it has a nil Issue.
+func (s *SQLiteStorage) History(ctx context.Context, issueID string) ([]*storage.HistoryEntry, error) {
+	h, err := s.jsonlHistory(ctx)
+	if err != nil {
+		return nil, err
+	}
+	commits, err := h.commits(ctx)
+	if err != nil {
+		return nil, err
+	}
+	changes, err := s.historyChanges(ctx, h, commits)
+	if err != nil {
+		return nil, err
+	}
+
+	// Walk oldest to newest, keeping the commits that changed the issue
+	var changed []jsonlCommit
+	for i := len(commits) - 1; i >= 0; i-- {
+		if _, ok := changes[commits[i].Hash][issueID]; ok {
+			changed = append(changed, commits[i])
+		}
+	}
+
+	hashes := make([]string, len(changed))
+	for i, c := range changed {
+		hashes[i] = c.Hash
+	}
+	snaps, err := h.snapshots(ctx, hashes)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]*storage.HistoryEntry, 0, len(changed))
+	for i := len(changed) - 1; i >= 0; i-- {
+		c := changed[i]
+		issue, err := decodeJSONLIssue(snaps[c.Hash][issueID])
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode %s at %s: %w", issueID, c.Hash[:8], err)
+		}
+		entries = append(entries, &storage.HistoryEntry{
+			CommitHash: c.Hash,
+			Committer:  c.Committer,
+			CommitDate: c.Date,
+			Issue:      issue,
+		})
+	}
+	return entries, nil
+}
+
+// AsOf returns the issue as exported at the given git ref, or nil if it was
+// not in the JSONL file then.
+func (s *SQLiteStorage) AsOf(ctx context.Context, issueID string, ref string) (*types.Issue, error) {
+	h, err := s.jsonlHistory(ctx)
+	if err != nil {
+		return nil, err
+	}
+	commit, err := h.resolve(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	snaps, err := h.snapshots(ctx, []string{commit})
+	if err != nil {
+		return nil, err
+	}
+	issue, err := decodeJSONLIssue(snaps[commit][issueID])
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %s at %s: %w", issueID, ref, err)
+	}
+	return issue, nil
+}
+
+// Diff compares the JSONL export at two git refs.
+func (s *SQLiteStorage) Diff(ctx context.Context, fromRef, toRef string) ([]*storage.DiffEntry, error) {
+	h, err := s.jsonlHistory(ctx)
+	if err != nil {
+		return nil, err
+	}
+	from, err := h.resolve(ctx, fromRef)
+	if err != nil {
+		return nil, err
+	}
+	to, err := h.resolve(ctx, toRef)
+	if err != nil {
+		return nil, err
+	}
+	snaps, err := h.snapshots(ctx, []string{from, to})
+	if err != nil {
+		return nil, err
+	}
+
+	ids := map[string]bool{}
+	for id := range snaps[from] {
+		ids[id] = true
+	}
+	for id := range snaps[to] {
+		ids[id] = true
+	}
+	sorted := make([]string, 0, len(ids))
+	for id := range ids {
+		sorted = append(sorted, id)
+	}
+	sort.Strings(sorted)
+
+	var entries []*storage.DiffEntry
+	for _, id := range sorted {
+		oldLine, newLine := snaps[from][id], snaps[to][id]
+		if bytes.Equal(oldLine, newLine) {
+			continue
+		}
+		oldIssue, err := decodeJSONLIssue(oldLine)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode %s at %s: %w", id, fromRef, err)
+		}
+		newIssue, err := decodeJSONLIssue(newLine)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode %s at %s: %w", id, toRef, err)
+		}
+		entry := &storage.DiffEntry{IssueID: id, OldValue: oldIssue, NewValue: newIssue}
+		switch {
+		case oldIssue == nil && newIssue == nil:
+			continue // Tombstone at both ends
+		case oldIssue == nil:
+			entry.DiffType = "added"
+		case newIssue == nil:
+			entry.DiffType = "removed"
+		default:
+			entry.DiffType = "modified"
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
@@ -0,0 +1,139 @@
+package sqlite
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestJSONLHistory(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+	dir := filepath.Dir(store.dbPath)
+
+	git := func(args ...string) string {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+		cmd.Env = append(os.Environ(), "GIT_AUTHOR_NAME=alice", "GIT_AUTHOR_EMAIL=alice@example.com",
+			"GIT_COMMITTER_NAME=alice", "GIT_COMMITTER_EMAIL=alice@example.com")
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, out)
+		}
+		return strings.TrimSpace(string(out))
+	}
+	commit := func(msg string, lines ...string) string {
+		t.Helper()
+		data := strings.Join(lines, "\n") + "\n"
+		if err := os.WriteFile(filepath.Join(dir, "issues.jsonl"), []byte(data), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		git("add", "issues.jsonl")
+		git("commit", "-q", "-m", msg)
+		return git("rev-parse", "HEAD")
+	}
+
+	git("init", "-q")
+	c1 := commit("one",
+		`{"id":"bd-1","title":"Login bug","status":"open","priority":2}`,
+		`{"id":"bd-2","title":"Docs","status":"open","priority":3}`)
+	c2 := commit("two",
+		`{"id":"bd-1","title":"Login bug","status":"in_progress","priority":1}`,
+		`{"id":"bd-2","title":"Docs","status":"open","priority":3}`)
+	// Unrelated commit: does not touch the JSONL file
+	if err := os.WriteFile(filepath.Join(dir, "README"), []byte("hi\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	git("add", "README")
+	git("commit", "-q", "-m", "readme")
+	// bd-1's line moves without changing, which is not a new version
+	c3 := commit("three",
+		`{"id":"bd-2","title":"Docs","status":"tombstone","priority":3}`,
+		`{"id":"bd-3","title":"New","status":"open","priority":2}`,
+		`{"id":"bd-1","title":"Login bug","status":"in_progress","priority":1}`)
+
+	// Cache entries for commits no longer in the log are pruned
+	if err := store.SetMetadata(ctx, historyCachePrefix+"0123456789abcdef", "{}"); err != nil {
+		t.Fatal(err)
+	}
+
+	history, err := store.History(ctx, "bd-1")
+	if err != nil {
+		t.Fatalf("History failed: %v", err)
+	}
+	if len(history) != 2 || history[0].CommitHash != c2 || history[1].CommitHash != c1 {
+		t.Fatalf("History(bd-1) = %d entries, want commits %s then %s", len(history), c2, c1)
+	}
+	if history[0].Issue.Priority != 1 || history[1].Issue.Priority != 2 || history[0].Committer != "alice" {
+		t.Errorf("History(bd-1) entries have wrong content: %+v %+v", history[0], history[1])
+	}
+
+	// Only the issues a commit changed are cached
+	cached, err := store.historyCache(ctx)
+	if err != nil {
+		t.Fatalf("historyCache failed: %v", err)
+	}
+	if len(cached) != 3 {
+		t.Errorf("cached commits = %v, want %s, %s and %s", cached, c1, c2, c3)
+	}
+	if !strings.Contains(cached[c2], `"bd-1"`) || strings.Contains(cached[c2], `"bd-2"`) {
+		t.Errorf("change set of %s = %s, want only bd-1", c2, cached[c2])
+	}
+	if strings.Contains(cached[c3], `"bd-1"`) || !strings.Contains(cached[c3], `"bd-2"`) {
+		t.Errorf("change set of %s = %s, want bd-2 and bd-3", c3, cached[c3])
+	}
+	// A second query is served from the cache and gives the same answer
+	again, err := store.History(ctx, "bd-2")
+	if err != nil {
+		t.Fatalf("History failed: %v", err)
+	}
+	if len(again) != 2 || again[0].CommitHash != c3 || again[0].Issue != nil {
+		t.Errorf("History(bd-2) should end with the deletion at %s, got %+v", c3, again)
+	}
+
+	issue, err := store.AsOf(ctx, "bd-1", "HEAD~2")
+	if err != nil {
+		t.Fatalf("AsOf failed: %v", err)
+	}
+	if issue == nil || issue.Status != "in_progress" {
+		t.Errorf("AsOf(bd-1, HEAD~2) = %+v, want the in_progress version", issue)
+	}
+	if issue, err := store.AsOf(ctx, "bd-3", c1); err != nil || issue != nil {
+		t.Errorf("AsOf(bd-3, %s) = %v, %v; want nil", c1, issue, err)
+	}
+	if _, err := store.AsOf(ctx, "bd-1", "--output=x"); err == nil {
+		t.Error("expected option-like ref to be rejected")
+	}
+	if _, err := store.AsOf(ctx, "bd-1", "no-such-branch"); err == nil {
+		t.Error("expected unknown ref to be rejected")
+	}
+
+	diff, err := store.Diff(ctx, c1, "HEAD")
+	if err != nil {
+		t.Fatalf("Diff failed: %v", err)
+	}
+	var got []string
+	for _, d := range diff {
+		got = append(got, d.IssueID+":"+d.DiffType)
+	}
+	if want := "bd-1:modified bd-2:removed bd-3:added"; strings.Join(got, " ") != want {
+		t.Errorf("Diff = %v, want %s", got, want)
+	}
+}
+
+func TestJSONLHistoryOutsideGit(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	t.Setenv("GIT_CEILING_DIRECTORIES", filepath.Dir(filepath.Dir(store.dbPath)))
+
+	if _, err := store.History(context.Background(), "bd-1"); err == nil || !strings.Contains(err.Error(), "git repository") {
+		t.Errorf("History outside git = %v, want a git repository error", err)
+	}
+}
@@ -0,0 +1,230 @@
+package sqlite
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// leaseExpiredSQL matches in_progress issues (aliased i) whose claim lease
+// has lapsed; bd ready treats them as open and unassigned.
+const leaseExpiredSQL = "(i.status = 'in_progress' AND i.lease_expires_at IS NOT NULL AND datetime(i.lease_expires_at) <= datetime('now'))"
+
+// ClaimIssueWithLease is ClaimIssue with a claim that lapses at leaseUntil
+// (nil for no lease). An in_progress issue whose lease has lapsed can be
+// claimed again without waiting for the daemon to reclaim it.
+func (s *SQLiteStorage) ClaimIssueWithLease(ctx context.Context, id string, assignee string, leaseUntil *time.Time) (bool, error) {
+	now := time.Now()
+	if leaseUntil != nil {
+		utc := leaseUntil.UTC()
+		leaseUntil = &utc
+	}
+
+	// Atomic claim: succeeds if assignee is empty OR status is not in_progress
+	// OR the current holder's lease has lapsed. This allows claiming open tasks
+	// even with stale assignees.
+	result, err := s.db.ExecContext(ctx, `
+		UPDATE issues
+		SET assignee = ?, status = ?, lease_expires_at = ?, updated_at = ?
+		WHERE id = ? AND (assignee = '' OR assignee IS NULL OR status != 'in_progress'
+			OR (lease_expires_at IS NOT NULL AND datetime(lease_expires_at) <= datetime(?)))
+	`, assignee, string(types.StatusInProgress), leaseUntil, now, id, now.UTC())
+	if err != nil {
+		return false, wrapDBError("claim issue", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, wrapDBError("get rows affected", err)
+	}
+
+	if rowsAffected == 0 {
+		// Either issue doesn't exist or is already claimed
+		// Check which case it is
+		issue, err := s.GetIssue(ctx, id)
+		if err != nil {
+			return false, wrapDBError("check issue exists", err)
+		}
+		if issue == nil {
+			return false, fmt.Errorf("issue %s not found", id)
+		}
+		// Issue exists but already claimed
+		return false, nil
+	}
+
+	// Successfully claimed - record the event
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		// Claim succeeded but event recording failed - not critical
+		return true, nil
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	oldData := fmt.Sprintf(`{"id":"%s","assignee":"","status":"open"}`, id)
+	newValue := map[string]interface{}{"assignee": assignee, "status": types.StatusInProgress}
+	if leaseUntil != nil {
+		newValue["lease_expires_at"] = leaseUntil
+	}
+	newData, _ := json.Marshal(newValue)
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO events (issue_id, event_type, actor, old_value, new_value)
+		VALUES (?, ?, ?, ?, ?)
+	`, id, types.EventClaimed, assignee, oldData, string(newData))
+	if err != nil {
+		// Event recording failed but claim succeeded
+		return true, nil
+	}
+
+	// Mark issue as dirty for incremental export
+	_, _ = tx.ExecContext(ctx, `
+		INSERT INTO dirty_issues (issue_id, marked_at)
+		VALUES (?, ?)
+		ON CONFLICT (issue_id) DO UPDATE SET marked_at = excluded.marked_at
+	`, id, now)
+
+	_ = tx.Commit()
+	return true, nil
+}
+
+// RenewLease moves the lease deadline of an in_progress issue assigned to
+// assignee. Only lease_expires_at changes: the lease is runtime state, so a
+// heartbeat neither counts as an edit (updated_at) nor marks the issue for
+// export.
+func (s *SQLiteStorage) RenewLease(ctx context.Context, id string, assignee string, leaseUntil time.Time) error {
+	result, err := s.db.ExecContext(ctx, `
+		UPDATE issues
+		SET lease_expires_at = ?
+		WHERE id = ? AND status = ? AND assignee = ?
+	`, leaseUntil.UTC(), id, string(types.StatusInProgress), assignee)
+	if err != nil {
+		return wrapDBError("renew lease", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return wrapDBError("get rows affected", err)
+	}
+	if rowsAffected == 0 {
+		issue, err := s.GetIssue(ctx, id)
+		if err != nil {
+			return wrapDBError("check issue exists", err)
+		}
+		return leaseNotHeldError(id, assignee, issue)
+	}
+	return nil
+}
+
+// ReclaimExpiredLeases returns in_progress issues whose lease lapsed at or
+// before now to open and unassigned. Each issue is updated in its own
+// transaction, guarded so that a claim or renewal that raced the scan wins.
+func (s *SQLiteStorage) ReclaimExpiredLeases(ctx context.Context, now time.Time, actor string) ([]*types.Issue, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id FROM issues
+		WHERE status = ? AND lease_expires_at IS NOT NULL
+		  AND datetime(lease_expires_at) <= datetime(?)
+		ORDER BY lease_expires_at, id
+	`, string(types.StatusInProgress), now.UTC())
+	if err != nil {
+		return nil, wrapDBError("find expired leases", err)
+	}
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			_ = rows.Close()
+			return nil, wrapDBError("scan expired lease", err)
+		}
+		ids = append(ids, id)
+	}
+	_ = rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, wrapDBError("find expired leases", err)
+	}
+
+	var reclaimed []*types.Issue
+	for _, id := range ids {
+		issue, err := s.reclaimLease(ctx, id, now, actor)
+		if err != nil {
+			return reclaimed, err
+		}
+		if issue != nil {
+			reclaimed = append(reclaimed, issue)
+		}
+	}
+	return reclaimed, nil
+}
+
+// reclaimLease reopens one issue if its lease is still lapsed. It returns
+// nil if the issue was claimed or renewed in the meantime.
+func (s *SQLiteStorage) reclaimLease(ctx context.Context, id string, now time.Time, actor string) (*types.Issue, error) {
+	issue, err := s.GetIssue(ctx, id)
+	if err != nil {
+		return nil, wrapDBError("get issue for reclaim", err)
+	}
+	if issue == nil || !issue.LeaseExpired(now) {
+		return nil, nil
+	}
+	oldValue, _ := json.Marshal(map[string]interface{}{
+		"assignee":         issue.Assignee,
+		"status":           issue.Status,
+		"lease_expires_at": issue.LeaseExpiresAt,
+	})
+
+	reopened := *issue
+	reopened.Status = types.StatusOpen
+	reopened.Assignee = ""
+	reopened.LeaseExpiresAt = nil
+	reopened.UpdatedAt = time.Now()
+	reopened.ContentHash = reopened.ComputeContentHash()
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	result, err := tx.ExecContext(ctx, `
+		UPDATE issues
+		SET status = ?, assignee = '', lease_expires_at = NULL, content_hash = ?, updated_at = ?
+		WHERE id = ? AND status = ? AND assignee = ?
+		  AND lease_expires_at IS NOT NULL AND datetime(lease_expires_at) <= datetime(?)
+	`, string(types.StatusOpen), reopened.ContentHash, reopened.UpdatedAt,
+		id, string(types.StatusInProgress), issue.Assignee, now.UTC())
+	if err != nil {
+		return nil, wrapDBError("reclaim lease", err)
+	}
+	if n, err := result.RowsAffected(); err != nil || n == 0 {
+		return nil, err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO events (issue_id, event_type, actor, old_value, new_value)
+		VALUES (?, ?, ?, ?, ?)
+	`, id, types.EventLeaseExpired, actor, string(oldValue), `{"status":"open"}`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to record event: %w", err)
+	}
+	if err := markIssuesDirtyTx(ctx, tx, []string{id}); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit reclaim: %w", err)
+	}
+	return &reopened, nil
+}
+
+// leaseNotHeldError explains why a lease can't be renewed. issue is nil if
+// it doesn't exist.
+func leaseNotHeldError(id, assignee string, issue *types.Issue) error {
+	switch {
+	case issue == nil:
+		return fmt.Errorf("issue %s not found", id)
+	case issue.Status != types.StatusInProgress:
+		return fmt.Errorf("issue %s is %s, not claimed (claim it again with bd update --claim)", id, issue.Status)
+	default:
+		return fmt.Errorf("issue %s is claimed by %s, not %s", id, issue.Assignee, assignee)
+	}
+}
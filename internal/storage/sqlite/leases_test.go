@@ -0,0 +1,254 @@
+package sqlite
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func createOpenIssue(t *testing.T, store *SQLiteStorage, title string) *types.Issue {
+	t.Helper()
+	issue := &types.Issue{Title: title, Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := store.CreateIssue(context.Background(), issue, "test-user"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	return issue
+}
+
+func TestClaimIssueWithLease(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+	issue := createOpenIssue(t, store, "Leased task")
+
+	lease := time.Now().Add(30 * time.Minute)
+	claimed, err := store.ClaimIssueWithLease(ctx, issue.ID, "agent-1", &lease)
+	if err != nil || !claimed {
+		t.Fatalf("ClaimIssueWithLease = %v, %v; want claimed", claimed, err)
+	}
+	got, err := store.GetIssue(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if got.LeaseExpiresAt == nil || got.LeaseExpiresAt.Sub(lease).Abs() > time.Second {
+		t.Errorf("LeaseExpiresAt = %v, want %v", got.LeaseExpiresAt, lease)
+	}
+
+	// A live lease blocks other agents
+	claimed, err = store.ClaimIssueWithLease(ctx, issue.ID, "agent-2", nil)
+	if err != nil || claimed {
+		t.Fatalf("second claim = %v, %v; want refused", claimed, err)
+	}
+
+	// A lapsed lease doesn't, and a claim without a lease clears it
+	if err := store.UpdateIssue(ctx, issue.ID, map[string]interface{}{"lease_expires_at": time.Now().Add(-time.Minute)}, "test"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	claimed, err = store.ClaimIssueWithLease(ctx, issue.ID, "agent-2", nil)
+	if err != nil || !claimed {
+		t.Fatalf("claim over lapsed lease = %v, %v; want claimed", claimed, err)
+	}
+	got, _ = store.GetIssue(ctx, issue.ID)
+	if got.Assignee != "agent-2" || got.LeaseExpiresAt != nil {
+		t.Errorf("after re-claim: assignee %q, lease %v", got.Assignee, got.LeaseExpiresAt)
+	}
+}
+
+func TestManualUpdateClearsLease(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+	issue := createOpenIssue(t, store, "Leased task")
+	lease := time.Now().Add(30 * time.Minute)
+	leaseOf := func() *time.Time {
+		t.Helper()
+		got, err := store.GetIssue(ctx, issue.ID)
+		if err != nil {
+			t.Fatalf("GetIssue failed: %v", err)
+		}
+		return got.LeaseExpiresAt
+	}
+
+	if claimed, err := store.ClaimIssueWithLease(ctx, issue.ID, "agent-1", &lease); err != nil || !claimed {
+		t.Fatalf("ClaimIssueWithLease = %v, %v; want claimed", claimed, err)
+	}
+	// Updates that keep the claim keep the lease
+	if err := store.UpdateIssue(ctx, issue.ID, map[string]interface{}{"priority": 1, "assignee": "agent-1"}, "agent-1"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	if leaseOf() == nil {
+		t.Fatal("lease cleared by an update that kept the claim")
+	}
+
+	// Handing the issue to someone else ends agent-1's lease, so the reaper
+	// can't take it back from agent-2 later
+	if err := store.UpdateIssue(ctx, issue.ID, map[string]interface{}{"assignee": "agent-2"}, "lead"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	if got := leaseOf(); got != nil {
+		t.Errorf("lease after reassignment = %v, want none", got)
+	}
+	if reclaimed, err := store.ReclaimExpiredLeases(ctx, lease.Add(time.Hour), "daemon"); err != nil || len(reclaimed) != 0 {
+		t.Errorf("ReclaimExpiredLeases = %v, %v; want nothing reclaimed", reclaimed, err)
+	}
+
+	// So does a status change
+	if err := store.RenewLease(ctx, issue.ID, "agent-2", lease); err != nil {
+		t.Fatalf("RenewLease failed: %v", err)
+	}
+	if err := store.UpdateIssue(ctx, issue.ID, map[string]interface{}{"status": string(types.StatusBlocked)}, "agent-2"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	if got := leaseOf(); got != nil {
+		t.Errorf("lease after status change = %v, want none", got)
+	}
+}
+
+func TestTransactionUpdateClearsLease(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+	issue := createOpenIssue(t, store, "Leased task")
+	lease := time.Now().Add(30 * time.Minute)
+	if claimed, err := store.ClaimIssueWithLease(ctx, issue.ID, "agent-1", &lease); err != nil || !claimed {
+		t.Fatalf("ClaimIssueWithLease = %v, %v; want claimed", claimed, err)
+	}
+
+	// Importers and other transaction users reassign through the same rules
+	err := store.RunInTransaction(ctx, func(tx storage.Transaction) error {
+		return tx.UpdateIssue(ctx, issue.ID, map[string]interface{}{"assignee": "agent-2"}, "lead")
+	})
+	if err != nil {
+		t.Fatalf("RunInTransaction failed: %v", err)
+	}
+	got, err := store.GetIssue(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if got.LeaseExpiresAt != nil {
+		t.Errorf("lease after reassignment in a transaction = %v, want none", got.LeaseExpiresAt)
+	}
+}
+
+func TestRenewLease(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+	issue := createOpenIssue(t, store, "Leased task")
+
+	lease := time.Now().Add(time.Minute)
+	if _, err := store.ClaimIssueWithLease(ctx, issue.ID, "agent-1", &lease); err != nil {
+		t.Fatalf("ClaimIssueWithLease failed: %v", err)
+	}
+
+	claimed, _ := store.GetIssue(ctx, issue.ID)
+	if err := store.ClearDirtyIssuesByID(ctx, []string{issue.ID}); err != nil {
+		t.Fatalf("ClearDirtyIssuesByID failed: %v", err)
+	}
+
+	renewed := time.Now().Add(time.Hour)
+	if err := store.RenewLease(ctx, issue.ID, "agent-1", renewed); err != nil {
+		t.Fatalf("RenewLease failed: %v", err)
+	}
+	got, _ := store.GetIssue(ctx, issue.ID)
+	if got.LeaseExpiresAt == nil || got.LeaseExpiresAt.Before(lease.Add(30*time.Minute)) {
+		t.Errorf("LeaseExpiresAt = %v, want about %v", got.LeaseExpiresAt, renewed)
+	}
+
+	// A renewal is runtime state, not an edit to export
+	if !got.UpdatedAt.Equal(claimed.UpdatedAt) {
+		t.Errorf("RenewLease moved UpdatedAt from %v to %v", claimed.UpdatedAt, got.UpdatedAt)
+	}
+	dirty, err := store.GetDirtyIssues(ctx)
+	if err != nil {
+		t.Fatalf("GetDirtyIssues failed: %v", err)
+	}
+	if len(dirty) != 0 {
+		t.Errorf("RenewLease marked %v dirty, want none", dirty)
+	}
+
+	err = store.RenewLease(ctx, issue.ID, "agent-2", renewed)
+	if err == nil || !strings.Contains(err.Error(), "claimed by agent-1") {
+		t.Errorf("RenewLease by another agent = %v, want claimed-by error", err)
+	}
+	other := createOpenIssue(t, store, "Not claimed")
+	if err := store.RenewLease(ctx, other.ID, "agent-1", renewed); err == nil || !strings.Contains(err.Error(), "not claimed") {
+		t.Errorf("RenewLease on open issue = %v, want not-claimed error", err)
+	}
+	if err := store.RenewLease(ctx, "bd-missing", "agent-1", renewed); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("RenewLease on missing issue = %v, want not-found error", err)
+	}
+}
+
+func TestReclaimExpiredLeases(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	expired := createOpenIssue(t, store, "Abandoned")
+	live := createOpenIssue(t, store, "Still working")
+	forever := createOpenIssue(t, store, "No lease")
+	past := time.Now().Add(-time.Minute)
+	future := time.Now().Add(time.Hour)
+	for _, c := range []struct {
+		id    string
+		lease *time.Time
+	}{{expired.ID, &past}, {live.ID, &future}, {forever.ID, nil}} {
+		if _, err := store.ClaimIssueWithLease(ctx, c.id, "agent-1", c.lease); err != nil {
+			t.Fatalf("ClaimIssueWithLease failed: %v", err)
+		}
+	}
+
+	// bd ready already offers the expired claim before the reaper runs
+	ready, err := store.GetReadyWork(ctx, types.WorkFilter{Status: types.StatusOpen, Unassigned: true})
+	if err != nil {
+		t.Fatalf("GetReadyWork failed: %v", err)
+	}
+	if len(ready) != 1 || ready[0].ID != expired.ID {
+		t.Fatalf("ready = %v, want only %s", issueIDs(ready), expired.ID)
+	}
+
+	reclaimed, err := store.ReclaimExpiredLeases(ctx, time.Now(), "daemon")
+	if err != nil {
+		t.Fatalf("ReclaimExpiredLeases failed: %v", err)
+	}
+	if len(reclaimed) != 1 || reclaimed[0].ID != expired.ID {
+		t.Fatalf("reclaimed = %v, want only %s", issueIDs(reclaimed), expired.ID)
+	}
+
+	got, _ := store.GetIssue(ctx, expired.ID)
+	if got.Status != types.StatusOpen || got.Assignee != "" || got.LeaseExpiresAt != nil {
+		t.Errorf("reclaimed issue: status %s, assignee %q, lease %v", got.Status, got.Assignee, got.LeaseExpiresAt)
+	}
+	if got.ContentHash != got.ComputeContentHash() {
+		t.Error("reclaimed issue has a stale content hash")
+	}
+	events, err := store.GetEvents(ctx, expired.ID, 0)
+	if err != nil {
+		t.Fatalf("GetEvents failed: %v", err)
+	}
+	found := false
+	for _, e := range events {
+		found = found || (e.EventType == types.EventLeaseExpired && e.Actor == "daemon")
+	}
+	if !found {
+		t.Error("expected a lease_expired event by daemon")
+	}
+
+	// Nothing left to reclaim
+	if again, err := store.ReclaimExpiredLeases(ctx, time.Now(), "daemon"); err != nil || len(again) != 0 {
+		t.Errorf("second ReclaimExpiredLeases = %v, %v", issueIDs(again), err)
+	}
+}
+
+func issueIDs(issues []*types.Issue) []string {
+	ids := make([]string, len(issues))
+	for i, issue := range issues {
+		ids[i] = issue.ID
+	}
+	return ids
+}
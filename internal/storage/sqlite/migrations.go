@@ -57,6 +57,12 @@ var migrationsList = []Migration{
 	{"work_type_column", migrations.MigrateWorkTypeColumn},
 	{"source_system_column", migrations.MigrateSourceSystemColumn},
 	{"quality_score_column", migrations.MigrateQualityScoreColumn},
+	{"search_index", migrations.MigrateSearchIndex},
+	{"work_log_table", migrations.MigrateWorkLogTable},
+	{"recurrence_column", migrations.MigrateRecurrenceColumn},
+	{"attachments_table", migrations.MigrateAttachmentsTable},
+	{"custom_fields_column", migrations.MigrateCustomFieldsColumn},
+	{"lease_column", migrations.MigrateLeaseColumn},
 }
 
 // MigrationInfo contains metadata about a migration for inspection
@@ -121,6 +127,12 @@ func getMigrationDescription(name string) string {
 		"work_type_column":             "Adds work_type column for work assignment model (mutex vs open_competition per Decision 006)",
 		"source_system_column":         "Adds source_system column for federation adapter tracking",
 		"quality_score_column":         "Adds quality_score column for aggregate quality (0.0-1.0) set by Refineries",
+		"search_index":                 "Adds issues_fts FTS5 table, keyed by issue ID, and sync triggers for ranked full-text search",
+		"work_log_table":               "Adds work_log table for time tracking (bd time)",
+		"recurrence_column":            "Adds recurrence column for recurring issues (bd create --recur)",
+		"attachments_table":            "Adds attachments table for file attachment metadata (bd attach)",
+		"custom_fields_column":         "Adds custom_fields column for typed custom field values (bd create --field)",
+		"lease_column":                 "Adds lease_expires_at column for leased claims (bd update --claim --lease)",
 	}
 
 	if desc, ok := descriptions[name]; ok {
@@ -0,0 +1,145 @@
+package migrations
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// searchIndexDocs gives each issue a stable FTS rowid. The implicit
+// issues.rowid isn't one: VACUUM and table rebuilds can renumber it, which
+// would leave the index pointing at the wrong issue. docid is an explicit
+// INTEGER PRIMARY KEY, so it keeps its value.
+const searchIndexDocs = `
+	CREATE TABLE issues_fts_ids (
+		docid INTEGER PRIMARY KEY,
+		id TEXT NOT NULL UNIQUE
+	)`
+
+// searchIndexTriggers keep issues_fts in sync with issues and comments.
+// FTS rows are found through issues_fts_ids by issue ID, so updates and
+// deletes are O(log n); comment triggers refresh the aggregated comments
+// column of the parent issue.
+var searchIndexTriggers = map[string]string{
+	"issues_fts_ai": `
+		CREATE TRIGGER issues_fts_ai AFTER INSERT ON issues BEGIN
+			INSERT OR IGNORE INTO issues_fts_ids(id) VALUES (NEW.id);
+			INSERT INTO issues_fts(rowid, id, title, description, design, acceptance_criteria, notes, comments)
+			VALUES ((SELECT docid FROM issues_fts_ids WHERE id = NEW.id),
+			        NEW.id, NEW.title, NEW.description, NEW.design, NEW.acceptance_criteria, NEW.notes,
+			        COALESCE((SELECT group_concat(text, ' ') FROM comments WHERE issue_id = NEW.id), ''));
+		END`,
+	"issues_fts_ad": `
+		CREATE TRIGGER issues_fts_ad AFTER DELETE ON issues BEGIN
+			DELETE FROM issues_fts WHERE rowid = (SELECT docid FROM issues_fts_ids WHERE id = OLD.id);
+			DELETE FROM issues_fts_ids WHERE id = OLD.id;
+		END`,
+	"issues_fts_au": `
+		CREATE TRIGGER issues_fts_au AFTER UPDATE OF id, title, description, design, acceptance_criteria, notes ON issues BEGIN
+			DELETE FROM issues_fts WHERE rowid = (SELECT docid FROM issues_fts_ids WHERE id = OLD.id);
+			UPDATE issues_fts_ids SET id = NEW.id WHERE id = OLD.id;
+			INSERT OR IGNORE INTO issues_fts_ids(id) VALUES (NEW.id);
+			INSERT INTO issues_fts(rowid, id, title, description, design, acceptance_criteria, notes, comments)
+			VALUES ((SELECT docid FROM issues_fts_ids WHERE id = NEW.id),
+			        NEW.id, NEW.title, NEW.description, NEW.design, NEW.acceptance_criteria, NEW.notes,
+			        COALESCE((SELECT group_concat(text, ' ') FROM comments WHERE issue_id = NEW.id), ''));
+		END`,
+	"comments_fts_ai": `
+		CREATE TRIGGER comments_fts_ai AFTER INSERT ON comments BEGIN
+			UPDATE issues_fts
+			SET comments = COALESCE((SELECT group_concat(text, ' ') FROM comments WHERE issue_id = NEW.issue_id), '')
+			WHERE rowid = (SELECT docid FROM issues_fts_ids WHERE id = NEW.issue_id);
+		END`,
+	"comments_fts_ad": `
+		CREATE TRIGGER comments_fts_ad AFTER DELETE ON comments BEGIN
+			UPDATE issues_fts
+			SET comments = COALESCE((SELECT group_concat(text, ' ') FROM comments WHERE issue_id = OLD.issue_id), '')
+			WHERE rowid = (SELECT docid FROM issues_fts_ids WHERE id = OLD.issue_id);
+		END`,
+	"comments_fts_au": `
+		CREATE TRIGGER comments_fts_au AFTER UPDATE OF text, issue_id ON comments BEGIN
+			UPDATE issues_fts
+			SET comments = COALESCE((SELECT group_concat(text, ' ') FROM comments WHERE issue_id = OLD.issue_id), '')
+			WHERE rowid = (SELECT docid FROM issues_fts_ids WHERE id = OLD.issue_id);
+			UPDATE issues_fts
+			SET comments = COALESCE((SELECT group_concat(text, ' ') FROM comments WHERE issue_id = NEW.issue_id), '')
+			WHERE rowid = (SELECT docid FROM issues_fts_ids WHERE id = NEW.issue_id);
+		END`,
+}
+
+// MigrateSearchIndex creates the issues_fts FTS5 virtual table used by
+// bd search for BM25-ranked full-text queries, plus the triggers that keep it
+// in sync with issues and comments.
+//
+// Table rebuilds in later migrations drop the triggers along with the old
+// issues table, so this migration re-checks the triggers on every run and
+// rebuilds the index whenever any are missing.
+func MigrateSearchIndex(db *sql.DB) error {
+	var tableName string
+	err := db.QueryRow(`
+		SELECT name FROM sqlite_master
+		WHERE type='table' AND name='issues_fts'
+	`).Scan(&tableName)
+
+	rebuild := false
+	if err == sql.ErrNoRows {
+		_, err := db.Exec(`
+			CREATE VIRTUAL TABLE issues_fts USING fts5(
+				id, title, description, design, acceptance_criteria, notes, comments,
+				tokenize = 'unicode61'
+			)
+		`)
+		if err != nil {
+			return fmt.Errorf("failed to create issues_fts table: %w", err)
+		}
+		if _, err := db.Exec(searchIndexDocs); err != nil {
+			return fmt.Errorf("failed to create issues_fts_ids table: %w", err)
+		}
+		rebuild = true
+	} else if err != nil {
+		return fmt.Errorf("failed to check for issues_fts table: %w", err)
+	}
+
+	for name, ddl := range searchIndexTriggers {
+		var exists bool
+		err := db.QueryRow(`
+			SELECT COUNT(*) > 0 FROM sqlite_master
+			WHERE type='trigger' AND name=?
+		`, name).Scan(&exists)
+		if err != nil {
+			return fmt.Errorf("failed to check for %s trigger: %w", name, err)
+		}
+		if exists {
+			continue
+		}
+		if _, err := db.Exec(ddl); err != nil {
+			return fmt.Errorf("failed to create %s trigger: %w", name, err)
+		}
+		rebuild = true
+	}
+
+	if !rebuild {
+		return nil
+	}
+
+	if _, err := db.Exec(`DELETE FROM issues_fts`); err != nil {
+		return fmt.Errorf("failed to clear issues_fts: %w", err)
+	}
+	if _, err := db.Exec(`DELETE FROM issues_fts_ids`); err != nil {
+		return fmt.Errorf("failed to clear issues_fts_ids: %w", err)
+	}
+	if _, err := db.Exec(`INSERT INTO issues_fts_ids(id) SELECT id FROM issues`); err != nil {
+		return fmt.Errorf("failed to populate issues_fts_ids: %w", err)
+	}
+	_, err = db.Exec(`
+		INSERT INTO issues_fts(rowid, id, title, description, design, acceptance_criteria, notes, comments)
+		SELECT m.docid, i.id, i.title, i.description, i.design, i.acceptance_criteria, i.notes,
+		       COALESCE((SELECT group_concat(c.text, ' ') FROM comments c WHERE c.issue_id = i.id), '')
+		FROM issues i
+		JOIN issues_fts_ids m ON m.id = i.id
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to populate issues_fts: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,43 @@
+package migrations
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// MigrateWorkLogTable adds the work_log table used by "bd time".
+func MigrateWorkLogTable(db *sql.DB) error {
+	var tableName string
+	err := db.QueryRow(`
+		SELECT name FROM sqlite_master
+		WHERE type='table' AND name='work_log'
+	`).Scan(&tableName)
+
+	if err == sql.ErrNoRows {
+		_, err := db.Exec(`
+			CREATE TABLE work_log (
+				id TEXT PRIMARY KEY,
+				issue_id TEXT NOT NULL,
+				actor TEXT NOT NULL,
+				minutes INTEGER NOT NULL DEFAULT 0,
+				started_at DATETIME NOT NULL,
+				ended_at DATETIME,
+				note TEXT NOT NULL DEFAULT '',
+				created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+				FOREIGN KEY (issue_id) REFERENCES issues(id) ON DELETE CASCADE
+			);
+			CREATE INDEX idx_work_log_issue ON work_log(issue_id);
+			CREATE INDEX idx_work_log_started_at ON work_log(started_at);
+		`)
+		if err != nil {
+			return fmt.Errorf("failed to create work_log table: %w", err)
+		}
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to check for work_log table: %w", err)
+	}
+
+	return nil
+}
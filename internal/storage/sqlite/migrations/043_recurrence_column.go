@@ -0,0 +1,33 @@
+package migrations
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// MigrateRecurrenceColumn adds the recurrence column to the issues table.
+// It holds the schedule ("every monday 09:00" or a cron expression) used to
+// spawn the next occurrence when a recurring issue is closed.
+func MigrateRecurrenceColumn(db *sql.DB) error {
+	// Check if column already exists
+	var columnExists bool
+	err := db.QueryRow(`
+		SELECT COUNT(*) > 0
+		FROM pragma_table_info('issues')
+		WHERE name = 'recurrence'
+	`).Scan(&columnExists)
+	if err != nil {
+		return fmt.Errorf("failed to check recurrence column: %w", err)
+	}
+
+	if columnExists {
+		return nil
+	}
+
+	_, err = db.Exec(`ALTER TABLE issues ADD COLUMN recurrence TEXT DEFAULT ''`)
+	if err != nil {
+		return fmt.Errorf("failed to add recurrence column: %w", err)
+	}
+
+	return nil
+}
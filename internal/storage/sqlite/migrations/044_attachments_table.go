@@ -0,0 +1,44 @@
+package migrations
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// MigrateAttachmentsTable adds the attachments table used by "bd attach".
+func MigrateAttachmentsTable(db *sql.DB) error {
+	var tableName string
+	err := db.QueryRow(`
+		SELECT name FROM sqlite_master
+		WHERE type='table' AND name='attachments'
+	`).Scan(&tableName)
+
+	if err == sql.ErrNoRows {
+		_, err := db.Exec(`
+			CREATE TABLE attachments (
+				id TEXT PRIMARY KEY,
+				issue_id TEXT NOT NULL,
+				name TEXT NOT NULL,
+				sha256 TEXT NOT NULL,
+				size INTEGER NOT NULL DEFAULT 0,
+				media_type TEXT NOT NULL DEFAULT '',
+				created_by TEXT NOT NULL DEFAULT '',
+				created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+				detached_at DATETIME,
+				FOREIGN KEY (issue_id) REFERENCES issues(id) ON DELETE CASCADE
+			);
+			CREATE INDEX idx_attachments_issue ON attachments(issue_id);
+			CREATE INDEX idx_attachments_sha256 ON attachments(sha256);
+		`)
+		if err != nil {
+			return fmt.Errorf("failed to create attachments table: %w", err)
+		}
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to check for attachments table: %w", err)
+	}
+
+	return nil
+}
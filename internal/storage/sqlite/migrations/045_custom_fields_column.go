@@ -0,0 +1,33 @@
+package migrations
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// MigrateCustomFieldsColumn adds the custom_fields column to the issues table.
+// It holds the issue's custom field values as a JSON object keyed by field
+// name; the fields themselves are defined under custom_fields in config.yaml.
+func MigrateCustomFieldsColumn(db *sql.DB) error {
+	// Check if column already exists
+	var columnExists bool
+	err := db.QueryRow(`
+		SELECT COUNT(*) > 0
+		FROM pragma_table_info('issues')
+		WHERE name = 'custom_fields'
+	`).Scan(&columnExists)
+	if err != nil {
+		return fmt.Errorf("failed to check custom_fields column: %w", err)
+	}
+
+	if columnExists {
+		return nil
+	}
+
+	_, err = db.Exec(`ALTER TABLE issues ADD COLUMN custom_fields TEXT DEFAULT ''`)
+	if err != nil {
+		return fmt.Errorf("failed to add custom_fields column: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,39 @@
+package migrations
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// MigrateLeaseColumn adds the lease_expires_at column to the issues table.
+// It holds the deadline of a leased claim (bd update --claim --lease); the
+// daemon returns in-progress issues past their deadline to open.
+func MigrateLeaseColumn(db *sql.DB) error {
+	// Check if column already exists
+	var columnExists bool
+	err := db.QueryRow(`
+		SELECT COUNT(*) > 0
+		FROM pragma_table_info('issues')
+		WHERE name = 'lease_expires_at'
+	`).Scan(&columnExists)
+	if err != nil {
+		return fmt.Errorf("failed to check lease_expires_at column: %w", err)
+	}
+
+	if columnExists {
+		return nil
+	}
+
+	_, err = db.Exec(`ALTER TABLE issues ADD COLUMN lease_expires_at DATETIME`)
+	if err != nil {
+		return fmt.Errorf("failed to add lease_expires_at column: %w", err)
+	}
+
+	// Partial index for the reaper's scan of leased claims
+	_, err = db.Exec(`CREATE INDEX IF NOT EXISTS idx_issues_lease ON issues(lease_expires_at) WHERE lease_expires_at IS NOT NULL`)
+	if err != nil {
+		return fmt.Errorf("failed to create lease index: %w", err)
+	}
+
+	return nil
+}
@@ -415,12 +415,34 @@ func (s *SQLiteStorage) upsertIssueInTx(ctx context.Context, tx *sql.Tx, issue *
 		}
 	}
 
+	// Import work log if present
+	for _, entry := range issue.WorkLog {
+		_, err = tx.ExecContext(ctx, `
+			INSERT OR IGNORE INTO work_log (id, issue_id, actor, minutes, started_at, ended_at, note, created_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		`, entry.ID, issue.ID, entry.Actor, entry.Minutes, entry.StartedAt, entry.EndedAt, entry.Note, entry.CreatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to import work log entry: %w", err)
+		}
+	}
+
+	// Import attachments if present
+	for _, a := range issue.Attachments {
+		_, err = tx.ExecContext(ctx, `
+			INSERT OR IGNORE INTO attachments (id, issue_id, name, sha256, size, media_type, created_by, created_at, detached_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+		`, a.ID, issue.ID, a.Name, a.SHA256, a.Size, a.MediaType, a.CreatedBy, a.CreatedAt, a.DetachedAt)
+		if err != nil {
+			return fmt.Errorf("failed to import attachment: %w", err)
+		}
+	}
+
 	return nil
 }
 
 // DeleteIssuesBySourceRepo permanently removes all issues from a specific source repository.
 // This is used when a repo is removed from the multi-repo configuration.
-// It also cleans up related data: dependencies, labels, comments, events, and dirty markers.
+// It also cleans up related data: dependencies, labels, comments, work log, attachments, events, and dirty markers.
 // Returns the number of issues deleted.
 func (s *SQLiteStorage) DeleteIssuesBySourceRepo(ctx context.Context, sourceRepo string) (int, error) {
 	tx, err := s.db.BeginTx(ctx, nil)
@@ -479,6 +501,22 @@ func (s *SQLiteStorage) DeleteIssuesBySourceRepo(ctx context.Context, sourceRepo
 		}
 	}
 
+	// Delete work log for all affected issues
+	for _, id := range issueIDs {
+		_, err = tx.ExecContext(ctx, `DELETE FROM work_log WHERE issue_id = ?`, id)
+		if err != nil {
+			return 0, fmt.Errorf("failed to delete work log for %s: %w", id, err)
+		}
+	}
+
+	// Delete attachments for all affected issues
+	for _, id := range issueIDs {
+		_, err = tx.ExecContext(ctx, `DELETE FROM attachments WHERE issue_id = ?`, id)
+		if err != nil {
+			return 0, fmt.Errorf("failed to delete attachments for %s: %w", id, err)
+		}
+	}
+
 	// Delete labels for all affected issues
 	for _, id := range issueIDs {
 		_, err = tx.ExecContext(ctx, `DELETE FROM labels WHERE issue_id = ?`, id)
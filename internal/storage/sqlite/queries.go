@@ -5,10 +5,13 @@ import (
 	"database/sql"
 	"encoding/json"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/steveyegge/beads/internal/query"
 	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/validation"
 )
 
 // NOTE: createGraphEdgesFromIssueFields and createGraphEdgesFromUpdates removed
@@ -75,6 +78,32 @@ func formatJSONStringArray(arr []string) string {
 	return string(data)
 }
 
+// parseJSONStringMap parses a JSON object of strings from a database TEXT column.
+// Returns nil if the string is empty or invalid JSON.
+func parseJSONStringMap(s string) map[string]string {
+	if s == "" {
+		return nil
+	}
+	var result map[string]string
+	if err := json.Unmarshal([]byte(s), &result); err != nil || len(result) == 0 {
+		return nil
+	}
+	return result
+}
+
+// formatJSONStringMap formats a string map as JSON for database storage.
+// Returns empty string if the map is nil or empty.
+func formatJSONStringMap(m map[string]string) string {
+	if len(m) == 0 {
+		return ""
+	}
+	data, err := json.Marshal(m) // json.Marshal sorts keys, keeping the column stable
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
 // REMOVED: getNextIDForPrefix and AllocateNextID - sequential ID generation
 // no longer needed with hash-based IDs
 // Migration functions moved to migrations.go
@@ -254,6 +283,12 @@ func (s *SQLiteStorage) CreateIssue(ctx context.Context, issue *types.Issue, act
 			if _, err := conn.ExecContext(ctx, `DELETE FROM comments WHERE issue_id = ?`, issue.ID); err != nil {
 				return fmt.Errorf("failed to delete tombstone comments: %w", err)
 			}
+			if _, err := conn.ExecContext(ctx, `DELETE FROM work_log WHERE issue_id = ?`, issue.ID); err != nil {
+				return fmt.Errorf("failed to delete tombstone work log: %w", err)
+			}
+			if _, err := conn.ExecContext(ctx, `DELETE FROM attachments WHERE issue_id = ?`, issue.ID); err != nil {
+				return fmt.Errorf("failed to delete tombstone attachments: %w", err)
+			}
 			if _, err := conn.ExecContext(ctx, `DELETE FROM dirty_issues WHERE issue_id = ?`, issue.ID); err != nil {
 				return fmt.Errorf("failed to delete tombstone dirty marker: %w", err)
 			}
@@ -353,6 +388,9 @@ func (s *SQLiteStorage) GetIssue(ctx context.Context, id string) (*types.Issue,
 	// Time-based scheduling fields (GH#820)
 	var dueAt sql.NullTime
 	var deferUntil sql.NullTime
+	var recurrence sql.NullString
+	var customFields sql.NullString
+	var leaseExpiresAt sql.NullTime
 
 	var contentHash sql.NullString
 	var compactedAtCommit sql.NullString
@@ -367,7 +405,7 @@ func (s *SQLiteStorage) GetIssue(ctx context.Context, id string) (*types.Issue,
 		       await_type, await_id, timeout_ns, waiters,
 		       hook_bead, role_bead, agent_state, last_activity, role_type, rig, mol_type,
 		       event_kind, actor, target, payload,
-		       due_at, defer_until
+		       due_at, defer_until, recurrence, custom_fields, lease_expires_at
 		FROM issues
 		WHERE id = ?
 	`, id).Scan(
@@ -381,7 +419,7 @@ func (s *SQLiteStorage) GetIssue(ctx context.Context, id string) (*types.Issue,
 		&awaitType, &awaitID, &timeoutNs, &waiters,
 		&hookBead, &roleBead, &agentState, &lastActivity, &roleType, &rig, &molType,
 		&eventKind, &actor, &target, &payload,
-		&dueAt, &deferUntil,
+		&dueAt, &deferUntil, &recurrence, &customFields, &leaseExpiresAt,
 	)
 
 	if err == sql.ErrNoRows {
@@ -518,6 +556,15 @@ func (s *SQLiteStorage) GetIssue(ctx context.Context, id string) (*types.Issue,
 	if deferUntil.Valid {
 		issue.DeferUntil = &deferUntil.Time
 	}
+	if recurrence.Valid {
+		issue.Recurrence = recurrence.String
+	}
+	if customFields.Valid {
+		issue.CustomFields = parseJSONStringMap(customFields.String)
+	}
+	if leaseExpiresAt.Valid {
+		issue.LeaseExpiresAt = &leaseExpiresAt.Time
+	}
 
 	// Fetch labels for this issue
 	labels, err := s.GetLabels(ctx, issue.ID)
@@ -802,8 +849,14 @@ var allowedUpdateFields = map[string]bool{
 	// Time-based scheduling fields (GH#820)
 	"due_at":      true,
 	"defer_until": true,
+	"recurrence":  true,
+	// Custom fields (whole map, JSON-encoded on write)
+	"custom_fields": true,
+	// Claim lease deadline (bd update --claim --lease, bd heartbeat)
+	"lease_expires_at": true,
 	// Gate fields (bd-z6kw: support await_id updates for gate discovery)
 	"await_id": true,
+	"waiters":  true, // JSON-encoded on write
 }
 
 // validatePriority validates a priority value
@@ -875,73 +928,45 @@ func manageClosedAt(oldIssue *types.Issue, updates map[string]interface{}, setCl
 	return setClauses, args
 }
 
-// ClaimIssue atomically claims an issue by setting assignee and status to in_progress.
-// Returns (true, nil) if claim succeeded, (false, nil) if already claimed by someone else,
-// or (false, error) if the operation failed.
-// This uses an atomic UPDATE with a WHERE clause to prevent race conditions when
-// multiple agents try to claim the same issue simultaneously.
-func (s *SQLiteStorage) ClaimIssue(ctx context.Context, id string, assignee string) (bool, error) {
-	now := time.Now()
-
-	// Atomic claim: succeeds if assignee is empty OR status is not in_progress
-	// This allows claiming open tasks even with stale assignees
-	result, err := s.db.ExecContext(ctx, `
-		UPDATE issues
-		SET assignee = ?, status = ?, updated_at = ?
-		WHERE id = ? AND (assignee = '' OR assignee IS NULL OR status != 'in_progress')
-	`, assignee, string(types.StatusInProgress), now, id)
-	if err != nil {
-		return false, wrapDBError("claim issue", err)
+// manageLease clears the claim lease when an update changes the status or
+// assignee without setting lease_expires_at itself. Claims always set it, so
+// a lease never outlives a manual reassignment or status change.
+func manageLease(oldIssue *types.Issue, updates map[string]interface{}, setClauses []string, args []interface{}) ([]string, []interface{}) {
+	if oldIssue.LeaseExpiresAt == nil {
+		return setClauses, args
 	}
-
-	rowsAffected, err := result.RowsAffected()
-	if err != nil {
-		return false, wrapDBError("get rows affected", err)
+	if _, hasExplicitLease := updates["lease_expires_at"]; hasExplicitLease {
+		return setClauses, args
 	}
 
-	if rowsAffected == 0 {
-		// Either issue doesn't exist or is already claimed
-		// Check which case it is
-		issue, err := s.GetIssue(ctx, id)
-		if err != nil {
-			return false, wrapDBError("check issue exists", err)
-		}
-		if issue == nil {
-			return false, fmt.Errorf("issue %s not found", id)
-		}
-		// Issue exists but already claimed
-		return false, nil
+	changed := false
+	switch v := updates["status"].(type) {
+	case string:
+		changed = v != string(oldIssue.Status)
+	case types.Status:
+		changed = v != oldIssue.Status
 	}
-
-	// Successfully claimed - record the event
-	tx, err := s.db.BeginTx(ctx, nil)
-	if err != nil {
-		// Claim succeeded but event recording failed - not critical
-		return true, nil
+	if value, hasAssignee := updates["assignee"]; hasAssignee {
+		assignee, _ := value.(string) // nil unassigns
+		changed = changed || assignee != oldIssue.Assignee
 	}
-	defer func() { _ = tx.Rollback() }()
-
-	oldData := fmt.Sprintf(`{"id":"%s","assignee":"","status":"open"}`, id)
-	newData := fmt.Sprintf(`{"assignee":"%s","status":"in_progress"}`, assignee)
-
-	_, err = tx.ExecContext(ctx, `
-		INSERT INTO events (issue_id, event_type, actor, old_value, new_value)
-		VALUES (?, ?, ?, ?, ?)
-	`, id, "claimed", assignee, oldData, newData)
-	if err != nil {
-		// Event recording failed but claim succeeded
-		return true, nil
+	if !changed {
+		return setClauses, args
 	}
 
-	// Mark issue as dirty for incremental export
-	_, _ = tx.ExecContext(ctx, `
-		INSERT INTO dirty_issues (issue_id, marked_at)
-		VALUES (?, ?)
-		ON CONFLICT (issue_id) DO UPDATE SET marked_at = excluded.marked_at
-	`, id, now)
+	updates["lease_expires_at"] = nil
+	setClauses = append(setClauses, "lease_expires_at = ?")
+	args = append(args, nil)
+	return setClauses, args
+}
 
-	_ = tx.Commit()
-	return true, nil
+// ClaimIssue atomically claims an issue by setting assignee and status to in_progress.
+// Returns (true, nil) if claim succeeded, (false, nil) if already claimed by someone else,
+// or (false, error) if the operation failed.
+// This uses an atomic UPDATE with a WHERE clause to prevent race conditions when
+// multiple agents try to claim the same issue simultaneously.
+func (s *SQLiteStorage) ClaimIssue(ctx context.Context, id string, assignee string) (bool, error) {
+	return s.ClaimIssueWithLease(ctx, id, assignee, nil)
 }
 
 // UpdateIssue updates fields on an issue
@@ -955,6 +980,12 @@ func (s *SQLiteStorage) UpdateIssue(ctx context.Context, id string, updates map[
 		return fmt.Errorf("issue %s not found", id)
 	}
 
+	// Enforce the status workflow from config.yaml
+	override, err := validation.CheckWorkflow(ctx, oldIssue, updates)
+	if err != nil {
+		return err
+	}
+
 	// Fetch custom statuses for validation
 	customStatuses, err := s.GetCustomStatuses(ctx)
 	if err != nil {
@@ -981,16 +1012,23 @@ func (s *SQLiteStorage) UpdateIssue(ctx context.Context, id string, updates map[
 		if key == "wisp" {
 			columnName = "ephemeral"
 		}
+		if key == "custom_fields" {
+			value = formatJSONStringMap(value.(map[string]string))
+		}
+		if key == "waiters" {
+			value = formatJSONStringArray(value.([]string))
+		}
 		setClauses = append(setClauses, fmt.Sprintf("%s = ?", columnName))
 		args = append(args, value)
 	}
 
 	// Auto-manage closed_at when status changes (enforce invariant)
 	setClauses, args = manageClosedAt(oldIssue, updates, setClauses, args)
+	setClauses, args = manageLease(oldIssue, updates, setClauses, args)
 
 	// Recompute content_hash if any content fields changed
 	contentChanged := false
-	contentFields := []string{"title", "description", "design", "acceptance_criteria", "notes", "status", "priority", "issue_type", "assignee", "external_ref"}
+	contentFields := []string{"title", "description", "design", "acceptance_criteria", "notes", "status", "priority", "issue_type", "assignee", "external_ref", "custom_fields"}
 	for _, field := range contentFields {
 		if _, exists := updates[field]; exists {
 			contentChanged = true
@@ -1048,6 +1086,8 @@ func (s *SQLiteStorage) UpdateIssue(ctx context.Context, id string, updates map[
 						return fmt.Errorf("external_ref must be string or *string, got %T", value)
 					}
 				}
+			case "custom_fields":
+				updatedIssue.CustomFields = value.(map[string]string)
 			}
 		}
 		newHash := updatedIssue.ComputeContentHash()
@@ -1094,6 +1134,9 @@ func (s *SQLiteStorage) UpdateIssue(ctx context.Context, id string, updates map[
 	if err != nil {
 		return fmt.Errorf("failed to record event: %w", err)
 	}
+	if err := recordWorkflowOverride(ctx, tx, id, actor, override); err != nil {
+		return err
+	}
 
 	// NOTE: Graph edges now managed via AddDependency() per Decision 004 Phase 4.
 
@@ -1181,6 +1224,16 @@ func (s *SQLiteStorage) UpdateIssueID(ctx context.Context, oldID, newID string,
 		return fmt.Errorf("failed to update comments: %w", err)
 	}
 
+	_, err = tx.ExecContext(ctx, `UPDATE work_log SET issue_id = ? WHERE issue_id = ?`, newID, oldID)
+	if err != nil {
+		return fmt.Errorf("failed to update work log: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `UPDATE attachments SET issue_id = ? WHERE issue_id = ?`, newID, oldID)
+	if err != nil {
+		return fmt.Errorf("failed to update attachments: %w", err)
+	}
+
 	_, err = tx.ExecContext(ctx, `
 		UPDATE dirty_issues SET issue_id = ? WHERE issue_id = ?
 	`, newID, oldID)
@@ -1267,6 +1320,12 @@ func (s *SQLiteStorage) ResetCounter(ctx context.Context, prefix string) error {
 // CloseIssue closes an issue with a reason.
 // The session parameter tracks which Claude Code session closed the issue (can be empty).
 func (s *SQLiteStorage) CloseIssue(ctx context.Context, id string, reason string, actor string, session string) error {
+	// Enforce the status workflow from config.yaml
+	override, err := validation.CheckWorkflowByID(ctx, s.GetIssue, id, validation.CloseUpdates(reason))
+	if err != nil {
+		return err
+	}
+
 	now := time.Now()
 
 	// Update with special event handling
@@ -1303,6 +1362,9 @@ func (s *SQLiteStorage) CloseIssue(ctx context.Context, id string, reason string
 	if err != nil {
 		return fmt.Errorf("failed to record event: %w", err)
 	}
+	if err := recordWorkflowOverride(ctx, tx, id, actor, override); err != nil {
+		return err
+	}
 
 	// Mark issue as dirty for incremental export
 	_, err = tx.ExecContext(ctx, `
@@ -1526,6 +1588,18 @@ func (s *SQLiteStorage) DeleteIssue(ctx context.Context, id string) error {
 		return fmt.Errorf("failed to delete comments: %w", err)
 	}
 
+	// Delete work log
+	_, err = tx.ExecContext(ctx, `DELETE FROM work_log WHERE issue_id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete work log: %w", err)
+	}
+
+	// Delete attachments (blobs are garbage-collected by bd cleanup)
+	_, err = tx.ExecContext(ctx, `DELETE FROM attachments WHERE issue_id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete attachments: %w", err)
+	}
+
 	// Delete from dirty_issues
 	_, err = tx.ExecContext(ctx, `DELETE FROM dirty_issues WHERE issue_id = ?`, id)
 	if err != nil {
@@ -1890,6 +1964,51 @@ func (s *SQLiteStorage) SearchIssues(ctx context.Context, query string, filter t
 	s.reconnectMu.RLock()
 	defer s.reconnectMu.RUnlock()
 
+	whereClauses, args, err := buildSearchFilterClauses(query, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	whereSQL := ""
+	if len(whereClauses) > 0 {
+		whereSQL = "WHERE " + strings.Join(whereClauses, " AND ")
+	}
+
+	limitSQL := ""
+	if filter.Limit > 0 {
+		limitSQL = " LIMIT ?"
+		args = append(args, filter.Limit)
+	}
+
+	// #nosec G201 - safe SQL with controlled formatting
+	querySQL := fmt.Sprintf(`
+		SELECT id, content_hash, title, description, design, acceptance_criteria, notes,
+		       status, priority, issue_type, assignee, estimated_minutes,
+		       created_at, created_by, owner, updated_at, closed_at, external_ref, source_repo, close_reason,
+		       deleted_at, deleted_by, delete_reason, original_type,
+		       sender, ephemeral, pinned, is_template, crystallizes,
+		       await_type, await_id, timeout_ns, waiters,
+		       hook_bead, role_bead, agent_state, last_activity, role_type, rig, mol_type,
+		       due_at, defer_until, recurrence, custom_fields, lease_expires_at
+		FROM issues
+		%s
+		ORDER BY priority ASC, created_at DESC
+		%s
+	`, whereSQL, limitSQL)
+
+	rows, err := s.db.QueryContext(ctx, querySQL, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search issues: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	return s.scanIssues(ctx, rows)
+}
+
+// buildSearchFilterClauses translates a text query and IssueFilter into SQL
+// WHERE clauses (unqualified issues columns) and their bind arguments.
+// Shared by SearchIssues and SearchIssuesRanked.
+func buildSearchFilterClauses(query string, filter types.IssueFilter) ([]string, []interface{}, error) {
 	whereClauses := []string{}
 	args := []interface{}{}
 
@@ -2087,6 +2206,9 @@ func (s *SQLiteStorage) SearchIssues(ctx context.Context, query string, filter t
 	if filter.Deferred {
 		whereClauses = append(whereClauses, "defer_until IS NOT NULL")
 	}
+	if filter.Recurring {
+		whereClauses = append(whereClauses, "recurrence != ''")
+	}
 	if filter.DeferAfter != nil {
 		whereClauses = append(whereClauses, "defer_until > ?")
 		args = append(args, filter.DeferAfter.Format(time.RFC3339))
@@ -2108,38 +2230,71 @@ func (s *SQLiteStorage) SearchIssues(ctx context.Context, query string, filter t
 		args = append(args, time.Now().Format(time.RFC3339), types.StatusClosed)
 	}
 
-	whereSQL := ""
-	if len(whereClauses) > 0 {
-		whereSQL = "WHERE " + strings.Join(whereClauses, " AND ")
+	// Custom field filters
+	for _, f := range filter.CustomFields {
+		clause, clauseArgs := customFieldClause(f)
+		whereClauses = append(whereClauses, clause)
+		args = append(args, clauseArgs...)
 	}
 
-	limitSQL := ""
-	if filter.Limit > 0 {
-		limitSQL = " LIMIT ?"
-		args = append(args, filter.Limit)
+	// Filter expression
+	if filter.Where != "" {
+		clause, clauseArgs, err := whereExpressionClause(filter.Where)
+		if err != nil {
+			return nil, nil, err
+		}
+		whereClauses = append(whereClauses, clause)
+		args = append(args, clauseArgs...)
 	}
 
-	// #nosec G201 - safe SQL with controlled formatting
-	querySQL := fmt.Sprintf(`
-		SELECT id, content_hash, title, description, design, acceptance_criteria, notes,
-		       status, priority, issue_type, assignee, estimated_minutes,
-		       created_at, created_by, owner, updated_at, closed_at, external_ref, source_repo, close_reason,
-		       deleted_at, deleted_by, delete_reason, original_type,
-		       sender, ephemeral, pinned, is_template, crystallizes,
-		       await_type, await_id, timeout_ns, waiters,
-		       hook_bead, role_bead, agent_state, last_activity, role_type, rig, mol_type,
-		       due_at, defer_until
-		FROM issues
-		%s
-		ORDER BY priority ASC, created_at DESC
-		%s
-	`, whereSQL, limitSQL)
+	return whereClauses, args, nil
+}
 
-	rows, err := s.db.QueryContext(ctx, querySQL, args...)
+// whereExpressionClause compiles a filter expression (--where) to a WHERE
+// clause. SearchIssues names its text search parameter query, which shadows
+// the package.
+func whereExpressionClause(where string) (string, []interface{}, error) {
+	q, err := query.Parse(where)
 	if err != nil {
-		return nil, fmt.Errorf("failed to search issues: %w", err)
+		return "", nil, err
 	}
-	defer func() { _ = rows.Close() }()
+	clause, args := q.SQL(query.SQLite)
+	return clause, args, nil
+}
 
-	return s.scanIssues(ctx, rows)
+// customFieldClause builds the WHERE clause for a custom field filter, with
+// the semantics of types.CustomFieldFilter.Matches.
+func customFieldClause(f types.CustomFieldFilter) (string, []interface{}) {
+	// Names are interpolated into the JSON path, so only well-formed names are
+	// accepted; anything else can't be a configured field and matches nothing
+	if !types.ValidCustomFieldName(f.Name) {
+		return "1 = 0", nil
+	}
+	column := fmt.Sprintf(`json_extract(COALESCE(NULLIF(custom_fields, ''), '{}'), '$."%s"')`, f.Name)
+
+	switch f.Op {
+	case types.CustomFieldSet:
+		return column + " IS NOT NULL", nil
+	case types.CustomFieldMissing:
+		return column + " IS NULL", nil
+	}
+
+	var value interface{} = f.Value
+	if f.Numeric {
+		n, err := strconv.ParseInt(f.Value, 10, 64)
+		if err != nil {
+			return "1 = 0", nil
+		}
+		column = "CAST(" + column + " AS INTEGER)"
+		value = n
+	}
+
+	switch f.Op {
+	case types.CustomFieldEq, types.CustomFieldLt, types.CustomFieldLe, types.CustomFieldGt, types.CustomFieldGe:
+		return fmt.Sprintf("%s %s ?", column, f.Op), []interface{}{value}
+	case types.CustomFieldNe:
+		return fmt.Sprintf("(%s IS NULL OR %s != ?)", column, column), []interface{}{value}
+	default:
+		return "1 = 0", nil
+	}
 }
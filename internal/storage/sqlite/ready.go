@@ -22,9 +22,12 @@ func (s *SQLiteStorage) GetReadyWork(ctx context.Context, filter types.WorkFilte
 	}
 	args := []interface{}{}
 
-	// Default to open OR in_progress if not specified
+	// Default to open OR in_progress if not specified.
+	// A claim whose lease lapsed counts as open and unassigned.
 	if filter.Status == "" {
 		whereClauses = append(whereClauses, "i.status IN ('open', 'in_progress')")
+	} else if filter.Status == types.StatusOpen {
+		whereClauses = append(whereClauses, "(i.status = 'open' OR "+leaseExpiredSQL+")")
 	} else {
 		whereClauses = append(whereClauses, "i.status = ?")
 		args = append(args, filter.Status)
@@ -64,7 +67,7 @@ func (s *SQLiteStorage) GetReadyWork(ctx context.Context, filter types.WorkFilte
 
 	// Unassigned takes precedence over Assignee filter
 	if filter.Unassigned {
-		whereClauses = append(whereClauses, "(i.assignee IS NULL OR i.assignee = '')")
+		whereClauses = append(whereClauses, "(i.assignee IS NULL OR i.assignee = '' OR "+leaseExpiredSQL+")")
 	} else if filter.Assignee != nil {
 		whereClauses = append(whereClauses, "i.assignee = ?")
 		args = append(args, *filter.Assignee)
@@ -132,6 +135,16 @@ func (s *SQLiteStorage) GetReadyWork(ctx context.Context, filter types.WorkFilte
 		whereClauses = append(whereClauses, "(i.defer_until IS NULL OR datetime(i.defer_until) <= datetime('now'))")
 	}
 
+	// Filter expression (columns resolve to the issues table)
+	if filter.Where != "" {
+		clause, clauseArgs, err := whereExpressionClause(filter.Where)
+		if err != nil {
+			return nil, err
+		}
+		whereClauses = append(whereClauses, clause)
+		args = append(args, clauseArgs...)
+	}
+
 	// Build WHERE clause properly
 	whereSQL := strings.Join(whereClauses, " AND ")
 
@@ -147,6 +160,10 @@ func (s *SQLiteStorage) GetReadyWork(ctx context.Context, filter types.WorkFilte
 	if sortPolicy == "" {
 		sortPolicy = types.SortPolicyHybrid
 	}
+	// Scores are applied by internal/ranking on top of this order
+	if sortPolicy.IsScored() {
+		sortPolicy = types.SortPolicyPriority
+	}
 	orderBySQL := buildOrderByClause(sortPolicy)
 
 	// Use blocked_issues_cache for performance
@@ -169,7 +186,7 @@ func (s *SQLiteStorage) GetReadyWork(ctx context.Context, filter types.WorkFilte
 		i.sender, i.ephemeral, i.pinned, i.is_template, i.crystallizes,
 		i.await_type, i.await_id, i.timeout_ns, i.waiters,
 		i.hook_bead, i.role_bead, i.agent_state, i.last_activity, i.role_type, i.rig, i.mol_type,
-		i.due_at, i.defer_until
+		i.due_at, i.defer_until, i.recurrence, i.custom_fields, i.lease_expires_at
 		FROM issues i
 		WHERE %s
 		AND NOT EXISTS (
@@ -783,7 +800,7 @@ func (s *SQLiteStorage) GetNewlyUnblockedByClose(ctx context.Context, closedIssu
 		       i.sender, i.ephemeral, i.pinned, i.is_template, i.crystallizes,
 		       i.await_type, i.await_id, i.timeout_ns, i.waiters,
 		       i.hook_bead, i.role_bead, i.agent_state, i.last_activity, i.role_type, i.rig, i.mol_type,
-		       i.due_at, i.defer_until
+		       i.due_at, i.defer_until, i.recurrence, i.custom_fields, i.lease_expires_at
 		FROM issues i
 		JOIN dependencies d ON i.id = d.issue_id
 		WHERE d.depends_on_id = ?
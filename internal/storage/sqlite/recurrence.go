@@ -0,0 +1,64 @@
+package sqlite
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// ClearRecurrence clears the recurrence rule of issue id if it is still rule.
+// The conditional UPDATE lets exactly one of several processes spawning from
+// the same closed issue win.
+func (s *SQLiteStorage) ClearRecurrence(ctx context.Context, id string, rule string, actor string) (bool, error) {
+	if rule == "" {
+		return false, nil
+	}
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	result, err := tx.ExecContext(ctx, `
+		UPDATE issues SET recurrence = '', updated_at = ?
+		WHERE id = ? AND recurrence = ?
+	`, time.Now(), id, rule)
+	if err != nil {
+		return false, wrapDBError("clear recurrence", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, wrapDBError("get rows affected", err)
+	}
+	if rowsAffected == 0 {
+		// Lost the race or never had the rule; tell which before returning
+		_ = tx.Rollback()
+		issue, err := s.GetIssue(ctx, id)
+		if err != nil {
+			return false, wrapDBError("check issue exists", err)
+		}
+		if issue == nil {
+			return false, fmt.Errorf("issue %s not found", id)
+		}
+		return false, nil
+	}
+
+	oldValue, _ := json.Marshal(map[string]string{"recurrence": rule})
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO events (issue_id, event_type, actor, old_value, new_value)
+		VALUES (?, ?, ?, ?, ?)
+	`, id, types.EventUpdated, actor, string(oldValue), `{"recurrence":""}`)
+	if err != nil {
+		return false, wrapDBError("record recurrence event", err)
+	}
+	if err := markIssuesDirtyTx(ctx, tx, []string{id}); err != nil {
+		return false, err
+	}
+	if err := tx.Commit(); err != nil {
+		return false, wrapDBError("commit recurrence", err)
+	}
+	return true, nil
+}
@@ -0,0 +1,42 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestClearRecurrence(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	issue := &types.Issue{Title: "Standup", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask, Recurrence: "every day 09:00"}
+	if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+
+	// A caller holding a stale rule loses
+	if won, err := store.ClearRecurrence(ctx, issue.ID, "every week", "daemon"); err != nil || won {
+		t.Fatalf("ClearRecurrence(stale rule) = %v, %v; want lost", won, err)
+	}
+	// Of two callers that both read the rule, only the first wins
+	if won, err := store.ClearRecurrence(ctx, issue.ID, "every day 09:00", "daemon"); err != nil || !won {
+		t.Fatalf("first ClearRecurrence = %v, %v; want won", won, err)
+	}
+	if won, err := store.ClearRecurrence(ctx, issue.ID, "every day 09:00", "cli"); err != nil || won {
+		t.Fatalf("second ClearRecurrence = %v, %v; want lost", won, err)
+	}
+
+	got, err := store.GetIssue(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if got.Recurrence != "" {
+		t.Errorf("recurrence = %q, want cleared", got.Recurrence)
+	}
+	if _, err := store.ClearRecurrence(ctx, "bd-missing", "every day", "daemon"); err == nil {
+		t.Error("expected an error for a missing issue")
+	}
+}
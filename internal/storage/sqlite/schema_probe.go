@@ -25,6 +25,8 @@ var expectedSchema = map[string][]string{
 	"dependencies":         {"issue_id", "depends_on_id", "type", "created_at", "created_by", "metadata", "thread_id"},
 	"labels":               {"issue_id", "label"},
 	"comments":             {"id", "issue_id", "author", "text", "created_at"},
+	"work_log":             {"id", "issue_id", "actor", "minutes", "started_at", "ended_at", "note", "created_at"},
+	"attachments":          {"id", "issue_id", "name", "sha256", "size", "media_type", "created_by", "created_at", "detached_at"},
 	"events":               {"id", "issue_id", "event_type", "actor", "old_value", "new_value", "comment", "created_at"},
 	"config":               {"key", "value"},
 	"metadata":             {"key", "value"},
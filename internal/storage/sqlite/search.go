@@ -0,0 +1,178 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/search"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// SearchIssuesRanked runs a full-text query against the issues_fts index and
+// returns hits ordered by FTS5 BM25 relevance with highlighted snippets.
+// Databases that predate the index (e.g. opened read-only before migrating)
+// fall back to in-memory ranking, which uses the same scoring formula.
+func (s *SQLiteStorage) SearchIssuesRanked(ctx context.Context, query string, filter types.IssueFilter) ([]*types.SearchResult, error) {
+	q, err := search.Parse(query)
+	if err != nil {
+		return nil, err
+	}
+
+	s.checkFreshness()
+
+	hasIndex, err := s.hasSearchIndex(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !hasIndex {
+		return s.searchIssuesRankedFallback(ctx, q, filter)
+	}
+
+	hits, err := s.querySearchIndex(ctx, q, filter)
+	if err != nil {
+		return nil, err
+	}
+	if len(hits) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]string, len(hits))
+	for i, h := range hits {
+		ids[i] = h.id
+	}
+	issues, err := s.SearchIssues(ctx, "", types.IssueFilter{IDs: ids, IncludeTombstones: true})
+	if err != nil {
+		return nil, err
+	}
+	comments, err := s.GetCommentsForIssues(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[string]*types.Issue, len(issues))
+	for _, issue := range issues {
+		byID[issue.ID] = issue
+	}
+
+	results := make([]*types.SearchResult, 0, len(hits))
+	for _, h := range hits {
+		issue, ok := byID[h.id]
+		if !ok {
+			continue // Deleted between the index query and the fetch
+		}
+		results = append(results, &types.SearchResult{
+			IssueWithCounts: types.IssueWithCounts{Issue: issue},
+			Score:           h.score,
+			Snippet:         search.Snippet(q, search.NewDocument(issue, comments[issue.ID])),
+		})
+	}
+	return results, nil
+}
+
+type searchHit struct {
+	id    string
+	score float64
+}
+
+// querySearchIndex returns matching issue IDs with their BM25 scores
+// (negated so that higher is better), applying filter in the same query.
+func (s *SQLiteStorage) querySearchIndex(ctx context.Context, q *search.Query, filter types.IssueFilter) ([]searchHit, error) {
+	s.reconnectMu.RLock()
+	defer s.reconnectMu.RUnlock()
+
+	weights := make([]string, len(search.ColumnWeights))
+	for i, w := range search.ColumnWeights {
+		weights[i] = fmt.Sprintf("%g", w)
+	}
+
+	args := []interface{}{q.FTS5()}
+	whereClauses, filterArgs, err := buildSearchFilterClauses("", filter)
+	if err != nil {
+		return nil, err
+	}
+	args = append(args, filterArgs...)
+
+	whereSQL := ""
+	if len(whereClauses) > 0 {
+		whereSQL = "WHERE " + strings.Join(whereClauses, " AND ")
+	}
+
+	limitSQL := ""
+	if filter.Limit > 0 {
+		limitSQL = " LIMIT ?"
+		args = append(args, filter.Limit)
+	}
+
+	// The CTE exposes only hit_id/score so the unqualified column names in the
+	// filter clauses resolve against issues, not the FTS table.
+	// #nosec G201 - safe SQL with controlled formatting
+	querySQL := fmt.Sprintf(`
+		WITH hits AS (
+			SELECT id AS hit_id, bm25(issues_fts, %s) AS score
+			FROM issues_fts
+			WHERE issues_fts MATCH ?
+		)
+		SELECT hits.hit_id, hits.score
+		FROM hits
+		JOIN issues ON issues.id = hits.hit_id
+		%s
+		ORDER BY hits.score ASC, issues.priority ASC, issues.id ASC
+		%s
+	`, strings.Join(weights, ", "), whereSQL, limitSQL)
+
+	rows, err := s.db.QueryContext(ctx, querySQL, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search issues: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var hits []searchHit
+	for rows.Next() {
+		var h searchHit
+		if err := rows.Scan(&h.id, &h.score); err != nil {
+			return nil, fmt.Errorf("failed to scan search hit: %w", err)
+		}
+		h.score = -h.score // FTS5 bm25() is negative, lower is better
+		hits = append(hits, h)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating search hits: %w", err)
+	}
+	return hits, nil
+}
+
+// hasSearchIndex reports whether the issues_fts table exists.
+func (s *SQLiteStorage) hasSearchIndex(ctx context.Context) (bool, error) {
+	s.reconnectMu.RLock()
+	defer s.reconnectMu.RUnlock()
+
+	var exists bool
+	err := s.db.QueryRowContext(ctx, `
+		SELECT COUNT(*) > 0 FROM sqlite_master
+		WHERE type='table' AND name='issues_fts'
+	`).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("failed to check for search index: %w", err)
+	}
+	return exists, nil
+}
+
+// searchIssuesRankedFallback ranks filtered candidates in Go when the FTS
+// index is unavailable.
+func (s *SQLiteStorage) searchIssuesRankedFallback(ctx context.Context, q *search.Query, filter types.IssueFilter) ([]*types.SearchResult, error) {
+	limit := filter.Limit
+	filter.Limit = 0
+	candidates, err := s.SearchIssues(ctx, "", filter)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, len(candidates))
+	for i, issue := range candidates {
+		ids[i] = issue.ID
+	}
+	comments, err := s.GetCommentsForIssues(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	return search.RankIssues(q, candidates, comments, limit), nil
+}
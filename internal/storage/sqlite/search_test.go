@@ -0,0 +1,223 @@
+package sqlite
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/search"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func createSearchTestIssue(t *testing.T, store *SQLiteStorage, title, description string, priority int) *types.Issue {
+	t.Helper()
+	issue := &types.Issue{
+		Title:       title,
+		Description: description,
+		Status:      types.StatusOpen,
+		Priority:    priority,
+		IssueType:   types.TypeTask,
+	}
+	if err := store.CreateIssue(context.Background(), issue, "test-user"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	return issue
+}
+
+func TestSearchIssuesRanked(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	loginTitle := createSearchTestIssue(t, store, "Fix login timeout", "Users are logged out after 30s", 1)
+	loginDesc := createSearchTestIssue(t, store, "Add dark mode", "Theme support for the login page", 2)
+	storage := createSearchTestIssue(t, store, "Refactor storage", "Split queries into smaller files", 2)
+
+	t.Run("ranks title hits above description hits", func(t *testing.T) {
+		results, err := store.SearchIssuesRanked(ctx, "login", types.IssueFilter{})
+		if err != nil {
+			t.Fatalf("SearchIssuesRanked failed: %v", err)
+		}
+		if len(results) != 2 {
+			t.Fatalf("expected 2 results, got %d", len(results))
+		}
+		if results[0].ID != loginTitle.ID || results[1].ID != loginDesc.ID {
+			t.Errorf("unexpected order: %s, %s", results[0].ID, results[1].ID)
+		}
+		if results[0].Score <= results[1].Score {
+			t.Errorf("expected descending scores, got %v then %v", results[0].Score, results[1].Score)
+		}
+		if !strings.Contains(results[1].Snippet, search.HighlightStart+"login"+search.HighlightEnd) {
+			t.Errorf("expected highlighted snippet, got %q", results[1].Snippet)
+		}
+	})
+
+	t.Run("boolean operators", func(t *testing.T) {
+		results, err := store.SearchIssuesRanked(ctx, "login NOT dark", types.IssueFilter{})
+		if err != nil {
+			t.Fatalf("SearchIssuesRanked failed: %v", err)
+		}
+		if len(results) != 1 || results[0].ID != loginTitle.ID {
+			t.Errorf("expected only %s, got %d results", loginTitle.ID, len(results))
+		}
+	})
+
+	t.Run("filters apply", func(t *testing.T) {
+		p := 2
+		results, err := store.SearchIssuesRanked(ctx, "login", types.IssueFilter{Priority: &p})
+		if err != nil {
+			t.Fatalf("SearchIssuesRanked failed: %v", err)
+		}
+		if len(results) != 1 || results[0].ID != loginDesc.ID {
+			t.Errorf("expected only %s with priority filter, got %d results", loginDesc.ID, len(results))
+		}
+	})
+
+	t.Run("limit", func(t *testing.T) {
+		results, err := store.SearchIssuesRanked(ctx, "login", types.IssueFilter{Limit: 1})
+		if err != nil {
+			t.Fatalf("SearchIssuesRanked failed: %v", err)
+		}
+		if len(results) != 1 || results[0].ID != loginTitle.ID {
+			t.Errorf("expected top hit only with limit 1, got %d results", len(results))
+		}
+	})
+
+	t.Run("partial id", func(t *testing.T) {
+		results, err := store.SearchIssuesRanked(ctx, storage.ID[:len(storage.ID)-1], types.IssueFilter{})
+		if err != nil {
+			t.Fatalf("SearchIssuesRanked failed: %v", err)
+		}
+		found := false
+		for _, r := range results {
+			if r.ID == storage.ID {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("expected partial ID search to find %s", storage.ID)
+		}
+	})
+
+	t.Run("invalid query", func(t *testing.T) {
+		if _, err := store.SearchIssuesRanked(ctx, `"unterminated`, types.IssueFilter{}); err == nil {
+			t.Error("expected error for malformed query")
+		}
+	})
+}
+
+func TestSearchIndexStaysInSync(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	issue := createSearchTestIssue(t, store, "Flaky pipeline", "CI fails intermittently", 2)
+
+	find := func(q string) []*types.SearchResult {
+		t.Helper()
+		results, err := store.SearchIssuesRanked(ctx, q, types.IssueFilter{})
+		if err != nil {
+			t.Fatalf("SearchIssuesRanked(%q) failed: %v", q, err)
+		}
+		return results
+	}
+
+	// Updates replace the indexed text
+	if err := store.UpdateIssue(ctx, issue.ID, map[string]interface{}{"title": "Slow pipeline"}, "test-user"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	if got := find("flaky"); len(got) != 0 {
+		t.Errorf("expected old title to be unindexed, got %d results", len(got))
+	}
+	if got := find("slow"); len(got) != 1 {
+		t.Errorf("expected new title to be indexed, got %d results", len(got))
+	}
+
+	// Comments are indexed
+	if _, err := store.AddIssueComment(ctx, issue.ID, "alice", "Seen on the arm64 runners"); err != nil {
+		t.Fatalf("AddIssueComment failed: %v", err)
+	}
+	got := find("arm64")
+	if len(got) != 1 {
+		t.Fatalf("expected comment text to be indexed, got %d results", len(got))
+	}
+	if !strings.Contains(got[0].Snippet, "arm64") {
+		t.Errorf("expected snippet from comment, got %q", got[0].Snippet)
+	}
+
+	// Deletes remove the row
+	if err := store.DeleteIssue(ctx, issue.ID); err != nil {
+		t.Fatalf("DeleteIssue failed: %v", err)
+	}
+	if got := find("pipeline"); len(got) != 0 {
+		t.Errorf("expected deleted issue to be unindexed, got %d results", len(got))
+	}
+}
+
+func TestSearchIndexRebuildsMissingTriggers(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	issue := createSearchTestIssue(t, store, "Memory leak in daemon", "", 1)
+
+	// Simulate a table rebuild that dropped the triggers and left the index stale
+	db := store.UnderlyingDB()
+	if _, err := db.Exec(`DROP TRIGGER issues_fts_ai`); err != nil {
+		t.Fatalf("failed to drop trigger: %v", err)
+	}
+	if _, err := db.Exec(`DELETE FROM issues_fts`); err != nil {
+		t.Fatalf("failed to clear index: %v", err)
+	}
+
+	if err := RunMigrations(db); err != nil {
+		t.Fatalf("RunMigrations failed: %v", err)
+	}
+
+	results, err := store.SearchIssuesRanked(ctx, "leak", types.IssueFilter{})
+	if err != nil {
+		t.Fatalf("SearchIssuesRanked failed: %v", err)
+	}
+	if len(results) != 1 || results[0].ID != issue.ID {
+		t.Errorf("expected index to be rebuilt with %s, got %d results", issue.ID, len(results))
+	}
+}
+
+func TestSearchIndexSurvivesRowidChanges(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	alpha := createSearchTestIssue(t, store, "Alpha parser crash", "", 1)
+	beta := createSearchTestIssue(t, store, "Beta exporter hang", "", 1)
+
+	// VACUUM and table rebuilds can renumber the implicit issues.rowid
+	db := store.UnderlyingDB()
+	if _, err := db.Exec(`UPDATE issues SET rowid = rowid + 1000`); err != nil {
+		t.Fatalf("failed to renumber rowids: %v", err)
+	}
+
+	if err := store.UpdateIssue(ctx, alpha.ID, map[string]interface{}{"title": "Gamma parser crash"}, "test-user"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	if err := store.DeleteIssue(ctx, beta.ID); err != nil {
+		t.Fatalf("DeleteIssue failed: %v", err)
+	}
+
+	for query, want := range map[string]int{"alpha": 0, "gamma": 1, "beta": 0, "parser": 1} {
+		results, err := store.SearchIssuesRanked(ctx, query, types.IssueFilter{})
+		if err != nil {
+			t.Fatalf("SearchIssuesRanked(%q) failed: %v", query, err)
+		}
+		if len(results) != want {
+			t.Errorf("SearchIssuesRanked(%q) returned %d results, want %d", query, len(results), want)
+		}
+	}
+	var indexed int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM issues_fts`).Scan(&indexed); err != nil {
+		t.Fatalf("failed to count index rows: %v", err)
+	}
+	if indexed != 1 {
+		t.Errorf("index holds %d rows, want 1", indexed)
+	}
+}
@@ -12,6 +12,7 @@ import (
 
 	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/validation"
 )
 
 // Verify sqliteTxStorage implements storage.Transaction at compile time
@@ -343,7 +344,7 @@ func (t *sqliteTxStorage) GetIssue(ctx context.Context, id string) (*types.Issue
 		       sender, ephemeral, pinned, is_template, crystallizes,
 		       await_type, await_id, timeout_ns, waiters,
 		       hook_bead, role_bead, agent_state, last_activity, role_type, rig, mol_type,
-		       due_at, defer_until
+		       due_at, defer_until, recurrence, custom_fields, lease_expires_at
 		FROM issues
 		WHERE id = ?
 	`, id)
@@ -399,6 +400,12 @@ func (t *sqliteTxStorage) UpdateIssue(ctx context.Context, id string, updates ma
 		return fmt.Errorf("issue %s not found", id)
 	}
 
+	// Enforce the status workflow from config.yaml
+	override, err := validation.CheckWorkflow(ctx, oldIssue, updates)
+	if err != nil {
+		return err
+	}
+
 	// Fetch custom statuses for validation
 	customStatuses, err := t.GetCustomStatuses(ctx)
 	if err != nil {
@@ -420,16 +427,20 @@ func (t *sqliteTxStorage) UpdateIssue(ctx context.Context, id string, updates ma
 			return fmt.Errorf("failed to validate field update: %w", err)
 		}
 
+		if key == "custom_fields" {
+			value = formatJSONStringMap(value.(map[string]string))
+		}
 		setClauses = append(setClauses, fmt.Sprintf("%s = ?", key))
 		args = append(args, value)
 	}
 
 	// Auto-manage closed_at when status changes
 	setClauses, args = manageClosedAt(oldIssue, updates, setClauses, args)
+	setClauses, args = manageLease(oldIssue, updates, setClauses, args)
 
 	// Recompute content_hash if any content fields changed
 	contentChanged := false
-	contentFields := []string{"title", "description", "design", "acceptance_criteria", "notes", "status", "priority", "issue_type", "assignee", "external_ref"}
+	contentFields := []string{"title", "description", "design", "acceptance_criteria", "notes", "status", "priority", "issue_type", "assignee", "external_ref", "custom_fields"}
 	for _, field := range contentFields {
 		if _, exists := updates[field]; exists {
 			contentChanged = true
@@ -472,6 +483,9 @@ func (t *sqliteTxStorage) UpdateIssue(ctx context.Context, id string, updates ma
 	if err != nil {
 		return fmt.Errorf("failed to record event: %w", err)
 	}
+	if err := recordWorkflowOverride(ctx, t.conn, id, actor, override); err != nil {
+		return err
+	}
 
 	// Mark issue as dirty
 	if err := markDirty(ctx, t.conn, id); err != nil {
@@ -546,6 +560,10 @@ func applyUpdatesToIssue(issue *types.Issue, updates map[string]interface{}) {
 					issue.ExternalRef = v
 				}
 			}
+		case "custom_fields":
+			if m, ok := value.(map[string]string); ok {
+				issue.CustomFields = m
+			}
 		}
 	}
 }
@@ -554,6 +572,12 @@ func applyUpdatesToIssue(issue *types.Issue, updates map[string]interface{}) {
 // NOTE: close_reason is stored in both issues table and events table - see SQLiteStorage.CloseIssue.
 // The session parameter tracks which Claude Code session closed the issue (can be empty).
 func (t *sqliteTxStorage) CloseIssue(ctx context.Context, id string, reason string, actor string, session string) error {
+	// Enforce the status workflow from config.yaml
+	override, err := validation.CheckWorkflowByID(ctx, t.GetIssue, id, validation.CloseUpdates(reason))
+	if err != nil {
+		return err
+	}
+
 	now := time.Now()
 
 	result, err := t.conn.ExecContext(ctx, `
@@ -579,6 +603,9 @@ func (t *sqliteTxStorage) CloseIssue(ctx context.Context, id string, reason stri
 	if err != nil {
 		return fmt.Errorf("failed to record event: %w", err)
 	}
+	if err := recordWorkflowOverride(ctx, t.conn, id, actor, override); err != nil {
+		return err
+	}
 
 	// Mark issue as dirty
 	if err := markDirty(ctx, t.conn, id); err != nil {
@@ -1372,7 +1399,7 @@ func (t *sqliteTxStorage) SearchIssues(ctx context.Context, query string, filter
 		       sender, ephemeral, pinned, is_template, crystallizes,
 		       await_type, await_id, timeout_ns, waiters,
 		       hook_bead, role_bead, agent_state, last_activity, role_type, rig, mol_type,
-		       due_at, defer_until
+		       due_at, defer_until, recurrence, custom_fields, lease_expires_at
 		FROM issues
 		%s
 		ORDER BY priority ASC, created_at DESC
@@ -1440,6 +1467,9 @@ func scanIssueRow(row scanner) (*types.Issue, error) {
 	// Time-based scheduling fields
 	var dueAt sql.NullTime
 	var deferUntil sql.NullTime
+	var recurrence sql.NullString
+	var customFields sql.NullString
+	var leaseExpiresAt sql.NullTime
 
 	err := row.Scan(
 		&issue.ID, &contentHash, &issue.Title, &issue.Description, &issue.Design,
@@ -1451,7 +1481,7 @@ func scanIssueRow(row scanner) (*types.Issue, error) {
 		&sender, &wisp, &pinned, &isTemplate, &crystallizes,
 		&awaitType, &awaitID, &timeoutNs, &waiters,
 		&hookBead, &roleBead, &agentState, &lastActivity, &roleType, &rig, &molType,
-		&dueAt, &deferUntil,
+		&dueAt, &deferUntil, &recurrence, &customFields, &leaseExpiresAt,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to scan issue: %w", err)
@@ -1570,6 +1600,15 @@ func scanIssueRow(row scanner) (*types.Issue, error) {
 	if deferUntil.Valid {
 		issue.DeferUntil = &deferUntil.Time
 	}
+	if recurrence.Valid {
+		issue.Recurrence = recurrence.String
+	}
+	if customFields.Valid {
+		issue.CustomFields = parseJSONStringMap(customFields.String)
+	}
+	if leaseExpiresAt.Valid {
+		issue.LeaseExpiresAt = &leaseExpiresAt.Time
+	}
 
 	return &issue, nil
 }
@@ -68,6 +68,21 @@ func validateEstimatedMinutes(value interface{}) error {
 	return nil
 }
 
+// validateCustomFields validates a custom_fields value: the complete map of
+// field values, keyed by field name
+func validateCustomFields(value interface{}) error {
+	fields, ok := value.(map[string]string)
+	if !ok {
+		return fmt.Errorf("custom_fields must be map[string]string, got %T", value)
+	}
+	for name := range fields {
+		if !types.ValidCustomFieldName(name) {
+			return fmt.Errorf("invalid custom field name: %q", name)
+		}
+	}
+	return nil
+}
+
 // fieldValidators maps field names to their validation functions
 var fieldValidators = map[string]func(interface{}) error{
 	"priority":          validatePriority,
@@ -75,6 +90,7 @@ var fieldValidators = map[string]func(interface{}) error{
 	"issue_type":        validateIssueType,
 	"title":             validateTitle,
 	"estimated_minutes": validateEstimatedMinutes,
+	"custom_fields":     validateCustomFields,
 }
 
 // validateFieldUpdate validates a field update value (built-in statuses only)
@@ -0,0 +1,173 @@
+package sqlite
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestSearchIssuesWhere(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	create := func(title string, priority int, issueType types.IssueType, assignee string) *types.Issue {
+		t.Helper()
+		issue := &types.Issue{Title: title, Status: types.StatusOpen, Priority: priority, IssueType: issueType, Assignee: assignee}
+		if err := store.CreateIssue(ctx, issue, "test-user"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+		return issue
+	}
+	epic := create("Login epic", 1, types.TypeEpic, "")
+	child := create("Login timeout", 3, types.TypeBug, "alice")
+	bot := create("Nightly chore", 0, types.TypeChore, "bot-ci")
+	blocker := create("Schema change", 2, types.TypeTask, "")
+
+	if err := store.AddLabel(ctx, child.ID, "security", "test-user"); err != nil {
+		t.Fatalf("AddLabel failed: %v", err)
+	}
+	for _, dep := range []*types.Dependency{
+		{IssueID: child.ID, DependsOnID: epic.ID, Type: types.DepParentChild},
+		{IssueID: child.ID, DependsOnID: blocker.ID, Type: types.DepBlocks},
+	} {
+		if err := store.AddDependency(ctx, dep, "test-user"); err != nil {
+			t.Fatalf("AddDependency failed: %v", err)
+		}
+	}
+	if err := store.CloseIssue(ctx, bot.ID, "done", "test-user", ""); err != nil {
+		t.Fatalf("CloseIssue failed: %v", err)
+	}
+
+	sorted := func(ids ...string) []string {
+		sort.Strings(ids)
+		return ids
+	}
+	tests := []struct {
+		where string
+		want  []string
+	}{
+		{"status:open AND (priority<=1 OR label:security) AND updated>-7d AND NOT assignee:bot-*", sorted(epic.ID, child.ID)},
+		{"assignee:bot-*", []string{bot.ID}},
+		{"NOT assignee:bot-*", sorted(epic.ID, child.ID, blocker.ID)},
+		{"status!=closed type:bug,task", sorted(child.ID, blocker.ID)},
+		{"blocked-by:" + blocker.ID, []string{child.ID}},
+		{"blocks:" + child.ID, []string{blocker.ID}},
+		{"parent:" + epic.ID, []string{child.ID}},
+		{"has:parent", []string{child.ID}},
+		{"has:children", []string{epic.ID}},
+		{"is:blocked", []string{child.ID}},
+		{"closed>-1h", []string{bot.ID}},
+		{"LOGIN", sorted(epic.ID, child.ID)},
+		{"title:100%", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.where, func(t *testing.T) {
+			issues, err := store.SearchIssues(ctx, "", types.IssueFilter{Where: tt.where})
+			if err != nil {
+				t.Fatalf("SearchIssues failed: %v", err)
+			}
+			var got []string
+			for _, issue := range issues {
+				got = append(got, issue.ID)
+			}
+			sort.Strings(got)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SearchIssues(where %q) = %v, want %v", tt.where, got, tt.want)
+			}
+		})
+	}
+
+	// Ranked search applies the expression in the same query
+	results, err := store.SearchIssuesRanked(ctx, "login", types.IssueFilter{Where: "type:bug"})
+	if err != nil {
+		t.Fatalf("SearchIssuesRanked failed: %v", err)
+	}
+	if len(results) != 1 || results[0].Issue.ID != child.ID {
+		t.Errorf("SearchIssuesRanked = %v, want only %s", results, child.ID)
+	}
+
+	if _, err := store.SearchIssues(ctx, "", types.IssueFilter{Where: "bogus:1"}); err == nil {
+		t.Error("expected an invalid expression to be rejected")
+	}
+}
+
+// TestSearchIssuesWhereLocalTime checks date predicates in a time zone far
+// from UTC. Timestamps are stored in the writer's zone, so a comparison as
+// text would be off by the UTC offset.
+func TestSearchIssuesWhereLocalTime(t *testing.T) {
+	oldLocal := time.Local
+	time.Local = time.FixedZone("NZDT", 13*60*60)
+	defer func() { time.Local = oldLocal }()
+
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	issue := &types.Issue{Title: "Quiet for hours", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, issue, "test-user"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	fiveHoursAgo := time.Now().Add(-5 * time.Hour)
+	if err := store.UpdateIssue(ctx, issue.ID, map[string]interface{}{"due_at": fiveHoursAgo}, "test-user"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	if _, err := store.UnderlyingDB().ExecContext(ctx, `UPDATE issues SET updated_at = ? WHERE id = ?`, fiveHoursAgo, issue.ID); err != nil {
+		t.Fatalf("failed to backdate updated_at: %v", err)
+	}
+
+	tests := []struct {
+		where string
+		want  bool
+	}{
+		{"updated>-2h", false},
+		{"updated<-2h", true},
+		{"updated>-6h", true},
+		{"is:overdue", true},
+		{"due>-2h", false},
+	}
+	for _, tt := range tests {
+		issues, err := store.SearchIssues(ctx, "", types.IssueFilter{Where: tt.where})
+		if err != nil {
+			t.Fatalf("SearchIssues(%q) failed: %v", tt.where, err)
+		}
+		if got := len(issues) == 1; got != tt.want {
+			t.Errorf("SearchIssues(where %q) matched = %v, want %v", tt.where, got, tt.want)
+		}
+	}
+}
+
+func TestGetReadyWorkWhere(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	var ids []string
+	for _, issue := range []*types.Issue{
+		{Title: "Frontend bug", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeBug},
+		{Title: "Backend task", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask},
+	} {
+		if err := store.CreateIssue(ctx, issue, "test-user"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+		ids = append(ids, issue.ID)
+	}
+	if err := store.AddLabel(ctx, ids[0], "frontend", "test-user"); err != nil {
+		t.Fatalf("AddLabel failed: %v", err)
+	}
+
+	issues, err := store.GetReadyWork(ctx, types.WorkFilter{Where: "label:frontend OR priority>=3"})
+	if err != nil {
+		t.Fatalf("GetReadyWork failed: %v", err)
+	}
+	if len(issues) != 1 || issues[0].ID != ids[0] {
+		t.Errorf("GetReadyWork(where) = %v, want only %s", issues, ids[0])
+	}
+	if _, err := store.GetReadyWork(ctx, types.WorkFilter{Where: "bogus:1"}); err == nil {
+		t.Error("expected an invalid expression to be rejected")
+	}
+}
@@ -0,0 +1,121 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// SaveWorkLogEntry inserts a work-log entry, or replaces the entry with the
+// same ID. Timestamps are stored as given so imports don't drift.
+func (s *SQLiteStorage) SaveWorkLogEntry(ctx context.Context, entry *types.WorkLogEntry) error {
+	if entry.ID == "" {
+		return fmt.Errorf("work log entry ID is required")
+	}
+
+	// Verify issue exists
+	var exists bool
+	err := s.db.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM issues WHERE id = ?)`, entry.IssueID).Scan(&exists)
+	if err != nil {
+		return fmt.Errorf("failed to check issue existence: %w", err)
+	}
+	if !exists {
+		return fmt.Errorf("issue %s not found", entry.IssueID)
+	}
+
+	if entry.CreatedAt.IsZero() {
+		entry.CreatedAt = time.Now().UTC()
+	}
+	var endedAt interface{}
+	if entry.EndedAt != nil {
+		endedAt = entry.EndedAt.UTC().Format(time.RFC3339Nano)
+	}
+
+	_, err = s.db.ExecContext(ctx, `
+		INSERT INTO work_log (id, issue_id, actor, minutes, started_at, ended_at, note, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (id) DO UPDATE SET
+			issue_id = excluded.issue_id,
+			actor = excluded.actor,
+			minutes = excluded.minutes,
+			started_at = excluded.started_at,
+			ended_at = excluded.ended_at,
+			note = excluded.note
+	`, entry.ID, entry.IssueID, entry.Actor, entry.Minutes,
+		entry.StartedAt.UTC().Format(time.RFC3339Nano), endedAt, entry.Note,
+		entry.CreatedAt.UTC().Format(time.RFC3339Nano))
+	if err != nil {
+		return fmt.Errorf("failed to save work log entry: %w", err)
+	}
+
+	// Mark issue as dirty for JSONL export
+	if err := s.MarkIssueDirty(ctx, entry.IssueID); err != nil {
+		return fmt.Errorf("failed to mark issue dirty: %w", err)
+	}
+
+	return nil
+}
+
+// GetWorkLog retrieves the work log of an issue, oldest first
+func (s *SQLiteStorage) GetWorkLog(ctx context.Context, issueID string) ([]*types.WorkLogEntry, error) {
+	result, err := s.GetWorkLogForIssues(ctx, []string{issueID})
+	if err != nil {
+		return nil, err
+	}
+	return result[issueID], nil
+}
+
+// GetWorkLogForIssues fetches work-log entries for multiple issues in a single query
+// Returns a map of issue_id -> []*WorkLogEntry
+func (s *SQLiteStorage) GetWorkLogForIssues(ctx context.Context, issueIDs []string) (map[string][]*types.WorkLogEntry, error) {
+	if len(issueIDs) == 0 {
+		return make(map[string][]*types.WorkLogEntry), nil
+	}
+
+	// Hold read lock during database operations to prevent reconnect() from
+	// closing the connection mid-query (GH#607 race condition fix)
+	s.reconnectMu.RLock()
+	defer s.reconnectMu.RUnlock()
+
+	placeholders := make([]interface{}, len(issueIDs))
+	for i, id := range issueIDs {
+		placeholders[i] = id
+	}
+
+	query := fmt.Sprintf(`
+		SELECT id, issue_id, actor, minutes, started_at, ended_at, note, created_at
+		FROM work_log
+		WHERE issue_id IN (%s)
+		ORDER BY issue_id, started_at ASC, id ASC
+	`, buildPlaceholders(len(issueIDs))) // #nosec G201 -- placeholders are generated internally
+
+	rows, err := s.db.QueryContext(ctx, query, placeholders...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to batch get work log: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	result := make(map[string][]*types.WorkLogEntry)
+	for rows.Next() {
+		entry := &types.WorkLogEntry{}
+		var endedAt sql.NullTime
+		err := rows.Scan(&entry.ID, &entry.IssueID, &entry.Actor, &entry.Minutes,
+			&entry.StartedAt, &endedAt, &entry.Note, &entry.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan work log entry: %w", err)
+		}
+		if endedAt.Valid {
+			entry.EndedAt = &endedAt.Time
+		}
+		result[entry.IssueID] = append(result[entry.IssueID], entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating work log: %w", err)
+	}
+
+	return result, nil
+}
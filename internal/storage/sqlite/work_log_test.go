@@ -0,0 +1,109 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestSaveWorkLogEntry(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	issue := &types.Issue{
+		Title:     "Test issue",
+		Status:    types.StatusOpen,
+		Priority:  1,
+		IssueType: types.TypeTask,
+	}
+	if err := store.CreateIssue(ctx, issue, "test-user"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+
+	started := time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)
+	running := &types.WorkLogEntry{
+		ID:        "wl-running",
+		IssueID:   issue.ID,
+		Actor:     "alice",
+		StartedAt: started,
+	}
+	if err := store.SaveWorkLogEntry(ctx, running); err != nil {
+		t.Fatalf("SaveWorkLogEntry failed: %v", err)
+	}
+
+	logged := &types.WorkLogEntry{
+		ID:        "wl-logged",
+		IssueID:   issue.ID,
+		Actor:     "bob",
+		Minutes:   90,
+		StartedAt: started.Add(-2 * time.Hour),
+		Note:      "pairing",
+	}
+	ended := logged.StartedAt.Add(90 * time.Minute)
+	logged.EndedAt = &ended
+	if err := store.SaveWorkLogEntry(ctx, logged); err != nil {
+		t.Fatalf("SaveWorkLogEntry failed: %v", err)
+	}
+
+	entries, err := store.GetWorkLog(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetWorkLog failed: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(entries))
+	}
+	if entries[0].ID != "wl-logged" || entries[1].ID != "wl-running" {
+		t.Errorf("entries not ordered by started_at: %s, %s", entries[0].ID, entries[1].ID)
+	}
+	if entries[0].Minutes != 90 || entries[0].Note != "pairing" || entries[0].EndedAt == nil {
+		t.Errorf("logged entry not round-tripped: %+v", entries[0])
+	}
+	if !entries[1].IsRunning() {
+		t.Errorf("expected running entry, got ended_at %v", entries[1].EndedAt)
+	}
+	if !entries[1].StartedAt.Equal(started) {
+		t.Errorf("started_at = %v, want %v", entries[1].StartedAt, started)
+	}
+
+	// Stopping the timer replaces the entry with the same ID
+	stopped := started.Add(45 * time.Minute)
+	running.EndedAt = &stopped
+	running.Minutes = 45
+	if err := store.SaveWorkLogEntry(ctx, running); err != nil {
+		t.Fatalf("SaveWorkLogEntry (stop) failed: %v", err)
+	}
+
+	byIssue, err := store.GetWorkLogForIssues(ctx, []string{issue.ID, "missing"})
+	if err != nil {
+		t.Fatalf("GetWorkLogForIssues failed: %v", err)
+	}
+	entries = byIssue[issue.ID]
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 entries after stop, got %d", len(entries))
+	}
+	if entries[1].IsRunning() || entries[1].Minutes != 45 {
+		t.Errorf("timer not stopped: %+v", entries[1])
+	}
+	if len(byIssue["missing"]) != 0 {
+		t.Errorf("expected no entries for missing issue")
+	}
+}
+
+func TestSaveWorkLogEntry_Errors(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	if err := store.SaveWorkLogEntry(ctx, &types.WorkLogEntry{IssueID: "bd-1"}); err == nil {
+		t.Error("expected error for entry without ID")
+	}
+	err := store.SaveWorkLogEntry(ctx, &types.WorkLogEntry{ID: "wl-1", IssueID: "bd-nope", StartedAt: time.Now()})
+	if err == nil {
+		t.Error("expected error for nonexistent issue")
+	}
+}
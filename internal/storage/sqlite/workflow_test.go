@@ -0,0 +1,131 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/validation"
+)
+
+// setWorkflow configures a workflow that requires review before closing.
+func setWorkflow(t *testing.T) {
+	t.Helper()
+	if err := config.Initialize(); err != nil {
+		t.Fatalf("failed to initialize config: %v", err)
+	}
+	config.Set("workflow", map[string]interface{}{
+		"default": map[string]interface{}{
+			"transitions": map[string]interface{}{
+				"open":        []string{"in_progress"},
+				"in_progress": []string{"review"},
+				"review":      []string{"closed"},
+			},
+			"guards": map[string]interface{}{
+				"in_progress": []string{"assignee"},
+			},
+		},
+	})
+	t.Cleanup(func() {
+		config.Set("workflow", nil)
+	})
+}
+
+func TestUpdateIssue_Workflow(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	setWorkflow(t)
+	ctx := context.Background()
+
+	issue := &types.Issue{Title: "Task", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+
+	var te *validation.TransitionError
+	err := store.UpdateIssue(ctx, issue.ID, map[string]interface{}{"status": string(types.StatusInProgress)}, "test")
+	if !errors.As(err, &te) {
+		t.Fatalf("UpdateIssue without assignee error = %v, want TransitionError", err)
+	}
+	if err := store.CloseIssue(ctx, issue.ID, "done", "test", ""); !errors.As(err, &te) {
+		t.Fatalf("CloseIssue from open error = %v, want TransitionError", err)
+	}
+	got, _ := store.GetIssue(ctx, issue.ID)
+	if got.Status != types.StatusOpen {
+		t.Errorf("status = %s after rejected changes, want open", got.Status)
+	}
+
+	// Guards see fields set in the same update
+	if err := store.UpdateIssue(ctx, issue.ID, map[string]interface{}{"status": string(types.StatusInProgress), "assignee": "alice"}, "test"); err != nil {
+		t.Fatalf("UpdateIssue with assignee failed: %v", err)
+	}
+
+	// Non-status updates are never checked
+	if err := store.UpdateIssue(ctx, issue.ID, map[string]interface{}{"title": "Renamed"}, "test"); err != nil {
+		t.Fatalf("UpdateIssue(title) failed: %v", err)
+	}
+
+	if err := store.CloseIssue(validation.WithWorkflowForce(ctx), issue.ID, "done", "test", ""); err != nil {
+		t.Fatalf("forced CloseIssue failed: %v", err)
+	}
+	events, err := store.GetEvents(ctx, issue.ID, 0)
+	if err != nil {
+		t.Fatalf("GetEvents failed: %v", err)
+	}
+	var override *types.Event
+	for _, e := range events {
+		if e.EventType == types.EventWorkflowOverride {
+			override = e
+		}
+	}
+	if override == nil {
+		t.Fatal("expected a workflow_override event")
+	}
+	if override.OldValue == nil || *override.OldValue != string(types.StatusInProgress) ||
+		override.NewValue == nil || *override.NewValue != string(types.StatusClosed) {
+		t.Errorf("override event = %v -> %v, want in_progress -> closed", override.OldValue, override.NewValue)
+	}
+
+	// Imports replay state without the workflow
+	if err := store.UpdateIssue(validation.WithoutWorkflow(ctx), issue.ID, map[string]interface{}{"status": string(types.StatusOpen)}, "test"); err != nil {
+		t.Fatalf("UpdateIssue without workflow failed: %v", err)
+	}
+}
+
+func TestTransaction_Workflow(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	setWorkflow(t)
+	ctx := context.Background()
+
+	issue := &types.Issue{Title: "Task", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+
+	err := store.RunInTransaction(ctx, func(tx storage.Transaction) error {
+		return tx.CloseIssue(ctx, issue.ID, "done", "test", "")
+	})
+	var te *validation.TransitionError
+	if !errors.As(err, &te) {
+		t.Fatalf("transaction CloseIssue error = %v, want TransitionError", err)
+	}
+
+	forced := validation.WithWorkflowForce(ctx)
+	if err := store.RunInTransaction(forced, func(tx storage.Transaction) error {
+		return tx.UpdateIssue(forced, issue.ID, map[string]interface{}{"status": string(types.StatusReview)}, "test")
+	}); err != nil {
+		t.Fatalf("forced transaction UpdateIssue failed: %v", err)
+	}
+	events, _ := store.GetEvents(ctx, issue.ID, 0)
+	found := false
+	for _, e := range events {
+		found = found || e.EventType == types.EventWorkflowOverride
+	}
+	if !found {
+		t.Error("expected a workflow_override event")
+	}
+}
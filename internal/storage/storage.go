@@ -92,9 +92,30 @@ type Storage interface {
 	// Returns (true, nil) if claim succeeded, (false, nil) if already claimed by someone else,
 	// or (false, error) if the operation failed.
 	ClaimIssue(ctx context.Context, id string, assignee string) (bool, error)
+	// ClaimIssueWithLease is ClaimIssue with a claim that lapses at leaseUntil
+	// (nil for no lease). An in_progress issue whose lease has lapsed can be
+	// claimed again.
+	ClaimIssueWithLease(ctx context.Context, id string, assignee string, leaseUntil *time.Time) (bool, error)
+	// RenewLease moves the lease deadline of an in_progress issue assigned to
+	// assignee. It fails if someone else holds the issue or it was reclaimed.
+	// Only the lease changes; updated_at and the dirty set are left alone.
+	RenewLease(ctx context.Context, id string, assignee string, leaseUntil time.Time) error
+	// ReclaimExpiredLeases returns in_progress issues whose lease lapsed at or
+	// before now to open and unassigned, recording a lease_expired event for
+	// each, and returns the reclaimed issues.
+	ReclaimExpiredLeases(ctx context.Context, now time.Time, actor string) ([]*types.Issue, error)
+	// ClearRecurrence clears the recurrence rule of issue id if it is still
+	// rule, and reports whether it did. Only one of several concurrent callers
+	// wins, which makes clearing the rule a claim on spawning its successor.
+	ClearRecurrence(ctx context.Context, id string, rule string, actor string) (bool, error)
 	CloseIssue(ctx context.Context, id string, reason string, actor string, session string) error
 	DeleteIssue(ctx context.Context, id string) error
 	SearchIssues(ctx context.Context, query string, filter types.IssueFilter) ([]*types.Issue, error)
+	// SearchIssuesRanked runs a full-text query (see internal/search for syntax)
+	// over title, description, design, acceptance criteria, notes and comments,
+	// returning BM25-ranked hits with highlighted snippets. The filter narrows
+	// the candidate set; filter.Limit caps the number of hits.
+	SearchIssuesRanked(ctx context.Context, query string, filter types.IssueFilter) ([]*types.SearchResult, error)
 
 	// Dependencies
 	AddDependency(ctx context.Context, dep *types.Dependency, actor string) error
@@ -129,6 +150,7 @@ type Storage interface {
 	// Events
 	AddComment(ctx context.Context, issueID, actor, comment string) error
 	GetEvents(ctx context.Context, issueID string, limit int) ([]*types.Event, error)
+	GetEventsForIssues(ctx context.Context, issueIDs []string) (map[string][]*types.Event, error) // Oldest first
 
 	// Comments
 	AddIssueComment(ctx context.Context, issueID, author, text string) (*types.Comment, error)
@@ -138,6 +160,23 @@ type Storage interface {
 	GetIssueComments(ctx context.Context, issueID string) ([]*types.Comment, error)
 	GetCommentsForIssues(ctx context.Context, issueIDs []string) (map[string][]*types.Comment, error)
 
+	// Work log (time tracking)
+	// SaveWorkLogEntry inserts an entry, or replaces the entry with the same ID.
+	// Stopping a timer and importing an entry both go through here.
+	SaveWorkLogEntry(ctx context.Context, entry *types.WorkLogEntry) error
+	GetWorkLog(ctx context.Context, issueID string) ([]*types.WorkLogEntry, error)
+	GetWorkLogForIssues(ctx context.Context, issueIDs []string) (map[string][]*types.WorkLogEntry, error)
+
+	// Attachments (metadata only; content lives in .beads/attachments)
+	// SaveAttachment inserts an attachment, or replaces the one with the same ID.
+	// Detaching and importing both go through here.
+	SaveAttachment(ctx context.Context, attachment *types.Attachment) error
+	GetAttachments(ctx context.Context, issueID string) ([]*types.Attachment, error)
+	GetAttachmentsForIssues(ctx context.Context, issueIDs []string) (map[string][]*types.Attachment, error)
+	// GetAttachmentHashes returns the content hashes of all attachments that
+	// haven't been detached, for garbage collection of unreferenced blobs.
+	GetAttachmentHashes(ctx context.Context) (map[string]bool, error)
+
 	// Statistics
 	GetStatistics(ctx context.Context) (*types.Statistics, error)
 
@@ -39,6 +39,18 @@ func (m *mockStorage) UpdateIssue(ctx context.Context, id string, updates map[st
 func (m *mockStorage) ClaimIssue(ctx context.Context, id string, assignee string) (bool, error) {
 	return true, nil
 }
+func (m *mockStorage) ClaimIssueWithLease(ctx context.Context, id string, assignee string, leaseUntil *time.Time) (bool, error) {
+	return true, nil
+}
+func (m *mockStorage) RenewLease(ctx context.Context, id string, assignee string, leaseUntil time.Time) error {
+	return nil
+}
+func (m *mockStorage) ReclaimExpiredLeases(ctx context.Context, now time.Time, actor string) ([]*types.Issue, error) {
+	return nil, nil
+}
+func (m *mockStorage) ClearRecurrence(ctx context.Context, id string, rule string, actor string) (bool, error) {
+	return true, nil
+}
 func (m *mockStorage) CloseIssue(ctx context.Context, id string, reason string, actor string, session string) error {
 	return nil
 }
@@ -48,6 +60,9 @@ func (m *mockStorage) DeleteIssue(ctx context.Context, id string) error {
 func (m *mockStorage) SearchIssues(ctx context.Context, query string, filter types.IssueFilter) ([]*types.Issue, error) {
 	return nil, nil
 }
+func (m *mockStorage) SearchIssuesRanked(ctx context.Context, query string, filter types.IssueFilter) ([]*types.SearchResult, error) {
+	return nil, nil
+}
 func (m *mockStorage) AddDependency(ctx context.Context, dep *types.Dependency, actor string) error {
 	return nil
 }
@@ -126,6 +141,9 @@ func (m *mockStorage) AddComment(ctx context.Context, issueID, actor, comment st
 func (m *mockStorage) GetEvents(ctx context.Context, issueID string, limit int) ([]*types.Event, error) {
 	return nil, nil
 }
+func (m *mockStorage) GetEventsForIssues(ctx context.Context, issueIDs []string) (map[string][]*types.Event, error) {
+	return nil, nil
+}
 func (m *mockStorage) AddIssueComment(ctx context.Context, issueID, author, text string) (*types.Comment, error) {
 	return nil, nil
 }
@@ -138,6 +156,27 @@ func (m *mockStorage) GetIssueComments(ctx context.Context, issueID string) ([]*
 func (m *mockStorage) GetCommentsForIssues(ctx context.Context, issueIDs []string) (map[string][]*types.Comment, error) {
 	return nil, nil
 }
+func (m *mockStorage) SaveWorkLogEntry(ctx context.Context, entry *types.WorkLogEntry) error {
+	return nil
+}
+func (m *mockStorage) GetWorkLog(ctx context.Context, issueID string) ([]*types.WorkLogEntry, error) {
+	return nil, nil
+}
+func (m *mockStorage) GetWorkLogForIssues(ctx context.Context, issueIDs []string) (map[string][]*types.WorkLogEntry, error) {
+	return nil, nil
+}
+func (m *mockStorage) SaveAttachment(ctx context.Context, attachment *types.Attachment) error {
+	return nil
+}
+func (m *mockStorage) GetAttachments(ctx context.Context, issueID string) ([]*types.Attachment, error) {
+	return nil, nil
+}
+func (m *mockStorage) GetAttachmentsForIssues(ctx context.Context, issueIDs []string) (map[string][]*types.Attachment, error) {
+	return nil, nil
+}
+func (m *mockStorage) GetAttachmentHashes(ctx context.Context) (map[string]bool, error) {
+	return nil, nil
+}
 func (m *mockStorage) GetStatistics(ctx context.Context) (*types.Statistics, error) {
 	return nil, nil
 }
@@ -333,6 +372,7 @@ func TestInterfaceDocumentation(t *testing.T) {
 		_ = s.CloseIssue
 		_ = s.DeleteIssue
 		_ = s.SearchIssues
+		_ = s.SearchIssuesRanked
 
 		// Verify dependency operations
 		_ = s.AddDependency
@@ -361,10 +401,22 @@ func TestInterfaceDocumentation(t *testing.T) {
 		// Verify event/comment operations
 		_ = s.AddComment
 		_ = s.GetEvents
+		_ = s.GetEventsForIssues
 		_ = s.AddIssueComment
 		_ = s.GetIssueComments
 		_ = s.GetCommentsForIssues
 
+		// Verify work log
+		_ = s.SaveWorkLogEntry
+		_ = s.GetWorkLog
+		_ = s.GetWorkLogForIssues
+
+		// Verify attachments
+		_ = s.SaveAttachment
+		_ = s.GetAttachments
+		_ = s.GetAttachmentsForIssues
+		_ = s.GetAttachmentHashes
+
 		// Verify statistics
 		_ = s.GetStatistics
 
@@ -8,17 +8,12 @@ import (
 	"github.com/steveyegge/beads/internal/types"
 )
 
-// VersionedStorage extends Storage with version control capabilities.
-// This interface is implemented by storage backends that support history,
-// branching, and merging (e.g., Dolt).
-//
-// Not all storage backends support versioning. Use IsVersioned() to check
-// if a storage instance supports these operations before calling them.
-type VersionedStorage interface {
+// HistoryStorage extends Storage with read-only history queries.
+// Dolt answers them from its commit graph; SQLite from the git history of
+// the JSONL export.
+type HistoryStorage interface {
 	Storage // Embed base interface
 
-	// History queries
-
 	// History returns the complete version history for an issue.
 	// Results are ordered by commit date, most recent first.
 	History(ctx context.Context, issueID string) ([]*HistoryEntry, error)
@@ -30,6 +25,16 @@ type VersionedStorage interface {
 	// Diff returns changes between two commits/branches.
 	// Shows which issues were added, modified, or removed.
 	Diff(ctx context.Context, fromRef, toRef string) ([]*DiffEntry, error)
+}
+
+// VersionedStorage extends Storage with version control capabilities.
+// This interface is implemented by storage backends that support history,
+// branching, and merging (e.g., Dolt).
+//
+// Not all storage backends support versioning. Use IsVersioned() to check
+// if a storage instance supports these operations before calling them.
+type VersionedStorage interface {
+	HistoryStorage // Storage plus history queries
 
 	// Branch operations
 
@@ -118,6 +123,13 @@ func AsVersioned(s Storage) (VersionedStorage, bool) {
 	return vs, ok
 }
 
+// AsHistory attempts to cast a Storage to HistoryStorage.
+// Returns the HistoryStorage and true if successful, nil and false otherwise.
+func AsHistory(s Storage) (HistoryStorage, bool) {
+	hs, ok := s.(HistoryStorage)
+	return hs, ok
+}
+
 // RemoteStorage extends VersionedStorage with remote synchronization capabilities.
 // This interface is implemented by storage backends that support push/pull to
 // remote repositories (e.g., Dolt with DoltHub remotes).
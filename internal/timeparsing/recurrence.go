@@ -0,0 +1,439 @@
+package timeparsing
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Recurrence is a parsed recurrence rule.
+//
+// Two kinds of rules are supported:
+//   - Calendar rules fire at matching wall-clock times. They come from cron
+//     expressions ("0 9 * * 1") or phrases like "every monday 09:00".
+//   - Interval rules repeat a fixed step from the previous occurrence
+//     ("every 2 weeks", "every 3d", "monthly"), using the compact duration
+//     units h, d, w, m and y.
+type Recurrence struct {
+	spec     string
+	schedule *cronSchedule // Calendar rule
+	amount   int           // Interval rule: step amount
+	unit     string        // Interval rule: step unit (h, d, w, m, y)
+}
+
+// String returns the rule as it was written.
+func (r *Recurrence) String() string {
+	return r.spec
+}
+
+// IsCalendar reports whether the rule fires at wall-clock times rather than
+// at a fixed interval from the previous occurrence.
+func (r *Recurrence) IsCalendar() bool {
+	return r.schedule != nil
+}
+
+// Next returns the first occurrence strictly after now.
+//
+// Calendar rules match wall-clock times in now's location. Interval rules
+// step from anchor, the previous occurrence, skipping occurrences missed
+// before now so the rule keeps its phase (a weekly issue closed late stays on
+// the same weekday). A zero anchor steps from now.
+func (r *Recurrence) Next(anchor, now time.Time) time.Time {
+	if r.schedule != nil {
+		return r.schedule.next(now)
+	}
+
+	if anchor.IsZero() {
+		anchor = now
+	}
+	// Step from the anchor each time instead of from the previous step, so
+	// month ends don't drift (Jan 31 + 1m + 1m is Mar 31, not Apr 3)
+	for k := 1; k < 1_000_000; k++ {
+		next := applyDuration(anchor, k*r.amount, r.unit)
+		if next.After(now) {
+			return next
+		}
+	}
+	return applyDuration(now, r.amount, r.unit)
+}
+
+// ParseRecurrence parses a recurrence rule.
+//
+// Accepted forms:
+//   - Cron expressions: "0 9 * * 1-5", "30 8 1 * *", "@weekly"
+//   - Named days: "every monday 09:00", "every mon, wed and fri at 9am",
+//     "every weekday at 8:30", "weekly on friday"
+//   - Days of the month: "every month on the 1st", "monthly on the 15th at 10:00"
+//   - Daily: "every day at 17:00", "daily at noon"
+//   - Intervals: "hourly", "daily", "weekly", "monthly", "yearly",
+//     "every 2 weeks", "every 3 days", "every 6h", "every +2w"
+//
+// Calendar rules without a time of day fire at midnight. Times are in the
+// local time zone.
+func ParseRecurrence(s string) (*Recurrence, error) {
+	spec := strings.TrimSpace(s)
+	if spec == "" {
+		return nil, fmt.Errorf("empty recurrence")
+	}
+	text := strings.ToLower(strings.Join(strings.Fields(spec), " "))
+
+	var r *Recurrence
+	if looksLikeCron(text) {
+		schedule, err := parseCron(text)
+		if err != nil {
+			return nil, err
+		}
+		r = &Recurrence{schedule: schedule}
+	} else {
+		var err error
+		if r, err = parseRecurrencePhrase(text); err != nil {
+			return nil, fmt.Errorf("cannot parse recurrence %q: %w (examples: \"every monday 09:00\", \"every 2 weeks\", \"0 9 * * 1-5\")", spec, err)
+		}
+	}
+	if r.schedule != nil && r.schedule.next(time.Now()).IsZero() {
+		return nil, fmt.Errorf("recurrence %q never occurs", spec)
+	}
+	r.spec = spec
+	return r, nil
+}
+
+var (
+	// timeOfDayRe matches a trailing time of day. Bare numbers need "at" so
+	// "every 2" isn't read as 2 o'clock.
+	timeOfDayRe = regexp.MustCompile(`(?:\s+at\s+(\d{1,2}(?::\d{2})?\s*(?:am|pm)?|noon|midnight)|\s+(\d{1,2}:\d{2}\s*(?:am|pm)?|\d{1,2}\s*(?:am|pm)|noon|midnight))$`)
+	clockRe     = regexp.MustCompile(`^(\d{1,2})(?::(\d{2}))?\s*(am|pm)?$`)
+	intervalRe  = regexp.MustCompile(`^every (\d+) (hour|day|week|month|year)s?$`)
+	monthDayRe  = regexp.MustCompile(`^(?:every month|monthly) on (?:the )?(\d{1,2})(?:st|nd|rd|th)?$`)
+	dayListRe   = regexp.MustCompile(`\s*(?:,|\band\b)\s*|\s+`)
+)
+
+// intervalKeywords maps interval phrases to compact duration steps.
+var intervalKeywords = map[string]string{
+	"hourly": "1h", "every hour": "1h",
+	"daily": "1d", "every day": "1d",
+	"weekly": "1w", "every week": "1w",
+	"monthly": "1m", "every month": "1m",
+	"yearly": "1y", "annually": "1y", "every year": "1y",
+}
+
+var weekdayNames = map[string]int{
+	"sunday": 0, "sun": 0,
+	"monday": 1, "mon": 1,
+	"tuesday": 2, "tue": 2, "tues": 2,
+	"wednesday": 3, "wed": 3,
+	"thursday": 4, "thu": 4, "thur": 4, "thurs": 4,
+	"friday": 5, "fri": 5,
+	"saturday": 6, "sat": 6,
+}
+
+func parseRecurrencePhrase(text string) (*Recurrence, error) {
+	hour, minute := 0, 0
+	hasTime := false
+	if m := timeOfDayRe.FindStringSubmatch(text); m != nil {
+		clock := m[1]
+		if clock == "" {
+			clock = m[2]
+		}
+		var err error
+		if hour, minute, err = parseClock(clock); err != nil {
+			return nil, err
+		}
+		hasTime = true
+		text = strings.TrimSpace(text[:len(text)-len(m[0])])
+	}
+	calendar := func(dom, dow string) (*Recurrence, error) {
+		schedule, err := parseCron(fmt.Sprintf("%d %d %s * %s", minute, hour, dom, dow))
+		if err != nil {
+			return nil, err
+		}
+		return &Recurrence{schedule: schedule}, nil
+	}
+
+	// Daily with a time of day is a calendar rule; without one it repeats
+	// from the previous occurrence like the other interval keywords
+	if hasTime && (text == "daily" || text == "every day") {
+		return calendar("*", "*")
+	}
+	if step, ok := intervalKeywords[text]; ok {
+		if hasTime {
+			return nil, fmt.Errorf("a time of day needs a day (e.g. \"every monday at 9am\")")
+		}
+		return intervalRecurrence(step)
+	}
+	if m := intervalRe.FindStringSubmatch(text); m != nil {
+		if hasTime {
+			return nil, fmt.Errorf("intervals can't have a time of day")
+		}
+		return intervalRecurrence(m[1] + m[2][:1])
+	}
+	if rest, ok := strings.CutPrefix(text, "every "); ok && IsCompactDuration(strings.TrimPrefix(rest, "+")) {
+		if hasTime {
+			return nil, fmt.Errorf("intervals can't have a time of day")
+		}
+		return intervalRecurrence(strings.TrimPrefix(rest, "+"))
+	}
+	if m := monthDayRe.FindStringSubmatch(text); m != nil {
+		return calendar(m[1], "*")
+	}
+
+	var days string
+	switch {
+	case strings.HasPrefix(text, "every "):
+		days = strings.TrimPrefix(text, "every ")
+	case strings.HasPrefix(text, "weekly on "):
+		days = strings.TrimPrefix(text, "weekly on ")
+	default:
+		return nil, fmt.Errorf("unrecognized phrase")
+	}
+	switch days {
+	case "weekday", "weekdays":
+		return calendar("*", "1-5")
+	case "weekend", "weekends":
+		return calendar("*", "0,6")
+	}
+	var dow []string
+	for _, name := range dayListRe.Split(days, -1) {
+		if name == "" {
+			continue
+		}
+		n, ok := weekdayNames[strings.TrimSuffix(name, "s")]
+		if !ok {
+			n, ok = weekdayNames[name]
+		}
+		if !ok {
+			return nil, fmt.Errorf("unknown day %q", name)
+		}
+		dow = append(dow, strconv.Itoa(n))
+	}
+	if len(dow) == 0 {
+		return nil, fmt.Errorf("no days given")
+	}
+	return calendar("*", strings.Join(dow, ","))
+}
+
+// intervalRecurrence builds an interval rule from a compact duration step.
+func intervalRecurrence(step string) (*Recurrence, error) {
+	m := compactDurationRe.FindStringSubmatch(step)
+	if m == nil || m[1] == "-" {
+		return nil, fmt.Errorf("invalid interval %q", step)
+	}
+	amount, err := strconv.Atoi(m[2])
+	if err != nil || amount <= 0 {
+		return nil, fmt.Errorf("invalid interval %q", step)
+	}
+	return &Recurrence{amount: amount, unit: m[3]}, nil
+}
+
+// parseClock parses "9", "09:30", "9am", "5:15 pm", "noon" and "midnight".
+func parseClock(s string) (hour, minute int, err error) {
+	switch s {
+	case "noon":
+		return 12, 0, nil
+	case "midnight":
+		return 0, 0, nil
+	}
+	m := clockRe.FindStringSubmatch(s)
+	if m == nil {
+		return 0, 0, fmt.Errorf("invalid time of day %q", s)
+	}
+	hour, _ = strconv.Atoi(m[1])
+	if m[2] != "" {
+		minute, _ = strconv.Atoi(m[2])
+	}
+	switch m[3] {
+	case "am", "pm":
+		if hour < 1 || hour > 12 {
+			return 0, 0, fmt.Errorf("invalid time of day %q", s)
+		}
+		hour %= 12
+		if m[3] == "pm" {
+			hour += 12
+		}
+	}
+	if hour > 23 || minute > 59 {
+		return 0, 0, fmt.Errorf("invalid time of day %q", s)
+	}
+	return hour, minute, nil
+}
+
+// cronMacros maps cron shorthands to their five-field expressions.
+var cronMacros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+var (
+	cronFieldRe       = regexp.MustCompile(`^[0-9a-z*,/\-]+$`)
+	cronMinuteFieldRe = regexp.MustCompile(`^[0-9*,/\-]+$`)
+)
+
+// looksLikeCron reports whether text is a cron expression rather than a phrase.
+func looksLikeCron(text string) bool {
+	if strings.HasPrefix(text, "@") {
+		return true
+	}
+	fields := strings.Fields(text)
+	if len(fields) != 5 || !cronMinuteFieldRe.MatchString(fields[0]) {
+		return false
+	}
+	for _, f := range fields {
+		if !cronFieldRe.MatchString(f) {
+			return false
+		}
+	}
+	return true
+}
+
+// cronSchedule is a parsed five-field cron expression. Each field is a
+// bitset of allowed values.
+type cronSchedule struct {
+	minute, hour, dom, month, dow uint64
+	domStar, dowStar              bool
+}
+
+var monthNames = map[string]int{
+	"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+	"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+}
+
+var cronDayNames = map[string]int{
+	"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+}
+
+func parseCron(text string) (*cronSchedule, error) {
+	if strings.HasPrefix(text, "@") {
+		expanded, ok := cronMacros[text]
+		if !ok {
+			return nil, fmt.Errorf("unknown cron macro %q", text)
+		}
+		text = expanded
+	}
+	fields := strings.Fields(text)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("cron expression needs 5 fields (minute hour day-of-month month day-of-week), got %d", len(fields))
+	}
+
+	var s cronSchedule
+	var err error
+	if s.minute, err = parseCronField(fields[0], 0, 59, nil); err != nil {
+		return nil, fmt.Errorf("cron minute: %w", err)
+	}
+	if s.hour, err = parseCronField(fields[1], 0, 23, nil); err != nil {
+		return nil, fmt.Errorf("cron hour: %w", err)
+	}
+	if s.dom, err = parseCronField(fields[2], 1, 31, nil); err != nil {
+		return nil, fmt.Errorf("cron day of month: %w", err)
+	}
+	if s.month, err = parseCronField(fields[3], 1, 12, monthNames); err != nil {
+		return nil, fmt.Errorf("cron month: %w", err)
+	}
+	if s.dow, err = parseCronField(fields[4], 0, 7, cronDayNames); err != nil {
+		return nil, fmt.Errorf("cron day of week: %w", err)
+	}
+	// 7 is an alias for Sunday
+	if s.dow&(1<<7) != 0 {
+		s.dow = s.dow&^(1<<7) | 1
+	}
+	s.domStar = strings.HasPrefix(fields[2], "*")
+	s.dowStar = strings.HasPrefix(fields[4], "*")
+	return &s, nil
+}
+
+// parseCronField parses a comma-separated list of values, ranges (a-b) and
+// steps (*/n, a-b/n) into a bitset.
+func parseCronField(field string, min, max int, names map[string]int) (uint64, error) {
+	value := func(s string) (int, error) {
+		if n, ok := names[s]; ok {
+			return n, nil
+		}
+		n, err := strconv.Atoi(s)
+		if err != nil || n < min || n > max {
+			return 0, fmt.Errorf("invalid value %q (want %d-%d)", s, min, max)
+		}
+		return n, nil
+	}
+
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		rng, stepStr, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			n, err := strconv.Atoi(stepStr)
+			if err != nil || n <= 0 {
+				return 0, fmt.Errorf("invalid step %q", stepStr)
+			}
+			step = n
+		}
+
+		lo, hi := min, max
+		if rng != "*" {
+			from, to, isRange := strings.Cut(rng, "-")
+			var err error
+			if lo, err = value(from); err != nil {
+				return 0, err
+			}
+			hi = lo
+			if isRange {
+				if hi, err = value(to); err != nil {
+					return 0, err
+				}
+			} else if hasStep {
+				hi = max
+			}
+			if hi < lo {
+				return 0, fmt.Errorf("invalid range %q", rng)
+			}
+		}
+		for v := lo; v <= hi; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	return bits, nil
+}
+
+// matchesDay applies cron's day rule: when both day-of-month and day-of-week
+// are restricted, either may match.
+func (s *cronSchedule) matchesDay(t time.Time) bool {
+	domMatch := s.dom&(1<<uint(t.Day())) != 0
+	dowMatch := s.dow&(1<<uint(t.Weekday())) != 0
+	if s.domStar || s.dowStar {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
+
+// next returns the first matching minute strictly after t, searching up to
+// five years ahead (enough for any valid expression, e.g. Feb 29).
+func (s *cronSchedule) next(t time.Time) time.Time {
+	loc := t.Location()
+	t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute()+1, 0, 0, loc)
+	limit := t.AddDate(5, 0, 0)
+
+	for t.Before(limit) {
+		if s.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if !s.matchesDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if s.hour&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+			continue
+		}
+		if s.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
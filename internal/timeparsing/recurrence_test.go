@@ -0,0 +1,119 @@
+package timeparsing
+
+import (
+	"testing"
+	"time"
+)
+
+// TestParseRecurrence_Next checks the next occurrence of each supported form.
+func TestParseRecurrence_Next(t *testing.T) {
+	// Fixed reference time: Wednesday, January 15, 2025, 10:00:00 AM
+	now := time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC)
+	// Previous occurrence for interval rules: Monday, January 6, 2025, 9:00 AM
+	anchor := time.Date(2025, 1, 6, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		input    string
+		want     time.Time
+		calendar bool
+	}{
+		// Named days
+		{"weekday and time", "every monday 09:00", time.Date(2025, 1, 20, 9, 0, 0, 0, time.UTC), true},
+		{"weekday at am time", "Every Monday at 9am", time.Date(2025, 1, 20, 9, 0, 0, 0, time.UTC), true},
+		{"later today", "every wednesday at 5:30 pm", time.Date(2025, 1, 15, 17, 30, 0, 0, time.UTC), true},
+		{"day list", "every mon, wed and fri at 9am", time.Date(2025, 1, 17, 9, 0, 0, 0, time.UTC), true},
+		{"plural day", "every fridays", time.Date(2025, 1, 17, 0, 0, 0, 0, time.UTC), true},
+		{"weekdays", "every weekday at 8:30", time.Date(2025, 1, 16, 8, 30, 0, 0, time.UTC), true},
+		{"weekends", "every weekend", time.Date(2025, 1, 18, 0, 0, 0, 0, time.UTC), true},
+		{"weekly on", "weekly on thursday at noon", time.Date(2025, 1, 16, 12, 0, 0, 0, time.UTC), true},
+
+		// Days of the month
+		{"month day", "every month on the 1st", time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC), true},
+		{"monthly on", "monthly on the 15th at 11:00", time.Date(2025, 1, 15, 11, 0, 0, 0, time.UTC), true},
+
+		// Daily with time
+		{"daily at", "daily at 09:00", time.Date(2025, 1, 16, 9, 0, 0, 0, time.UTC), true},
+		{"every day at", "every day at midnight", time.Date(2025, 1, 16, 0, 0, 0, 0, time.UTC), true},
+
+		// Intervals keep the anchor's phase
+		{"weekly", "weekly", time.Date(2025, 1, 20, 9, 0, 0, 0, time.UTC), false},
+		{"every 2 weeks", "every 2 weeks", time.Date(2025, 1, 20, 9, 0, 0, 0, time.UTC), false},
+		{"every 3 days", "every 3 days", time.Date(2025, 1, 18, 9, 0, 0, 0, time.UTC), false},
+		{"compact", "every 4d", time.Date(2025, 1, 18, 9, 0, 0, 0, time.UTC), false},
+		{"compact with sign", "every +2w", time.Date(2025, 1, 20, 9, 0, 0, 0, time.UTC), false},
+		{"hourly", "hourly", time.Date(2025, 1, 15, 11, 0, 0, 0, time.UTC), false},
+		{"monthly", "monthly", time.Date(2025, 2, 6, 9, 0, 0, 0, time.UTC), false},
+
+		// Cron
+		{"cron weekdays", "0 9 * * 1-5", time.Date(2025, 1, 16, 9, 0, 0, 0, time.UTC), true},
+		{"cron step", "*/20 * * * *", time.Date(2025, 1, 15, 10, 20, 0, 0, time.UTC), true},
+		{"cron names", "30 8 * jan,feb mon", time.Date(2025, 1, 20, 8, 30, 0, 0, time.UTC), true},
+		{"cron sunday as 7", "0 0 * * 7", time.Date(2025, 1, 19, 0, 0, 0, 0, time.UTC), true},
+		{"cron dom or dow", "0 12 20 * 4", time.Date(2025, 1, 16, 12, 0, 0, 0, time.UTC), true},
+		{"cron macro", "@monthly", time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC), true},
+		{"cron leap day", "0 0 29 2 *", time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := ParseRecurrence(tt.input)
+			if err != nil {
+				t.Fatalf("ParseRecurrence(%q) error: %v", tt.input, err)
+			}
+			if r.String() != tt.input {
+				t.Errorf("String() = %q, want %q", r.String(), tt.input)
+			}
+			if r.IsCalendar() != tt.calendar {
+				t.Errorf("IsCalendar() = %v, want %v", r.IsCalendar(), tt.calendar)
+			}
+			if got := r.Next(anchor, now); !got.Equal(tt.want) {
+				t.Errorf("Next() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestParseRecurrence_Errors tests rejected recurrence rules.
+func TestParseRecurrence_Errors(t *testing.T) {
+	inputs := []string{
+		"",
+		"sometimes",
+		"every blursday",
+		"every 2 weeks at 9am",
+		"weekly at 10:00",
+		"every monday at 25:00",
+		"every monday at 13pm",
+		"61 * * * *",
+		"0 9 * * 1-8",
+		"0 0 31 2 *",
+		"*/0 * * * *",
+		"@fortnightly",
+	}
+	for _, input := range inputs {
+		if _, err := ParseRecurrence(input); err == nil {
+			t.Errorf("ParseRecurrence(%q) succeeded, want error", input)
+		}
+	}
+}
+
+// TestRecurrence_NextIntervalNoDrift tests that monthly steps from the 31st
+// don't drift to the start of the following month.
+func TestRecurrence_NextIntervalNoDrift(t *testing.T) {
+	r, err := ParseRecurrence("monthly")
+	if err != nil {
+		t.Fatal(err)
+	}
+	anchor := time.Date(2025, 1, 31, 9, 0, 0, 0, time.UTC)
+	now := time.Date(2025, 3, 5, 0, 0, 0, 0, time.UTC)
+	want := time.Date(2025, 3, 31, 9, 0, 0, 0, time.UTC)
+	if got := r.Next(anchor, now); !got.Equal(want) {
+		t.Errorf("Next() = %v, want %v", got, want)
+	}
+
+	// A zero anchor steps from now
+	want = now.AddDate(0, 1, 0)
+	if got := r.Next(time.Time{}, now); !got.Equal(want) {
+		t.Errorf("Next() with zero anchor = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,116 @@
+package tracker
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// DiffIssues returns the synced fields that differ between two versions of
+// an issue, in a stable order. Labels are compared as sets.
+func DiffIssues(from, to *types.Issue) []FieldChange {
+	var changes []FieldChange
+	add := func(field, old, new string) {
+		if old != new {
+			changes = append(changes, FieldChange{Field: field, Old: old, New: new})
+		}
+	}
+
+	add("title", from.Title, to.Title)
+	add("description", from.Description, to.Description)
+	add("design", from.Design, to.Design)
+	add("acceptance_criteria", from.AcceptanceCriteria, to.AcceptanceCriteria)
+	add("notes", from.Notes, to.Notes)
+	add("status", string(from.Status), string(to.Status))
+	add("priority", strconv.Itoa(from.Priority), strconv.Itoa(to.Priority))
+	add("issue_type", string(from.IssueType), string(to.IssueType))
+	add("assignee", from.Assignee, to.Assignee)
+	add("labels", labelSet(from.Labels), labelSet(to.Labels))
+	add("custom_fields", fieldSet(from.CustomFields), fieldSet(to.CustomFields))
+
+	return changes
+}
+
+// labelSet renders labels as a sorted, comma-separated list.
+func labelSet(labels []string) string {
+	sorted := append([]string(nil), labels...)
+	sort.Strings(sorted)
+	return strings.Join(sorted, ",")
+}
+
+// fieldSet renders custom fields as a sorted, comma-separated list of
+// name=value pairs.
+func fieldSet(fields map[string]string) string {
+	pairs := make([]string, 0, len(fields))
+	for name, value := range fields {
+		pairs = append(pairs, name+"="+value)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+// LocalUpdates builds the UpdateIssue map that makes a local issue match a
+// pulled one. Labels are not included; they are reconciled separately.
+// Custom fields are included only if the adapter set them.
+func LocalUpdates(issue *types.Issue) map[string]interface{} {
+	updates := map[string]interface{}{
+		"title":               issue.Title,
+		"description":         issue.Description,
+		"design":              issue.Design,
+		"acceptance_criteria": issue.AcceptanceCriteria,
+		"notes":               issue.Notes,
+		"priority":            issue.Priority,
+		"issue_type":          string(issue.IssueType),
+		"status":              string(issue.Status),
+		"assignee":            issue.Assignee,
+	}
+	if issue.ClosedAt != nil {
+		updates["closed_at"] = *issue.ClosedAt
+	}
+	if issue.CustomFields != nil {
+		updates["custom_fields"] = issue.CustomFields
+	}
+	return updates
+}
+
+// longFields are shown by name only when formatting a change.
+var longFields = map[string]bool{
+	"description":         true,
+	"design":              true,
+	"acceptance_criteria": true,
+	"notes":               true,
+}
+
+// FormatChange renders a planned change on one line, e.g.
+//
+//	~ bd-a1b2 -> PROJ-12: status (open → closed), description
+func FormatChange(c Change) string {
+	if c.Action == ActionCreate {
+		id := c.IssueID
+		if c.Direction == DirectionPull {
+			id = c.RemoteID
+		}
+		return fmt.Sprintf("+ %s %q", id, c.Title)
+	}
+
+	arrow := "->"
+	if c.Direction == DirectionPull {
+		arrow = "<-"
+	}
+	fields := make([]string, len(c.Fields))
+	for i, f := range c.Fields {
+		if longFields[f.Field] {
+			fields[i] = f.Field
+		} else {
+			fields[i] = fmt.Sprintf("%s (%s → %s)", f.Field, f.Old, f.New)
+		}
+	}
+	line := fmt.Sprintf("~ %s %s %s", c.IssueID, arrow, c.RemoteID)
+	if len(fields) > 0 {
+		line += ": " + strings.Join(fields, ", ")
+	}
+	return line
+}
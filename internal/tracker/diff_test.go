@@ -0,0 +1,80 @@
+package tracker
+
+import (
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestDiffIssues(t *testing.T) {
+	from := &types.Issue{
+		Title:       "Title",
+		Description: "Old",
+		Status:      types.StatusOpen,
+		Priority:    2,
+		Labels:      []string{"b", "a"},
+	}
+	to := &types.Issue{
+		Title:       "Title",
+		Description: "New",
+		Status:      types.StatusClosed,
+		Priority:    2,
+		Labels:      []string{"a", "b"},
+	}
+
+	changes := DiffIssues(from, to)
+	if len(changes) != 2 {
+		t.Fatalf("changes = %+v, want description and status", changes)
+	}
+	if changes[0].Field != "description" || changes[1].Field != "status" {
+		t.Errorf("fields = %s, %s", changes[0].Field, changes[1].Field)
+	}
+	if changes[1].Old != "open" || changes[1].New != "closed" {
+		t.Errorf("status change = %+v", changes[1])
+	}
+
+	if changes := DiffIssues(from, from); len(changes) != 0 {
+		t.Errorf("DiffIssues(x, x) = %+v, want none", changes)
+	}
+
+	from.CustomFields = map[string]string{"points": "3", "customer": "Acme"}
+	to = &types.Issue{Title: "Title", Description: "Old", Status: types.StatusOpen, Priority: 2,
+		Labels: []string{"a", "b"}, CustomFields: map[string]string{"customer": "Acme", "points": "5"}}
+	changes = DiffIssues(from, to)
+	if len(changes) != 1 || changes[0].Old != "customer=Acme,points=3" || changes[0].New != "customer=Acme,points=5" {
+		t.Errorf("custom field changes = %+v", changes)
+	}
+}
+
+func TestFormatChange(t *testing.T) {
+	tests := []struct {
+		change Change
+		want   string
+	}{
+		{
+			Change{Direction: DirectionPush, Action: ActionCreate, IssueID: "bd-1", Title: "New"},
+			`+ bd-1 "New"`,
+		},
+		{
+			Change{Direction: DirectionPull, Action: ActionCreate, RemoteID: "PROJ-1", Title: "New"},
+			`+ PROJ-1 "New"`,
+		},
+		{
+			Change{
+				Direction: DirectionPush, Action: ActionUpdate, IssueID: "bd-1", RemoteID: "PROJ-1",
+				Fields: []FieldChange{{Field: "status", Old: "open", New: "closed"}, {Field: "description", Old: "a", New: "b"}},
+			},
+			"~ bd-1 -> PROJ-1: status (open → closed), description",
+		},
+		{
+			Change{Direction: DirectionPull, Action: ActionUpdate, IssueID: "bd-1", RemoteID: "PROJ-1"},
+			"~ bd-1 <- PROJ-1",
+		},
+	}
+
+	for _, tt := range tests {
+		if got := FormatChange(tt.change); got != tt.want {
+			t.Errorf("FormatChange(%+v) = %q, want %q", tt.change, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,843 @@
+package tracker
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/validation"
+)
+
+// ImportFunc writes pulled issues to the local store. Linked issues carry the
+// ID of their local copy and should only be updated if the incoming version
+// is newer. It returns how many issues were created, updated and skipped.
+type ImportFunc func(ctx context.Context, issues []*types.Issue, dryRun bool) (created, updated, skipped int, err error)
+
+// Engine syncs a local store with one tracker through its Adapter.
+type Engine struct {
+	Adapter Adapter
+	Store   storage.Storage
+	Actor   string
+
+	// Import writes pulled issues to the store. It is required for pulls.
+	Import ImportFunc
+
+	// GenerateIDs assigns IDs to pulled issues that don't have one yet.
+	// If nil, new issues are passed to Import without IDs.
+	GenerateIDs func(issues []*types.Issue) error
+
+	// Out receives progress output; Err receives warnings.
+	Out io.Writer
+	Err io.Writer
+}
+
+// NewEngine creates an engine that writes progress to stdout and warnings
+// to stderr.
+func NewEngine(adapter Adapter, store storage.Storage, actor string) *Engine {
+	return &Engine{
+		Adapter: adapter,
+		Store:   store,
+		Actor:   actor,
+		Out:     os.Stdout,
+		Err:     os.Stderr,
+	}
+}
+
+// PullOptions controls a pull.
+type PullOptions struct {
+	DryRun  bool
+	State   string          // "open", "closed" or "all"
+	SkipIDs map[string]bool // Remote IDs to leave untouched (local wins a conflict)
+}
+
+// PushOptions controls a push.
+type PushOptions struct {
+	DryRun       bool
+	CreateOnly   bool            // Only create remote issues; don't update linked ones
+	UpdateRefs   bool            // Set external_ref on local issues after creating them remotely
+	ForceIDs     map[string]bool // Local IDs to push even if the remote looks newer (local wins a conflict)
+	SkipIDs      map[string]bool // Local IDs not to push (remote wins a conflict)
+	Types        []string        // Only push issues of these types (empty means all)
+	ExcludeTypes []string        // Never push issues of these types
+}
+
+// SyncOptions controls a full sync. If neither Pull nor Push is set, both are.
+type SyncOptions struct {
+	Pull         bool
+	Push         bool
+	DryRun       bool
+	State        string
+	Policy       ConflictPolicy
+	CreateOnly   bool
+	UpdateRefs   bool
+	Types        []string
+	ExcludeTypes []string
+}
+
+func (e *Engine) printf(format string, args ...interface{}) {
+	fmt.Fprintf(e.Out, format, args...)
+}
+
+func (e *Engine) warnf(format string, args ...interface{}) {
+	fmt.Fprintf(e.Err, "Warning: "+format+"\n", args...)
+}
+
+// CursorKey returns the config key holding the incremental sync cursor.
+func (e *Engine) CursorKey() string {
+	return e.Adapter.Name() + ".last_sync"
+}
+
+// Cursor returns the time of the last successful sync, or the zero time if
+// there hasn't been one.
+func (e *Engine) Cursor(ctx context.Context) (time.Time, error) {
+	value, _ := e.Store.GetConfig(ctx, e.CursorKey())
+	if value == "" {
+		return time.Time{}, nil
+	}
+	cursor, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid %s timestamp: %w", e.CursorKey(), err)
+	}
+	return cursor, nil
+}
+
+// SetCursor records the time of a successful sync.
+func (e *Engine) SetCursor(ctx context.Context, t time.Time) error {
+	return e.Store.SetConfig(ctx, e.CursorKey(), t.Format(time.RFC3339))
+}
+
+// linkedIssues returns local issues linked to the tracker, keyed by remote
+// ID, with their labels populated.
+func (e *Engine) linkedIssues(ctx context.Context) (map[string]*types.Issue, error) {
+	allIssues, err := e.Store.SearchIssues(ctx, "", types.IssueFilter{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get local issues: %w", err)
+	}
+
+	linked := make(map[string]*types.Issue)
+	var issues []*types.Issue
+	for _, issue := range allIssues {
+		if issue.ExternalRef == nil || !e.Adapter.IsExternalRef(*issue.ExternalRef) {
+			continue
+		}
+		if id := e.Adapter.ExtractID(*issue.ExternalRef); id != "" {
+			linked[id] = issue
+			issues = append(issues, issue)
+		}
+	}
+
+	if err := e.populateLabels(ctx, issues); err != nil {
+		return nil, err
+	}
+	return linked, nil
+}
+
+// populateLabels fills in Labels for the given issues.
+func (e *Engine) populateLabels(ctx context.Context, issues []*types.Issue) error {
+	if len(issues) == 0 {
+		return nil
+	}
+	ids := make([]string, len(issues))
+	for i, issue := range issues {
+		ids[i] = issue.ID
+	}
+	labelsByID, err := e.Store.GetLabelsForIssues(ctx, ids)
+	if err != nil {
+		return fmt.Errorf("failed to get labels: %w", err)
+	}
+	for _, issue := range issues {
+		issue.Labels = labelsByID[issue.ID]
+	}
+	return nil
+}
+
+// syncsLabels reports whether label removals are propagated from the tracker.
+func (e *Engine) syncsLabels() bool {
+	syncer, ok := e.Adapter.(LabelSyncer)
+	return ok && syncer.SyncsLabels()
+}
+
+// syncLabels adds the labels in want that a local issue is missing. If
+// remove is set, labels not in want are removed as well.
+func (e *Engine) syncLabels(ctx context.Context, issueID string, current, want []string, remove bool) error {
+	have := make(map[string]bool, len(current))
+	for _, l := range current {
+		have[l] = true
+	}
+	keep := make(map[string]bool, len(want))
+	for _, l := range want {
+		keep[l] = true
+		if !have[l] {
+			if err := e.Store.AddLabel(ctx, issueID, l, e.Actor); err != nil {
+				return fmt.Errorf("failed to add label %q: %w", l, err)
+			}
+		}
+	}
+	if !remove {
+		return nil
+	}
+	for _, l := range current {
+		if !keep[l] {
+			if err := e.Store.RemoveLabel(ctx, issueID, l, e.Actor); err != nil {
+				return fmt.Errorf("failed to remove label %q: %w", l, err)
+			}
+		}
+	}
+	return nil
+}
+
+// Pull imports remote issues. After the first sync, only issues updated
+// since the cursor are fetched.
+func (e *Engine) Pull(ctx context.Context, opts PullOptions) (*PullStats, error) {
+	stats := &PullStats{}
+	name := e.Adapter.DisplayName()
+
+	since, err := e.Cursor(ctx)
+	if err != nil {
+		e.warnf("%v, doing full sync", err)
+	}
+
+	remoteIssues, err := e.Adapter.FetchIssues(ctx, opts.State, since)
+	if err != nil {
+		return stats, fmt.Errorf("failed to fetch issues from %s: %w", name, err)
+	}
+	if !since.IsZero() {
+		stats.Incremental = true
+		stats.SyncedSince = since.Format(time.RFC3339)
+	}
+	if !opts.DryRun {
+		if stats.Incremental {
+			e.printf("  Incremental sync since %s\n", since.Format("2006-01-02 15:04:05"))
+		} else {
+			e.printf("  Full sync (no previous sync timestamp)\n")
+		}
+	}
+
+	if len(remoteIssues) == 0 {
+		e.printf("  No issues to import\n")
+		return stats, nil
+	}
+
+	linked, err := e.linkedIssues(ctx)
+	if err != nil {
+		return stats, err
+	}
+
+	depAdapter, _ := e.Adapter.(DependencyAdapter)
+
+	// Convert, matching already-linked issues to their local IDs so that
+	// updates (and labels) land on the existing issue.
+	var issues []*types.Issue
+	var labelSyncs []*types.Issue
+	var deps []Dependency
+	for i := range remoteIssues {
+		remote := &remoteIssues[i]
+		if opts.SkipIDs[remote.ID] {
+			stats.Skipped++
+			continue
+		}
+
+		local := linked[remote.ID]
+		issue := e.Adapter.ToLocal(remote, local)
+		if local != nil {
+			issue.ID = local.ID
+			issue.SourceSystem = local.SourceSystem
+			if issue.UpdatedAt.After(local.UpdatedAt) {
+				labelSyncs = append(labelSyncs, issue)
+				if fields := DiffIssues(local, issue); opts.DryRun && len(fields) > 0 {
+					stats.Changes = append(stats.Changes, Change{
+						Direction: DirectionPull, Action: ActionUpdate,
+						IssueID: local.ID, RemoteID: remote.ID, Title: issue.Title, Fields: fields,
+					})
+				}
+			}
+		} else {
+			issue.SourceSystem = e.Adapter.Name()
+			if opts.DryRun {
+				stats.Changes = append(stats.Changes, Change{
+					Direction: DirectionPull, Action: ActionCreate,
+					RemoteID: remote.ID, Title: issue.Title,
+				})
+			}
+		}
+		issues = append(issues, issue)
+
+		if depAdapter != nil {
+			for _, dep := range depAdapter.Dependencies(remote) {
+				if !opts.SkipIDs[dep.FromID] && !opts.SkipIDs[dep.ToID] {
+					deps = append(deps, dep)
+				}
+			}
+		}
+	}
+
+	if len(issues) == 0 {
+		e.printf("  No issues to import\n")
+		return stats, nil
+	}
+
+	if e.GenerateIDs != nil {
+		if err := e.GenerateIDs(issues); err != nil {
+			return stats, fmt.Errorf("failed to generate issue IDs: %w", err)
+		}
+	}
+
+	created, updated, skipped, err := e.Import(ctx, issues, opts.DryRun)
+	if err != nil {
+		return stats, fmt.Errorf("import failed: %w", err)
+	}
+	stats.Created = created
+	stats.Updated = updated
+	stats.Skipped += skipped
+
+	if opts.DryRun {
+		if stats.Incremental {
+			e.printf("  Would import %d issues from %s (incremental since %s)\n",
+				len(issues), name, stats.SyncedSince)
+		} else {
+			e.printf("  Would import %d issues from %s (full sync)\n", len(issues), name)
+		}
+		e.printChanges(stats.Changes)
+		return stats, nil
+	}
+
+	// Import only adds labels; when the remote has the newer version,
+	// also drop labels that were removed there.
+	if e.syncsLabels() {
+		for _, issue := range labelSyncs {
+			current, err := e.Store.GetLabels(ctx, issue.ID)
+			if err != nil {
+				e.warnf("failed to get labels for %s: %v", issue.ID, err)
+				continue
+			}
+			if err := e.syncLabels(ctx, issue.ID, current, issue.Labels, true); err != nil {
+				e.warnf("failed to sync labels for %s: %v", issue.ID, err)
+			}
+		}
+	}
+
+	if len(deps) > 0 {
+		e.addDependencies(ctx, deps)
+	}
+
+	return stats, nil
+}
+
+// addDependencies links the local copies of related remote issues.
+func (e *Engine) addDependencies(ctx context.Context, deps []Dependency) {
+	linked, err := e.linkedIssues(ctx)
+	if err != nil {
+		e.warnf("failed to fetch issues for dependency mapping: %v", err)
+		return
+	}
+
+	created := 0
+	for _, dep := range deps {
+		from, fromOK := linked[dep.FromID]
+		to, toOK := linked[dep.ToID]
+		if !fromOK || !toOK {
+			continue
+		}
+
+		dependency := &types.Dependency{
+			IssueID:     from.ID,
+			DependsOnID: to.ID,
+			Type:        types.DependencyType(dep.Type),
+			CreatedAt:   time.Now(),
+		}
+		if err := e.Store.AddDependency(ctx, dependency, e.Actor); err != nil {
+			if !strings.Contains(err.Error(), "already exists") &&
+				!strings.Contains(err.Error(), "duplicate") {
+				e.warnf("failed to create dependency %s -> %s (%s): %v", from.ID, to.ID, dep.Type, err)
+			}
+			continue
+		}
+		created++
+	}
+
+	if created > 0 {
+		e.printf("  Created %d dependencies from %s relations\n", created, e.Adapter.DisplayName())
+	}
+}
+
+// filterTypes applies include/exclude issue type filters (case-insensitive).
+func filterTypes(issues []*types.Issue, include, exclude []string) []*types.Issue {
+	if len(include) == 0 && len(exclude) == 0 {
+		return issues
+	}
+
+	includeSet := make(map[string]bool, len(include))
+	for _, t := range include {
+		includeSet[strings.ToLower(t)] = true
+	}
+	excludeSet := make(map[string]bool, len(exclude))
+	for _, t := range exclude {
+		excludeSet[strings.ToLower(t)] = true
+	}
+
+	var filtered []*types.Issue
+	for _, issue := range issues {
+		issueType := strings.ToLower(string(issue.IssueType))
+		if len(include) > 0 && !includeSet[issueType] {
+			continue
+		}
+		if excludeSet[issueType] {
+			continue
+		}
+		filtered = append(filtered, issue)
+	}
+	return filtered
+}
+
+// Push exports local issues: unlinked issues are created remotely and linked
+// issues that changed locally since the remote was updated are overwritten.
+func (e *Engine) Push(ctx context.Context, opts PushOptions) (*PushStats, error) {
+	stats := &PushStats{}
+	name := e.Adapter.DisplayName()
+
+	allIssues, err := e.Store.SearchIssues(ctx, "", types.IssueFilter{})
+	if err != nil {
+		return stats, fmt.Errorf("failed to get local issues: %w", err)
+	}
+	allIssues = filterTypes(allIssues, opts.Types, opts.ExcludeTypes)
+
+	var toCreate []*types.Issue
+	var toUpdate []*types.Issue
+	for _, issue := range allIssues {
+		if issue.IsTombstone() || issue.Ephemeral {
+			continue
+		}
+		if issue.ExternalRef == nil {
+			toCreate = append(toCreate, issue)
+		} else if e.Adapter.IsExternalRef(*issue.ExternalRef) && !opts.CreateOnly {
+			toUpdate = append(toUpdate, issue)
+		}
+	}
+
+	if err := e.populateLabels(ctx, append(append([]*types.Issue(nil), toCreate...), toUpdate...)); err != nil {
+		return stats, err
+	}
+
+	for _, issue := range toCreate {
+		if opts.DryRun {
+			stats.Created++
+			stats.Changes = append(stats.Changes, Change{
+				Direction: DirectionPush, Action: ActionCreate,
+				IssueID: issue.ID, Title: issue.Title,
+			})
+			continue
+		}
+
+		remote, err := e.Adapter.CreateIssue(ctx, issue)
+		if remote == nil {
+			e.warnf("failed to create issue '%s' in %s: %v", issue.Title, name, err)
+			stats.Errors++
+			continue
+		}
+		if err != nil {
+			// Created, but a follow-up step failed; still link it.
+			e.warnf("%v", err)
+			stats.Errors++
+		}
+
+		stats.Created++
+		e.printf("  Created: %s -> %s\n", issue.ID, remote.ID)
+
+		if ref := e.Adapter.ExternalRef(remote); opts.UpdateRefs && ref != "" {
+			updates := map[string]interface{}{
+				"external_ref": ref,
+			}
+			if err := e.Store.UpdateIssue(ctx, issue.ID, updates, e.Actor); err != nil {
+				e.warnf("failed to update external_ref for %s: %v", issue.ID, err)
+				stats.Errors++
+			}
+		}
+	}
+
+	for _, issue := range toUpdate {
+		if opts.SkipIDs[issue.ID] {
+			stats.Skipped++
+			continue
+		}
+
+		id := e.Adapter.ExtractID(*issue.ExternalRef)
+		if id == "" {
+			e.warnf("could not extract %s identifier from %s: %s", name, issue.ID, *issue.ExternalRef)
+			stats.Errors++
+			continue
+		}
+
+		remote, err := e.Adapter.FetchIssue(ctx, id)
+		if err != nil {
+			e.warnf("failed to fetch %s issue %s: %v", name, id, err)
+			stats.Errors++
+			continue
+		}
+		if remote == nil {
+			e.warnf("%s issue %s not found (may have been deleted)", name, id)
+			stats.Skipped++
+			continue
+		}
+
+		if !opts.ForceIDs[issue.ID] {
+			if !issue.UpdatedAt.After(remote.UpdatedAt) || e.Adapter.Matches(issue, remote) {
+				stats.Skipped++
+				continue
+			}
+		}
+
+		if opts.DryRun {
+			stats.Updated++
+			stats.Changes = append(stats.Changes, Change{
+				Direction: DirectionPush, Action: ActionUpdate,
+				IssueID: issue.ID, RemoteID: id, Title: issue.Title,
+				Fields: DiffIssues(e.Adapter.ToLocal(remote, issue), issue),
+			})
+			continue
+		}
+
+		if err := e.Adapter.UpdateIssue(ctx, remote, issue); err != nil {
+			e.warnf("failed to update %s issue %s: %v", name, id, err)
+			stats.Errors++
+			continue
+		}
+
+		stats.Updated++
+		e.printf("  Updated: %s -> %s\n", issue.ID, id)
+	}
+
+	if opts.DryRun {
+		e.printf("  Would create %d issues in %s\n", stats.Created, name)
+		if !opts.CreateOnly {
+			e.printf("  Would update %d issues in %s\n", stats.Updated, name)
+		}
+		e.printChanges(stats.Changes)
+	}
+
+	return stats, nil
+}
+
+// DetectConflicts finds linked issues that have been modified both locally
+// and remotely since the last sync. This is a more expensive operation as it
+// fetches each locally-modified linked issue from the tracker.
+func (e *Engine) DetectConflicts(ctx context.Context) ([]Conflict, error) {
+	since, err := e.Cursor(ctx)
+	if err != nil || since.IsZero() {
+		return nil, err
+	}
+
+	linked, err := e.linkedIssues(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(linked))
+	for id := range linked {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	var conflicts []Conflict
+	for _, id := range ids {
+		issue := linked[id]
+		if !issue.UpdatedAt.After(since) {
+			continue
+		}
+
+		remote, err := e.Adapter.FetchIssue(ctx, id)
+		if err != nil {
+			e.warnf("failed to fetch %s issue %s for conflict check: %v", e.Adapter.DisplayName(), id, err)
+			continue
+		}
+		if remote == nil || !remote.UpdatedAt.After(since) {
+			continue
+		}
+
+		if e.Adapter.Matches(issue, remote) {
+			continue
+		}
+
+		conflicts = append(conflicts, Conflict{
+			IssueID:       issue.ID,
+			RemoteID:      id,
+			ExternalRef:   *issue.ExternalRef,
+			LocalUpdated:  issue.UpdatedAt,
+			RemoteUpdated: remote.UpdatedAt,
+		})
+	}
+
+	return conflicts, nil
+}
+
+// SplitConflictsByTimestamp partitions conflicts by which side was modified
+// most recently. Ties go to the local version.
+func SplitConflictsByTimestamp(conflicts []Conflict) (remoteWins, localWins []Conflict) {
+	for _, conflict := range conflicts {
+		if conflict.RemoteUpdated.After(conflict.LocalUpdated) {
+			remoteWins = append(remoteWins, conflict)
+		} else {
+			localWins = append(localWins, conflict)
+		}
+	}
+	return remoteWins, localWins
+}
+
+// ApplyRemote resolves conflicts in favor of the tracker by overwriting each
+// local issue with the current remote version.
+func (e *Engine) ApplyRemote(ctx context.Context, conflicts []Conflict) error {
+	// The tracker's state wins, whatever the local workflow allows
+	ctx = validation.WithoutWorkflow(ctx)
+	name := e.Adapter.DisplayName()
+	resolved := 0
+	failed := 0
+
+	for _, conflict := range conflicts {
+		remote, err := e.Adapter.FetchIssue(ctx, conflict.RemoteID)
+		if err != nil {
+			e.warnf("failed to fetch %s for resolution: %v", conflict.RemoteID, err)
+			failed++
+			continue
+		}
+		if remote == nil {
+			e.warnf("%s issue %s not found, skipping", name, conflict.RemoteID)
+			failed++
+			continue
+		}
+
+		local, err := e.Store.GetIssue(ctx, conflict.IssueID)
+		if err != nil || local == nil {
+			e.warnf("failed to load local issue %s: %v", conflict.IssueID, err)
+			failed++
+			continue
+		}
+		local.Labels, _ = e.Store.GetLabels(ctx, local.ID)
+
+		issue := e.Adapter.ToLocal(remote, local)
+		if err := e.Store.UpdateIssue(ctx, local.ID, LocalUpdates(issue), e.Actor); err != nil {
+			e.warnf("failed to update local issue %s: %v", local.ID, err)
+			failed++
+			continue
+		}
+		if err := e.syncLabels(ctx, local.ID, local.Labels, issue.Labels, e.syncsLabels()); err != nil {
+			e.warnf("failed to sync labels for %s: %v", local.ID, err)
+		}
+
+		e.printf("  Resolved: %s <- %s (%s wins)\n", local.ID, conflict.RemoteID, name)
+		resolved++
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d conflict(s) failed to resolve", failed)
+	}
+
+	e.printf("  Resolved %d conflict(s) by keeping %s version\n", resolved, name)
+	return nil
+}
+
+// issueIDs returns the set of local issue IDs in conflicts.
+func issueIDs(conflicts []Conflict) map[string]bool {
+	ids := make(map[string]bool, len(conflicts))
+	for _, conflict := range conflicts {
+		ids[conflict.IssueID] = true
+	}
+	return ids
+}
+
+// remoteIDs returns the set of remote IDs in conflicts.
+func remoteIDs(conflicts []Conflict) map[string]bool {
+	ids := make(map[string]bool, len(conflicts))
+	for _, conflict := range conflicts {
+		ids[conflict.RemoteID] = true
+	}
+	return ids
+}
+
+// Sync pulls and/or pushes, resolving conflicts according to opts.Policy,
+// and advances the cursor after a successful sync. If the pull or push
+// fails, the partial result is returned along with the error.
+//
+// Conflicts are only detected when pulling. With PolicyManual, conflicting
+// issues are left alone on both sides and the cursor is not advanced, so
+// they are reported again on the next sync.
+func (e *Engine) Sync(ctx context.Context, opts SyncOptions) (*Result, error) {
+	result := &Result{Success: true}
+	name := e.Adapter.DisplayName()
+
+	pull, push := opts.Pull, opts.Push
+	if !pull && !push {
+		pull, push = true, true
+	}
+	policy := opts.Policy
+	if policy == "" {
+		policy = PolicyNewest
+	}
+
+	var conflicts []Conflict
+	detected := false
+	detect := func() {
+		found, err := e.DetectConflicts(ctx)
+		if err != nil {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("conflict detection failed: %v", err))
+			return
+		}
+		conflicts, detected = found, true
+	}
+
+	var skipPullIDs map[string]bool
+	if pull {
+		// With a fixed policy, detect conflicts before pulling so the pull
+		// doesn't overwrite local changes that should win.
+		if policy != PolicyNewest {
+			detect()
+			if policy == PolicyPreferLocal || policy == PolicyManual {
+				skipPullIDs = remoteIDs(conflicts)
+			}
+		}
+
+		if opts.DryRun {
+			e.printf("→ [DRY RUN] Would pull issues from %s\n", name)
+		} else {
+			e.printf("→ Pulling issues from %s...\n", name)
+		}
+
+		stats, err := e.Pull(ctx, PullOptions{DryRun: opts.DryRun, State: opts.State, SkipIDs: skipPullIDs})
+		if err != nil {
+			result.Success = false
+			result.Error = err.Error()
+			return result, err
+		}
+
+		result.Stats.Pulled = stats.Created + stats.Updated
+		result.Stats.Created += stats.Created
+		result.Stats.Updated += stats.Updated
+		result.Stats.Skipped += stats.Skipped
+		result.Changes = append(result.Changes, stats.Changes...)
+
+		if !opts.DryRun {
+			e.printf("✓ Pulled %d issues (%d created, %d updated)\n",
+				result.Stats.Pulled, stats.Created, stats.Updated)
+		}
+
+		if !detected && push {
+			detect()
+		}
+	}
+
+	var forceIDs, skipPushIDs map[string]bool
+	if len(conflicts) > 0 {
+		result.Stats.Conflicts = len(conflicts)
+
+		verb := "Resolving"
+		if opts.DryRun {
+			verb = "[DRY RUN] Would resolve"
+		}
+
+		var remoteWins, localWins []Conflict
+		switch policy {
+		case PolicyPreferLocal:
+			e.printf("→ %s %d conflicts (preferring local)\n", verb, len(conflicts))
+			localWins = conflicts
+		case PolicyPreferRemote:
+			e.printf("→ %s %d conflicts (preferring %s)\n", verb, len(conflicts), name)
+			remoteWins = conflicts
+		case PolicyManual:
+			e.printf("→ %d conflicts need manual resolution (left unchanged)\n", len(conflicts))
+			for _, conflict := range conflicts {
+				e.printf("  Conflict: %s <-> %s (local %s, %s %s)\n", conflict.IssueID, conflict.RemoteID,
+					conflict.LocalUpdated.Format(time.RFC3339), name, conflict.RemoteUpdated.Format(time.RFC3339))
+			}
+			result.Conflicts = conflicts
+			skipPushIDs = issueIDs(conflicts)
+		default:
+			e.printf("→ %s %d conflicts (newer wins)\n", verb, len(conflicts))
+			remoteWins, localWins = SplitConflictsByTimestamp(conflicts)
+			if len(remoteWins) > 0 {
+				e.printf("  %d conflict(s): %s is newer, will re-import\n", len(remoteWins), name)
+			}
+			if len(localWins) > 0 {
+				e.printf("  %d conflict(s): Local is newer, will push to %s\n", len(localWins), name)
+			}
+		}
+
+		if len(localWins) > 0 {
+			forceIDs = issueIDs(localWins)
+			if !opts.DryRun && push {
+				for _, conflict := range localWins {
+					e.printf("  Resolved: %s -> %s (local wins, will push)\n", conflict.IssueID, conflict.RemoteID)
+				}
+			}
+		}
+		if len(remoteWins) > 0 {
+			skipPushIDs = issueIDs(remoteWins)
+			if !opts.DryRun {
+				if err := e.ApplyRemote(ctx, remoteWins); err != nil {
+					result.Warnings = append(result.Warnings, fmt.Sprintf("conflict resolution failed: %v", err))
+				}
+			}
+		}
+	}
+
+	if push {
+		if opts.DryRun {
+			e.printf("→ [DRY RUN] Would push issues to %s\n", name)
+		} else {
+			e.printf("→ Pushing issues to %s...\n", name)
+		}
+
+		stats, err := e.Push(ctx, PushOptions{
+			DryRun:       opts.DryRun,
+			CreateOnly:   opts.CreateOnly,
+			UpdateRefs:   opts.UpdateRefs,
+			ForceIDs:     forceIDs,
+			SkipIDs:      skipPushIDs,
+			Types:        opts.Types,
+			ExcludeTypes: opts.ExcludeTypes,
+		})
+		if err != nil {
+			result.Success = false
+			result.Error = err.Error()
+			return result, err
+		}
+
+		result.Stats.Pushed = stats.Created + stats.Updated
+		result.Stats.Created += stats.Created
+		result.Stats.Updated += stats.Updated
+		result.Stats.Skipped += stats.Skipped
+		result.Stats.Errors += stats.Errors
+		result.Changes = append(result.Changes, stats.Changes...)
+
+		if !opts.DryRun {
+			e.printf("✓ Pushed %d issues (%d created, %d updated)\n",
+				result.Stats.Pushed, stats.Created, stats.Updated)
+		}
+	}
+
+	if !opts.DryRun {
+		if len(result.Conflicts) > 0 {
+			result.Warnings = append(result.Warnings,
+				fmt.Sprintf("%d unresolved conflict(s); %s was not advanced", len(result.Conflicts), e.CursorKey()))
+		} else {
+			now := time.Now()
+			if err := e.SetCursor(ctx, now); err != nil {
+				result.Warnings = append(result.Warnings, fmt.Sprintf("failed to update last_sync: %v", err))
+			} else {
+				result.LastSync = now.Format(time.RFC3339)
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// printChanges lists planned dry-run changes, one per line.
+func (e *Engine) printChanges(changes []Change) {
+	for _, change := range changes {
+		e.printf("    %s\n", FormatChange(change))
+	}
+}
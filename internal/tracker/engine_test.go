@@ -0,0 +1,457 @@
+package tracker
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage/memory"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// fakeAdapter is an in-memory tracker. Remote issues are stored as beads
+// issues and referenced as "fake:<id>".
+type fakeAdapter struct {
+	issues  map[string]*types.Issue
+	deps    map[string][]Dependency
+	nextID  int
+	updated []string
+	fetches []time.Time
+}
+
+func newFakeAdapter() *fakeAdapter {
+	return &fakeAdapter{issues: make(map[string]*types.Issue), deps: make(map[string][]Dependency)}
+}
+
+func (f *fakeAdapter) add(id, title string, updated time.Time, labels ...string) {
+	f.issues[id] = &types.Issue{
+		Title:     title,
+		Status:    types.StatusOpen,
+		Priority:  2,
+		IssueType: types.TypeTask,
+		Labels:    labels,
+		CreatedAt: updated,
+		UpdatedAt: updated,
+	}
+}
+
+func (f *fakeAdapter) remote(id string) *RemoteIssue {
+	issue := *f.issues[id]
+	return &RemoteIssue{ID: id, UpdatedAt: issue.UpdatedAt, Data: &issue}
+}
+
+func (f *fakeAdapter) Name() string        { return "fake" }
+func (f *fakeAdapter) DisplayName() string { return "Fake" }
+func (f *fakeAdapter) SyncsLabels() bool   { return true }
+
+func (f *fakeAdapter) FetchIssues(ctx context.Context, state string, since time.Time) ([]RemoteIssue, error) {
+	f.fetches = append(f.fetches, since)
+	ids := make([]string, 0, len(f.issues))
+	for id := range f.issues {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	var remote []RemoteIssue
+	for _, id := range ids {
+		if f.issues[id].UpdatedAt.Before(since) {
+			continue
+		}
+		remote = append(remote, *f.remote(id))
+	}
+	return remote, nil
+}
+
+func (f *fakeAdapter) FetchIssue(ctx context.Context, id string) (*RemoteIssue, error) {
+	if f.issues[id] == nil {
+		return nil, nil
+	}
+	return f.remote(id), nil
+}
+
+func (f *fakeAdapter) CreateIssue(ctx context.Context, issue *types.Issue) (*RemoteIssue, error) {
+	f.nextID++
+	id := fmt.Sprintf("F-%d", f.nextID)
+	f.add(id, issue.Title, time.Now(), issue.Labels...)
+	f.issues[id].Status = issue.Status
+	return f.remote(id), nil
+}
+
+func (f *fakeAdapter) UpdateIssue(ctx context.Context, remote *RemoteIssue, issue *types.Issue) error {
+	f.updated = append(f.updated, remote.ID)
+	stored := f.issues[remote.ID]
+	stored.Title = issue.Title
+	stored.Status = issue.Status
+	stored.Labels = issue.Labels
+	stored.UpdatedAt = time.Now()
+	return nil
+}
+
+func (f *fakeAdapter) ToLocal(remote *RemoteIssue, local *types.Issue) *types.Issue {
+	issue := *remote.Data.(*types.Issue)
+	ref := "fake:" + remote.ID
+	issue.ExternalRef = &ref
+	return &issue
+}
+
+func (f *fakeAdapter) Matches(local *types.Issue, remote *RemoteIssue) bool {
+	return len(DiffIssues(f.ToLocal(remote, local), local)) == 0
+}
+
+func (f *fakeAdapter) IsExternalRef(ref string) bool { return strings.HasPrefix(ref, "fake:") }
+func (f *fakeAdapter) ExtractID(ref string) string   { return strings.TrimPrefix(ref, "fake:") }
+
+func (f *fakeAdapter) ExternalRef(remote *RemoteIssue) string { return "fake:" + remote.ID }
+
+func (f *fakeAdapter) Dependencies(remote *RemoteIssue) []Dependency {
+	return f.deps[remote.ID]
+}
+
+// newTestEngine returns a quiet engine over a memory store whose importer,
+// like the real one, only updates linked issues when the incoming version
+// is newer.
+func newTestEngine(t *testing.T) (*Engine, *fakeAdapter) {
+	t.Helper()
+	store := memory.New("")
+	adapter := newFakeAdapter()
+	engine := NewEngine(adapter, store, "test")
+	engine.Out = io.Discard
+	engine.Err = io.Discard
+
+	engine.Import = func(ctx context.Context, issues []*types.Issue, dryRun bool) (int, int, int, error) {
+		var created, updated, skipped int
+		for _, issue := range issues {
+			if issue.ID != "" {
+				existing, err := store.GetIssue(ctx, issue.ID)
+				if err != nil {
+					return created, updated, skipped, err
+				}
+				if existing != nil {
+					if !issue.UpdatedAt.After(existing.UpdatedAt) {
+						skipped++
+						continue
+					}
+					updated++
+					if !dryRun {
+						if err := store.UpdateIssue(ctx, issue.ID, LocalUpdates(issue), "test"); err != nil {
+							return created, updated, skipped, err
+						}
+					}
+					continue
+				}
+			}
+			created++
+			if dryRun {
+				continue
+			}
+			labels := issue.Labels
+			if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+				return created, updated, skipped, err
+			}
+			for _, l := range labels {
+				if err := store.AddLabel(ctx, issue.ID, l, "test"); err != nil {
+					return created, updated, skipped, err
+				}
+			}
+		}
+		return created, updated, skipped, nil
+	}
+	return engine, adapter
+}
+
+// linked returns the local issue linked to a remote ID, with labels.
+func linked(t *testing.T, e *Engine, id string) *types.Issue {
+	t.Helper()
+	issues, err := e.linkedIssues(context.Background())
+	if err != nil {
+		t.Fatalf("linkedIssues failed: %v", err)
+	}
+	issue := issues[id]
+	if issue == nil {
+		t.Fatalf("no local issue linked to %s", id)
+	}
+	return issue
+}
+
+func TestPullCreatesAndLinks(t *testing.T) {
+	ctx := context.Background()
+	engine, adapter := newTestEngine(t)
+
+	old := time.Now().Add(-time.Hour)
+	adapter.add("F-1", "First", old, "backend")
+	adapter.add("F-2", "Second", old)
+	adapter.deps["F-2"] = []Dependency{{FromID: "F-2", ToID: "F-1", Type: string(types.DepBlocks)}}
+
+	stats, err := engine.Pull(ctx, PullOptions{State: "all"})
+	if err != nil {
+		t.Fatalf("Pull failed: %v", err)
+	}
+	if stats.Created != 2 || stats.Incremental {
+		t.Fatalf("stats = %+v, want 2 created in a full sync", stats)
+	}
+
+	first := linked(t, engine, "F-1")
+	if first.Title != "First" || first.SourceSystem != "fake" {
+		t.Errorf("First = %+v", first)
+	}
+	if strings.Join(first.Labels, ",") != "backend" {
+		t.Errorf("Labels = %v", first.Labels)
+	}
+
+	second := linked(t, engine, "F-2")
+	deps, err := engine.Store.GetDependencies(ctx, second.ID)
+	if err != nil {
+		t.Fatalf("GetDependencies failed: %v", err)
+	}
+	if len(deps) != 1 || deps[0].ID != first.ID {
+		t.Errorf("dependencies of %s = %v, want %s", second.ID, deps, first.ID)
+	}
+}
+
+func TestPullIncremental(t *testing.T) {
+	ctx := context.Background()
+	engine, adapter := newTestEngine(t)
+
+	cursor := time.Now().Add(-time.Minute).Truncate(time.Second)
+	if err := engine.SetCursor(ctx, cursor); err != nil {
+		t.Fatalf("SetCursor failed: %v", err)
+	}
+	adapter.add("F-1", "Stale", cursor.Add(-time.Hour))
+	adapter.add("F-2", "Fresh", cursor.Add(time.Second))
+
+	stats, err := engine.Pull(ctx, PullOptions{State: "all"})
+	if err != nil {
+		t.Fatalf("Pull failed: %v", err)
+	}
+	if !stats.Incremental || stats.Created != 1 {
+		t.Fatalf("stats = %+v, want 1 created incrementally", stats)
+	}
+	if got := adapter.fetches[0]; !got.Equal(cursor) {
+		t.Errorf("fetched since %v, want %v", got, cursor)
+	}
+}
+
+func TestPullRemovesLabels(t *testing.T) {
+	ctx := context.Background()
+	engine, adapter := newTestEngine(t)
+
+	adapter.add("F-1", "Issue", time.Now().Add(-time.Hour), "a", "b")
+	if _, err := engine.Pull(ctx, PullOptions{State: "all"}); err != nil {
+		t.Fatalf("Pull failed: %v", err)
+	}
+
+	adapter.issues["F-1"].Labels = []string{"b", "c"}
+	adapter.issues["F-1"].UpdatedAt = time.Now().Add(time.Minute)
+	if _, err := engine.Pull(ctx, PullOptions{State: "all"}); err != nil {
+		t.Fatalf("second Pull failed: %v", err)
+	}
+
+	issue := linked(t, engine, "F-1")
+	sort.Strings(issue.Labels)
+	if strings.Join(issue.Labels, ",") != "b,c" {
+		t.Errorf("Labels = %v, want [b c]", issue.Labels)
+	}
+}
+
+func TestPushCreatesAndUpdates(t *testing.T) {
+	ctx := context.Background()
+	engine, adapter := newTestEngine(t)
+
+	issue := &types.Issue{Title: "Local", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := engine.Store.CreateIssue(ctx, issue, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	wisp := &types.Issue{Title: "Wisp", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask, Ephemeral: true}
+	if err := engine.Store.CreateIssue(ctx, wisp, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+
+	stats, err := engine.Push(ctx, PushOptions{UpdateRefs: true})
+	if err != nil {
+		t.Fatalf("Push failed: %v", err)
+	}
+	if stats.Created != 1 || len(adapter.issues) != 1 {
+		t.Fatalf("stats = %+v, remote = %d issues; want 1 created", stats, len(adapter.issues))
+	}
+	local, _ := engine.Store.GetIssue(ctx, issue.ID)
+	if local.ExternalRef == nil || *local.ExternalRef != "fake:F-1" {
+		t.Fatalf("ExternalRef = %v, want fake:F-1", local.ExternalRef)
+	}
+
+	// Unchanged issues are skipped.
+	stats, err = engine.Push(ctx, PushOptions{UpdateRefs: true})
+	if err != nil {
+		t.Fatalf("second Push failed: %v", err)
+	}
+	if stats.Updated != 0 || stats.Skipped != 1 {
+		t.Errorf("stats = %+v, want 1 skipped", stats)
+	}
+
+	// Local edits newer than the remote are pushed.
+	adapter.issues["F-1"].UpdatedAt = time.Now().Add(-time.Minute)
+	if err := engine.Store.UpdateIssue(ctx, issue.ID, map[string]interface{}{"title": "Edited"}, "test"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	stats, err = engine.Push(ctx, PushOptions{UpdateRefs: true})
+	if err != nil {
+		t.Fatalf("third Push failed: %v", err)
+	}
+	if stats.Updated != 1 || adapter.issues["F-1"].Title != "Edited" {
+		t.Errorf("stats = %+v, remote title = %q; want edit pushed", stats, adapter.issues["F-1"].Title)
+	}
+}
+
+func TestPushTypeFilters(t *testing.T) {
+	ctx := context.Background()
+	engine, adapter := newTestEngine(t)
+
+	for _, issueType := range []types.IssueType{types.TypeTask, types.TypeBug, types.TypeChore} {
+		issue := &types.Issue{Title: string(issueType), Status: types.StatusOpen, Priority: 2, IssueType: issueType}
+		if err := engine.Store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+
+	stats, err := engine.Push(ctx, PushOptions{Types: []string{"task", "BUG"}, ExcludeTypes: []string{"bug"}})
+	if err != nil {
+		t.Fatalf("Push failed: %v", err)
+	}
+	if stats.Created != 1 || adapter.issues["F-1"].Title != "task" {
+		t.Errorf("stats = %+v, want only the task pushed", stats)
+	}
+}
+
+// setupConflict links one issue, then edits it on both sides after the
+// cursor. remoteNewer decides which edit is more recent.
+func setupConflict(t *testing.T, remoteNewer bool) (*Engine, *fakeAdapter, string, time.Time) {
+	t.Helper()
+	ctx := context.Background()
+	engine, adapter := newTestEngine(t)
+
+	adapter.add("F-1", "Original", time.Now().Add(-time.Hour))
+	if _, err := engine.Pull(ctx, PullOptions{State: "all"}); err != nil {
+		t.Fatalf("Pull failed: %v", err)
+	}
+	cursor := time.Now().Add(-time.Minute).Truncate(time.Second)
+	if err := engine.SetCursor(ctx, cursor); err != nil {
+		t.Fatalf("SetCursor failed: %v", err)
+	}
+
+	id := linked(t, engine, "F-1").ID
+	if err := engine.Store.UpdateIssue(ctx, id, map[string]interface{}{"title": "Local edit"}, "test"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	adapter.issues["F-1"].Title = "Remote edit"
+	if remoteNewer {
+		adapter.issues["F-1"].UpdatedAt = time.Now().Add(time.Minute)
+	} else {
+		adapter.issues["F-1"].UpdatedAt = cursor.Add(time.Second)
+	}
+	return engine, adapter, id, cursor
+}
+
+func TestSyncConflictPolicies(t *testing.T) {
+	tests := []struct {
+		policy      ConflictPolicy
+		remoteNewer bool
+		wantLocal   string
+		wantRemote  string
+		wantPending int
+	}{
+		{PolicyNewest, true, "Remote edit", "Remote edit", 0},
+		{PolicyNewest, false, "Local edit", "Local edit", 0},
+		{PolicyPreferLocal, true, "Local edit", "Local edit", 0},
+		{PolicyPreferRemote, false, "Remote edit", "Remote edit", 0},
+		{PolicyManual, true, "Local edit", "Remote edit", 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s/remoteNewer=%v", tt.policy, tt.remoteNewer), func(t *testing.T) {
+			ctx := context.Background()
+			engine, adapter, id, cursor := setupConflict(t, tt.remoteNewer)
+
+			result, err := engine.Sync(ctx, SyncOptions{State: "all", Policy: tt.policy, UpdateRefs: true})
+			if err != nil {
+				t.Fatalf("Sync failed: %v", err)
+			}
+
+			local, _ := engine.Store.GetIssue(ctx, id)
+			if local.Title != tt.wantLocal {
+				t.Errorf("local title = %q, want %q", local.Title, tt.wantLocal)
+			}
+			if got := adapter.issues["F-1"].Title; got != tt.wantRemote {
+				t.Errorf("remote title = %q, want %q", got, tt.wantRemote)
+			}
+			if len(result.Conflicts) != tt.wantPending {
+				t.Errorf("unresolved conflicts = %+v, want %d", result.Conflicts, tt.wantPending)
+			}
+
+			// The cursor only advances once every conflict is resolved.
+			after, err := engine.Cursor(ctx)
+			if err != nil {
+				t.Fatalf("Cursor failed: %v", err)
+			}
+			if advanced := after.After(cursor); advanced != (tt.wantPending == 0) {
+				t.Errorf("cursor advanced = %v, want %v", advanced, tt.wantPending == 0)
+			}
+		})
+	}
+}
+
+func TestSyncDryRun(t *testing.T) {
+	ctx := context.Background()
+	engine, adapter, id, cursor := setupConflict(t, true)
+
+	adapter.add("F-2", "New remote", time.Now())
+	unlinked := &types.Issue{Title: "New local", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := engine.Store.CreateIssue(ctx, unlinked, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+
+	result, err := engine.Sync(ctx, SyncOptions{DryRun: true, State: "all", UpdateRefs: true})
+	if err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+
+	var lines []string
+	for _, change := range result.Changes {
+		lines = append(lines, FormatChange(change))
+	}
+	want := []string{
+		fmt.Sprintf("~ %s <- F-1: title (Local edit → Remote edit)", id),
+		`+ F-2 "New remote"`,
+		fmt.Sprintf("+ %s %q", unlinked.ID, "New local"),
+	}
+	if strings.Join(lines, "\n") != strings.Join(want, "\n") {
+		t.Errorf("changes =\n%s\nwant\n%s", strings.Join(lines, "\n"), strings.Join(want, "\n"))
+	}
+
+	if len(adapter.issues) != 2 || len(adapter.updated) != 0 {
+		t.Error("dry run changed the remote")
+	}
+	if local, _ := engine.Store.GetIssue(ctx, id); local.Title != "Local edit" {
+		t.Errorf("dry run changed the local issue: %q", local.Title)
+	}
+	if after, _ := engine.Cursor(ctx); !after.Equal(cursor) {
+		t.Errorf("dry run moved the cursor to %v", after)
+	}
+}
+
+func TestParseConflictPolicy(t *testing.T) {
+	for _, name := range []string{"newest", "prefer-local", "prefer-remote", "manual"} {
+		policy, err := ParseConflictPolicy(name)
+		if err != nil || string(policy) != name {
+			t.Errorf("ParseConflictPolicy(%q) = %q, %v", name, policy, err)
+		}
+	}
+	if policy, err := ParseConflictPolicy(""); err != nil || policy != PolicyNewest {
+		t.Errorf("ParseConflictPolicy(\"\") = %q, %v; want newest", policy, err)
+	}
+	if _, err := ParseConflictPolicy("remote"); err == nil {
+		t.Error("expected an error for an unknown policy")
+	}
+}
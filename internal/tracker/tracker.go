@@ -0,0 +1,119 @@
+// Package tracker provides a generic two-way sync between beads and external
+// issue trackers.
+//
+// Each tracker (Linear, Jira, GitHub, ...) implements Adapter, which covers
+// talking to the remote API and mapping between remote and beads issues.
+// The Engine owns everything else: incremental cursors, linking remote issues
+// to local ones through external_ref, conflict detection and resolution
+// policies, dry-run diffs and statistics.
+package tracker
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// Adapter connects the sync engine to one external issue tracker.
+type Adapter interface {
+	// Name identifies the tracker. It is the config namespace (the cursor is
+	// stored under "<name>.last_sync") and the SourceSystem recorded on
+	// issues created by a pull.
+	Name() string
+
+	// DisplayName is the human-readable tracker name used in output.
+	DisplayName() string
+
+	// FetchIssues returns remote issues in the given state ("open", "closed"
+	// or "all"). If since is non-zero, only issues updated at or after since
+	// are returned.
+	FetchIssues(ctx context.Context, state string, since time.Time) ([]RemoteIssue, error)
+
+	// FetchIssue returns a single remote issue by identifier, or nil if it
+	// does not exist.
+	FetchIssue(ctx context.Context, id string) (*RemoteIssue, error)
+
+	// CreateIssue creates a remote issue from a local one, including its
+	// status. If the issue was created but a follow-up step failed, both
+	// the new issue and an error are returned.
+	CreateIssue(ctx context.Context, issue *types.Issue) (*RemoteIssue, error)
+
+	// UpdateIssue overwrites a remote issue with the local version.
+	UpdateIssue(ctx context.Context, remote *RemoteIssue, issue *types.Issue) error
+
+	// ToLocal maps a remote issue to a beads issue, applying the tracker's
+	// status, priority and type mappings. local is the linked local issue,
+	// or nil; adapters use it to keep local values that the tracker cannot
+	// represent. The returned issue has its ExternalRef set.
+	ToLocal(remote *RemoteIssue, local *types.Issue) *types.Issue
+
+	// Matches reports whether the remote issue already reflects the local
+	// issue, as far as the tracker can represent it.
+	Matches(local *types.Issue, remote *RemoteIssue) bool
+
+	// IsExternalRef reports whether an external_ref points at this tracker.
+	IsExternalRef(ref string) bool
+
+	// ExtractID returns the remote identifier from an external_ref, or ""
+	// if the ref is not recognized.
+	ExtractID(ref string) string
+
+	// ExternalRef returns the canonical external_ref for a remote issue.
+	ExternalRef(remote *RemoteIssue) string
+}
+
+// DependencyAdapter is implemented by adapters whose trackers model relations
+// between issues. After a pull, the engine adds the returned dependencies
+// between the local copies of the related issues.
+type DependencyAdapter interface {
+	Dependencies(remote *RemoteIssue) []Dependency
+}
+
+// LabelSyncer is implemented by adapters that push labels. When a pull brings
+// in a newer remote version, the engine also removes local labels that were
+// dropped remotely. For other adapters pulled labels are only ever added.
+type LabelSyncer interface {
+	SyncsLabels() bool
+}
+
+// RemoteIssue is an issue as seen by a tracker.
+type RemoteIssue struct {
+	ID        string      // Identifier used in external refs and FetchIssue (e.g., "PROJ-12")
+	UpdatedAt time.Time   // When the remote issue was last modified
+	Data      interface{} // Tracker-specific issue (e.g., *jira.Issue)
+}
+
+// Dependency is a relation between two remote issues, identified by their
+// remote IDs.
+type Dependency struct {
+	FromID string // Remote ID of the dependent issue
+	ToID   string // Remote ID of the dependency target
+	Type   string // Beads dependency type (blocks, related, duplicates, parent-child)
+}
+
+// ConflictPolicy decides which side wins when an issue was modified both
+// locally and remotely since the last sync.
+type ConflictPolicy string
+
+// Conflict policies.
+const (
+	PolicyNewest       ConflictPolicy = "newest"        // Most recently updated side wins (ties go to local)
+	PolicyPreferLocal  ConflictPolicy = "prefer-local"  // Local version always wins
+	PolicyPreferRemote ConflictPolicy = "prefer-remote" // Remote version always wins
+	PolicyManual       ConflictPolicy = "manual"        // Neither side is changed; conflicts are reported
+)
+
+// ParseConflictPolicy validates a conflict policy name. An empty name means
+// PolicyNewest.
+func ParseConflictPolicy(s string) (ConflictPolicy, error) {
+	switch policy := ConflictPolicy(s); policy {
+	case "":
+		return PolicyNewest, nil
+	case PolicyNewest, PolicyPreferLocal, PolicyPreferRemote, PolicyManual:
+		return policy, nil
+	default:
+		return "", fmt.Errorf("invalid conflict policy %q (expected newest, prefer-local, prefer-remote or manual)", s)
+	}
+}
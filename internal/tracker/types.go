@@ -0,0 +1,81 @@
+package tracker
+
+import "time"
+
+// Stats tracks statistics for a sync operation.
+type Stats struct {
+	Pulled    int `json:"pulled"`
+	Pushed    int `json:"pushed"`
+	Created   int `json:"created"`
+	Updated   int `json:"updated"`
+	Skipped   int `json:"skipped"`
+	Errors    int `json:"errors"`
+	Conflicts int `json:"conflicts"`
+}
+
+// Result represents the result of a sync operation.
+type Result struct {
+	Success   bool       `json:"success"`
+	Stats     Stats      `json:"stats"`
+	LastSync  string     `json:"last_sync,omitempty"`
+	Error     string     `json:"error,omitempty"`
+	Warnings  []string   `json:"warnings,omitempty"`
+	Conflicts []Conflict `json:"conflicts,omitempty"` // Unresolved conflicts (manual policy)
+	Changes   []Change   `json:"changes,omitempty"`   // Planned changes (dry run)
+}
+
+// PullStats tracks pull operation statistics.
+type PullStats struct {
+	Created     int
+	Updated     int
+	Skipped     int
+	Incremental bool     // Whether this was an incremental sync
+	SyncedSince string   // Timestamp we synced since (if incremental)
+	Changes     []Change // Planned changes (dry run)
+}
+
+// PushStats tracks push operation statistics.
+type PushStats struct {
+	Created int
+	Updated int
+	Skipped int
+	Errors  int
+	Changes []Change // Planned changes (dry run)
+}
+
+// Conflict represents an issue that was modified both locally and remotely
+// since the last sync.
+type Conflict struct {
+	IssueID       string    `json:"issue_id"`       // Beads issue ID
+	RemoteID      string    `json:"remote_id"`      // Remote identifier (e.g., "PROJ-12")
+	ExternalRef   string    `json:"external_ref"`   // external_ref of the local issue
+	LocalUpdated  time.Time `json:"local_updated"`  // When the local version was last modified
+	RemoteUpdated time.Time `json:"remote_updated"` // When the remote version was last modified
+}
+
+// Sync directions and actions for Change.
+const (
+	DirectionPull = "pull"
+	DirectionPush = "push"
+
+	ActionCreate = "create"
+	ActionUpdate = "update"
+)
+
+// Change describes one change a sync would make. Changes are collected
+// during dry runs.
+type Change struct {
+	Direction string        `json:"direction"`          // DirectionPull or DirectionPush
+	Action    string        `json:"action"`             // ActionCreate or ActionUpdate
+	IssueID   string        `json:"issue_id,omitempty"` // Local issue ID (empty for pulled creates)
+	RemoteID  string        `json:"remote_id,omitempty"`
+	Title     string        `json:"title"`
+	Fields    []FieldChange `json:"fields,omitempty"` // Changed fields (updates only)
+}
+
+// FieldChange is a single field difference within a Change.
+type FieldChange struct {
+	Field string `json:"field"`
+	Old   string `json:"old"`
+	New   string `json:"new"`
+}
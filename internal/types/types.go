@@ -2,9 +2,12 @@
 package types
 
 import (
+	"cmp"
 	"crypto/sha256"
 	"fmt"
 	"hash"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -32,6 +35,10 @@ type Issue struct {
 	Assignee         string `json:"assignee,omitempty"`
 	Owner            string `json:"owner,omitempty"` // Human owner for CV attribution (git author email)
 	EstimatedMinutes *int   `json:"estimated_minutes,omitempty"`
+	// Deadline of a leased claim (bd update --claim --lease). Once it passes
+	// the claim lapses and the daemon returns the issue to open; bd heartbeat
+	// renews it.
+	LeaseExpiresAt *time.Time `json:"lease_expires_at,omitempty"`
 
 	// ===== Timestamps =====
 	CreatedAt   time.Time  `json:"created_at"`
@@ -44,6 +51,12 @@ type Issue struct {
 	// ===== Time-Based Scheduling (GH#820) =====
 	DueAt      *time.Time `json:"due_at,omitempty"`      // When this issue should be completed
 	DeferUntil *time.Time `json:"defer_until,omitempty"` // Hide from bd ready until this time
+	Recurrence string     `json:"recurrence,omitempty"`  // Schedule for the next occurrence when closed ("every monday 09:00", cron)
+
+	// ===== Custom Fields =====
+	// Values of the fields defined under custom_fields in config.yaml, keyed
+	// by field name and stored in canonical form (see validation.FieldSchema)
+	CustomFields map[string]string `json:"custom_fields,omitempty"`
 
 	// ===== External Integration =====
 	ExternalRef  *string `json:"external_ref,omitempty"`  // e.g., "gh-9", "jira-ABC"
@@ -61,9 +74,11 @@ type Issue struct {
 	PrefixOverride string `json:"-"` // Completely replace config prefix (for cross-rig creation)
 
 	// ===== Relational Data (populated for export/import) =====
-	Labels       []string      `json:"labels,omitempty"`
-	Dependencies []*Dependency `json:"dependencies,omitempty"`
-	Comments     []*Comment    `json:"comments,omitempty"`
+	Labels       []string        `json:"labels,omitempty"`
+	Dependencies []*Dependency   `json:"dependencies,omitempty"`
+	Comments     []*Comment      `json:"comments,omitempty"`
+	WorkLog      []*WorkLogEntry `json:"work_log,omitempty"`
+	Attachments  []*Attachment   `json:"attachments,omitempty"`
 
 	// ===== Tombstone Fields (soft-delete support) =====
 	DeletedAt    *time.Time `json:"deleted_at,omitempty"`    // When deleted
@@ -149,6 +164,15 @@ func (i *Issue) ComputeContentHash() string {
 	w.str(i.SourceSystem)
 	w.flag(i.Pinned, "pinned")
 	w.flag(i.IsTemplate, "template")
+	// Recurrence is only hashed when set, so hashes of other issues don't change
+	if i.Recurrence != "" {
+		w.str(i.Recurrence)
+	}
+	// Custom fields likewise, in name order
+	for _, name := range i.CustomFieldNames() {
+		w.str(name)
+		w.str(i.CustomFields[name])
+	}
 
 	// Bonded molecules
 	for _, br := range i.BondedFrom {
@@ -309,6 +333,18 @@ func (i *Issue) IsExpired(ttl time.Duration) bool {
 	return time.Now().After(expirationTime)
 }
 
+// LeaseExpired reports whether the issue is in progress under a claim lease
+// that has passed. Such an issue can be claimed again.
+func (i *Issue) LeaseExpired(now time.Time) bool {
+	return i.Status == StatusInProgress && i.LeaseExpiresAt != nil && !now.Before(*i.LeaseExpiresAt)
+}
+
+// IsClaimed reports whether someone is actively working the issue: it is in
+// progress, assigned, and not past its claim lease.
+func (i *Issue) IsClaimed(now time.Time) bool {
+	return i.Assignee != "" && i.Status == StatusInProgress && !i.LeaseExpired(now)
+}
+
 // Validate checks if the issue has valid field values (built-in statuses only)
 func (i *Issue) Validate() error {
 	return i.ValidateWithCustomStatuses(nil)
@@ -671,6 +707,17 @@ type IssueWithCounts struct {
 	DependentCount  int `json:"dependent_count"`
 }
 
+// SearchResult is a full-text search hit returned by SearchIssuesRanked.
+// Score is BM25 relevance (higher is better). Snippet is an excerpt of the
+// best-matching field with query terms wrapped in ** markers. The dependency
+// counts are left for callers to fill in, so bd search --json keeps the
+// fields of IssueWithCounts.
+type SearchResult struct {
+	IssueWithCounts
+	Score   float64 `json:"score"`
+	Snippet string  `json:"snippet,omitempty"`
+}
+
 // IssueDetails extends Issue with labels, dependencies, dependents, and comments.
 // Used for JSON serialization in bd show and RPC responses.
 // Note: Labels, Dependencies, Dependents, and Comments do NOT use omitempty
@@ -828,6 +875,59 @@ type Comment struct {
 	CreatedAt time.Time `json:"created_at"`
 }
 
+// WorkLogEntry records time spent on an issue. IDs are random so entries
+// created in different clones never collide when JSONL files are merged.
+// A timer started with "bd time start" is an entry without EndedAt; its
+// Minutes are filled in when it is stopped.
+type WorkLogEntry struct {
+	ID        string     `json:"id"`
+	IssueID   string     `json:"issue_id"`
+	Actor     string     `json:"actor"`
+	Minutes   int        `json:"minutes"`
+	StartedAt time.Time  `json:"started_at"`
+	EndedAt   *time.Time `json:"ended_at,omitempty"`
+	Note      string     `json:"note,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// IsRunning reports whether the entry is a timer that hasn't been stopped.
+func (e *WorkLogEntry) IsRunning() bool {
+	return e.EndedAt == nil
+}
+
+// Attachment is a file attached to an issue. Only this metadata is stored in
+// the database and JSONL; the content lives under .beads/attachments, named
+// by its SHA-256 hash. IDs are random so attachments added in different
+// clones never collide when JSONL files are merged. Detaching keeps the row
+// with DetachedAt set so that the removal reaches other clones.
+type Attachment struct {
+	ID         string     `json:"id"`
+	IssueID    string     `json:"issue_id"`
+	Name       string     `json:"name"`
+	SHA256     string     `json:"sha256"`
+	Size       int64      `json:"size"`
+	MediaType  string     `json:"media_type,omitempty"`
+	CreatedBy  string     `json:"created_by,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	DetachedAt *time.Time `json:"detached_at,omitempty"`
+}
+
+// IsDetached reports whether the attachment has been removed from its issue.
+func (a *Attachment) IsDetached() bool {
+	return a.DetachedAt != nil
+}
+
+// LiveAttachments returns the attachments that haven't been detached.
+func LiveAttachments(attachments []*Attachment) []*Attachment {
+	var live []*Attachment
+	for _, a := range attachments {
+		if !a.IsDetached() {
+			live = append(live, a)
+		}
+	}
+	return live
+}
+
 // Event represents an audit trail entry
 type Event struct {
 	ID        int64      `json:"id"`
@@ -851,11 +951,14 @@ const (
 	EventCommented         EventType = "commented"
 	EventClosed            EventType = "closed"
 	EventReopened          EventType = "reopened"
+	EventClaimed           EventType = "claimed" // Atomic claim set assignee and in_progress
 	EventDependencyAdded   EventType = "dependency_added"
 	EventDependencyRemoved EventType = "dependency_removed"
 	EventLabelAdded        EventType = "label_added"
 	EventLabelRemoved      EventType = "label_removed"
 	EventCompacted         EventType = "compacted"
+	EventWorkflowOverride  EventType = "workflow_override" // Status change forced past the workflow rules
+	EventLeaseExpired      EventType = "lease_expired"     // Claim lease passed, issue returned to open
 )
 
 // BlockedIssue extends Issue with blocking information
@@ -967,6 +1070,111 @@ type IssueFilter struct {
 	DueAfter    *time.Time // Filter issues with due_at > this time
 	DueBefore   *time.Time // Filter issues with due_at < this time
 	Overdue     bool       // Filter issues where due_at < now AND status != closed
+
+	// Recurrence filter
+	Recurring bool // Filter issues with a recurrence rule
+
+	// Custom field filters (AND semantics)
+	CustomFields []CustomFieldFilter
+
+	// Filter expression (see internal/query), e.g. "status:open AND label:ui"
+	Where string
+}
+
+// CustomFieldFilter matches issues on the value of a custom field.
+type CustomFieldFilter struct {
+	Name    string `json:"name"`
+	Op      string `json:"op"`                // Operator, see the constants below
+	Value   string `json:"value,omitempty"`   // Canonical value to compare with
+	Numeric bool   `json:"numeric,omitempty"` // Compare as integers (int fields)
+}
+
+// Custom field filter operators
+const (
+	CustomFieldEq      = "="
+	CustomFieldNe      = "!="
+	CustomFieldLt      = "<"
+	CustomFieldLe      = "<="
+	CustomFieldGt      = ">"
+	CustomFieldGe      = ">="
+	CustomFieldSet     = "set"   // Field has a value
+	CustomFieldMissing = "unset" // Field has no value
+)
+
+// ValidCustomFieldName reports whether name can be used as a custom field
+// name: lowercase letters, digits and underscores, starting with a letter.
+// Storage backends rely on this to build JSON paths.
+func ValidCustomFieldName(name string) bool {
+	if name == "" || name[0] < 'a' || name[0] > 'z' {
+		return false
+	}
+	for _, c := range name {
+		if (c < 'a' || c > 'z') && (c < '0' || c > '9') && c != '_' {
+			return false
+		}
+	}
+	return true
+}
+
+// Matches reports whether custom field values satisfy the filter. Backends
+// that filter in SQL implement the same semantics: a missing field matches
+// only != and unset.
+func (f CustomFieldFilter) Matches(fields map[string]string) bool {
+	value, ok := fields[f.Name]
+	switch f.Op {
+	case CustomFieldSet:
+		return ok
+	case CustomFieldMissing:
+		return !ok
+	case CustomFieldNe:
+		return !ok || !customFieldEqual(value, f.Value, f.Numeric)
+	}
+	if !ok {
+		return false
+	}
+	c := CompareCustomFieldValues(value, f.Value, f.Numeric)
+	switch f.Op {
+	case CustomFieldEq:
+		return c == 0
+	case CustomFieldLt:
+		return c < 0
+	case CustomFieldLe:
+		return c <= 0
+	case CustomFieldGt:
+		return c > 0
+	case CustomFieldGe:
+		return c >= 0
+	default:
+		return false
+	}
+}
+
+func customFieldEqual(a, b string, numeric bool) bool {
+	return CompareCustomFieldValues(a, b, numeric) == 0
+}
+
+// CompareCustomFieldValues compares two canonical custom field values,
+// as integers when numeric is set and as strings otherwise (canonical dates
+// are YYYY-MM-DD, so they compare correctly as strings).
+func CompareCustomFieldValues(a, b string, numeric bool) int {
+	if numeric {
+		x, errA := strconv.ParseInt(a, 10, 64)
+		y, errB := strconv.ParseInt(b, 10, 64)
+		if errA == nil && errB == nil {
+			return cmp.Compare(x, y)
+		}
+	}
+	return strings.Compare(a, b)
+}
+
+// CustomFieldNames returns the names of the issue's custom fields, sorted.
+func (i *Issue) CustomFieldNames() []string {
+	names := make([]string, 0, len(i.CustomFields))
+	for name := range i.CustomFields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
 }
 
 // SortPolicy determines how ready work is ordered
@@ -986,6 +1194,29 @@ const (
 	// SortPolicyOldest always sorts by creation date (oldest first)
 	// Use for backlog clearing, preventing issue starvation
 	SortPolicyOldest SortPolicy = "oldest"
+
+	// Scored policies rank ready work with internal/ranking, which returns a
+	// score breakdown with each issue. Storage backends order them like
+	// SortPolicyPriority; use ranking.ReadyWork to apply the score.
+
+	// SortPolicyUnblock ranks by how many open issues the work unblocks
+	SortPolicyUnblock SortPolicy = "unblock"
+
+	// SortPolicyCriticalPath ranks by the longest chain of work waiting on it
+	SortPolicyCriticalPath SortPolicy = "critical-path"
+
+	// SortPolicyDue ranks by due-date urgency (overdue first)
+	SortPolicyDue SortPolicy = "due"
+
+	// SortPolicyWSJF ranks by weighted shortest job first: cost of delay
+	// (priority, urgency, unblocked work) divided by the estimate
+	SortPolicyWSJF SortPolicy = "wsjf"
+
+	// SortPolicyAge ranks the longest-waiting work first, with a decaying bonus
+	SortPolicyAge SortPolicy = "age"
+
+	// SortPolicyScore ranks by the weighted formula in the ready.score config
+	SortPolicyScore SortPolicy = "score"
 )
 
 // IsValid checks if the sort policy value is valid
@@ -994,6 +1225,15 @@ func (s SortPolicy) IsValid() bool {
 	case SortPolicyHybrid, SortPolicyPriority, SortPolicyOldest, "":
 		return true
 	}
+	return s.IsScored()
+}
+
+// IsScored reports whether the policy ranks work by a computed score.
+func (s SortPolicy) IsScored() bool {
+	switch s {
+	case SortPolicyUnblock, SortPolicyCriticalPath, SortPolicyDue, SortPolicyWSJF, SortPolicyAge, SortPolicyScore:
+		return true
+	}
 	return false
 }
 
@@ -1018,6 +1258,9 @@ type WorkFilter struct {
 	// Time-based deferral filtering (GH#820)
 	IncludeDeferred bool // If true, include issues with future defer_until timestamps
 
+	// Filter expression (see internal/query)
+	Where string
+
 	// Molecule step filtering
 	// By default, GetReadyWork excludes mol/wisp steps (IDs containing -mol- or -wisp-)
 	// Set to true for internal callers that need to see mol steps (e.g., findGateReadyMolecules)
@@ -945,6 +945,8 @@ func TestSortPolicyIsValid(t *testing.T) {
 		{SortPolicyHybrid, true},
 		{SortPolicyPriority, true},
 		{SortPolicyOldest, true},
+		{SortPolicyWSJF, true},
+		{SortPolicyCriticalPath, true},
 		{SortPolicy(""), true}, // empty is valid
 		{SortPolicy("invalid"), false},
 	}
@@ -1172,6 +1174,30 @@ func TestIsExpired(t *testing.T) {
 	}
 }
 
+func TestLeaseExpired(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name    string
+		issue   Issue
+		expired bool
+		claimed bool
+	}{
+		{"claim without lease", Issue{Status: StatusInProgress, Assignee: "a"}, false, true},
+		{"live lease", Issue{Status: StatusInProgress, Assignee: "a", LeaseExpiresAt: timePtr(now.Add(time.Minute))}, false, true},
+		{"lapsed lease", Issue{Status: StatusInProgress, Assignee: "a", LeaseExpiresAt: timePtr(now)}, true, false},
+		{"lapsed lease on closed issue", Issue{Status: StatusClosed, Assignee: "a", LeaseExpiresAt: timePtr(now.Add(-time.Hour))}, false, false},
+		{"open with stale assignee", Issue{Status: StatusOpen, Assignee: "a"}, false, false},
+	}
+	for _, tt := range tests {
+		if got := tt.issue.LeaseExpired(now); got != tt.expired {
+			t.Errorf("%s: LeaseExpired = %v, want %v", tt.name, got, tt.expired)
+		}
+		if got := tt.issue.IsClaimed(now); got != tt.claimed {
+			t.Errorf("%s: IsClaimed = %v, want %v", tt.name, got, tt.claimed)
+		}
+	}
+}
+
 func TestTombstoneTTLConstants(t *testing.T) {
 	// Test that constants have expected values
 	if DefaultTombstoneTTL != 30*24*time.Hour {
@@ -1820,3 +1846,42 @@ func TestIssueDetailsJSONStructure(t *testing.T) {
 		}
 	})
 }
+
+func TestCustomFieldFilterMatches(t *testing.T) {
+	fields := map[string]string{"points": "10", "severity": "high", "review": "2025-03-01"}
+
+	tests := []struct {
+		filter CustomFieldFilter
+		want   bool
+	}{
+		{CustomFieldFilter{Name: "points", Op: CustomFieldGe, Value: "9", Numeric: true}, true},
+		{CustomFieldFilter{Name: "points", Op: CustomFieldGe, Value: "9"}, false}, // "10" < "9" as strings
+		{CustomFieldFilter{Name: "points", Op: CustomFieldEq, Value: "10", Numeric: true}, true},
+		{CustomFieldFilter{Name: "review", Op: CustomFieldLt, Value: "2025-04-01"}, true},
+		{CustomFieldFilter{Name: "severity", Op: CustomFieldNe, Value: "high"}, false},
+		{CustomFieldFilter{Name: "customer", Op: CustomFieldNe, Value: "Acme"}, true},
+		{CustomFieldFilter{Name: "customer", Op: CustomFieldEq, Value: "Acme"}, false},
+		{CustomFieldFilter{Name: "customer", Op: CustomFieldGt, Value: ""}, false},
+		{CustomFieldFilter{Name: "severity", Op: CustomFieldSet}, true},
+		{CustomFieldFilter{Name: "customer", Op: CustomFieldMissing}, true},
+		{CustomFieldFilter{Name: "severity", Op: CustomFieldMissing}, false},
+	}
+	for _, tt := range tests {
+		if got := tt.filter.Matches(fields); got != tt.want {
+			t.Errorf("%+v.Matches() = %v, want %v", tt.filter, got, tt.want)
+		}
+	}
+}
+
+func TestValidCustomFieldName(t *testing.T) {
+	for _, name := range []string{"points", "review_date", "p2"} {
+		if !ValidCustomFieldName(name) {
+			t.Errorf("ValidCustomFieldName(%q) = false", name)
+		}
+	}
+	for _, name := range []string{"", "Points", "2p", "_x", "a-b", `a"b`, "a.b"} {
+		if ValidCustomFieldName(name) {
+			t.Errorf("ValidCustomFieldName(%q) = true", name)
+		}
+	}
+}
@@ -3,11 +3,18 @@ package ui
 
 import (
 	"os"
+	"sync"
 
 	"github.com/charmbracelet/glamour"
+	"github.com/muesli/termenv"
 	"golang.org/x/term"
 )
 
+// HasDarkBackground reports whether the terminal has a dark background.
+// The terminal is queried once and the answer reused, so full-screen
+// programs can call it before taking over the terminal.
+var HasDarkBackground = sync.OnceValue(termenv.HasDarkBackground)
+
 // RenderMarkdown renders markdown text using glamour with beads theme colors.
 // Returns the rendered markdown or the original text if rendering fails.
 // Word wraps at terminal width (or 80 columns if width can't be detected).
@@ -34,9 +41,27 @@ func RenderMarkdown(markdown string) string {
 		wrapWidth = maxReadableWidth
 	}
 
-	// Create renderer with auto-detected style (respects terminal light/dark mode)
+	return renderMarkdown(markdown, wrapWidth)
+}
+
+// RenderMarkdownWidth renders markdown like RenderMarkdown, but word wraps at
+// the given width instead of the terminal width. Use it when the text goes
+// into a pane narrower than the terminal (e.g. bd tui).
+func RenderMarkdownWidth(markdown string, width int) string {
+	if IsAgentMode() || !ShouldUseColor() {
+		return markdown
+	}
+	return renderMarkdown(markdown, width)
+}
+
+func renderMarkdown(markdown string, wrapWidth int) string {
+	// Pick the style from the terminal background (respects light/dark mode)
+	style := "light"
+	if HasDarkBackground() {
+		style = "dark"
+	}
 	renderer, err := glamour.NewTermRenderer(
-		glamour.WithAutoStyle(),
+		glamour.WithStandardStyle(style),
 		glamour.WithWordWrap(wrapWidth),
 	)
 	if err != nil {
@@ -0,0 +1,362 @@
+package validation
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/timeparsing"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// FieldType is the type of a custom field.
+type FieldType string
+
+// Custom field types supported in custom_fields.<name>.type
+const (
+	FieldString FieldType = "string"
+	FieldInt    FieldType = "int"
+	FieldEnum   FieldType = "enum"
+	FieldDate   FieldType = "date" // Stored as YYYY-MM-DD
+	FieldUser   FieldType = "user"
+	FieldURL    FieldType = "url"
+)
+
+var knownFieldTypes = []FieldType{FieldString, FieldInt, FieldEnum, FieldDate, FieldUser, FieldURL}
+
+// wildcardType requires a custom field on every issue type.
+const wildcardType = "*"
+
+// FieldDef is one custom field definition.
+type FieldDef struct {
+	Name        string    `json:"name"`
+	Type        FieldType `json:"type"`
+	Values      []string  `json:"values,omitempty"`   // Allowed values of an enum field
+	Required    []string  `json:"required,omitempty"` // Issue types that must set the field ("*" for all)
+	Description string    `json:"description,omitempty"`
+}
+
+// Numeric reports whether values of the field compare as integers.
+func (d *FieldDef) Numeric() bool {
+	return d.Type == FieldInt
+}
+
+// RequiredFor reports whether issues of the given type must set the field.
+func (d *FieldDef) RequiredFor(issueType types.IssueType) bool {
+	return slices.Contains(d.Required, wildcardType) || slices.Contains(d.Required, string(issueType))
+}
+
+// Normalize validates a value for the field and returns its canonical form:
+// integers without leading zeros, enum values as configured, dates as
+// YYYY-MM-DD (relative dates such as "+2w" or "next friday" are resolved),
+// and URLs with an http or https scheme.
+func (d *FieldDef) Normalize(value string) (string, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return "", fmt.Errorf("%s: empty value", d.Name)
+	}
+	switch d.Type {
+	case FieldInt:
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return "", fmt.Errorf("%s: %q is not an integer", d.Name, value)
+		}
+		return strconv.FormatInt(n, 10), nil
+	case FieldEnum:
+		for _, allowed := range d.Values {
+			if strings.EqualFold(value, allowed) {
+				return allowed, nil
+			}
+		}
+		return "", fmt.Errorf("%s: %q is not one of %s", d.Name, value, strings.Join(d.Values, ", "))
+	case FieldDate:
+		t, err := timeparsing.ParseRelativeTime(value, time.Now())
+		if err != nil {
+			return "", fmt.Errorf("%s: %q is not a date", d.Name, value)
+		}
+		return t.Format("2006-01-02"), nil
+	case FieldURL:
+		u, err := url.Parse(value)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return "", fmt.Errorf("%s: %q is not an http(s) URL", d.Name, value)
+		}
+		return value, nil
+	default: // string, user
+		return value, nil
+	}
+}
+
+// FieldSchema holds the custom fields defined in config.yaml.
+type FieldSchema struct {
+	Fields map[string]*FieldDef // Field name -> definition
+}
+
+// Field returns the definition of a field, or nil if it isn't defined.
+func (s *FieldSchema) Field(name string) *FieldDef {
+	if s == nil {
+		return nil
+	}
+	return s.Fields[name]
+}
+
+// Names returns the defined field names, sorted.
+func (s *FieldSchema) Names() []string {
+	if s == nil {
+		return nil
+	}
+	names := make([]string, 0, len(s.Fields))
+	for name := range s.Fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// lookup returns the definition of a field, or an error naming the defined
+// fields if there is none.
+func (s *FieldSchema) lookup(name string) (*FieldDef, error) {
+	if def := s.Field(name); def != nil {
+		return def, nil
+	}
+	if s == nil || len(s.Fields) == 0 {
+		return nil, fmt.Errorf("unknown custom field %q (no custom_fields are configured)", name)
+	}
+	return nil, fmt.Errorf("unknown custom field %q (defined: %s)", name, strings.Join(s.Names(), ", "))
+}
+
+// Apply returns fields with changes applied. Each change is validated and
+// normalized; an empty value clears the field. fields is not modified.
+// Returns nil if no fields remain.
+func (s *FieldSchema) Apply(fields, changes map[string]string) (map[string]string, error) {
+	result := make(map[string]string, len(fields)+len(changes))
+	for name, value := range fields {
+		result[name] = value
+	}
+	for _, name := range sortedKeys(changes) {
+		def, err := s.lookup(name)
+		if err != nil {
+			return nil, err
+		}
+		if strings.TrimSpace(changes[name]) == "" {
+			delete(result, name)
+			continue
+		}
+		value, err := def.Normalize(changes[name])
+		if err != nil {
+			return nil, err
+		}
+		result[name] = value
+	}
+	if len(result) == 0 {
+		return nil, nil
+	}
+	return result, nil
+}
+
+// CheckRequired returns an error naming the fields an issue of the given
+// type must set but doesn't.
+func (s *FieldSchema) CheckRequired(issueType types.IssueType, fields map[string]string) error {
+	var missing []string
+	for _, name := range s.Names() {
+		if s.Fields[name].RequiredFor(issueType) && fields[name] == "" {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("%s issues require custom field(s): %s (set with --field name=value)", issueType, strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// filterOps lists filter operators, longest first so "<=" wins over "<".
+var filterOps = []string{
+	types.CustomFieldNe, types.CustomFieldLe, types.CustomFieldGe,
+	types.CustomFieldEq, types.CustomFieldLt, types.CustomFieldGt,
+}
+
+// ParseFilter parses a custom field filter expression:
+//
+//	points>=3       compare (=, !=, <, <=, >, >=)
+//	severity=high   values are normalized like field values
+//	reviewer        field is set (also "reviewer!=")
+//	reviewer=       field is not set
+func (s *FieldSchema) ParseFilter(expr string) (types.CustomFieldFilter, error) {
+	name, op, value := strings.TrimSpace(expr), types.CustomFieldSet, ""
+	if i := strings.IndexAny(expr, "=!<>"); i >= 0 {
+		name = strings.TrimSpace(expr[:i])
+		rest := expr[i:]
+		op = ""
+		for _, candidate := range filterOps {
+			if strings.HasPrefix(rest, candidate) {
+				op, value = candidate, strings.TrimSpace(rest[len(candidate):])
+				break
+			}
+		}
+		if op == "" {
+			return types.CustomFieldFilter{}, fmt.Errorf("invalid field filter %q", expr)
+		}
+	}
+	def, err := s.lookup(name)
+	if err != nil {
+		return types.CustomFieldFilter{}, err
+	}
+
+	filter := types.CustomFieldFilter{Name: name, Op: op, Numeric: def.Numeric()}
+	if value == "" {
+		switch op {
+		case types.CustomFieldSet, types.CustomFieldNe:
+			filter.Op = types.CustomFieldSet
+		case types.CustomFieldEq:
+			filter.Op = types.CustomFieldMissing
+		default:
+			return types.CustomFieldFilter{}, fmt.Errorf("invalid field filter %q: %s needs a value", expr, op)
+		}
+		return filter, nil
+	}
+	if filter.Value, err = def.Normalize(value); err != nil {
+		return types.CustomFieldFilter{}, err
+	}
+	return filter, nil
+}
+
+// ParseFieldSchema builds a schema from its config.yaml form. Returns nil if
+// cfg is empty.
+func ParseFieldSchema(cfg map[string]config.CustomFieldConfig) (*FieldSchema, error) {
+	if len(cfg) == 0 {
+		return nil, nil
+	}
+	s := &FieldSchema{Fields: make(map[string]*FieldDef, len(cfg))}
+	for name, fc := range cfg {
+		if !types.ValidCustomFieldName(name) {
+			return nil, fmt.Errorf("custom_fields.%s: invalid name (use lowercase letters, digits and underscores)", name)
+		}
+		def := &FieldDef{
+			Name:        name,
+			Type:        FieldType(strings.ToLower(strings.TrimSpace(fc.Type))),
+			Description: fc.Description,
+		}
+		if def.Type == "" {
+			def.Type = FieldString
+		}
+		if !slices.Contains(knownFieldTypes, def.Type) {
+			return nil, fmt.Errorf("custom_fields.%s: unknown type %q (valid: %s)", name, fc.Type, joinFieldTypes(knownFieldTypes))
+		}
+		for _, v := range fc.Values {
+			if v = strings.TrimSpace(v); v != "" {
+				def.Values = append(def.Values, v)
+			}
+		}
+		if def.Type == FieldEnum && len(def.Values) == 0 {
+			return nil, fmt.Errorf("custom_fields.%s: enum fields need values", name)
+		}
+		if def.Type != FieldEnum && len(def.Values) > 0 {
+			return nil, fmt.Errorf("custom_fields.%s: values only apply to enum fields", name)
+		}
+		for _, t := range fc.Required {
+			def.Required = append(def.Required, strings.ToLower(strings.TrimSpace(t)))
+		}
+		s.Fields[name] = def
+	}
+	return s, nil
+}
+
+// LoadFieldSchema returns the custom fields configured in config.yaml, or
+// nil if none are configured.
+func LoadFieldSchema() (*FieldSchema, error) {
+	cfg, err := config.GetCustomFieldsConfig()
+	if err != nil {
+		return nil, err
+	}
+	return ParseFieldSchema(cfg)
+}
+
+// CustomFieldsForCreate validates the custom field values of a new issue of
+// the given type against the configured schema, including required fields,
+// and returns them normalized.
+func CustomFieldsForCreate(issueType types.IssueType, values map[string]string) (map[string]string, error) {
+	schema, err := LoadFieldSchema()
+	if err != nil {
+		return nil, err
+	}
+	fields, err := schema.Apply(nil, values)
+	if err != nil {
+		return nil, err
+	}
+	if err := schema.CheckRequired(issueType, fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+// CustomFieldUpdates validates custom field changes to issue against the
+// configured schema and adds the resulting custom_fields value to updates,
+// in the form UpdateIssue expects. When the fields or the issue type change,
+// required fields are checked for the updated type; other updates don't
+// re-check issues created before a field became required.
+func CustomFieldUpdates(issue *types.Issue, changes map[string]string, updates map[string]interface{}) error {
+	_, typeChanged := updates["issue_type"]
+	if len(changes) == 0 && !typeChanged {
+		return nil
+	}
+	schema, err := LoadFieldSchema()
+	if err != nil {
+		return err
+	}
+	if schema == nil && len(changes) == 0 {
+		return nil
+	}
+	fields, err := schema.Apply(issue.CustomFields, changes)
+	if err != nil {
+		return err
+	}
+	if err := schema.CheckRequired(applyUpdates(issue, updates).IssueType, fields); err != nil {
+		return err
+	}
+	if len(changes) > 0 {
+		if fields == nil {
+			fields = map[string]string{}
+		}
+		updates["custom_fields"] = fields
+	}
+	return nil
+}
+
+// ParseFieldAssignments parses name=value arguments (--field) into a map.
+// Values are not validated; see FieldSchema.Apply.
+func ParseFieldAssignments(args []string) (map[string]string, error) {
+	if len(args) == 0 {
+		return nil, nil
+	}
+	result := make(map[string]string, len(args))
+	for _, arg := range args {
+		name, value, ok := strings.Cut(arg, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid field %q (expected name=value)", arg)
+		}
+		result[name] = value
+	}
+	return result, nil
+}
+
+func joinFieldTypes(fieldTypes []FieldType) string {
+	names := make([]string, len(fieldTypes))
+	for i, t := range fieldTypes {
+		names[i] = string(t)
+	}
+	return strings.Join(names, ", ")
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
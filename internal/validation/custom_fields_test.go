@@ -0,0 +1,186 @@
+package validation
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func testFieldSchema(t *testing.T) *FieldSchema {
+	t.Helper()
+	s, err := ParseFieldSchema(map[string]config.CustomFieldConfig{
+		"points":   {Type: "int", Required: []string{"feature", "Bug"}},
+		"severity": {Type: "enum", Values: []string{"low", "High"}},
+		"customer": {},
+		"review":   {Type: "date"},
+		"reviewer": {Type: "user", Required: []string{"*"}},
+		"spec":     {Type: "url"},
+	})
+	if err != nil {
+		t.Fatalf("ParseFieldSchema() error = %v", err)
+	}
+	return s
+}
+
+func TestParseFieldSchema(t *testing.T) {
+	s := testFieldSchema(t)
+	if got := strings.Join(s.Names(), ","); got != "customer,points,review,reviewer,severity,spec" {
+		t.Errorf("Names() = %s", got)
+	}
+	if s.Field("customer").Type != FieldString {
+		t.Errorf("customer type = %s, want string default", s.Field("customer").Type)
+	}
+	if !s.Field("points").RequiredFor(types.TypeBug) || s.Field("points").RequiredFor(types.TypeTask) {
+		t.Error("points should be required for bugs but not tasks")
+	}
+	if !s.Field("reviewer").RequiredFor(types.TypeChore) {
+		t.Error("reviewer should be required for every type")
+	}
+
+	bad := []map[string]config.CustomFieldConfig{
+		{"Points": {Type: "int"}},
+		{"points": {Type: "float"}},
+		{"severity": {Type: "enum"}},
+		{"points": {Type: "int", Values: []string{"1"}}},
+	}
+	for _, cfg := range bad {
+		if _, err := ParseFieldSchema(cfg); err == nil {
+			t.Errorf("ParseFieldSchema(%v) should fail", cfg)
+		}
+	}
+
+	if s, err := ParseFieldSchema(nil); s != nil || err != nil {
+		t.Errorf("ParseFieldSchema(nil) = %v, %v; want nil, nil", s, err)
+	}
+}
+
+func TestFieldDefNormalize(t *testing.T) {
+	s := testFieldSchema(t)
+	tomorrow := time.Now().AddDate(0, 0, 1).Format("2006-01-02")
+
+	tests := []struct {
+		field, value, want string
+		wantErr            bool
+	}{
+		{field: "points", value: " 08 ", want: "8"},
+		{field: "points", value: "three", wantErr: true},
+		{field: "severity", value: "HIGH", want: "High"},
+		{field: "severity", value: "medium", wantErr: true},
+		{field: "review", value: "2025-07-01", want: "2025-07-01"},
+		{field: "review", value: "+1d", want: tomorrow},
+		{field: "review", value: "someday", wantErr: true},
+		{field: "spec", value: "https://example.com/spec", want: "https://example.com/spec"},
+		{field: "spec", value: "example.com/spec", wantErr: true},
+		{field: "customer", value: " Acme ", want: "Acme"},
+		{field: "customer", value: "  ", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := s.Field(tt.field).Normalize(tt.value)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("Normalize(%s, %q) = %q, want error", tt.field, tt.value, got)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("Normalize(%s, %q) = %q, %v; want %q", tt.field, tt.value, got, err, tt.want)
+		}
+	}
+}
+
+func TestFieldSchemaApply(t *testing.T) {
+	s := testFieldSchema(t)
+	current := map[string]string{"points": "3", "customer": "Acme", "legacy": "kept"}
+
+	got, err := s.Apply(current, map[string]string{"points": "5", "customer": "", "severity": "low"})
+	if err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	want := map[string]string{"points": "5", "severity": "low", "legacy": "kept"}
+	if len(got) != len(want) {
+		t.Fatalf("Apply() = %v, want %v", got, want)
+	}
+	for k, v := range want {
+		if got[k] != v {
+			t.Errorf("Apply()[%s] = %q, want %q", k, got[k], v)
+		}
+	}
+	if current["points"] != "3" {
+		t.Error("Apply() modified its input")
+	}
+
+	if _, err := s.Apply(nil, map[string]string{"sprint": "7"}); err == nil || !strings.Contains(err.Error(), "unknown custom field") {
+		t.Errorf("Apply(unknown) error = %v", err)
+	}
+	if _, err := (*FieldSchema)(nil).Apply(nil, map[string]string{"points": "1"}); err == nil || !strings.Contains(err.Error(), "no custom_fields") {
+		t.Errorf("Apply() without schema error = %v", err)
+	}
+	if got, err := s.Apply(map[string]string{"points": "1"}, map[string]string{"points": ""}); got != nil || err != nil {
+		t.Errorf("clearing the last field = %v, %v; want nil, nil", got, err)
+	}
+}
+
+func TestFieldSchemaCheckRequired(t *testing.T) {
+	s := testFieldSchema(t)
+
+	err := s.CheckRequired(types.TypeBug, map[string]string{"points": "3"})
+	if err == nil || !strings.Contains(err.Error(), "reviewer") || strings.Contains(err.Error(), "points") {
+		t.Errorf("CheckRequired(bug) error = %v, want reviewer missing", err)
+	}
+	if err := s.CheckRequired(types.TypeTask, map[string]string{"reviewer": "alice"}); err != nil {
+		t.Errorf("CheckRequired(task) error = %v", err)
+	}
+	if err := (*FieldSchema)(nil).CheckRequired(types.TypeBug, nil); err != nil {
+		t.Errorf("CheckRequired() without schema error = %v", err)
+	}
+}
+
+func TestFieldSchemaParseFilter(t *testing.T) {
+	s := testFieldSchema(t)
+
+	tests := []struct {
+		expr    string
+		want    types.CustomFieldFilter
+		wantErr bool
+	}{
+		{expr: "points>=3", want: types.CustomFieldFilter{Name: "points", Op: ">=", Value: "3", Numeric: true}},
+		{expr: "points<10", want: types.CustomFieldFilter{Name: "points", Op: "<", Value: "10", Numeric: true}},
+		{expr: "severity=high", want: types.CustomFieldFilter{Name: "severity", Op: "=", Value: "High"}},
+		{expr: "severity != low", want: types.CustomFieldFilter{Name: "severity", Op: "!=", Value: "low"}},
+		{expr: "reviewer", want: types.CustomFieldFilter{Name: "reviewer", Op: "set"}},
+		{expr: "reviewer!=", want: types.CustomFieldFilter{Name: "reviewer", Op: "set"}},
+		{expr: "reviewer=", want: types.CustomFieldFilter{Name: "reviewer", Op: "unset"}},
+		{expr: "points>", wantErr: true},
+		{expr: "points>=x", wantErr: true},
+		{expr: "points!3", wantErr: true},
+		{expr: "sprint=7", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := s.ParseFilter(tt.expr)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("ParseFilter(%q) = %+v, want error", tt.expr, got)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("ParseFilter(%q) = %+v, %v; want %+v", tt.expr, got, err, tt.want)
+		}
+	}
+}
+
+func TestParseFieldAssignments(t *testing.T) {
+	got, err := ParseFieldAssignments([]string{"points=3", "customer=", "url=https://x.test/?a=b"})
+	if err != nil {
+		t.Fatalf("ParseFieldAssignments() error = %v", err)
+	}
+	if got["points"] != "3" || got["customer"] != "" || got["url"] != "https://x.test/?a=b" {
+		t.Errorf("ParseFieldAssignments() = %v", got)
+	}
+	if _, err := ParseFieldAssignments([]string{"points"}); err == nil {
+		t.Error("ParseFieldAssignments(points) should fail")
+	}
+}
@@ -0,0 +1,414 @@
+package validation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// Guard names a field that must be set before an issue can enter a status.
+type Guard string
+
+// Guards supported in workflow.<type>.guards
+const (
+	GuardAssignee           Guard = "assignee"
+	GuardCloseReason        Guard = "close_reason"
+	GuardAcceptanceCriteria Guard = "acceptance_criteria"
+	GuardDescription        Guard = "description"
+	GuardDesign             Guard = "design"
+	GuardEstimate           Guard = "estimate"
+)
+
+var knownGuards = []Guard{
+	GuardAssignee,
+	GuardCloseReason,
+	GuardAcceptanceCriteria,
+	GuardDescription,
+	GuardDesign,
+	GuardEstimate,
+}
+
+// satisfiedBy reports whether the issue has the guarded field set.
+func (g Guard) satisfiedBy(issue *types.Issue) bool {
+	switch g {
+	case GuardAssignee:
+		return strings.TrimSpace(issue.Assignee) != ""
+	case GuardCloseReason:
+		// "Closed" is what bd close records when no reason is given
+		reason := strings.TrimSpace(issue.CloseReason)
+		return reason != "" && !strings.EqualFold(reason, "closed")
+	case GuardAcceptanceCriteria:
+		return strings.TrimSpace(issue.AcceptanceCriteria) != ""
+	case GuardDescription:
+		return strings.TrimSpace(issue.Description) != ""
+	case GuardDesign:
+		return strings.TrimSpace(issue.Design) != ""
+	case GuardEstimate:
+		return issue.EstimatedMinutes != nil && *issue.EstimatedMinutes > 0
+	default:
+		return false
+	}
+}
+
+// wildcardStatus matches any status in workflow transitions.
+const wildcardStatus types.Status = "*"
+
+// defaultWorkflowType is the workflow entry used by types without their own.
+const defaultWorkflowType = "default"
+
+// WorkflowRules holds the allowed transitions and guards for one issue type.
+type WorkflowRules struct {
+	Transitions map[types.Status][]types.Status // Source status -> allowed targets
+	Guards      map[types.Status][]Guard        // Target status -> required fields
+}
+
+// Targets returns the statuses an issue may move to from status from,
+// including wildcard rules. A nil result with a non-nil Transitions map
+// means no transitions are allowed.
+func (r *WorkflowRules) Targets(from types.Status) []types.Status {
+	var targets []types.Status
+	for _, to := range append(slices.Clone(r.Transitions[from]), r.Transitions[wildcardStatus]...) {
+		if !slices.Contains(targets, to) {
+			targets = append(targets, to)
+		}
+	}
+	return targets
+}
+
+// Allows reports whether moving from one status to another is allowed.
+// Rules without transitions only apply guards.
+func (r *WorkflowRules) Allows(from, to types.Status) bool {
+	if from == to || len(r.Transitions) == 0 {
+		return true
+	}
+	targets := r.Targets(from)
+	return slices.Contains(targets, to) || slices.Contains(targets, wildcardStatus)
+}
+
+// Workflow restricts status transitions per issue type.
+type Workflow struct {
+	Rules map[string]*WorkflowRules // Issue type (or "default") -> rules
+}
+
+// RulesFor returns the rules for an issue type, falling back to the default
+// rules. Returns nil if the type's status changes are unrestricted.
+func (w *Workflow) RulesFor(issueType types.IssueType) *WorkflowRules {
+	if w == nil {
+		return nil
+	}
+	if r, ok := w.Rules[string(issueType)]; ok {
+		return r
+	}
+	return w.Rules[defaultWorkflowType]
+}
+
+// Types returns the configured type names, "default" first.
+func (w *Workflow) Types() []string {
+	var names []string
+	for name := range w.Rules {
+		if name != defaultWorkflowType {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	if _, ok := w.Rules[defaultWorkflowType]; ok {
+		names = append([]string{defaultWorkflowType}, names...)
+	}
+	return names
+}
+
+// ParseWorkflow builds a workflow from its config.yaml form. Returns nil if
+// cfg is empty.
+func ParseWorkflow(cfg map[string]config.WorkflowRules) (*Workflow, error) {
+	if len(cfg) == 0 {
+		return nil, nil
+	}
+	w := &Workflow{Rules: make(map[string]*WorkflowRules, len(cfg))}
+	for typeName, rc := range cfg {
+		// Custom types are accepted like custom statuses
+		typeName = strings.ToLower(strings.TrimSpace(typeName))
+		rules := &WorkflowRules{
+			Transitions: make(map[types.Status][]types.Status, len(rc.Transitions)),
+			Guards:      make(map[types.Status][]Guard, len(rc.Guards)),
+		}
+		for from, targets := range rc.Transitions {
+			fromStatus, err := parseWorkflowStatus(from)
+			if err != nil {
+				return nil, fmt.Errorf("workflow.%s.transitions: %w", typeName, err)
+			}
+			for _, to := range targets {
+				toStatus, err := parseWorkflowStatus(to)
+				if err != nil {
+					return nil, fmt.Errorf("workflow.%s.transitions.%s: %w", typeName, from, err)
+				}
+				rules.Transitions[fromStatus] = append(rules.Transitions[fromStatus], toStatus)
+			}
+			if rules.Transitions[fromStatus] == nil {
+				// An empty list means the status is final
+				rules.Transitions[fromStatus] = []types.Status{}
+			}
+		}
+		for to, guards := range rc.Guards {
+			toStatus, err := parseWorkflowStatus(to)
+			if err != nil || toStatus == wildcardStatus {
+				return nil, fmt.Errorf("workflow.%s.guards: invalid status %q", typeName, to)
+			}
+			for _, name := range guards {
+				guard := Guard(strings.ToLower(strings.TrimSpace(name)))
+				if !slices.Contains(knownGuards, guard) {
+					return nil, fmt.Errorf("workflow.%s.guards.%s: unknown guard %q (valid: %s)", typeName, to, name, joinGuards(knownGuards))
+				}
+				rules.Guards[toStatus] = append(rules.Guards[toStatus], guard)
+			}
+		}
+		w.Rules[typeName] = rules
+	}
+	return w, nil
+}
+
+// parseWorkflowStatus normalizes a status name from the workflow config.
+// Custom statuses are accepted; they can't be checked without a store.
+func parseWorkflowStatus(s string) (types.Status, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if s == "" {
+		return "", fmt.Errorf("empty status")
+	}
+	return types.Status(s), nil
+}
+
+// LoadWorkflow returns the workflow configured in config.yaml, or nil if
+// none is configured.
+func LoadWorkflow() (*Workflow, error) {
+	cfg, err := config.GetWorkflowConfig()
+	if err != nil {
+		return nil, err
+	}
+	return ParseWorkflow(cfg)
+}
+
+// TransitionError reports a status change the workflow doesn't allow.
+type TransitionError struct {
+	ID     string
+	From   types.Status
+	To     types.Status
+	Reason string
+}
+
+func (e *TransitionError) Error() string {
+	return fmt.Sprintf("cannot move %s from %s to %s: %s (use --force to override)", e.ID, e.From, e.To, e.Reason)
+}
+
+// Transition validates that the proposed issue, whose status is the target
+// of a status change from status from, satisfies the workflow.
+func Transition(w *Workflow, from types.Status) IssueValidator {
+	return func(id string, issue *types.Issue) error {
+		if issue == nil || issue.Status == from {
+			return nil
+		}
+		rules := w.RulesFor(issue.IssueType)
+		if rules == nil {
+			return nil
+		}
+		if !rules.Allows(from, issue.Status) {
+			reason := fmt.Sprintf("the %s workflow allows no transitions from %s", workflowName(w, issue.IssueType), from)
+			if targets := rules.Targets(from); len(targets) > 0 {
+				reason = fmt.Sprintf("the %s workflow allows %s -> %s", workflowName(w, issue.IssueType), from, joinStatuses(targets))
+			}
+			return &TransitionError{ID: id, From: from, To: issue.Status, Reason: reason}
+		}
+		var missing []Guard
+		for _, guard := range rules.Guards[issue.Status] {
+			if !guard.satisfiedBy(issue) {
+				missing = append(missing, guard)
+			}
+		}
+		if len(missing) > 0 {
+			return &TransitionError{ID: id, From: from, To: issue.Status, Reason: "requires " + joinGuards(missing)}
+		}
+		return nil
+	}
+}
+
+// ForStatusChange returns a validator chain for status changes.
+// Validates: issue exists and the transition from status from is allowed.
+func ForStatusChange(w *Workflow, from types.Status) IssueValidator {
+	return Chain(
+		Exists(),
+		Transition(w, from),
+	)
+}
+
+// workflowName returns the workflow entry name that applies to a type.
+func workflowName(w *Workflow, issueType types.IssueType) string {
+	if _, ok := w.Rules[string(issueType)]; ok {
+		return string(issueType)
+	}
+	return defaultWorkflowType
+}
+
+func joinStatuses(statuses []types.Status) string {
+	names := make([]string, len(statuses))
+	for i, s := range statuses {
+		names[i] = string(s)
+	}
+	return strings.Join(names, ", ")
+}
+
+func joinGuards(guards []Guard) string {
+	names := make([]string, len(guards))
+	for i, g := range guards {
+		names[i] = string(g)
+	}
+	return strings.Join(names, ", ")
+}
+
+type workflowModeKey struct{}
+
+type workflowMode int
+
+const (
+	workflowEnforce workflowMode = iota
+	workflowForce                // Allow rejected transitions and audit them
+	workflowSkip                 // Don't apply the workflow at all
+)
+
+// WithWorkflowForce returns a context in which status changes the workflow
+// rejects are allowed. Storage records each one as a workflow_override event.
+func WithWorkflowForce(ctx context.Context) context.Context {
+	return context.WithValue(ctx, workflowModeKey{}, workflowForce)
+}
+
+// WithoutWorkflow returns a context in which the workflow is not applied.
+// Used when replaying state that was already validated elsewhere, such as
+// JSONL imports and external tracker syncs.
+func WithoutWorkflow(ctx context.Context) context.Context {
+	return context.WithValue(ctx, workflowModeKey{}, workflowSkip)
+}
+
+func workflowModeFrom(ctx context.Context) workflowMode {
+	mode, _ := ctx.Value(workflowModeKey{}).(workflowMode)
+	return mode
+}
+
+// CheckWorkflow applies the configured workflow to an update of issue. It
+// is called by every storage backend's UpdateIssue and CloseIssue.
+//
+// It returns a TransitionError if the status change is rejected. When ctx
+// comes from WithWorkflowForce, the change is allowed instead and the
+// TransitionError is returned as the override for the caller to record.
+func CheckWorkflow(ctx context.Context, issue *types.Issue, updates map[string]interface{}) (override *TransitionError, err error) {
+	if _, ok := updates["status"]; !ok || issue == nil {
+		return nil, nil
+	}
+	mode := workflowModeFrom(ctx)
+	if mode == workflowSkip {
+		return nil, nil
+	}
+	w, err := LoadWorkflow()
+	if err != nil {
+		return nil, err
+	}
+	if w == nil {
+		return nil, nil
+	}
+
+	proposed := applyUpdates(issue, updates)
+	if err := ForStatusChange(w, issue.Status)(issue.ID, proposed); err != nil {
+		var te *TransitionError
+		if errors.As(err, &te) && mode == workflowForce {
+			return te, nil
+		}
+		return nil, err
+	}
+	return nil, nil
+}
+
+// CheckWorkflowByID is CheckWorkflow for backends that don't already have
+// the issue loaded. The issue is only fetched when a workflow applies.
+func CheckWorkflowByID(ctx context.Context, getIssue func(context.Context, string) (*types.Issue, error), id string, updates map[string]interface{}) (*TransitionError, error) {
+	if _, ok := updates["status"]; !ok || workflowModeFrom(ctx) == workflowSkip {
+		return nil, nil
+	}
+	cfg, err := config.GetWorkflowConfig()
+	if err != nil {
+		return nil, err
+	}
+	if len(cfg) == 0 {
+		return nil, nil
+	}
+	issue, err := getIssue(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get issue %s: %w", id, err)
+	}
+	return CheckWorkflow(ctx, issue, updates)
+}
+
+// CloseUpdates returns the updates CloseIssue makes, for CheckWorkflow.
+func CloseUpdates(reason string) map[string]interface{} {
+	return map[string]interface{}{
+		"status":       types.StatusClosed,
+		"close_reason": reason,
+	}
+}
+
+// applyUpdates returns a copy of issue with the fields the workflow checks
+// set from updates.
+func applyUpdates(issue *types.Issue, updates map[string]interface{}) *types.Issue {
+	proposed := *issue
+	for key, value := range updates {
+		switch key {
+		case "status":
+			switch v := value.(type) {
+			case types.Status:
+				proposed.Status = v
+			case string:
+				proposed.Status = types.Status(v)
+			}
+		case "issue_type":
+			switch v := value.(type) {
+			case types.IssueType:
+				proposed.IssueType = v
+			case string:
+				proposed.IssueType = types.IssueType(v)
+			}
+		case "assignee":
+			proposed.Assignee = stringValue(value)
+		case "close_reason":
+			proposed.CloseReason = stringValue(value)
+		case "acceptance_criteria":
+			proposed.AcceptanceCriteria = stringValue(value)
+		case "description":
+			proposed.Description = stringValue(value)
+		case "design":
+			proposed.Design = stringValue(value)
+		case "estimated_minutes":
+			switch v := value.(type) {
+			case int:
+				proposed.EstimatedMinutes = &v
+			case *int:
+				proposed.EstimatedMinutes = v
+			case nil:
+				proposed.EstimatedMinutes = nil
+			}
+		}
+	}
+	return &proposed
+}
+
+func stringValue(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case *string:
+		if v != nil {
+			return *v
+		}
+	}
+	return ""
+}
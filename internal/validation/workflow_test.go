@@ -0,0 +1,266 @@
+package validation
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func testWorkflow(t *testing.T) *Workflow {
+	t.Helper()
+	w, err := ParseWorkflow(map[string]config.WorkflowRules{
+		"default": {
+			Transitions: map[string][]string{
+				"open":        {"in_progress"},
+				"in_progress": {"review", "open"},
+				"review":      {"closed", "in_progress"},
+				"closed":      {"open"},
+				"*":           {"deferred"},
+			},
+			Guards: map[string][]string{
+				"in_progress": {"assignee"},
+				"closed":      {"close_reason", "acceptance_criteria"},
+			},
+		},
+		"Bug": {
+			Transitions: map[string][]string{
+				"open":      {"*"},
+				"tombstone": {},
+			},
+		},
+		"chore": {
+			Guards: map[string][]string{"closed": {"Estimate"}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("ParseWorkflow() error = %v", err)
+	}
+	return w
+}
+
+func TestParseWorkflow(t *testing.T) {
+	w := testWorkflow(t)
+	if got := strings.Join(w.Types(), ","); got != "default,bug,chore" {
+		t.Errorf("Types() = %s, want default,bug,chore", got)
+	}
+	if w.RulesFor(types.TypeFeature) != w.Rules["default"] {
+		t.Error("RulesFor(feature) should fall back to default")
+	}
+	if w.RulesFor(types.TypeBug) != w.Rules["bug"] {
+		t.Error("RulesFor(bug) should use the bug rules")
+	}
+	if got := w.Rules["chore"].Guards[types.StatusClosed]; len(got) != 1 || got[0] != GuardEstimate {
+		t.Errorf("chore guards = %v, want [estimate]", got)
+	}
+
+	none, err := ParseWorkflow(nil)
+	if err != nil || none != nil {
+		t.Errorf("ParseWorkflow(nil) = %v, %v; want nil, nil", none, err)
+	}
+	if none.RulesFor(types.TypeTask) != nil {
+		t.Error("nil workflow should have no rules")
+	}
+
+	invalid := []map[string]config.WorkflowRules{
+		{"default": {Transitions: map[string][]string{"open": {" "}}}},
+		{"default": {Guards: map[string][]string{"closed": {"reviewer"}}}},
+		{"default": {Guards: map[string][]string{"*": {"assignee"}}}},
+	}
+	for _, cfg := range invalid {
+		if _, err := ParseWorkflow(cfg); err == nil {
+			t.Errorf("ParseWorkflow(%v) succeeded, want error", cfg)
+		}
+	}
+}
+
+func TestWorkflowRules_Allows(t *testing.T) {
+	w := testWorkflow(t)
+	def, bug, chore := w.Rules["default"], w.Rules["bug"], w.Rules["chore"]
+
+	tests := []struct {
+		name  string
+		rules *WorkflowRules
+		from  types.Status
+		to    types.Status
+		want  bool
+	}{
+		{"listed transition", def, types.StatusOpen, types.StatusInProgress, true},
+		{"skipping review", def, types.StatusOpen, types.StatusClosed, false},
+		{"wildcard source", def, types.StatusReview, types.StatusDeferred, true},
+		{"unlisted source uses wildcard only", def, types.StatusDeferred, types.StatusOpen, false},
+		{"same status", def, types.StatusBlocked, types.StatusBlocked, true},
+		{"wildcard target", bug, types.StatusOpen, types.StatusClosed, true},
+		{"final status", bug, types.StatusTombstone, types.StatusOpen, false},
+		{"unlisted source", bug, types.StatusClosed, types.StatusOpen, false},
+		{"guards only", chore, types.StatusOpen, types.StatusClosed, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.rules.Allows(tt.from, tt.to); got != tt.want {
+				t.Errorf("Allows(%s, %s) = %v, want %v", tt.from, tt.to, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTransition(t *testing.T) {
+	w := testWorkflow(t)
+	estimate := 30
+
+	tests := []struct {
+		name    string
+		from    types.Status
+		issue   *types.Issue
+		wantErr string
+	}{
+		{
+			name:  "allowed with guard satisfied",
+			from:  types.StatusOpen,
+			issue: &types.Issue{Status: types.StatusInProgress, IssueType: types.TypeTask, Assignee: "alice"},
+		},
+		{
+			name:    "missing assignee",
+			from:    types.StatusOpen,
+			issue:   &types.Issue{Status: types.StatusInProgress, IssueType: types.TypeTask},
+			wantErr: "requires assignee",
+		},
+		{
+			name:    "not allowed",
+			from:    types.StatusOpen,
+			issue:   &types.Issue{Status: types.StatusClosed, IssueType: types.TypeTask, CloseReason: "done"},
+			wantErr: "allows open -> in_progress, deferred",
+		},
+		{
+			name:    "default close reason",
+			from:    types.StatusReview,
+			issue:   &types.Issue{Status: types.StatusClosed, IssueType: types.TypeTask, CloseReason: "Closed", AcceptanceCriteria: "works"},
+			wantErr: "requires close_reason",
+		},
+		{
+			name:    "several guards missing",
+			from:    types.StatusReview,
+			issue:   &types.Issue{Status: types.StatusClosed, IssueType: types.TypeTask},
+			wantErr: "requires close_reason, acceptance_criteria",
+		},
+		{
+			name:    "final status",
+			from:    types.StatusTombstone,
+			issue:   &types.Issue{Status: types.StatusOpen, IssueType: types.TypeBug},
+			wantErr: "allows no transitions from tombstone",
+		},
+		{
+			name:    "estimate guard",
+			from:    types.StatusOpen,
+			issue:   &types.Issue{Status: types.StatusClosed, IssueType: types.TypeChore},
+			wantErr: "requires estimate",
+		},
+		{
+			name:  "estimate set",
+			from:  types.StatusOpen,
+			issue: &types.Issue{Status: types.StatusClosed, IssueType: types.TypeChore, EstimatedMinutes: &estimate},
+		},
+		{
+			name:  "unchanged status",
+			from:  types.StatusOpen,
+			issue: &types.Issue{Status: types.StatusOpen, IssueType: types.TypeTask},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Transition(w, tt.from)("bd-1", tt.issue)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Transition() error = %v, want nil", err)
+				}
+				return
+			}
+			var te *TransitionError
+			if !errors.As(err, &te) {
+				t.Fatalf("Transition() error = %v, want TransitionError", err)
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Transition() error = %q, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// setupWorkflowConfig loads a config.yaml with a workflow for the test.
+func setupWorkflowConfig(t *testing.T, content string) {
+	t.Helper()
+	tmpDir := t.TempDir()
+	beadsDir := filepath.Join(tmpDir, ".beads")
+	if err := os.MkdirAll(beadsDir, 0750); err != nil {
+		t.Fatalf("failed to create .beads directory: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(beadsDir, "config.yaml"), []byte(content), 0600); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+	t.Chdir(tmpDir)
+	if err := config.Initialize(); err != nil {
+		t.Fatalf("config.Initialize() error = %v", err)
+	}
+	t.Cleanup(func() {
+		t.Chdir(t.TempDir())
+		_ = config.Initialize()
+	})
+}
+
+func TestCheckWorkflow(t *testing.T) {
+	setupWorkflowConfig(t, `
+workflow:
+  default:
+    transitions:
+      open: [in_progress]
+      in_progress: [closed]
+    guards:
+      closed: [close_reason]
+`)
+	ctx := context.Background()
+	issue := &types.Issue{ID: "bd-1", Status: types.StatusOpen, IssueType: types.TypeTask}
+
+	// Updates that don't change status aren't checked
+	if _, err := CheckWorkflow(ctx, issue, map[string]interface{}{"title": "x"}); err != nil {
+		t.Errorf("CheckWorkflow(title) error = %v", err)
+	}
+
+	closeUpdates := CloseUpdates("done")
+	if _, err := CheckWorkflow(ctx, issue, closeUpdates); err == nil {
+		t.Error("CheckWorkflow(open -> closed) succeeded, want error")
+	}
+
+	override, err := CheckWorkflow(WithWorkflowForce(ctx), issue, closeUpdates)
+	if err != nil {
+		t.Fatalf("CheckWorkflow with force error = %v", err)
+	}
+	if override == nil || override.From != types.StatusOpen || override.To != types.StatusClosed {
+		t.Errorf("override = %+v, want open -> closed", override)
+	}
+
+	if override, err := CheckWorkflow(WithoutWorkflow(ctx), issue, closeUpdates); err != nil || override != nil {
+		t.Errorf("CheckWorkflow without workflow = %v, %v; want nil, nil", override, err)
+	}
+
+	// Allowed transitions aren't overrides, even when forced
+	inProgress := &types.Issue{ID: "bd-1", Status: types.StatusInProgress, IssueType: types.TypeTask}
+	if override, err := CheckWorkflow(WithWorkflowForce(ctx), inProgress, closeUpdates); err != nil || override != nil {
+		t.Errorf("CheckWorkflow(in_progress -> closed) = %v, %v; want nil, nil", override, err)
+	}
+
+	// Guards see the fields set by the same update
+	updates := map[string]interface{}{"status": "closed", "close_reason": "Closed"}
+	if _, err := CheckWorkflow(ctx, inProgress, updates); err == nil {
+		t.Error("CheckWorkflow with default close reason succeeded, want error")
+	}
+
+	getIssue := func(context.Context, string) (*types.Issue, error) { return issue, nil }
+	if _, err := CheckWorkflowByID(ctx, getIssue, "bd-1", closeUpdates); err == nil {
+		t.Error("CheckWorkflowByID(open -> closed) succeeded, want error")
+	}
+}
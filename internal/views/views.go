@@ -0,0 +1,286 @@
+// Package views stores saved views: named bd list queries with their sort
+// order, columns and output format.
+//
+// Views live in the config table under view.<name>, so they are versioned
+// with the database on the dolt backend. They are also written to
+// .beads/views.jsonl, which is committed with the other beads files and
+// shares them through git. When both copies exist the newer one wins, and
+// deleted views are kept as tombstones so deletions propagate too.
+package views
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/beads/internal/query"
+)
+
+// FileName is the git-tracked views file in the .beads directory.
+const FileName = "views.jsonl"
+
+// configPrefix prefixes view keys in the config table.
+const configPrefix = "view."
+
+// View is a saved bd list query.
+type View struct {
+	Name        string    `json:"name"`
+	Description string    `json:"description,omitempty"`
+	Where       string    `json:"where,omitempty"`   // Filter expression (internal/query)
+	Sort        string    `json:"sort,omitempty"`    // bd list --sort field, or a bd ready sort policy
+	Reverse     bool      `json:"reverse,omitempty"` // Reverse the sort order
+	Columns     []string  `json:"columns,omitempty"` // Table columns (bd list --columns)
+	Format      string    `json:"format,omitempty"`  // long, pretty, json, or a bd list --format value
+	Limit       *int      `json:"limit,omitempty"`   // nil keeps the command default, 0 is unlimited
+	UpdatedAt   time.Time `json:"updated_at"`
+	Deleted     bool      `json:"deleted,omitempty"` // Tombstone
+}
+
+// Config is the part of storage.Storage that views need. Get only uses
+// GetConfig, so it also works through the daemon.
+type Config interface {
+	GetConfig(ctx context.Context, key string) (string, error)
+}
+
+// ConfigStore reads and writes the config table.
+type ConfigStore interface {
+	Config
+	SetConfig(ctx context.Context, key, value string) error
+	GetAllConfig(ctx context.Context) (map[string]string, error)
+}
+
+var namePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// ValidName reports whether name can be used for a view.
+func ValidName(name string) bool {
+	return namePattern.MatchString(name)
+}
+
+// Validate checks the view's name and filter expression.
+func (v *View) Validate() error {
+	if !ValidName(v.Name) {
+		return fmt.Errorf("invalid view name %q (use lowercase letters, digits, '-' and '_')", v.Name)
+	}
+	if v.Where != "" {
+		if _, err := query.Parse(v.Where); err != nil {
+			return fmt.Errorf("view %s: %w", v.Name, err)
+		}
+	}
+	if v.Limit != nil && *v.Limit < 0 {
+		return fmt.Errorf("view %s: limit must be 0 (unlimited) or more", v.Name)
+	}
+	return nil
+}
+
+// Get returns the named view, or an error naming the saved views if there is
+// no such view. beadsDir may be empty to skip views.jsonl.
+func Get(ctx context.Context, cfg Config, beadsDir, name string) (*View, error) {
+	var newest *View
+	value, err := cfg.GetConfig(ctx, configPrefix+name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read view %s: %w", name, err)
+	}
+	if value != "" {
+		if newest, err = decode(value); err != nil {
+			return nil, fmt.Errorf("view %s: %w", name, err)
+		}
+	}
+	fileViews, err := readFile(beadsDir)
+	if err != nil {
+		return nil, err
+	}
+	if v, ok := fileViews[name]; ok && newer(v, newest) {
+		newest = v
+	}
+	if newest == nil || newest.Deleted {
+		return nil, unknownView(name, fileViews)
+	}
+	return newest, nil
+}
+
+// List returns the saved views sorted by name, without tombstones.
+func List(ctx context.Context, s ConfigStore, beadsDir string) ([]*View, error) {
+	all, err := load(ctx, s, beadsDir)
+	if err != nil {
+		return nil, err
+	}
+	return live(all), nil
+}
+
+// Save stores the view in the config table and views.jsonl, replacing any
+// view with the same name.
+func Save(ctx context.Context, s ConfigStore, beadsDir string, v *View) error {
+	if err := v.Validate(); err != nil {
+		return err
+	}
+	v.UpdatedAt = time.Now().UTC()
+	return put(ctx, s, beadsDir, v)
+}
+
+// Delete removes the named view, leaving a tombstone so the deletion reaches
+// other clones.
+func Delete(ctx context.Context, s ConfigStore, beadsDir, name string) error {
+	all, err := load(ctx, s, beadsDir)
+	if err != nil {
+		return err
+	}
+	if v, ok := all[name]; !ok || v.Deleted {
+		return unknownView(name, all)
+	}
+	return put(ctx, s, beadsDir, &View{Name: name, UpdatedAt: time.Now().UTC(), Deleted: true})
+}
+
+// put writes v to the config table and rewrites views.jsonl.
+func put(ctx context.Context, s ConfigStore, beadsDir string, v *View) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	if err := s.SetConfig(ctx, configPrefix+v.Name, string(data)); err != nil {
+		return fmt.Errorf("failed to save view %s: %w", v.Name, err)
+	}
+	if beadsDir == "" {
+		return nil
+	}
+	all, err := load(ctx, s, beadsDir)
+	if err != nil {
+		return err
+	}
+	return writeFile(beadsDir, all)
+}
+
+// load merges the config table and views.jsonl, newest first.
+func load(ctx context.Context, s ConfigStore, beadsDir string) (map[string]*View, error) {
+	cfg, err := s.GetAllConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read views: %w", err)
+	}
+	all, err := readFile(beadsDir)
+	if err != nil {
+		return nil, err
+	}
+	for key, value := range cfg {
+		if !strings.HasPrefix(key, configPrefix) {
+			continue
+		}
+		v, err := decode(value)
+		if err != nil {
+			return nil, fmt.Errorf("config %s: %w", key, err)
+		}
+		v.Name = strings.TrimPrefix(key, configPrefix)
+		if newer(v, all[v.Name]) {
+			all[v.Name] = v
+		}
+	}
+	return all, nil
+}
+
+func decode(value string) (*View, error) {
+	var v View
+	if err := json.Unmarshal([]byte(value), &v); err != nil {
+		return nil, fmt.Errorf("invalid view: %w", err)
+	}
+	return &v, nil
+}
+
+// newer reports whether a should replace b.
+func newer(a, b *View) bool {
+	return b == nil || a.UpdatedAt.After(b.UpdatedAt)
+}
+
+func live(all map[string]*View) []*View {
+	var result []*View
+	for _, v := range all {
+		if !v.Deleted {
+			result = append(result, v)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result
+}
+
+func unknownView(name string, known map[string]*View) error {
+	var names []string
+	for _, v := range live(known) {
+		names = append(names, v.Name)
+	}
+	if len(names) == 0 {
+		return fmt.Errorf("unknown view %q (no views saved, see 'bd views save')", name)
+	}
+	return fmt.Errorf("unknown view %q (saved: %s)", name, strings.Join(names, ", "))
+}
+
+// readFile reads views.jsonl. A missing file has no views.
+func readFile(beadsDir string) (map[string]*View, error) {
+	all := map[string]*View{}
+	if beadsDir == "" {
+		return all, nil
+	}
+	path := filepath.Join(beadsDir, FileName)
+	// #nosec G304 -- path is within the beads directory
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return all, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer func() { _ = f.Close() }()
+
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		v, err := decode(text)
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", path, line, err)
+		}
+		if ValidName(v.Name) && newer(v, all[v.Name]) {
+			all[v.Name] = v
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return all, nil
+}
+
+// writeFile atomically rewrites views.jsonl, one view per line sorted by
+// name so diffs stay small.
+func writeFile(beadsDir string, all map[string]*View) error {
+	names := make([]string, 0, len(all))
+	for name := range all {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	for _, name := range names {
+		data, err := json.Marshal(all[name])
+		if err != nil {
+			return err
+		}
+		b.Write(data)
+		b.WriteByte('\n')
+	}
+
+	path := filepath.Join(beadsDir, FileName)
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, []byte(b.String()), 0o600); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,157 @@
+package views
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+type fakeConfig map[string]string
+
+func (c fakeConfig) GetConfig(_ context.Context, key string) (string, error) {
+	return c[key], nil
+}
+
+func (c fakeConfig) SetConfig(_ context.Context, key, value string) error {
+	c[key] = value
+	return nil
+}
+
+func (c fakeConfig) GetAllConfig(_ context.Context) (map[string]string, error) {
+	result := make(map[string]string, len(c))
+	for k, v := range c {
+		result[k] = v
+	}
+	return result, nil
+}
+
+func TestValidate(t *testing.T) {
+	negative := -1
+	tests := []struct {
+		view View
+		want string
+	}{
+		{View{Name: "triage", Where: "status:open priority<=1"}, ""},
+		{View{Name: "Triage"}, "invalid view name"},
+		{View{Name: "my view"}, "invalid view name"},
+		{View{Name: ""}, "invalid view name"},
+		{View{Name: "bad", Where: "bogus:1"}, "unknown field"},
+		{View{Name: "bad", Limit: &negative}, "limit"},
+	}
+	for _, tt := range tests {
+		err := tt.view.Validate()
+		switch {
+		case tt.want == "" && err != nil:
+			t.Errorf("Validate(%+v) = %v, want nil", tt.view, err)
+		case tt.want != "" && (err == nil || !strings.Contains(err.Error(), tt.want)):
+			t.Errorf("Validate(%+v) = %v, want error containing %q", tt.view, err, tt.want)
+		}
+	}
+}
+
+func TestSaveGetListDelete(t *testing.T) {
+	ctx := context.Background()
+	cfg := fakeConfig{"issue_prefix": "bd"}
+	dir := t.TempDir()
+
+	limit := 5
+	triage := &View{Name: "triage", Where: "status:open priority<=1", Sort: "priority", Columns: []string{"id", "title"}, Limit: &limit}
+	if err := Save(ctx, cfg, dir, triage); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	if err := Save(ctx, cfg, dir, &View{Name: "mine", Where: "assignee:alice"}); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	if err := Save(ctx, cfg, dir, &View{Name: "bad", Where: "bogus:1"}); err == nil {
+		t.Fatal("expected an invalid view to be rejected")
+	}
+
+	got, err := Get(ctx, cfg, dir, "triage")
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if got.Where != triage.Where || got.Sort != "priority" || len(got.Columns) != 2 || got.Limit == nil || *got.Limit != 5 {
+		t.Errorf("Get = %+v, want %+v", got, triage)
+	}
+
+	list, err := List(ctx, cfg, dir)
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(list) != 2 || list[0].Name != "mine" || list[1].Name != "triage" {
+		t.Errorf("List = %v, want [mine triage]", names(list))
+	}
+
+	// The file holds every view, so a clone without the config rows sees them
+	fromFile, err := List(ctx, fakeConfig{}, dir)
+	if err != nil {
+		t.Fatalf("List from file failed: %v", err)
+	}
+	if len(fromFile) != 2 {
+		t.Errorf("List from file = %v, want 2 views", names(fromFile))
+	}
+
+	if err := Delete(ctx, cfg, dir, "mine"); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if _, err := Get(ctx, cfg, dir, "mine"); err == nil || !strings.Contains(err.Error(), "saved: triage") {
+		t.Errorf("Get after delete = %v, want unknown view listing triage", err)
+	}
+	if err := Delete(ctx, cfg, dir, "mine"); err == nil {
+		t.Error("expected deleting a missing view to fail")
+	}
+	data, err := os.ReadFile(filepath.Join(dir, FileName))
+	if err != nil {
+		t.Fatalf("ReadFile failed: %v", err)
+	}
+	if !strings.Contains(string(data), `"deleted":true`) {
+		t.Errorf("expected a tombstone in %s:\n%s", FileName, data)
+	}
+}
+
+func TestNewestWins(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	old := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	cfg := fakeConfig{"view.triage": `{"name":"triage","where":"priority:0","updated_at":"` + old.Format(time.RFC3339) + `"}`}
+
+	// A newer copy pulled through git overrides the local config row
+	line := `{"name":"triage","where":"priority<=1","updated_at":"` + old.Add(time.Hour).Format(time.RFC3339) + `"}` + "\n"
+	if err := os.WriteFile(filepath.Join(dir, FileName), []byte(line), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	got, err := Get(ctx, cfg, dir, "triage")
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if got.Where != "priority<=1" {
+		t.Errorf("Get = %q, want the newer file copy", got.Where)
+	}
+
+	// A newer tombstone in the file hides the view
+	tombstone := `{"name":"triage","updated_at":"` + old.Add(2*time.Hour).Format(time.RFC3339) + `","deleted":true}` + "\n"
+	if err := os.WriteFile(filepath.Join(dir, FileName), []byte(tombstone), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Get(ctx, cfg, dir, "triage"); err == nil {
+		t.Error("expected a newer tombstone to hide the view")
+	}
+	list, err := List(ctx, cfg, dir)
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(list) != 0 {
+		t.Errorf("List = %v, want none", names(list))
+	}
+}
+
+func names(list []*View) []string {
+	var result []string
+	for _, v := range list {
+		result = append(result, v.Name)
+	}
+	return result
+}
@@ -0,0 +1,240 @@
+package webhooks
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/steveyegge/beads/internal/config"
+)
+
+// DefaultPollInterval is how often the dispatcher rescans the queue for due
+// deliveries, which also picks up items requeued by `bd webhooks replay`.
+const DefaultPollInterval = 5 * time.Second
+
+// maxEndpointWorkers bounds how many endpoints ProcessDue delivers to at
+// once, so one slow endpoint doesn't hold up the others.
+const maxEndpointWorkers = 4
+
+// Dispatcher turns events into persisted deliveries and sends them with
+// exponential backoff. It is safe for concurrent use.
+type Dispatcher struct {
+	store  *Store
+	cfg    config.WebhookConfig
+	client *http.Client
+	wake   chan struct{}
+
+	// Logger receives delivery failures; nil disables logging.
+	Logger *slog.Logger
+	// now is overridable for tests.
+	now func() time.Time
+}
+
+// NewDispatcher creates a dispatcher for the configured endpoints.
+func NewDispatcher(store *Store, cfg config.WebhookConfig) *Dispatcher {
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = 1
+	}
+	return &Dispatcher{
+		store:  store,
+		cfg:    cfg,
+		client: &http.Client{Timeout: cfg.Timeout},
+		wake:   make(chan struct{}, 1),
+		now:    time.Now,
+	}
+}
+
+// Endpoint returns the configured endpoint with the given name.
+func (d *Dispatcher) Endpoint(name string) (config.WebhookEndpoint, bool) {
+	for _, e := range d.cfg.Endpoints {
+		if e.Name == name {
+			return e, true
+		}
+	}
+	return config.WebhookEndpoint{}, false
+}
+
+// Enqueue persists one delivery per endpoint subscribed to the event type
+// and wakes the delivery loop. It returns the number of deliveries queued.
+// Persisting before sending means events survive a daemon restart.
+func (d *Dispatcher) Enqueue(event Event) (int, error) {
+	queued := 0
+	now := d.now()
+	for _, e := range d.cfg.Endpoints {
+		if !e.Matches(event.Type) {
+			continue
+		}
+		if err := d.store.Enqueue(NewDelivery(e.Name, event, now)); err != nil {
+			return queued, err
+		}
+		queued++
+	}
+	if queued > 0 {
+		d.Wake()
+	}
+	return queued, nil
+}
+
+// Wake triggers an immediate queue scan.
+func (d *Dispatcher) Wake() {
+	select {
+	case d.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Run delivers queued items until ctx is cancelled, scanning the queue
+// whenever Enqueue or Wake is called and at least every pollInterval.
+func (d *Dispatcher) Run(ctx context.Context, pollInterval time.Duration) {
+	if pollInterval <= 0 {
+		pollInterval = DefaultPollInterval
+	}
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	d.ProcessDue(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-d.wake:
+		}
+		d.ProcessDue(ctx)
+	}
+}
+
+// ProcessDue attempts every queued delivery whose next attempt time has
+// passed. Endpoints are served concurrently, up to maxEndpointWorkers at a
+// time; each endpoint gets its deliveries in creation order. Failed
+// deliveries are rescheduled with exponential backoff or, once retries are
+// exhausted or the endpoint rejects the payload, moved to the dead-letter
+// directory. It returns the number of successful deliveries.
+func (d *Dispatcher) ProcessDue(ctx context.Context) int {
+	pending, err := d.store.Pending()
+	if err != nil {
+		d.logWarn("failed to read webhook queue", "error", err)
+		return 0
+	}
+
+	var endpoints []string
+	due := make(map[string][]*Delivery)
+	now := d.now()
+	for _, item := range pending {
+		if item.NextAttempt.After(now) {
+			continue
+		}
+		if _, ok := due[item.Endpoint]; !ok {
+			endpoints = append(endpoints, item.Endpoint)
+		}
+		due[item.Endpoint] = append(due[item.Endpoint], item)
+	}
+
+	var (
+		mu        sync.Mutex
+		wg        sync.WaitGroup
+		delivered int
+	)
+	sem := make(chan struct{}, maxEndpointWorkers)
+	for _, name := range endpoints {
+		items := due[name]
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer func() { <-sem; wg.Done() }()
+			for _, item := range items {
+				if ctx.Err() != nil {
+					return
+				}
+				if d.attempt(ctx, item) {
+					mu.Lock()
+					delivered++
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	return delivered
+}
+
+// attempt makes one delivery attempt and records the outcome in the store.
+func (d *Dispatcher) attempt(ctx context.Context, item *Delivery) bool {
+	endpoint, ok := d.Endpoint(item.Endpoint)
+	if !ok {
+		item.LastError = "endpoint no longer configured"
+		d.deadLetter(item)
+		return false
+	}
+
+	item.Attempts++
+	err := Send(ctx, d.client, endpoint, item)
+	if err == nil {
+		if err := d.store.Complete(item); err != nil {
+			d.logWarn("failed to remove delivered webhook", "delivery", item.ID, "error", err)
+		}
+		return true
+	}
+	if ctx.Err() != nil {
+		// Shutting down: don't count an attempt that was cut short
+		item.Attempts--
+		return false
+	}
+
+	item.LastError = err.Error()
+	item.LastStatus = 0
+	retryable := true
+	var derr *DeliveryError
+	if errors.As(err, &derr) {
+		item.LastStatus = derr.StatusCode
+		retryable = derr.Retryable()
+	}
+
+	if !retryable || item.Attempts >= d.cfg.MaxAttempts {
+		d.deadLetter(item)
+		return false
+	}
+
+	item.NextAttempt = d.now().Add(Backoff(d.cfg, item.Attempts))
+	if err := d.store.Enqueue(item); err != nil {
+		d.logWarn("failed to reschedule webhook", "delivery", item.ID, "error", err)
+	}
+	return false
+}
+
+func (d *Dispatcher) deadLetter(item *Delivery) {
+	d.logWarn("webhook delivery dead-lettered",
+		"delivery", item.ID, "endpoint", item.Endpoint, "event", item.Event.Type,
+		"attempts", item.Attempts, "error", item.LastError)
+	if err := d.store.DeadLetter(item); err != nil {
+		d.logWarn("failed to dead-letter webhook", "delivery", item.ID, "error", err)
+	}
+}
+
+func (d *Dispatcher) logWarn(msg string, args ...interface{}) {
+	if d.Logger != nil {
+		d.Logger.Warn(msg, args...)
+	}
+}
+
+// Backoff returns the delay before retrying after the given number of
+// failed attempts: InitialBackoff doubled per attempt, capped at MaxBackoff.
+func Backoff(cfg config.WebhookConfig, attempts int) time.Duration {
+	delay := cfg.InitialBackoff
+	if delay <= 0 {
+		delay = time.Second
+	}
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if cfg.MaxBackoff > 0 && delay >= cfg.MaxBackoff {
+			return cfg.MaxBackoff
+		}
+	}
+	if cfg.MaxBackoff > 0 && delay > cfg.MaxBackoff {
+		return cfg.MaxBackoff
+	}
+	return delay
+}
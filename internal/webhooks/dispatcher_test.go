@@ -0,0 +1,238 @@
+package webhooks
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/config"
+)
+
+// newTestDispatcher returns a dispatcher with a controllable clock.
+func newTestDispatcher(t *testing.T, cfg config.WebhookConfig) (*Dispatcher, *Store, *time.Time) {
+	t.Helper()
+	store, err := NewStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+	d := NewDispatcher(store, cfg)
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	d.now = func() time.Time { return now }
+	return d, store, &now
+}
+
+func TestDispatcherEnqueueFiltersEvents(t *testing.T) {
+	d, store, _ := newTestDispatcher(t, config.WebhookConfig{
+		Endpoints: []config.WebhookEndpoint{
+			{Name: "all", URL: "http://example.invalid/all"},
+			{Name: "status", URL: "http://example.invalid/status", Events: []string{"status"}},
+		},
+	})
+
+	n, err := d.Enqueue(Event{Type: "create", IssueID: "bd-1"})
+	if err != nil || n != 1 {
+		t.Fatalf("Enqueue(create) = %d, %v; want 1", n, err)
+	}
+	n, err = d.Enqueue(Event{Type: "status", IssueID: "bd-1"})
+	if err != nil || n != 2 {
+		t.Fatalf("Enqueue(status) = %d, %v; want 2", n, err)
+	}
+	if pending, _ := store.Pending(); len(pending) != 3 {
+		t.Errorf("expected 3 queued deliveries, got %d", len(pending))
+	}
+}
+
+func TestDispatcherRetriesWithBackoff(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer srv.Close()
+
+	d, store, now := newTestDispatcher(t, config.WebhookConfig{
+		Endpoints:      []config.WebhookEndpoint{{Name: "ci", URL: srv.URL}},
+		MaxAttempts:    5,
+		InitialBackoff: time.Second,
+		MaxBackoff:     time.Minute,
+	})
+	ctx := context.Background()
+
+	if _, err := d.Enqueue(Event{Type: "create", IssueID: "bd-1"}); err != nil {
+		t.Fatal(err)
+	}
+
+	// First attempt fails and is rescheduled 1s out
+	if got := d.ProcessDue(ctx); got != 0 {
+		t.Fatalf("expected first attempt to fail")
+	}
+	pending, _ := store.Pending()
+	if len(pending) != 1 || pending[0].Attempts != 1 || pending[0].LastStatus != http.StatusServiceUnavailable {
+		t.Fatalf("unexpected queue after first failure: %+v", pending)
+	}
+	if want := now.Add(time.Second); !pending[0].NextAttempt.Equal(want) {
+		t.Errorf("NextAttempt = %v, want %v", pending[0].NextAttempt, want)
+	}
+
+	// Not yet due: nothing happens
+	d.ProcessDue(ctx)
+	if calls.Load() != 1 {
+		t.Fatalf("delivery retried before backoff elapsed")
+	}
+
+	// Second attempt fails, backoff doubles
+	*now = now.Add(time.Second)
+	d.ProcessDue(ctx)
+	pending, _ = store.Pending()
+	if want := now.Add(2 * time.Second); len(pending) != 1 || !pending[0].NextAttempt.Equal(want) {
+		t.Fatalf("expected retry scheduled at %v, got %+v", want, pending)
+	}
+
+	// Third attempt succeeds and the delivery leaves the queue
+	*now = now.Add(2 * time.Second)
+	if got := d.ProcessDue(ctx); got != 1 {
+		t.Fatalf("expected third attempt to succeed")
+	}
+	if pending, _ := store.Pending(); len(pending) != 0 {
+		t.Errorf("expected empty queue after success, got %d", len(pending))
+	}
+	if dead, _ := store.DeadLetters(); len(dead) != 0 {
+		t.Errorf("expected no dead letters, got %d", len(dead))
+	}
+}
+
+func TestDispatcherDeadLetters(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/reject" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	d, store, now := newTestDispatcher(t, config.WebhookConfig{
+		Endpoints: []config.WebhookEndpoint{
+			{Name: "flaky", URL: srv.URL + "/flaky"},
+			{Name: "reject", URL: srv.URL + "/reject"},
+		},
+		MaxAttempts:    2,
+		InitialBackoff: time.Second,
+	})
+	ctx := context.Background()
+
+	if _, err := d.Enqueue(Event{Type: "create", IssueID: "bd-1"}); err != nil {
+		t.Fatal(err)
+	}
+
+	// 4xx is dead-lettered immediately; 5xx is retried
+	d.ProcessDue(ctx)
+	dead, _ := store.DeadLetters()
+	if len(dead) != 1 || dead[0].Endpoint != "reject" || dead[0].Attempts != 1 {
+		t.Fatalf("expected rejected delivery dead-lettered after one attempt, got %+v", dead)
+	}
+
+	// Second 5xx exhausts MaxAttempts
+	*now = now.Add(time.Minute)
+	d.ProcessDue(ctx)
+	dead, _ = store.DeadLetters()
+	if len(dead) != 2 {
+		t.Fatalf("expected 2 dead letters, got %d", len(dead))
+	}
+	if pending, _ := store.Pending(); len(pending) != 0 {
+		t.Errorf("expected empty queue, got %d", len(pending))
+	}
+}
+
+func TestDispatcherUnknownEndpoint(t *testing.T) {
+	d, store, _ := newTestDispatcher(t, config.WebhookConfig{MaxAttempts: 3})
+	if err := store.Enqueue(NewDelivery("removed", Event{Type: "create"}, d.now())); err != nil {
+		t.Fatal(err)
+	}
+	d.ProcessDue(context.Background())
+	dead, _ := store.DeadLetters()
+	if len(dead) != 1 || dead[0].LastError == "" {
+		t.Fatalf("expected delivery to removed endpoint to be dead-lettered, got %+v", dead)
+	}
+}
+
+func TestDispatcherEndpointsInParallel(t *testing.T) {
+	// The slow endpoint only answers once the fast one got its delivery,
+	// which deadlocks if endpoints are served one after another
+	fastDone := make(chan struct{})
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-fastDone:
+		case <-time.After(5 * time.Second):
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer slow.Close()
+	fast := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(fastDone)
+	}))
+	defer fast.Close()
+
+	d, store, _ := newTestDispatcher(t, config.WebhookConfig{
+		Endpoints: []config.WebhookEndpoint{
+			{Name: "slow", URL: slow.URL},
+			{Name: "fast", URL: fast.URL},
+		},
+		MaxAttempts: 1,
+	})
+	if _, err := d.Enqueue(Event{Type: "create", IssueID: "bd-1"}); err != nil {
+		t.Fatal(err)
+	}
+	if got := d.ProcessDue(context.Background()); got != 2 {
+		t.Errorf("ProcessDue delivered %d, want 2", got)
+	}
+	if pending, _ := store.Pending(); len(pending) != 0 {
+		t.Errorf("expected empty queue, got %d deliveries", len(pending))
+	}
+}
+
+func TestDispatcherRun(t *testing.T) {
+	delivered := make(chan string, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		delivered <- r.Header.Get(HeaderEvent)
+	}))
+	defer srv.Close()
+
+	store, err := NewStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	d := NewDispatcher(store, config.WebhookConfig{
+		Endpoints:   []config.WebhookEndpoint{{Name: "ci", URL: srv.URL}},
+		MaxAttempts: 1,
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go d.Run(ctx, time.Hour)
+
+	if _, err := d.Enqueue(Event{Type: "comment", IssueID: "bd-1"}); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case got := <-delivered:
+		if got != "comment" {
+			t.Errorf("delivered event %q, want comment", got)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Run did not deliver the enqueued event")
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	cfg := config.WebhookConfig{InitialBackoff: 2 * time.Second, MaxBackoff: 10 * time.Second}
+	want := []time.Duration{2 * time.Second, 4 * time.Second, 8 * time.Second, 10 * time.Second, 10 * time.Second}
+	for i, w := range want {
+		if got := Backoff(cfg, i+1); got != w {
+			t.Errorf("Backoff(attempt %d) = %v, want %v", i+1, got, w)
+		}
+	}
+}
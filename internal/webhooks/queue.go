@@ -0,0 +1,176 @@
+package webhooks
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Directory layout under .beads/webhooks/
+const (
+	QueueDirName      = "queue"
+	DeadLetterDirName = "dead-letter"
+)
+
+// Store persists deliveries as one JSON file each, in a pending queue
+// directory and a dead-letter directory. One file per delivery keeps the
+// daemon and the `bd webhooks` command safe to run concurrently: every
+// change is an atomic write-and-rename of a single file.
+type Store struct {
+	queueDir string
+	deadDir  string
+}
+
+// NewStore opens (creating if needed) the delivery store rooted at dir,
+// normally .beads/webhooks.
+func NewStore(dir string) (*Store, error) {
+	s := &Store{
+		queueDir: filepath.Join(dir, QueueDirName),
+		deadDir:  filepath.Join(dir, DeadLetterDirName),
+	}
+	for _, d := range []string{s.queueDir, s.deadDir} {
+		if err := os.MkdirAll(d, 0700); err != nil {
+			return nil, fmt.Errorf("failed to create webhook directory: %w", err)
+		}
+	}
+	return s, nil
+}
+
+// Enqueue adds or updates a pending delivery.
+func (s *Store) Enqueue(d *Delivery) error {
+	return writeDelivery(s.queueDir, d)
+}
+
+// Pending returns all queued deliveries, oldest first.
+func (s *Store) Pending() ([]*Delivery, error) {
+	return readDeliveries(s.queueDir)
+}
+
+// DeadLetters returns all dead-lettered deliveries, oldest first.
+func (s *Store) DeadLetters() ([]*Delivery, error) {
+	return readDeliveries(s.deadDir)
+}
+
+// Complete removes a delivered item from the queue.
+func (s *Store) Complete(d *Delivery) error {
+	err := os.Remove(deliveryPath(s.queueDir, d.ID))
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove delivery %s: %w", d.ID, err)
+	}
+	return nil
+}
+
+// DeadLetter moves a delivery from the queue to the dead-letter directory.
+func (s *Store) DeadLetter(d *Delivery) error {
+	if err := writeDelivery(s.deadDir, d); err != nil {
+		return err
+	}
+	return s.Complete(d)
+}
+
+// Replay moves a dead-lettered delivery back to the queue with its attempt
+// count reset, so it is retried with the full retry policy starting at now.
+// The ID may be a unique prefix. Returns the requeued delivery.
+func (s *Store) Replay(id string, now time.Time) (*Delivery, error) {
+	dead, err := s.DeadLetters()
+	if err != nil {
+		return nil, err
+	}
+	var match *Delivery
+	for _, d := range dead {
+		if d.ID == id {
+			match = d
+			break
+		}
+		if strings.HasPrefix(d.ID, id) {
+			if match != nil {
+				return nil, fmt.Errorf("ambiguous delivery ID %q", id)
+			}
+			match = d
+		}
+	}
+	if match == nil {
+		return nil, fmt.Errorf("no dead-lettered delivery %q", id)
+	}
+
+	match.Attempts = 0
+	match.NextAttempt = now
+	match.LastError = ""
+	match.LastStatus = 0
+	if err := writeDelivery(s.queueDir, match); err != nil {
+		return nil, err
+	}
+	if err := os.Remove(deliveryPath(s.deadDir, match.ID)); err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to remove dead letter %s: %w", match.ID, err)
+	}
+	return match, nil
+}
+
+func deliveryPath(dir, id string) string {
+	return filepath.Join(dir, id+".json")
+}
+
+// writeDelivery atomically writes d into dir.
+func writeDelivery(dir string, d *Delivery) error {
+	data, err := json.MarshalIndent(d, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode delivery: %w", err)
+	}
+	tmp, err := os.CreateTemp(dir, ".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to write delivery %s: %w", d.ID, err)
+	}
+	tmpPath := tmp.Name()
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmpPath)
+		return fmt.Errorf("failed to write delivery %s: %w", d.ID, err)
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmpPath)
+		return fmt.Errorf("failed to write delivery %s: %w", d.ID, err)
+	}
+	if err := os.Rename(tmpPath, deliveryPath(dir, d.ID)); err != nil {
+		_ = os.Remove(tmpPath)
+		return fmt.Errorf("failed to write delivery %s: %w", d.ID, err)
+	}
+	return nil
+}
+
+// readDeliveries loads every delivery in dir, oldest first. Unreadable
+// files are skipped so one corrupt entry cannot wedge the queue.
+func readDeliveries(dir string) ([]*Delivery, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read %s: %w", dir, err)
+	}
+	var deliveries []*Delivery
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".json") {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, e.Name())) // #nosec G304 - path within .beads/webhooks
+		if err != nil {
+			continue
+		}
+		var d Delivery
+		if err := json.Unmarshal(data, &d); err != nil || d.ID == "" {
+			continue
+		}
+		deliveries = append(deliveries, &d)
+	}
+	sort.Slice(deliveries, func(i, j int) bool {
+		if !deliveries[i].CreatedAt.Equal(deliveries[j].CreatedAt) {
+			return deliveries[i].CreatedAt.Before(deliveries[j].CreatedAt)
+		}
+		return deliveries[i].ID < deliveries[j].ID
+	})
+	return deliveries, nil
+}
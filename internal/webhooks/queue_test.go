@@ -0,0 +1,114 @@
+package webhooks
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStoreLifecycle(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewStore(dir)
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+
+	now := time.Now()
+	first := NewDelivery("ci", Event{Type: "create", IssueID: "bd-1"}, now)
+	second := NewDelivery("ci", Event{Type: "update", IssueID: "bd-1"}, now.Add(time.Second))
+	for _, d := range []*Delivery{second, first} {
+		if err := store.Enqueue(d); err != nil {
+			t.Fatalf("Enqueue failed: %v", err)
+		}
+	}
+
+	pending, err := store.Pending()
+	if err != nil {
+		t.Fatalf("Pending failed: %v", err)
+	}
+	if len(pending) != 2 || pending[0].ID != first.ID {
+		t.Fatalf("expected 2 pending deliveries oldest first, got %d", len(pending))
+	}
+
+	// Complete one, dead-letter the other
+	if err := store.Complete(first); err != nil {
+		t.Fatalf("Complete failed: %v", err)
+	}
+	second.Attempts = 8
+	second.LastError = "endpoint returned HTTP 500"
+	if err := store.DeadLetter(second); err != nil {
+		t.Fatalf("DeadLetter failed: %v", err)
+	}
+
+	if pending, _ := store.Pending(); len(pending) != 0 {
+		t.Errorf("expected empty queue, got %d", len(pending))
+	}
+	dead, err := store.DeadLetters()
+	if err != nil {
+		t.Fatalf("DeadLetters failed: %v", err)
+	}
+	if len(dead) != 1 || dead[0].LastError != second.LastError || dead[0].Attempts != 8 {
+		t.Fatalf("unexpected dead letters: %+v", dead)
+	}
+
+	// Replay by prefix resets retry state and moves it back
+	replayAt := now.Add(time.Hour)
+	replayed, err := store.Replay(second.ID[:len(second.ID)-2], replayAt)
+	if err != nil {
+		t.Fatalf("Replay failed: %v", err)
+	}
+	if replayed.Attempts != 0 || replayed.LastError != "" || !replayed.NextAttempt.Equal(replayAt) {
+		t.Errorf("replay did not reset delivery: %+v", replayed)
+	}
+	if dead, _ := store.DeadLetters(); len(dead) != 0 {
+		t.Errorf("expected no dead letters after replay, got %d", len(dead))
+	}
+	if pending, _ := store.Pending(); len(pending) != 1 || pending[0].ID != second.ID {
+		t.Errorf("expected replayed delivery in queue")
+	}
+
+	if _, err := store.Replay("missing", now); err == nil {
+		t.Error("expected error replaying unknown delivery")
+	}
+}
+
+func TestStoreSkipsCorruptFiles(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewStore(dir)
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, QueueDirName, "bad.json"), []byte("{not json"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Enqueue(NewDelivery("ci", Event{Type: "create"}, time.Now())); err != nil {
+		t.Fatal(err)
+	}
+	pending, err := store.Pending()
+	if err != nil {
+		t.Fatalf("Pending failed: %v", err)
+	}
+	if len(pending) != 1 {
+		t.Errorf("expected corrupt file to be skipped, got %d deliveries", len(pending))
+	}
+}
+
+func TestStoreReplayAmbiguousPrefix(t *testing.T) {
+	store, err := NewStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+	a := &Delivery{ID: "abc-1", Endpoint: "ci", CreatedAt: now}
+	b := &Delivery{ID: "abc-2", Endpoint: "ci", CreatedAt: now}
+	for _, d := range []*Delivery{a, b} {
+		if err := store.DeadLetter(d); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := store.Replay("abc", now); err == nil || !strings.Contains(err.Error(), "ambiguous") {
+		t.Errorf("expected ambiguous prefix error, got %v", err)
+	}
+}
@@ -0,0 +1,173 @@
+// Package webhooks delivers daemon mutation events to external HTTP endpoints.
+//
+// Each mutation becomes one Delivery per subscribed endpoint. Deliveries are
+// persisted in .beads/webhooks/queue/ until they succeed; deliveries that
+// exhaust their retries (or are rejected outright) move to
+// .beads/webhooks/dead-letter/ where `bd webhooks replay` can requeue them.
+// Payloads are JSON and signed with HMAC-SHA256 when a secret is configured.
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/steveyegge/beads/internal/config"
+)
+
+// HTTP headers sent with every delivery
+const (
+	HeaderEvent     = "X-Beads-Event"
+	HeaderDelivery  = "X-Beads-Delivery"
+	HeaderTimestamp = "X-Beads-Timestamp" // Send time in unix seconds
+	HeaderSignature = "X-Beads-Signature" // "sha256=<hex HMAC of timestamp.body>"
+)
+
+// EventPing is the synthetic event type sent by `bd webhooks test`.
+const EventPing = "ping"
+
+// UserAgent is sent with every delivery; cmd/bd sets the version suffix.
+var UserAgent = "beads-webhooks"
+
+// Event is the JSON payload POSTed to webhook endpoints. It mirrors
+// rpc.MutationEvent plus the workspace it came from.
+type Event struct {
+	Type      string    `json:"type"`
+	IssueID   string    `json:"issue_id,omitempty"`
+	Title     string    `json:"title,omitempty"`
+	Assignee  string    `json:"assignee,omitempty"`
+	Actor     string    `json:"actor,omitempty"`
+	Timestamp time.Time `json:"timestamp"`
+	OldStatus string    `json:"old_status,omitempty"`
+	NewStatus string    `json:"new_status,omitempty"`
+	ParentID  string    `json:"parent_id,omitempty"`
+	StepCount int       `json:"step_count,omitempty"`
+	Workspace string    `json:"workspace,omitempty"`
+}
+
+// Delivery is one event destined for one endpoint, with its retry state.
+type Delivery struct {
+	ID          string    `json:"id"`
+	Endpoint    string    `json:"endpoint"` // Endpoint name
+	Event       Event     `json:"event"`
+	Attempts    int       `json:"attempts"`
+	CreatedAt   time.Time `json:"created_at"`
+	NextAttempt time.Time `json:"next_attempt"`
+	LastError   string    `json:"last_error,omitempty"`
+	LastStatus  int       `json:"last_status,omitempty"` // HTTP status of the last attempt, 0 if none
+}
+
+// NewDelivery creates a delivery of event to the named endpoint, due now.
+func NewDelivery(endpoint string, event Event, now time.Time) *Delivery {
+	return &Delivery{
+		ID:          newDeliveryID(now),
+		Endpoint:    endpoint,
+		Event:       event,
+		CreatedAt:   now,
+		NextAttempt: now,
+	}
+}
+
+// newDeliveryID returns a unique, time-sortable delivery ID.
+func newDeliveryID(now time.Time) string {
+	var b [4]byte
+	_, _ = rand.Read(b[:])
+	return fmt.Sprintf("%s-%s", strconv.FormatInt(now.UnixNano(), 36), hex.EncodeToString(b[:]))
+}
+
+// Sign returns the signature header value for a delivery: "sha256=" followed
+// by the hex-encoded HMAC-SHA256 of timestamp + "." + body keyed with secret,
+// where timestamp is the X-Beads-Timestamp header value. Covering the
+// timestamp lets receivers reject replayed deliveries.
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify reports whether signature is a valid Sign(secret, timestamp, body)
+// value. Receivers written in Go can use it to authenticate deliveries; they
+// should also reject deliveries whose X-Beads-Timestamp is more than a few
+// minutes old, since a valid signature can be replayed.
+func Verify(secret, timestamp string, body []byte, signature string) bool {
+	return hmac.Equal([]byte(Sign(secret, timestamp, body)), []byte(signature))
+}
+
+// DeliveryError describes a failed delivery attempt.
+type DeliveryError struct {
+	StatusCode int // 0 if the request never got a response
+	Err        error
+}
+
+func (e *DeliveryError) Error() string {
+	if e.StatusCode != 0 {
+		return fmt.Sprintf("endpoint returned HTTP %d: %v", e.StatusCode, e.Err)
+	}
+	return e.Err.Error()
+}
+
+func (e *DeliveryError) Unwrap() error { return e.Err }
+
+// Retryable reports whether the attempt may succeed if repeated. Network
+// errors, 5xx, 408 and 429 are retryable; other 4xx responses mean the
+// endpoint rejected the payload and retrying would not help.
+func (e *DeliveryError) Retryable() bool {
+	if e.StatusCode == 0 || e.StatusCode >= 500 {
+		return true
+	}
+	return e.StatusCode == http.StatusRequestTimeout || e.StatusCode == http.StatusTooManyRequests
+}
+
+// Send makes a single delivery attempt: it POSTs the event to the endpoint
+// and returns nil on any 2xx response, or a *DeliveryError otherwise.
+func Send(ctx context.Context, client *http.Client, endpoint config.WebhookEndpoint, d *Delivery) error {
+	body, err := json.Marshal(d.Event)
+	if err != nil {
+		return &DeliveryError{Err: fmt.Errorf("failed to encode payload: %w", err)}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.URL, bytes.NewReader(body))
+	if err != nil {
+		return &DeliveryError{Err: fmt.Errorf("failed to build request: %w", err)}
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", UserAgent)
+	req.Header.Set(HeaderEvent, d.Event.Type)
+	req.Header.Set(HeaderDelivery, d.ID)
+	// The send time, not the event time, so retries still pass a receiver's
+	// freshness check
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set(HeaderTimestamp, timestamp)
+	if secret := endpoint.SigningSecret(); secret != "" {
+		req.Header.Set(HeaderSignature, Sign(secret, timestamp, body))
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return &DeliveryError{Err: err}
+	}
+	defer func() { _ = resp.Body.Close() }()
+	// Read a bounded amount of the body so the connection can be reused and
+	// the error message can include the endpoint's explanation.
+	respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		msg := string(bytes.TrimSpace(respBody))
+		if msg == "" {
+			msg = http.StatusText(resp.StatusCode)
+		}
+		return &DeliveryError{StatusCode: resp.StatusCode, Err: fmt.Errorf("%s", msg)}
+	}
+	return nil
+}
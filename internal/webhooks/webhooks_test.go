@@ -0,0 +1,145 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/config"
+)
+
+func TestSignVerify(t *testing.T) {
+	body := []byte(`{"type":"create"}`)
+	sig := Sign("s3cret", "1767323045", body)
+	if len(sig) != len("sha256=")+64 || sig[:7] != "sha256=" {
+		t.Fatalf("unexpected signature format: %q", sig)
+	}
+	if !Verify("s3cret", "1767323045", body, sig) {
+		t.Error("Verify rejected a valid signature")
+	}
+	if Verify("other", "1767323045", body, sig) {
+		t.Error("Verify accepted a signature made with a different secret")
+	}
+	if Verify("s3cret", "1767323045", []byte(`{"type":"delete"}`), sig) {
+		t.Error("Verify accepted a signature for a different body")
+	}
+	if Verify("s3cret", "1767326645", body, sig) {
+		t.Error("Verify accepted a signature for a different timestamp")
+	}
+}
+
+func TestSend(t *testing.T) {
+	var gotHeaders http.Header
+	var gotBody []byte
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotHeaders = r.Header.Clone()
+		gotBody, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	endpoint := config.WebhookEndpoint{Name: "test", URL: srv.URL, Secret: "s3cret"}
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	d := NewDelivery("test", Event{Type: "create", IssueID: "bd-1", Title: "New", Timestamp: now}, now)
+
+	if err := Send(context.Background(), srv.Client(), endpoint, d); err != nil {
+		t.Fatalf("Send failed: %v", err)
+	}
+
+	if got := gotHeaders.Get(HeaderEvent); got != "create" {
+		t.Errorf("%s = %q, want create", HeaderEvent, got)
+	}
+	if got := gotHeaders.Get(HeaderDelivery); got != d.ID {
+		t.Errorf("%s = %q, want %q", HeaderDelivery, got, d.ID)
+	}
+	// The header carries the send time; the event time stays in the payload
+	sent, err := strconv.ParseInt(gotHeaders.Get(HeaderTimestamp), 10, 64)
+	if err != nil || time.Since(time.Unix(sent, 0)) > time.Minute {
+		t.Errorf("%s = %q, want the send time", HeaderTimestamp, gotHeaders.Get(HeaderTimestamp))
+	}
+	if !Verify("s3cret", gotHeaders.Get(HeaderTimestamp), gotBody, gotHeaders.Get(HeaderSignature)) {
+		t.Error("signature header does not verify against body")
+	}
+
+	var event Event
+	if err := json.Unmarshal(gotBody, &event); err != nil {
+		t.Fatalf("invalid JSON body: %v", err)
+	}
+	if event.IssueID != "bd-1" || event.Title != "New" {
+		t.Errorf("unexpected payload: %+v", event)
+	}
+}
+
+func TestSendUnsigned(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get(HeaderSignature) != "" {
+			t.Error("expected no signature header without a secret")
+		}
+	}))
+	defer srv.Close()
+
+	endpoint := config.WebhookEndpoint{Name: "test", URL: srv.URL}
+	d := NewDelivery("test", Event{Type: "update", Timestamp: time.Now()}, time.Now())
+	if err := Send(context.Background(), srv.Client(), endpoint, d); err != nil {
+		t.Fatalf("Send failed: %v", err)
+	}
+}
+
+func TestSendErrors(t *testing.T) {
+	tests := []struct {
+		status    int
+		retryable bool
+	}{
+		{http.StatusInternalServerError, true},
+		{http.StatusBadGateway, true},
+		{http.StatusTooManyRequests, true},
+		{http.StatusRequestTimeout, true},
+		{http.StatusBadRequest, false},
+		{http.StatusUnauthorized, false},
+		{http.StatusNotFound, false},
+	}
+	for _, tt := range tests {
+		t.Run(http.StatusText(tt.status), func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				http.Error(w, "nope", tt.status)
+			}))
+			defer srv.Close()
+
+			endpoint := config.WebhookEndpoint{Name: "test", URL: srv.URL}
+			d := NewDelivery("test", Event{Type: "create", Timestamp: time.Now()}, time.Now())
+			err := Send(context.Background(), srv.Client(), endpoint, d)
+
+			var derr *DeliveryError
+			if !errors.As(err, &derr) {
+				t.Fatalf("expected *DeliveryError, got %v", err)
+			}
+			if derr.StatusCode != tt.status {
+				t.Errorf("StatusCode = %d, want %d", derr.StatusCode, tt.status)
+			}
+			if derr.Retryable() != tt.retryable {
+				t.Errorf("Retryable() = %v, want %v", derr.Retryable(), tt.retryable)
+			}
+		})
+	}
+}
+
+func TestSendConnectionError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	url := srv.URL
+	srv.Close()
+
+	endpoint := config.WebhookEndpoint{Name: "test", URL: url}
+	d := NewDelivery("test", Event{Type: "create", Timestamp: time.Now()}, time.Now())
+	err := Send(context.Background(), http.DefaultClient, endpoint, d)
+
+	var derr *DeliveryError
+	if !errors.As(err, &derr) || !derr.Retryable() {
+		t.Fatalf("expected retryable *DeliveryError, got %v", err)
+	}
+}